@@ -1,10 +1,20 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata" // Airport time zones on systems without a time zone database
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation"
@@ -12,6 +22,39 @@ import (
 )
 
 func main() {
+	verbose := flag.Bool("verbose", false, "print raw simulation logs instead of progress bars")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to `file`")
+	memProfile := flag.String("memprofile", "", "write a heap profile to `file` when all scenarios complete")
+	listPolicies := flag.Bool("list-policies", false, "list the registered policies and exit")
+	logFormat := flag.String("log-format", "text", "log record format: text or json (for ingestion by analysis pipelines)")
+	scenarioFile := flag.String("scenario", "", "run the scenario defined in `file` instead of the demonstration")
+	whatIf := flag.Bool("what-if", false, "explore runway closures, wind and curfew interactively (on the -scenario airport if given)")
+	var extraPolicies policyFlags
+	flag.Var(&extraPolicies, "policy", "add a registered policy to Scenario 1 as `name[=json]` (repeatable)")
+	flag.Parse()
+
+	if *listPolicies {
+		for _, name := range policy.Registered() {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if *cpuProfile != "" {
+		stop, err := startCPUProfile(*cpuProfile)
+		if err != nil {
+			panic(err)
+		}
+		defer stop()
+	}
+	if *memProfile != "" {
+		defer func() {
+			if err := writeHeapProfile(*memProfile); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
 	// Create a realistic major international airport configuration
 	// Inspired by airports like LAX, with parallel runways and a crossing runway
 	majorAirport := airport.Airport{
@@ -24,7 +67,7 @@ func main() {
 			// North parallel runway complex (09L/27R)
 			{
 				RunwayDesignation:   "09L",
-				TrueBearing:         86.0, // Slightly off from magnetic east
+				TrueBearing:         86.0,   // Slightly off from magnetic east
 				LengthMeters:        3685.0, // 12,090 ft - typical for wide-body aircraft
 				WidthMeters:         60.0,
 				SurfaceType:         airport.Asphalt,
@@ -57,7 +100,7 @@ func main() {
 				ElevationMeters:     14.0,
 				GradientPercent:     0.15,
 				CrosswindLimitKnots: 33.0,
-				TailwindLimitKnots:  8.0, // Shorter runway, more conservative
+				TailwindLimitKnots:  8.0,              // Shorter runway, more conservative
 				MinimumSeparation:   50 * time.Second, // Smaller aircraft
 			},
 			// Additional parallel (for high capacity operations)
@@ -84,7 +127,45 @@ func main() {
 		}),
 	}
 
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	if *whatIf {
+		ap := majorAirport
+		if *scenarioFile != "" {
+			scenario, err := simulation.LoadScenario(*scenarioFile)
+			if err != nil {
+				panic(err)
+			}
+			ap = scenario.LoadedAirport()
+		}
+		if err := runWhatIf(os.Stdin, os.Stdout, ap); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	logger, err := newLogger(*logFormat, os.Stdout, slog.LevelInfo)
+	if err != nil {
+		panic(err)
+	}
+
+	// Simulations only report warnings unless raw logs are requested; progress is shown as a bar instead
+	simLogger, err := newLogger(*logFormat, os.Stderr, slog.LevelWarn)
+	if err != nil {
+		panic(err)
+	}
+	if *verbose {
+		simLogger = logger
+	}
+
+	if *scenarioFile != "" {
+		if err := runScenarioFile(context.Background(), *scenarioFile, logger, simLogger, !*verbose); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	run := func(name string, sim *simulation.Simulation) (float32, error) {
+		return runScenario(context.Background(), name, sim, !*verbose)
+	}
 
 	logger.Info("╔═══════════════════════════════════════════════════════════════╗")
 	logger.Info("║   Airport Capacity Calculator - Comprehensive Demonstration   ║")
@@ -109,13 +190,13 @@ func main() {
 	logger.Info("Constraints:")
 	logger.Info("  • Curfew: 11 PM - 6 AM daily (7 hours)")
 	logger.Info("  • Wind: Westerly 15kt (270°) - favoring 27 operations")
-	logger.Info("  • Rotation: Preferential runway (noise abatement)")
+	logger.Info("  • Noise: Main parallels only 6-7 AM and 9-11 PM (08/26 kept quiet)")
 	logger.Info("  • Maintenance: 09R - Monthly 8hr maintenance windows")
 	logger.Info("  • Gates: 50 gates, 45min turnaround")
 	logger.Info("  • Taxi: 8min average (5min in, 3min out)")
 	logger.Info("")
 
-	sim1Temp, err := simulation.NewSimulation(majorAirport, logger).
+	sim1Temp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -126,7 +207,16 @@ func main() {
 		panic(err)
 	}
 
-	sim1Temp = sim1Temp.RunwayRotationPolicy(simulation.PreferentialRunway)
+	// Noise-preferential runways either side of the curfew keep traffic off 08/26, the runway
+	// closest to housing, at a capacity cost on the main parallels alone
+	mainParallels := [][]string{{"27R", "27L"}, {"09L", "09R"}}
+	sim1Temp, err = sim1Temp.AddNoisePreferentialPolicy([]simulation.NoisePreferentialBand{
+		{From: 6 * time.Hour, Until: 7 * time.Hour, Preferences: mainParallels},
+		{From: 21 * time.Hour, Until: 23 * time.Hour, Preferences: mainParallels},
+	})
+	if err != nil {
+		panic(err)
+	}
 
 	// Add maintenance for 09R
 	sim1Temp = sim1Temp.AddMaintenancePolicy(simulation.MaintenanceSchedule{
@@ -146,54 +236,80 @@ func main() {
 
 	// Add taxi time
 	sim1Temp, err = sim1Temp.AddTaxiTimePolicy(simulation.TaxiTimeConfiguration{
-		AverageTaxiInTime: 5 * time.Minute,
+		AverageTaxiInTime:  5 * time.Minute,
 		AverageTaxiOutTime: 3 * time.Minute,
 	})
 	if err != nil {
 		panic(err)
 	}
 
-	capacity1, err := sim1Temp.Run(context.Background())
+	// Add any registered policies requested on the command line
+	for _, spec := range extraPolicies {
+		sim1Temp, err = sim1Temp.AddRegisteredPolicy(spec.name, policy.JSONDecoder([]byte(spec.params)))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// The theoretical maximum for Scenario 2 is derived from the same setup in one call
+	baseline, err := runScenarioWithBaseline(context.Background(), "Scenario 1: Realistic operations", sim1Temp, !*verbose)
 	if err != nil {
 		panic(err)
 	}
+	capacity1 := baseline.Constrained.TotalCapacity
+	// All scenarios simulate the same period; rates are normalized to its actual length
+	period := baseline.Constrained.Period()
 
 	logger.Info("───────────────────────────────────────────────────────────────")
-	logger.Info("RESULT: Annual Capacity", "movements", int(capacity1))
-	logger.Info("        Daily Average", "movements", int(capacity1)/365)
-	logger.Info("        Peak Hour Estimate", "movements", int(capacity1)/365/17) // 17 operating hours
+	logger.Info("RESULT: Annual Capacity", "movements", int(period.Annualized(capacity1)))
+	logger.Info("        Daily Average", "movements", int(period.PerDay(capacity1)))
+	declared, err := baseline.Constrained.DeclaredCapacity(simulation.DeclaredCapacityOptions{})
+	if err != nil {
+		panic(err)
+	}
+	for _, dc := range declared {
+		logger.Info(fmt.Sprintf("        Declared Capacity per %v", dc.Period),
+			"movements", int(dc.Declared[simulation.DefaultDeclaredReliability]),
+			"reliability", "95%",
+			"peak", int(dc.Peak))
+	}
+	peaks, err := baseline.Constrained.Peaks()
+	if err != nil {
+		panic(err)
+	}
+	for _, peak := range peaks {
+		logger.Info(fmt.Sprintf("        Busiest %v", peak.Period), "movements", int(peak.Movements), "start", peak.Start.Format(time.DateTime))
+	}
+	logger.Info("        Runway Flow Usage", "flows", simulation.FormatUsage(baseline.Constrained.FlowUsage()))
+	logger.Info("        Runway Movement Shares", "runways", simulation.FormatMovementShares(baseline.Constrained.RunwayUsage()))
+	logger.Info("        Runway End Movement Shares", "runwayEnds", simulation.FormatMovementShares(baseline.Constrained.RunwayEndOperationUsage()))
 	logger.Info("")
 
 	// Scenario 2: Theoretical Maximum (No Constraints)
 	logger.Info("═══════════════════════════════════════════════════════════════")
 	logger.Info("Scenario 2: THEORETICAL MAXIMUM - No Constraints")
 	logger.Info("═══════════════════════════════════════════════════════════════")
-	logger.Info("Configuration:")
+	logger.Info("Derived automatically from Scenario 1 with all policies removed:")
 	logger.Info("  • No curfew (24/7 operations)")
 	logger.Info("  • Calm wind (all runways forward direction)")
-	logger.Info("  • No rotation penalty")
+	logger.Info("  • No noise-preferential runways")
 	logger.Info("  • No maintenance")
 	logger.Info("  • No gate constraints")
 	logger.Info("  • No taxi time overhead")
 	logger.Info("")
 
-	sim2Temp, err := simulation.NewSimulation(majorAirport, logger).
-		AddWindPolicy(0, 0) // Calm wind
-	if err != nil {
-		panic(err)
-	}
-
-	sim2Temp = sim2Temp.RunwayRotationPolicy(simulation.NoRotation)
+	capacity2 := baseline.Baseline.TotalCapacity
 
-	capacity2, err := sim2Temp.Run(context.Background())
+	logger.Info("───────────────────────────────────────────────────────────────")
+	logger.Info("RESULT: Annual Capacity", "movements", int(period.Annualized(capacity2)))
+	logger.Info("        Daily Average", "movements", int(period.PerDay(capacity2)))
+	peaks, err = baseline.Baseline.Peaks()
 	if err != nil {
 		panic(err)
 	}
-
-	logger.Info("───────────────────────────────────────────────────────────────")
-	logger.Info("RESULT: Annual Capacity", "movements", int(capacity2))
-	logger.Info("        Daily Average", "movements", int(capacity2)/365)
-	logger.Info("        Peak Hour Estimate", "movements", int(capacity2)/365/24)
+	for _, peak := range peaks {
+		logger.Info(fmt.Sprintf("        Busiest %v", peak.Period), "movements", int(peak.Movements), "start", peak.Start.Format(time.DateTime))
+	}
 	logger.Info("")
 
 	// Scenario 3: Wind Impact Analysis
@@ -219,7 +335,7 @@ func main() {
 	for i, scenario := range windScenarios {
 		logger.Info(scenario.name+" Wind", "speed", scenario.speed, "direction", scenario.direction, "desc", scenario.desc)
 
-		simTemp, err := simulation.NewSimulation(majorAirport, logger).
+		simTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 			AddCurfewPolicy(curfewStart, curfewEnd)
 		if err != nil {
 			panic(err)
@@ -230,13 +346,13 @@ func main() {
 			panic(err)
 		}
 
-		capacity, err := simTemp.Run(context.Background())
+		capacity, err := run("Scenario 3: "+scenario.name+" wind", simTemp)
 		if err != nil {
 			panic(err)
 		}
 
 		windResults[i] = capacity
-		logger.Info("  → Capacity", "movements", int(capacity), "daily_avg", int(capacity)/365)
+		logger.Info("  → Capacity", "movements", int(capacity), "daily_avg", int(period.PerDay(capacity)))
 	}
 	logger.Info("")
 
@@ -247,7 +363,7 @@ func main() {
 
 	// Simple maintenance
 	logger.Info("Simple Maintenance (no coordination):")
-	sim4aTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim4aTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -264,7 +380,7 @@ func main() {
 		Frequency:          30 * 24 * time.Hour,
 	})
 
-	capacity4a, err := sim4aTemp.Run(context.Background())
+	capacity4a, err := run("Scenario 4a: Simple maintenance", sim4aTemp)
 	if err != nil {
 		panic(err)
 	}
@@ -272,7 +388,7 @@ func main() {
 
 	// Intelligent maintenance
 	logger.Info("Intelligent Maintenance (curfew-aware):")
-	sim4bTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim4bTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -293,7 +409,7 @@ func main() {
 		panic(err)
 	}
 
-	capacity4b, err := sim4bTemp.Run(context.Background())
+	capacity4b, err := run("Scenario 4b: Intelligent maintenance", sim4bTemp)
 	if err != nil {
 		panic(err)
 	}
@@ -321,7 +437,7 @@ func main() {
 		270,  // westerly direction
 	)
 
-	sim5aTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim5aTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -332,12 +448,12 @@ func main() {
 		panic(err)
 	}
 
-	capacity5a, err := sim5aTemp.Run(context.Background())
+	capacity5a, err := run("Scenario 5a: Diurnal wind", sim5aTemp)
 	if err != nil {
 		panic(err)
 	}
 
-	logger.Info("  → Capacity", "movements", int(capacity5a), "daily_avg", int(capacity5a)/365)
+	logger.Info("  → Capacity", "movements", int(capacity5a), "daily_avg", int(period.PerDay(capacity5a)))
 	logger.Info("")
 
 	// Sub-scenario 5b: Frontal passage (abrupt wind shift)
@@ -353,7 +469,7 @@ func main() {
 		270, // post-frontal direction (west)
 	)
 
-	sim5bTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim5bTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -364,12 +480,12 @@ func main() {
 		panic(err)
 	}
 
-	capacity5b, err := sim5bTemp.Run(context.Background())
+	capacity5b, err := run("Scenario 5b: Frontal passage", sim5bTemp)
 	if err != nil {
 		panic(err)
 	}
 
-	logger.Info("  → Capacity", "movements", int(capacity5b), "daily_avg", int(capacity5b)/365)
+	logger.Info("  → Capacity", "movements", int(capacity5b), "daily_avg", int(period.PerDay(capacity5b)))
 	logger.Info("")
 
 	// Sub-scenario 5c: Seasonal wind variation
@@ -379,11 +495,11 @@ func main() {
 	seasonalSchedule := policy.SeasonalWindPattern(
 		2024,
 		time.UTC,
-		15, 10, 5, 12,   // speeds (winter, spring, summer, fall)
+		15, 10, 5, 12, // speeds (winter, spring, summer, fall)
 		270, 180, 90, 225, // directions
 	)
 
-	sim5cTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim5cTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -394,12 +510,12 @@ func main() {
 		panic(err)
 	}
 
-	capacity5c, err := sim5cTemp.Run(context.Background())
+	capacity5c, err := run("Scenario 5c: Seasonal wind", sim5cTemp)
 	if err != nil {
 		panic(err)
 	}
 
-	logger.Info("  → Capacity", "movements", int(capacity5c), "daily_avg", int(capacity5c)/365)
+	logger.Info("  → Capacity", "movements", int(capacity5c), "daily_avg", int(period.PerDay(capacity5c)))
 	logger.Info("")
 
 	// Sub-scenario 5d: Linear wind transition
@@ -412,13 +528,13 @@ func main() {
 		4*time.Hour, // duration
 		5,           // steps
 		10, 90,      // initial: 10kt from east
-		30, 180,     // final: 30kt from south
+		30, 180, // final: 30kt from south
 	)
 	if err != nil {
 		panic(err)
 	}
 
-	sim5dTemp, err := simulation.NewSimulation(majorAirport, logger).
+	sim5dTemp, err := simulation.NewSimulation(majorAirport, simLogger).
 		AddCurfewPolicy(curfewStart, curfewEnd)
 	if err != nil {
 		panic(err)
@@ -429,20 +545,20 @@ func main() {
 		panic(err)
 	}
 
-	capacity5d, err := sim5dTemp.Run(context.Background())
+	capacity5d, err := run("Scenario 5d: Linear wind transition", sim5dTemp)
 	if err != nil {
 		panic(err)
 	}
 
-	logger.Info("  → Capacity", "movements", int(capacity5d), "daily_avg", int(capacity5d)/365)
+	logger.Info("  → Capacity", "movements", int(capacity5d), "daily_avg", int(period.PerDay(capacity5d)))
 	logger.Info("")
 
 	logger.Info("Comparison:")
 	logger.Info("  Static Westerly 15kt", "movements", int(windResults[1]))
 	logger.Info("  Diurnal Pattern (avg 15kt)", "movements", int(capacity5a))
-	diffPercent := int((float32(windResults[1])-capacity5a)/float32(windResults[1])*100)
+	diffPercent := int((float32(windResults[1]) - capacity5a) / float32(windResults[1]) * 100)
 	if capacity5a > windResults[1] {
-		diffPercent = int((capacity5a-float32(windResults[1]))/capacity5a*100)
+		diffPercent = int((capacity5a - float32(windResults[1])) / capacity5a * 100)
 	}
 	logger.Info("  Difference", "percent", diffPercent)
 	logger.Info("")
@@ -453,16 +569,19 @@ func main() {
 	logger.Info("═══════════════════════════════════════════════════════════════")
 	logger.Info("Theoretical Maximum (24/7, optimal)", "movements", int(capacity2))
 	logger.Info("Realistic Operations (all constraints)", "movements", int(capacity1))
-	logger.Info("Capacity Utilization", "percent", int(float32(capacity1)/float32(capacity2)*100))
+	logger.Info("Capacity Utilization", "percent", int(baseline.UtilizationPercent))
 	logger.Info("")
 	logger.Info("Primary Limiting Factors:")
 	capacityLoss := capacity2 - capacity1
 	logger.Info("  Total capacity loss", "movements", int(capacityLoss), "percent", int(capacityLoss/capacity2*100))
-	logger.Info("  • Curfew (7hrs daily): ~29% time reduction")
-	logger.Info("  • Rotation policy: ~10% efficiency reduction")
-	logger.Info("  • Gate/taxi constraints: Variable based on demand")
-	logger.Info("  • Maintenance: ~1-2% when scheduled intelligently")
-	logger.Info("  • Wind: 0-15% depending on conditions")
+	// Attribute the loss by adding Scenario 1's policies one at a time to the baseline
+	waterfall, err := sim1Temp.WithProgress(nil).CapacityWaterfall(context.Background(), simulation.CumulativeAttribution)
+	if err != nil {
+		panic(err)
+	}
+	for _, step := range waterfall.Steps {
+		logger.Info("  • "+step.Policy, "movements", -int(step.Loss), "percent", fmt.Sprintf("%.1f", step.LossPercent))
+	}
 	logger.Info("")
 	logger.Info("Wind Impact Range:")
 	maxWind := windResults[0]
@@ -483,3 +602,540 @@ func main() {
 	logger.Info("Simulation complete! 🎉")
 	logger.Info("═══════════════════════════════════════════════════════════════")
 }
+
+// runScenarioFile loads the scenario file at path, runs it and reports the results it asks for.
+func runScenarioFile(ctx context.Context, path string, logger, simLogger *slog.Logger, showProgress bool) error {
+	scenario, err := simulation.LoadScenario(path)
+	if err != nil {
+		return err
+	}
+	if err := scenario.LoadWeather(ctx, nil); err != nil {
+		return err
+	}
+	sim, err := scenario.Simulation(simLogger)
+	if err != nil {
+		return err
+	}
+	ctx = simulation.ContextWithRunID(ctx, scenario.Name)
+
+	var result *simulation.Result
+	var baseline *simulation.BaselineResult
+	if scenario.Output.Baseline || scenario.Output.Heatmap != "" {
+		baseline, err = runScenarioWithBaseline(ctx, scenario.Name, sim, showProgress)
+		if err != nil {
+			return err
+		}
+		result = baseline.Constrained
+	} else if scenario.Output.FlowUsage || scenario.Output.RunwayUsage || scenario.Output.RunwayEndUsage || scenario.Output.Parquet != "" || scenario.Output.StandDemand != "" || scenario.Output.Advice > 0 || scenario.Output.CurfewStudy != "" || scenario.Output.Trends != "" {
+		if err := reportPolicyWarnings(ctx, scenario.Name, sim); err != nil {
+			return err
+		}
+		var bar *progressBar
+		if showProgress {
+			bar = newProgressBar(os.Stderr, scenario.Name)
+			sim = sim.WithProgress(bar.update)
+		}
+		started := time.Now()
+		result, err = sim.RunResult(ctx)
+		if bar != nil {
+			bar.finish()
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-40s %12d movements  %8s\n", scenario.Name, int(result.TotalCapacity), time.Since(started).Round(time.Millisecond))
+	} else {
+		_, err := runScenario(ctx, scenario.Name, sim, showProgress)
+		return err
+	}
+
+	logger.Info("Result provenance", "hash", result.Provenance.Hash, "version", result.Provenance.Version, "seed", result.Provenance.Seed)
+	if scenario.Output.FlowUsage {
+		logger.Info("Runway Flow Usage", "flows", simulation.FormatUsage(result.FlowUsage()))
+	}
+	if scenario.Output.RunwayUsage {
+		logger.Info("Runway Movement Shares", "runways", simulation.FormatMovementShares(result.RunwayUsage()))
+	}
+	if scenario.Output.RunwayEndUsage {
+		logger.Info("Runway End Movement Shares", "runwayEnds", simulation.FormatMovementShares(result.RunwayEndOperationUsage()))
+	}
+	if scenario.Output.Parquet != "" {
+		if err := writeWindowsParquetFile(scenario.Output.Parquet, result); err != nil {
+			return err
+		}
+		logger.Info("Window results written", "file", scenario.Output.Parquet, "windows", len(result.Windows))
+	}
+	if scenario.Output.Heatmap != "" {
+		if err := writeCapacityHeatmapFile(scenario.Output.Heatmap, baseline.CapacityHeatmap()); err != nil {
+			return err
+		}
+		if err := writeProvenanceFile(scenario.Output.Heatmap, result.Provenance); err != nil {
+			return err
+		}
+		logger.Info("Capacity heatmap written", "file", scenario.Output.Heatmap)
+	}
+	if scenario.Output.StandDemand != "" {
+		profile, err := result.StandDemand(simulation.DefaultStandDemandInterval)
+		if err != nil {
+			return err
+		}
+		if profile == nil {
+			logger.Warn("No stand demand to write: the scenario has no gate capacity policy", "file", scenario.Output.StandDemand)
+		} else if err := writeStandDemandFile(scenario.Output.StandDemand, profile); err != nil {
+			return err
+		} else if err := writeProvenanceFile(scenario.Output.StandDemand, result.Provenance); err != nil {
+			return err
+		} else {
+			logger.Info("Stand demand written", "file", scenario.Output.StandDemand, "samples", len(profile))
+		}
+	}
+	if scenario.Output.Trends != "" {
+		// Drop the default windows longer than the run rather than fail short scenarios
+		days := len(result.DailyCapacity())
+		var windows []int
+		for _, window := range simulation.DefaultTrendWindows {
+			if window <= days {
+				windows = append(windows, window)
+			}
+		}
+		if len(windows) == 0 {
+			logger.Warn("No capacity trends to write: the run is shorter than a week", "file", scenario.Output.Trends)
+		} else if trends, err := result.CapacityTrends(windows...); err != nil {
+			return err
+		} else if err := writeCapacityTrendsFile(scenario.Output.Trends, trends); err != nil {
+			return err
+		} else if err := writeProvenanceFile(scenario.Output.Trends, result.Provenance); err != nil {
+			return err
+		} else {
+			logger.Info("Capacity trends written", "file", scenario.Output.Trends, "days", days, "windows", windows)
+		}
+	}
+	if scenario.Output.Advice > 0 {
+		suggestions, err := sim.WithProgress(nil).Advise(ctx, result, scenario.Output.Advice)
+		if err != nil {
+			return err
+		}
+		for i, suggestion := range suggestions {
+			logger.Info("Suggested change", "rank", i+1, "kind", suggestion.Kind, "suggestion", suggestion.String())
+		}
+		if len(suggestions) == 0 {
+			logger.Info("No suggested changes gain capacity")
+		}
+	}
+	if scenario.Output.CurfewStudy != "" {
+		study, err := sim.WithProgress(nil).CurfewStudy(ctx, simulation.DefaultCurfewStudyIncrement)
+		if err != nil {
+			return err
+		}
+		if err := writeCurfewStudyFile(scenario.Output.CurfewStudy, study); err != nil {
+			return err
+		}
+		logger.Info("Curfew study written", "file", scenario.Output.CurfewStudy, "steps", len(study.LaterStart)+len(study.EarlierEnd))
+	}
+	return nil
+}
+
+// writeProvenanceFile writes the provenance of the result an export at path was produced from
+// to a JSON file beside it, path with ".provenance.json" appended, since CSV files have nowhere
+// to record it.
+func writeProvenanceFile(path string, provenance simulation.Provenance) error {
+	data, err := json.MarshalIndent(provenance, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path+".provenance.json", append(data, '\n'), 0o644)
+}
+
+// writeCurfewStudyFile writes a curfew study as CSV to the file at path.
+func writeCurfewStudyFile(path string, study *simulation.CurfewStudy) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := simulation.WriteCurfewStudyCSV(f, study); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeCapacityTrendsFile writes capacity trends as CSV to the file at path.
+func writeCapacityTrendsFile(path string, trends *simulation.CapacityTrends) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := simulation.WriteCapacityTrendsCSV(f, trends); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeStandDemandFile writes a stand demand profile as CSV to the file at path.
+func writeStandDemandFile(path string, profile []simulation.GateSample) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := simulation.WriteStandDemandCSV(f, profile); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeCapacityHeatmapFile writes a capacity heatmap as CSV to the file at path.
+func writeCapacityHeatmapFile(path string, heatmap *simulation.CapacityHeatmap) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := simulation.WriteCapacityHeatmapCSV(f, heatmap); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writeWindowsParquetFile writes the windows of result to a gzip-compressed Parquet file at path.
+func writeWindowsParquetFile(path string, result *simulation.Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := simulation.WriteWindowsParquet(f, simulation.ParquetOptions{Gzip: true}, result); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// newLogger creates a logger writing records of at least level to w in the given format,
+// "text" or "json".
+func newLogger(format string, w io.Writer, level slog.Level) (*slog.Logger, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (expected text or json)", format)
+	}
+}
+
+// policySpec is a registered policy requested on the command line.
+type policySpec struct {
+	name   string
+	params string // JSON parameters, empty if none
+}
+
+// policyFlags collects repeated -policy flags of the form name or name={json}.
+type policyFlags []policySpec
+
+// String returns the requested policy names.
+func (f *policyFlags) String() string {
+	names := make([]string, len(*f))
+	for i, spec := range *f {
+		names[i] = spec.name
+	}
+	return strings.Join(names, ",")
+}
+
+// Set parses one -policy flag value.
+func (f *policyFlags) Set(value string) error {
+	name, params, _ := strings.Cut(value, "=")
+	if name == "" {
+		return fmt.Errorf("policy name missing in %q", value)
+	}
+	*f = append(*f, policySpec{name: name, params: params})
+	return nil
+}
+
+// runScenarioWithBaseline runs a simulation together with its unconstrained baseline and
+// prints a summary line for each plus the utilization. Its logs are tagged with name as run ID.
+func runScenarioWithBaseline(ctx context.Context, name string, sim *simulation.Simulation, showProgress bool) (*simulation.BaselineResult, error) {
+	ctx = simulation.ContextWithRunID(ctx, name)
+	if err := reportPolicyWarnings(ctx, name, sim); err != nil {
+		return nil, err
+	}
+
+	var bar *progressBar
+	if showProgress {
+		bar = newProgressBar(os.Stderr, name+" (+ baseline)")
+		sim = sim.WithProgress(bar.update)
+	}
+
+	started := time.Now()
+	result, err := sim.RunWithBaseline(ctx)
+	if bar != nil {
+		bar.finish()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Since(started).Round(time.Millisecond)
+	fmt.Printf("%-40s %12d movements  %8s\n", name, int(result.Constrained.TotalCapacity), elapsed)
+	fmt.Printf("%-40s %12d movements  (%.1f%% utilization, %.1f%% realism gap)\n",
+		"  Theoretical maximum", int(result.Baseline.TotalCapacity), result.UtilizationPercent, result.RealismGapPercent)
+	return result, nil
+}
+
+// startCPUProfile starts CPU profiling into path and returns a function that stops it.
+func startCPUProfile(path string) (func(), error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		f.Close()
+	}, nil
+}
+
+// writeHeapProfile writes a heap profile (including allocation counts) to path.
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating heap profile: %w", err)
+	}
+	defer f.Close()
+
+	runtime.GC() // Materialize up-to-date allocation statistics
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		return fmt.Errorf("writing heap profile: %w", err)
+	}
+	return nil
+}
+
+// runScenario runs a simulation and prints a one-line summary when it completes.
+// When showProgress is set, a progress bar with an ETA is drawn on stderr while it runs.
+// Its logs are tagged with name as run ID.
+func runScenario(ctx context.Context, name string, sim *simulation.Simulation, showProgress bool) (float32, error) {
+	ctx = simulation.ContextWithRunID(ctx, name)
+	if err := reportPolicyWarnings(ctx, name, sim); err != nil {
+		return 0, err
+	}
+
+	var bar *progressBar
+	if showProgress {
+		bar = newProgressBar(os.Stderr, name)
+		sim = sim.WithProgress(bar.update)
+	}
+
+	started := time.Now()
+	capacity, err := sim.Run(ctx)
+	if bar != nil {
+		bar.finish()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("%-40s %12d movements  %8s\n", name, int(capacity), time.Since(started).Round(time.Millisecond))
+	return capacity, nil
+}
+
+// maxReportedWarnings limits how many policy warnings are printed per scenario.
+const maxReportedWarnings = 5
+
+// reportPolicyWarnings prints conflicting or redundant policy events found by the
+// pre-run analysis to stderr.
+func reportPolicyWarnings(ctx context.Context, name string, sim *simulation.Simulation) error {
+	warnings, err := sim.Analyze(ctx)
+	if err != nil {
+		return err
+	}
+	for i, warning := range warnings {
+		if i == maxReportedWarnings {
+			fmt.Fprintf(os.Stderr, "%s: ... and %d more policy warnings\n", name, len(warnings)-i)
+			break
+		}
+		fmt.Fprintf(os.Stderr, "%s: warning: %s\n", name, warning)
+	}
+	return nil
+}
+
+// progressBarWidth is the number of characters in the bar itself.
+const progressBarWidth = 30
+
+// progressRedrawInterval limits how often the bar is redrawn.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// progressBar draws a single-line progress bar with an ETA derived from the
+// engine's progress callback.
+type progressBar struct {
+	out      io.Writer
+	label    string
+	started  time.Time
+	lastDraw time.Time
+}
+
+// newProgressBar creates a progress bar that writes to out.
+func newProgressBar(out io.Writer, label string) *progressBar {
+	return &progressBar{
+		out:     out,
+		label:   label,
+		started: time.Now(),
+	}
+}
+
+// update redraws the bar. It matches simulation.ProgressFunc.
+// The total grows as policies schedule follow-up events, so the ETA is an estimate.
+func (p *progressBar) update(done, total int, simTime time.Time) {
+	now := time.Now()
+	if done < total && now.Sub(p.lastDraw) < progressRedrawInterval {
+		return
+	}
+	p.lastDraw = now
+
+	fraction := 1.0
+	if total > 0 {
+		fraction = float64(done) / float64(total)
+	}
+	filled := int(fraction * progressBarWidth)
+
+	eta := "--"
+	if done > 0 && done < total {
+		elapsed := now.Sub(p.started)
+		remaining := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+		eta = remaining.Round(time.Second).String()
+	} else if done >= total {
+		eta = "0s"
+	}
+
+	fmt.Fprintf(p.out, "\r%-40s [%s%s] %3.0f%%  ETA %-6s %s\033[K",
+		p.label,
+		strings.Repeat("#", filled),
+		strings.Repeat(".", progressBarWidth-filled),
+		fraction*100,
+		eta,
+		simTime.Format("2006-01-02"))
+}
+
+// finish clears the bar so the summary line can take its place.
+func (p *progressBar) finish() {
+	fmt.Fprint(p.out, "\r\033[K")
+}
+
+const whatIfHelp = `Commands:
+  close <runway>             close a runway
+  open <runway>              reopen a runway
+  wind <knots> <deg> [gust]  set the wind speed, direction it blows from and gust speed
+  calm                       set calm wind
+  curfew on|off              start or end a curfew
+  show                       show the current conditions and capacity
+  help                       show this help
+  quit                       leave the what-if mode`
+
+// runWhatIf reads what-if commands from in and writes the resulting runway configuration and
+// hourly capacity to out after each one, until quit or the end of the input.
+func runWhatIf(in io.Reader, out io.Writer, ap airport.Airport) error {
+	w, err := simulation.NewWhatIf(ap)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "What-if mode for %s (type help for commands)\n", ap.Name)
+	printWhatIf(out, w)
+
+	scanner := bufio.NewScanner(in)
+	for fmt.Fprint(out, "> "); scanner.Scan(); fmt.Fprint(out, "> ") {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		command := strings.ToLower(fields[0])
+		switch command {
+		case "quit", "exit":
+			return nil
+		case "help":
+			fmt.Fprintln(out, whatIfHelp)
+		case "show":
+			printWhatIf(out, w)
+		default:
+			if err := applyWhatIfCommand(w, command, fields[1:]); err != nil {
+				fmt.Fprintf(out, "error: %v\n", err)
+				continue
+			}
+			printWhatIf(out, w)
+		}
+	}
+	fmt.Fprintln(out)
+	return scanner.Err()
+}
+
+// applyWhatIfCommand applies a command that changes the what-if conditions.
+func applyWhatIfCommand(w *simulation.WhatIf, command string, args []string) error {
+	switch command {
+	case "close", "open":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: %s <runway>", command)
+		}
+		return w.SetRunwayOpen(strings.ToUpper(args[0]), command == "open")
+	case "wind":
+		if len(args) < 2 || len(args) > 3 {
+			return fmt.Errorf("usage: wind <knots> <deg> [gust]")
+		}
+		values := make([]float64, 3)
+		for i, arg := range args {
+			v, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q", arg)
+			}
+			values[i] = v
+		}
+		return w.SetWind(values[0], values[2], values[1])
+	case "calm":
+		return w.SetWind(0, 0, 0)
+	case "curfew":
+		if len(args) != 1 || (args[0] != "on" && args[0] != "off") {
+			return fmt.Errorf("usage: curfew on|off")
+		}
+		w.SetCurfew(args[0] == "on")
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q (type help for commands)", command)
+	}
+}
+
+// printWhatIf writes the current conditions, active configuration and hourly capacity.
+func printWhatIf(out io.Writer, w *simulation.WhatIf) {
+	speed, gust, direction := w.Wind()
+	wind := "calm"
+	if speed > 0 {
+		wind = fmt.Sprintf("%03.0f° %.0fkt", direction, speed)
+		if gust > 0 {
+			wind += fmt.Sprintf(" gusting %.0fkt", gust)
+		}
+	}
+	curfew := "off"
+	if w.Curfew() {
+		curfew = "on"
+	}
+	closed := "none"
+	if runways := w.ClosedRunways(); len(runways) > 0 {
+		closed = strings.Join(runways, ", ")
+	}
+
+	config := w.Configuration()
+	runway, helipad := w.HourlyCapacity()
+	fmt.Fprintf(out, "  Wind: %s | Curfew: %s | Closed: %s\n", wind, curfew, closed)
+	if len(config) == 0 {
+		fmt.Fprintf(out, "  Configuration: %s\n", simulation.ClosedConfigurationLabel)
+	} else {
+		fmt.Fprintf(out, "  Configuration: %s (%s)\n", simulation.ConfigurationLabel(config), simulation.ConfigurationFlow(config))
+	}
+	if helipad > 0 {
+		fmt.Fprintf(out, "  Capacity: %.0f movements/hour (%.0f runway + %.0f helipad)\n", runway+helipad, runway, helipad)
+	} else {
+		fmt.Fprintf(out, "  Capacity: %.0f movements/hour\n", runway)
+	}
+}
@@ -1,13 +1,95 @@
 // Package airport provides combined airport modeling and calculations.
 package airport
 
+import (
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
 // Airport represents a physical airport with all its subcomponents.
 type Airport struct {
-	Name                string                // The commercial name of the airport
-	IATACode            string                // The IATA code of the Airport
-	ICAOCode            string                // The ICAO code of the Airport
-	City                string                // The city where the airport is located
-	Country             string                // The country where the airport is located
-	Runways             []Runway              // A list of runways at the Airport
-	RunwayCompatibility *RunwayCompatibility  // Optional compatibility graph defining which runways can operate simultaneously (nil means all runways compatible)
+	Name                string               // The commercial name of the airport
+	IATACode            string               // The IATA code of the Airport
+	ICAOCode            string               // The ICAO code of the Airport
+	City                string               // The city where the airport is located
+	Country             string               // The country where the airport is located
+	Runways             []Runway             // A list of runways at the Airport
+	RunwayCompatibility *RunwayCompatibility // Optional compatibility graph defining which runways can operate simultaneously (nil means all runways compatible)
+	FleetMix            *FleetMix            // Optional aircraft fleet mix used for separation, runway occupancy and gate turnaround (nil means each runway's minimum separation applies)
+	Gates               int                  // Optional number of aircraft gates/stands (0 means unknown), used by gate capacity constraints that do not set their own
+	Location            string               // Optional IANA time zone of the airport (e.g., "Europe/London"), in which curfews are scheduled (empty means the simulation's time zone)
+	Helipads            []Helipad            // Optional helipads whose helicopter movements add to the runways' without consuming runway capacity
+}
+
+// TimeZone returns the airport's time zone, or nil if no Location is set.
+// Returns an error if Location is not a known IANA time zone.
+func (a Airport) TimeZone() (*time.Location, error) {
+	if a.Location == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(a.Location)
+	if err != nil {
+		return nil, simerrors.Invalidf("unknown airport location %q: %v", a.Location, err)
+	}
+	return loc, nil
+}
+
+// Validate checks the airport configuration: every runway must be valid, designations
+// must be unique, the compatibility graph must be consistent with the runway list, and
+// the fleet mix (if any) must be valid, the gate count must not be negative, the
+// location (if any) must be a known time zone, and every helipad must be valid with a
+// designation not used by another helipad or runway.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (a Airport) Validate() error {
+	var problems []error
+
+	if len(a.Runways) == 0 {
+		problems = append(problems, simerrors.Invalidf("airport has no runways"))
+	}
+
+	seen := make(map[string]bool, len(a.Runways))
+	ids := make([]string, 0, len(a.Runways))
+	for _, runway := range a.Runways {
+		if err := runway.Validate(); err != nil {
+			// Runway.Validate joins its problems; list them individually
+			problems = append(problems, err.(interface{ Unwrap() []error }).Unwrap()...)
+		}
+		if seen[runway.RunwayDesignation] {
+			problems = append(problems, simerrors.Invalidf("duplicate runway designation: %s", runway.RunwayDesignation))
+		}
+		seen[runway.RunwayDesignation] = true
+		ids = append(ids, runway.RunwayDesignation)
+	}
+
+	if err := a.RunwayCompatibility.validate(ids, a.RunwayEndDesignations()); err != nil {
+		problems = append(problems, err)
+	}
+
+	for _, helipad := range a.Helipads {
+		if err := helipad.Validate(); err != nil {
+			// Helipad.Validate joins its problems; list them individually
+			problems = append(problems, err.(interface{ Unwrap() []error }).Unwrap()...)
+		}
+		if seen[helipad.Designation] {
+			problems = append(problems, simerrors.Invalidf("duplicate helipad designation: %s", helipad.Designation))
+		}
+		seen[helipad.Designation] = true
+	}
+
+	if a.Gates < 0 {
+		problems = append(problems, simerrors.Invalidf("gate count must not be negative, got %d", a.Gates))
+	}
+
+	if _, err := a.TimeZone(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if err := a.FleetMix.Validate(); err != nil {
+		// FleetMix.Validate joins its problems; list them individually
+		problems = append(problems, err.(interface{ Unwrap() []error }).Unwrap()...)
+	}
+
+	return errors.Join(problems...)
 }
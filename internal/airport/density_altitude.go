@@ -0,0 +1,21 @@
+package airport
+
+// International Standard Atmosphere (ISA) values used for density altitude
+const (
+	ISASeaLevelTemperatureCelsius  = 15.0   // Standard temperature at mean sea level
+	ISALapseRateCelsiusPerMeter    = 0.0065 // Standard temperature decrease with height
+	DensityAltitudeMetersPerDegree = 36.576 // Density altitude increase per °C above ISA (120 ft)
+)
+
+// ISATemperatureCelsius returns the standard atmosphere temperature at an elevation.
+func ISATemperatureCelsius(elevationMeters float64) float64 {
+	return ISASeaLevelTemperatureCelsius - ISALapseRateCelsiusPerMeter*elevationMeters
+}
+
+// DensityAltitudeMeters returns the altitude in the standard atmosphere at which the air
+// density equals that at the given elevation and outside air temperature. Aircraft perform as
+// if operating from this altitude, so hot days at high airports need much longer runways.
+// Pressure altitude is taken to be the elevation (standard pressure).
+func DensityAltitudeMeters(elevationMeters, temperatureCelsius float64) float64 {
+	return elevationMeters + DensityAltitudeMetersPerDegree*(temperatureCelsius-ISATemperatureCelsius(elevationMeters))
+}
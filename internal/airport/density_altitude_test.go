@@ -0,0 +1,42 @@
+package airport
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDensityAltitudeMeters(t *testing.T) {
+	tests := []struct {
+		name               string
+		elevationMeters    float64
+		temperatureCelsius float64
+		expected           float64
+	}{
+		{"sea level ISA", 0, 15, 0},
+		{"high airport at ISA", 1600, ISATemperatureCelsius(1600), 1600},
+		{"sea level 10 degrees above ISA", 0, 25, 365.76},
+		{"cold day below sea level density", 0, 5, -365.76},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DensityAltitudeMeters(tt.elevationMeters, tt.temperatureCelsius); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected %.2f, got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunway_DensityCorrectedLengthMeters(t *testing.T) {
+	runway := Runway{LengthMeters: 3000, ElevationMeters: 600}
+
+	if got, want := runway.DensityCorrectedLengthMeters(ISATemperatureCelsius(600)), runway.CorrectedLengthMeters(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected ISA temperature to match elevation correction %.1f, got %.1f", want, got)
+	}
+	if hot, standard := runway.DensityCorrectedLengthMeters(40), runway.CorrectedLengthMeters(); hot >= standard {
+		t.Errorf("Expected hot day to shorten corrected length below %.1f, got %.1f", standard, hot)
+	}
+	if cold := runway.DensityCorrectedLengthMeters(-40); cold > runway.LengthMeters {
+		t.Errorf("Expected no credit beyond the physical length, got %.1f", cold)
+	}
+}
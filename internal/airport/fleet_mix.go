@@ -0,0 +1,323 @@
+package airport
+
+import (
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// Standard wake turbulence class names used by NewStandardFleetMix
+const (
+	HeavyClass  = "Heavy"
+	MediumClass = "Medium"
+	LightClass  = "Light"
+)
+
+// DefaultRadarSeparationNM is the minimum distance between successive aircraft on final
+// approach when no wake turbulence separation applies.
+const DefaultRadarSeparationNM = 3.0
+
+// AircraftClass describes one class of aircraft operating at an airport.
+type AircraftClass struct {
+	Name               string        // Class or type label (e.g., "Heavy", "A320")
+	Share              float64       // Fraction of movements flown by this class (shares are normalised)
+	ApproachSpeedKnots float64       // Final approach speed in knots (0 = distance separation not modelled)
+	RunwayOccupancy    time.Duration // Arrival runway occupancy time (0 = not limiting)
+	TurnaroundTime     time.Duration // Average gate turnaround (0 = use the gate constraint's average)
+
+	RequiredRunwayLengthMeters float64 // Sea-level runway length the class needs (0 = any runway)
+}
+
+// FleetMix describes the aircraft actually operating at an airport. It replaces a single
+// hard-coded separation with the expected separation between successive movements, and
+// the gate model's average turnaround with the share-weighted turnaround of the fleet.
+type FleetMix struct {
+	Classes []AircraftClass
+
+	// WakeSeparationNM is the minimum distance in nautical miles between a leader class
+	// (outer key) and a follower class (inner key). Pairs not listed use RadarSeparationNM.
+	WakeSeparationNM map[string]map[string]float64
+
+	// RadarSeparationNM is the minimum distance between any two aircraft (0 = DefaultRadarSeparationNM)
+	RadarSeparationNM float64
+
+	// CorrectRunwayLength compares class length requirements with runway lengths corrected
+	// for elevation and gradient (see Runway.CorrectedLengthMeters) instead of physical lengths
+	CorrectRunwayLength bool
+}
+
+// NewStandardFleetMix creates a fleet mix of ICAO Heavy, Medium and Light wake turbulence
+// classes with typical approach speeds, runway occupancy, turnaround times and sea-level
+// runway length requirements, and the ICAO wake turbulence separation minima. Shares are normalised so they need not sum to 1.
+func NewStandardFleetMix(heavy, medium, light float64) *FleetMix {
+	return &FleetMix{
+		Classes: []AircraftClass{
+			{Name: HeavyClass, Share: heavy, ApproachSpeedKnots: 150, RunwayOccupancy: 60 * time.Second, TurnaroundTime: 90 * time.Minute, RequiredRunwayLengthMeters: 3000},
+			{Name: MediumClass, Share: medium, ApproachSpeedKnots: 135, RunwayOccupancy: 50 * time.Second, TurnaroundTime: 45 * time.Minute, RequiredRunwayLengthMeters: 2000},
+			{Name: LightClass, Share: light, ApproachSpeedKnots: 100, RunwayOccupancy: 45 * time.Second, TurnaroundTime: 30 * time.Minute, RequiredRunwayLengthMeters: 1200},
+		},
+		WakeSeparationNM: map[string]map[string]float64{
+			HeavyClass:  {HeavyClass: 4, MediumClass: 5, LightClass: 6},
+			MediumClass: {LightClass: 5},
+		},
+		RadarSeparationNM: DefaultRadarSeparationNM,
+	}
+}
+
+// Validate checks that the fleet mix has at least one class, class names are unique,
+// shares sum to a positive value, speeds, durations and lengths are non-negative, and every wake
+// separation refers to known classes with a positive distance.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (f *FleetMix) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	var problems []error
+	if len(f.Classes) == 0 {
+		problems = append(problems, simerrors.Invalidf("fleet mix must have at least one aircraft class"))
+	}
+
+	known := make(map[string]bool, len(f.Classes))
+	totalShare := 0.0
+	for _, class := range f.Classes {
+		if class.Name == "" {
+			problems = append(problems, simerrors.Invalidf("fleet mix aircraft class name cannot be empty"))
+		} else if known[class.Name] {
+			problems = append(problems, simerrors.Invalidf("duplicate fleet mix aircraft class: %s", class.Name))
+		}
+		known[class.Name] = true
+
+		if class.Share < 0 {
+			problems = append(problems, simerrors.Invalidf("fleet mix class %s: share cannot be negative, got %f", class.Name, class.Share))
+		}
+		totalShare += class.Share
+		if class.ApproachSpeedKnots < 0 || class.RunwayOccupancy < 0 || class.TurnaroundTime < 0 || class.RequiredRunwayLengthMeters < 0 {
+			problems = append(problems, simerrors.Invalidf("fleet mix class %s: speeds, times and runway lengths cannot be negative", class.Name))
+		}
+	}
+	if len(f.Classes) > 0 && totalShare <= 0 {
+		problems = append(problems, simerrors.Invalidf("fleet mix shares must sum to a positive value"))
+	}
+
+	for leader, followers := range f.WakeSeparationNM {
+		for follower, distance := range followers {
+			if !known[leader] || !known[follower] {
+				problems = append(problems, simerrors.Invalidf("wake separation %s-%s references an unknown aircraft class", leader, follower))
+			}
+			if distance <= 0 {
+				problems = append(problems, simerrors.Invalidf("wake separation %s-%s must be positive, got %f", leader, follower, distance))
+			}
+		}
+	}
+	if f.RadarSeparationNM < 0 {
+		problems = append(problems, simerrors.Invalidf("radar separation cannot be negative, got %f", f.RadarSeparationNM))
+	}
+
+	return errors.Join(problems...)
+}
+
+// Separation returns the expected time between successive movements on a runway whose own
+// minimum separation is runwayMinimum. For each leader/follower pair, the gap is the longest
+// of the runway minimum, the follower's time to fly the required distance at its approach
+// speed, and the leader's runway occupancy; pairs are weighted by their shares.
+//
+// A nil fleet mix, or one whose shares sum to zero, returns runwayMinimum unchanged.
+func (f *FleetMix) Separation(runwayMinimum time.Duration) time.Duration {
+	return f.separation(runwayMinimum, 1)
+}
+
+// RunwaySeparation returns the expected time between successive movements on a runway in
+// mixed mode. See RunwayModeSeparation.
+func (f *FleetMix) RunwaySeparation(runway Runway) time.Duration {
+	return f.RunwayModeSeparation(runway, MixedMode)
+}
+
+// RunwayModeSeparation returns the expected time between successive movements on a runway in
+// the given mode and its current surface condition: the runway's separation in that mode (see
+// Runway.Separation) and the fleet's runway occupancy times are stretched by the condition's
+// separation factor (see SurfaceCondition.Effect) before being combined as in Separation.
+func (f *FleetMix) RunwayModeSeparation(runway Runway, mode SeparationMode) time.Duration {
+	factor := runway.SurfaceCondition.Effect(runway.SurfaceType).SeparationFactor
+	return f.separation(time.Duration(float64(runway.Separation(mode))*factor), factor)
+}
+
+// separation implements Separation with runway occupancy times scaled by occupancyFactor.
+func (f *FleetMix) separation(runwayMinimum time.Duration, occupancyFactor float64) time.Duration {
+	if f == nil {
+		return runwayMinimum
+	}
+	totalShare := f.totalShare()
+	if totalShare <= 0 {
+		return runwayMinimum
+	}
+
+	expected := 0.0
+	for _, leader := range f.Classes {
+		for _, follower := range f.Classes {
+			gap := runwayMinimum
+			if follower.ApproachSpeedKnots > 0 {
+				distanceHours := f.separationNM(leader.Name, follower.Name) / follower.ApproachSpeedKnots
+				gap = max(gap, time.Duration(distanceHours*float64(time.Hour)))
+			}
+			gap = max(gap, time.Duration(float64(leader.RunwayOccupancy)*occupancyFactor))
+
+			expected += (leader.Share / totalShare) * (follower.Share / totalShare) * float64(gap)
+		}
+	}
+	return time.Duration(expected)
+}
+
+// AverageTurnaround returns the share-weighted gate turnaround time of the fleet. Classes
+// without a turnaround time use fallback. A nil fleet mix, or one whose shares sum to zero,
+// returns fallback unchanged.
+func (f *FleetMix) AverageTurnaround(fallback time.Duration) time.Duration {
+	if f == nil {
+		return fallback
+	}
+	totalShare := f.totalShare()
+	if totalShare <= 0 {
+		return fallback
+	}
+
+	average := 0.0
+	for _, class := range f.Classes {
+		turnaround := class.TurnaroundTime
+		if turnaround == 0 {
+			turnaround = fallback
+		}
+		average += class.Share / totalShare * float64(turnaround)
+	}
+	return time.Duration(average)
+}
+
+// CanUse reports whether an aircraft class can operate on a runway given its length requirement.
+// Runways without a recorded length are assumed usable by every class.
+func (f *FleetMix) CanUse(class AircraftClass, runway Runway) bool {
+	length := runway.LengthMeters
+	if f.CorrectRunwayLength {
+		length = runway.CorrectedLengthMeters()
+	}
+	return meetsLengthRequirement(class, runway, length)
+}
+
+// CanUseAt is like CanUse at a known outside air temperature. The runway length is always
+// corrected for the density altitude at that temperature, whatever CorrectRunwayLength says.
+func (f *FleetMix) CanUseAt(class AircraftClass, runway Runway, temperatureCelsius float64) bool {
+	return meetsLengthRequirement(class, runway, runway.DensityCorrectedLengthMeters(temperatureCelsius))
+}
+
+// ServableMovements returns the most movements the given runways can handle, where
+// capacities[i] is the capacity of runways[i], when each aircraft class may only use runways
+// long enough for it.
+//
+// Classes that cannot use any of the runways are lost, and their share of the movements with
+// them. The remaining classes keep their relative shares, so the total is also limited by the
+// runways available to the most demanding classes: when a long runway closes, the traffic
+// that needs it is capped by what the remaining long runways can handle. A nil fleet mix, or
+// one whose shares sum to zero, returns the total capacity.
+func (f *FleetMix) ServableMovements(runways []Runway, capacities []float64) float64 {
+	return f.servableMovements(runways, capacities, f.CanUse)
+}
+
+// ServableMovementsAt is like ServableMovements at a known outside air temperature (see CanUseAt).
+func (f *FleetMix) ServableMovementsAt(runways []Runway, capacities []float64, temperatureCelsius float64) float64 {
+	return f.servableMovements(runways, capacities, func(class AircraftClass, runway Runway) bool {
+		return f.CanUseAt(class, runway, temperatureCelsius)
+	})
+}
+
+// servableMovements implements ServableMovements with canUse deciding which runways each
+// class may use.
+func (f *FleetMix) servableMovements(runways []Runway, capacities []float64, canUse func(AircraftClass, Runway) bool) float64 {
+	total := 0.0
+	for _, capacity := range capacities {
+		total += capacity
+	}
+	if f == nil || f.totalShare() <= 0 {
+		return total
+	}
+
+	type classUsage struct {
+		share    float64
+		usable   []bool  // usable[i] reports whether the class can use runways[i]
+		capacity float64 // Capacity of the runways the class can use
+	}
+
+	var usages []classUsage
+	servedShare := 0.0
+	for _, class := range f.Classes {
+		if class.Share <= 0 {
+			continue
+		}
+		usage := classUsage{share: class.Share, usable: make([]bool, len(runways))}
+		for i, runway := range runways {
+			if canUse(class, runway) {
+				usage.usable[i] = true
+				usage.capacity += capacities[i]
+			}
+		}
+		if usage.capacity > 0 {
+			usages = append(usages, usage)
+			servedShare += class.Share
+		}
+	}
+	if servedShare == 0 {
+		return 0
+	}
+
+	// Length requirements make usable runway sets nested, so the binding limit is the
+	// capacity of each class's runways over the share of all classes confined to them
+	served := total
+	for _, usage := range usages {
+		confinedShare := 0.0
+		for _, other := range usages {
+			if isUsableSubset(other.usable, usage.usable) {
+				confinedShare += other.share / servedShare
+			}
+		}
+		served = min(served, usage.capacity/confinedShare)
+	}
+
+	return served * servedShare / f.totalShare()
+}
+
+// meetsLengthRequirement reports whether a runway of the given usable length meets a class's
+// length requirement. Classes without a requirement and runways without a recorded length pass.
+func meetsLengthRequirement(class AircraftClass, runway Runway, length float64) bool {
+	if class.RequiredRunwayLengthMeters == 0 || runway.LengthMeters == 0 {
+		return true
+	}
+	return length >= class.RequiredRunwayLengthMeters
+}
+
+// isUsableSubset reports whether every runway usable in a is usable in b.
+func isUsableSubset(a, b []bool) bool {
+	for i := range a {
+		if a[i] && !b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// separationNM returns the minimum distance between a leader and follower class.
+func (f *FleetMix) separationNM(leader, follower string) float64 {
+	if distance, ok := f.WakeSeparationNM[leader][follower]; ok {
+		return distance
+	}
+	if f.RadarSeparationNM > 0 {
+		return f.RadarSeparationNM
+	}
+	return DefaultRadarSeparationNM
+}
+
+// totalShare returns the sum of class shares.
+func (f *FleetMix) totalShare() float64 {
+	total := 0.0
+	for _, class := range f.Classes {
+		total += class.Share
+	}
+	return total
+}
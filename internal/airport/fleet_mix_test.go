@@ -0,0 +1,169 @@
+package airport
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestFleetMix_Separation(t *testing.T) {
+	tests := []struct {
+		name          string
+		mix           *FleetMix
+		runwayMinimum time.Duration
+		expected      time.Duration
+	}{
+		{"nil mix keeps runway minimum", nil, 60 * time.Second, 60 * time.Second},
+		{
+			"radar separation at approach speed",
+			&FleetMix{Classes: []AircraftClass{{Name: "A320", Share: 1, ApproachSpeedKnots: 120}}},
+			60 * time.Second,
+			90 * time.Second, // 3 NM at 120 kt
+		},
+		{
+			"runway minimum is a floor",
+			&FleetMix{Classes: []AircraftClass{{Name: "A320", Share: 1, ApproachSpeedKnots: 120}}},
+			100 * time.Second,
+			100 * time.Second,
+		},
+		{
+			"leader runway occupancy limits follower",
+			&FleetMix{Classes: []AircraftClass{{Name: "A320", Share: 1, ApproachSpeedKnots: 120, RunwayOccupancy: 110 * time.Second}}},
+			60 * time.Second,
+			110 * time.Second,
+		},
+		{"heavy behind heavy", NewStandardFleetMix(1, 0, 0), 60 * time.Second, 96 * time.Second}, // 4 NM at 150 kt
+		{
+			// Pairs: H-H 96s, H-L 216s, L-H 72s, L-L 108s, each a quarter of movements
+			"wake separation weighted by pair shares",
+			NewStandardFleetMix(1, 0, 1),
+			60 * time.Second,
+			123 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mix.Separation(tt.runwayMinimum); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFleetMix_AverageTurnaround(t *testing.T) {
+	if got := (*FleetMix)(nil).AverageTurnaround(time.Hour); got != time.Hour {
+		t.Errorf("Expected nil mix to keep fallback, got %v", got)
+	}
+
+	// Half heavies (90 min) and half mediums (45 min)
+	if got := NewStandardFleetMix(1, 1, 0).AverageTurnaround(time.Hour); got != 67*time.Minute+30*time.Second {
+		t.Errorf("Expected 67m30s, got %v", got)
+	}
+
+	mix := &FleetMix{Classes: []AircraftClass{
+		{Name: "Cargo", Share: 1, TurnaroundTime: 3 * time.Hour},
+		{Name: "Regional", Share: 1},
+	}}
+	if got := mix.AverageTurnaround(time.Hour); got != 2*time.Hour {
+		t.Errorf("Expected classes without a turnaround to use the fallback, got %v", got)
+	}
+}
+
+func TestFleetMix_CanUse(t *testing.T) {
+	heavy := AircraftClass{Name: HeavyClass, Share: 1, RequiredRunwayLengthMeters: 3000}
+	highRunway := Runway{LengthMeters: 3200, ElevationMeters: 900}
+
+	if !(&FleetMix{}).CanUse(heavy, highRunway) {
+		t.Error("Expected physical length to be compared without correction")
+	}
+	if (&FleetMix{CorrectRunwayLength: true}).CanUse(heavy, highRunway) {
+		t.Error("Expected corrected length below the requirement to be unusable")
+	}
+	if !(&FleetMix{}).CanUse(heavy, Runway{}) {
+		t.Error("Expected runways without a recorded length to be usable")
+	}
+
+	// 3100 m at sea level suffices at ISA, but not at 40 °C (density altitude about 900 m)
+	seaLevelRunway := Runway{LengthMeters: 3100}
+	if !(&FleetMix{}).CanUseAt(heavy, seaLevelRunway, 15) {
+		t.Error("Expected runway to be usable at ISA temperature")
+	}
+	if (&FleetMix{}).CanUseAt(heavy, seaLevelRunway, 40) {
+		t.Error("Expected runway to be too short on a hot day")
+	}
+}
+
+func TestFleetMix_ServableMovements(t *testing.T) {
+	long := Runway{RunwayDesignation: "09L", LengthMeters: 3500}
+	short := Runway{RunwayDesignation: "09R", LengthMeters: 2000}
+	mix := func(heavyShare float64) *FleetMix {
+		return &FleetMix{Classes: []AircraftClass{
+			{Name: HeavyClass, Share: heavyShare, RequiredRunwayLengthMeters: 3000},
+			{Name: MediumClass, Share: 1 - heavyShare},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		mix        *FleetMix
+		runways    []Runway
+		capacities []float64
+		expected   float64
+	}{
+		{"nil mix", nil, []Runway{short}, []float64{60}, 60},
+		{"both runways open", mix(0.5), []Runway{long, short}, []float64{60, 60}, 120},
+		// Heavies need the long runway, so 60 movements there cap the total at 75
+		{"heavy mix limited by long runway", mix(0.8), []Runway{long, short}, []float64{60, 60}, 75},
+		{"short runway closed", mix(0.5), []Runway{long}, []float64{60}, 60},
+		// Heavies are lost; the short runway still serves the mediums at their own share
+		{"long runway closed", mix(0.5), []Runway{short}, []float64{60}, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mix.ServableMovements(tt.runways, tt.capacities); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected %.1f, got %.1f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFleetMix_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mix         *FleetMix
+		expectError bool
+	}{
+		{"nil mix", nil, false},
+		{"standard mix", NewStandardFleetMix(0.2, 0.7, 0.1), false},
+		{"no classes", &FleetMix{}, true},
+		{"duplicate class", &FleetMix{Classes: []AircraftClass{{Name: "A", Share: 1}, {Name: "A", Share: 1}}}, true},
+		{"zero shares", &FleetMix{Classes: []AircraftClass{{Name: "A"}}}, true},
+		{"negative speed", &FleetMix{Classes: []AircraftClass{{Name: "A", Share: 1, ApproachSpeedKnots: -1}}}, true},
+		{"negative runway length", &FleetMix{Classes: []AircraftClass{{Name: "A", Share: 1, RequiredRunwayLengthMeters: -1}}}, true},
+		{
+			"wake separation for unknown class",
+			&FleetMix{
+				Classes:          []AircraftClass{{Name: "A", Share: 1}},
+				WakeSeparationNM: map[string]map[string]float64{"A": {"B": 5}},
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mix.Validate()
+			if tt.expectError && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
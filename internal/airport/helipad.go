@@ -0,0 +1,41 @@
+package airport
+
+import (
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// Helipad is a helipad or helicopter operating area used by rotary-wing traffic independently
+// of the runways: its movements add to the airport's total without consuming runway capacity.
+type Helipad struct {
+	Designation       string        // Identifier of the helipad (e.g., "H1")
+	MinimumSeparation time.Duration // Minimum time between successive helicopter movements
+	WindLimitKnots    float64       // Wind speed, including gusts, above which the helipad closes (0 = no limit)
+}
+
+// UsableInWind reports whether the helipad operates in a wind of speedKnots gusting to
+// gustKnots (0 means no gusts).
+func (h Helipad) UsableInWind(speedKnots, gustKnots float64) bool {
+	return h.WindLimitKnots == 0 || max(speedKnots, gustKnots) <= h.WindLimitKnots
+}
+
+// Validate checks the helipad configuration: the designation must not be empty, the minimum
+// separation must be positive and the wind limit must not be negative.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (h Helipad) Validate() error {
+	var problems []error
+
+	if h.Designation == "" {
+		problems = append(problems, simerrors.Invalidf("helipad designation cannot be empty"))
+	}
+	if h.MinimumSeparation <= 0 {
+		problems = append(problems, simerrors.Invalidf("helipad %s: minimum separation must be positive, got %v", h.Designation, h.MinimumSeparation))
+	}
+	if h.WindLimitKnots < 0 {
+		problems = append(problems, simerrors.Invalidf("helipad %s: wind limit cannot be negative", h.Designation))
+	}
+
+	return errors.Join(problems...)
+}
@@ -0,0 +1,71 @@
+package airport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHelipad_Validate(t *testing.T) {
+	valid := Helipad{Designation: "H1", MinimumSeparation: 120 * time.Second, WindLimitKnots: 35}
+
+	tests := []struct {
+		name        string
+		modify      func(h *Helipad)
+		expectError bool
+	}{
+		{"valid helipad", func(h *Helipad) {}, false},
+		{"no wind limit", func(h *Helipad) { h.WindLimitKnots = 0 }, false},
+		{"missing designation", func(h *Helipad) { h.Designation = "" }, true},
+		{"zero separation", func(h *Helipad) { h.MinimumSeparation = 0 }, true},
+		{"negative wind limit", func(h *Helipad) { h.WindLimitKnots = -5 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helipad := valid
+			tt.modify(&helipad)
+			err := helipad.Validate()
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHelipad_UsableInWind(t *testing.T) {
+	helipad := Helipad{Designation: "H1", MinimumSeparation: 120 * time.Second, WindLimitKnots: 35}
+
+	if !helipad.UsableInWind(35, 0) {
+		t.Error("Expected helipad to be usable at its wind limit")
+	}
+	if helipad.UsableInWind(20, 40) {
+		t.Error("Expected gusts above the limit to close the helipad")
+	}
+	if !(Helipad{Designation: "H2", MinimumSeparation: time.Minute}).UsableInWind(80, 90) {
+		t.Error("Expected helipad without a limit to be usable in any wind")
+	}
+}
+
+func TestAirport_Validate_Helipads(t *testing.T) {
+	ap := Airport{
+		Runways: []Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+		Helipads: []Helipad{
+			{Designation: "H1", MinimumSeparation: 0},
+			{Designation: "H1", MinimumSeparation: time.Minute},
+			{Designation: "09", MinimumSeparation: time.Minute},
+		},
+	}
+
+	err := ap.Validate()
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined error, got %v", err)
+	}
+	// Zero separation, duplicate H1, and a helipad sharing the runway's designation
+	if n := len(joined.Unwrap()); n != 3 {
+		t.Errorf("Expected 3 problems, got %d: %v", n, err)
+	}
+}
@@ -0,0 +1,44 @@
+package airport
+
+// DefaultTailwindDistanceFactorPerKnot is the fractional increase in required landing
+// distance for each knot of tailwind (rule of thumb: +10% per 2 knots).
+const DefaultTailwindDistanceFactorPerKnot = 0.05
+
+// LandingPerformance describes the landing distance requirement for a share of the
+// aircraft movements operating at an airport.
+type LandingPerformance struct {
+	Class                 string  // Aircraft class or type label (e.g., "Heavy", "A320")
+	Share                 float64 // Fraction of movements flown by this class (shares are normalised)
+	LandingDistanceMeters float64 // Required landing distance in zero wind
+}
+
+// RequiredLandingDistance returns the landing distance required with the given tailwind.
+// Headwinds (tailwindKnots <= 0) are conservatively ignored and the zero-wind distance is returned.
+func (lp LandingPerformance) RequiredLandingDistance(tailwindKnots, factorPerKnot float64) float64 {
+	if tailwindKnots <= 0 {
+		return lp.LandingDistanceMeters
+	}
+	return lp.LandingDistanceMeters * (1 + tailwindKnots*factorPerKnot)
+}
+
+// FeasibleLandingShare returns the fraction of landing movements (0-1) whose required
+// landing distance fits within runwayLengthMeters under the given tailwind component.
+//
+// Shares are normalised so they need not sum to 1. An empty fleet, or one whose shares
+// sum to zero, is treated as unconstrained and returns 1.
+func FeasibleLandingShare(fleet []LandingPerformance, runwayLengthMeters, tailwindKnots, factorPerKnot float64) float64 {
+	totalShare := 0.0
+	feasibleShare := 0.0
+
+	for _, lp := range fleet {
+		totalShare += lp.Share
+		if lp.RequiredLandingDistance(tailwindKnots, factorPerKnot) <= runwayLengthMeters {
+			feasibleShare += lp.Share
+		}
+	}
+
+	if totalShare <= 0 {
+		return 1
+	}
+	return feasibleShare / totalShare
+}
@@ -0,0 +1,66 @@
+package airport
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLandingPerformance_RequiredLandingDistance(t *testing.T) {
+	lp := LandingPerformance{Class: "Medium", Share: 1, LandingDistanceMeters: 2000}
+
+	tests := []struct {
+		name     string
+		tailwind float64
+		expected float64
+	}{
+		{"calm", 0, 2000},
+		{"headwind ignored", -10, 2000},
+		{"5kt tailwind", 5, 2500},
+		{"10kt tailwind", 10, 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lp.RequiredLandingDistance(tt.tailwind, DefaultTailwindDistanceFactorPerKnot)
+			if math.Abs(got-tt.expected) > 0.001 {
+				t.Errorf("Expected %.1fm, got %.1fm", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFeasibleLandingShare(t *testing.T) {
+	fleet := []LandingPerformance{
+		{Class: "Heavy", Share: 0.2, LandingDistanceMeters: 2500},
+		{Class: "Medium", Share: 0.6, LandingDistanceMeters: 1800},
+		{Class: "Light", Share: 0.2, LandingDistanceMeters: 900},
+	}
+
+	tests := []struct {
+		name     string
+		length   float64
+		tailwind float64
+		expected float64
+	}{
+		{"long runway calm", 3000, 0, 1.0},
+		{"long runway 5kt tailwind loses heavies", 3000, 5, 0.8},
+		{"short runway calm", 2000, 0, 0.8},
+		{"short runway 5kt tailwind", 2000, 5, 0.2},
+		{"very short runway", 500, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FeasibleLandingShare(fleet, tt.length, tt.tailwind, DefaultTailwindDistanceFactorPerKnot)
+			if math.Abs(got-tt.expected) > 0.001 {
+				t.Errorf("Expected share %.2f, got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFeasibleLandingShare_EmptyFleet(t *testing.T) {
+	if got := FeasibleLandingShare(nil, 1000, 10, DefaultTailwindDistanceFactorPerKnot); got != 1 {
+		t.Errorf("Expected empty fleet to be unconstrained, got %.2f", got)
+	}
+}
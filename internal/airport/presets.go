@@ -0,0 +1,195 @@
+package airport
+
+import (
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// presets maps each preset name to a function building a fresh copy of the airport, so
+// callers can modify the returned airport without affecting later calls.
+var presets = map[string]func() Airport{
+	"GA-single":        gaSinglePreset,
+	"LHR-like":         lhrLikePreset,
+	"LAX-like":         laxLikePreset,
+	"crossing-midsize": crossingMidsizePreset,
+}
+
+// Preset returns a ready-made airport configuration modelled on a real-world layout, so
+// comparisons can be run without describing an airport first. The available presets are:
+//
+//   - "GA-single": a general aviation field with one short asphalt runway
+//   - "LHR-like": two widely spaced parallel runways operated independently
+//   - "LAX-like": two pairs of closely spaced parallels with dependent approaches
+//   - "crossing-midsize": a mid-size airport with a main runway and a crossing runway
+//
+// Each call returns a new airport that can be modified freely.
+// Returns an error if no preset has the given name.
+func Preset(name string) (Airport, error) {
+	build, ok := presets[name]
+	if !ok {
+		return Airport{}, simerrors.Invalidf("unknown airport preset %q (available: %v)", name, Presets())
+	}
+	return build(), nil
+}
+
+// Presets returns the names of the available airport presets in sorted order.
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// gaSinglePreset is a general aviation field with a single short runway and no
+// precision approach.
+func gaSinglePreset() Airport {
+	return Airport{
+		Name: "General Aviation Field",
+		Runways: []Runway{
+			{
+				RunwayDesignation:   "06",
+				TrueBearing:         58.0,
+				LengthMeters:        1200.0,
+				WidthMeters:         23.0,
+				SurfaceType:         Asphalt,
+				ElevationMeters:     90.0,
+				CrosswindLimitKnots: 15.0, // Light aircraft
+				TailwindLimitKnots:  5.0,
+				MinimumSeparation:   90 * time.Second, // Visual circuit spacing
+				ApproachCategory:    NonPrecisionApproach,
+			},
+		},
+		Gates: 20,
+	}
+}
+
+// lhrLikePreset has two widely spaced parallel runways operated independently, in the
+// style of London Heathrow.
+func lhrLikePreset() Airport {
+	return Airport{
+		Name:    "LHR-like Hub",
+		City:    "London",
+		Country: "United Kingdom",
+		Runways: []Runway{
+			{
+				RunwayDesignation:   "09L",
+				TrueBearing:         89.7,
+				LengthMeters:        3902.0,
+				WidthMeters:         50.0,
+				SurfaceType:         Asphalt,
+				ElevationMeters:     25.0,
+				CrosswindLimitKnots: 35.0,
+				TailwindLimitKnots:  5.0, // Westerly preference
+				MinimumSeparation:   90 * time.Second,
+				ApproachCategory:    PrecisionCatIII,
+			},
+			{
+				RunwayDesignation:   "09R",
+				TrueBearing:         89.7,
+				LengthMeters:        3660.0,
+				WidthMeters:         50.0,
+				SurfaceType:         Asphalt,
+				ElevationMeters:     23.0,
+				CrosswindLimitKnots: 35.0,
+				TailwindLimitKnots:  5.0,
+				MinimumSeparation:   90 * time.Second,
+				ApproachCategory:    PrecisionCatIII,
+			},
+		},
+		// 1,400 m apart: the parallels operate independently
+		RunwayCompatibility: NewRunwayCompatibility(map[string][]string{
+			"09L": {"09R"},
+			"09R": {"09L"},
+		}),
+		Gates: 180,
+	}
+}
+
+// laxLikePreset has two pairs of closely spaced parallel runways, in the style of Los
+// Angeles International. Runways within a pair have dependent approaches.
+func laxLikePreset() Airport {
+	parallel := func(designation string, length, width float64) Runway {
+		return Runway{
+			RunwayDesignation:   designation,
+			TrueBearing:         83.0,
+			LengthMeters:        length,
+			WidthMeters:         width,
+			SurfaceType:         Concrete,
+			ElevationMeters:     38.0,
+			CrosswindLimitKnots: 35.0,
+			TailwindLimitKnots:  10.0,
+			MinimumSeparation:   75 * time.Second,
+			ApproachCategory:    PrecisionCatIII,
+		}
+	}
+
+	return Airport{
+		Name:    "LAX-like Hub",
+		City:    "Los Angeles",
+		Country: "United States",
+		Runways: []Runway{
+			parallel("06L", 2720.0, 45.0),
+			parallel("06R", 3135.0, 45.0),
+			parallel("07L", 3685.0, 61.0),
+			parallel("07R", 3382.0, 61.0),
+		},
+		RunwayCompatibility: &RunwayCompatibility{
+			CompatibleWith: map[string][]string{
+				"06L": {"06R", "07L", "07R"},
+				"06R": {"06L", "07L", "07R"},
+				"07L": {"06L", "06R", "07R"},
+				"07R": {"06L", "06R", "07L"},
+			},
+			// Each pair is about 200 m apart, so approaches to it must be staggered
+			CapacityInteractions: []CapacityInteraction{
+				{Runway: "06L", OtherRunway: "06R", Factor: 0.85},
+				{Runway: "07L", OtherRunway: "07R", Factor: 0.85},
+			},
+		},
+		Gates: 145,
+	}
+}
+
+// crossingMidsizePreset is a mid-size airport with a main runway and a shorter crossing
+// runway that cannot be used at the same time.
+func crossingMidsizePreset() Airport {
+	return Airport{
+		Name: "Mid-size Regional Airport",
+		Runways: []Runway{
+			{
+				RunwayDesignation:   "10",
+				TrueBearing:         102.0,
+				LengthMeters:        2800.0,
+				WidthMeters:         45.0,
+				SurfaceType:         Asphalt,
+				ElevationMeters:     60.0,
+				CrosswindLimitKnots: 33.0,
+				TailwindLimitKnots:  10.0,
+				MinimumSeparation:   75 * time.Second,
+				ApproachCategory:    PrecisionCatI,
+			},
+			{
+				RunwayDesignation:   "16",
+				TrueBearing:         158.0,
+				LengthMeters:        1900.0,
+				WidthMeters:         45.0,
+				SurfaceType:         Asphalt,
+				ElevationMeters:     58.0,
+				CrosswindLimitKnots: 25.0,
+				TailwindLimitKnots:  5.0,
+				MinimumSeparation:   80 * time.Second,
+				ApproachCategory:    NonPrecisionApproach,
+			},
+		},
+		// The runways intersect, so only one can be active
+		RunwayCompatibility: NewRunwayCompatibility(map[string][]string{
+			"10": {},
+			"16": {},
+		}),
+		Gates: 40,
+	}
+}
@@ -0,0 +1,62 @@
+package airport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestPreset(t *testing.T) {
+	expectedRunways := map[string]int{
+		"GA-single":        1,
+		"LHR-like":         2,
+		"LAX-like":         4,
+		"crossing-midsize": 2,
+	}
+
+	if len(Presets()) != len(expectedRunways) {
+		t.Errorf("Expected %d presets, got %v", len(expectedRunways), Presets())
+	}
+
+	for _, name := range Presets() {
+		t.Run(name, func(t *testing.T) {
+			ap, err := Preset(name)
+			if err != nil {
+				t.Fatalf("Preset failed: %v", err)
+			}
+			if err := ap.Validate(); err != nil {
+				t.Errorf("Expected a valid airport, got %v", err)
+			}
+			if len(ap.Runways) != expectedRunways[name] {
+				t.Errorf("Expected %d runways, got %d", expectedRunways[name], len(ap.Runways))
+			}
+		})
+	}
+}
+
+func TestPreset_ReturnsCopy(t *testing.T) {
+	first, err := Preset("LHR-like")
+	if err != nil {
+		t.Fatalf("Preset failed: %v", err)
+	}
+	first.Runways[0].LengthMeters = 1000
+	first.RunwayCompatibility.CompatibleWith["09L"] = nil
+
+	second, err := Preset("LHR-like")
+	if err != nil {
+		t.Fatalf("Preset failed: %v", err)
+	}
+	if second.Runways[0].LengthMeters == 1000 {
+		t.Error("Expected modifying a preset not to affect later calls")
+	}
+	if len(second.RunwayCompatibility.CompatibleWith["09L"]) != 1 {
+		t.Error("Expected modifying a preset's compatibility not to affect later calls")
+	}
+}
+
+func TestPreset_Unknown(t *testing.T) {
+	if _, err := Preset("JFK-like"); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
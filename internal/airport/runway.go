@@ -1,6 +1,14 @@
 package airport
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
 
 // SurfaceType represents the type of surface of the runway.
 type SurfaceType int
@@ -10,18 +18,258 @@ const (
 	Concrete
 	Grass
 	Dirt
+	// Water represents a seaplane operating area whose availability may depend on tide
+	Water
 )
 
+// String returns the string representation of the surface type.
+func (st SurfaceType) String() string {
+	switch st {
+	case Asphalt:
+		return "Asphalt"
+	case Concrete:
+		return "Concrete"
+	case Grass:
+		return "Grass"
+	case Dirt:
+		return "Dirt"
+	case Water:
+		return "Water"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsWater reports whether the surface is a water operating area.
+func (st SurfaceType) IsWater() bool {
+	return st == Water
+}
+
+// ApproachCategory represents the most capable approach procedure available to a runway.
+type ApproachCategory int
+
+const (
+	// VisualApproach means only visual approaches are available (VFR)
+	VisualApproach ApproachCategory = iota
+	// NonPrecisionApproach means lateral-only guidance (e.g., RNAV, VOR)
+	NonPrecisionApproach
+	// PrecisionCatI means an ILS/GLS Category I approach is available
+	PrecisionCatI
+	// PrecisionCatII means an ILS Category II approach is available
+	PrecisionCatII
+	// PrecisionCatIII means an ILS Category III approach is available
+	PrecisionCatIII
+)
+
+// String returns the string representation of the approach category.
+func (ac ApproachCategory) String() string {
+	switch ac {
+	case VisualApproach:
+		return "Visual"
+	case NonPrecisionApproach:
+		return "NonPrecision"
+	case PrecisionCatI:
+		return "CatI"
+	case PrecisionCatII:
+		return "CatII"
+	case PrecisionCatIII:
+		return "CatIII"
+	default:
+		return "Unknown"
+	}
+}
+
 // Runway represents a physical runway with all operational parameters.
 type Runway struct {
-	RunwayDesignation  string        // Runway designation (e.g., "09L", "27R")
-	TrueBearing        float64       // True bearing of the runway in degrees
-	LengthMeters       float64       // Length of the runway in meters
-	WidthMeters        float64       // Width of the runway in WidthMeters
-	SurfaceType        SurfaceType   // Surface type of the runway (e.g., "Asphalt", "Concrete", "Grass")
-	ElevationMeters    float64       // Elevation of the runway above sea level in meters
-	GradientPercent    float64       // Gradient of the runway in percent
-	CrosswindLimitKnots float64       // Maximum crosswind component in knots (0 = no limit)
-	TailwindLimitKnots  float64       // Maximum tailwind component in knots (0 = no limit)
-	MinimumSeparation  time.Duration // Minimum separation time between incoming flights
+	RunwayDesignation          string           // Runway designation (e.g., "09L", "27R")
+	TrueBearing                float64          // True bearing of the runway in degrees
+	LengthMeters               float64          // Length of the runway in meters
+	WidthMeters                float64          // Width of the runway in WidthMeters
+	SurfaceType                SurfaceType      // Surface type of the runway (e.g., "Asphalt", "Concrete", "Grass", "Water")
+	ElevationMeters            float64          // Elevation of the runway above sea level in meters
+	GradientPercent            float64          // Gradient of the runway in percent
+	CrosswindLimitKnots        float64          // Maximum crosswind component in knots (0 = no limit)
+	TailwindLimitKnots         float64          // Maximum tailwind component in knots (0 = no limit)
+	ReverseCrosswindLimitKnots float64          // Maximum crosswind operating on the reciprocal end (0 = same as CrosswindLimitKnots)
+	ReverseTailwindLimitKnots  float64          // Maximum tailwind operating on the reciprocal end (0 = same as TailwindLimitKnots)
+	MinimumSeparation          time.Duration    // Minimum separation time between movements (default for the separations below)
+	ArrivalSeparation          time.Duration    // Separation between successive arrivals on a landing-only runway (0 = MinimumSeparation)
+	DepartureSeparation        time.Duration    // Separation between successive departures on a takeoff-only runway (0 = MinimumSeparation)
+	MixedSeparation            time.Duration    // Separation between alternating arrivals and departures in mixed mode (0 = MinimumSeparation)
+	ApproachCategory           ApproachCategory // Most capable approach procedure available (default: Visual)
+	SurfaceCondition           SurfaceCondition // Current surface condition (default: Dry)
+	Ends                       [2]RunwayEnd     // Both runway ends, designated end first (zero = derived from the fields above; see End)
+}
+
+// SeparationMode identifies the sequence of movements a runway's separation applies to.
+type SeparationMode int
+
+const (
+	// MixedMode is alternating arrivals and departures on a runway handling both
+	MixedMode SeparationMode = iota
+	// ArrivalMode is successive arrivals on a runway handling only landings
+	ArrivalMode
+	// DepartureMode is successive departures on a runway handling only takeoffs
+	DepartureMode
+)
+
+// String returns the string representation of the separation mode.
+func (m SeparationMode) String() string {
+	switch m {
+	case MixedMode:
+		return "Mixed"
+	case ArrivalMode:
+		return "Arrival"
+	case DepartureMode:
+		return "Departure"
+	default:
+		return "Unknown"
+	}
+}
+
+// Separation returns the runway's minimum separation in the given mode: ArrivalSeparation,
+// DepartureSeparation or MixedSeparation, or MinimumSeparation if that is not set.
+func (r Runway) Separation(mode SeparationMode) time.Duration {
+	separation := r.MixedSeparation
+	switch mode {
+	case ArrivalMode:
+		separation = r.ArrivalSeparation
+	case DepartureMode:
+		separation = r.DepartureSeparation
+	}
+	if separation > 0 {
+		return separation
+	}
+	return r.MinimumSeparation
+}
+
+// RunwayModification describes a change to a runway's characteristics, such as a length
+// extension or an approach procedure upgrade. Zero-valued fields leave the runway unchanged.
+type RunwayModification struct {
+	LengthMeters      float64           // New runway length (0 = unchanged)
+	ApproachCategory  *ApproachCategory // New approach category (nil = unchanged)
+	MinimumSeparation time.Duration     // New minimum separation (0 = unchanged)
+	SurfaceCondition  *SurfaceCondition // New surface condition (nil = unchanged)
+
+	// New wind limits for operations from either end, e.g. after a fleet upgrade (0 = unchanged)
+	CrosswindLimitKnots float64
+	TailwindLimitKnots  float64
+}
+
+// IsEmpty reports whether the modification changes nothing.
+func (m RunwayModification) IsEmpty() bool {
+	return m.LengthMeters == 0 && m.ApproachCategory == nil && m.MinimumSeparation == 0 && m.SurfaceCondition == nil &&
+		m.CrosswindLimitKnots == 0 && m.TailwindLimitKnots == 0
+}
+
+// Apply returns a copy of the runway with the modification applied.
+func (m RunwayModification) Apply(r Runway) Runway {
+	if m.LengthMeters > 0 {
+		r.LengthMeters = m.LengthMeters
+	}
+	if m.ApproachCategory != nil {
+		r.ApproachCategory = *m.ApproachCategory
+	}
+	if m.MinimumSeparation > 0 {
+		r.MinimumSeparation = m.MinimumSeparation
+	}
+	if m.SurfaceCondition != nil {
+		r.SurfaceCondition = *m.SurfaceCondition
+	}
+	if m.CrosswindLimitKnots > 0 {
+		r.CrosswindLimitKnots, r.ReverseCrosswindLimitKnots = m.CrosswindLimitKnots, m.CrosswindLimitKnots
+		if r.HasExplicitEnds() {
+			r.Ends[0].CrosswindLimitKnots, r.Ends[1].CrosswindLimitKnots = m.CrosswindLimitKnots, m.CrosswindLimitKnots
+		}
+	}
+	if m.TailwindLimitKnots > 0 {
+		r.TailwindLimitKnots, r.ReverseTailwindLimitKnots = m.TailwindLimitKnots, m.TailwindLimitKnots
+		if r.HasExplicitEnds() {
+			r.Ends[0].TailwindLimitKnots, r.Ends[1].TailwindLimitKnots = m.TailwindLimitKnots, m.TailwindLimitKnots
+		}
+	}
+	return r
+}
+
+// Validate checks that the runway has a designation, a true bearing in [0, 360),
+// a positive minimum separation, non-negative mode separations, dimensions and wind limits, and valid
+// runway ends if they are given explicitly.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (r Runway) Validate() error {
+	var problems []error
+
+	if r.RunwayDesignation == "" {
+		problems = append(problems, simerrors.Invalidf("runway designation cannot be empty"))
+	}
+	if r.TrueBearing < 0 || r.TrueBearing >= 360 {
+		problems = append(problems, simerrors.Invalidf("runway %s: true bearing must be in [0, 360), got %.1f", r.RunwayDesignation, r.TrueBearing))
+	}
+	if r.MinimumSeparation <= 0 {
+		problems = append(problems, simerrors.Invalidf("runway %s: minimum separation must be positive, got %v", r.RunwayDesignation, r.MinimumSeparation))
+	}
+	if r.ArrivalSeparation < 0 || r.DepartureSeparation < 0 || r.MixedSeparation < 0 {
+		problems = append(problems, simerrors.Invalidf("runway %s: arrival, departure and mixed separations cannot be negative", r.RunwayDesignation))
+	}
+	if r.LengthMeters < 0 || r.WidthMeters < 0 {
+		problems = append(problems, simerrors.Invalidf("runway %s: dimensions cannot be negative", r.RunwayDesignation))
+	}
+	if r.CrosswindLimitKnots < 0 || r.TailwindLimitKnots < 0 || r.ReverseCrosswindLimitKnots < 0 || r.ReverseTailwindLimitKnots < 0 {
+		problems = append(problems, simerrors.Invalidf("runway %s: wind limits cannot be negative", r.RunwayDesignation))
+	}
+	problems = append(problems, r.endProblems()...)
+
+	return errors.Join(problems...)
+}
+
+// Runway length corrections (ICAO Aerodrome Design Manual rule of thumb)
+const (
+	ElevationLengthCorrectionPer300m   = 0.07 // Required length increases 7% per 300 m of elevation
+	GradientLengthCorrectionPerPercent = 0.10 // Required length increases 10% per 1% of gradient
+)
+
+// CorrectedLengthMeters returns the runway length equivalent to a sea-level, level runway:
+// the physical length reduced by the elevation and gradient corrections, so it can be compared
+// directly with sea-level aircraft length requirements. Downhill gradients are treated like
+// uphill ones since runways are used in both directions.
+func (r Runway) CorrectedLengthMeters() float64 {
+	return r.DensityCorrectedLengthMeters(ISATemperatureCelsius(r.ElevationMeters))
+}
+
+// DensityCorrectedLengthMeters is like CorrectedLengthMeters, but corrects for the density
+// altitude at the given outside air temperature instead of the runway's elevation, so hot
+// days shorten the equivalent length further.
+func (r Runway) DensityCorrectedLengthMeters(temperatureCelsius float64) float64 {
+	densityAltitude := DensityAltitudeMeters(r.ElevationMeters, temperatureCelsius)
+	elevationFactor := 1 + ElevationLengthCorrectionPer300m*max(densityAltitude, 0)/300
+	gradientFactor := 1 + GradientLengthCorrectionPerPercent*math.Abs(r.GradientPercent)
+	return r.LengthMeters / (elevationFactor * gradientFactor)
+}
+
+// ReciprocalDesignation returns the designation of the opposite end of a runway
+// (e.g., "09L" -> "27R", "18" -> "36", "04C" -> "22C"). Designations that do not start
+// with a runway number are returned unchanged.
+func ReciprocalDesignation(designation string) string {
+	digits := 0
+	for digits < len(designation) && digits < 2 && designation[digits] >= '0' && designation[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 {
+		return designation
+	}
+
+	number, err := strconv.Atoi(designation[:digits])
+	if err != nil {
+		return designation
+	}
+	reciprocal := (number+18-1)%36 + 1
+
+	suffix := designation[digits:]
+	switch suffix {
+	case "L":
+		suffix = "R"
+	case "R":
+		suffix = "L"
+	}
+
+	return fmt.Sprintf("%02d%s", reciprocal, suffix)
 }
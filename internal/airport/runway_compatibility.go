@@ -2,8 +2,12 @@ package airport
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 )
 
 // RunwayCompatibility defines which runways can operate simultaneously.
@@ -25,6 +29,90 @@ type RunwayCompatibility struct {
 	// CompatibleWith maps each runway designation to a list of runways
 	// it can operate with simultaneously.
 	CompatibleWith map[string][]string
+
+	// AirspaceConflicts lists operations on runway ends that cannot run simultaneously
+	// in terminal airspace even though the runways are compatible on the ground.
+	AirspaceConflicts []AirspaceConflict
+
+	// CapacityInteractions lists compatible runways that lose capacity when operating
+	// together, rather than adding their capacities perfectly.
+	CapacityInteractions []CapacityInteraction
+
+	// Hours limits compatible runways to operating together during part of each day
+	// (e.g., land-and-hold-short operations permitted only in daylight).
+	Hours []CompatibilityHours
+}
+
+// CompatibilityHours declares that two compatible runways may only operate together between
+// two local times of day (see Airport.Location); outside them the runways are incompatible.
+// A runway pair with several entries is compatible while any of them permits it.
+type CompatibilityHours struct {
+	Runway      string        // Runway designation (e.g., "09")
+	OtherRunway string        // Other runway designation (e.g., "18")
+	From        time.Duration // Time of day after local midnight the runways become compatible (e.g., 6h)
+	Until       time.Duration // Time of day they stop being compatible (e.g., 20h); before From wraps past midnight
+}
+
+// Permits reports whether the runways may operate together at the given time of day after
+// local midnight.
+func (h CompatibilityHours) Permits(timeOfDay time.Duration) bool {
+	if h.From <= h.Until {
+		return timeOfDay >= h.From && timeOfDay < h.Until
+	}
+	return timeOfDay >= h.From || timeOfDay < h.Until
+}
+
+// pair returns the runway designations of h in sorted order.
+func (h CompatibilityHours) pair() [2]string {
+	if h.Runway > h.OtherRunway {
+		return [2]string{h.OtherRunway, h.Runway}
+	}
+	return [2]string{h.Runway, h.OtherRunway}
+}
+
+// CapacityInteraction declares that two runways operating together handle less than the sum
+// of their capacities (e.g., closely spaced parallels whose approaches are dependent, needing
+// staggered arrivals). While both are active, each runway's capacity is multiplied by Factor,
+// so their combined capacity is the sum times Factor. A runway in several active pairs is
+// de-rated by each of their factors.
+type CapacityInteraction struct {
+	Runway      string  // Runway designation (e.g., "09L")
+	OtherRunway string  // Other runway designation (e.g., "09R")
+	Factor      float64 // Fraction (0, 1] of the pair's combined capacity retained (e.g., 0.85)
+}
+
+// RunwayOperation identifies the kind of movement a runway end handles.
+type RunwayOperation int
+
+const (
+	// Arrivals covers landings on the runway end, including their missed approach paths
+	Arrivals RunwayOperation = iota
+
+	// Departures covers takeoffs from the runway end and their initial departure routes
+	Departures
+)
+
+// String returns the string representation of the runway operation.
+func (o RunwayOperation) String() string {
+	switch o {
+	case Arrivals:
+		return "Arrivals"
+	case Departures:
+		return "Departures"
+	default:
+		return "Unknown"
+	}
+}
+
+// AirspaceConflict declares that an operation on one runway end conflicts in terminal
+// airspace with an operation on another runway end (e.g., the missed approach of 09L
+// crosses the departure route from 18). Runway ends are identified by the designation
+// in use, so "27R" refers to runway 09L operating in reverse. Conflicts are symmetric.
+type AirspaceConflict struct {
+	RunwayEnd            string          // Runway end designation (e.g., "09L")
+	Operation            RunwayOperation // Operation on RunwayEnd that conflicts
+	ConflictingRunwayEnd string          // Other runway end designation (e.g., "18")
+	ConflictingOperation RunwayOperation // Operation on ConflictingRunwayEnd that conflicts
 }
 
 // NewRunwayCompatibility creates a new RunwayCompatibility instance.
@@ -39,12 +127,37 @@ func NewRunwayCompatibility(compatibleWith map[string][]string) *RunwayCompatibi
 //  1. Symmetry: If runway A is compatible with B, then B must be compatible with A
 //  2. No invalid references: All referenced runways must exist in the airport's runway list
 //  3. Self-loops are ignored (a runway is implicitly compatible with itself)
+//  4. Airspace conflicts reference runway ends of runways in the airport's runway list
+//  5. Capacity interactions pair two different known runways, at most once, with a factor in (0, 1]
+//  6. Compatibility hours restrict compatible runway pairs of an explicit graph to times within a day
 //
 // Returns a descriptive error if validation fails, nil otherwise.
 func (rc *RunwayCompatibility) Validate(runwayIDs []string) error {
-	if rc == nil || rc.CompatibleWith == nil {
+	runwayEnds := make([]string, 0, 2*len(runwayIDs))
+	for _, id := range runwayIDs {
+		runwayEnds = append(runwayEnds, id, ReciprocalDesignation(id))
+	}
+	return rc.validate(runwayIDs, runwayEnds)
+}
+
+// validate is Validate with the designations of every runway end given explicitly, for
+// runways whose ends are not named by the reciprocal designation (see Runway.End).
+func (rc *RunwayCompatibility) validate(runwayIDs, runwayEnds []string) error {
+	if rc == nil {
 		return nil // nil compatibility is valid (means all runways compatible)
 	}
+	if err := rc.validateAirspaceConflicts(runwayEnds); err != nil {
+		return err
+	}
+	if err := rc.validateCapacityInteractions(runwayIDs); err != nil {
+		return err
+	}
+	if err := rc.validateHours(); err != nil {
+		return err
+	}
+	if rc.CompatibleWith == nil {
+		return nil // nil graph means all runways compatible on the ground
+	}
 
 	// Build a set of valid runway IDs for quick lookup
 	validRunways := make(map[string]bool)
@@ -56,7 +169,7 @@ func (rc *RunwayCompatibility) Validate(runwayIDs []string) error {
 	for runwayID, compatibleList := range rc.CompatibleWith {
 		// Check that the runway itself exists
 		if !validRunways[runwayID] {
-			return fmt.Errorf("compatibility graph references non-existent runway: %s", runwayID)
+			return simerrors.Invalidf("compatibility graph references non-existent runway: %s", runwayID)
 		}
 
 		// Check each runway in the compatible list
@@ -68,14 +181,14 @@ func (rc *RunwayCompatibility) Validate(runwayIDs []string) error {
 
 			// Check that referenced runway exists
 			if !validRunways[compatibleID] {
-				return fmt.Errorf("runway %s references non-existent compatible runway: %s",
+				return simerrors.Invalidf("runway %s references non-existent compatible runway: %s",
 					runwayID, compatibleID)
 			}
 
 			// Check symmetry: if A -> B, then B -> A must exist
 			reverseList, exists := rc.CompatibleWith[compatibleID]
 			if !exists {
-				return fmt.Errorf("asymmetric compatibility: %s lists %s as compatible, but %s has no compatibility list",
+				return simerrors.Invalidf("asymmetric compatibility: %s lists %s as compatible, but %s has no compatibility list",
 					runwayID, compatibleID, compatibleID)
 			}
 
@@ -89,7 +202,7 @@ func (rc *RunwayCompatibility) Validate(runwayIDs []string) error {
 			}
 
 			if !reverseExists {
-				return fmt.Errorf("asymmetric compatibility: %s lists %s as compatible, but %s does not list %s",
+				return simerrors.Invalidf("asymmetric compatibility: %s lists %s as compatible, but %s does not list %s",
 					runwayID, compatibleID, compatibleID, runwayID)
 			}
 		}
@@ -99,13 +212,160 @@ func (rc *RunwayCompatibility) Validate(runwayIDs []string) error {
 	// (even if their compatible list is empty)
 	for _, runwayID := range runwayIDs {
 		if _, exists := rc.CompatibleWith[runwayID]; !exists {
-			return fmt.Errorf("runway %s is not in the compatibility graph", runwayID)
+			return simerrors.Invalidf("runway %s is not in the compatibility graph", runwayID)
 		}
 	}
 
 	return nil
 }
 
+// validateAirspaceConflicts checks that every airspace conflict names one of the runway ends.
+func (rc *RunwayCompatibility) validateAirspaceConflicts(runwayEnds []string) error {
+	validEnds := make(map[string]bool, len(runwayEnds))
+	for _, end := range runwayEnds {
+		validEnds[end] = true
+	}
+
+	for _, conflict := range rc.AirspaceConflicts {
+		if !validEnds[conflict.RunwayEnd] {
+			return simerrors.Invalidf("airspace conflict references non-existent runway end: %s", conflict.RunwayEnd)
+		}
+		if !validEnds[conflict.ConflictingRunwayEnd] {
+			return simerrors.Invalidf("airspace conflict references non-existent runway end: %s", conflict.ConflictingRunwayEnd)
+		}
+	}
+
+	return nil
+}
+
+// validateCapacityInteractions checks that every capacity interaction pairs two different
+// known runways, that no pair is listed twice, and that every factor is in (0, 1].
+func (rc *RunwayCompatibility) validateCapacityInteractions(runwayIDs []string) error {
+	seen := make(map[[2]string]bool, len(rc.CapacityInteractions))
+	for _, interaction := range rc.CapacityInteractions {
+		for _, id := range []string{interaction.Runway, interaction.OtherRunway} {
+			if !slices.Contains(runwayIDs, id) {
+				return simerrors.Invalidf("capacity interaction references non-existent runway: %s", id)
+			}
+		}
+		if interaction.Runway == interaction.OtherRunway {
+			return simerrors.Invalidf("capacity interaction pairs runway %s with itself", interaction.Runway)
+		}
+		if interaction.Factor <= 0 || interaction.Factor > 1 {
+			return simerrors.Invalidf("capacity interaction factor for %s and %s must be in (0, 1], got %g",
+				interaction.Runway, interaction.OtherRunway, interaction.Factor)
+		}
+
+		pair := [2]string{interaction.Runway, interaction.OtherRunway}
+		if pair[0] > pair[1] {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+		if seen[pair] {
+			return simerrors.Invalidf("capacity interaction between %s and %s is listed more than once", pair[0], pair[1])
+		}
+		seen[pair] = true
+	}
+
+	return nil
+}
+
+// validateHours checks that compatibility hours are only given for an explicit graph, that each
+// restricts a compatible pair of different runways, and that its times fall within a day.
+func (rc *RunwayCompatibility) validateHours() error {
+	if len(rc.Hours) > 0 && rc.CompatibleWith == nil {
+		return simerrors.Invalidf("compatibility hours require a compatibility graph")
+	}
+
+	day := 24 * time.Hour
+	for _, hours := range rc.Hours {
+		if hours.Runway == hours.OtherRunway {
+			return simerrors.Invalidf("compatibility hours pair runway %s with itself", hours.Runway)
+		}
+		if !rc.IsCompatible(hours.Runway, hours.OtherRunway) {
+			return simerrors.Invalidf("compatibility hours given for incompatible runways %s and %s", hours.Runway, hours.OtherRunway)
+		}
+		if hours.From < 0 || hours.From >= day || hours.Until < 0 || hours.Until >= day {
+			return simerrors.Invalidf("compatibility hours for %s and %s must be times of day, got %v to %v",
+				hours.Runway, hours.OtherRunway, hours.From, hours.Until)
+		}
+		if hours.From == hours.Until {
+			return simerrors.Invalidf("compatibility hours for %s and %s are empty (%v to %v)",
+				hours.Runway, hours.OtherRunway, hours.From, hours.Until)
+		}
+	}
+
+	return nil
+}
+
+// HoursInEffect reports, for each entry of Hours, whether it permits its runways to operate
+// together at the given time of day after local midnight (nil if there are none).
+func (rc *RunwayCompatibility) HoursInEffect(timeOfDay time.Duration) []bool {
+	if rc == nil || len(rc.Hours) == 0 {
+		return nil
+	}
+	permitted := make([]bool, len(rc.Hours))
+	for i, hours := range rc.Hours {
+		permitted[i] = hours.Permits(timeOfDay)
+	}
+	return permitted
+}
+
+// At returns the compatibility graph in effect at the given time of day after local midnight:
+// runway pairs whose compatibility hours exclude it are incompatible. Returns rc itself when no
+// compatibility hours exclude it.
+func (rc *RunwayCompatibility) At(timeOfDay time.Duration) *RunwayCompatibility {
+	if rc == nil || len(rc.Hours) == 0 || rc.CompatibleWith == nil {
+		return rc
+	}
+
+	permitted := make(map[[2]string]bool, len(rc.Hours))
+	for _, hours := range rc.Hours {
+		permitted[hours.pair()] = permitted[hours.pair()] || hours.Permits(timeOfDay)
+	}
+
+	var effective *RunwayCompatibility
+	for pair, ok := range permitted {
+		if ok {
+			continue
+		}
+		if effective == nil {
+			effective = rc.Clone()
+		}
+		effective.CompatibleWith[pair[0]] = slices.DeleteFunc(effective.CompatibleWith[pair[0]], func(c string) bool { return c == pair[1] })
+		effective.CompatibleWith[pair[1]] = slices.DeleteFunc(effective.CompatibleWith[pair[1]], func(c string) bool { return c == pair[0] })
+	}
+	if effective == nil {
+		return rc
+	}
+	return effective
+}
+
+// InteractionFactor returns the factor a runway's capacity is multiplied by when operating
+// with the active runways: the product of the factors of its capacity interactions with them
+// (1 if there are none or rc is nil).
+func (rc *RunwayCompatibility) InteractionFactor(runwayID string, active []string) float64 {
+	if rc == nil {
+		return 1
+	}
+
+	factor := 1.0
+	for _, interaction := range rc.CapacityInteractions {
+		other := ""
+		switch runwayID {
+		case interaction.Runway:
+			other = interaction.OtherRunway
+		case interaction.OtherRunway:
+			other = interaction.Runway
+		default:
+			continue
+		}
+		if slices.Contains(active, other) {
+			factor *= interaction.Factor
+		}
+	}
+	return factor
+}
+
 // IsCompatible checks if two runways can operate simultaneously.
 // If compatibility is nil, returns true (all runways compatible).
 // Self-compatibility always returns true.
@@ -158,6 +418,56 @@ func (rc *RunwayCompatibility) GetCompatibleRunways(runwayID string, allRunways
 	return result
 }
 
+// Clone returns a deep copy of the compatibility graph (nil if rc is nil).
+func (rc *RunwayCompatibility) Clone() *RunwayCompatibility {
+	if rc == nil {
+		return nil
+	}
+
+	clone := &RunwayCompatibility{
+		AirspaceConflicts:    slices.Clone(rc.AirspaceConflicts),
+		CapacityInteractions: slices.Clone(rc.CapacityInteractions),
+		Hours:                slices.Clone(rc.Hours),
+	}
+	if rc.CompatibleWith != nil {
+		clone.CompatibleWith = make(map[string][]string, len(rc.CompatibleWith))
+		for runwayID, compatibleList := range rc.CompatibleWith {
+			clone.CompatibleWith[runwayID] = slices.Clone(compatibleList)
+		}
+	}
+	return clone
+}
+
+// WithRunway returns a copy of the compatibility graph in which runwayID is compatible with
+// exactly the runways in compatibleWith, updating the other runways' lists to keep the graph
+// symmetric. A nil receiver or graph (all runways compatible) is first expanded to an explicit
+// graph over allRunways. Airspace conflicts, capacity interactions and compatibility hours are
+// kept, except hours for pairs involving runwayID that are no longer compatible.
+func (rc *RunwayCompatibility) WithRunway(runwayID string, compatibleWith, allRunways []string) *RunwayCompatibility {
+	updated := rc.Clone()
+	if updated == nil {
+		updated = &RunwayCompatibility{}
+	}
+	if updated.CompatibleWith == nil {
+		updated.CompatibleWith = make(map[string][]string, len(allRunways))
+		for _, id := range allRunways {
+			updated.CompatibleWith[id] = rc.GetCompatibleRunways(id, allRunways)
+		}
+	}
+
+	for id, compatibleList := range updated.CompatibleWith {
+		updated.CompatibleWith[id] = slices.DeleteFunc(compatibleList, func(c string) bool { return c == runwayID })
+	}
+	updated.CompatibleWith[runwayID] = slices.DeleteFunc(slices.Clone(compatibleWith), func(c string) bool { return c == runwayID })
+	for _, id := range updated.CompatibleWith[runwayID] {
+		updated.CompatibleWith[id] = append(updated.CompatibleWith[id], runwayID)
+	}
+	updated.Hours = slices.DeleteFunc(updated.Hours, func(h CompatibilityHours) bool {
+		return !updated.IsCompatible(h.Runway, h.OtherRunway)
+	})
+	return updated
+}
+
 // String returns a human-readable representation of the compatibility graph.
 func (rc *RunwayCompatibility) String() string {
 	if rc == nil || rc.CompatibleWith == nil {
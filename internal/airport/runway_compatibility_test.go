@@ -1,8 +1,13 @@
 package airport
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 )
 
 func TestRunwayCompatibility_Validate_ValidConfiguration(t *testing.T) {
@@ -64,7 +69,7 @@ func TestRunwayCompatibility_Validate_AsymmetricMissingReverseEntry(t *testing.T
 	runwayIDs := []string{"09L", "09R", "18"}
 	compat := NewRunwayCompatibility(map[string][]string{
 		"09L": {"09R"},
-		"09R": {},    // Has list but doesn't include 09L
+		"09R": {}, // Has list but doesn't include 09L
 		"18":  {},
 	})
 
@@ -83,7 +88,7 @@ func TestRunwayCompatibility_Validate_NonExistentRunwayInGraph(t *testing.T) {
 	compat := NewRunwayCompatibility(map[string][]string{
 		"09L": {"09R"},
 		"09R": {"09L"},
-		"27":  {},    // Runway "27" doesn't exist in airport
+		"27":  {}, // Runway "27" doesn't exist in airport
 	})
 
 	err := compat.Validate(runwayIDs)
@@ -99,7 +104,7 @@ func TestRunwayCompatibility_Validate_NonExistentRunwayInCompatibleList(t *testi
 	// Test compatible list referencing non-existent runway
 	runwayIDs := []string{"09L", "09R"}
 	compat := NewRunwayCompatibility(map[string][]string{
-		"09L": {"09R", "27"},  // "27" doesn't exist
+		"09L": {"09R", "27"}, // "27" doesn't exist
 		"09R": {"09L"},
 	})
 
@@ -134,7 +139,7 @@ func TestRunwayCompatibility_Validate_SelfLoop(t *testing.T) {
 	// Self-loops should be ignored (not cause errors)
 	runwayIDs := []string{"09L", "09R"}
 	compat := NewRunwayCompatibility(map[string][]string{
-		"09L": {"09L", "09R"},  // Self-loop
+		"09L": {"09L", "09R"}, // Self-loop
 		"09R": {"09L"},
 	})
 
@@ -222,7 +227,7 @@ func TestRunwayCompatibility_GetCompatibleRunways_EmptyList(t *testing.T) {
 	compat := NewRunwayCompatibility(map[string][]string{
 		"09L": {"09R"},
 		"09R": {"09L"},
-		"18":  {},  // No compatible runways
+		"18":  {}, // No compatible runways
 	})
 
 	compatible := compat.GetCompatibleRunways("18", []string{"09L", "09R", "18"})
@@ -313,3 +318,167 @@ func TestRunwayCompatibility_String_WithRunways(t *testing.T) {
 		t.Error("String should contain 18")
 	}
 }
+
+func TestRunwayCompatibility_Validate_AirspaceConflicts(t *testing.T) {
+	tests := []struct {
+		name        string
+		conflict    AirspaceConflict
+		expectError bool
+	}{
+		{"known runway ends", AirspaceConflict{RunwayEnd: "09L", Operation: Arrivals, ConflictingRunwayEnd: "18", ConflictingOperation: Departures}, false},
+		{"reciprocal runway end", AirspaceConflict{RunwayEnd: "27R", Operation: Departures, ConflictingRunwayEnd: "36", ConflictingOperation: Arrivals}, false},
+		{"unknown runway end", AirspaceConflict{RunwayEnd: "09L", Operation: Arrivals, ConflictingRunwayEnd: "04", ConflictingOperation: Departures}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &RunwayCompatibility{AirspaceConflicts: []AirspaceConflict{tt.conflict}}
+			err := rc.Validate([]string{"09L", "18"})
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunwayCompatibility_Validate_CapacityInteractions(t *testing.T) {
+	tests := []struct {
+		name         string
+		interactions []CapacityInteraction
+		expectError  bool
+	}{
+		{"dependent parallels", []CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0.85}}, false},
+		{"unknown runway", []CapacityInteraction{{Runway: "09L", OtherRunway: "04", Factor: 0.85}}, true},
+		{"same runway", []CapacityInteraction{{Runway: "09L", OtherRunway: "09L", Factor: 0.85}}, true},
+		{"zero factor", []CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0}}, true},
+		{"factor above one", []CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 1.2}}, true},
+		{"duplicate pair", []CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0.85}, {Runway: "09R", OtherRunway: "09L", Factor: 0.9}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &RunwayCompatibility{CapacityInteractions: tt.interactions}
+			err := rc.Validate([]string{"09L", "09R", "18"})
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunwayCompatibility_InteractionFactor(t *testing.T) {
+	rc := &RunwayCompatibility{CapacityInteractions: []CapacityInteraction{
+		{Runway: "09L", OtherRunway: "09R", Factor: 0.85},
+		{Runway: "09R", OtherRunway: "09C", Factor: 0.9},
+	}}
+
+	tests := []struct {
+		runway string
+		active []string
+		want   float64
+	}{
+		{"09L", []string{"09L"}, 1},
+		{"09L", []string{"09L", "09R"}, 0.85},
+		{"09R", []string{"09L", "09R"}, 0.85},
+		{"09R", []string{"09L", "09R", "09C"}, 0.85 * 0.9},
+		{"09C", []string{"09L", "09C"}, 1},
+	}
+	for _, tt := range tests {
+		if got := rc.InteractionFactor(tt.runway, tt.active); got != tt.want {
+			t.Errorf("InteractionFactor(%s, %v) = %g, want %g", tt.runway, tt.active, got, tt.want)
+		}
+	}
+
+	var none *RunwayCompatibility
+	if got := none.InteractionFactor("09L", []string{"09L", "09R"}); got != 1 {
+		t.Errorf("Expected factor 1 without a compatibility graph, got %g", got)
+	}
+}
+
+func TestRunwayCompatibility_WithRunway(t *testing.T) {
+	runwayIDs := []string{"09L", "09R", "18"}
+	compat := NewRunwayCompatibility(map[string][]string{
+		"09L": {"09R"},
+		"09R": {"09L"},
+		"18":  {},
+	})
+
+	// 18 rebuilt clear of 09R
+	updated := compat.WithRunway("18", []string{"09R"}, runwayIDs)
+	if err := updated.Validate(runwayIDs); err != nil {
+		t.Fatalf("Expected updated graph to be valid, got %v", err)
+	}
+	if !updated.IsCompatible("18", "09R") || updated.IsCompatible("18", "09L") {
+		t.Errorf("Expected 18 compatible with 09R only, got %v", updated)
+	}
+	if compat.IsCompatible("18", "09R") {
+		t.Error("Expected the original graph to be unchanged")
+	}
+
+	// A nil graph (all compatible) is expanded before 18 is restricted
+	var all *RunwayCompatibility
+	restricted := all.WithRunway("18", nil, runwayIDs)
+	if err := restricted.Validate(runwayIDs); err != nil {
+		t.Fatalf("Expected expanded graph to be valid, got %v", err)
+	}
+	if !restricted.IsCompatible("09L", "09R") || restricted.IsCompatible("18", "09L") {
+		t.Errorf("Expected 09L/09R compatible and 18 alone, got %v", restricted)
+	}
+}
+
+func TestRunwayCompatibility_Hours(t *testing.T) {
+	runwayIDs := []string{"09", "18", "04"}
+	graph := map[string][]string{"09": {"18"}, "18": {"09"}, "04": {}}
+	daylight := CompatibilityHours{Runway: "09", OtherRunway: "18", From: 6 * time.Hour, Until: 20 * time.Hour}
+
+	rc := &RunwayCompatibility{CompatibleWith: graph, Hours: []CompatibilityHours{daylight}}
+	if err := rc.Validate(runwayIDs); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := rc.At(12 * time.Hour); got != rc {
+		t.Error("Expected the graph itself within the hours")
+	}
+	if !rc.At(12*time.Hour).IsCompatible("09", "18") || rc.At(22*time.Hour).IsCompatible("09", "18") {
+		t.Error("Expected 09 and 18 compatible only from 06:00 to 20:00")
+	}
+	if !rc.IsCompatible("09", "18") {
+		t.Error("Expected At to leave the graph unchanged")
+	}
+
+	overnight := CompatibilityHours{Runway: "09", OtherRunway: "18", From: 22 * time.Hour, Until: 2 * time.Hour}
+	for timeOfDay, want := range map[time.Duration]bool{23 * time.Hour: true, time.Hour: true, 2 * time.Hour: false, 12 * time.Hour: false} {
+		if got := overnight.Permits(timeOfDay); got != want {
+			t.Errorf("Permits(%v) = %v, want %v", timeOfDay, got, want)
+		}
+	}
+	both := &RunwayCompatibility{CompatibleWith: graph, Hours: []CompatibilityHours{daylight, overnight}}
+	if got := both.HoursInEffect(23 * time.Hour); !slices.Equal(got, []bool{false, true}) || !both.At(23*time.Hour).IsCompatible("09", "18") {
+		t.Errorf("Expected the pair compatible while either entry permits it, got %v", got)
+	}
+
+	invalid := []struct {
+		name string
+		rc   *RunwayCompatibility
+	}{
+		{"no graph", &RunwayCompatibility{Hours: []CompatibilityHours{daylight}}},
+		{"incompatible pair", &RunwayCompatibility{CompatibleWith: graph, Hours: []CompatibilityHours{{Runway: "09", OtherRunway: "04", From: 6 * time.Hour, Until: 20 * time.Hour}}}},
+		{"beyond a day", &RunwayCompatibility{CompatibleWith: graph, Hours: []CompatibilityHours{{Runway: "09", OtherRunway: "18", From: 6 * time.Hour, Until: 26 * time.Hour}}}},
+		{"empty", &RunwayCompatibility{CompatibleWith: graph, Hours: []CompatibilityHours{{Runway: "09", OtherRunway: "18", From: 6 * time.Hour, Until: 6 * time.Hour}}}},
+	}
+	for _, tt := range invalid {
+		if err := tt.rc.Validate(runwayIDs); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected invalid configuration error, got %v", tt.name, err)
+		}
+	}
+
+	// Hours for a pair that is no longer compatible are dropped
+	if updated := rc.WithRunway("18", nil, runwayIDs); len(updated.Hours) != 0 {
+		t.Errorf("Expected the hours to be dropped with the pair, got %v", updated.Hours)
+	}
+}
@@ -0,0 +1,105 @@
+package airport
+
+import "github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+
+// RunwayEnd is one end of a runway: the threshold aircraft land on and take off from when
+// operating in its direction (e.g., "27R" of runway 09L/27R).
+type RunwayEnd struct {
+	Designation              string  // Runway end designation (e.g., "27R")
+	TrueBearing              float64 // True bearing of operations from this end in degrees
+	CrosswindLimitKnots      float64 // Maximum crosswind operating on this end (0 = the runway's limit)
+	TailwindLimitKnots       float64 // Maximum tailwind operating on this end (0 = the runway's limit)
+	DisplacedThresholdMeters float64 // Runway length before the landing threshold, unavailable for landing
+}
+
+// End returns the runway end in use when operating the runway in the designated direction, or
+// in the reciprocal direction when reverse is set. Without explicit Ends, the designated end is
+// the runway itself and the reciprocal end has the reciprocal designation and bearing and the
+// Reverse wind limits.
+func (r Runway) End(reverse bool) RunwayEnd {
+	if r.HasExplicitEnds() {
+		if reverse {
+			return r.Ends[1]
+		}
+		return r.Ends[0]
+	}
+
+	if !reverse {
+		return RunwayEnd{
+			Designation:         r.RunwayDesignation,
+			TrueBearing:         r.TrueBearing,
+			CrosswindLimitKnots: r.CrosswindLimitKnots,
+			TailwindLimitKnots:  r.TailwindLimitKnots,
+		}
+	}
+	bearing := r.TrueBearing + 180
+	if bearing >= 360 {
+		bearing -= 360
+	}
+	return RunwayEnd{
+		Designation:         ReciprocalDesignation(r.RunwayDesignation),
+		TrueBearing:         bearing,
+		CrosswindLimitKnots: r.ReverseCrosswindLimitKnots,
+		TailwindLimitKnots:  r.ReverseTailwindLimitKnots,
+	}
+}
+
+// HasExplicitEnds reports whether the runway's ends are given in Ends rather than derived
+// from its own fields.
+func (r Runway) HasExplicitEnds() bool {
+	return r.Ends != [2]RunwayEnd{}
+}
+
+// EndDesignations returns the designations of both runway ends, designated end first.
+func (r Runway) EndDesignations() []string {
+	return []string{r.End(false).Designation, r.End(true).Designation}
+}
+
+// RunwayEndDesignations returns the designations of both ends of every runway at the airport.
+func (a Airport) RunwayEndDesignations() []string {
+	ends := make([]string, 0, 2*len(a.Runways))
+	for _, runway := range a.Runways {
+		ends = append(ends, runway.EndDesignations()...)
+	}
+	return ends
+}
+
+// HasEnd reports whether designation is either end of the runway.
+func (r Runway) HasEnd(designation string) bool {
+	return r.End(false).Designation == designation || r.End(true).Designation == designation
+}
+
+// LandingDistanceMeters returns the runway length available for landing on the given end:
+// the runway length less the end's displaced threshold.
+func (r Runway) LandingDistanceMeters(reverse bool) float64 {
+	return max(r.LengthMeters-r.End(reverse).DisplacedThresholdMeters, 0)
+}
+
+// endProblems checks explicit runway ends: the first must be designated like the runway and
+// the second distinctly, with bearings in [0, 360), non-negative limits and displaced
+// thresholds within the runway length.
+func (r Runway) endProblems() []error {
+	if !r.HasExplicitEnds() {
+		return nil
+	}
+
+	var problems []error
+	if r.Ends[0].Designation != r.RunwayDesignation {
+		problems = append(problems, simerrors.Invalidf("runway %s: first runway end must be designated %s, got %q", r.RunwayDesignation, r.RunwayDesignation, r.Ends[0].Designation))
+	}
+	if r.Ends[1].Designation == "" || r.Ends[1].Designation == r.Ends[0].Designation {
+		problems = append(problems, simerrors.Invalidf("runway %s: reciprocal runway end needs its own designation, got %q", r.RunwayDesignation, r.Ends[1].Designation))
+	}
+	for _, end := range r.Ends {
+		if end.TrueBearing < 0 || end.TrueBearing >= 360 {
+			problems = append(problems, simerrors.Invalidf("runway end %s: true bearing must be in [0, 360), got %.1f", end.Designation, end.TrueBearing))
+		}
+		if end.CrosswindLimitKnots < 0 || end.TailwindLimitKnots < 0 {
+			problems = append(problems, simerrors.Invalidf("runway end %s: wind limits cannot be negative", end.Designation))
+		}
+		if end.DisplacedThresholdMeters < 0 || (r.LengthMeters > 0 && end.DisplacedThresholdMeters >= r.LengthMeters) {
+			problems = append(problems, simerrors.Invalidf("runway end %s: displaced threshold must be in [0, runway length), got %.0f m", end.Designation, end.DisplacedThresholdMeters))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,98 @@
+package airport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunway_DerivedEnds(t *testing.T) {
+	r := Runway{RunwayDesignation: "09L", TrueBearing: 270, CrosswindLimitKnots: 30, ReverseTailwindLimitKnots: 5}
+
+	if end := r.End(false); end.Designation != "09L" || end.TrueBearing != 270 || end.CrosswindLimitKnots != 30 {
+		t.Errorf("Unexpected designated end: %+v", end)
+	}
+	if end := r.End(true); end.Designation != "27R" || end.TrueBearing != 90 || end.TailwindLimitKnots != 5 {
+		t.Errorf("Unexpected reciprocal end: %+v", end)
+	}
+	if r.HasExplicitEnds() || !r.HasEnd("27R") || r.HasEnd("27L") {
+		t.Errorf("Unexpected ends %v", r.EndDesignations())
+	}
+}
+
+func TestRunway_ExplicitEnds(t *testing.T) {
+	// A runway whose ends are not 180 degrees apart in name or bearing
+	r := Runway{
+		RunwayDesignation:   "03",
+		TrueBearing:         32,
+		LengthMeters:        2500,
+		MinimumSeparation:   60 * time.Second,
+		CrosswindLimitKnots: 25,
+		TailwindLimitKnots:  10,
+		Ends: [2]RunwayEnd{
+			{Designation: "03", TrueBearing: 32},
+			{Designation: "20", TrueBearing: 205, TailwindLimitKnots: 5, DisplacedThresholdMeters: 400},
+		},
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := r.EndDesignations(); got[0] != "03" || got[1] != "20" {
+		t.Errorf("Expected ends 03 and 20, got %v", got)
+	}
+	if crosswind, tailwind := r.EndWindLimitsKnots(true); crosswind != 25 || tailwind != 5 {
+		t.Errorf("Expected 25 kt crosswind and 5 kt tailwind limits on 20, got %v and %v", crosswind, tailwind)
+	}
+	if r.LandingDistanceMeters(false) != 2500 || r.LandingDistanceMeters(true) != 2100 {
+		t.Errorf("Expected 2500 m and 2100 m landing distance, got %v and %v", r.LandingDistanceMeters(false), r.LandingDistanceMeters(true))
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *Runway)
+	}{
+		{"first end misnamed", func(r *Runway) { r.Ends[0].Designation = "21" }},
+		{"reciprocal end unnamed", func(r *Runway) { r.Ends[1].Designation = "" }},
+		{"same designation", func(r *Runway) { r.Ends[1].Designation = "03" }},
+		{"bearing out of range", func(r *Runway) { r.Ends[1].TrueBearing = 360 }},
+		{"negative limit", func(r *Runway) { r.Ends[0].CrosswindLimitKnots = -1 }},
+		{"threshold beyond runway", func(r *Runway) { r.Ends[1].DisplacedThresholdMeters = 2500 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runway := r
+			tt.modify(&runway)
+			if err := runway.Validate(); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestAirport_ValidateExplicitRunwayEnds(t *testing.T) {
+	compat := NewRunwayCompatibility(map[string][]string{"03": {}})
+	compat.AirspaceConflicts = []AirspaceConflict{
+		{RunwayEnd: "03", Operation: Arrivals, ConflictingRunwayEnd: "20", ConflictingOperation: Departures},
+	}
+	ap := Airport{
+		Name: "Test",
+		Runways: []Runway{{
+			RunwayDesignation: "03",
+			TrueBearing:       32,
+			MinimumSeparation: 60 * time.Second,
+			Ends:              [2]RunwayEnd{{Designation: "03", TrueBearing: 32}, {Designation: "20", TrueBearing: 205}},
+		}},
+		RunwayCompatibility: compat,
+	}
+
+	// Airspace conflicts may name explicit runway ends...
+	if err := ap.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// ...but not the reciprocal designation they replace
+	compat.AirspaceConflicts[0].ConflictingRunwayEnd = "21"
+	if err := ap.Validate(); err == nil {
+		t.Error("Expected error for the derived reciprocal designation")
+	}
+}
@@ -0,0 +1,182 @@
+package airport
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestReciprocalDesignation(t *testing.T) {
+	tests := []struct {
+		designation string
+		expected    string
+	}{
+		{"09L", "27R"},
+		{"27R", "09L"},
+		{"04C", "22C"},
+		{"18", "36"},
+		{"36", "18"},
+		{"01", "19"},
+		{"9", "27"},
+		{"W1", "W1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.designation, func(t *testing.T) {
+			if got := ReciprocalDesignation(tt.designation); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunway_CorrectedLengthMeters(t *testing.T) {
+	tests := []struct {
+		name     string
+		runway   Runway
+		expected float64
+	}{
+		{"sea level", Runway{LengthMeters: 3000}, 3000},
+		{"600 m elevation", Runway{LengthMeters: 3420, ElevationMeters: 600}, 3000},
+		{"downhill gradient", Runway{LengthMeters: 3300, GradientPercent: -1}, 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.runway.CorrectedLengthMeters(); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected %.1f, got %.1f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunway_Validate(t *testing.T) {
+	valid := Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+
+	tests := []struct {
+		name        string
+		modify      func(r *Runway)
+		expectError bool
+	}{
+		{"valid runway", func(r *Runway) {}, false},
+		{"north bearing", func(r *Runway) { r.TrueBearing = 0 }, false},
+		{"missing designation", func(r *Runway) { r.RunwayDesignation = "" }, true},
+		{"bearing of 360", func(r *Runway) { r.TrueBearing = 360 }, true},
+		{"negative bearing", func(r *Runway) { r.TrueBearing = -10 }, true},
+		{"zero separation", func(r *Runway) { r.MinimumSeparation = 0 }, true},
+		{"arrival separation only", func(r *Runway) { r.ArrivalSeparation = 90 * time.Second }, false},
+		{"negative departure separation", func(r *Runway) { r.DepartureSeparation = -time.Second }, true},
+		{"negative length", func(r *Runway) { r.LengthMeters = -1 }, true},
+		{"negative wind limit", func(r *Runway) { r.TailwindLimitKnots = -5 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runway := valid
+			tt.modify(&runway)
+			err := runway.Validate()
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunway_Separation(t *testing.T) {
+	runway := Runway{MinimumSeparation: 60 * time.Second, ArrivalSeparation: 90 * time.Second, DepartureSeparation: 75 * time.Second}
+	if got := runway.Separation(ArrivalMode); got != 90*time.Second {
+		t.Errorf("Expected the arrival separation, got %v", got)
+	}
+	if got := runway.Separation(DepartureMode); got != 75*time.Second {
+		t.Errorf("Expected the departure separation, got %v", got)
+	}
+	if got := runway.Separation(MixedMode); got != 60*time.Second {
+		t.Errorf("Expected the minimum separation without a mixed separation, got %v", got)
+	}
+
+	runway.MixedSeparation = 100 * time.Second
+	if got := runway.Separation(MixedMode); got != 100*time.Second {
+		t.Errorf("Expected the mixed separation, got %v", got)
+	}
+}
+
+func TestAirport_Validate_AggregatesProblems(t *testing.T) {
+	ap := Airport{
+		Runways: []Runway{
+			{RunwayDesignation: "09", TrueBearing: 400, MinimumSeparation: 0},
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: NewRunwayCompatibility(map[string][]string{"09": {"18"}}),
+	}
+
+	err := ap.Validate()
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined error, got %T", err)
+	}
+	// Bad bearing and separation on the first runway, duplicate designation, bad compatibility
+	if got := len(joined.Unwrap()); got != 4 {
+		t.Errorf("Expected 4 problems, got %d: %v", got, err)
+	}
+
+	if err := (Airport{}).Validate(); err == nil {
+		t.Error("Expected error for airport without runways")
+	}
+}
+
+func TestAirport_TimeZone(t *testing.T) {
+	runways := []Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}}
+
+	loc, err := Airport{Runways: runways}.TimeZone()
+	if loc != nil || err != nil {
+		t.Errorf("Expected no time zone without a location, got %v, %v", loc, err)
+	}
+
+	loc, err = Airport{Runways: runways, Location: "Europe/London"}.TimeZone()
+	if err != nil || loc.String() != "Europe/London" {
+		t.Errorf("Expected Europe/London, got %v, %v", loc, err)
+	}
+
+	ap := Airport{Runways: runways, Location: "Mars/Olympus_Mons"}
+	if _, err := ap.TimeZone(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an unknown location, got %v", err)
+	}
+	if err := ap.Validate(); err == nil {
+		t.Error("Expected an unknown location to fail validation")
+	}
+}
+
+func TestRunwayModification_WindLimits(t *testing.T) {
+	derived := Runway{RunwayDesignation: "09", TrueBearing: 90, CrosswindLimitKnots: 33, TailwindLimitKnots: 10, ReverseTailwindLimitKnots: 5}
+	explicit := Runway{RunwayDesignation: "09", TrueBearing: 90, Ends: [2]RunwayEnd{
+		{Designation: "09", TrueBearing: 90, CrosswindLimitKnots: 33},
+		{Designation: "27", TrueBearing: 270, CrosswindLimitKnots: 30, TailwindLimitKnots: 5},
+	}}
+
+	mod := RunwayModification{CrosswindLimitKnots: 38}
+	if mod.IsEmpty() {
+		t.Fatal("expected a wind limit modification not to be empty")
+	}
+	for name, runway := range map[string]Runway{"derived ends": derived, "explicit ends": explicit} {
+		modified := mod.Apply(runway)
+		for _, reverse := range []bool{false, true} {
+			before, after := runway.End(reverse), modified.End(reverse)
+			if after.CrosswindLimitKnots != 38 || after.TailwindLimitKnots != before.TailwindLimitKnots {
+				t.Errorf("%s: expected end %s to have a 38 kt crosswind limit and its tailwind limit unchanged, got %+v", name, after.Designation, after)
+			}
+		}
+	}
+
+	if end := (RunwayModification{TailwindLimitKnots: 15}).Apply(derived).End(true); end.TailwindLimitKnots != 15 || end.CrosswindLimitKnots != 0 {
+		t.Errorf("expected the reciprocal end to take the 15 kt tailwind limit, got %+v", end)
+	}
+}
@@ -0,0 +1,87 @@
+package airport
+
+// SurfaceCondition represents the current state of a runway surface.
+type SurfaceCondition int
+
+const (
+	// Dry is the default condition with no degradation
+	Dry SurfaceCondition = iota
+	// Wet means the surface is damp or covered by water up to 3 mm deep
+	Wet
+	// Contaminated means standing water, slush, snow or ice covers the surface
+	Contaminated
+)
+
+// String returns the string representation of the surface condition.
+func (c SurfaceCondition) String() string {
+	switch c {
+	case Dry:
+		return "Dry"
+	case Wet:
+		return "Wet"
+	case Contaminated:
+		return "Contaminated"
+	default:
+		return "Unknown"
+	}
+}
+
+// SurfaceConditionEffect describes how a surface condition degrades runway operations.
+type SurfaceConditionEffect struct {
+	CrosswindLimitFactor float64 // Multiplier applied to the runway's crosswind limit
+	TailwindLimitFactor  float64 // Multiplier applied to the runway's tailwind limit
+	SeparationFactor     float64 // Multiplier applied to runway separation and occupancy times
+}
+
+// Effect returns the degradation a surface condition causes on a runway of the given surface
+// type. Unpaved surfaces lose more braking action than paved ones when wet or contaminated,
+// and water operating areas are unaffected.
+func (c SurfaceCondition) Effect(surface SurfaceType) SurfaceConditionEffect {
+	unpaved := surface == Grass || surface == Dirt
+	switch {
+	case c == Wet && unpaved:
+		return SurfaceConditionEffect{CrosswindLimitFactor: 0.6, TailwindLimitFactor: 0.5, SeparationFactor: 1.25}
+	case c == Wet && !surface.IsWater():
+		return SurfaceConditionEffect{CrosswindLimitFactor: 0.75, TailwindLimitFactor: 0.5, SeparationFactor: 1.1}
+	case c == Contaminated && unpaved:
+		return SurfaceConditionEffect{CrosswindLimitFactor: 0.4, TailwindLimitFactor: 0.2, SeparationFactor: 1.5}
+	case c == Contaminated && !surface.IsWater():
+		return SurfaceConditionEffect{CrosswindLimitFactor: 0.5, TailwindLimitFactor: 0.2, SeparationFactor: 1.25}
+	default:
+		return SurfaceConditionEffect{CrosswindLimitFactor: 1, TailwindLimitFactor: 1, SeparationFactor: 1}
+	}
+}
+
+// WindLimitsKnots returns the runway's crosswind and tailwind limits reduced for its current
+// surface condition. A limit of 0 means no limit and is never reduced.
+func (r Runway) WindLimitsKnots() (crosswind, tailwind float64) {
+	effect := r.SurfaceCondition.Effect(r.SurfaceType)
+	return r.CrosswindLimitKnots * effect.CrosswindLimitFactor, r.TailwindLimitKnots * effect.TailwindLimitFactor
+}
+
+// EndWindLimitsKnots is like WindLimitsKnots, but for operations on one runway end (see End):
+// the designated end, or the reciprocal end when reverse is set, whose own limits (if any)
+// apply instead (e.g., 27 limited to less tailwind than 09 by a displaced threshold).
+func (r Runway) EndWindLimitsKnots(reverse bool) (crosswind, tailwind float64) {
+	end := r.End(reverse)
+	if end.CrosswindLimitKnots > 0 {
+		r.CrosswindLimitKnots = end.CrosswindLimitKnots
+	}
+	if end.TailwindLimitKnots > 0 {
+		r.TailwindLimitKnots = end.TailwindLimitKnots
+	}
+	return r.WindLimitsKnots()
+}
+
+// HasWindLimits reports whether either runway end has a crosswind or tailwind limit.
+func (r Runway) HasWindLimits() bool {
+	if r.CrosswindLimitKnots > 0 || r.TailwindLimitKnots > 0 {
+		return true
+	}
+	for _, reverse := range []bool{false, true} {
+		if end := r.End(reverse); end.CrosswindLimitKnots > 0 || end.TailwindLimitKnots > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package airport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSurfaceCondition_Effect(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition SurfaceCondition
+		surface   SurfaceType
+		expected  SurfaceConditionEffect
+	}{
+		{"dry asphalt", Dry, Asphalt, SurfaceConditionEffect{1, 1, 1}},
+		{"wet concrete", Wet, Concrete, SurfaceConditionEffect{0.75, 0.5, 1.1}},
+		{"wet grass", Wet, Grass, SurfaceConditionEffect{0.6, 0.5, 1.25}},
+		{"contaminated asphalt", Contaminated, Asphalt, SurfaceConditionEffect{0.5, 0.2, 1.25}},
+		{"contaminated dirt", Contaminated, Dirt, SurfaceConditionEffect{0.4, 0.2, 1.5}},
+		{"water unaffected", Contaminated, Water, SurfaceConditionEffect{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.condition.Effect(tt.surface); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunway_WindLimitsKnots(t *testing.T) {
+	runway := Runway{SurfaceType: Asphalt, CrosswindLimitKnots: 20, TailwindLimitKnots: 10}
+
+	if crosswind, tailwind := runway.WindLimitsKnots(); crosswind != 20 || tailwind != 10 {
+		t.Errorf("Expected dry limits 20/10, got %.1f/%.1f", crosswind, tailwind)
+	}
+
+	wet := Wet
+	runway = RunwayModification{SurfaceCondition: &wet}.Apply(runway)
+	if crosswind, tailwind := runway.WindLimitsKnots(); crosswind != 15 || tailwind != 5 {
+		t.Errorf("Expected wet limits 15/5, got %.1f/%.1f", crosswind, tailwind)
+	}
+
+	unlimited := Runway{SurfaceType: Asphalt, SurfaceCondition: Contaminated}
+	if crosswind, tailwind := unlimited.WindLimitsKnots(); crosswind != 0 || tailwind != 0 {
+		t.Errorf("Expected runway without limits to stay unlimited, got %.1f/%.1f", crosswind, tailwind)
+	}
+}
+
+func TestRunway_EndWindLimitsKnots(t *testing.T) {
+	runway := Runway{SurfaceType: Asphalt, CrosswindLimitKnots: 20, TailwindLimitKnots: 10, ReverseTailwindLimitKnots: 5}
+
+	if crosswind, tailwind := runway.EndWindLimitsKnots(false); crosswind != 20 || tailwind != 10 {
+		t.Errorf("Expected forward limits 20/10, got %.1f/%.1f", crosswind, tailwind)
+	}
+	if crosswind, tailwind := runway.EndWindLimitsKnots(true); crosswind != 20 || tailwind != 5 {
+		t.Errorf("Expected reverse limits 20/5, got %.1f/%.1f", crosswind, tailwind)
+	}
+
+	// Reverse limits are reduced for surface condition like the others
+	wet := Wet
+	runway = RunwayModification{SurfaceCondition: &wet}.Apply(runway)
+	if crosswind, tailwind := runway.EndWindLimitsKnots(true); crosswind != 15 || tailwind != 2.5 {
+		t.Errorf("Expected wet reverse limits 15/2.5, got %.1f/%.1f", crosswind, tailwind)
+	}
+
+	if !(Runway{ReverseTailwindLimitKnots: 5}).HasWindLimits() || (Runway{}).HasWindLimits() {
+		t.Error("Expected HasWindLimits to consider reverse limits")
+	}
+}
+
+func TestFleetMix_RunwaySeparation(t *testing.T) {
+	runway := Runway{SurfaceType: Concrete, SurfaceCondition: Wet, MinimumSeparation: 60 * time.Second}
+
+	if got := (*FleetMix)(nil).RunwaySeparation(runway); got != 66*time.Second {
+		t.Errorf("Expected wet runway minimum stretched to 66s, got %v", got)
+	}
+
+	// Occupancy (80s) exceeds the runway minimum and is stretched too
+	mix := &FleetMix{Classes: []AircraftClass{{Name: "A", Share: 1, RunwayOccupancy: 80 * time.Second}}}
+	if got := mix.RunwaySeparation(runway); got != 88*time.Second {
+		t.Errorf("Expected 88s, got %v", got)
+	}
+}
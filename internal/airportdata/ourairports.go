@@ -0,0 +1,340 @@
+// Package airportdata imports airport layouts from public data sets, so runway designators,
+// dimensions, headings and surfaces need not be entered by hand.
+package airportdata
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// DefaultOurAirportsBaseURL is the location of the OurAirports CSV data files.
+const DefaultOurAirportsBaseURL = "https://davidmegginson.github.io/ourairports-data"
+
+// Conversion factor from the feet used by OurAirports to meters.
+const metersPerFoot = 0.3048
+
+// ErrAirportNotFound indicates the requested airport is not in the data set
+var ErrAirportNotFound = errors.New("airport not found")
+
+// OurAirports imports airports from the OurAirports public domain data set
+// (https://ourairports.com/data/), either fetched from BaseURL or read from local copies of
+// airports.csv and runways.csv.
+//
+// Imported airports contain the runway designators, lengths, widths, true headings, surfaces,
+// elevations and gradients. Minimum separations and runway compatibility are not in the data
+// set and must be filled in before the airport is simulated; Airport.Validate reports missing
+// separations.
+type OurAirports struct {
+	BaseURL string       // Location of airports.csv and runways.csv (defaults to DefaultOurAirportsBaseURL)
+	Client  *http.Client // HTTP client (defaults to http.DefaultClient)
+}
+
+// NewOurAirports creates an importer using the published OurAirports data files.
+func NewOurAirports() *OurAirports {
+	return &OurAirports{
+		BaseURL: DefaultOurAirportsBaseURL,
+		Client:  http.DefaultClient,
+	}
+}
+
+// Fetch downloads the OurAirports data files and builds the airport identified by ident
+// (its ICAO, GPS or IATA code, e.g. "EGLL" or "LHR").
+func (o *OurAirports) Fetch(ctx context.Context, ident string) (airport.Airport, error) {
+	airports, err := o.get(ctx, "airports.csv")
+	if err != nil {
+		return airport.Airport{}, err
+	}
+	defer airports.Close()
+
+	runways, err := o.get(ctx, "runways.csv")
+	if err != nil {
+		return airport.Airport{}, err
+	}
+	defer runways.Close()
+
+	return ReadOurAirports(airports, runways, ident)
+}
+
+// get requests one of the data files and returns its body.
+func (o *OurAirports) get(ctx context.Context, file string) (io.ReadCloser, error) {
+	base := o.BaseURL
+	if base == "" {
+		base = DefaultOurAirportsBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+"/"+file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: airport data returned status %d", file, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// ReadOurAirports builds the airport identified by ident (its ICAO, GPS or IATA code) from
+// OurAirports airports.csv and runways.csv data. Closed runways and runways whose heading
+// cannot be determined (such as helipads) are skipped.
+// Returns ErrAirportNotFound if the airport is not in the data or has no usable runways.
+func ReadOurAirports(airports, runways io.Reader, ident string) (airport.Airport, error) {
+	ident = strings.ToUpper(strings.TrimSpace(ident))
+
+	var ap airport.Airport
+	var ref string
+	var elevationFeet float64
+	err := readCSV(airports, "airports.csv", func(row csvRow) bool {
+		if !strings.EqualFold(row.get("ident"), ident) && !strings.EqualFold(row.get("gps_code"), ident) && !strings.EqualFold(row.get("iata_code"), ident) {
+			return false
+		}
+		ref = row.get("id")
+		elevationFeet, _ = strconv.ParseFloat(row.get("elevation_ft"), 64)
+		ap = airport.Airport{
+			Name:     row.get("name"),
+			IATACode: row.get("iata_code"),
+			ICAOCode: icaoCode(row),
+			City:     row.get("municipality"),
+			Country:  row.get("iso_country"),
+		}
+		return true
+	})
+	if err != nil {
+		return airport.Airport{}, err
+	}
+	if ref == "" {
+		return airport.Airport{}, fmt.Errorf("%w: %s", ErrAirportNotFound, ident)
+	}
+
+	err = readCSV(runways, "runways.csv", func(row csvRow) bool {
+		if row.get("airport_ref") != ref || row.get("closed") == "1" {
+			return false
+		}
+		if runway, ok := ourAirportsRunway(row, elevationFeet); ok {
+			ap.Runways = append(ap.Runways, runway)
+		}
+		return false
+	})
+	if err != nil {
+		return airport.Airport{}, err
+	}
+	if len(ap.Runways) == 0 {
+		return airport.Airport{}, fmt.Errorf("%w: %s has no open runways with a known heading", ErrAirportNotFound, ident)
+	}
+
+	return ap, nil
+}
+
+// icaoCode returns the airport's ICAO code: its GPS code, or its identifier when that is a
+// four-letter code.
+func icaoCode(row csvRow) string {
+	if code := row.get("gps_code"); code != "" {
+		return code
+	}
+	if ident := row.get("ident"); len(ident) == 4 {
+		return ident
+	}
+	return ""
+}
+
+// ourAirportsRunway converts a runways.csv row, named after its low-numbered end.
+// Reports false if the runway's true heading cannot be determined.
+func ourAirportsRunway(row csvRow, airportElevationFeet float64) (airport.Runway, bool) {
+	bearing, ok := trueBearing(row)
+	if !ok {
+		return airport.Runway{}, false
+	}
+
+	length := row.float("length_ft") * metersPerFoot
+	elevation := airportElevationFeet
+	if e, ok := row.optionalFloat("le_elevation_ft"); ok {
+		elevation = e
+	}
+
+	var gradient float64
+	leElevation, leOK := row.optionalFloat("le_elevation_ft")
+	heElevation, heOK := row.optionalFloat("he_elevation_ft")
+	if leOK && heOK && length > 0 {
+		gradient = (heElevation - leElevation) * metersPerFoot / length * 100
+	}
+
+	return airport.Runway{
+		RunwayDesignation: row.get("le_ident"),
+		TrueBearing:       bearing,
+		LengthMeters:      length,
+		WidthMeters:       row.float("width_ft") * metersPerFoot,
+		SurfaceType:       surfaceType(row.get("surface")),
+		ElevationMeters:   elevation * metersPerFoot,
+		GradientPercent:   gradient,
+		Ends:              ourAirportsRunwayEnds(row, bearing, length),
+	}, true
+}
+
+// ourAirportsRunwayEnds returns both ends of a runways.csv row with their published
+// designations, headings and displaced thresholds, or no explicit ends if the high-numbered
+// end is not named. Displaced thresholds that leave no landing distance are ignored.
+func ourAirportsRunwayEnds(row csvRow, bearing, lengthMeters float64) [2]airport.RunwayEnd {
+	heIdent := row.get("he_ident")
+	if heIdent == "" || heIdent == row.get("le_ident") {
+		return [2]airport.RunwayEnd{}
+	}
+
+	heBearing := normalizeBearing(bearing + 180)
+	if heading, ok := row.optionalFloat("he_heading_degT"); ok {
+		heBearing = normalizeBearing(heading)
+	}
+	displaced := func(column string) float64 {
+		meters := row.float(column) * metersPerFoot
+		if meters < 0 || (lengthMeters > 0 && meters >= lengthMeters) {
+			return 0
+		}
+		return meters
+	}
+
+	return [2]airport.RunwayEnd{
+		{Designation: row.get("le_ident"), TrueBearing: bearing, DisplacedThresholdMeters: displaced("le_displaced_threshold_ft")},
+		{Designation: heIdent, TrueBearing: heBearing, DisplacedThresholdMeters: displaced("he_displaced_threshold_ft")},
+	}
+}
+
+// trueBearing returns the true heading of the runway's low-numbered end: the published
+// heading, else the bearing between the threshold coordinates, else the runway number.
+func trueBearing(row csvRow) (float64, bool) {
+	if heading, ok := row.optionalFloat("le_heading_degT"); ok {
+		return normalizeBearing(heading), true
+	}
+
+	lat1, ok1 := row.optionalFloat("le_latitude_deg")
+	lon1, ok2 := row.optionalFloat("le_longitude_deg")
+	lat2, ok3 := row.optionalFloat("he_latitude_deg")
+	lon2, ok4 := row.optionalFloat("he_longitude_deg")
+	if ok1 && ok2 && ok3 && ok4 && (lat1 != lat2 || lon1 != lon2) {
+		return initialBearing(lat1, lon1, lat2, lon2), true
+	}
+
+	// Runway numbers are magnetic headings in tens of degrees, close enough as a last resort
+	ident := row.get("le_ident")
+	digits := 0
+	for digits < len(ident) && digits < 2 && ident[digits] >= '0' && ident[digits] <= '9' {
+		digits++
+	}
+	number, err := strconv.Atoi(ident[:digits])
+	if err != nil || number < 1 || number > 36 {
+		return 0, false
+	}
+	return normalizeBearing(float64(number * 10)), true
+}
+
+// initialBearing returns the initial great-circle bearing in degrees true from the first
+// point to the second.
+func initialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := lat1*math.Pi/180, lat2*math.Pi/180
+	deltaLon := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(deltaLon) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(deltaLon)
+	return normalizeBearing(math.Atan2(y, x) * 180 / math.Pi)
+}
+
+// normalizeBearing maps a bearing in degrees into [0, 360).
+func normalizeBearing(bearing float64) float64 {
+	bearing = math.Mod(bearing, 360)
+	if bearing < 0 {
+		bearing += 360
+	}
+	return bearing
+}
+
+// surfaceType maps the free-text OurAirports surface description (e.g. "ASP", "Concrete",
+// "GRS") to a surface type. Unrecognised surfaces are treated as asphalt.
+func surfaceType(surface string) airport.SurfaceType {
+	s := strings.ToUpper(surface)
+	switch {
+	case strings.Contains(s, "WATER"):
+		return airport.Water
+	case strings.HasPrefix(s, "CON"), strings.HasPrefix(s, "PEM"):
+		return airport.Concrete
+	case strings.HasPrefix(s, "GRS"), strings.HasPrefix(s, "GRASS"), strings.Contains(s, "TURF"):
+		return airport.Grass
+	case strings.HasPrefix(s, "DIRT"), strings.HasPrefix(s, "GRV"), strings.HasPrefix(s, "GRAV"), strings.HasPrefix(s, "GRE"),
+		strings.HasPrefix(s, "SAND"), strings.HasPrefix(s, "CLAY"), strings.HasPrefix(s, "SOIL"):
+		return airport.Dirt
+	default:
+		return airport.Asphalt
+	}
+}
+
+// csvRow is a CSV record with access to its fields by column name.
+type csvRow struct {
+	columns map[string]int
+	record  []string
+}
+
+// get returns the named field, or "" if the column is missing.
+func (r csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// optionalFloat returns the named field as a number, reporting false if it is empty or invalid.
+func (r csvRow) optionalFloat(column string) (float64, bool) {
+	v, err := strconv.ParseFloat(r.get(column), 64)
+	return v, err == nil
+}
+
+// float returns the named field as a number, or 0 if it is empty or invalid.
+func (r csvRow) float(column string) float64 {
+	v, _ := r.optionalFloat(column)
+	return v
+}
+
+// readCSV calls fn for every record of the CSV data in r, which must start with a header
+// row, until fn reports it is done.
+func readCSV(r io.Reader, name string, fn func(row csvRow) (done bool)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("reading %s header: %w", name, err)
+	}
+	row := csvRow{columns: make(map[string]int, len(header))}
+	for i, column := range header {
+		row.columns[strings.TrimSpace(column)] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		row.record = record
+		if fn(row) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package airportdata
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+const sampleAirports = `"id","ident","type","name","latitude_deg","longitude_deg","elevation_ft","continent","iso_country","iso_region","municipality","scheduled_service","gps_code","iata_code","local_code","home_link","wikipedia_link","keywords"
+2434,"EGLL","large_airport","London Heathrow Airport",51.4706,-0.461941,83,"EU","GB","GB-ENG","London","yes","EGLL","LHR",,,,
+9999,"XXGA","small_airport","Grass Strip",51.0,-1.0,300,"EU","GB","GB-ENG","Nowhere","no",,,,,,
+`
+
+const sampleRunways = `"id","airport_ref","airport_ident","length_ft","width_ft","surface","lighted","closed","le_ident","le_latitude_deg","le_longitude_deg","le_elevation_ft","le_heading_degT","le_displaced_threshold_ft","he_ident","he_latitude_deg","he_longitude_deg","he_elevation_ft","he_heading_degT","he_displaced_threshold_ft"
+1,2434,"EGLL",12802,164,"ASP",1,0,"09L",51.4775,-0.484948,79,89.6,1013,"27R",51.4777,-0.433378,78,269.6,
+2,2434,"EGLL",12008,164,"ASP",1,0,"09R",51.4648,-0.482578,75,89.6,1007,"27L",51.4650,-0.434062,77,269.6,
+3,2434,"EGLL",6000,150,"ASP",0,1,"05",,,,,,"23",,,,,
+4,2434,"EGLL",60,60,"CON",1,0,"H1",,,,,,,,,,,
+5,9999,"XXGA",2000,60,"Grass",0,0,"16",51.005,-1.0,310,,,"34",51.0,-1.0,300,,
+`
+
+func TestReadOurAirports(t *testing.T) {
+	ap, err := ReadOurAirports(strings.NewReader(sampleAirports), strings.NewReader(sampleRunways), "LHR")
+	if err != nil {
+		t.Fatalf("ReadOurAirports failed: %v", err)
+	}
+
+	if ap.Name != "London Heathrow Airport" || ap.ICAOCode != "EGLL" || ap.IATACode != "LHR" || ap.City != "London" || ap.Country != "GB" {
+		t.Errorf("Unexpected airport details: %+v", ap)
+	}
+
+	// The closed runway and the helipad are skipped
+	if len(ap.Runways) != 2 {
+		t.Fatalf("Expected 2 runways, got %d", len(ap.Runways))
+	}
+	r := ap.Runways[0]
+	if r.RunwayDesignation != "09L" || r.TrueBearing != 89.6 || r.SurfaceType != airport.Asphalt {
+		t.Errorf("Unexpected runway: %+v", r)
+	}
+	if math.Abs(r.LengthMeters-3902.05) > 0.01 || math.Abs(r.WidthMeters-49.99) > 0.01 {
+		t.Errorf("Expected 3902 x 50 m, got %.2f x %.2f m", r.LengthMeters, r.WidthMeters)
+	}
+	if math.Abs(r.ElevationMeters-24.08) > 0.01 {
+		t.Errorf("Expected threshold elevation of 24.08 m, got %.2f", r.ElevationMeters)
+	}
+
+	// Both ends are imported with 09L's displaced threshold
+	if end := r.End(true); end.Designation != "27R" || end.TrueBearing != 269.6 || end.DisplacedThresholdMeters != 0 {
+		t.Errorf("Unexpected reciprocal end: %+v", end)
+	}
+	if got := r.LandingDistanceMeters(false); math.Abs(got-3593.29) > 0.01 {
+		t.Errorf("Expected 3593.29 m available for landing on 09L, got %.2f", got)
+	}
+
+	// Separations are left for the user to fill in
+	if err := ap.Validate(); err == nil {
+		t.Error("Expected the imported airport to need minimum separations")
+	}
+}
+
+func TestReadOurAirports_DerivedHeading(t *testing.T) {
+	ap, err := ReadOurAirports(strings.NewReader(sampleAirports), strings.NewReader(sampleRunways), "xxga")
+	if err != nil {
+		t.Fatalf("ReadOurAirports failed: %v", err)
+	}
+
+	r := ap.Runways[0]
+	// Without a published heading, the bearing comes from the threshold coordinates (due south)
+	if math.Abs(r.TrueBearing-180) > 1e-6 {
+		t.Errorf("Expected a bearing of 180, got %v", r.TrueBearing)
+	}
+	if r.SurfaceType != airport.Grass {
+		t.Errorf("Expected a grass runway, got %v", r.SurfaceType)
+	}
+	if ap.ICAOCode != "XXGA" {
+		t.Errorf("Expected the four-letter ident as ICAO code, got %q", ap.ICAOCode)
+	}
+}
+
+func TestReadOurAirports_NotFound(t *testing.T) {
+	_, err := ReadOurAirports(strings.NewReader(sampleAirports), strings.NewReader(sampleRunways), "KJFK")
+	if !errors.Is(err, ErrAirportNotFound) {
+		t.Errorf("Expected ErrAirportNotFound, got %v", err)
+	}
+}
+
+func TestSurfaceType(t *testing.T) {
+	tests := []struct {
+		surface  string
+		expected airport.SurfaceType
+	}{
+		{"ASP", airport.Asphalt},
+		{"ASPH-CONC", airport.Asphalt},
+		{"Concrete", airport.Concrete},
+		{"PEM", airport.Concrete},
+		{"GRS", airport.Grass},
+		{"Turf", airport.Grass},
+		{"GRVL", airport.Dirt},
+		{"DIRT", airport.Dirt},
+		{"WATER", airport.Water},
+		{"", airport.Asphalt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.surface, func(t *testing.T) {
+			if got := surfaceType(tt.surface); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOurAirports_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/airports.csv":
+			w.Write([]byte(sampleAirports))
+		case "/runways.csv":
+			w.Write([]byte(sampleRunways))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	importer := &OurAirports{BaseURL: server.URL, Client: server.Client()}
+	ap, err := importer.Fetch(context.Background(), "EGLL")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(ap.Runways) != 2 {
+		t.Errorf("Expected 2 runways, got %d", len(ap.Runways))
+	}
+}
+
+func TestOurAirports_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	importer := &OurAirports{BaseURL: server.URL, Client: server.Client()}
+	if _, err := importer.Fetch(context.Background(), "EGLL"); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
@@ -0,0 +1,314 @@
+// Package parquet writes flat tables as Apache Parquet files (https://parquet.apache.org/docs/),
+// the columnar format read by pandas, Polars, DuckDB and Spark, using the Parquet
+// implementation of arrow-go (github.com/apache/arrow-go).
+//
+// Only what large simulation outputs need is supported: required (non-null) columns of
+// booleans, 64-bit integers, floats, doubles, UTF-8 strings and UTC timestamps, optionally
+// gzip compressed. Rows are buffered until a row group is full and then written out, so memory
+// use is bounded by the row group size rather than the file size.
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/metadata"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DefaultRowGroupRows is the number of rows buffered per row group when Options.RowGroupRows
+// is not set.
+const DefaultRowGroupRows = 100_000
+
+// Type is the type of the values in a column.
+type Type int
+
+const (
+	Boolean   Type = iota // bool
+	Int64                 // int64
+	Float                 // float32
+	Double                // float64
+	String                // string, UTF-8
+	Timestamp             // time.Time, stored as microseconds since the Unix epoch in UTC
+)
+
+// String returns the name of the type.
+func (t Type) String() string {
+	switch t {
+	case Boolean:
+		return "Boolean"
+	case Int64:
+		return "Int64"
+	case Float:
+		return "Float"
+	case Double:
+		return "Double"
+	case String:
+		return "String"
+	case Timestamp:
+		return "Timestamp"
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// node returns the schema node of a required column of type t.
+func (t Type) node(name string) (schema.Node, error) {
+	switch t {
+	case Boolean:
+		return schema.NewPrimitiveNode(name, parquet.Repetitions.Required, parquet.Types.Boolean, -1, -1)
+	case Int64:
+		return schema.NewPrimitiveNode(name, parquet.Repetitions.Required, parquet.Types.Int64, -1, -1)
+	case Float:
+		return schema.NewPrimitiveNode(name, parquet.Repetitions.Required, parquet.Types.Float, -1, -1)
+	case Double:
+		return schema.NewPrimitiveNode(name, parquet.Repetitions.Required, parquet.Types.Double, -1, -1)
+	case String:
+		return schema.NewPrimitiveNodeLogical(name, parquet.Repetitions.Required, schema.StringLogicalType{}, parquet.Types.ByteArray, -1, -1)
+	default:
+		return schema.NewPrimitiveNodeLogical(name, parquet.Repetitions.Required, schema.NewTimestampLogicalType(true, schema.TimeUnitMicros), parquet.Types.Int64, -1, -1)
+	}
+}
+
+// Column names and types one column of a table.
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Options configures a Writer.
+type Options struct {
+	RowGroupRows int               // Rows per row group (0 = DefaultRowGroupRows)
+	Gzip         bool              // Compress pages with gzip
+	Metadata     map[string]string // Key-value metadata stored in the file footer (e.g., a schema version)
+	CreatedBy    string            // Application that wrote the file (omitted = the arrow-go version)
+}
+
+// Writer writes rows to a Parquet file. Call Close to write the file footer; a file without
+// its footer cannot be read.
+type Writer struct {
+	fw      *file.Writer
+	columns []Column
+	options Options
+
+	values    []columnValues // Values of each column in the current row group
+	rows      int            // Rows in the current row group
+	totalRows int64
+	closed    bool
+}
+
+// columnValues holds the buffered values of a column, in the slice for its type.
+type columnValues struct {
+	bools   []bool
+	int64s  []int64 // Int64 and Timestamp (microseconds since the Unix epoch) values
+	floats  []float32
+	doubles []float64
+	strings []parquet.ByteArray
+}
+
+// reset empties the values, keeping their storage for the next row group.
+func (v *columnValues) reset() {
+	v.bools, v.int64s, v.floats, v.doubles = v.bools[:0], v.int64s[:0], v.floats[:0], v.doubles[:0]
+	clear(v.strings)
+	v.strings = v.strings[:0]
+}
+
+// NewWriter starts a Parquet file with the given columns on out.
+// Returns an error if there are no columns, a column has no name, two share a name, a type
+// is unknown, the metadata is not valid UTF-8 or the start of the file cannot be written.
+func NewWriter(out io.Writer, columns []Column, options Options) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, simerrors.Invalidf("parquet file must have at least one column")
+	}
+	seen := make(map[string]bool, len(columns))
+	fields := make(schema.FieldList, len(columns))
+	for i, c := range columns {
+		if c.Name == "" {
+			return nil, simerrors.Invalidf("parquet column %d has no name", i)
+		}
+		if seen[c.Name] {
+			return nil, simerrors.Invalidf("parquet column %q is defined more than once", c.Name)
+		}
+		if c.Type < Boolean || c.Type > Timestamp {
+			return nil, simerrors.Invalidf("parquet column %q has unknown type %v", c.Name, c.Type)
+		}
+		seen[c.Name] = true
+
+		node, err := c.Type.node(c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("parquet column %q: %w", c.Name, err)
+		}
+		fields[i] = node
+	}
+	if options.RowGroupRows < 0 {
+		return nil, simerrors.Invalidf("parquet row group size must not be negative, got %d", options.RowGroupRows)
+	}
+	if options.RowGroupRows == 0 {
+		options.RowGroupRows = DefaultRowGroupRows
+	}
+
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, fields, -1)
+	if err != nil {
+		return nil, err
+	}
+	properties := []parquet.WriterProperty{parquet.WithMaxRowGroupLength(int64(options.RowGroupRows))}
+	if options.CreatedBy != "" {
+		properties = append(properties, parquet.WithCreatedBy(options.CreatedBy))
+	}
+	if options.Gzip {
+		properties = append(properties, parquet.WithCompression(compress.Codecs.Gzip))
+	}
+	kv := metadata.NewKeyValueMetadata()
+	for _, k := range slices.Sorted(maps.Keys(options.Metadata)) {
+		if err := kv.Append(k, options.Metadata[k]); err != nil {
+			return nil, simerrors.Invalidf("parquet metadata: %w", err)
+		}
+	}
+
+	fw, err := startFile(out, root,
+		file.WithWriterProps(parquet.NewWriterProperties(properties...)),
+		file.WithWriteMetadata(kv))
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{fw: fw, columns: columns, options: options, values: make([]columnValues, len(columns))}, nil
+}
+
+// startFile starts a Parquet file on out. The file writer panics if it cannot write the start
+// of the file, and closes out when it is closed if it can, so it is given out without its
+// Close method.
+func startFile(out io.Writer, root *schema.GroupNode, options ...file.WriteOption) (fw *file.Writer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("starting parquet file: %v", r)
+		}
+	}()
+	return file.NewParquetWriter(struct{ io.Writer }{out}, root, options...), nil
+}
+
+// WriteRow adds a row with one value per column, in column order, of the Go type listed for
+// the column's Type. A full row group is written out before WriteRow returns.
+// Returns an error if the number or types of the values do not match the columns.
+func (w *Writer) WriteRow(values ...any) error {
+	if w.closed {
+		return simerrors.Invalidf("parquet writer is closed")
+	}
+	if len(values) != len(w.columns) {
+		return simerrors.Invalidf("parquet row has %d values, expected %d", len(values), len(w.columns))
+	}
+	for i, c := range w.columns {
+		if !matchesType(c.Type, values[i]) {
+			return simerrors.Invalidf("parquet column %q expects %v values, got %T", c.Name, c.Type, values[i])
+		}
+	}
+
+	for i, v := range values {
+		buf := &w.values[i]
+		switch v := v.(type) {
+		case bool:
+			buf.bools = append(buf.bools, v)
+		case int64:
+			buf.int64s = append(buf.int64s, v)
+		case float32:
+			buf.floats = append(buf.floats, v)
+		case float64:
+			buf.doubles = append(buf.doubles, v)
+		case string:
+			buf.strings = append(buf.strings, parquet.ByteArray(v))
+		case time.Time:
+			buf.int64s = append(buf.int64s, v.UnixMicro())
+		}
+	}
+
+	w.rows++
+	if w.rows >= w.options.RowGroupRows {
+		return w.flush()
+	}
+	return nil
+}
+
+// matchesType reports whether v is of the Go type stored in columns of type t.
+func matchesType(t Type, v any) bool {
+	switch v.(type) {
+	case bool:
+		return t == Boolean
+	case int64:
+		return t == Int64
+	case float32:
+		return t == Float
+	case float64:
+		return t == Double
+	case string:
+		return t == String
+	case time.Time:
+		return t == Timestamp
+	}
+	return false
+}
+
+// flush writes the buffered rows as a row group.
+func (w *Writer) flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	group := w.fw.AppendRowGroup()
+	for i := range w.columns {
+		cw, err := group.NextColumn()
+		if err != nil {
+			return err
+		}
+		buf := &w.values[i]
+		switch cw := cw.(type) {
+		case *file.BooleanColumnChunkWriter:
+			_, err = cw.WriteBatch(buf.bools, nil, nil)
+		case *file.Int64ColumnChunkWriter:
+			_, err = cw.WriteBatch(buf.int64s, nil, nil)
+		case *file.Float32ColumnChunkWriter:
+			_, err = cw.WriteBatch(buf.floats, nil, nil)
+		case *file.Float64ColumnChunkWriter:
+			_, err = cw.WriteBatch(buf.doubles, nil, nil)
+		case *file.ByteArrayColumnChunkWriter:
+			_, err = cw.WriteBatch(buf.strings, nil, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("writing parquet column %q: %w", w.columns[i].Name, err)
+		}
+		if err := cw.Close(); err != nil {
+			return fmt.Errorf("writing parquet column %q: %w", w.columns[i].Name, err)
+		}
+		buf.reset()
+	}
+	if err := group.Close(); err != nil {
+		return err
+	}
+
+	w.totalRows += int64(w.rows)
+	w.rows = 0
+	return nil
+}
+
+// Close writes any buffered rows and the file footer. It does not close the underlying
+// writer. Closing a closed Writer does nothing.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	w.closed = true
+	return w.fw.Close()
+}
+
+// Rows returns the number of rows written so far.
+func (w *Writer) Rows() int64 {
+	return w.totalRows + int64(w.rows)
+}
@@ -0,0 +1,217 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/metadata"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// parquetFile is a Parquet file read back with arrow-go's reader: its footer and each column's
+// values, concatenated over the row groups.
+type parquetFile struct {
+	metadata *metadata.FileMetaData
+	columns  map[string][]any
+}
+
+// readParquet reads a file written by Writer with the given columns.
+func readParquet(t *testing.T, data []byte, columns []Column) parquetFile {
+	t.Helper()
+	r, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("reading parquet file: %v", err)
+	}
+	defer r.Close()
+
+	pf := parquetFile{metadata: r.MetaData(), columns: map[string][]any{}}
+	for g := range r.NumRowGroups() {
+		group := r.RowGroup(g)
+		rows := group.NumRows()
+		for i, c := range columns {
+			cr, err := group.Column(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var values []any
+			switch cr := cr.(type) {
+			case *file.BooleanColumnChunkReader:
+				values = readColumn(t, rows, cr.ReadBatch, func(v bool) any { return v })
+			case *file.Int64ColumnChunkReader:
+				if c.Type == Timestamp {
+					values = readColumn(t, rows, cr.ReadBatch, func(v int64) any { return time.UnixMicro(v).UTC() })
+				} else {
+					values = readColumn(t, rows, cr.ReadBatch, func(v int64) any { return v })
+				}
+			case *file.Float32ColumnChunkReader:
+				values = readColumn(t, rows, cr.ReadBatch, func(v float32) any { return v })
+			case *file.Float64ColumnChunkReader:
+				values = readColumn(t, rows, cr.ReadBatch, func(v float64) any { return v })
+			case *file.ByteArrayColumnChunkReader:
+				values = readColumn(t, rows, cr.ReadBatch, func(v parquet.ByteArray) any { return string(v) })
+			default:
+				t.Fatalf("column %s has unexpected reader %T", c.Name, cr)
+			}
+			pf.columns[c.Name] = append(pf.columns[c.Name], values...)
+		}
+	}
+	return pf
+}
+
+// readColumn reads the rows values of a column chunk with readBatch and converts them.
+func readColumn[T any](t *testing.T, rows int64, readBatch func(int64, []T, []int16, []int16) (int64, int, error), convert func(T) any) []any {
+	t.Helper()
+	values := make([]T, rows)
+	if _, n, err := readBatch(rows, values, nil, nil); err != nil || int64(n) != rows {
+		t.Fatalf("read %d of %d values: %v", n, rows, err)
+	}
+	converted := make([]any, rows)
+	for i, v := range values {
+		converted[i] = convert(v)
+	}
+	return converted
+}
+
+var testColumns = []Column{
+	{Name: "run", Type: Int64},
+	{Name: "start", Type: Timestamp},
+	{Name: "capacity", Type: Float},
+	{Name: "wind_speed", Type: Double},
+	{Name: "configuration", Type: String},
+	{Name: "curfew", Type: Boolean},
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	for _, compressed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("gzip=%v", compressed), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, testColumns, Options{RowGroupRows: 4, Gzip: compressed, Metadata: map[string]string{"schema_version": "1"}, CreatedBy: "test"})
+			if err != nil {
+				t.Fatalf("NewWriter failed: %v", err)
+			}
+
+			first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			const rows = 10
+			for i := range rows {
+				if err := w.WriteRow(int64(i), first.Add(time.Duration(i)*time.Hour), float32(i)*1.5, float64(i)/4, fmt.Sprintf("config %d", i%3), i%3 == 0); err != nil {
+					t.Fatalf("WriteRow failed: %v", err)
+				}
+			}
+			if w.Rows() != rows {
+				t.Errorf("Expected %d rows, got %d", rows, w.Rows())
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			read := readParquet(t, buf.Bytes(), testColumns)
+			meta := read.metadata
+			if meta.NumRows != rows {
+				t.Errorf("Expected the footer to record %d rows, got %d", rows, meta.NumRows)
+			}
+			if groups := meta.NumRowGroups(); groups != 3 {
+				t.Errorf("Expected rows in 3 row groups of at most 4, got %d", groups)
+			}
+			if meta.NumColumns() != len(testColumns) {
+				t.Fatalf("Expected %d columns, got %d", len(testColumns), meta.NumColumns())
+			}
+			start := meta.Schema.Column(1)
+			if start.Name() != "start" || !start.LogicalType().Equals(schema.NewTimestampLogicalType(true, schema.TimeUnitMicros)) {
+				t.Errorf("Expected the second column to be start, a UTC timestamp in microseconds, got %s %v", start.Name(), start.LogicalType())
+			}
+			if logical := meta.Schema.Column(4).LogicalType(); !logical.Equals(schema.StringLogicalType{}) {
+				t.Errorf("Expected configuration to be a string, got %v", logical)
+			}
+			if version := meta.KeyValueMetadata().FindValue("schema_version"); version == nil || *version != "1" || meta.GetCreatedBy() != "test" {
+				t.Errorf("Expected the schema version and creator in the footer, got %v and %q", meta.KeyValueMetadata(), meta.GetCreatedBy())
+			}
+			chunk, err := meta.RowGroup(0).ColumnChunk(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := map[bool]compress.Compression{false: compress.Codecs.Uncompressed, true: compress.Codecs.Gzip}[compressed]; chunk.Compression() != want {
+				t.Errorf("Expected %v compression, got %v", want, chunk.Compression())
+			}
+
+			for i := range rows {
+				want := []any{int64(i), first.Add(time.Duration(i) * time.Hour), float32(i) * 1.5, float64(i) / 4, fmt.Sprintf("config %d", i%3), i%3 == 0}
+				for c, column := range testColumns {
+					if got := read.columns[column.Name][i]; got != want[c] {
+						t.Errorf("Row %d column %s: expected %v, got %v", i, column.Name, want[c], got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testColumns, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	read := readParquet(t, buf.Bytes(), testColumns)
+	if read.metadata.NumRows != 0 || read.metadata.NumRowGroups() != 0 {
+		t.Errorf("Expected no rows or row groups, got %d rows in %d groups", read.metadata.NumRows, read.metadata.NumRowGroups())
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []Column
+		options Options
+	}{
+		{"no columns", nil, Options{}},
+		{"unnamed column", []Column{{Type: Int64}}, Options{}},
+		{"duplicate column", []Column{{Name: "a", Type: Int64}, {Name: "a", Type: Float}}, Options{}},
+		{"unknown type", []Column{{Name: "a", Type: Type(42)}}, Options{}},
+		{"negative row group", []Column{{Name: "a", Type: Int64}}, Options{RowGroupRows: -1}},
+		{"invalid metadata", []Column{{Name: "a", Type: Int64}}, Options{Metadata: map[string]string{"version": "\xff"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWriter(io.Discard, tt.columns, tt.options); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+
+	if _, err := NewWriter(failingWriter{}, testColumns, Options{}); err == nil {
+		t.Error("Expected an error when the file cannot be written")
+	}
+
+	w, err := NewWriter(io.Discard, []Column{{Name: "a", Type: Int64}, {Name: "b", Type: String}}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow(int64(1)); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a short row, got %v", err)
+	}
+	if err := w.WriteRow(1, "x"); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an int instead of an int64, got %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow(int64(1), "x"); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration writing after Close, got %v", err)
+	}
+}
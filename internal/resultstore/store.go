@@ -0,0 +1,346 @@
+// Package resultstore keeps simulation results in a SQLite database, so runs can be compared
+// over time instead of being lost with the console output that reported them.
+//
+// The store uses database/sql and does not depend on a particular SQLite driver: open the
+// database with any registered driver (e.g., modernc.org/sqlite or github.com/mattn/go-sqlite3)
+// and pass it to Open, which creates the tables if they do not exist.
+package resultstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation"
+)
+
+// ErrRunNotFound indicates no run with the requested ID is stored.
+var ErrRunNotFound = errors.New("run not found")
+
+// schema creates the tables and indexes used by the store. Times are stored as RFC 3339 text
+// in UTC so they sort chronologically; runs record the time zone their results were in.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	scenario TEXT NOT NULL,
+	scenario_hash TEXT NOT NULL,
+	parameters TEXT NOT NULL,
+	recorded_at TEXT NOT NULL,
+	start_time TEXT NOT NULL,
+	end_time TEXT NOT NULL,
+	time_zone TEXT NOT NULL,
+	total_capacity REAL NOT NULL,
+	events_processed INTEGER NOT NULL,
+	configuration_history TEXT NOT NULL,
+	provenance TEXT NOT NULL DEFAULT ''
+)`,
+	`CREATE INDEX IF NOT EXISTS runs_scenario_hash ON runs (scenario_hash, recorded_at)`,
+	`CREATE TABLE IF NOT EXISTS windows (
+	run_id INTEGER NOT NULL REFERENCES runs (id) ON DELETE CASCADE,
+	seq INTEGER NOT NULL,
+	start_time TEXT NOT NULL,
+	end_time TEXT NOT NULL,
+	capacity REAL NOT NULL,
+	configuration TEXT NOT NULL,
+	flow TEXT NOT NULL,
+	curfew_active INTEGER NOT NULL,
+	detail TEXT NOT NULL,
+	PRIMARY KEY (run_id, seq)
+)`,
+}
+
+const (
+	runColumns = `id, scenario, scenario_hash, parameters, recorded_at, start_time, end_time, time_zone, total_capacity, events_processed`
+
+	insertRun = `INSERT INTO runs (scenario, scenario_hash, parameters, recorded_at, start_time, end_time, time_zone, total_capacity, events_processed, configuration_history, provenance)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	insertWindow = `INSERT INTO windows (run_id, seq, start_time, end_time, capacity, configuration, flow, curfew_active, detail)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectRun            = `SELECT ` + runColumns + ` FROM runs WHERE id = ?`
+	selectRuns           = `SELECT ` + runColumns + ` FROM runs ORDER BY recorded_at, id`
+	selectRunsByHash     = `SELECT ` + runColumns + ` FROM runs WHERE scenario_hash = ? ORDER BY recorded_at, id`
+	selectRunHistory     = `SELECT configuration_history, provenance FROM runs WHERE id = ?`
+	selectWindowsByRunID = `SELECT detail FROM windows WHERE run_id = ? ORDER BY seq`
+
+	countProvenanceColumn = `SELECT COUNT(*) FROM pragma_table_info('runs') WHERE name = 'provenance'`
+	addProvenanceColumn   = `ALTER TABLE runs ADD COLUMN provenance TEXT NOT NULL DEFAULT ''`
+)
+
+// Store reads and writes simulation runs in a SQLite database.
+type Store struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	locations map[string]*time.Location // Time zones loaded by name, shared so results from different runs compare equal
+}
+
+// Run describes a stored simulation run: the scenario that produced it and its headline
+// figures. The per-window results are loaded separately with Store.Result.
+type Run struct {
+	ID              int64           // Assigned when the run is saved
+	Scenario        string          // Scenario name (e.g., "Summer 2024 with night curfew")
+	ScenarioHash    string          // Identifies the scenario definition (see simulation.Scenario.Hash)
+	Parameters      json.RawMessage // Scenario definition or other parameters, as JSON (omitted = null)
+	RecordedAt      time.Time       // When the run was made (zero = when it is saved)
+	StartTime       time.Time       // Simulation start time
+	EndTime         time.Time       // Simulation end time
+	TotalCapacity   float32         // Total movements across all windows
+	EventsProcessed int             // Events applied during the run
+}
+
+// Open prepares db for use as a result store, creating the tables if they do not exist.
+// The store does not close db.
+func Open(ctx context.Context, db *sql.DB) (*Store, error) {
+	for _, statement := range schema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("creating result store schema: %w", err)
+		}
+	}
+
+	// Stores created before results recorded their provenance lack its column
+	var columns int
+	if err := db.QueryRowContext(ctx, countProvenanceColumn).Scan(&columns); err != nil {
+		return nil, fmt.Errorf("checking result store schema: %w", err)
+	}
+	if columns == 0 {
+		if _, err := db.ExecContext(ctx, addProvenanceColumn); err != nil {
+			return nil, fmt.Errorf("adding provenance to result store: %w", err)
+		}
+	}
+	return &Store{db: db, locations: map[string]*time.Location{}}, nil
+}
+
+// Save stores result with the scenario details from run and returns the ID assigned to it.
+// The run's times, total and event count are taken from result. The run and its windows are
+// written in one transaction, so a failed save leaves nothing behind.
+func (s *Store) Save(ctx context.Context, run Run, result *simulation.Result) (int64, error) {
+	if result == nil {
+		return 0, simerrors.Invalidf("cannot save run of scenario %q without a result", run.Scenario)
+	}
+	if run.ScenarioHash == "" {
+		return 0, simerrors.Invalidf("run of scenario %q has no scenario hash", run.Scenario)
+	}
+	if run.RecordedAt.IsZero() {
+		run.RecordedAt = time.Now()
+	}
+	parameters := run.Parameters
+	if len(parameters) == 0 {
+		parameters = json.RawMessage("null")
+	}
+	history, err := json.Marshal(result.ConfigurationHistory)
+	if err != nil {
+		return 0, fmt.Errorf("encoding configuration history: %w", err)
+	}
+	var provenance []byte
+	if !result.Provenance.IsZero() {
+		if provenance, err = json.Marshal(result.Provenance); err != nil {
+			return 0, fmt.Errorf("encoding provenance: %w", err)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	inserted, err := tx.ExecContext(ctx, insertRun,
+		run.Scenario, run.ScenarioHash, string(parameters), formatTime(run.RecordedAt),
+		formatTime(result.StartTime), formatTime(result.EndTime), result.StartTime.Location().String(),
+		result.TotalCapacity, result.EventsProcessed, string(history), string(provenance))
+	if err != nil {
+		return 0, fmt.Errorf("saving run of scenario %q: %w", run.Scenario, err)
+	}
+	id, err := inserted.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, insertWindow)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for i, w := range result.Windows {
+		detail, err := json.Marshal(w)
+		if err != nil {
+			return 0, fmt.Errorf("encoding window %d: %w", i, err)
+		}
+		if _, err := stmt.ExecContext(ctx, id, i, formatTime(w.Start), formatTime(w.End),
+			w.Capacity, w.Configuration, w.Flow, w.CurfewActive, string(detail)); err != nil {
+			return 0, fmt.Errorf("saving window %d of scenario %q: %w", i, run.Scenario, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Run returns the stored run with the given ID.
+// Returns an error matching ErrRunNotFound if there is none.
+func (s *Store) Run(ctx context.Context, id int64) (Run, error) {
+	runs, err := s.queryRuns(ctx, selectRun, id)
+	if err != nil {
+		return Run{}, err
+	}
+	if len(runs) == 0 {
+		return Run{}, fmt.Errorf("run %d: %w", id, ErrRunNotFound)
+	}
+	return runs[0], nil
+}
+
+// Runs returns the stored runs of the scenario with the given hash in the order they were
+// recorded, so its capacity can be followed over time. An empty hash returns every run.
+func (s *Store) Runs(ctx context.Context, scenarioHash string) ([]Run, error) {
+	if scenarioHash == "" {
+		return s.queryRuns(ctx, selectRuns)
+	}
+	return s.queryRuns(ctx, selectRunsByHash, scenarioHash)
+}
+
+// queryRuns runs a query selecting runColumns and scans the runs it returns.
+func (s *Store) queryRuns(ctx context.Context, query string, args ...any) ([]Run, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var (
+			run                              Run
+			parameters                       string
+			recordedAt, start, end, timeZone string
+		)
+		if err := rows.Scan(&run.ID, &run.Scenario, &run.ScenarioHash, &parameters, &recordedAt,
+			&start, &end, &timeZone, &run.TotalCapacity, &run.EventsProcessed); err != nil {
+			return nil, err
+		}
+		run.Parameters = json.RawMessage(parameters)
+
+		loc := s.location(timeZone)
+		var err error
+		if run.RecordedAt, err = parseTime(recordedAt, time.Local); err != nil {
+			return nil, err
+		}
+		if run.StartTime, err = parseTime(start, loc); err != nil {
+			return nil, err
+		}
+		if run.EndTime, err = parseTime(end, loc); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// Result loads the full result of the stored run with the given ID, with its times in the
+// time zone the run was simulated in, so it can be analysed like a result from a new run.
+// Returns an error matching ErrRunNotFound if there is none.
+func (s *Store) Result(ctx context.Context, id int64) (*simulation.Result, error) {
+	run, err := s.Run(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	loc := run.StartTime.Location()
+
+	result := &simulation.Result{
+		StartTime:       run.StartTime,
+		EndTime:         run.EndTime,
+		TotalCapacity:   run.TotalCapacity,
+		EventsProcessed: run.EventsProcessed,
+	}
+
+	var history, provenance string
+	if err := s.db.QueryRowContext(ctx, selectRunHistory, id).Scan(&history, &provenance); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(history), &result.ConfigurationHistory); err != nil {
+		return nil, fmt.Errorf("decoding configuration history of run %d: %w", id, err)
+	}
+	if provenance != "" {
+		if err := json.Unmarshal([]byte(provenance), &result.Provenance); err != nil {
+			return nil, fmt.Errorf("decoding provenance of run %d: %w", id, err)
+		}
+	}
+	for i := range result.ConfigurationHistory {
+		result.ConfigurationHistory[i].Time = result.ConfigurationHistory[i].Time.In(loc)
+	}
+
+	rows, err := s.db.QueryContext(ctx, selectWindowsByRunID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var detail string
+		if err := rows.Scan(&detail); err != nil {
+			return nil, err
+		}
+		var w simulation.WindowResult
+		if err := json.Unmarshal([]byte(detail), &w); err != nil {
+			return nil, fmt.Errorf("decoding window %d of run %d: %w", len(result.Windows), id, err)
+		}
+		w.Start, w.End = w.Start.In(loc), w.End.In(loc)
+		result.Windows = append(result.Windows, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Compare loads two stored runs and compares them day by day (see simulation.DiffResults),
+// e.g., to explain a change in capacity between runs of a scenario made months apart.
+// Returns an error matching ErrRunNotFound if either run is not stored.
+func (s *Store) Compare(ctx context.Context, baselineID, comparisonID int64) (*simulation.ResultDiff, error) {
+	baseline, err := s.Result(ctx, baselineID)
+	if err != nil {
+		return nil, err
+	}
+	comparison, err := s.Result(ctx, comparisonID)
+	if err != nil {
+		return nil, err
+	}
+	return simulation.DiffResults(baseline, comparison), nil
+}
+
+// location returns the time zone with the given name, or UTC if it cannot be loaded. Each
+// name is loaded once, so times from different runs share a *time.Location and days keyed
+// by time (e.g., in simulation.DiffResults) match.
+func (s *Store) location(name string) *time.Location {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if loc, ok := s.locations[name]; ok {
+		return loc
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+	s.locations[name] = loc
+	return loc
+}
+
+// formatTime encodes t in UTC so stored times sort chronologically as text.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseTime decodes a time stored by formatTime into loc.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decoding stored time %q: %w", s, err)
+	}
+	return t.In(loc), nil
+}
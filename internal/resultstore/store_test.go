@@ -0,0 +1,393 @@
+package resultstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation"
+	_ "modernc.org/sqlite"
+)
+
+// memoryDriver is a database/sql driver that keeps the store's tables in memory. It understands
+// only the statements the store uses, and is used to test the error paths a real database
+// cannot be made to take on demand (e.g., an insert failing part way through a save).
+// Each data source name is a separate database.
+type memoryDriver struct {
+	mu        sync.Mutex
+	databases map[string]*memoryDatabase
+}
+
+// memoryDatabase holds the rows of the runs and windows tables, with the columns in the order
+// the store inserts them (runs rows start with the assigned ID).
+type memoryDatabase struct {
+	mu         sync.Mutex
+	runs       [][]driver.Value
+	windows    [][]driver.Value
+	failWindow int // Seq of the window whose insert fails (-1 = none)
+}
+
+var testDriver = &memoryDriver{databases: map[string]*memoryDatabase{}}
+
+func init() {
+	sql.Register("resultstore-memory", testDriver)
+}
+
+func (d *memoryDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db, ok := d.databases[name]
+	if !ok {
+		db = &memoryDatabase{failWindow: -1}
+		d.databases[name] = db
+	}
+	return &memoryConn{db: db}, nil
+}
+
+// memoryConn applies statements directly to the database, or to a copy of it during a
+// transaction that replaces the database on commit.
+type memoryConn struct {
+	db *memoryDatabase
+	tx *memoryDatabase
+}
+
+func (c *memoryConn) Prepare(query string) (driver.Stmt, error) {
+	return &memoryStmt{conn: c, query: query}, nil
+}
+
+func (c *memoryConn) Close() error { return nil }
+
+func (c *memoryConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.tx = &memoryDatabase{runs: slices.Clone(c.db.runs), windows: slices.Clone(c.db.windows), failWindow: c.db.failWindow}
+	return c, nil
+}
+
+func (c *memoryConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.runs, c.db.windows, c.tx = c.tx.runs, c.tx.windows, nil
+	return nil
+}
+
+func (c *memoryConn) Rollback() error {
+	c.tx = nil
+	return nil
+}
+
+type memoryStmt struct {
+	conn  *memoryConn
+	query string
+}
+
+func (s *memoryStmt) Close() error  { return nil }
+func (s *memoryStmt) NumInput() int { return -1 }
+
+func (s *memoryStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := s.conn.tx
+	if db == nil {
+		db = s.conn.db
+		db.mu.Lock()
+		defer db.mu.Unlock()
+	}
+
+	switch {
+	case strings.HasPrefix(s.query, "CREATE "):
+		return driver.RowsAffected(0), nil
+	case s.query == insertRun:
+		id := int64(len(db.runs) + 1)
+		db.runs = append(db.runs, append([]driver.Value{id}, args...))
+		return memoryResult(id), nil
+	case s.query == insertWindow:
+		if args[1] == int64(db.failWindow) {
+			return nil, errors.New("disk full")
+		}
+		db.windows = append(db.windows, args)
+		return memoryResult(0), nil
+	}
+	return nil, fmt.Errorf("unsupported statement %q", s.query)
+}
+
+func (s *memoryStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db := s.conn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	runRow := func(r []driver.Value) []driver.Value { return r[:10] }
+	rows := &memoryRows{}
+	switch s.query {
+	case selectRun, selectRunHistory:
+		for _, r := range db.runs {
+			if r[0] == args[0] {
+				if s.query == selectRun {
+					rows.rows = append(rows.rows, runRow(r))
+				} else {
+					rows.rows = append(rows.rows, r[10:])
+				}
+			}
+		}
+	case selectRuns, selectRunsByHash:
+		for _, r := range db.runs {
+			if s.query == selectRuns || r[2] == args[0] {
+				rows.rows = append(rows.rows, runRow(r))
+			}
+		}
+		slices.SortStableFunc(rows.rows, func(a, b []driver.Value) int { return strings.Compare(a[4].(string), b[4].(string)) })
+	case countProvenanceColumn:
+		rows.rows = append(rows.rows, []driver.Value{int64(1)})
+	case selectWindowsByRunID:
+		for _, w := range db.windows {
+			if w[0] == args[0] {
+				rows.rows = append(rows.rows, []driver.Value{w[8]})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported query %q", s.query)
+	}
+	return rows, nil
+}
+
+type memoryResult int64
+
+func (r memoryResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r memoryResult) RowsAffected() (int64, error) { return 1, nil }
+
+type memoryRows struct {
+	rows [][]driver.Value
+}
+
+func (r *memoryRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *memoryRows) Close() error { return nil }
+
+func (r *memoryRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// openTestStore opens a store in a new SQLite database file (through modernc.org/sqlite).
+func openTestStore(t *testing.T) (*Store, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "results.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := Open(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return store, db
+}
+
+// openMemoryStore opens a store in a new memoryDriver database.
+func openMemoryStore(t *testing.T) (*Store, *memoryDatabase) {
+	t.Helper()
+	db, err := sql.Open("resultstore-memory", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := Open(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return store, testDriver.databases[t.Name()]
+}
+
+// testResult simulates two days at a single-runway airport in New York with a northerly wind.
+func testResult(t *testing.T, windSpeed float64) *simulation.Result {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	ap := airport.Airport{
+		Name:    "Store Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 90 * time.Second}},
+	}
+	start := time.Date(2024, 3, 9, 0, 0, 0, 0, loc)
+	sim, err := simulation.NewSimulation(ap, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		WithPeriod(start, start.AddDate(0, 0, 2)).
+		AddWindPolicy(windSpeed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	return result
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	store, _ := openTestStore(t)
+	ctx := context.Background()
+	result := testResult(t, 10)
+
+	recorded := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	id, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc", Parameters: []byte(`{"seed":1}`), RecordedAt: recorded}, result)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	run, err := store.Run(ctx, id)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if run.Scenario != "Baseline" || run.ScenarioHash != "abc" || string(run.Parameters) != `{"seed":1}` || !run.RecordedAt.Equal(recorded) {
+		t.Errorf("Unexpected run details %+v", run)
+	}
+	if run.TotalCapacity != result.TotalCapacity || run.EventsProcessed != result.EventsProcessed {
+		t.Errorf("Expected total %v from %d events, got %v from %d", result.TotalCapacity, result.EventsProcessed, run.TotalCapacity, run.EventsProcessed)
+	}
+
+	loaded, err := store.Result(ctx, id)
+	if err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Windows, result.Windows) {
+		t.Errorf("Loaded windows differ:\n got %+v\nwant %+v", loaded.Windows, result.Windows)
+	}
+	if !loaded.StartTime.Equal(result.StartTime) || !loaded.EndTime.Equal(result.EndTime) || loaded.StartTime.Location().String() != "America/New_York" {
+		t.Errorf("Expected period %v to %v in the original time zone, got %v to %v", result.StartTime, result.EndTime, loaded.StartTime, loaded.EndTime)
+	}
+	if len(loaded.ConfigurationHistory) != len(result.ConfigurationHistory) || loaded.ConfigurationHistory[0].Label != result.ConfigurationHistory[0].Label {
+		t.Errorf("Expected configuration history %+v, got %+v", result.ConfigurationHistory, loaded.ConfigurationHistory)
+	}
+	// Day boundaries are those of the time zone simulated in
+	if got, want := loaded.DailyCapacity(), result.DailyCapacity(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected daily capacity %v, got %v", want, got)
+	}
+	if result.Provenance.IsZero() || loaded.Provenance != result.Provenance {
+		t.Errorf("Expected provenance %+v, got %+v", result.Provenance, loaded.Provenance)
+	}
+}
+
+func TestOpen_AddsProvenanceColumn(t *testing.T) {
+	store, db := openTestStore(t)
+	ctx := context.Background()
+	id, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc"}, testResult(t, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make the store look like one created before provenance was recorded
+	if _, err := db.ExecContext(ctx, `ALTER TABLE runs DROP COLUMN provenance`); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := Open(ctx, db)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	var columns int
+	if err := db.QueryRowContext(ctx, countProvenanceColumn).Scan(&columns); err != nil || columns != 1 {
+		t.Errorf("Expected the provenance column to be added, got %d columns (%v)", columns, err)
+	}
+
+	loaded, err := reopened.Result(ctx, id)
+	if err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+	if !loaded.Provenance.IsZero() || loaded.TotalCapacity == 0 {
+		t.Errorf("Expected the run with unknown provenance, got %v movements with %+v", loaded.TotalCapacity, loaded.Provenance)
+	}
+
+	// Opening a store that is up to date changes nothing
+	if _, err := Open(ctx, db); err != nil {
+		t.Errorf("Reopening failed: %v", err)
+	}
+}
+
+func TestStore_RunsAndCompare(t *testing.T) {
+	store, _ := openTestStore(t)
+	ctx := context.Background()
+	calm, windy := testResult(t, 5), testResult(t, 40)
+
+	may := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	later, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc", RecordedAt: may.AddDate(0, 1, 0)}, windy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	earlier, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc", RecordedAt: may}, calm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Save(ctx, Run{Scenario: "Other", ScenarioHash: "def", RecordedAt: may}, calm); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := store.Runs(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Runs failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != earlier || runs[1].ID != later {
+		t.Errorf("Expected runs %d then %d in recorded order, got %+v", earlier, later, runs)
+	}
+	if string(runs[0].Parameters) != "null" {
+		t.Errorf("Expected omitted parameters to be stored as null, got %s", runs[0].Parameters)
+	}
+	if all, err := store.Runs(ctx, ""); err != nil || len(all) != 3 {
+		t.Errorf("Expected all 3 runs, got %d (%v)", len(all), err)
+	}
+
+	diff, err := store.Compare(ctx, earlier, later)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if want := windy.TotalCapacity - calm.TotalCapacity; diff.TotalDelta != want || len(diff.Days) != 2 {
+		t.Errorf("Expected a total delta of %v over 2 days, got %v over %d", want, diff.TotalDelta, len(diff.Days))
+	}
+}
+
+func TestStore_Errors(t *testing.T) {
+	store, db := openMemoryStore(t)
+	ctx := context.Background()
+	result := testResult(t, 5)
+
+	if _, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc"}, nil); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration without a result, got %v", err)
+	}
+	if _, err := store.Save(ctx, Run{Scenario: "Baseline"}, result); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration without a scenario hash, got %v", err)
+	}
+
+	// A failed window leaves no part of the run behind
+	db.failWindow = 0
+	if _, err := store.Save(ctx, Run{Scenario: "Baseline", ScenarioHash: "abc"}, result); err == nil {
+		t.Error("Expected the failed window insert to be reported")
+	}
+	if len(db.runs) != 0 || len(db.windows) != 0 {
+		t.Errorf("Expected the failed save to be rolled back, got %d runs and %d windows", len(db.runs), len(db.windows))
+	}
+
+	if _, err := store.Run(ctx, 42); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Expected ErrRunNotFound, got %v", err)
+	}
+	if _, err := store.Compare(ctx, 1, 42); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Expected ErrRunNotFound comparing a missing run, got %v", err)
+	}
+}
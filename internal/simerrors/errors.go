@@ -0,0 +1,83 @@
+// Package simerrors defines the error values shared by the airport model, policies, and
+// simulation engine, so library consumers can use errors.Is and errors.As instead of
+// matching error strings.
+package simerrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrRunwayNotFound matches errors caused by a reference to a runway or runway end
+	// that the airport does not have.
+	ErrRunwayNotFound = errors.New("runway not found")
+
+	// ErrPolicyConflict matches errors caused by policies that cannot be combined
+	// in one simulation.
+	ErrPolicyConflict = errors.New("policy conflict")
+
+	// ErrInvalidConfiguration matches errors caused by invalid airport, policy,
+	// or world state configuration values.
+	ErrInvalidConfiguration = errors.New("invalid configuration")
+)
+
+// RunwayNotFoundError reports a reference to a runway or runway end the airport does not have.
+// It matches ErrRunwayNotFound.
+type RunwayNotFoundError struct {
+	RunwayID string // Runway designation or runway end that was not found
+}
+
+// Error returns the error message.
+func (e *RunwayNotFoundError) Error() string {
+	return fmt.Sprintf("runway %s not found in airport", e.RunwayID)
+}
+
+// Is reports whether target is ErrRunwayNotFound.
+func (e *RunwayNotFoundError) Is(target error) bool {
+	return target == ErrRunwayNotFound
+}
+
+// PolicyConflictError reports policies that cannot be combined in one simulation.
+// It matches ErrPolicyConflict.
+type PolicyConflictError struct {
+	Policies []string // Names of the conflicting policies
+	Reason   string   // Why they conflict
+}
+
+// Error returns the error message.
+func (e *PolicyConflictError) Error() string {
+	return fmt.Sprintf("conflicting policies %s: %s", strings.Join(e.Policies, ", "), e.Reason)
+}
+
+// Is reports whether target is ErrPolicyConflict.
+func (e *PolicyConflictError) Is(target error) bool {
+	return target == ErrPolicyConflict
+}
+
+// ConfigurationError reports an invalid configuration value. It matches
+// ErrInvalidConfiguration and unwraps to any error wrapped in its message.
+type ConfigurationError struct {
+	err error
+}
+
+// Invalidf formats an error message (supporting %w) as a ConfigurationError.
+func Invalidf(format string, args ...any) error {
+	return &ConfigurationError{err: fmt.Errorf(format, args...)}
+}
+
+// Error returns the error message.
+func (e *ConfigurationError) Error() string {
+	return e.err.Error()
+}
+
+// Is reports whether target is ErrInvalidConfiguration.
+func (e *ConfigurationError) Is(target error) bool {
+	return target == ErrInvalidConfiguration
+}
+
+// Unwrap returns the error wrapped by the message, if any.
+func (e *ConfigurationError) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
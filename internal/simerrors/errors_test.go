@@ -0,0 +1,48 @@
+package simerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRunwayNotFoundError(t *testing.T) {
+	err := fmt.Errorf("scheduling maintenance: %w", &RunwayNotFoundError{RunwayID: "09L"})
+
+	if !errors.Is(err, ErrRunwayNotFound) {
+		t.Error("Expected error to match ErrRunwayNotFound")
+	}
+	var notFound *RunwayNotFoundError
+	if !errors.As(err, &notFound) || notFound.RunwayID != "09L" {
+		t.Errorf("Expected RunwayNotFoundError for 09L, got %v", err)
+	}
+	if errors.Is(err, ErrInvalidConfiguration) {
+		t.Error("Expected error not to match ErrInvalidConfiguration")
+	}
+}
+
+func TestPolicyConflictError(t *testing.T) {
+	err := error(&PolicyConflictError{Policies: []string{"GateCapacityPolicy", "GateCapacityPolicy"}, Reason: "only one may be applied"})
+
+	if !errors.Is(err, ErrPolicyConflict) {
+		t.Error("Expected error to match ErrPolicyConflict")
+	}
+	if want := "conflicting policies GateCapacityPolicy, GateCapacityPolicy: only one may be applied"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestInvalidf(t *testing.T) {
+	sentinel := errors.New("wind speed cannot be negative")
+	err := Invalidf("wind change %d: %w", 3, sentinel)
+
+	if !errors.Is(err, ErrInvalidConfiguration) {
+		t.Error("Expected error to match ErrInvalidConfiguration")
+	}
+	if !errors.Is(err, sentinel) {
+		t.Error("Expected error to unwrap to the wrapped sentinel")
+	}
+	if want := "wind change 3: wind speed cannot be negative"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
@@ -0,0 +1,243 @@
+package simulation
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// advisorCurfewStep is how much the advisor shortens a curfew at either end.
+const advisorCurfewStep = time.Hour
+
+// SuggestionKind identifies what an advisor suggestion changes.
+type SuggestionKind int
+
+const (
+	// CompatibilitySuggestion makes two runways compatible at all hours
+	CompatibilitySuggestion SuggestionKind = iota
+	// ConfigurationSuggestion changes how the runway configuration follows the wind
+	ConfigurationSuggestion
+	// CurfewSuggestion shortens or lifts an airport curfew
+	CurfewSuggestion
+)
+
+// String returns the kind name.
+func (k SuggestionKind) String() string {
+	switch k {
+	case CompatibilitySuggestion:
+		return "Compatibility"
+	case ConfigurationSuggestion:
+		return "Configuration"
+	case CurfewSuggestion:
+		return "Curfew"
+	default:
+		return "Unknown"
+	}
+}
+
+// Suggestion is a change to the airport or its operation with the capacity a counterfactual
+// run of the simulation with the change achieved.
+type Suggestion struct {
+	Kind        SuggestionKind
+	Change      string  // Description of the change, e.g. "making 09R compatible with 08"
+	Capacity    float32 // Capacity with the change
+	Gain        float32 // Movements gained over the current capacity
+	GainPercent float64 // Gain as a percentage of the current capacity (0 when it is zero)
+}
+
+// String formats the suggestion for display, e.g. "making 09R compatible with 08 would add
+// 16200 movements (+3.1%)".
+func (s Suggestion) String() string {
+	return fmt.Sprintf("%s would add %.0f movements (+%.1f%%)", s.Change, s.Gain, s.GainPercent)
+}
+
+// advisorCandidate is a change for the advisor to evaluate: the simulation with it applied.
+type advisorCandidate struct {
+	kind   SuggestionKind
+	change string
+	sim    *Simulation
+}
+
+// Advise suggests the runway compatibility, configuration and curfew changes that would gain
+// the most capacity, best first, returning at most limit of them (all if limit is not
+// positive). The candidates come from the detailed result of a run of the simulation (nil to
+// run it first):
+//   - runways that are incompatible, or compatible only at some hours, while one of them was
+//     active and the other available but idle: made compatible at all hours
+//   - airport curfews (CurfewPolicy), if a curfew was in effect: shortened by an hour at
+//     either end, or lifted
+//   - a minimum dwell or wind deadband (see RunwayManagerOptions): removed
+//
+// Each candidate is evaluated by a counterfactual run of the simulation with the change. Like
+// CapacityWaterfall, every run uses the same seed, so gains come only from the change. Only
+// changes gaining capacity are suggested.
+func (s *Simulation) Advise(ctx context.Context, result *Result, limit int) ([]Suggestion, error) {
+	s = s.enabledPolicies()
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	all := make([]int, len(s.policies))
+	for i := range all {
+		all[i] = i
+	}
+
+	var current float32
+	var err error
+	if result == nil {
+		if result, err = s.withPolicies(all).WithSeed(seed).RunResult(ctx); err != nil {
+			return nil, err
+		}
+		current = result.TotalCapacity
+	} else if current, err = s.withPolicies(all).WithSeed(seed).Run(ctx); err != nil {
+		return nil, err
+	}
+
+	candidates := s.compatibilityCandidates(ctx, result, all)
+	candidates = append(candidates, s.configurationCandidates(all)...)
+	candidates = append(candidates, s.curfewCandidates(result, all)...)
+	s.log(ctx).InfoContext(ctx, "Evaluating advisor candidates", "candidates", len(candidates))
+
+	var suggestions []Suggestion
+	for _, candidate := range candidates {
+		capacity, err := candidate.sim.WithSeed(seed).Run(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating %s: %w", candidate.change, err)
+		}
+		if capacity <= current {
+			continue
+		}
+		suggestion := Suggestion{Kind: candidate.kind, Change: candidate.change, Capacity: capacity, Gain: capacity - current}
+		if current > 0 {
+			suggestion.GainPercent = float64(suggestion.Gain) / float64(current) * 100
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		return cmp.Compare(b.Gain, a.Gain)
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// compatibilityCandidates returns the runway pairs of an explicit compatibility graph that are
+// incompatible, or compatible only at some hours, while the result shows one of them active
+// and the other available but idle, made compatible at all hours.
+func (s *Simulation) compatibilityCandidates(ctx context.Context, result *Result, all []int) []advisorCandidate {
+	ap := s.applyPlugins(ctx, nil)
+	rc := ap.RunwayCompatibility
+	if rc == nil || rc.CompatibleWith == nil {
+		return nil
+	}
+
+	limited := make(map[[2]string]bool, len(rc.Hours))
+	for _, hours := range rc.Hours {
+		limited[[2]string{hours.Runway, hours.OtherRunway}] = true
+		limited[[2]string{hours.OtherRunway, hours.Runway}] = true
+	}
+
+	var candidates []advisorCandidate
+	for i, runway := range ap.Runways {
+		for _, other := range ap.Runways[i+1:] {
+			id, otherID := runway.RunwayDesignation, other.RunwayDesignation
+			if rc.IsCompatible(id, otherID) && !limited[[2]string{id, otherID}] {
+				continue
+			}
+			if !idleAlongside(result, id, otherID) {
+				continue
+			}
+
+			sim := s.withPolicies(all)
+			sim.preSimulationPlugins = append(slices.Clip(sim.preSimulationPlugins), RunwayCompatibilityPlugin{RunwayDesignation: id, OtherRunwayDesignation: otherID})
+			change := fmt.Sprintf("making %s compatible with %s", id, otherID)
+			if rc.IsCompatible(id, otherID) {
+				change = fmt.Sprintf("letting %s and %s operate together at all hours", id, otherID)
+			}
+			candidates = append(candidates, advisorCandidate{kind: CompatibilitySuggestion, change: change, sim: sim})
+		}
+	}
+	return candidates
+}
+
+// idleAlongside reports whether the result has a window in which one of the runways was
+// active and the other available but idle.
+func idleAlongside(result *Result, runway, other string) bool {
+	for _, w := range result.Windows {
+		active, otherActive := slices.Contains(w.ActiveRunways, runway), slices.Contains(w.ActiveRunways, other)
+		if active == otherActive {
+			continue
+		}
+		idle := other
+		if otherActive {
+			idle = runway
+		}
+		if !slices.Contains(w.UnavailableRunways, idle) {
+			return true
+		}
+	}
+	return false
+}
+
+// configurationCandidates returns the simulation without a minimum dwell or wind deadband, if
+// it has either.
+func (s *Simulation) configurationCandidates(all []int) []advisorCandidate {
+	options := s.runwayOptions
+	if options.MinimumDwell <= 0 && options.WindDeadbandKnots <= 0 {
+		return nil
+	}
+	options.MinimumDwell, options.WindDeadbandKnots = 0, 0
+	return []advisorCandidate{{
+		kind:   ConfigurationSuggestion,
+		change: "changing runway configuration as soon as the wind allows (no minimum dwell or wind deadband)",
+		sim:    s.withPolicies(all).WithRunwayManagerOptions(options),
+	}}
+}
+
+// curfewCandidates returns the simulation with each airport curfew shortened at either end
+// and lifted, if the result shows a curfew in effect.
+func (s *Simulation) curfewCandidates(result *Result, all []int) []advisorCandidate {
+	if !slices.ContainsFunc(result.Windows, func(w WindowResult) bool { return w.CurfewActive }) {
+		return nil
+	}
+
+	var candidates []advisorCandidate
+	for i, p := range s.policies {
+		curfew, ok := p.(*policy.CurfewPolicy)
+		if !ok {
+			continue
+		}
+		start, end := curfew.GetStartTime(), curfew.GetEndTime()
+		label := fmt.Sprintf("the %s-%s curfew", start.Format("15:04"), end.Format("15:04"))
+
+		if end.Sub(start) > advisorCurfewStep {
+			later, earlier := start.Add(advisorCurfewStep), end.Add(-advisorCurfewStep)
+			for _, shortened := range []struct {
+				change     string
+				start, end time.Time
+			}{
+				{fmt.Sprintf("starting %s at %s", label, later.Format("15:04")), later, end},
+				{fmt.Sprintf("ending %s at %s", label, earlier.Format("15:04")), start, earlier},
+			} {
+				replacement, err := policy.NewCurfewPolicy(shortened.start, shortened.end)
+				if err != nil {
+					continue
+				}
+				sim := s.withPolicies(all)
+				sim.policies[i] = replacement
+				candidates = append(candidates, advisorCandidate{kind: CurfewSuggestion, change: shortened.change, sim: sim})
+			}
+		}
+
+		without := append(slices.Clone(all[:i]), all[i+1:]...)
+		candidates = append(candidates, advisorCandidate{kind: CurfewSuggestion, change: "lifting " + label, sim: s.withPolicies(without)})
+	}
+	return candidates
+}
@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+func TestSimulation_Advise(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: time.Minute},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: time.Minute},
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{"09": {}, "18": {}}),
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sim, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(start, start.Add(36*time.Hour)).
+		WithSeed(1).
+		AddCurfewPolicy(start.Add(11*time.Hour), start.Add(18*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, err := sim.Advise(context.Background(), nil, 0)
+	if err != nil {
+		t.Fatalf("Advise failed: %v", err)
+	}
+
+	// One runway at 60 movements per hour operates for the 28 hours outside the curfew (the
+	// first night and 23:00 on the second): 1680 movements. Each hour of curfew removed gains 60
+	want := []Suggestion{
+		{Kind: CompatibilitySuggestion, Change: "making 09 compatible with 18", Capacity: 3360, Gain: 1680, GainPercent: 100},
+		{Kind: CurfewSuggestion, Change: "lifting the 23:00-06:00 curfew", Capacity: 2160, Gain: 480, GainPercent: 480.0 / 1680 * 100},
+		{Kind: CurfewSuggestion, Change: "starting the 23:00-06:00 curfew at 00:00", Capacity: 1800, Gain: 120, GainPercent: 120.0 / 1680 * 100},
+		{Kind: CurfewSuggestion, Change: "ending the 23:00-06:00 curfew at 05:00", Capacity: 1740, Gain: 60, GainPercent: 60.0 / 1680 * 100},
+	}
+	if len(suggestions) != len(want) {
+		t.Fatalf("Expected %d suggestions, got %v", len(want), suggestions)
+	}
+	for i, s := range suggestions {
+		if s.Kind != want[i].Kind || s.Change != want[i].Change || math.Abs(float64(s.Capacity-want[i].Capacity)) > 0.01 ||
+			math.Abs(float64(s.Gain-want[i].Gain)) > 0.01 || math.Abs(s.GainPercent-want[i].GainPercent) > 1e-3 {
+			t.Errorf("Suggestion %d: expected %+v, got %+v", i, want[i], s)
+		}
+	}
+	if got := suggestions[0].String(); got != "making 09 compatible with 18 would add 1680 movements (+100.0%)" {
+		t.Errorf("Unexpected suggestion string %q", got)
+	}
+
+	// The limit keeps the best suggestions, and the simulation itself is unchanged
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	top, err := sim.Advise(context.Background(), result, 2)
+	if err != nil {
+		t.Fatalf("Advise failed: %v", err)
+	}
+	if !slices.Equal(top, suggestions[:2]) {
+		t.Errorf("Expected the top 2 suggestions %v, got %v", suggestions[:2], top)
+	}
+	if result.TotalCapacity != 1680 {
+		t.Errorf("Expected the simulation to keep its 1680 movements, got %v", result.TotalCapacity)
+	}
+}
+
+func TestSimulation_AdviseConfiguration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulation(capacityTableAirport(), testEngineLogger()).
+		WithPeriod(start, start.AddDate(0, 0, 1)).
+		WithRunwayManagerOptions(RunwayManagerOptions{MinimumDwell: 3 * time.Hour})
+
+	// In calm wind the best configuration is selected at the start and kept: nothing to gain
+	suggestions, err := sim.Advise(context.Background(), nil, 0)
+	if err != nil {
+		t.Fatalf("Advise failed: %v", err)
+	}
+	if len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %v", suggestions)
+	}
+}
@@ -0,0 +1,363 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// PolicyWarningKind categorizes a conflict or redundancy between policy events.
+type PolicyWarningKind int
+
+const (
+	// OverlappingMaintenance indicates two policies close the same runway for maintenance at once
+	OverlappingMaintenance PolicyWarningKind = iota
+
+	// MaintenanceDuringClosure indicates maintenance is scheduled while the runway is already
+	// closed by another restriction (closure, tide restriction, or runway curfew)
+	MaintenanceDuringClosure
+
+	// MaintenanceDuringUnusableWind indicates maintenance is scheduled while the wind already
+	// makes the runway unusable in both directions
+	MaintenanceDuringUnusableWind
+)
+
+// String returns the string representation of the warning kind.
+func (k PolicyWarningKind) String() string {
+	switch k {
+	case OverlappingMaintenance:
+		return "OverlappingMaintenance"
+	case MaintenanceDuringClosure:
+		return "MaintenanceDuringClosure"
+	case MaintenanceDuringUnusableWind:
+		return "MaintenanceDuringUnusableWind"
+	default:
+		return "Unknown"
+	}
+}
+
+// PolicyWarning describes conflicting or redundant events generated by the simulation's policies.
+type PolicyWarning struct {
+	Kind     PolicyWarningKind
+	RunwayID string    // Runway affected
+	Start    time.Time // Start of the affected period
+	End      time.Time // End of the affected period
+	Policies []string  // Names of the policies whose events are involved
+	Detail   string    // Human-readable description
+}
+
+// String returns a one-line description of the warning.
+func (w PolicyWarning) String() string {
+	return fmt.Sprintf("%s to %s runway %s: %s",
+		w.Start.Format(time.RFC3339), w.End.Format(time.RFC3339), w.RunwayID, w.Detail)
+}
+
+// runwayInterval is a period during which a runway is closed by one policy's events.
+type runwayInterval struct {
+	runwayID string
+	source   int    // Index of the policy in the simulation
+	policy   string // Name of the policy
+	cause    string // "maintenance", or what else closed the runway
+	start    time.Time
+	end      time.Time
+}
+
+// windSample is the wind in effect from a point in time until the next sample.
+type windSample struct {
+	time          time.Time
+	speedKnots    float64
+	gustKnots     float64
+	directionTrue float64
+}
+
+// Analyze generates every policy's events without running the engine and reports events that
+// conflict with or duplicate each other: maintenance windows from different policies that
+// overlap on the same runway, maintenance on a runway already closed by another restriction,
+// and maintenance while the wind already makes the runway unusable.
+//
+// Airport-wide curfews are not reported; they are the intended slots for maintenance
+// (see IntelligentMaintenancePolicy). Stochastic policies only generate the same events as a
+// later run when the simulation is seeded with WithSeed.
+// Warnings are returned in chronological order.
+func (s *Simulation) Analyze(ctx context.Context) ([]PolicyWarning, error) {
+	s = s.enabledPolicies()
+	ap := s.applyPlugins(ctx, nil)
+
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	s.seedPolicies(seed)
+
+	var maintenance, closures []runwayInterval
+	var winds []windSample
+	startTime, endTime := s.period()
+
+	// Generate each policy into its own world so events can be attributed to it
+	for i, p := range s.policies {
+		world := NewWorld(ap, startTime, endTime)
+		if ip, ok := p.(policy.InitialStatePolicy); ok {
+			if err := ip.SetInitialState(ctx, world); err != nil {
+				return nil, fmt.Errorf("setting initial state for %s: %w", p.Name(), err)
+			}
+		}
+		if err := p.GenerateEvents(ctx, world); err != nil {
+			return nil, fmt.Errorf("generating events for %s: %w", p.Name(), err)
+		}
+
+		m, c, w := collectPolicyTimeline(ap, i, p.Name(), world)
+		maintenance = append(maintenance, m...)
+		closures = append(closures, c...)
+		winds = append(winds, w...)
+	}
+
+	sortIntervals(maintenance)
+	sortIntervals(closures)
+	sort.SliceStable(winds, func(i, j int) bool { return winds[i].time.Before(winds[j].time) })
+
+	var warnings []PolicyWarning
+	warnings = append(warnings, overlappingMaintenance(maintenance)...)
+	warnings = append(warnings, maintenanceDuringClosures(maintenance, closures)...)
+	warnings = append(warnings, maintenanceDuringUnusableWind(ap, maintenance, winds, endTime)...)
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if !warnings[i].Start.Equal(warnings[j].Start) {
+			return warnings[i].Start.Before(warnings[j].Start)
+		}
+		return warnings[i].RunwayID < warnings[j].RunwayID
+	})
+	return warnings, nil
+}
+
+// collectPolicyTimeline drains the events one policy scheduled into world and pairs start
+// and end events into maintenance and other closure intervals, starting from the initial
+// state the policy set. Wind changes are returned as samples.
+func collectPolicyTimeline(ap airport.Airport, source int, policyName string, world *World) ([]runwayInterval, []runwayInterval, []windSample) {
+	var maintenance, closures []runwayInterval
+	var winds []windSample
+
+	// Initial state policies set the wind at the start directly instead of scheduling events
+	if world.WindSpeed != 0 {
+		winds = append(winds, windSample{time: world.StartTime, speedKnots: world.WindSpeed, gustKnots: world.WindGust, directionTrue: world.WindDirection})
+	}
+
+	open := make(map[string]time.Time) // runwayID + cause -> start
+	curfews := make(map[*event.CurfewRestriction]time.Time)
+
+	// ... and close runways at the start the same way, so their first end event closes an
+	// interval from the start
+	closedAtStart := make(map[string]bool)
+	for runwayID, state := range world.RunwayStates {
+		if !state.Available {
+			closedAtStart[runwayID] = true
+		}
+	}
+
+	closeInterval := func(intervals []runwayInterval, runwayID, cause string, end time.Time) []runwayInterval {
+		key := runwayID + "|" + cause
+		start, ok := open[key]
+		switch {
+		case ok:
+			delete(open, key)
+		case closedAtStart[runwayID]:
+			start = world.StartTime
+			delete(closedAtStart, runwayID)
+		default:
+			return intervals
+		}
+		return append(intervals, runwayInterval{runwayID: runwayID, source: source, policy: policyName, cause: cause, start: start, end: end})
+	}
+
+	for world.Events.HasNext() {
+		evt := world.Events.Pop()
+		switch e := evt.(type) {
+		case *event.RunwayMaintenanceStartEvent:
+			open[e.RunwayID()+"|maintenance"] = e.Time()
+		case *event.RunwayMaintenanceEndEvent:
+			maintenance = closeInterval(maintenance, e.RunwayID(), "maintenance", e.Time())
+		case *event.RunwayClosureStartEvent:
+			open[e.RunwayID()+"|"+e.Reason()] = e.Time()
+		case *event.RunwayClosureEndEvent:
+			closures = closeInterval(closures, e.RunwayID(), e.Reason(), e.Time())
+		case *event.TideRestrictionStartEvent:
+			open[e.RunwayID()+"|tide restriction"] = e.Time()
+		case *event.TideRestrictionEndEvent:
+			closures = closeInterval(closures, e.RunwayID(), "tide restriction", e.Time())
+		case *event.RunwayCurfewStartEvent:
+			curfews[e.Restriction()] = e.Time()
+		case *event.RunwayCurfewEndEvent:
+			start, ok := curfews[e.Restriction()]
+			if !ok {
+				continue
+			}
+			delete(curfews, e.Restriction())
+			for _, runwayID := range closedRunways(ap, e.Restriction()) {
+				closures = append(closures, runwayInterval{runwayID: runwayID, source: source, policy: policyName, cause: "runway curfew", start: start, end: e.Time()})
+			}
+		case *event.WindChangeEvent:
+			winds = append(winds, windSample{time: e.Time(), speedKnots: e.GetSpeed(), gustKnots: e.GetGust(), directionTrue: e.GetDirection()})
+			event.Release(e)
+		}
+	}
+
+	return maintenance, closures, winds
+}
+
+// closedRunways returns the runways a curfew restriction closes completely: every operation
+// is stopped on both runway ends. Airport-wide restrictions close no individual runway.
+func closedRunways(ap airport.Airport, restriction *event.CurfewRestriction) []string {
+	if len(restriction.RunwayEnds) == 0 || len(restriction.Operations) > 0 || restriction.MaxMovementsPerHour > 0 {
+		return nil
+	}
+
+	var closed []string
+	for _, runway := range ap.Runways {
+		if restriction.AppliesToRunwayEnd(runway.End(false).Designation) &&
+			restriction.AppliesToRunwayEnd(runway.End(true).Designation) {
+			closed = append(closed, runway.RunwayDesignation)
+		}
+	}
+	return closed
+}
+
+// sortIntervals orders intervals by runway, then start time.
+func sortIntervals(intervals []runwayInterval) {
+	sort.SliceStable(intervals, func(i, j int) bool {
+		if intervals[i].runwayID != intervals[j].runwayID {
+			return intervals[i].runwayID < intervals[j].runwayID
+		}
+		return intervals[i].start.Before(intervals[j].start)
+	})
+}
+
+// overlappingMaintenance reports maintenance windows from different policies that overlap
+// on the same runway. Intervals must be sorted with sortIntervals.
+func overlappingMaintenance(maintenance []runwayInterval) []PolicyWarning {
+	var warnings []PolicyWarning
+	for i, a := range maintenance {
+		for _, b := range maintenance[i+1:] {
+			if b.runwayID != a.runwayID || !b.start.Before(a.end) {
+				break
+			}
+			if b.source == a.source {
+				continue
+			}
+			start, end := b.start, minTime(a.end, b.end)
+			warnings = append(warnings, PolicyWarning{
+				Kind:     OverlappingMaintenance,
+				RunwayID: a.runwayID,
+				Start:    start,
+				End:      end,
+				Policies: []string{a.policy, b.policy},
+				Detail:   fmt.Sprintf("maintenance from %s and %s overlaps for %v", a.policy, b.policy, end.Sub(start)),
+			})
+		}
+	}
+	return warnings
+}
+
+// maintenanceDuringClosures reports maintenance windows that overlap a period in which
+// another restriction already closes the runway. Both slices must be sorted with sortIntervals.
+func maintenanceDuringClosures(maintenance, closures []runwayInterval) []PolicyWarning {
+	var warnings []PolicyWarning
+	for _, m := range maintenance {
+		for _, c := range closures {
+			if c.runwayID != m.runwayID || !c.end.After(m.start) || !c.start.Before(m.end) {
+				continue
+			}
+			start, end := maxTime(m.start, c.start), minTime(m.end, c.end)
+			warnings = append(warnings, PolicyWarning{
+				Kind:     MaintenanceDuringClosure,
+				RunwayID: m.runwayID,
+				Start:    start,
+				End:      end,
+				Policies: []string{m.policy, c.policy},
+				Detail:   fmt.Sprintf("maintenance from %s falls within a %s from %s for %v", m.policy, c.cause, c.policy, end.Sub(start)),
+			})
+		}
+	}
+	return warnings
+}
+
+// maintenanceDuringUnusableWind reports maintenance windows during which the wind already
+// makes the runway unusable in both directions. Winds must be sorted chronologically;
+// the wind is calm until the first sample.
+func maintenanceDuringUnusableWind(ap airport.Airport, maintenance []runwayInterval, winds []windSample, endTime time.Time) []PolicyWarning {
+	if len(winds) == 0 {
+		return nil
+	}
+
+	runways := make(map[string]airport.Runway, len(ap.Runways))
+	for _, runway := range ap.Runways {
+		runways[runway.RunwayDesignation] = runway
+	}
+
+	var warnings []PolicyWarning
+	for _, m := range maintenance {
+		runway, ok := runways[m.runwayID]
+		if !ok || !runway.HasWindLimits() {
+			continue
+		}
+
+		// First sample in effect at the start of maintenance
+		i := sort.Search(len(winds), func(i int) bool { return winds[i].time.After(m.start) }) - 1
+
+		var unusable time.Duration
+		var first, last time.Time
+		for ; i < len(winds); i++ {
+			segmentStart, segmentEnd := m.start, endTime
+			if i >= 0 {
+				segmentStart = maxTime(m.start, winds[i].time)
+			}
+			if i+1 < len(winds) {
+				segmentEnd = winds[i+1].time
+			}
+			segmentEnd = minTime(segmentEnd, m.end)
+			if !segmentStart.Before(m.end) {
+				break
+			}
+			if i < 0 || !segmentEnd.After(segmentStart) ||
+				runwayUsableInWind(runway, winds[i].speedKnots, winds[i].gustKnots, winds[i].directionTrue) {
+				continue
+			}
+
+			if unusable == 0 {
+				first = segmentStart
+			}
+			last = segmentEnd
+			unusable += segmentEnd.Sub(segmentStart)
+		}
+
+		if unusable > 0 {
+			warnings = append(warnings, PolicyWarning{
+				Kind:     MaintenanceDuringUnusableWind,
+				RunwayID: m.runwayID,
+				Start:    first,
+				End:      last,
+				Policies: []string{m.policy},
+				Detail:   fmt.Sprintf("runway is wind-unusable for %v of the %v maintenance window from %s", unusable, m.end.Sub(m.start), m.policy),
+			})
+		}
+	}
+	return warnings
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
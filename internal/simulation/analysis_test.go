@@ -0,0 +1,114 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+func TestSimulation_Analyze(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second, CrosswindLimitKnots: 20}
+	ap := airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}
+	maintenance := MaintenanceSchedule{RunwayDesignations: []string{"09"}, Duration: 8 * time.Hour, Frequency: 180 * 24 * time.Hour}
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	analyze := func(t *testing.T, sim *Simulation) []PolicyWarning {
+		t.Helper()
+		warnings, err := sim.Analyze(context.Background())
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		return warnings
+	}
+
+	t.Run("single maintenance policy has no warnings", func(t *testing.T) {
+		warnings := analyze(t, NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance))
+		if len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("overlapping maintenance from two policies", func(t *testing.T) {
+		sim := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance).AddMaintenancePolicy(maintenance)
+		warnings := analyze(t, sim)
+		if len(warnings) != 2 {
+			t.Fatalf("Expected one warning per maintenance window, got %v", warnings)
+		}
+		first := warnings[0]
+		if first.Kind != OverlappingMaintenance || first.RunwayID != "09" {
+			t.Errorf("Expected overlapping maintenance on 09, got %v", first)
+		}
+		if !first.Start.Equal(jan1) || !first.End.Equal(jan1.Add(8*time.Hour)) {
+			t.Errorf("Expected overlap 00:00-08:00 on 1 January, got %v to %v", first.Start, first.End)
+		}
+	})
+
+	t.Run("planned maintenance under way at the start", func(t *testing.T) {
+		sim, err := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance).AddPlannedMaintenancePolicy([]MaintenanceWindow{
+			{RunwayDesignation: "09", Start: jan1.Add(-4 * time.Hour), End: jan1.Add(4 * time.Hour)},
+		})
+		if err != nil {
+			t.Fatalf("AddPlannedMaintenancePolicy failed: %v", err)
+		}
+		warnings := analyze(t, sim)
+		if len(warnings) != 1 {
+			t.Fatalf("Expected one warning, got %v", warnings)
+		}
+		first := warnings[0]
+		if first.Kind != OverlappingMaintenance || !first.Start.Equal(jan1) || !first.End.Equal(jan1.Add(4*time.Hour)) {
+			t.Errorf("Expected overlapping maintenance 00:00-04:00 on 1 January, got %v", first)
+		}
+	})
+
+	t.Run("maintenance during runway curfew closing both ends", func(t *testing.T) {
+		sim, err := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance).AddRunwayCurfewPolicy(RunwayCurfewConfiguration{
+			StartTime:  jan1.Add(2 * time.Hour),
+			EndTime:    jan1.Add(4 * time.Hour),
+			RunwayEnds: []string{"09", "27"},
+		})
+		if err != nil {
+			t.Fatalf("AddRunwayCurfewPolicy failed: %v", err)
+		}
+		warnings := analyze(t, sim)
+		if len(warnings) == 0 {
+			t.Fatal("Expected a warning, got none")
+		}
+		first := warnings[0]
+		if first.Kind != MaintenanceDuringClosure {
+			t.Errorf("Expected MaintenanceDuringClosure, got %v", first.Kind)
+		}
+		if !first.Start.Equal(jan1.Add(2*time.Hour)) || !first.End.Equal(jan1.Add(4*time.Hour)) {
+			t.Errorf("Expected overlap 02:00-04:00 on 1 January, got %v to %v", first.Start, first.End)
+		}
+	})
+
+	t.Run("runway curfew on one end does not close the runway", func(t *testing.T) {
+		sim, err := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance).AddRunwayCurfewPolicy(RunwayCurfewConfiguration{
+			StartTime:  jan1.Add(2 * time.Hour),
+			EndTime:    jan1.Add(4 * time.Hour),
+			RunwayEnds: []string{"27"},
+		})
+		if err != nil {
+			t.Fatalf("AddRunwayCurfewPolicy failed: %v", err)
+		}
+		if warnings := analyze(t, sim); len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("maintenance while wind makes runway unusable", func(t *testing.T) {
+		sim, err := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(maintenance).AddWindPolicy(30, 180)
+		if err != nil {
+			t.Fatalf("AddWindPolicy failed: %v", err)
+		}
+		warnings := analyze(t, sim)
+		if len(warnings) != 2 {
+			t.Fatalf("Expected one warning per maintenance window, got %v", warnings)
+		}
+		if warnings[0].Kind != MaintenanceDuringUnusableWind {
+			t.Errorf("Expected MaintenanceDuringUnusableWind, got %v", warnings[0].Kind)
+		}
+	})
+}
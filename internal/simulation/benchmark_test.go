@@ -0,0 +1,168 @@
+package simulation
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// createSixRunwayAirport builds three pairs of parallel runways where each pair operates
+// independently but crosses the other two pairs.
+func createSixRunwayAirport() airport.Airport {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, LengthMeters: 3500, MinimumSeparation: 90 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, LengthMeters: 3200, MinimumSeparation: 90 * time.Second},
+		{RunwayDesignation: "04L", TrueBearing: 40, LengthMeters: 3000, MinimumSeparation: 100 * time.Second},
+		{RunwayDesignation: "04R", TrueBearing: 40, LengthMeters: 2800, MinimumSeparation: 100 * time.Second},
+		{RunwayDesignation: "14L", TrueBearing: 140, LengthMeters: 2600, MinimumSeparation: 110 * time.Second},
+		{RunwayDesignation: "14R", TrueBearing: 140, LengthMeters: 2400, MinimumSeparation: 110 * time.Second},
+	}
+	for i := range runways {
+		runways[i].SurfaceType = airport.Asphalt
+		runways[i].CrosswindLimitKnots = 25
+		runways[i].TailwindLimitKnots = 10
+	}
+
+	return airport.Airport{
+		Name:    "Benchmark",
+		Runways: runways,
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{
+			"09L": {"09R"},
+			"09R": {"09L"},
+			"04L": {"04R"},
+			"04R": {"04L"},
+			"14L": {"14R"},
+			"14R": {"14L"},
+		}),
+	}
+}
+
+// hourlyWindSchedule returns a year of hourly wind changes veering through every direction
+// over the day, so the active configuration changes several times a day.
+func hourlyWindSchedule(start time.Time) []WindChange {
+	var schedule []WindChange
+	for t := start; t.Before(start.AddDate(1, 0, 0)); t = t.Add(time.Hour) {
+		schedule = append(schedule, WindChange{
+			Timestamp:     t,
+			SpeedKnots:    float64(5 + t.Hour()%12*2),
+			DirectionTrue: float64(t.Hour() * 15),
+		})
+	}
+	return schedule
+}
+
+// newYearSimulation builds the performance budget scenario: a one-year, six-runway
+// simulation with a nightly curfew, hourly wind changes and jittered weekly maintenance.
+func newYearSimulation(tb testing.TB) *Simulation {
+	start, _ := simulationPeriod()
+	sim, err := NewSimulation(createSixRunwayAirport(), testEngineLogger()).
+		AddCurfewPolicy(start.Add(23*time.Hour), start.Add(30*time.Hour))
+	if err != nil {
+		tb.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+	if sim, err = sim.AddScheduledWindPolicy(hourlyWindSchedule(start)); err != nil {
+		tb.Fatalf("AddScheduledWindPolicy failed: %v", err)
+	}
+	return sim.AddMaintenancePolicy(MaintenanceSchedule{
+		RunwayDesignations: []string{"09L", "04R"},
+		Duration:           6 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+		Jitter:             12 * time.Hour,
+	})
+}
+
+// BenchmarkSimulation_YearSixRunways measures the performance budget scenario (see README).
+func BenchmarkSimulation_YearSixRunways(b *testing.B) {
+	sim := newYearSimulation(b).WithSeed(1)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := sim.RunResult(context.Background()); err != nil {
+			b.Fatalf("RunResult failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkSimulation_MonteCarloBatch measures a batch of differently seeded runs of the
+// performance budget scenario, as used to estimate capacity under random maintenance timing.
+func BenchmarkSimulation_MonteCarloBatch(b *testing.B) {
+	const batchSize = 8
+	sim := newYearSimulation(b)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for seed := int64(0); seed < batchSize; seed++ {
+			if _, err := sim.WithSeed(seed).Run(context.Background()); err != nil {
+				b.Fatalf("Run failed: %v", err)
+			}
+		}
+	}
+}
+
+// BenchmarkEngine_LargeTimeline measures a one-year timeline of over 50k events: wind
+// changes every 10 minutes plus the configuration changes they trigger.
+func BenchmarkEngine_LargeTimeline(b *testing.B) {
+	engine := NewEngine(testEngineLogger())
+	start, end := simulationPeriod()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		world := NewWorld(createSixRunwayAirport(), start, end)
+		for t := start; t.Before(end); t = t.Add(10 * time.Minute) {
+			world.ScheduleEvent(event.NewWindChangeEvent(15, float64(t.Minute()*6+t.Hour()*15), t))
+		}
+
+		if _, err := engine.Calculate(context.Background(), world); err != nil {
+			b.Fatalf("Calculate failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkSimulation_ParallelMonths measures a one-year run whose months are independent
+// (the curfew ends before midnight and the wind is static), processed one month per core.
+func BenchmarkSimulation_ParallelMonths(b *testing.B) {
+	start, _ := simulationPeriod()
+	sim, err := NewSimulation(createSixRunwayAirport(), testEngineLogger()).
+		AddCurfewPolicy(start.Add(20*time.Hour), start.Add(23*time.Hour))
+	if err != nil {
+		b.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+	if sim, err = sim.AddWindPolicy(12, 270); err != nil {
+		b.Fatalf("AddWindPolicy failed: %v", err)
+	}
+	sim = sim.WithParallelism(runtime.GOMAXPROCS(0))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := sim.Run(context.Background()); err != nil {
+			b.Fatalf("Run failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkEngine_MinimumWindow measures a month of per-minute wind changes, as replayed from
+// one-minute observations, with and without coalescing into 5-minute windows.
+func BenchmarkEngine_MinimumWindow(b *testing.B) {
+	for _, minimum := range []time.Duration{0, 5 * time.Minute} {
+		b.Run(minimum.String(), func(b *testing.B) {
+			engine := NewEngine(testEngineLogger()).WithMinimumWindow(minimum)
+			start, _ := simulationPeriod()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				world := NewWorld(createSixRunwayAirport(), start, start.AddDate(0, 1, 0))
+				for t := start; t.Before(world.EndTime); t = t.Add(time.Minute) {
+					world.ScheduleEvent(event.NewWindChangeEvent(15, float64(t.Minute()*6+t.Hour()*15), t))
+				}
+
+				if _, err := engine.Calculate(context.Background(), world); err != nil {
+					b.Fatalf("Calculate failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// CapacityModel calculates the movements each runway of a configuration can handle, before
+// operational constraints (tailwind landing performance, curfews, runway lengths, operation
+// balance, GA reservations, rotation, gates and quotas) are applied. The engine uses it for
+// every window and the runway manager to rate candidate configurations, so an alternative model
+// (e.g., FAA Airfield Capacity Model equations or an empirical regression) changes both the
+// configuration selected and the capacity achieved in it. The default is SeparationModel.
+//
+// Runs processed in parallel (see Simulation.WithParallelism) call the model from several
+// goroutines, so implementations must be safe for concurrent use.
+type CapacityModel interface {
+	// RunwayCapacities sets capacities[i] to the movements in.Runways[i] can handle in its
+	// operating time. capacities has the same length as in.Runways.
+	RunwayCapacities(in CapacityInput, capacities []float32)
+}
+
+// CapacityInput describes a runway configuration operating for a period.
+type CapacityInput struct {
+	Runways        []CapacityRunway             // Runways of the configuration, sorted by designation
+	FleetMix       *airport.FleetMix            // Aircraft using the runways (nil = each runway's minimum separation)
+	Compatibility  *airport.RunwayCompatibility // Runway compatibility and capacity interactions (nil = none)
+	GustSeparation time.Duration                // Separation added to every movement in gusty conditions
+	WindSpeed      float64                      // Wind speed in knots
+	WindDirection  float64                      // Wind direction in degrees true
+}
+
+// CapacityRunway is one runway of a configuration given to a CapacityModel.
+type CapacityRunway struct {
+	*event.ActiveRunwayInfo               // Runway, direction and operations
+	OperatingTime           time.Duration // Time in the period the runway handles movements (less any direction changeover)
+}
+
+// SeparationModel is the default capacity model. Each runway handles one movement per
+// separation: its arrival, departure or mixed separation for the operation type assigned to
+// it, adjusted for the fleet mix (see airport.FleetMix.RunwayModeSeparation), plus any gust
+// separation, de-rated by the interaction factor with the other runways of the configuration
+// (see airport.CapacityInteraction). A configuration's capacity is the sum of its runways'.
+type SeparationModel struct{}
+
+// RunwayCapacities implements CapacityModel.
+func (SeparationModel) RunwayCapacities(in CapacityInput, capacities []float32) {
+	var runwayIDs []string
+	if in.Compatibility != nil && len(in.Compatibility.CapacityInteractions) > 0 {
+		runwayIDs = make([]string, len(in.Runways))
+		for i, r := range in.Runways {
+			runwayIDs[i] = r.RunwayDesignation
+		}
+	}
+
+	for i, r := range in.Runways {
+		capacities[i] = 0
+		separationSeconds := float32((in.FleetMix.RunwayModeSeparation(r.Runway, r.OperationType.SeparationMode()) + in.GustSeparation).Seconds())
+		if separationSeconds <= 0 {
+			continue
+		}
+		capacities[i] = float32(r.OperatingTime.Seconds()) / separationSeconds
+		if runwayIDs != nil {
+			capacities[i] *= float32(in.Compatibility.InteractionFactor(r.RunwayDesignation, runwayIDs))
+		}
+	}
+}
+
+// SetCapacityModel sets the model used to rate configuration capacity (nil = SeparationModel)
+// and recalculates the active configuration.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetCapacityModel(model CapacityModel) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	rm.model = model
+	clear(rm.selectedConfigs)
+	rm.calculateActiveConfiguration(ReasonCapacityModel)
+}
+
+// CapacityModel returns the model used to rate configuration capacity.
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) CapacityModel() CapacityModel {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.capacityModel()
+}
+
+// capacityModel returns the configured model, or SeparationModel if none is set.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) capacityModel() CapacityModel {
+	if rm.model == nil {
+		return SeparationModel{}
+	}
+	return rm.model
+}
+
+// rateConfiguration returns the capacity of a configuration over the options' reference
+// duration, with no gusts or direction changeovers.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) rateConfiguration(config map[string]*event.ActiveRunwayInfo) float32 {
+	reference := rm.options.referenceDuration()
+	runwayIDs := configurationRunwayIDs(config)
+	runways := make([]CapacityRunway, len(runwayIDs))
+	for i, runwayID := range runwayIDs {
+		runways[i] = CapacityRunway{ActiveRunwayInfo: config[runwayID], OperatingTime: reference}
+	}
+
+	capacities := make([]float32, len(runways))
+	rm.capacityModel().RunwayCapacities(CapacityInput{
+		Runways:       runways,
+		FleetMix:      rm.fleetMix,
+		Compatibility: rm.compatibility,
+		WindSpeed:     rm.windSpeed,
+		WindDirection: rm.windDirection,
+	}, capacities)
+
+	capacity := float32(0)
+	for _, c := range capacities {
+		capacity += c
+	}
+	return capacity
+}
+
+// SetCapacityModel sets the model used to calculate runway capacity (nil = SeparationModel)
+// and recalculates the active configuration.
+func (w *World) SetCapacityModel(model CapacityModel) {
+	w.RunwayManager.SetCapacityModel(model)
+
+	w.activeConfigMu.Lock()
+	w.ActiveRunwayConfiguration = w.RunwayManager.GetActiveConfiguration()
+	w.activeConfigMu.Unlock()
+	w.summarizeActiveConfiguration()
+	w.configurationHistory = nil
+	w.recordConfigurationChange("")
+}
@@ -0,0 +1,119 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// runwayRateModel is a capacity model giving each runway a fixed hourly rate, ignoring
+// separations and interactions.
+type runwayRateModel map[string]float32
+
+func (m runwayRateModel) RunwayCapacities(in CapacityInput, capacities []float32) {
+	for i, r := range in.Runways {
+		capacities[i] = m[r.RunwayDesignation] * float32(r.OperatingTime.Hours())
+	}
+}
+
+func TestSeparationModel_MatchesDefault(t *testing.T) {
+	ap := capacityTableAirport()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	defaultResult, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, end).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	modelResult, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, end).WithCapacityModel(SeparationModel{}).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if defaultResult.TotalCapacity != modelResult.TotalCapacity {
+		t.Errorf("Expected SeparationModel to match the default capacity %v, got %v", defaultResult.TotalCapacity, modelResult.TotalCapacity)
+	}
+
+	// Two parallel runways at 60 s separation for a day
+	if want := float32(2 * 24 * 60); math.Abs(float64(defaultResult.TotalCapacity-want)) > 0.01 {
+		t.Errorf("Expected %v movements, got %v", want, defaultResult.TotalCapacity)
+	}
+}
+
+func TestCapacityModel_SelectsAndRatesConfiguration(t *testing.T) {
+	ap := capacityTableAirport()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	model := runwayRateModel{"09L": 10, "09R": 10, "18": 100}
+
+	result, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(start, start.AddDate(0, 0, 1)).
+		WithCapacityModel(model).
+		RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// The crosswind runway rates highest under the model, so it is selected over the parallels
+	for _, w := range result.Windows {
+		if !slices.Equal(w.ActiveRunways, []string{"18"}) {
+			t.Fatalf("Expected runway 18 to be selected, got %v", w.ActiveRunways)
+		}
+	}
+	if want := float32(24 * 100); math.Abs(float64(result.TotalCapacity-want)) > 0.01 {
+		t.Errorf("Expected the model's %v movements, got %v", want, result.TotalCapacity)
+	}
+}
+
+func TestSimulation_CapacityTableUsesModel(t *testing.T) {
+	sim := NewSimulation(capacityTableAirport(), testEngineLogger()).WithCapacityModel(runwayRateModel{"18": 100})
+	table, err := sim.CapacityTable(0)
+	if err != nil {
+		t.Fatalf("CapacityTable failed: %v", err)
+	}
+	if len(table) == 0 || table[0].MovementsPerHour != 100 {
+		t.Fatalf("Expected runway 18 rated at 100 movements per hour first, got %+v", table)
+	}
+	if _, ok := table[0].Configuration["18"]; !ok {
+		t.Errorf("Expected the top row to be runway 18, got %q", table[0].Label)
+	}
+}
+
+func TestSeparationModel_OperationSeparations(t *testing.T) {
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"18"},
+		"18":  {"09L"},
+	})
+	compat.AirspaceConflicts = []airport.AirspaceConflict{
+		{RunwayEnd: "09L", Operation: airport.Arrivals, ConflictingRunwayEnd: "18", ConflictingOperation: airport.Departures},
+	}
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second, ArrivalSeparation: 45 * time.Second, MixedSeparation: 48 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second, ArrivalSeparation: 60 * time.Second, DepartureSeparation: 120 * time.Second},
+		},
+		RunwayCompatibility: compat,
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.Add(time.Hour)).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// 09L operates mixed mode at its 48 s mixed separation and 18 lands only at its 60 s
+	// arrival separation (within the departures 09L can balance)
+	w := result.Windows[0]
+	want := map[string]float32{"09L": 75, "18": 60}
+	for i, runwayID := range w.ActiveRunways {
+		if math.Abs(float64(w.RunwayMovements[i]-want[runwayID])) > 0.01 {
+			t.Errorf("Expected %v movements on %s (%s), got %v", want[runwayID], runwayID, w.RunwayOperations[i], w.RunwayMovements[i])
+		}
+	}
+	if math.Abs(float64(result.TotalCapacity-135)) > 0.01 {
+		t.Errorf("Expected 135 movements, got %v", result.TotalCapacity)
+	}
+}
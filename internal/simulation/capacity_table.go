@@ -0,0 +1,181 @@
+package simulation
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// ConfigurationCapacity is one row of a capacity table: a runway configuration with the
+// direction of each runway and the movements per hour it can handle.
+type ConfigurationCapacity struct {
+	Label            string                             // Configuration label (see ConfigurationLabel)
+	Flow             string                             // Traffic flow (see ConfigurationFlow)
+	Configuration    map[string]*event.ActiveRunwayInfo // Runways with their direction and operation type
+	WindDirections   int                                // Whole-degree wind directions (of 360) in which every runway end is usable
+	MovementsPerHour float64                            // Theoretical movements per hour
+}
+
+// CapacityTable computes the static capacity of every runway configuration the airport can
+// operate: each maximal compatible runway set in each combination of runway directions that
+// is wind-legal, i.e. in which every runway end is within its crosswind and tailwind limits
+// for at least one wind direction at windSpeedKnots (every combination is legal in calm
+// wind). Rows are sorted by movements per hour, highest first, then by label.
+//
+// Capacities are theoretical hourly rates from each runway's separation, adjusted for the
+// airport's fleet mix, de-rated for capacity interactions and with operation types
+// restricted to resolve terminal airspace conflicts, as in the engine; runtime policies are
+// not applied. Capacities come from SeparationModel; see Simulation.CapacityTable for a
+// simulation's own capacity model. Each maximal set of n runways has 2^n direction combinations, so the table
+// grows quickly for airports with many mutually compatible runways.
+// Returns an error if the wind speed is negative or the airport configuration is invalid.
+func CapacityTable(ap airport.Airport, windSpeedKnots float64) ([]ConfigurationCapacity, error) {
+	return capacityTable(ap, windSpeedKnots, nil)
+}
+
+// capacityTable computes the capacity table with the given capacity model (nil = SeparationModel).
+func capacityTable(ap airport.Airport, windSpeedKnots float64, model CapacityModel) ([]ConfigurationCapacity, error) {
+	if windSpeedKnots < 0 || math.IsNaN(windSpeedKnots) {
+		return nil, simerrors.Invalidf("capacity table wind speed must not be negative, got %v", windSpeedKnots)
+	}
+	if err := ap.Validate(); err != nil {
+		return nil, err
+	}
+
+	rm := NewRunwayManager(ap.Runways, ap.RunwayCompatibility)
+	rm.SetFleetMix(ap.FleetMix)
+	rm.SetCapacityModel(model)
+	table := rm.capacityTable(windSpeedKnots)
+
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].MovementsPerHour != table[j].MovementsPerHour {
+			return table[i].MovementsPerHour > table[j].MovementsPerHour
+		}
+		return table[i].Label < table[j].Label
+	})
+	return table, nil
+}
+
+// CapacityTable computes the capacity table of the simulated airport, after pre-simulation
+// plugins are applied and with its capacity model (see WithCapacityModel). See CapacityTable.
+func (s *Simulation) CapacityTable(windSpeedKnots float64) ([]ConfigurationCapacity, error) {
+	ap := s.enabledPolicies().applyPlugins(context.Background(), nil)
+	return capacityTable(ap, windSpeedKnots, s.capacityModel)
+}
+
+// WriteCapacityTableCSV writes a capacity table as CSV with a header row and one row per
+// configuration: label, flow, runway ends, legal wind directions and movements per hour.
+func WriteCapacityTableCSV(w io.Writer, table []ConfigurationCapacity) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"configuration", "flow", "runway_ends", "wind_directions", "movements_per_hour"}); err != nil {
+		return err
+	}
+
+	for _, row := range table {
+		ends := make([]string, 0, len(row.Configuration))
+		for _, info := range row.Configuration {
+			ends = append(ends, info.RunwayEnd())
+		}
+		sort.Strings(ends)
+
+		record := []string{
+			row.Label,
+			row.Flow,
+			strings.Join(ends, " "),
+			strconv.Itoa(row.WindDirections),
+			strconv.FormatFloat(row.MovementsPerHour, 'f', 1, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// capacityTable rates every wind-legal direction combination of every maximal clique over
+// one hour.
+//
+// Thread-safe: Uses write lock (maximal cliques are computed lazily).
+func (rm *RunwayManager) capacityTable(windSpeedKnots float64) []ConfigurationCapacity {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if !rm.maximalCliquesComputed {
+		rm.computeMaximalCliques()
+	}
+
+	var table []ConfigurationCapacity
+	for _, clique := range rm.maximalCliques {
+		runways := make([]airport.Runway, 0, len(clique))
+		for _, runwayID := range clique {
+			if runway, found := rm.findRunwayByID(runwayID); found {
+				runways = append(runways, runway)
+			}
+		}
+
+		// Each bit of combination reverses one runway
+		for combination := 0; combination < 1<<len(runways); combination++ {
+			directions := make([]event.Direction, len(runways))
+			for i := range runways {
+				if combination&(1<<i) != 0 {
+					directions[i] = event.Reverse
+				}
+			}
+
+			windDirections := legalWindDirections(runways, directions, windSpeedKnots)
+			if windDirections == 0 {
+				continue
+			}
+
+			config := make(map[string]*event.ActiveRunwayInfo, len(runways))
+			for i, runway := range runways {
+				config[runway.RunwayDesignation] = &event.ActiveRunwayInfo{
+					RunwayDesignation: runway.RunwayDesignation,
+					OperationType:     event.Mixed,
+					Direction:         directions[i],
+					Runway:            runway,
+				}
+			}
+			rm.resolveAirspaceConflicts(config)
+
+			capacity := rm.rateConfiguration(config)
+			table = append(table, ConfigurationCapacity{
+				Label:            ConfigurationLabel(config),
+				Flow:             ConfigurationFlow(config),
+				Configuration:    config,
+				WindDirections:   windDirections,
+				MovementsPerHour: float64(capacity) * 3600 / float64(rm.options.referenceSeconds()),
+			})
+		}
+	}
+	return table
+}
+
+// legalWindDirections returns the number of whole-degree wind directions in which every
+// runway can operate in its given direction at the wind speed.
+func legalWindDirections(runways []airport.Runway, directions []event.Direction, windSpeedKnots float64) int {
+	count := 0
+	for degrees := 0; degrees < 360; degrees++ {
+		legal := true
+		for i, runway := range runways {
+			if _, usable := runwayDirectionInWind(runway, directions[i], windSpeedKnots, 0, float64(degrees)); !usable {
+				legal = false
+				break
+			}
+		}
+		if legal {
+			count++
+		}
+	}
+	return count
+}
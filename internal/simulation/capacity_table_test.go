@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func capacityTableAirport() airport.Airport {
+	limited := func(designation string, bearing float64, separation time.Duration) airport.Runway {
+		return airport.Runway{
+			RunwayDesignation:   designation,
+			TrueBearing:         bearing,
+			MinimumSeparation:   separation,
+			CrosswindLimitKnots: 15,
+			TailwindLimitKnots:  5,
+		}
+	}
+	return airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			limited("09L", 90, 60*time.Second),
+			limited("09R", 90, 60*time.Second),
+			limited("18", 180, 90*time.Second),
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{
+			"09L": {"09R"},
+			"09R": {"09L"},
+			"18":  {},
+		}),
+	}
+}
+
+func TestCapacityTable(t *testing.T) {
+	ap := capacityTableAirport()
+
+	// In calm wind every direction combination is legal
+	calm, err := CapacityTable(ap, 0)
+	if err != nil {
+		t.Fatalf("CapacityTable failed: %v", err)
+	}
+	if len(calm) != 6 {
+		t.Fatalf("Expected 4 parallel and 2 crosswind runway rows in calm wind, got %d", len(calm))
+	}
+	for _, row := range calm {
+		if row.WindDirections != 360 {
+			t.Errorf("Expected %q legal in every wind direction, got %d", row.Label, row.WindDirections)
+		}
+	}
+
+	// At 20 knots the parallel runways can only operate in the same direction
+	table, err := CapacityTable(ap, 20)
+	if err != nil {
+		t.Fatalf("CapacityTable failed: %v", err)
+	}
+	var got []string
+	for _, row := range table {
+		got = append(got, row.Label)
+	}
+	if want := "09L, 09R|27L, 27R|18|36"; strings.Join(got, "|") != want {
+		t.Fatalf("Expected rows %s, got %s", want, strings.Join(got, "|"))
+	}
+	if table[0].MovementsPerHour != 120 || table[2].MovementsPerHour != 40 {
+		t.Errorf("Expected 120 and 40 movements per hour, got %.1f and %.1f", table[0].MovementsPerHour, table[2].MovementsPerHour)
+	}
+	if table[0].Flow != "East flow" || table[1].Flow != "West flow" {
+		t.Errorf("Expected east and west flows, got %q and %q", table[0].Flow, table[1].Flow)
+	}
+	if table[0].WindDirections == 0 || table[0].WindDirections >= 360 {
+		t.Errorf("Expected east flow legal in some wind directions, got %d", table[0].WindDirections)
+	}
+
+	if _, err := CapacityTable(ap, -1); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected invalid configuration error for negative wind speed, got %v", err)
+	}
+}
+
+func TestWriteCapacityTableCSV(t *testing.T) {
+	table, err := CapacityTable(capacityTableAirport(), 20)
+	if err != nil {
+		t.Fatalf("CapacityTable failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCapacityTableCSV(&buf, table); err != nil {
+		t.Fatalf("WriteCapacityTableCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(table)+1 {
+		t.Fatalf("Expected header and %d rows, got %d lines", len(table), len(lines))
+	}
+	if lines[0] != "configuration,flow,runway_ends,wind_directions,movements_per_hour" {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], `"09L, 09R",East flow,09L 09R,`) || !strings.HasSuffix(lines[1], ",120.0") {
+		t.Errorf("Unexpected first row %q", lines[1])
+	}
+}
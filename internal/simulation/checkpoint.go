@@ -0,0 +1,176 @@
+package simulation
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Checkpoint is a run paused at a point in simulated time: the world state there, the events
+// still to be applied and the windows calculated so far. A checkpoint can be resumed any number
+// of times, each from the same state, so alternatives (e.g., with and without a runway closure
+// from July 1st) can be compared without re-simulating the period before it.
+type Checkpoint struct {
+	Time time.Time // Simulation time the run was paused at
+
+	world      *World        // State at Time (its event queue is empty)
+	events     []event.Event // Events after Time still to be applied, in chronological order
+	result     *Result       // Windows completed before resumeFrom
+	resumeFrom time.Time     // Start of the window open at Time: the end of the last completed window, or the start time
+	provenance Provenance    // Provenance of the run checkpointed (set by Simulation.RunUntil)
+}
+
+// CalculateUntil processes the world's timeline up to and including the events at until, and
+// returns a checkpoint of the run there. The world is consumed; use Checkpoint.Fork to obtain
+// worlds to resume from. The timeline is processed sequentially.
+// Returns an error if until is outside the simulation period.
+func (e *Engine) CalculateUntil(ctx context.Context, world *World, until time.Time) (*Checkpoint, error) {
+	if until.Before(world.StartTime) || until.After(world.EndTime) {
+		return nil, simerrors.Invalidf("checkpoint time %v is outside the simulation period %v to %v", until, world.StartTime, world.EndTime)
+	}
+
+	e.log(ctx).InfoContext(ctx, "Calculating until checkpoint",
+		"airport", world.Airport.Name,
+		"startTime", world.StartTime,
+		"checkpointTime", until,
+		"numEvents", world.Events.Len())
+
+	result := &Result{
+		StartTime: world.StartTime,
+		EndTime:   world.EndTime,
+		Windows:   make([]WindowResult, 0, world.Events.Len()+1),
+	}
+	eventCount, err := e.processWindows(ctx, world, result, world.StartTime, until, false, true)
+	if err != nil {
+		return nil, err
+	}
+	result.EventsProcessed = eventCount
+	result.ConfigurationHistory = world.ConfigurationHistory()
+
+	// The window in progress starts where the last completed one ended
+	resumeFrom := world.StartTime
+	if n := len(result.Windows); n > 0 {
+		resumeFrom = result.Windows[n-1].End
+	}
+
+	events := make([]event.Event, 0, world.Events.Len())
+	for world.Events.HasNext() {
+		events = append(events, world.Events.Pop())
+	}
+
+	e.log(ctx).InfoContext(ctx, "Checkpoint created",
+		"checkpointTime", until,
+		"eventsProcessed", eventCount,
+		"eventsPending", len(events))
+
+	return &Checkpoint{
+		Time:       until,
+		world:      world,
+		events:     events,
+		result:     result,
+		resumeFrom: resumeFrom,
+	}, nil
+}
+
+// Result returns the windows completed before the checkpoint. The window in progress at the
+// checkpoint time is completed when the checkpoint is resumed.
+func (c *Checkpoint) Result() *Result {
+	result := *c.result
+	result.Windows = slices.Clone(c.result.Windows)
+	result.ConfigurationHistory = slices.Clone(c.result.ConfigurationHistory)
+	return &result
+}
+
+// Fork returns a new world in the checkpoint's state with its pending events queued. Events
+// (e.g., a runway closure) can be scheduled on it before it is passed to Engine.Resume; the
+// checkpoint itself is left unchanged.
+func (c *Checkpoint) Fork() *World {
+	w := c.world.snapshot()
+	w.retainEvents = true
+	for _, evt := range c.events {
+		w.ScheduleEvent(evt)
+	}
+	return w
+}
+
+// Resume processes a world forked from the checkpoint to the end of the simulation and returns
+// the result of the whole run, including the windows before the checkpoint. Events scheduled
+// on the world before the checkpoint time are ignored, since the run has already passed them.
+func (e *Engine) Resume(ctx context.Context, c *Checkpoint, world *World) (*Result, error) {
+	e.log(ctx).InfoContext(ctx, "Resuming from checkpoint",
+		"airport", world.Airport.Name,
+		"checkpointTime", c.Time,
+		"endTime", world.EndTime,
+		"numEvents", world.Events.Len())
+
+	// Drop events the run has already passed
+	events := make([]event.Event, 0, world.Events.Len())
+	for world.Events.HasNext() {
+		if evt := world.Events.Pop(); !evt.Time().Before(c.Time) {
+			events = append(events, evt)
+		}
+	}
+	for _, evt := range events {
+		world.ScheduleEvent(evt)
+	}
+
+	started := time.Now()
+	result := c.Result()
+	result.Windows = slices.Grow(result.Windows, world.Events.Len()+1)
+	eventCount, err := e.processWindows(ctx, world, result, c.resumeFrom, world.EndTime, false, false)
+	if err != nil {
+		return nil, err
+	}
+	e.skipEventsAfterEnd(ctx, world, result)
+	result.EventsProcessed += eventCount
+	result.ConfigurationHistory = world.ConfigurationHistory()
+	e.reportProgress(eventCount, eventCount, world.EndTime)
+
+	if e.metrics != nil {
+		// Report only the resumed part, which is all the engine calculated
+		resumed := &Result{EventsProcessed: eventCount, Windows: result.Windows[len(c.result.Windows):]}
+		recordMetrics(e.metrics, resumed, time.Since(started))
+	}
+
+	e.log(ctx).InfoContext(ctx, "Event-driven calculation complete", "totalCapacity", result.TotalCapacity)
+
+	return result, nil
+}
+
+// snapshot returns an independent copy of w's full state, including its configuration history,
+// noise and night quota usage and runway wear, with an empty event queue.
+func (w *World) snapshot() *World {
+	s := w.fork(w.StartTime, w.EndTime)
+	s.CurrentTime = w.CurrentTime
+	s.TotalCapacity = w.TotalCapacity
+
+	w.activeConfigMu.RLock()
+	configuration := make(map[string]*event.ActiveRunwayInfo, len(w.ActiveRunwayConfiguration))
+	for runwayID, info := range w.ActiveRunwayConfiguration {
+		infoCopy := *info
+		configuration[runwayID] = &infoCopy
+	}
+	history := slices.Clone(w.configurationHistory)
+	w.activeConfigMu.RUnlock()
+
+	s.activeConfigMu.Lock()
+	s.ActiveRunwayConfiguration = configuration
+	s.configurationHistory = history
+	s.activeConfigMu.Unlock()
+
+	s.NoiseQuotaWeights = maps.Clone(w.NoiseQuotaWeights)
+	s.NoiseQuotaPoints = w.NoiseQuotaPoints
+	s.noiseQuotaUsed = maps.Clone(w.noiseQuotaUsed)
+	s.NightQuotaMovements = w.NightQuotaMovements
+	s.NightQuotaAnnualMovements = w.NightQuotaAnnualMovements
+	s.NightQuotaActive = w.NightQuotaActive
+	s.nightQuotaRemaining = w.nightQuotaRemaining
+	s.nightQuotaUsed = maps.Clone(w.nightQuotaUsed)
+	s.runwayMovements = maps.Clone(w.runwayMovements)
+	s.wearBaselines = maps.Clone(w.wearBaselines)
+	return s
+}
@@ -0,0 +1,184 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// newCheckpointTestWorld returns a ten-day world with nightly curfews and wind changes
+// (pooled events) every six hours.
+func newCheckpointTestWorld() *World {
+	world := createTestWorld(10)
+	for day := 0; day < 10; day++ {
+		midnight := world.StartTime.AddDate(0, 0, day)
+		world.ScheduleEvent(event.NewCurfewEndEvent(midnight.Add(6 * time.Hour)))
+		world.ScheduleEvent(event.NewCurfewStartEvent(midnight.Add(23 * time.Hour)))
+		for hour := 0; hour < 24; hour += 6 {
+			world.ScheduleEvent(event.NewWindChangeEvent(float64(5+hour), float64(90+hour), midnight.Add(time.Duration(hour)*time.Hour)))
+		}
+	}
+	return world
+}
+
+func TestEngine_ResumeMatchesUninterruptedRun(t *testing.T) {
+	ctx := context.Background()
+	want, err := NewEngine(testEngineLogger()).CalculateResult(ctx, newCheckpointTestWorld())
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	engine := NewEngine(testEngineLogger())
+	world := newCheckpointTestWorld()
+	// Between events, so the window in progress spans the checkpoint
+	checkpoint, err := engine.CalculateUntil(ctx, world, world.StartTime.AddDate(0, 0, 5).Add(8*time.Hour+30*time.Minute))
+	if err != nil {
+		t.Fatalf("CalculateUntil failed: %v", err)
+	}
+	if partial := checkpoint.Result(); partial.TotalCapacity >= want.TotalCapacity || len(partial.Windows) == 0 {
+		t.Errorf("Expected a partial result, got %.0f movements in %d windows", partial.TotalCapacity, len(partial.Windows))
+	}
+
+	// A checkpoint can be resumed repeatedly, each time from the same state
+	for i := 0; i < 2; i++ {
+		got, err := engine.Resume(ctx, checkpoint, checkpoint.Fork())
+		if err != nil {
+			t.Fatalf("Resume failed: %v", err)
+		}
+		assertSameResult(t, want, got)
+	}
+}
+
+func TestEngine_ResumeBranches(t *testing.T) {
+	ctx := context.Background()
+	engine := NewEngine(testEngineLogger())
+	world := newCheckpointTestWorld()
+	at := world.StartTime.AddDate(0, 0, 5)
+
+	checkpoint, err := engine.CalculateUntil(ctx, world, at)
+	if err != nil {
+		t.Fatalf("CalculateUntil failed: %v", err)
+	}
+
+	unchanged, err := engine.Resume(ctx, checkpoint, checkpoint.Fork())
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	closed := checkpoint.Fork()
+	closed.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09L", at.Add(12*time.Hour)))
+	closed.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09L", at.Add(36*time.Hour)))
+	// Before the checkpoint: ignored
+	closed.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09R", at.Add(-48*time.Hour)))
+	withClosure, err := engine.Resume(ctx, checkpoint, closed)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	if withClosure.TotalCapacity >= unchanged.TotalCapacity {
+		t.Errorf("Expected the closure to cost capacity, got %.0f vs %.0f", withClosure.TotalCapacity, unchanged.TotalCapacity)
+	}
+	for _, window := range withClosure.Windows {
+		for _, id := range window.UnavailableRunways {
+			if id == "09R" {
+				t.Fatalf("Expected the event before the checkpoint to be ignored, but 09R closed at %v", window.Start)
+			}
+		}
+	}
+}
+
+func TestEngine_ResumeKeepsSameTimeEventOrder(t *testing.T) {
+	ctx := context.Background()
+	newWorld := func() *World {
+		world := createTestWorld(1)
+		at := world.StartTime.Add(12 * time.Hour)
+		world.ScheduleEvent(event.NewWindChangeEvent(10, 90, at))
+		world.ScheduleEvent(event.NewWindChangeEvent(10, 270, at))
+		return world
+	}
+
+	straight := newWorld()
+	want, err := NewEngine(testEngineLogger()).CalculateResult(ctx, straight)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	if straight.WindDirection != 270 {
+		t.Fatalf("Expected the straight run to end on 270°, got %v°", straight.WindDirection)
+	}
+
+	// The checkpoint stops at the first wind change, which must stay ahead of the second
+	engine := NewEngine(testEngineLogger())
+	world := newWorld()
+	checkpoint, err := engine.CalculateUntil(ctx, world, world.StartTime.Add(11*time.Hour))
+	if err != nil {
+		t.Fatalf("CalculateUntil failed: %v", err)
+	}
+	resumed := checkpoint.Fork()
+	got, err := engine.Resume(ctx, checkpoint, resumed)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if resumed.WindDirection != 270 {
+		t.Errorf("Expected the resumed run to end on 270°, got %v°", resumed.WindDirection)
+	}
+	assertSameResult(t, want, got)
+}
+
+func TestEngine_CalculateUntilOutsidePeriod(t *testing.T) {
+	world := newCheckpointTestWorld()
+	_, err := NewEngine(testEngineLogger()).CalculateUntil(context.Background(), world, world.EndTime.Add(time.Hour))
+	if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestSimulation_RunUntilAndResume(t *testing.T) {
+	ctx := context.Background()
+	ap := airport.Airport{Name: "Test", Runways: createTestRunways()}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 1, 0))
+	sim, err := sim.AddCurfewPolicy(start.Add(23*time.Hour), start.Add(30*time.Hour))
+	if err != nil {
+		t.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+
+	want, err := sim.RunResult(ctx)
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	checkpoint, err := sim.RunUntil(ctx, start.AddDate(0, 0, 15))
+	if err != nil {
+		t.Fatalf("RunUntil failed: %v", err)
+	}
+	got, err := sim.Resume(ctx, checkpoint)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	assertSameResult(t, want, got)
+
+	closure := policy.NewMaintenancePolicy(policy.MaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           48 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+	})
+	withClosure, err := sim.Resume(ctx, checkpoint, closure)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if withClosure.TotalCapacity >= want.TotalCapacity {
+		t.Errorf("Expected maintenance after the checkpoint to cost capacity, got %.0f vs %.0f", withClosure.TotalCapacity, want.TotalCapacity)
+	}
+
+	unknown := policy.NewMaintenancePolicy(policy.MaintenanceSchedule{RunwayDesignations: []string{"04"}, Duration: time.Hour, Frequency: 24 * time.Hour})
+	var validationErr *ValidationError
+	if _, err := sim.Resume(ctx, checkpoint, unknown); !errors.As(err, &validationErr) {
+		t.Errorf("Expected *ValidationError for an unknown runway, got %v", err)
+	}
+}
@@ -19,5 +19,6 @@ const (
 	SecondsPerHour = 3600
 )
 
-// YearDuration represents the duration of a standard year
+// YearDuration represents the duration of a standard year. Use Period to normalize results
+// to the actual simulated duration, which accounts for leap years and partial years.
 const YearDuration = DaysPerYear * HoursPerDay * time.Hour
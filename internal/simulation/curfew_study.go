@@ -0,0 +1,192 @@
+package simulation
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// DefaultCurfewStudyIncrement is the increment a curfew is relaxed by in curfew studies run
+// from scenarios.
+const DefaultCurfewStudyIncrement = 30 * time.Minute
+
+// CurfewRelaxationSide identifies which end of a curfew a relaxation moves.
+type CurfewRelaxationSide int
+
+const (
+	// LaterStart starts the curfew later, opening the evening
+	LaterStart CurfewRelaxationSide = iota
+	// EarlierEnd ends the curfew earlier, opening the morning
+	EarlierEnd
+)
+
+// String returns the side name.
+func (c CurfewRelaxationSide) String() string {
+	switch c {
+	case LaterStart:
+		return "LaterStart"
+	case EarlierEnd:
+		return "EarlierEnd"
+	default:
+		return "Unknown"
+	}
+}
+
+// CurfewRelaxation is the capacity of a simulation with its curfew relaxed at one end.
+type CurfewRelaxation struct {
+	Side    CurfewRelaxationSide
+	Relaxed time.Duration // Curfew removed each night
+	Start   time.Time     // Start of the relaxed curfew (zero when lifted)
+	End     time.Time     // End of the relaxed curfew (zero when lifted)
+	Lifted  bool          // Whether the relaxation removes the curfew entirely
+
+	Capacity float32 // Capacity with the relaxed curfew
+	Gain     float32 // Movements gained over the current curfew
+
+	// MarginalPerHour is the movements gained over the previous step of the curve, per hour
+	// of curfew relaxed each night: what the last hour relaxed is worth.
+	MarginalPerHour float64
+}
+
+// CurfewStudy is the capacity an airport curfew costs, relaxed step by step at either end.
+type CurfewStudy struct {
+	Start     time.Time     // Start of the current curfew
+	End       time.Time     // End of the current curfew
+	Increment time.Duration // Curfew relaxed per step
+	Capacity  float32       // Capacity with the current curfew
+
+	LaterStart []CurfewRelaxation // Curfew starting one increment later per step, until lifted
+	EarlierEnd []CurfewRelaxation // Curfew ending one increment earlier per step, until lifted
+}
+
+// CurfewStudy relaxes the simulation's airport curfew (CurfewPolicy) in steps of the given
+// increment, starting it later or ending it earlier until it is lifted, and reports the
+// capacity of each step and its marginal capacity per curfew hour relaxed: the curve of what
+// one more operating hour is worth.
+//
+// Each step is a counterfactual run of the simulation with the relaxed curfew. Like
+// CapacityWaterfall, every run uses the same seed, so gains come only from the relaxation.
+// Returns an error if the increment is not positive or the simulation does not have exactly
+// one curfew.
+func (s *Simulation) CurfewStudy(ctx context.Context, increment time.Duration) (*CurfewStudy, error) {
+	if increment <= 0 {
+		return nil, simerrors.Invalidf("curfew study increment must be positive, got %v", increment)
+	}
+	s = s.enabledPolicies()
+
+	index := -1
+	for i, p := range s.policies {
+		if _, ok := p.(*policy.CurfewPolicy); !ok {
+			continue
+		}
+		if index >= 0 {
+			return nil, simerrors.Invalidf("curfew study needs a single curfew, the simulation has more than one")
+		}
+		index = i
+	}
+	if index < 0 {
+		return nil, simerrors.Invalidf("curfew study needs a curfew policy")
+	}
+	curfew := s.policies[index].(*policy.CurfewPolicy)
+
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	all := make([]int, len(s.policies))
+	for i := range all {
+		all[i] = i
+	}
+	without := append(all[:index:index], all[index+1:]...)
+
+	s.log(ctx).InfoContext(ctx, "Running curfew study", "increment", increment)
+	current, err := s.withPolicies(all).WithSeed(seed).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	study := &CurfewStudy{
+		Start:     curfew.GetStartTime(),
+		End:       curfew.GetEndTime(),
+		Increment: increment,
+		Capacity:  current,
+	}
+
+	length := study.End.Sub(study.Start)
+	for _, side := range []CurfewRelaxationSide{LaterStart, EarlierEnd} {
+		var curve []CurfewRelaxation
+		previous, previousRelaxed := current, time.Duration(0)
+		for relaxed := increment; ; relaxed += increment {
+			step := CurfewRelaxation{Side: side, Relaxed: min(relaxed, length), Lifted: relaxed >= length}
+			sim := s.withPolicies(without)
+			if !step.Lifted {
+				step.Start, step.End = study.Start, study.End
+				if side == LaterStart {
+					step.Start = step.Start.Add(relaxed)
+				} else {
+					step.End = step.End.Add(-relaxed)
+				}
+				relaxedCurfew, err := policy.NewCurfewPolicy(step.Start, step.End)
+				if err != nil {
+					return nil, err
+				}
+				sim = s.withPolicies(all)
+				sim.policies[index] = relaxedCurfew
+			}
+			if step.Capacity, err = sim.WithSeed(seed).Run(ctx); err != nil {
+				return nil, fmt.Errorf("relaxing the curfew by %v: %w", step.Relaxed, err)
+			}
+			step.Gain = step.Capacity - current
+			step.MarginalPerHour = float64(step.Capacity-previous) / (step.Relaxed - previousRelaxed).Hours()
+			previous, previousRelaxed = step.Capacity, step.Relaxed
+			curve = append(curve, step)
+			if step.Lifted {
+				break
+			}
+		}
+		if side == LaterStart {
+			study.LaterStart = curve
+		} else {
+			study.EarlierEnd = curve
+		}
+	}
+	return study, nil
+}
+
+// WriteCurfewStudyCSV writes a curfew study as CSV with a header row and one row per step:
+// the side relaxed, the hours relaxed, the relaxed curfew's clock times (empty when lifted),
+// the capacity, the gain and the marginal movements per hour relaxed.
+func WriteCurfewStudyCSV(w io.Writer, study *CurfewStudy) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"side", "hours_relaxed", "curfew_start", "curfew_end", "capacity", "gain", "marginal_per_hour"}); err != nil {
+		return err
+	}
+	for _, curve := range [][]CurfewRelaxation{study.LaterStart, study.EarlierEnd} {
+		for _, step := range curve {
+			var start, end string
+			if !step.Lifted {
+				start, end = step.Start.Format("15:04"), step.End.Format("15:04")
+			}
+			record := []string{
+				step.Side.String(),
+				strconv.FormatFloat(step.Relaxed.Hours(), 'f', 2, 64),
+				start,
+				end,
+				strconv.FormatFloat(float64(step.Capacity), 'f', 1, 32),
+				strconv.FormatFloat(float64(step.Gain), 'f', 1, 32),
+				strconv.FormatFloat(step.MarginalPerHour, 'f', 1, 64),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,119 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestSimulation_CurfewStudy(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: time.Minute}},
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sim, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(start, start.Add(36*time.Hour)).
+		WithSeed(1).
+		AddCurfewPolicy(start.Add(11*time.Hour), start.Add(18*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	study, err := sim.CurfewStudy(context.Background(), time.Hour)
+	if err != nil {
+		t.Fatalf("CurfewStudy failed: %v", err)
+	}
+	if study.Capacity != 1680 || !study.Start.Equal(start.Add(11*time.Hour)) || study.Increment != time.Hour {
+		t.Errorf("Expected the 23:00-06:00 curfew with 1680 movements, got %+v", study)
+	}
+
+	// The period has the first night and 23:00 on the second: the first hour the curfew starts
+	// later and the hour lifting it opens at the end are worth two nights, the rest one
+	tests := []struct {
+		name    string
+		curve   []CurfewRelaxation
+		want    []float32
+		wantEnd string
+	}{
+		{"later start", study.LaterStart, []float32{1800, 1860, 1920, 1980, 2040, 2100, 2160}, "06:00"},
+		{"earlier end", study.EarlierEnd, []float32{1740, 1800, 1860, 1920, 1980, 2040, 2160}, "05:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.curve) != len(tt.want) {
+				t.Fatalf("Expected %d steps, got %+v", len(tt.want), tt.curve)
+			}
+			previous := study.Capacity
+			for i, step := range tt.curve {
+				if step.Relaxed != time.Duration(i+1)*time.Hour || step.Lifted != (i == len(tt.want)-1) ||
+					step.Capacity != tt.want[i] || step.Gain != tt.want[i]-1680 || math.Abs(step.MarginalPerHour-float64(tt.want[i]-previous)) > 1e-6 {
+					t.Errorf("Step %d: expected %v movements, got %+v", i, tt.want[i], step)
+				}
+				previous = step.Capacity
+			}
+			if got := tt.curve[0].End.Format("15:04"); got != tt.wantEnd {
+				t.Errorf("Expected the first step to end at %s, got %s", tt.wantEnd, got)
+			}
+			if last := tt.curve[len(tt.curve)-1]; !last.Start.IsZero() || !last.End.IsZero() {
+				t.Errorf("Expected no curfew times once lifted, got %+v", last)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCurfewStudyCSV(&buf, study); err != nil {
+		t.Fatalf("WriteCurfewStudyCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 15 || lines[0] != "side,hours_relaxed,curfew_start,curfew_end,capacity,gain,marginal_per_hour" ||
+		lines[1] != "LaterStart,1.00,00:00,06:00,1800.0,120.0,120.0" || lines[14] != "EarlierEnd,7.00,,,2160.0,480.0,120.0" {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+
+	// A coarser increment reports the marginal capacity per hour of each step
+	coarse, err := sim.CurfewStudy(context.Background(), 3*time.Hour)
+	if err != nil {
+		t.Fatalf("CurfewStudy failed: %v", err)
+	}
+	if len(coarse.LaterStart) != 3 || coarse.LaterStart[0].MarginalPerHour != 80 || coarse.LaterStart[2].Relaxed != 7*time.Hour || coarse.LaterStart[2].MarginalPerHour != 60 {
+		t.Errorf("Unexpected coarse curve %+v", coarse.LaterStart)
+	}
+}
+
+func TestSimulation_CurfewStudyErrors(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: time.Minute}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 2))
+
+	if _, err := sim.CurfewStudy(context.Background(), time.Hour); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration without a curfew, got %v", err)
+	}
+	sim, err := sim.AddCurfewPolicy(start.Add(23*time.Hour), start.Add(30*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.CurfewStudy(context.Background(), 0); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a zero increment, got %v", err)
+	}
+	sim, err = sim.AddCurfewPolicy(start.Add(12*time.Hour), start.Add(13*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.CurfewStudy(context.Background(), time.Hour); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration with two curfews, got %v", err)
+	}
+	if _, err := sim.DisablePolicy("CurfewPolicy").CurfewStudy(context.Background(), time.Hour); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration with the curfews disabled, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package simulation
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// Defaults for DeclaredCapacityOptions
+const (
+	DefaultDeclaredRollingStep = 5 * time.Minute // Offset between consecutive rolling periods
+	DefaultDeclaredReliability = 95.0            // Percent of open periods the declared value must be achievable in
+)
+
+// DefaultDeclaredPeriods are the rolling period lengths used for slot coordination by default.
+var DefaultDeclaredPeriods = []time.Duration{time.Hour, 15 * time.Minute}
+
+// DeclaredCapacityOptions configures Result.DeclaredCapacity. Zero values select the defaults.
+type DeclaredCapacityOptions struct {
+	Periods     []time.Duration // Rolling period lengths (default: DefaultDeclaredPeriods)
+	Step        time.Duration   // Offset between consecutive rolling periods (default: DefaultDeclaredRollingStep)
+	Reliability []float64       // Percentiles to declare, e.g. 95 for capacity achievable in 95% of periods (default: DefaultDeclaredReliability)
+}
+
+// DeclaredCapacity is the slot coordination capacity for one rolling period length.
+type DeclaredCapacity struct {
+	Period      time.Duration       // Rolling period length
+	Peak        float64             // Most movements in any rolling period
+	Declared    map[float64]float64 // Movements achievable in at least the given percent of open rolling periods
+	OpenPeriods int                 // Rolling periods with non-zero capacity
+}
+
+// DeclaredCapacity converts the run into declared capacity numbers for slot coordination:
+// the movements available in every rolling period of each configured length, sampled every
+// Step from the simulation start. Movements are assumed to be spread evenly within each window.
+//
+// Periods with no capacity (e.g., curfews) are excluded, since no slots are declared while the
+// airport is closed. Declared values are taken over the remaining periods, so the 95th
+// percentile is the capacity available in at least 95% of open periods whatever the weather
+// or maintenance in effect. Returns an error if a period, step, or reliability is out of range.
+func (r *Result) DeclaredCapacity(opts DeclaredCapacityOptions) ([]DeclaredCapacity, error) {
+	periods := opts.Periods
+	if len(periods) == 0 {
+		periods = DefaultDeclaredPeriods
+	}
+	step := opts.Step
+	if step == 0 {
+		step = DefaultDeclaredRollingStep
+	}
+	reliability := opts.Reliability
+	if len(reliability) == 0 {
+		reliability = []float64{DefaultDeclaredReliability}
+	}
+
+	if step < 0 {
+		return nil, simerrors.Invalidf("rolling step must be positive, got %v", step)
+	}
+	for _, period := range periods {
+		if period <= 0 {
+			return nil, simerrors.Invalidf("rolling period must be positive, got %v", period)
+		}
+	}
+	for _, percent := range reliability {
+		if percent <= 0 || percent > 100 {
+			return nil, simerrors.Invalidf("reliability must be in (0, 100], got %f", percent)
+		}
+	}
+
+	cumulative := r.cumulativeCapacity()
+	declared := make([]DeclaredCapacity, 0, len(periods))
+	for _, period := range periods {
+		var movements []float64
+		for start := r.StartTime; !start.Add(period).After(r.EndTime); start = start.Add(step) {
+			if m := cumulative(start.Add(period)) - cumulative(start); m > 0 {
+				movements = append(movements, m)
+			}
+		}
+		sort.Float64s(movements)
+
+		dc := DeclaredCapacity{
+			Period:      period,
+			Declared:    make(map[float64]float64, len(reliability)),
+			OpenPeriods: len(movements),
+		}
+		if len(movements) > 0 {
+			dc.Peak = movements[len(movements)-1]
+			for _, percent := range reliability {
+				// At least percent% of periods reach the value at this index
+				i := int(math.Floor((1 - percent/100) * float64(len(movements))))
+				dc.Declared[percent] = movements[min(i, len(movements)-1)]
+			}
+		}
+		declared = append(declared, dc)
+	}
+
+	return declared, nil
+}
+
+// cumulativeCapacity returns a function giving the movements from the simulation start up
+// to t, interpolating linearly within windows.
+func (r *Result) cumulativeCapacity() func(t time.Time) float64 {
+	prefix := make([]float64, len(r.Windows)+1)
+	for i, w := range r.Windows {
+		prefix[i+1] = prefix[i] + float64(w.Capacity)
+	}
+
+	return func(t time.Time) float64 {
+		// Index of the first window starting after t
+		i := sort.Search(len(r.Windows), func(i int) bool { return r.Windows[i].Start.After(t) })
+		if i == 0 {
+			return 0
+		}
+
+		w := r.Windows[i-1]
+		if !t.Before(w.End) {
+			return prefix[i]
+		}
+		fraction := float64(t.Sub(w.Start)) / float64(w.Duration())
+		return prefix[i-1] + float64(w.Capacity)*fraction
+	}
+}
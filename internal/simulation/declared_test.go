@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestResult_DeclaredCapacity(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	// Closed for two hours, then 60/h for 17 hours and 30/h (e.g., poor weather) for 1 hour
+	result := &Result{
+		StartTime: start,
+		EndTime:   hour(20),
+		Windows: []WindowResult{
+			{Start: hour(0), End: hour(2), Capacity: 0},
+			{Start: hour(2), End: hour(19), Capacity: 60 * 17},
+			{Start: hour(19), End: hour(20), Capacity: 30},
+		},
+	}
+
+	declared, err := result.DeclaredCapacity(DeclaredCapacityOptions{
+		Step:        time.Hour,
+		Reliability: []float64{100, 90},
+	})
+	if err != nil {
+		t.Fatalf("DeclaredCapacity failed: %v", err)
+	}
+	if len(declared) != 2 {
+		t.Fatalf("Expected default 60 and 15 minute periods, got %d", len(declared))
+	}
+
+	hourly := declared[0]
+	if hourly.Period != time.Hour || hourly.OpenPeriods != 18 {
+		t.Errorf("Expected 18 open hourly periods, got %d for %v", hourly.OpenPeriods, hourly.Period)
+	}
+	if hourly.Peak != 60 {
+		t.Errorf("Expected peak of 60, got %.1f", hourly.Peak)
+	}
+	if hourly.Declared[100] != 30 {
+		t.Errorf("Expected 30 available in every open hour, got %.1f", hourly.Declared[100])
+	}
+	if hourly.Declared[90] != 60 {
+		t.Errorf("Expected 60 available in 90%% of open hours, got %.1f", hourly.Declared[90])
+	}
+
+	quarter := declared[1]
+	if quarter.Peak != 15 || quarter.Declared[90] != 15 {
+		t.Errorf("Expected 15 movements per 15 minutes, got peak %.1f declared %.1f", quarter.Peak, quarter.Declared[90])
+	}
+}
+
+func TestResult_DeclaredCapacity_RollingPeriodsSpanWindows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &Result{
+		StartTime: start,
+		EndTime:   start.Add(2 * time.Hour),
+		Windows: []WindowResult{
+			{Start: start, End: start.Add(time.Hour), Capacity: 0},
+			{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), Capacity: 60},
+		},
+	}
+
+	declared, err := result.DeclaredCapacity(DeclaredCapacityOptions{Periods: []time.Duration{time.Hour}, Step: 30 * time.Minute})
+	if err != nil {
+		t.Fatalf("DeclaredCapacity failed: %v", err)
+	}
+
+	// Periods starting at 00:30 (half open) and 01:00 (fully open)
+	if declared[0].OpenPeriods != 2 {
+		t.Errorf("Expected 2 open periods, got %d", declared[0].OpenPeriods)
+	}
+	if declared[0].Declared[DefaultDeclaredReliability] != 30 {
+		t.Errorf("Expected 30 movements declared, got %.1f", declared[0].Declared[DefaultDeclaredReliability])
+	}
+}
+
+func TestResult_DeclaredCapacity_InvalidOptions(t *testing.T) {
+	result := &Result{StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	result.EndTime = result.StartTime.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		opts DeclaredCapacityOptions
+	}{
+		{"negative step", DeclaredCapacityOptions{Step: -time.Minute}},
+		{"zero period", DeclaredCapacityOptions{Periods: []time.Duration{0}}},
+		{"reliability above 100", DeclaredCapacityOptions{Reliability: []float64{101}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := result.DeclaredCapacity(tt.opts); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+}
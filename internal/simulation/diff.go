@@ -0,0 +1,213 @@
+package simulation
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DeltaCause categorizes why capacity differs between two runs on a given day.
+type DeltaCause int
+
+const (
+	// CauseWind indicates the wind conditions differed
+	CauseWind DeltaCause = iota
+
+	// CauseMaintenance indicates runway closures (maintenance or other restrictions) differed
+	CauseMaintenance
+
+	// CauseCurfew indicates curfew hours differed
+	CauseCurfew
+
+	// CauseRotation indicates the rotation efficiency multiplier differed
+	CauseRotation
+
+	// CauseOther indicates no recorded state difference explains the delta
+	CauseOther
+)
+
+// String returns the string representation of the delta cause.
+func (c DeltaCause) String() string {
+	switch c {
+	case CauseWind:
+		return "Wind"
+	case CauseMaintenance:
+		return "Maintenance"
+	case CauseCurfew:
+		return "Curfew"
+	case CauseRotation:
+		return "Rotation"
+	case CauseOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
+
+// Thresholds below which differences in daily state are ignored when explaining deltas
+const (
+	diffHoursTolerance     = 1.0 / 60 // One minute
+	diffWindSpeedTolerance = 1.0      // Knots
+	diffWindDirTolerance   = 10.0     // Degrees
+	diffRotationTolerance  = 0.001
+)
+
+// DailyDelta is the capacity difference between two runs on one calendar day.
+type DailyDelta struct {
+	Date               time.Time    // Midnight at the start of the day
+	BaselineCapacity   float32      // Movements in the baseline run
+	ComparisonCapacity float32      // Movements in the comparison run
+	Delta              float32      // ComparisonCapacity - BaselineCapacity
+	Causes             []DeltaCause // Categorized explanation (CauseOther if nothing differs)
+}
+
+// ResultDiff is the day-by-day comparison of two simulation results.
+type ResultDiff struct {
+	Days       []DailyDelta // Per-day deltas in chronological order
+	TotalDelta float32      // Comparison total minus baseline total
+}
+
+// LargestDeltas returns up to n days with the largest absolute capacity change,
+// ordered from largest to smallest.
+func (d *ResultDiff) LargestDeltas(n int) []DailyDelta {
+	sorted := make([]DailyDelta, len(d.Days))
+	copy(sorted, d.Days)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return math.Abs(float64(sorted[i].Delta)) > math.Abs(float64(sorted[j].Delta))
+	})
+
+	if n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// dayProfile summarizes the world state over one day for cause attribution.
+type dayProfile struct {
+	capacity          float32
+	curfewHours       float64
+	closedRunwayHours float64
+	windSpeedHours    float64 // Wind speed weighted by hours
+	windX, windY      float64 // Wind vector weighted by hours
+	rotationHours     float64 // Rotation multiplier weighted by hours
+	hours             float64
+}
+
+// DiffResults compares two results day by day and explains each day's delta.
+// Days present in only one result are compared against an empty day.
+func DiffResults(baseline, comparison *Result) *ResultDiff {
+	base := buildDayProfiles(baseline)
+	comp := buildDayProfiles(comparison)
+
+	dates := []time.Time{}
+	seen := map[time.Time]bool{}
+	for _, profiles := range []map[time.Time]*dayProfile{base, comp} {
+		for day := range profiles {
+			if !seen[day] {
+				seen[day] = true
+				dates = append(dates, day)
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	diff := &ResultDiff{
+		Days:       make([]DailyDelta, 0, len(dates)),
+		TotalDelta: comparison.TotalCapacity - baseline.TotalCapacity,
+	}
+
+	for _, day := range dates {
+		b, c := base[day], comp[day]
+		if b == nil {
+			b = &dayProfile{}
+		}
+		if c == nil {
+			c = &dayProfile{}
+		}
+
+		diff.Days = append(diff.Days, DailyDelta{
+			Date:               day,
+			BaselineCapacity:   b.capacity,
+			ComparisonCapacity: c.capacity,
+			Delta:              c.capacity - b.capacity,
+			Causes:             explainDelta(b, c),
+		})
+	}
+
+	return diff
+}
+
+// buildDayProfiles aggregates a result's windows into per-day profiles.
+func buildDayProfiles(r *Result) map[time.Time]*dayProfile {
+	profiles := map[time.Time]*dayProfile{}
+
+	for _, w := range r.Windows {
+		windowHours := w.Duration().Hours()
+		dirRad := w.WindDirection * math.Pi / 180
+
+		forEachDaySegment(w, func(day time.Time, fraction float64) {
+			p, ok := profiles[day]
+			if !ok {
+				p = &dayProfile{}
+				profiles[day] = p
+			}
+
+			hours := windowHours * fraction
+			p.capacity += w.Capacity * float32(fraction)
+			p.hours += hours
+			if w.CurfewActive {
+				p.curfewHours += hours
+			}
+			p.closedRunwayHours += hours * float64(len(w.UnavailableRunways))
+			p.windSpeedHours += hours * w.WindSpeed
+			p.windX += hours * w.WindSpeed * math.Sin(dirRad)
+			p.windY += hours * w.WindSpeed * math.Cos(dirRad)
+			p.rotationHours += hours * float64(w.RotationMultiplier)
+		})
+	}
+
+	return profiles
+}
+
+// explainDelta lists the state differences between two day profiles.
+func explainDelta(b, c *dayProfile) []DeltaCause {
+	causes := []DeltaCause{}
+
+	speedChanged := math.Abs(b.windSpeedHours/nonZero(b.hours)-c.windSpeedHours/nonZero(c.hours)) > diffWindSpeedTolerance
+	windy := b.windSpeedHours > 0 || c.windSpeedHours > 0
+	directionChanged := windy && angularDifference(math.Atan2(b.windX, b.windY), math.Atan2(c.windX, c.windY)) > diffWindDirTolerance
+	if speedChanged || directionChanged {
+		causes = append(causes, CauseWind)
+	}
+	if math.Abs(b.closedRunwayHours-c.closedRunwayHours) > diffHoursTolerance {
+		causes = append(causes, CauseMaintenance)
+	}
+	if math.Abs(b.curfewHours-c.curfewHours) > diffHoursTolerance {
+		causes = append(causes, CauseCurfew)
+	}
+	if math.Abs(b.rotationHours/nonZero(b.hours)-c.rotationHours/nonZero(c.hours)) > diffRotationTolerance {
+		causes = append(causes, CauseRotation)
+	}
+
+	if len(causes) == 0 {
+		causes = append(causes, CauseOther)
+	}
+	return causes
+}
+
+// angularDifference returns the absolute difference between two angles in radians, in degrees (0-180).
+func angularDifference(a, b float64) float64 {
+	diff := math.Abs(a-b) * 180 / math.Pi
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
+
+// nonZero returns v, or 1 if v is zero, to guard divisions.
+func nonZero(v float64) float64 {
+	if v == 0 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestResult_DailyCapacity_SplitsAtMidnight(t *testing.T) {
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &Result{
+		Windows: []WindowResult{
+			{Start: day1.Add(18 * time.Hour), End: day1.Add(30 * time.Hour), Capacity: 120},
+		},
+	}
+
+	daily := result.DailyCapacity()
+	if len(daily) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(daily))
+	}
+	if daily[0].Capacity != 60 || daily[1].Capacity != 60 {
+		t.Errorf("Expected 60/60 split, got %.1f/%.1f", daily[0].Capacity, daily[1].Capacity)
+	}
+	if !daily[1].Date.Equal(day1.AddDate(0, 0, 1)) {
+		t.Errorf("Expected second day %v, got %v", day1.AddDate(0, 0, 1), daily[1].Date)
+	}
+}
+
+func TestDiffResults_CategorizesCauses(t *testing.T) {
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day2.AddDate(0, 0, 1)
+
+	baseline := &Result{
+		TotalCapacity: 300,
+		Windows: []WindowResult{
+			{Start: day1, End: day2, Capacity: 100, WindSpeed: 10, WindDirection: 90, RotationMultiplier: 1},
+			{Start: day2, End: day3, Capacity: 100, RotationMultiplier: 1},
+			{Start: day3, End: day3.Add(24 * time.Hour), Capacity: 100, RotationMultiplier: 1},
+		},
+	}
+	comparison := &Result{
+		TotalCapacity: 230,
+		Windows: []WindowResult{
+			{Start: day1, End: day2, Capacity: 90, WindSpeed: 10, WindDirection: 270, RotationMultiplier: 1},
+			{Start: day2, End: day2.Add(12 * time.Hour), Capacity: 40, UnavailableRunways: []string{"09L"}, RotationMultiplier: 1},
+			{Start: day2.Add(12 * time.Hour), End: day3, Capacity: 50, RotationMultiplier: 1},
+			{Start: day3, End: day3.Add(6 * time.Hour), Capacity: 0, CurfewActive: true, RotationMultiplier: 1},
+			{Start: day3.Add(6 * time.Hour), End: day3.Add(24 * time.Hour), Capacity: 50, RotationMultiplier: 1},
+		},
+	}
+
+	diff := DiffResults(baseline, comparison)
+	if diff.TotalDelta != -70 {
+		t.Errorf("Expected total delta -70, got %.1f", diff.TotalDelta)
+	}
+	if len(diff.Days) != 3 {
+		t.Fatalf("Expected 3 days, got %d", len(diff.Days))
+	}
+
+	expected := []struct {
+		delta float32
+		cause DeltaCause
+	}{
+		{-10, CauseWind},
+		{-10, CauseMaintenance},
+		{-50, CauseCurfew},
+	}
+	for i, exp := range expected {
+		day := diff.Days[i]
+		if day.Delta != exp.delta {
+			t.Errorf("Day %d: expected delta %.1f, got %.1f", i, exp.delta, day.Delta)
+		}
+		if len(day.Causes) != 1 || day.Causes[0] != exp.cause {
+			t.Errorf("Day %d: expected cause %v, got %v", i, exp.cause, day.Causes)
+		}
+	}
+
+	largest := diff.LargestDeltas(1)
+	if len(largest) != 1 || !largest[0].Date.Equal(day3) {
+		t.Errorf("Expected largest delta on %v, got %+v", day3, largest)
+	}
+}
+
+func TestDiffResults_IdenticalRunsReportOther(t *testing.T) {
+	result := createTestResult(t)
+	diff := DiffResults(result, result)
+
+	for _, day := range diff.Days {
+		if day.Delta != 0 {
+			t.Errorf("Expected zero delta on %v, got %.2f", day.Date, day.Delta)
+		}
+		if len(day.Causes) != 1 || day.Causes[0] != CauseOther {
+			t.Errorf("Expected CauseOther on %v, got %v", day.Date, day.Causes)
+		}
+	}
+}
+
+// createTestResult runs a two-day simulation with a maintenance window.
+func createTestResult(t *testing.T) *Result {
+	t.Helper()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := NewWorld(airport.Airport{Name: "Test", Runways: createTestRunways()}, start, start.AddDate(0, 0, 2))
+	world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09L", start.Add(6*time.Hour)))
+	world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09L", start.Add(10*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	return result
+}
@@ -2,14 +2,54 @@ package simulation
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
 	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
 )
 
+// ProgressFunc is called by the engine as events are processed.
+// done is the number of events processed so far, total is the number of events
+// known to the engine (processed plus still queued), and simTime is the current
+// simulation time. total may grow during the run as events schedule follow-up events.
+type ProgressFunc func(done, total int, simTime time.Time)
+
 // Engine is the core event-driven simulation engine that calculates total movements
 // by processing events chronologically and calculating capacity for each time window.
 type Engine struct {
-	logger *slog.Logger
+	logger      *slog.Logger
+	progress    ProgressFunc // Optional progress callback (nil = no reporting)
+	parallelism int          // Months processed concurrently (<= 1 = sequential)
+	quiet       bool         // Suppress per-event logs
+	metrics     Metrics      // Optional instrumentation (nil = none)
+
+	minimumWindow time.Duration // Shortest window calculated; closer state changes are coalesced (0 = none)
+}
+
+// windowScratch holds the per-runway maps used while calculating one window's capacity.
+// They are cleared and reused for every window of a run instead of being rebuilt.
+type windowScratch struct {
+	runwayCapacities    map[string]float32 // Runway ID -> movements in the window
+	runwayEndCapacities map[string]float32 // Runway end -> movements in the window (for the noise quota)
+	runwayMovements     map[string]float32 // Runway ID -> movements attributed to it once every constraint applies
+	gaMovements         map[string]float32 // Runway ID -> movements reserved for general aviation
+	modelRunways        []CapacityRunway   // Active runways given to the capacity model
+	modelCapacities     []float32          // Capacity model's movements for each of modelRunways
+	gateSamples         []GateSample       // Aircraft on gates through the window (empty if the gates were not advanced)
+}
+
+// newWindowScratch creates scratch maps sized for the airport's runways.
+func newWindowScratch(runways int) *windowScratch {
+	return &windowScratch{
+		runwayCapacities:    make(map[string]float32, runways),
+		runwayEndCapacities: make(map[string]float32, runways),
+		runwayMovements:     make(map[string]float32, runways),
+		gaMovements:         make(map[string]float32, runways),
+	}
 }
 
 // NewEngine creates a new simulation engine.
@@ -19,106 +59,287 @@ func NewEngine(logger *slog.Logger) *Engine {
 	}
 }
 
+// WithProgress registers a callback that is invoked after each processed event
+// and once more when the timeline is complete. Passing nil disables reporting.
+func (e *Engine) WithProgress(fn ProgressFunc) *Engine {
+	e.progress = fn
+	return e
+}
+
+// WithParallelism lets the engine split the timeline at calendar month boundaries and process
+// up to n months on separate goroutines, merging the results. Each month is processed
+// speculatively from the world's state once the events at the start time are applied; a month
+// whose predecessor ends in a different state (e.g., a curfew, maintenance window or wind
+// change spanning the boundary) is reprocessed sequentially from where its predecessor left
+// off, so results always match a sequential run. The speed-up therefore depends on how many
+// month boundaries carry no state.
+//
+// Timelines with an annual noise quota, a night movement quota or runway wear checks, which
+// carry usage across months, are always processed sequentially. When processed in parallel, the
+// world passed to Calculate is not left in its end-of-run state. Values below 2 process the timeline sequentially (the default).
+func (e *Engine) WithParallelism(n int) *Engine {
+	e.parallelism = n
+	return e
+}
+
+// WithQuiet suppresses the per-event debug logs (one record per applied event and window),
+// keeping only the start, completion, warning and error logs. Use it for Monte Carlo batches,
+// where per-event logs would dominate the output.
+func (e *Engine) WithQuiet(quiet bool) *Engine {
+	e.quiet = quiet
+	return e
+}
+
+// WithMetrics reports every completed run to m (see Metrics). Passing nil disables reporting.
+func (e *Engine) WithMetrics(m Metrics) *Engine {
+	e.metrics = m
+	return e
+}
+
+// WithMinimumWindow coalesces state changes less than d apart into one window, so dense event
+// schedules (e.g., per-minute wind changes replayed from METARs) are calculated as windows of
+// at least d rather than one window per event. Every event is still applied, but an event less
+// than d after the start of the window in progress does not close it: its state applies from
+// the window start. Each state change can therefore move earlier by up to d, so the total is
+// off by at most d's worth of capacity per state change; this matters most for short events
+// such as gusts or brief closures. A minimum window processes the timeline sequentially.
+// Zero (the default) calculates every window.
+func (e *Engine) WithMinimumWindow(d time.Duration) *Engine {
+	e.minimumWindow = d
+	return e
+}
+
 // Calculate computes total annual movements using event-driven state-window approach.
 // This method processes events chronologically and calculates capacity for each time window.
+//
+// The context is checked between windows; if it is cancelled the calculation stops
+// and the context error is returned.
 func (e *Engine) Calculate(ctx context.Context, world *World) (float32, error) {
-	e.logger.InfoContext(ctx, "Starting event-driven capacity calculation",
+	result, err := e.CalculateResult(ctx, world)
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalCapacity, nil
+}
+
+// CalculateResult is like Calculate but returns the full Result, including the
+// capacity and world state of every time window processed.
+func (e *Engine) CalculateResult(ctx context.Context, world *World) (*Result, error) {
+	e.log(ctx).InfoContext(ctx, "Starting event-driven capacity calculation",
 		"airport", world.Airport.Name,
 		"startTime", world.StartTime,
 		"endTime", world.EndTime,
 		"numEvents", world.Events.Len())
 
-	totalCapacity, err := e.processTimeline(ctx, world)
+	started := time.Now()
+	result, err := e.processTimeline(ctx, world)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if e.metrics != nil {
+		recordMetrics(e.metrics, result, time.Since(started))
 	}
 
-	e.logger.InfoContext(ctx, "Event-driven calculation complete", "totalCapacity", totalCapacity)
+	e.log(ctx).InfoContext(ctx, "Event-driven calculation complete", "totalCapacity", result.TotalCapacity)
 
-	return totalCapacity, nil
+	return result, nil
 }
 
 // processTimeline processes events chronologically and calculates capacity for each time window.
-func (e *Engine) processTimeline(ctx context.Context, world *World) (float32, error) {
-	totalCapacity := float32(0)
-	previousEventTime := world.StartTime
+func (e *Engine) processTimeline(ctx context.Context, world *World) (*Result, error) {
+	e.log(ctx).InfoContext(ctx, "Processing timeline", "numEvents", world.Events.Len())
 
-	e.logger.InfoContext(ctx, "Processing timeline", "numEvents", world.Events.Len())
+	if e.parallelism > 1 && e.minimumWindow <= 0 && world.partitionable() {
+		return e.processPartitioned(ctx, world)
+	}
+
+	// Every queued event closes at most one window; events scheduled during the run grow the slice
+	result := &Result{
+		StartTime: world.StartTime,
+		EndTime:   world.EndTime,
+		Windows:   make([]WindowResult, 0, world.Events.Len()+1),
+	}
+
+	eventCount, err := e.processWindows(ctx, world, result, world.StartTime, world.EndTime, false, false)
+	if err != nil {
+		return nil, err
+	}
+	e.skipEventsAfterEnd(ctx, world, result)
+
+	e.reportProgress(eventCount, eventCount, world.EndTime)
+	result.ConfigurationHistory = world.ConfigurationHistory()
+	result.EventsProcessed = eventCount
+
+	e.log(ctx).InfoContext(ctx, "Timeline processing complete",
+		"eventsProcessed", eventCount,
+		"totalCapacity", result.TotalCapacity)
+
+	return result, nil
+}
+
+// processWindows applies the world's events between from and to in chronological order,
+// appending the capacity of each window between consecutive events (and the final window up
+// to to) to result. Events at exactly to are applied; events after to are left in the queue,
+// and events before from are skipped and counted in result.EventsSkipped. Returns the number
+// of events applied.
+//
+// Speculative processing (of a timeline partition that may be discarded) neither reports
+// progress nor releases pooled events, so the events can be applied again to another world.
+// With openEnd, the window after the last event applied is left open rather than calculated
+// up to to, so processing can later resume from that event (see CalculateUntil).
+func (e *Engine) processWindows(ctx context.Context, world *World, result *Result, from, to time.Time, speculative, openEnd bool) (int, error) {
+	previousEventTime := from
+	scratch := newWindowScratch(len(world.Airport.Runways))
 
 	// Process events in chronological order
 	eventCount := 0
 	for world.Events.HasNext() {
-		evt := world.Events.Pop()
+		// Honour cancellation between windows
+		if err := ctx.Err(); err != nil {
+			e.log(ctx).WarnContext(ctx, "Timeline processing cancelled",
+				"eventsProcessed", eventCount,
+				"simTime", previousEventTime)
+			return 0, err
+		}
+
+		evt := world.Events.Peek()
 		eventTime := evt.Time()
 
-		// Skip events outside simulation period
-		if eventTime.Before(world.StartTime) {
-			e.logger.DebugContext(ctx, "Skipping event before start time",
+		if eventTime.After(to) {
+			e.log(ctx).DebugContext(ctx, "Stopping at event after end time",
 				"eventType", evt.Type().String(),
 				"eventTime", eventTime,
-				"startTime", world.StartTime)
-			continue
+				"endTime", to)
+			// Leave it queued in its place among events at the same time; the final window
+			// below runs up to the end time
+			break
 		}
+		world.Events.Pop()
 
-		if eventTime.After(world.EndTime) {
-			e.logger.DebugContext(ctx, "Skipping event after end time",
+		// Skip events outside simulation period
+		if eventTime.Before(from) {
+			e.log(ctx).DebugContext(ctx, "Skipping event before start time",
 				"eventType", evt.Type().String(),
 				"eventTime", eventTime,
-				"endTime", world.EndTime)
-			// Put it back for final window calculation
-			previousEventTime = world.EndTime
-			break
+				"startTime", from)
+			result.EventsSkipped++
+			continue
 		}
 
-		// Calculate capacity for window [previousEventTime, eventTime]
+		// Calculate capacity for window [previousEventTime, eventTime], unless the event falls
+		// within the minimum window and is coalesced into the window in progress. Events at the
+		// end of the period never are: they must not change the capacity of the period itself
 		windowDuration := eventTime.Sub(previousEventTime)
-		// TODO: What happens if duration is 0. Probably just skip window calculation?
-		windowCapacity := e.calculateWindowCapacity(ctx, world, windowDuration)
+		coalesced := e.minimumWindow > 0 && windowDuration < e.minimumWindow && eventTime.Before(world.EndTime)
+		if !coalesced {
+			// TODO: What happens if duration is 0. Probably just skip window calculation?
+			windowCapacity := e.calculateWindowCapacity(ctx, world, scratch, previousEventTime, windowDuration)
 
-		e.logger.DebugContext(ctx, "Window capacity calculated",
-			"windowStart", previousEventTime,
-			"windowEnd", eventTime,
-			"duration", windowDuration,
-			"capacity", windowCapacity)
+			e.logWindow(ctx, world, "Applying event", evt.Type().String(), previousEventTime, eventTime, windowCapacity)
 
-		totalCapacity += windowCapacity
+			result.addWindow(world, previousEventTime, eventTime, windowCapacity, scratch)
+		}
 
 		// Apply event (changes world state)
-		e.logger.InfoContext(ctx, "Applying event",
-			"eventType", evt.Type().String(),
-			"eventTime", eventTime)
+		world.CurrentTime = eventTime
+		world.RunwayManager.SetTime(eventTime)
 
-		if err := evt.Apply(ctx, world); err != nil {
-			e.logger.ErrorContext(ctx, "Failed to apply event",
+		world.applyingEvent = evt.Type().String()
+		err := evt.Apply(ctx, world)
+		world.applyingEvent = ""
+		if err != nil {
+			e.log(ctx).ErrorContext(ctx, "Failed to apply event",
 				"eventType", evt.Type().String(),
 				"error", err)
-			return 0, err
+			return 0, fmt.Errorf("applying %s event at %v: %w", evt.Type(), evt.Time(), err)
 		}
 
-		world.CurrentTime = eventTime
-		previousEventTime = eventTime
+		if !coalesced {
+			previousEventTime = eventTime
+		}
 		eventCount++
+
+		if !speculative {
+			// Recycle high-volume event types now that their state has been applied, unless
+			// they are shared with other worlds forked from a checkpoint
+			if !world.retainEvents {
+				event.Release(evt)
+			}
+			e.reportProgress(eventCount, eventCount+world.Events.Len(), eventTime)
+		}
 	}
 
-	// Calculate capacity for final window from last event to end of simulation
-	if previousEventTime.Before(world.EndTime) {
-		finalDuration := world.EndTime.Sub(previousEventTime)
-		finalCapacity := e.calculateWindowCapacity(ctx, world, finalDuration)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 
-		e.logger.DebugContext(ctx, "Final window capacity calculated",
-			"windowStart", previousEventTime,
-			"windowEnd", world.EndTime,
-			"duration", finalDuration,
-			"capacity", finalCapacity)
+	// Calculate capacity for final window from last event to the end time
+	if previousEventTime.Before(to) && !openEnd {
+		finalDuration := to.Sub(previousEventTime)
+		finalCapacity := e.calculateWindowCapacity(ctx, world, scratch, previousEventTime, finalDuration)
 
-		totalCapacity += finalCapacity
+		e.logWindow(ctx, world, "Final window calculated", "", previousEventTime, to, finalCapacity)
+
+		result.addWindow(world, previousEventTime, to, finalCapacity, scratch)
 	}
 
-	e.logger.InfoContext(ctx, "Timeline processing complete",
-		"eventsProcessed", eventCount,
-		"totalCapacity", totalCapacity)
+	return eventCount, nil
+}
+
+// skipEventsAfterEnd drains the events left in the world's queue once its timeline has been
+// processed to the end of the simulation period, counting them in result.EventsSkipped, so a
+// finished world holds no events and schedules far beyond the period are reported rather than
+// left behind silently.
+func (e *Engine) skipEventsAfterEnd(ctx context.Context, world *World, result *Result) {
+	if !world.Events.HasNext() {
+		return
+	}
+
+	first := world.Events.Peek().Time()
+	skipped := 0
+	for world.Events.HasNext() {
+		world.Events.Pop()
+		skipped++
+	}
+	result.EventsSkipped += skipped
 
-	return totalCapacity, nil
+	e.log(ctx).DebugContext(ctx, "Skipped events after end time",
+		"events", skipped,
+		"firstEventTime", first,
+		"endTime", world.EndTime)
+}
+
+// logWindow logs the capacity of the window [start, end) as a structured debug record, with
+// the window's attributes in an "event" group so log pipelines can ingest them: the type of the
+// event ending the window (omitted for the final window), the simulation time the window ends,
+// its start, capacity and active runways. Nothing is logged in quiet mode.
+func (e *Engine) logWindow(ctx context.Context, world *World, msg, eventType string, start, end time.Time, capacity float32) {
+	if e.quiet {
+		return
+	}
+	logger := e.log(ctx)
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := make([]any, 0, 5)
+	if eventType != "" {
+		attrs = append(attrs, slog.String("event_type", eventType))
+	}
+	attrs = append(attrs,
+		slog.Time("sim_time", end),
+		slog.Time("window_start", start),
+		slog.Float64("window_capacity", float64(capacity)),
+		slog.Any("active_runways", world.activeRunwayIDs()))
+
+	logger.LogAttrs(ctx, slog.LevelDebug, msg, slog.Group("event", attrs...))
+}
+
+// reportProgress invokes the progress callback if one is registered.
+func (e *Engine) reportProgress(done, total int, simTime time.Time) {
+	if e.progress != nil {
+		e.progress(done, total, simTime)
+	}
 }
 
 // calculateWindowCapacity calculates the theoretical maximum capacity for a time window
@@ -126,70 +347,343 @@ func (e *Engine) processTimeline(ctx context.Context, world *World) (float32, er
 // No validation logic here - the active configuration already accounts for:
 // - Curfew status (empty config during curfew)
 // - Runway availability (maintenance, etc.)
-// - Future: crossing runways, wind direction, etc.
-func (e *Engine) calculateWindowCapacity(ctx context.Context, world *World, duration time.Duration) float32 {
+// - Wind limits, runway compatibility, and each runway's direction and operation type
+//
+// Each runway's capacity before operational constraints comes from the runway manager's
+// CapacityModel (see SeparationModel for the default). The window's movements are attributed to the active runways
+// in scratch.runwayMovements and added to the world's per-runway totals.
+func (e *Engine) calculateWindowCapacity(ctx context.Context, world *World, scratch *windowScratch, windowStart time.Time, duration time.Duration) float32 {
 	durationSeconds := float32(duration.Seconds())
+	windowEnd := windowStart.Add(duration)
 	capacity := float32(0)
+	clear(scratch.runwayMovements)
+	clear(scratch.gaMovements)
+	scratch.gateSamples = scratch.gateSamples[:0]
+
+	// Helicopter movements on helipads do not consume runway capacity
+	helipadMovements := world.helipadMovements(duration)
 
-	// Get active runway configuration (single source of truth)
-	activeRunways := world.GetActiveRunwayConfiguration()
+	// Get active runway configuration (single source of truth, read-only)
+	activeRunways := world.activeConfiguration()
 
-	// If no active runways (e.g., during curfew or all under maintenance), capacity is zero
+	// If no active runways (e.g., during curfew or all under maintenance), runway capacity is zero
 	if len(activeRunways) == 0 {
-		return 0
+		return helipadMovements
+	}
+
+	// Runways reversing direction handle no movements until the changeover completes
+	scratch.modelRunways = scratch.modelRunways[:0]
+	for _, runwayID := range configurationRunwayIDs(activeRunways) {
+		operatingTime := duration
+		if loss := world.DirectionChangeoverLoss(runwayID, windowStart, windowEnd); loss > 0 {
+			operatingTime -= loss
+		}
+		scratch.modelRunways = append(scratch.modelRunways, CapacityRunway{ActiveRunwayInfo: activeRunways[runwayID], OperatingTime: operatingTime})
+	}
+
+	// Calculate capacity for each active runway with the capacity model (by default duration /
+	// separation, with gusty periods adding separation to every movement and runways that
+	// interact de-rated)
+	if cap(scratch.modelCapacities) < len(scratch.modelRunways) {
+		scratch.modelCapacities = make([]float32, len(scratch.modelRunways))
+	}
+	scratch.modelCapacities = scratch.modelCapacities[:len(scratch.modelRunways)]
+	world.RunwayManager.CapacityModel().RunwayCapacities(CapacityInput{
+		Runways:        scratch.modelRunways,
+		FleetMix:       world.Airport.FleetMix,
+		Compatibility:  world.Airport.RunwayCompatibility,
+		GustSeparation: world.gustSeparation(),
+		WindSpeed:      world.WindSpeed,
+		WindDirection:  world.WindDirection,
+	}, scratch.modelCapacities)
+
+	runwayCapacities := scratch.runwayCapacities
+	clear(runwayCapacities)
+	for i, runway := range scratch.modelRunways {
+		// Remove arrivals that cannot land within the runway length under the current tailwind,
+		// apply any rotation penalty on the runway end in use, and stretch every movement's
+		// separation by any temporary separation adjustment
+		runwayCapacities[runway.RunwayDesignation] = scratch.modelCapacities[i] * e.tailwindCapacityFactor(world, runway.ActiveRunwayInfo) *
+			world.runwayRotationMultiplier(runway.RunwayEnd()) / world.separationFactor(runway.RunwayDesignation)
+	}
+
+	// Remove or cap movements covered by runway-specific and partial curfews
+	if len(world.CurfewRestrictions) > 0 {
+		e.applyCurfewRestrictions(world, activeRunways, runwayCapacities, duration)
+	}
+
+	// Remove movements by aircraft classes that cannot use the active runways' lengths
+	if world.Airport.FleetMix != nil {
+		e.applyRunwayLengthRestrictions(world, activeRunways, runwayCapacities)
 	}
 
-	// Sum capacity across all active runways
-	for _, activeRunway := range activeRunways {
-		separationSeconds := float32(activeRunway.Runway.MinimumSeparation.Seconds())
+	// Keep arrivals and departures balanced across runways restricted to one operation
+	e.applyOperationBalance(activeRunways, runwayCapacities)
+
+	// Set aside movements reserved for general aviation, which commercial traffic cannot use
+	if len(world.GAReservations) > 0 {
+		e.applyGAReservations(world, runwayCapacities, scratch.gaMovements)
+	}
 
-		// Runway capacity = duration / separation
-		// TODO: In future, adjust based on OperationType (TakeoffOnly, LandingOnly vs Mixed)
-		// TODO: In future, adjust based on Direction (Forward vs Reverse may have different characteristics)
-		runwayCapacity := durationSeconds / separationSeconds
+	// Sum capacity across all active runways, remembering each runway end's share for the noise quota
+	runwayEndCapacities := scratch.runwayEndCapacities
+	clear(runwayEndCapacities)
+	for runwayID, runwayCapacity := range runwayCapacities {
+		runwayEndCapacities[activeRunways[runwayID].RunwayEnd()] += runwayCapacity
 		capacity += runwayCapacity
 	}
+	unconstrainedCapacity := capacity
 
 	// Apply rotation efficiency multiplier
 	capacity *= world.RotationMultiplier
 
-	// Apply gate capacity constraint if present
-	if world.GateCapacityConstraint > 0 {
-		// Gate constraint is in movements per second
-		effectiveGateConstraint := world.GateCapacityConstraint
-
-		// If taxi time overhead is configured, adjust gate capacity
-		if world.TaxiTimeOverhead > 0 {
-			// Taxi time extends the effective turnaround time, reducing sustainable capacity
-			// For example: if base constraint allows 50 mvmt/hour (1 mvmt/72s)
-			// and taxi adds 10 min (600s) overhead, effective becomes 1 mvmt/(72s+600s)
-
-			// Calculate movements per second with taxi overhead
-			// Original: 1 movement per X seconds
-			// With taxi: 1 movement per (X + taxi_overhead) seconds
-			baseSecondsPerMovement := float32(1.0) / effectiveGateConstraint
-			taxiOverheadSeconds := float32(world.TaxiTimeOverhead.Seconds())
-			adjustedSecondsPerMovement := baseSecondsPerMovement + taxiOverheadSeconds
-			effectiveGateConstraint = 1.0 / adjustedSecondsPerMovement
-
-			e.logger.DebugContext(ctx, "Taxi time overhead applied to gate capacity",
-				"baseGateConstraint", world.GateCapacityConstraint,
-				"effectiveGateConstraint", effectiveGateConstraint,
-				"taxiOverhead", world.TaxiTimeOverhead)
-		}
-
-		// Convert to movements for this duration
-		gateConstrainedCapacity := effectiveGateConstraint * durationSeconds
-
-		// Take the minimum of runway capacity and gate-constrained capacity
-		if gateConstrainedCapacity < capacity {
-			e.logger.DebugContext(ctx, "Gate capacity constraint applied",
+	// Apply airfield disruptions (e.g., wind shear alerts, thunderstorms overhead)
+	capacity *= world.DisruptionFactor()
+
+	// Apply the de-icing constraint while it is cold enough for departures to need de-icing
+	if deicingConstraint := world.deicingConstraint(); deicingConstraint > 0 {
+		deicingConstrainedCapacity := deicingConstraint * durationSeconds
+		if deicingConstrainedCapacity < capacity {
+			e.log(ctx).DebugContext(ctx, "De-icing constraint applied",
+				"runwayCapacity", capacity,
+				"deicingConstrainedCapacity", deicingConstrainedCapacity,
+				"temperature", world.TemperatureCelsius,
+				"duration", duration)
+			capacity = deicingConstrainedCapacity
+		}
+	}
+
+	// Apply the declared capacity of a slot-coordinated airport. The rate is constant within a
+	// window, so capping it caps every rolling hour
+	if world.DeclaredCapacity > 0 {
+		declaredCapacity := float32(world.DeclaredCapacity * duration.Hours())
+		if declaredCapacity < capacity {
+			capacity = declaredCapacity
+		}
+	}
+
+	// Limit arrivals to the gates free and departures to the aircraft ready to leave them. The
+	// gates are advanced on a copy until the quotas below have settled the window's movements
+	var gates *gateOccupancy
+	gateCapacity := capacity
+	if world.gateOccupancy != nil && capacity > 0 {
+		gates = world.gateOccupancy.clone()
+		gateCapacity, scratch.gateSamples = gates.advance(windowStart, duration, capacity, world.OpenGates, world.gateOccupancyTime(), scratch.gateSamples[:0])
+		if gateCapacity < capacity {
+			e.log(ctx).DebugContext(ctx, "Gate capacity constraint applied",
 				"runwayCapacity", capacity,
-				"gateConstrainedCapacity", gateConstrainedCapacity,
+				"gateConstrainedCapacity", gateCapacity,
+				"openGates", world.OpenGates,
 				"duration", duration)
-			capacity = gateConstrainedCapacity
+			capacity = gateCapacity
+		}
+	}
+
+	// Cap movements on noise-critical runway ends once the annual noise quota is exhausted
+	if len(world.NoiseQuotaWeights) > 0 && capacity > 0 {
+		capacity = e.applyNoiseQuota(ctx, world, runwayEndCapacities, capacity/unconstrainedCapacity, windowStart)
+	}
+
+	// Limit movements during a night quota period to the nightly and annual allowances left
+	if world.NightQuotaActive && capacity > 0 {
+		capacity = world.ConsumeNightQuota(capacity, windowStart)
+	}
+
+	// Move the aircraft the window's movements actually used onto and off the gates
+	if gates != nil {
+		if capacity == gateCapacity {
+			world.gateOccupancy = gates
+		} else {
+			_, scratch.gateSamples = world.gateOccupancy.advance(windowStart, duration, capacity, world.OpenGates, world.gateOccupancyTime(), scratch.gateSamples[:0])
+		}
+	}
+
+	// Attribute the window's movements to runways in proportion to their capacity
+	if unconstrainedCapacity > 0 {
+		scale := capacity / unconstrainedCapacity
+		for runwayID, runwayCapacity := range runwayCapacities {
+			scratch.runwayMovements[runwayID] = runwayCapacity * scale
+		}
+		world.recordRunwayMovements(scratch.runwayMovements)
+	}
+
+	return capacity + helipadMovements
+}
+
+// applyRunwayLengthRestrictions scales runway capacities to the movements the fleet mix can
+// fly from the active runways (see airport.FleetMix.ServableMovements), so closing the only
+// runway long enough for an aircraft class removes that class's movements. Once a temperature
+// is known, runway lengths are corrected for the density altitude at that temperature.
+func (e *Engine) applyRunwayLengthRestrictions(world *World, activeRunways map[string]*event.ActiveRunwayInfo, runwayCapacities map[string]float32) {
+	runwayIDs := configurationRunwayIDs(activeRunways)
+	runways := make([]airport.Runway, len(runwayIDs))
+	capacities := make([]float64, len(runwayIDs))
+	total := 0.0
+	for i, runwayID := range runwayIDs {
+		runways[i] = activeRunways[runwayID].Runway
+		capacities[i] = float64(runwayCapacities[runwayID])
+		total += capacities[i]
+	}
+	if total <= 0 {
+		return
+	}
+
+	served := world.Airport.FleetMix.ServableMovements(runways, capacities)
+	if world.TemperatureKnown {
+		served = world.Airport.FleetMix.ServableMovementsAt(runways, capacities, world.TemperatureCelsius)
+	}
+
+	factor := float32(served / total)
+	for runwayID := range runwayCapacities {
+		runwayCapacities[runwayID] *= factor
+	}
+}
+
+// applyOperationBalance scales down runways restricted to one operation so that arrivals and
+// departures stay balanced, since every arriving aircraft eventually departs. Arrivals-only
+// runways can be used no more than departures-only and mixed-mode runways together can
+// depart, and vice versa; mixed-mode runways absorb any imbalance before dedicated runways
+// lose capacity.
+func (e *Engine) applyOperationBalance(activeRunways map[string]*event.ActiveRunwayInfo, runwayCapacities map[string]float32) {
+	var arrivals, departures, mixed float32
+	for runwayID, runwayCapacity := range runwayCapacities {
+		switch activeRunways[runwayID].OperationType {
+		case event.LandingOnly:
+			arrivals += runwayCapacity
+		case event.TakeoffOnly:
+			departures += runwayCapacity
+		default:
+			mixed += runwayCapacity
+		}
+	}
+
+	scale := func(opType event.OperationType, factor float32) {
+		for runwayID := range runwayCapacities {
+			if activeRunways[runwayID].OperationType == opType {
+				runwayCapacities[runwayID] *= factor
+			}
+		}
+	}
+
+	switch {
+	case arrivals > departures+mixed:
+		scale(event.LandingOnly, (departures+mixed)/arrivals)
+	case departures > arrivals+mixed:
+		scale(event.TakeoffOnly, (arrivals+mixed)/departures)
+	}
+}
+
+// applyGAReservations moves the movements reserved for general aviation from runwayCapacities
+// to gaMovements: every movement on a runway reserved for GA, and each reservation's share of
+// the other runways' movements. Reserved movements are reduced by airfield disruptions like
+// the commercial ones.
+func (e *Engine) applyGAReservations(world *World, runwayCapacities, gaMovements map[string]float32) {
+	disruption := world.DisruptionFactor()
+	for runwayID, runwayCapacity := range runwayCapacities {
+		reserved := float32(0)
+		for _, reservation := range world.GAReservations {
+			if reservation.IsReservedRunway(runwayID) {
+				reserved = runwayCapacity
+				break
+			}
+		}
+		if reserved < runwayCapacity {
+			// Shares of several reservations are each taken from what the others leave
+			remaining := runwayCapacity
+			for _, reservation := range world.GAReservations {
+				remaining *= 1 - float32(reservation.Share)
+			}
+			reserved = runwayCapacity - remaining
+		}
+		runwayCapacities[runwayID] -= reserved
+		gaMovements[runwayID] = reserved * disruption
+	}
+}
+
+// applyCurfewRestrictions reduces runway capacities for each active curfew restriction.
+// For every restriction, the movements it covers on matching runway ends are capped at its
+// hourly allowance for the window (zero for a full curfew), with the reduction shared across
+// the matching runways in proportion to their covered movements.
+func (e *Engine) applyCurfewRestrictions(world *World, activeRunways map[string]*event.ActiveRunwayInfo, runwayCapacities map[string]float32, duration time.Duration) {
+	for _, restriction := range world.CurfewRestrictions {
+		covered := make(map[string]float32, len(runwayCapacities))
+		totalCovered := float32(0)
+		for runwayID, runwayCapacity := range runwayCapacities {
+			activeRunway := activeRunways[runwayID]
+			if !restriction.AppliesToRunwayEnd(activeRunway.RunwayEnd()) {
+				continue
+			}
+			movements := runwayCapacity * restriction.RestrictedShare(activeRunway.OperationType)
+			covered[runwayID] = movements
+			totalCovered += movements
+		}
+
+		allowed := float32(restriction.MaxMovementsPerHour * duration.Hours())
+		if totalCovered <= allowed {
+			continue
 		}
+
+		reduction := 1 - allowed/totalCovered
+		for runwayID, movements := range covered {
+			runwayCapacities[runwayID] -= movements * reduction
+		}
+	}
+}
+
+// applyNoiseQuota charges each runway end's movements against the noise quota and returns the
+// window capacity the remaining quota allows. Runway capacities are first scaled by the ratio
+// applied to the total by rotation and gate constraints. Runway ends are charged in designation
+// order so results are deterministic when the quota runs out mid-window.
+func (e *Engine) applyNoiseQuota(ctx context.Context, world *World, runwayEndCapacities map[string]float32, scale float32, windowStart time.Time) float32 {
+	ends := make([]string, 0, len(runwayEndCapacities))
+	for end := range runwayEndCapacities {
+		ends = append(ends, end)
+	}
+	sort.Strings(ends)
+
+	capacity := float32(0)
+	for _, end := range ends {
+		movements := runwayEndCapacities[end] * scale
+		allowed := world.ConsumeNoiseQuota(end, movements, windowStart)
+		if allowed < movements {
+			e.log(ctx).DebugContext(ctx, "Noise quota limited runway end",
+				"runwayEnd", end,
+				"movements", movements,
+				"allowed", allowed,
+				"quotaUsed", world.NoiseQuotaUsed(windowStart))
+		}
+		capacity += allowed
 	}
 
 	return capacity
 }
+
+// tailwindCapacityFactor returns the fraction of a runway's capacity that remains feasible
+// once tailwind-corrected landing distances are compared against the landing distance
+// available on the runway end in use.
+// Only arrivals are affected: a LandingOnly runway is scaled by the feasible landing share,
+// a Mixed runway (half arrivals) by the average of 1 and that share, and TakeoffOnly is unaffected.
+// Returns 1 when no landing performance data is configured or there is no tailwind.
+func (e *Engine) tailwindCapacityFactor(world *World, activeRunway *event.ActiveRunwayInfo) float32 {
+	if len(world.LandingPerformance) == 0 || world.WindSpeed == 0 || activeRunway.OperationType == event.TakeoffOnly {
+		return 1
+	}
+
+	bearing := activeRunway.End().TrueBearing
+	headwind, _ := policy.CalculateWindComponents(bearing, world.WindSpeed, world.WindDirection)
+	if headwind >= 0 {
+		return 1
+	}
+
+	feasible := airport.FeasibleLandingShare(
+		world.LandingPerformance,
+		activeRunway.Runway.LandingDistanceMeters(activeRunway.Direction == event.Reverse),
+		-headwind,
+		world.TailwindDistanceFactorPerKnot,
+	)
+
+	if activeRunway.OperationType == event.LandingOnly {
+		return float32(feasible)
+	}
+	return float32(0.5 + 0.5*feasible)
+}
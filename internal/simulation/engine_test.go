@@ -0,0 +1,834 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func testEngineLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func createTestWorld(days int) *World {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, days)
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: createTestRunways(),
+	}
+	return NewWorld(ap, start, end)
+}
+
+func TestEngine_WithProgress(t *testing.T) {
+	world := createTestWorld(1)
+	start := world.StartTime
+	for i := 1; i <= 3; i++ {
+		world.ScheduleEvent(event.NewRotationChangeEvent(1.0, start.Add(time.Duration(i)*time.Hour)))
+	}
+
+	var calls []int
+	var lastDone, lastTotal int
+	var lastTime time.Time
+	engine := NewEngine(testEngineLogger()).WithProgress(func(done, total int, simTime time.Time) {
+		calls = append(calls, done)
+		lastDone, lastTotal, lastTime = done, total, simTime
+	})
+
+	if _, err := engine.Calculate(context.Background(), world); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+
+	// One call per event plus a final completion call
+	if len(calls) != 4 {
+		t.Fatalf("Expected 4 progress calls, got %d", len(calls))
+	}
+	if lastDone != 3 || lastTotal != 3 {
+		t.Errorf("Expected final progress 3/3, got %d/%d", lastDone, lastTotal)
+	}
+	if !lastTime.Equal(world.EndTime) {
+		t.Errorf("Expected final progress time %v, got %v", world.EndTime, lastTime)
+	}
+}
+
+func TestEngine_StructuredEventLogs(t *testing.T) {
+	run := func(quiet bool) []map[string]any {
+		world := createTestWorld(1)
+		world.ScheduleEvent(event.NewCurfewStartEvent(world.StartTime.Add(23 * time.Hour)))
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		if _, err := NewEngine(logger).WithQuiet(quiet).Calculate(context.Background(), world); err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+
+		var records []map[string]any
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var record map[string]any
+			if err := dec.Decode(&record); err != nil {
+				t.Fatalf("Expected JSON log records, got %v", err)
+			}
+			if group, ok := record["event"].(map[string]any); ok {
+				records = append(records, group)
+			}
+		}
+		return records
+	}
+
+	records := run(false)
+	// The window ending at the curfew start, the empty window before the configuration change
+	// it triggers, then the final window
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 event records, got %d: %v", len(records), records)
+	}
+	first := records[0]
+	if first["event_type"] != "CurfewStart" {
+		t.Errorf("Expected event_type CurfewStart, got %v", first["event_type"])
+	}
+	if first["sim_time"] != "2024-01-01T23:00:00Z" || first["window_start"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("Unexpected window times: %v", first)
+	}
+	if capacity, _ := first["window_capacity"].(float64); capacity <= 0 {
+		t.Errorf("Expected a positive window_capacity, got %v", first["window_capacity"])
+	}
+	if runways, _ := first["active_runways"].([]any); len(runways) == 0 {
+		t.Errorf("Expected active_runways before the curfew, got %v", first["active_runways"])
+	}
+	if final := records[2]; final["window_capacity"] != 0.0 {
+		t.Errorf("Expected no capacity during the curfew, got %v", final["window_capacity"])
+	}
+
+	if records := run(true); len(records) != 0 {
+		t.Errorf("Expected quiet mode to suppress per-event logs, got %v", records)
+	}
+}
+
+func TestEngine_CancelledContext(t *testing.T) {
+	world := createTestWorld(1)
+	world.ScheduleEvent(event.NewRotationChangeEvent(1.0, world.StartTime.Add(time.Hour)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewEngine(testEngineLogger()).Calculate(ctx, world)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEngine_CancelMidRun(t *testing.T) {
+	world := createTestWorld(1)
+	for i := 1; i <= 10; i++ {
+		world.ScheduleEvent(event.NewRotationChangeEvent(1.0, world.StartTime.Add(time.Duration(i)*time.Hour)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := 0
+	engine := NewEngine(testEngineLogger()).WithProgress(func(done, total int, simTime time.Time) {
+		processed = done
+		if done == 2 {
+			cancel()
+		}
+	})
+
+	_, err := engine.Calculate(ctx, world)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if processed != 2 {
+		t.Errorf("Expected processing to stop after 2 events, got %d", processed)
+	}
+}
+
+func TestEngine_TailwindCapacityFactor(t *testing.T) {
+	world := createTestWorld(1)
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 2000, MinimumSeparation: 60 * time.Second}
+	_ = world.SetLandingPerformance([]airport.LandingPerformance{
+		{Class: "Heavy", Share: 0.5, LandingDistanceMeters: 1900},
+		{Class: "Light", Share: 0.5, LandingDistanceMeters: 1000},
+	}, airport.DefaultTailwindDistanceFactorPerKnot)
+	world.WindSpeed = 10
+	world.WindDirection = 270 // 10kt tailwind on 09, 10kt headwind on 27
+
+	engine := NewEngine(testEngineLogger())
+
+	tests := []struct {
+		name      string
+		direction event.Direction
+		opType    event.OperationType
+		expected  float32
+	}{
+		{"headwind direction unaffected", event.Reverse, event.Mixed, 1.0},
+		{"tailwind landing only loses heavies", event.Forward, event.LandingOnly, 0.5},
+		{"tailwind mixed loses half of heavy arrivals", event.Forward, event.Mixed, 0.75},
+		{"tailwind takeoff only unaffected", event.Forward, event.TakeoffOnly, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &event.ActiveRunwayInfo{
+				RunwayDesignation: runway.RunwayDesignation,
+				OperationType:     tt.opType,
+				Direction:         tt.direction,
+				Runway:            runway,
+			}
+			if got := engine.tailwindCapacityFactor(world, info); got != tt.expected {
+				t.Errorf("Expected factor %.2f, got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEngine_DirectionChangeoverPenalty(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	run := func(penalty time.Duration) float32 {
+		world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+		if penalty > 0 {
+			world.ScheduleEvent(event.NewDirectionChangeoverPenaltyEvent(penalty, start))
+		}
+		// Easterly wind keeps 09 forward, westerly wind flips it to 27 after one hour
+		world.ScheduleEvent(event.NewWindChangeEvent(10, 90, start))
+		world.ScheduleEvent(event.NewWindChangeEvent(10, 270, start.Add(time.Hour)))
+
+		capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		return capacity
+	}
+
+	baseline := run(0)
+	penalised := run(10 * time.Minute)
+
+	if baseline != 120 {
+		t.Errorf("Expected baseline 120 movements, got %.1f", baseline)
+	}
+	if penalised != 110 {
+		t.Errorf("Expected 10 movements lost to changeover, got %.1f", penalised)
+	}
+}
+
+func TestEngine_GustFactor(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+	world.ScheduleEvent(event.NewGustFactorEvent(10, 30*time.Second, start))
+	// Gusts 8 kt above the mean stay below the threshold, then 15 kt above it, then die out
+	world.ScheduleEvent(event.NewGustingWindChangeEvent(10, 18, 90, start))
+	world.ScheduleEvent(event.NewGustingWindChangeEvent(15, 30, 90, start.Add(time.Hour)))
+	world.ScheduleEvent(event.NewWindChangeEvent(15, 90, start.Add(2*time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 60 + 40 (90s separation while gusty) + 60
+	if capacity != 160 {
+		t.Errorf("Expected 160 movements, got %.1f", capacity)
+	}
+}
+
+func TestEngine_RunwayRotationMultipliers(t *testing.T) {
+	world := createTestWorld(1)
+	// Halve 09L in either direction on top of a 10% airport-wide penalty, then lift the runway penalty
+	world.ScheduleEvent(event.NewRotationChangeEvent(0.9, world.StartTime))
+	world.ScheduleEvent(event.NewRunwayRotationChangeEvent(map[string]float32{"09L": 0.5, "27R": 0.5}, world.StartTime))
+	world.ScheduleEvent(event.NewRunwayRotationChangeEvent(nil, world.StartTime.Add(12*time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 12h at (20 + 40 + 40) * 0.9 + 12h at 120 * 0.9
+	if math.Abs(float64(capacity-2376)) > 0.01 {
+		t.Errorf("Expected 2376 movements, got %.2f", capacity)
+	}
+
+	world = createTestWorld(1)
+	if err := world.SetRunwayRotationMultipliers(map[string]float32{"09L": -0.5}); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a negative multiplier, got %v", err)
+	}
+}
+
+func TestEngine_Deicing(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+	// De-icing sustains 20 movements an hour below 3 °C
+	world.ScheduleEvent(event.NewDeicingConstraintEvent(20.0/3600, 3, start))
+	// No temperature is known in the first hour, then it is cold, then mild
+	world.ScheduleEvent(event.NewTemperatureChangeEvent(-5, start.Add(time.Hour)))
+	world.ScheduleEvent(event.NewTemperatureChangeEvent(5, start.Add(2*time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 60 + 20 (de-icing limited) + 60
+	if math.Abs(float64(capacity-140)) > 0.01 {
+		t.Errorf("Expected 140 movements, got %.2f", capacity)
+	}
+}
+
+func TestEngine_DeclaredCapacity(t *testing.T) {
+	world := createTestWorld(1)
+	world.ScheduleEvent(event.NewDeclaredCapacityEvent(88, world.StartTime))
+	// Two of the three runways (80 an hour) stay below the declared capacity
+	world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("18", world.StartTime.Add(20*time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 20h at 88 (declared, below the physical 120) + 4h at 80
+	if capacity != 88*20+80*4 {
+		t.Errorf("Expected %d movements, got %.1f", 88*20+80*4, capacity)
+	}
+}
+
+func TestEngine_NightQuota(t *testing.T) {
+	world := createTestWorld(2)
+	world.ScheduleEvent(event.NewNightQuotaEvent(16, 20, world.StartTime))
+	for day := 0; day < 2; day++ {
+		midnight := world.StartTime.AddDate(0, 0, day+1)
+		world.ScheduleEvent(event.NewNightQuotaStartEvent(midnight.Add(-2 * time.Hour)))
+		world.ScheduleEvent(event.NewNightQuotaEndEvent(midnight))
+	}
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	// 22h at 120 a day, 16 movements the first night and the 4 left of the annual quota the second
+	if want := float32(2*22*120 + 16 + 4); result.TotalCapacity != want {
+		t.Errorf("Expected %.0f movements, got %.1f", want, result.TotalCapacity)
+	}
+	if last := result.Windows[len(result.Windows)-1]; last.NightQuotaUsed != 20 {
+		t.Errorf("Expected the annual night quota to be used up, got %.1f", last.NightQuotaUsed)
+	}
+}
+
+func TestEngine_Disruptions(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+	closure := &event.Disruption{Reason: "thunderstorm", CapacityFactor: 0}
+	reduction := &event.Disruption{Reason: "wind shear", CapacityFactor: 0.5}
+	// Hour 2 at half rate; hour 3 closed, with the overlapping reduction having no further effect
+	world.ScheduleEvent(event.NewDisruptionStartEvent(reduction, start.Add(time.Hour)))
+	world.ScheduleEvent(event.NewDisruptionStartEvent(closure, start.Add(2*time.Hour)))
+	world.ScheduleEvent(event.NewDisruptionEndEvent(reduction, start.Add(150*time.Minute)))
+	world.ScheduleEvent(event.NewDisruptionEndEvent(closure, start.Add(3*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	// 60 + 30 + 0 + 60
+	if result.TotalCapacity != 150 {
+		t.Errorf("Expected 150 movements, got %.1f", result.TotalCapacity)
+	}
+	if len(world.Disruptions) != 0 {
+		t.Errorf("Expected all disruptions lifted, got %d", len(world.Disruptions))
+	}
+}
+
+func TestEngine_SeparationAdjustments(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	world := NewWorld(airport.Airport{Name: "Test", Runways: runways}, start, end)
+	trainee := &event.SeparationAdjustment{Reason: "trainee controllers", Factor: 1.5}
+	inspection := &event.SeparationAdjustment{Reason: "runway inspection", Runways: []string{"18"}, Factor: 2}
+	// Hour 2 with every runway at 1.5 times separation, and runway 18 doubled on top from half past
+	world.ScheduleEvent(event.NewSeparationAdjustmentStartEvent(trainee, start.Add(time.Hour)))
+	world.ScheduleEvent(event.NewSeparationAdjustmentStartEvent(inspection, start.Add(90*time.Minute)))
+	world.ScheduleEvent(event.NewSeparationAdjustmentEndEvent(trainee, start.Add(2*time.Hour)))
+	world.ScheduleEvent(event.NewSeparationAdjustmentEndEvent(inspection, start.Add(3*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	// 120 + (20 + 20) + (20 + 10) + (60 + 30) + 120
+	if result.TotalCapacity != 400 {
+		t.Errorf("Expected 400 movements, got %.1f", result.TotalCapacity)
+	}
+	if len(world.SeparationAdjustments) != 0 {
+		t.Errorf("Expected all separation adjustments lifted, got %d", len(world.SeparationAdjustments))
+	}
+}
+
+func TestEngine_NoiseQuota(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	run := func(weights map[string]float64, quota float64) *Result {
+		world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+		world.ScheduleEvent(event.NewNoiseQuotaEvent(weights, quota, start))
+		world.ScheduleEvent(event.NewWindChangeEvent(10, 90, start))
+		world.ScheduleEvent(event.NewRotationChangeEvent(1.0, start.Add(time.Hour)))
+
+		result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+		if err != nil {
+			t.Fatalf("CalculateResult failed: %v", err)
+		}
+		return result
+	}
+
+	t.Run("quota exhausted in first hour caps later movements", func(t *testing.T) {
+		// 09 handles 60 movements per hour at 2 points each; 150 points allow 75 movements
+		result := run(map[string]float64{"09": 2}, 150)
+		if result.TotalCapacity != 75 {
+			t.Errorf("Expected 75 movements, got %.1f", result.TotalCapacity)
+		}
+		last := result.Windows[len(result.Windows)-1]
+		if last.NoiseQuotaUsed != 150 {
+			t.Errorf("Expected 150 quota points used, got %.1f", last.NoiseQuotaUsed)
+		}
+	})
+
+	t.Run("unweighted runway end is not limited", func(t *testing.T) {
+		result := run(map[string]float64{"27": 2}, 0)
+		if result.TotalCapacity != 120 {
+			t.Errorf("Expected 120 movements, got %.1f", result.TotalCapacity)
+		}
+	})
+}
+
+func TestEngine_CapacityInteractions(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	}
+	compatibility := &airport.RunwayCompatibility{
+		CompatibleWith:       map[string][]string{"09L": {"09R"}, "09R": {"09L"}},
+		CapacityInteractions: []airport.CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0.85}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// 120 movements from the two runways together are de-rated to 102, 51 on each
+	world := NewWorld(airport.Airport{Name: "Test", Runways: runways, RunwayCompatibility: compatibility}, start, end)
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	if math.Abs(float64(result.TotalCapacity)-102) > 1e-3 {
+		t.Errorf("Expected 102 movements, got %.2f", result.TotalCapacity)
+	}
+	if movements := world.RunwayMovements("09L"); math.Abs(movements-51) > 1e-3 {
+		t.Errorf("Expected 51 movements on 09L, got %.2f", movements)
+	}
+}
+
+func TestEngine_RunwayWearMaintenance(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(12 * time.Hour)
+
+	// 09 handles 60 movements per hour, so 300 movements wear it out every 5 hours of use
+	world := NewWorld(airport.Airport{Name: "Test", Runways: []airport.Runway{runway}}, start, end)
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		world.ScheduleEvent(event.NewRunwayWearCheckEvent("09", 300, time.Hour, t))
+	}
+
+	result, err := NewEngine(testEngineLogger()).WithParallelism(4).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	// Closed 05:00-06:00 and 11:00-12:00
+	if result.TotalCapacity != 600 {
+		t.Errorf("Expected 600 movements, got %.1f", result.TotalCapacity)
+	}
+	if movements := world.RunwayMovements("09"); movements != 600 {
+		t.Errorf("Expected 600 movements attributed to 09, got %.1f", movements)
+	}
+
+	if err := world.CheckRunwayWear("27", 300, time.Hour, end); err == nil {
+		t.Error("Expected error checking wear of a nonexistent runway")
+	}
+}
+
+func TestWorld_ConsumeNoiseQuota_ResetsEachYear(t *testing.T) {
+	world := createTestWorld(1)
+	if err := world.SetNoiseQuota(map[string]float64{"27R": 1}, 10); err != nil {
+		t.Fatalf("SetNoiseQuota failed: %v", err)
+	}
+
+	lateDecember := time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)
+	if allowed := world.ConsumeNoiseQuota("27R", 15, lateDecember); allowed != 10 {
+		t.Errorf("Expected 10 movements allowed, got %.1f", allowed)
+	}
+	if allowed := world.ConsumeNoiseQuota("27R", 5, lateDecember); allowed != 0 {
+		t.Errorf("Expected quota exhausted, got %.1f allowed", allowed)
+	}
+	if allowed := world.ConsumeNoiseQuota("27R", 5, lateDecember.AddDate(0, 0, 1)); allowed != 5 {
+		t.Errorf("Expected quota reset in new year, got %.1f allowed", allowed)
+	}
+}
+
+func TestEngine_CurfewRestrictions(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		restriction *event.CurfewRestriction
+		expected    float32
+	}{
+		{"full curfew on one runway end", &event.CurfewRestriction{RunwayEnds: []string{"09L"}}, 60},
+		{"no departures off one runway end", &event.CurfewRestriction{RunwayEnds: []string{"09L"}, Operations: []airport.RunwayOperation{airport.Departures}}, 90},
+		{"restriction on inactive runway end", &event.CurfewRestriction{RunwayEnds: []string{"27L"}}, 120},
+		{"partial airport curfew", &event.CurfewRestriction{MaxMovementsPerHour: 20}, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			world := NewWorld(airport.Airport{Name: "Test", Runways: runways}, start, end)
+			world.ScheduleEvent(event.NewRunwayCurfewStartEvent(tt.restriction, start))
+
+			capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+			if err != nil {
+				t.Fatalf("Calculate failed: %v", err)
+			}
+			if capacity != tt.expected {
+				t.Errorf("Expected %.1f movements, got %.1f", tt.expected, capacity)
+			}
+		})
+	}
+}
+
+// BenchmarkEngine_HourlyWindChanges measures a one-year run driven by hourly wind changes,
+// each of which also schedules an active runway configuration change.
+func BenchmarkEngine_HourlyWindChanges(b *testing.B) {
+	logger := testEngineLogger()
+	engine := NewEngine(logger)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		world := createTestWorld(365)
+		for t := world.StartTime; t.Before(world.EndTime); t = t.Add(time.Hour) {
+			direction := 90.0
+			if t.Hour() >= 12 {
+				direction = 270
+			}
+			world.ScheduleEvent(event.NewWindChangeEvent(10, direction, t))
+		}
+
+		if _, err := engine.Calculate(context.Background(), world); err != nil {
+			b.Fatalf("Calculate failed: %v", err)
+		}
+	}
+}
+
+func TestEngine_FleetMixSeparation(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{runway},
+		// 3 NM at 120 kt is 90 seconds, longer than the runway's 60 second minimum
+		FleetMix: &airport.FleetMix{Classes: []airport.AircraftClass{{Name: "A320", Share: 1, ApproachSpeedKnots: 120}}},
+	}
+	world := NewWorld(ap, start, start.Add(time.Hour))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if capacity != 40 {
+		t.Errorf("Expected 40 movements, got %.1f", capacity)
+	}
+}
+
+func TestEngine_RunwayLengthRestrictions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Half the fleet needs 3000 m, so only the other half can use the 2500 m runway
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 2500, MinimumSeparation: 60 * time.Second}},
+		FleetMix: &airport.FleetMix{Classes: []airport.AircraftClass{
+			{Name: airport.HeavyClass, Share: 0.5, RequiredRunwayLengthMeters: 3000},
+			{Name: airport.MediumClass, Share: 0.5, RequiredRunwayLengthMeters: 2000},
+		}},
+	}
+	world := NewWorld(ap, start, start.Add(time.Hour))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if capacity != 30 {
+		t.Errorf("Expected 30 movements, got %.1f", capacity)
+	}
+}
+
+func TestEngine_TemperatureRestrictsHeavies(t *testing.T) {
+	start := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	// 3100 m is long enough for heavies at ISA, but not once 40 °C raises the density altitude
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3100, MinimumSeparation: 60 * time.Second}},
+		FleetMix: &airport.FleetMix{Classes: []airport.AircraftClass{
+			{Name: airport.HeavyClass, Share: 0.5, RequiredRunwayLengthMeters: 3000},
+			{Name: airport.MediumClass, Share: 0.5, RequiredRunwayLengthMeters: 2000},
+		}},
+	}
+	world := NewWorld(ap, start, start.Add(2*time.Hour))
+	world.ScheduleEvent(event.NewTemperatureChangeEvent(15, start))
+	world.ScheduleEvent(event.NewTemperatureChangeEvent(40, start.Add(time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 60 movements in the first hour, then only the mediums' 30
+	if capacity != 90 {
+		t.Errorf("Expected 90 movements, got %.1f", capacity)
+	}
+}
+
+func TestEngine_WetRunwaySeparation(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, SurfaceType: airport.Concrete, MinimumSeparation: 60 * time.Second}},
+	}
+	world := NewWorld(ap, start, start.Add(2*time.Hour))
+	world.ScheduleEvent(event.NewRunwaySurfaceConditionEvent("09", airport.Wet, start.Add(time.Hour)))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 60 movements dry, then one every 66 seconds while wet
+	expected := float32(60 + 3600.0/66)
+	if math.Abs(float64(capacity-expected)) > 0.01 {
+		t.Errorf("Expected %.2f movements, got %.2f", expected, capacity)
+	}
+}
+
+func TestEngine_OperationBalance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"18"},
+		"18":  {"09L"},
+	})
+	compat.AirspaceConflicts = []airport.AirspaceConflict{
+		{RunwayEnd: "09L", Operation: airport.Arrivals, ConflictingRunwayEnd: "18", ConflictingOperation: airport.Departures},
+	}
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: compat,
+	}
+	world := NewWorld(ap, start, start.Add(time.Hour))
+
+	capacity, err := NewEngine(testEngineLogger()).Calculate(context.Background(), world)
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 18 lands 60/h but 09L (40/h mixed) can only depart 40 of them
+	if math.Abs(float64(capacity-80)) > 0.01 {
+		t.Errorf("Expected 80 movements, got %.2f", capacity)
+	}
+}
+
+// newDenseWindWorld returns a world with a wind change every minute for the given days and a
+// nightly curfew from 23:02 to 06:02, so curfew changes fall inside coalesced windows.
+func newDenseWindWorld(days int) *World {
+	world := createTestWorld(days)
+	for t := world.StartTime.Add(time.Minute); t.Before(world.EndTime); t = t.Add(time.Minute) {
+		world.ScheduleEvent(event.NewWindChangeEvent(10, float64(t.Minute()*6), t))
+	}
+	for day := 0; day < days; day++ {
+		midnight := world.StartTime.AddDate(0, 0, day)
+		world.ScheduleEvent(event.NewCurfewEndEvent(midnight.Add(6*time.Hour + 2*time.Minute)))
+		world.ScheduleEvent(event.NewCurfewStartEvent(midnight.Add(23*time.Hour + 2*time.Minute)))
+	}
+	return world
+}
+
+func TestEngine_WithMinimumWindow(t *testing.T) {
+	ctx := context.Background()
+	exact, err := NewEngine(testEngineLogger()).CalculateResult(ctx, newDenseWindWorld(3))
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	coalesced, err := NewEngine(testEngineLogger()).WithMinimumWindow(5*time.Minute).CalculateResult(ctx, newDenseWindWorld(3))
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	// Every event is applied, but into windows of at least 5 minutes
+	if coalesced.EventsProcessed != exact.EventsProcessed {
+		t.Errorf("Expected %d events processed, got %d", exact.EventsProcessed, coalesced.EventsProcessed)
+	}
+	if maxWindows := 3*24*12 + 1; len(coalesced.Windows) > maxWindows {
+		t.Errorf("Expected at most %d windows, got %d (exact run: %d)", maxWindows, len(coalesced.Windows), len(exact.Windows))
+	}
+	for _, window := range coalesced.Windows[:len(coalesced.Windows)-1] {
+		if window.Duration() < 5*time.Minute {
+			t.Fatalf("Expected windows of at least 5 minutes, got %v at %v", window.Duration(), window.Start)
+		}
+	}
+
+	// Each curfew change moves by at most the minimum window: 5 of 120 movements per hour
+	// (3 runways at 90s) per change
+	changes := float64(2*3 - 1)
+	if diff := math.Abs(float64(coalesced.TotalCapacity - exact.TotalCapacity)); diff > changes*10 {
+		t.Errorf("Expected totals within %.0f movements, got %.0f and %.0f", changes*10, coalesced.TotalCapacity, exact.TotalCapacity)
+	}
+}
+
+func TestEngine_WithMinimumWindowSparseEvents(t *testing.T) {
+	ctx := context.Background()
+	newWorld := func() *World {
+		world := createTestWorld(5)
+		for day := 0; day < 5; day++ {
+			midnight := world.StartTime.AddDate(0, 0, day)
+			world.ScheduleEvent(event.NewCurfewEndEvent(midnight.Add(6 * time.Hour)))
+			world.ScheduleEvent(event.NewCurfewStartEvent(midnight.Add(23 * time.Hour)))
+		}
+		return world
+	}
+
+	exact, err := NewEngine(testEngineLogger()).CalculateResult(ctx, newWorld())
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	// Only the configuration changes at the same instants are coalesced, as zero-length windows
+	coalesced, err := NewEngine(testEngineLogger()).WithMinimumWindow(5*time.Minute).CalculateResult(ctx, newWorld())
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	assertSameResult(t, exact, coalesced)
+}
+
+func TestEngine_SameTimeEventsEndBeforeStart(t *testing.T) {
+	ctx := context.Background()
+	var totals []float32
+	// Back-to-back maintenance on 09L, with the second block's start pushed first and last
+	for _, startFirst := range []bool{true, false} {
+		world := createTestWorld(1)
+		handover := world.StartTime.Add(8 * time.Hour)
+		events := []event.Event{
+			event.NewRunwayMaintenanceStartEvent("09L", handover),
+			event.NewRunwayMaintenanceEndEvent("09L", handover),
+		}
+		if !startFirst {
+			events[0], events[1] = events[1], events[0]
+		}
+		world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09L", world.StartTime.Add(4*time.Hour)))
+		for _, evt := range events {
+			world.ScheduleEvent(evt)
+		}
+		world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09L", world.StartTime.Add(12*time.Hour)))
+
+		result, err := NewEngine(testEngineLogger()).CalculateResult(ctx, world)
+		if err != nil {
+			t.Fatalf("CalculateResult failed: %v", err)
+		}
+		totals = append(totals, result.TotalCapacity)
+	}
+
+	// 09L is closed from 04:00 to 12:00 either way: 8 hours of 40 movements lost
+	expected := float32(3*24*40 - 8*40)
+	if totals[0] != expected || totals[1] != expected {
+		t.Errorf("Expected %.0f movements regardless of push order, got %v", expected, totals)
+	}
+}
+
+func TestEngine_PeriodBoundaryEvents(t *testing.T) {
+	newWorld := func() *World {
+		world := createTestWorld(62) // January to early March, so parallel runs partition
+		world.ScheduleEvent(event.NewCurfewStartEvent(world.StartTime.AddDate(-1, 0, 0)))
+		world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09L", world.StartTime))
+		world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09L", world.StartTime.Add(4*time.Hour)))
+		world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09R", world.EndTime.Add(-time.Minute)))
+		world.ScheduleEvent(event.NewCurfewStartEvent(world.EndTime))
+		world.ScheduleEvent(event.NewCurfewEndEvent(world.EndTime.Add(time.Hour)))
+		world.ScheduleEvent(event.NewCurfewEndEvent(world.EndTime.AddDate(10, 0, 0)))
+		return world
+	}
+
+	// 09L is closed for the first 4 hours and 09R for the last minute; the curfew starting at
+	// the end is applied but takes nothing from the period, even when a minimum window would
+	// otherwise coalesce it into the final minute
+	expected := float64(62*24*120 - 4*40 - 40.0/60)
+	engines := map[string]*Engine{
+		"sequential":     NewEngine(testEngineLogger()),
+		"parallel":       NewEngine(testEngineLogger()).WithParallelism(4),
+		"minimum window": NewEngine(testEngineLogger()).WithMinimumWindow(5 * time.Minute),
+	}
+	for name, engine := range engines {
+		t.Run(name, func(t *testing.T) {
+			world := newWorld()
+			result, err := engine.CalculateResult(context.Background(), world)
+			if err != nil {
+				t.Fatalf("CalculateResult failed: %v", err)
+			}
+			if math.Abs(float64(result.TotalCapacity)-expected) > 0.5 {
+				t.Errorf("Expected %.1f movements, got %.1f", expected, result.TotalCapacity)
+			}
+			if last := result.Windows[len(result.Windows)-1]; !last.End.Equal(world.EndTime) {
+				t.Errorf("Expected the last window to end at %v, got %v", world.EndTime, last.End)
+			}
+			if result.EventsSkipped != 3 {
+				t.Errorf("Expected the 3 events outside the period to be skipped, got %d", result.EventsSkipped)
+			}
+			if world.Events.HasNext() {
+				t.Errorf("Expected no events left in the queue, got %d", world.Events.Len())
+			}
+		})
+	}
+
+	// The event at the end is applied: the world finishes in curfew
+	world := newWorld()
+	if _, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world); err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+	if !world.CurfewActive {
+		t.Error("Expected the curfew starting at the end time to be applied")
+	}
+}
@@ -0,0 +1,125 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// EnvelopePoint is one point on a capacity envelope: the most movements per hour a runway
+// configuration can handle at a given mix of arrivals and departures.
+type EnvelopePoint struct {
+	ArrivalFraction   float64 // Share of movements that are arrivals (0 = departures only, 1 = arrivals only)
+	ArrivalsPerHour   float64 // Arrivals per hour at this mix
+	DeparturesPerHour float64 // Departures per hour at this mix
+}
+
+// TotalPerHour returns the total movements per hour at this point.
+func (p EnvelopePoint) TotalPerHour() float64 {
+	return p.ArrivalsPerHour + p.DeparturesPerHour
+}
+
+// CapacityEnvelope is the arrival/departure capacity frontier (Pareto curve) of one runway
+// configuration. Every point on the curve is a mix the configuration can sustain; any
+// increase in arrivals beyond it costs departures and vice versa.
+type CapacityEnvelope struct {
+	RunwayIDs      []string                       // Runways in the configuration, sorted
+	OperationTypes map[string]event.OperationType // Operation type of each runway after airspace conflicts
+	Points         []EnvelopePoint                // Points in order of increasing arrival fraction
+}
+
+// CapacityEnvelopes computes the capacity envelope of every runway configuration the
+// airport's compatibility graph allows (every maximal compatible runway set), sweeping the
+// arrival fraction from 0 to 1 in steps points.
+//
+// Capacities are theoretical hourly rates from each runway's separation (adjusted for the
+// airport's fleet mix and de-rated for capacity interactions between runways, as in the
+// engine) in calm wind; runtime policies are not applied. Mixed-mode runways
+// can split their movements between arrivals and departures in any proportion, while
+// runways restricted to one operation by terminal airspace conflicts contribute only to
+// that operation.
+// Returns an error if steps is less than 2 or the airport configuration is invalid.
+func CapacityEnvelopes(ap airport.Airport, steps int) ([]CapacityEnvelope, error) {
+	if steps < 2 {
+		return nil, simerrors.Invalidf("capacity envelope needs at least 2 steps, got %d", steps)
+	}
+	if err := ap.Validate(); err != nil {
+		return nil, err
+	}
+
+	rm := NewRunwayManager(ap.Runways, ap.RunwayCompatibility)
+	rm.SetFleetMix(ap.FleetMix)
+	var envelopes []CapacityEnvelope
+	for _, config := range rm.CandidateConfigurations() {
+		envelopes = append(envelopes, newCapacityEnvelope(config, ap.FleetMix, ap.RunwayCompatibility, steps))
+	}
+
+	sort.Slice(envelopes, func(i, j int) bool {
+		return strings.Join(envelopes[i].RunwayIDs, ",") < strings.Join(envelopes[j].RunwayIDs, ",")
+	})
+	return envelopes, nil
+}
+
+// CapacityEnvelopes computes the capacity envelope of every runway configuration of the
+// simulated airport, after pre-simulation plugins are applied. See CapacityEnvelopes.
+func (s *Simulation) CapacityEnvelopes(steps int) ([]CapacityEnvelope, error) {
+	ap := s.enabledPolicies().applyPlugins(context.Background(), nil)
+	return CapacityEnvelopes(ap, steps)
+}
+
+// newCapacityEnvelope sweeps the arrival fraction for one configuration.
+//
+// With arrivals-only capacity L, departures-only capacity D and mixed-mode capacity M,
+// the most movements T at arrival fraction f satisfy fT <= L+M, (1-f)T <= D+M and
+// T <= L+D+M, so T = min(L+D+M, (L+M)/f, (D+M)/(1-f)).
+func newCapacityEnvelope(config map[string]*event.ActiveRunwayInfo, fleetMix *airport.FleetMix, compatibility *airport.RunwayCompatibility, steps int) CapacityEnvelope {
+	envelope := CapacityEnvelope{
+		RunwayIDs:      configurationRunwayIDs(config),
+		OperationTypes: make(map[string]event.OperationType, len(config)),
+		Points:         make([]EnvelopePoint, 0, steps),
+	}
+
+	var arrivalsOnly, departuresOnly, mixed float64
+	for runwayID, info := range config {
+		envelope.OperationTypes[runwayID] = info.OperationType
+
+		separationSeconds := fleetMix.RunwayModeSeparation(info.Runway, info.OperationType.SeparationMode()).Seconds()
+		if separationSeconds <= 0 {
+			continue
+		}
+		perHour := 3600 / separationSeconds * compatibility.InteractionFactor(runwayID, envelope.RunwayIDs)
+		switch info.OperationType {
+		case event.LandingOnly:
+			arrivalsOnly += perHour
+		case event.TakeoffOnly:
+			departuresOnly += perHour
+		default:
+			mixed += perHour
+		}
+	}
+
+	for i := 0; i < steps; i++ {
+		f := float64(i) / float64(steps-1)
+
+		total := arrivalsOnly + departuresOnly + mixed
+		if f > 0 {
+			total = math.Min(total, (arrivalsOnly+mixed)/f)
+		}
+		if f < 1 {
+			total = math.Min(total, (departuresOnly+mixed)/(1-f))
+		}
+
+		envelope.Points = append(envelope.Points, EnvelopePoint{
+			ArrivalFraction:   f,
+			ArrivalsPerHour:   f * total,
+			DeparturesPerHour: (1 - f) * total,
+		})
+	}
+
+	return envelope
+}
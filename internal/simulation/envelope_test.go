@@ -0,0 +1,146 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestCapacityEnvelopes_SingleMixedRunway(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: []airport.Runway{
+		{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+	}}
+
+	envelopes, err := CapacityEnvelopes(ap, 5)
+	if err != nil {
+		t.Fatalf("CapacityEnvelopes failed: %v", err)
+	}
+	if len(envelopes) != 1 || len(envelopes[0].Points) != 5 {
+		t.Fatalf("Expected one envelope with 5 points, got %+v", envelopes)
+	}
+
+	// A mixed-mode runway trades arrivals for departures one for one
+	for _, point := range envelopes[0].Points {
+		if point.TotalPerHour() != 60 {
+			t.Errorf("Expected 60 movements at arrival fraction %.2f, got %.1f", point.ArrivalFraction, point.TotalPerHour())
+		}
+	}
+	quarter := envelopes[0].Points[1]
+	if quarter.ArrivalFraction != 0.25 || quarter.ArrivalsPerHour != 15 || quarter.DeparturesPerHour != 45 {
+		t.Errorf("Expected 15 arrivals and 45 departures at 25%%, got %+v", quarter)
+	}
+}
+
+func TestCapacityEnvelopes_AirspaceConflict(t *testing.T) {
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"18"},
+		"18":  {"09L"},
+	})
+	compat.AirspaceConflicts = []airport.AirspaceConflict{
+		{RunwayEnd: "09L", Operation: airport.Arrivals, ConflictingRunwayEnd: "18", ConflictingOperation: airport.Departures},
+	}
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second},
+		},
+		RunwayCompatibility: compat,
+	}
+
+	envelopes, err := CapacityEnvelopes(ap, 3)
+	if err != nil {
+		t.Fatalf("CapacityEnvelopes failed: %v", err)
+	}
+	if len(envelopes) != 1 {
+		t.Fatalf("Expected one configuration, got %d", len(envelopes))
+	}
+	envelope := envelopes[0]
+	if envelope.OperationTypes["18"] != event.LandingOnly {
+		t.Errorf("Expected 18 restricted to LandingOnly, got %s", envelope.OperationTypes["18"])
+	}
+
+	// 09L (40/h mixed) and 18 (40/h arrivals only)
+	expected := []EnvelopePoint{
+		{ArrivalFraction: 0, ArrivalsPerHour: 0, DeparturesPerHour: 40},
+		{ArrivalFraction: 0.5, ArrivalsPerHour: 40, DeparturesPerHour: 40},
+		{ArrivalFraction: 1, ArrivalsPerHour: 80, DeparturesPerHour: 0},
+	}
+	for i, want := range expected {
+		if envelope.Points[i] != want {
+			t.Errorf("Point %d: expected %+v, got %+v", i, want, envelope.Points[i])
+		}
+	}
+}
+
+func TestCapacityEnvelopes_OneEnvelopePerConfiguration(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{
+			"09": {},
+			"18": {},
+		}),
+	}
+
+	envelopes, err := NewSimulation(ap, testEngineLogger()).CapacityEnvelopes(2)
+	if err != nil {
+		t.Fatalf("CapacityEnvelopes failed: %v", err)
+	}
+	if len(envelopes) != 2 || envelopes[0].RunwayIDs[0] != "09" || envelopes[1].RunwayIDs[0] != "18" {
+		t.Errorf("Expected separate envelopes for 09 and 18, got %+v", envelopes)
+	}
+}
+
+// The envelope and the engine must agree on the same airport: each runway's own separation,
+// with capacity interactions between runways operating together.
+func TestCapacityEnvelopes_MatchEngine(t *testing.T) {
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"09R"},
+		"09R": {"09L"},
+	})
+	compat.CapacityInteractions = []airport.CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0.8}}
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+		},
+		RunwayCompatibility: compat,
+	}
+
+	envelopes, err := CapacityEnvelopes(ap, 2)
+	if err != nil {
+		t.Fatalf("CapacityEnvelopes failed: %v", err)
+	}
+	// (60 + 40) movements per hour, de-rated by the interaction
+	if len(envelopes) != 1 || envelopes[0].Points[0].TotalPerHour() != 80 {
+		t.Fatalf("Expected one envelope of 80 movements per hour, got %+v", envelopes)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if perHour := result.Period().PerHour(result.TotalCapacity); math.Abs(perHour-envelopes[0].Points[0].TotalPerHour()) > 1e-3 {
+		t.Errorf("Expected the engine to match the envelope's %.1f movements per hour, got %.3f", envelopes[0].Points[0].TotalPerHour(), perHour)
+	}
+}
+
+func TestCapacityEnvelopes_InvalidSteps(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createTestRunways()}
+
+	if _, err := CapacityEnvelopes(ap, 1); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
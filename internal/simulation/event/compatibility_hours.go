@@ -0,0 +1,35 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// CompatibilityHoursEvent marks a time of day at which runway pairs enter or leave their
+// compatibility hours (see airport.CompatibilityHours), so the runway configuration is
+// re-selected with the compatibility graph in effect from then on.
+type CompatibilityHoursEvent struct {
+	timestamp time.Time
+}
+
+// NewCompatibilityHoursEvent creates a new compatibility hours event.
+func NewCompatibilityHoursEvent(timestamp time.Time) *CompatibilityHoursEvent {
+	return &CompatibilityHoursEvent{
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the compatibility hours change.
+func (e *CompatibilityHoursEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *CompatibilityHoursEvent) Type() EventType {
+	return CompatibilityHoursType
+}
+
+// Apply re-selects the runway configuration for the compatibility hours in effect.
+func (e *CompatibilityHoursEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.NotifyCompatibilityHours(e.timestamp)
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// DeclaredCapacityEvent represents a declared capacity being applied: the movements per hour
+// a slot-coordinated airport may schedule, whatever its runways could handle.
+type DeclaredCapacityEvent struct {
+	movementsPerHour float64
+	timestamp        time.Time
+}
+
+// NewDeclaredCapacityEvent creates a new declared capacity event.
+func NewDeclaredCapacityEvent(movementsPerHour float64, timestamp time.Time) *DeclaredCapacityEvent {
+	return &DeclaredCapacityEvent{
+		movementsPerHour: movementsPerHour,
+		timestamp:        timestamp,
+	}
+}
+
+// Time returns when the declared capacity is applied.
+func (e *DeclaredCapacityEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *DeclaredCapacityEvent) Type() EventType {
+	return DeclaredCapacityType
+}
+
+// MovementsPerHour returns the declared capacity in movements per rolling hour.
+func (e *DeclaredCapacityEvent) MovementsPerHour() float64 {
+	return e.movementsPerHour
+}
+
+// Apply sets the declared capacity in the world state.
+func (e *DeclaredCapacityEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetDeclaredCapacity(e.movementsPerHour)
+}
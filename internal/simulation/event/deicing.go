@@ -0,0 +1,49 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// DeicingConstraintEvent represents a de-icing capacity constraint being applied: the
+// movements per second the de-icing pads can sustain while the temperature is below a
+// threshold.
+type DeicingConstraintEvent struct {
+	maxMovementsPerSecond float32
+	thresholdCelsius      float64
+	timestamp             time.Time
+}
+
+// NewDeicingConstraintEvent creates a new de-icing constraint event.
+func NewDeicingConstraintEvent(maxMovementsPerSecond float32, thresholdCelsius float64, timestamp time.Time) *DeicingConstraintEvent {
+	return &DeicingConstraintEvent{
+		maxMovementsPerSecond: maxMovementsPerSecond,
+		thresholdCelsius:      thresholdCelsius,
+		timestamp:             timestamp,
+	}
+}
+
+// Time returns when the constraint is applied.
+func (e *DeicingConstraintEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *DeicingConstraintEvent) Type() EventType {
+	return DeicingConstraintType
+}
+
+// MaxMovementsPerSecond returns the maximum movements per second allowed by the de-icing pads.
+func (e *DeicingConstraintEvent) MaxMovementsPerSecond() float32 {
+	return e.maxMovementsPerSecond
+}
+
+// ThresholdCelsius returns the temperature below which departures need de-icing.
+func (e *DeicingConstraintEvent) ThresholdCelsius() float64 {
+	return e.thresholdCelsius
+}
+
+// Apply sets the de-icing constraint in the world state.
+func (e *DeicingConstraintEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetDeicingConstraint(e.maxMovementsPerSecond, e.thresholdCelsius)
+}
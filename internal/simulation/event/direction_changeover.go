@@ -0,0 +1,41 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// DirectionChangeoverPenaltyEvent represents the capacity loss window applied whenever an
+// active runway reverses its operating direction (e.g., 09 to 27).
+type DirectionChangeoverPenaltyEvent struct {
+	penalty   time.Duration
+	timestamp time.Time
+}
+
+// NewDirectionChangeoverPenaltyEvent creates a new direction changeover penalty event.
+func NewDirectionChangeoverPenaltyEvent(penalty time.Duration, timestamp time.Time) *DirectionChangeoverPenaltyEvent {
+	return &DirectionChangeoverPenaltyEvent{
+		penalty:   penalty,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the penalty is applied.
+func (e *DirectionChangeoverPenaltyEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *DirectionChangeoverPenaltyEvent) Type() EventType {
+	return DirectionChangeoverPenaltyType
+}
+
+// Penalty returns the duration of lost throughput per direction change.
+func (e *DirectionChangeoverPenaltyEvent) Penalty() time.Duration {
+	return e.penalty
+}
+
+// Apply sets the direction changeover penalty in the world state.
+func (e *DirectionChangeoverPenaltyEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetDirectionChangeoverPenalty(e.penalty)
+}
@@ -0,0 +1,84 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Disruption describes a short-duration total or partial closure of the whole airfield
+// (e.g., a wind shear alert or a thunderstorm overhead). The same disruption value is shared
+// by the start and end events so the world can identify which disruption to lift.
+type Disruption struct {
+	Reason         string  // What caused the disruption (e.g., "thunderstorm")
+	CapacityFactor float64 // Fraction of capacity remaining while disrupted (0 = airfield closed)
+}
+
+// DisruptionStartEvent represents the beginning of an airfield disruption.
+type DisruptionStartEvent struct {
+	disruption *Disruption
+	timestamp  time.Time
+}
+
+// NewDisruptionStartEvent creates a new disruption start event.
+func NewDisruptionStartEvent(disruption *Disruption, timestamp time.Time) *DisruptionStartEvent {
+	return &DisruptionStartEvent{
+		disruption: disruption,
+		timestamp:  timestamp,
+	}
+}
+
+// Time returns when the disruption starts.
+func (e *DisruptionStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *DisruptionStartEvent) Type() EventType {
+	return DisruptionStartType
+}
+
+// Disruption returns the disruption being activated.
+func (e *DisruptionStartEvent) Disruption() *Disruption {
+	return e.disruption
+}
+
+// Apply activates the disruption in the world state.
+func (e *DisruptionStartEvent) Apply(ctx context.Context, world WorldState) error {
+	world.ActivateDisruption(e.disruption)
+	return nil
+}
+
+// DisruptionEndEvent represents the end of an airfield disruption.
+type DisruptionEndEvent struct {
+	disruption *Disruption
+	timestamp  time.Time
+}
+
+// NewDisruptionEndEvent creates a new disruption end event.
+func NewDisruptionEndEvent(disruption *Disruption, timestamp time.Time) *DisruptionEndEvent {
+	return &DisruptionEndEvent{
+		disruption: disruption,
+		timestamp:  timestamp,
+	}
+}
+
+// Time returns when the disruption ends.
+func (e *DisruptionEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *DisruptionEndEvent) Type() EventType {
+	return DisruptionEndType
+}
+
+// Disruption returns the disruption being lifted.
+func (e *DisruptionEndEvent) Disruption() *Disruption {
+	return e.disruption
+}
+
+// Apply lifts the disruption in the world state.
+func (e *DisruptionEndEvent) Apply(ctx context.Context, world WorldState) error {
+	world.DeactivateDisruption(e.disruption)
+	return nil
+}
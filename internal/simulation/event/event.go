@@ -4,6 +4,8 @@ package event
 import (
 	"context"
 	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 )
 
 // Event represents a state change that occurs at a specific time during the simulation.
@@ -49,32 +51,141 @@ const (
 
 	// WindChangeType indicates wind conditions have changed
 	WindChangeType
+
+	// TailwindPerformanceType indicates fleet landing performance data is being applied
+	TailwindPerformanceType
+
+	// TideRestrictionStartType indicates a water runway becomes unusable due to low tide
+	TideRestrictionStartType
+
+	// TideRestrictionEndType indicates a water runway becomes usable again as the tide rises
+	TideRestrictionEndType
+
+	// DirectionChangeoverPenaltyType indicates a runway direction changeover penalty is being applied
+	DirectionChangeoverPenaltyType
+
+	// RunwayClosureStartType indicates a runway is closed for a reason other than maintenance
+	RunwayClosureStartType
+
+	// RunwayClosureEndType indicates a closed runway reopens
+	RunwayClosureEndType
+
+	// RunwayModificationType indicates a runway's characteristics have changed
+	RunwayModificationType
+
+	// NoiseQuotaType indicates a noise quota is being applied
+	NoiseQuotaType
+
+	// RunwayCurfewStartType indicates a runway-specific or partial curfew begins
+	RunwayCurfewStartType
+
+	// RunwayCurfewEndType indicates a runway-specific or partial curfew ends
+	RunwayCurfewEndType
+
+	// TemperatureChangeType indicates the outside air temperature has changed
+	TemperatureChangeType
+
+	// RunwaySurfaceConditionType indicates a runway's surface condition has changed
+	RunwaySurfaceConditionType
+
+	// RunwayCompatibilityChangeType indicates the runways a runway can operate with have changed
+	RunwayCompatibilityChangeType
+
+	// GustFactorType indicates extra separation is applied during gusty periods
+	GustFactorType
+
+	// DisruptionStartType indicates a short-duration airfield disruption begins (e.g., wind shear)
+	DisruptionStartType
+
+	// DisruptionEndType indicates an airfield disruption ends
+	DisruptionEndType
+
+	// RunwayWearCheckType indicates a runway's wear is checked for condition-based maintenance
+	RunwayWearCheckType
+
+	// DeclaredCapacityType indicates a declared (slot-coordinated) capacity cap is applied
+	DeclaredCapacityType
+
+	// NightQuotaType indicates a night movement quota is being applied
+	NightQuotaType
+
+	// NightQuotaStartType indicates a night quota period begins
+	NightQuotaStartType
+
+	// NightQuotaEndType indicates a night quota period ends
+	NightQuotaEndType
+
+	// CompatibilityHoursType indicates runway pairs enter or leave their compatibility hours
+	CompatibilityHoursType
+
+	// GAReservationStartType indicates capacity reserved for general aviation begins
+	GAReservationStartType
+
+	// GAReservationEndType indicates a general aviation reservation ends
+	GAReservationEndType
+
+	// DeicingConstraintType indicates a cold-weather de-icing capacity constraint is applied
+	DeicingConstraintType
+
+	// NoisePreferenceType indicates the noise-preferential runway configurations have changed
+	NoisePreferenceType
+
+	// SeparationAdjustmentStartType indicates a temporary runway separation adjustment begins
+	SeparationAdjustmentStartType
+
+	// SeparationAdjustmentEndType indicates a temporary runway separation adjustment ends
+	SeparationAdjustmentEndType
 )
 
+// eventTypeNames holds the name of every event type, indexed by type. It is the registry of
+// event types: EventTypes, String and ParseEventType are all derived from it, so a new type
+// needs only its constant above and its name here.
+var eventTypeNames = [...]string{
+	CurfewStartType:                      "CurfewStart",
+	CurfewEndType:                        "CurfewEnd",
+	RunwayMaintenanceStartType:           "RunwayMaintenanceStart",
+	RunwayMaintenanceEndType:             "RunwayMaintenanceEnd",
+	RotationChangeType:                   "RotationChange",
+	GateCapacityConstraintType:           "GateCapacityConstraint",
+	TaxiTimeAdjustmentType:               "TaxiTimeAdjustment",
+	ActiveRunwayConfigurationChangedType: "ActiveRunwayConfigurationChanged",
+	WindChangeType:                       "WindChange",
+	TailwindPerformanceType:              "TailwindPerformance",
+	TideRestrictionStartType:             "TideRestrictionStart",
+	TideRestrictionEndType:               "TideRestrictionEnd",
+	DirectionChangeoverPenaltyType:       "DirectionChangeoverPenalty",
+	RunwayClosureStartType:               "RunwayClosureStart",
+	RunwayClosureEndType:                 "RunwayClosureEnd",
+	RunwayModificationType:               "RunwayModification",
+	NoiseQuotaType:                       "NoiseQuota",
+	RunwayCurfewStartType:                "RunwayCurfewStart",
+	RunwayCurfewEndType:                  "RunwayCurfewEnd",
+	TemperatureChangeType:                "TemperatureChange",
+	RunwaySurfaceConditionType:           "RunwaySurfaceCondition",
+	RunwayCompatibilityChangeType:        "RunwayCompatibilityChange",
+	GustFactorType:                       "GustFactor",
+	DisruptionStartType:                  "DisruptionStart",
+	DisruptionEndType:                    "DisruptionEnd",
+	RunwayWearCheckType:                  "RunwayWearCheck",
+	DeclaredCapacityType:                 "DeclaredCapacity",
+	NightQuotaType:                       "NightQuota",
+	NightQuotaStartType:                  "NightQuotaStart",
+	NightQuotaEndType:                    "NightQuotaEnd",
+	CompatibilityHoursType:               "CompatibilityHours",
+	GAReservationStartType:               "GAReservationStart",
+	GAReservationEndType:                 "GAReservationEnd",
+	DeicingConstraintType:                "DeicingConstraint",
+	NoisePreferenceType:                  "NoisePreference",
+	SeparationAdjustmentStartType:        "SeparationAdjustmentStart",
+	SeparationAdjustmentEndType:          "SeparationAdjustmentEnd",
+}
+
 // String returns the string representation of the event type
 func (et EventType) String() string {
-	switch et {
-	case CurfewStartType:
-		return "CurfewStart"
-	case CurfewEndType:
-		return "CurfewEnd"
-	case RunwayMaintenanceStartType:
-		return "RunwayMaintenanceStart"
-	case RunwayMaintenanceEndType:
-		return "RunwayMaintenanceEnd"
-	case RotationChangeType:
-		return "RotationChange"
-	case GateCapacityConstraintType:
-		return "GateCapacityConstraint"
-	case TaxiTimeAdjustmentType:
-		return "TaxiTimeAdjustment"
-	case ActiveRunwayConfigurationChangedType:
-		return "ActiveRunwayConfigurationChanged"
-	case WindChangeType:
-		return "WindChange"
-	default:
+	if et < 0 || int(et) >= len(eventTypeNames) || eventTypeNames[et] == "" {
 		return "Unknown"
 	}
+	return eventTypeNames[et]
 }
 
 // WorldState defines the interface for accessing and modifying simulation state.
@@ -86,8 +197,9 @@ type WorldState interface {
 	// GetCurfewActive returns whether curfew is currently active
 	GetCurfewActive() bool
 
-	// SetRunwayAvailable marks a runway as available or unavailable
-	SetRunwayAvailable(runwayID string, available bool) error
+	// SetRunwayAvailable starts (false) or ends (true) a closure of a runway for a reason;
+	// the runway stays unavailable until all of its closures have ended
+	SetRunwayAvailable(runwayID, reason string, available bool) error
 
 	// GetRunwayAvailable checks if a runway is currently available
 	GetRunwayAvailable(runwayID string) (bool, error)
@@ -98,11 +210,21 @@ type WorldState interface {
 	// GetRotationMultiplier returns the current rotation efficiency multiplier
 	GetRotationMultiplier() float32
 
-	// SetGateCapacityConstraint sets the maximum movements per second allowed by gate capacity
-	SetGateCapacityConstraint(maxMovementsPerSecond float32) error
+	// SetRunwayRotationMultipliers sets the rotation efficiency multiplier of each runway end (nil clears them)
+	SetRunwayRotationMultipliers(multipliers map[string]float32) error
+
+	// SetGateCapacity sets the gates open for turnarounds and the average turnaround (0 gates means no constraint)
+	SetGateCapacity(gates int, turnaround time.Duration) error
+
+	// GetGateCapacity returns the gates open for turnarounds and the average turnaround
+	GetGateCapacity() (gates int, turnaround time.Duration)
 
-	// GetGateCapacityConstraint returns the gate capacity constraint (0 means no constraint)
-	GetGateCapacityConstraint() float32
+	// SetDeclaredCapacity sets the declared capacity in movements per rolling hour (0 means none)
+	SetDeclaredCapacity(movementsPerHour float64) error
+
+	// SetDeicingConstraint sets the maximum movements per second allowed by de-icing while the
+	// temperature is below thresholdCelsius (0 means no constraint)
+	SetDeicingConstraint(maxMovementsPerSecond float32, thresholdCelsius float64) error
 
 	// SetTaxiTimeOverhead sets the total taxi time overhead per aircraft cycle
 	SetTaxiTimeOverhead(overhead time.Duration) error
@@ -110,8 +232,9 @@ type WorldState interface {
 	// GetTaxiTimeOverhead returns the taxi time overhead (0 means no overhead)
 	GetTaxiTimeOverhead() time.Duration
 
-	// SetActiveRunwayConfiguration sets the active runway configuration (single source of truth)
-	SetActiveRunwayConfiguration(config map[string]*ActiveRunwayInfo) error
+	// SetActiveRunwayConfiguration sets the active runway configuration (single source of truth),
+	// recording the type of the event that caused the change ("" if none)
+	SetActiveRunwayConfiguration(config map[string]*ActiveRunwayInfo, trigger string) error
 
 	// GetActiveRunwayConfiguration returns the active runway configuration
 	GetActiveRunwayConfiguration() map[string]*ActiveRunwayInfo
@@ -128,9 +251,79 @@ type WorldState interface {
 	// and notifies the runway manager to recalculate active runway configuration
 	SetWind(speed, direction float64) error
 
+	// SetGustingWind sets the current wind conditions with gusts up to gust knots
+	// and notifies the runway manager to recalculate active runway configuration
+	SetGustingWind(speed, gust, direction float64) error
+
 	// GetWindSpeed returns the current wind speed in knots
 	GetWindSpeed() float64
 
 	// GetWindDirection returns the current wind direction in degrees true
 	GetWindDirection() float64
+
+	// SetLandingPerformance sets the fleet landing performance data used to penalise tailwind operations
+	SetLandingPerformance(fleet []airport.LandingPerformance, factorPerKnot float64) error
+
+	// SetDirectionChangeoverPenalty sets the throughput lost each time an active runway reverses direction
+	SetDirectionChangeoverPenalty(penalty time.Duration) error
+
+	// SetGustFactor sets the extra separation applied while the gust spread reaches a threshold
+	SetGustFactor(thresholdKnots float64, extraSeparation time.Duration) error
+
+	// ModifyRunway changes a runway's characteristics, notifies the runway manager,
+	// and schedules an ActiveRunwayConfigurationChangedEvent
+	ModifyRunway(runwayID string, modification airport.RunwayModification, timestamp time.Time) error
+
+	// SetNoiseQuota sets the per-runway-end noise weights and the annual noise quota
+	SetNoiseQuota(weights map[string]float64, quotaPoints float64) error
+
+	// SetNightQuota sets the movements permitted per night quota period and per calendar year
+	SetNightQuota(movementsPerNight, movementsPerYear float64) error
+
+	// SetNightQuotaActive sets whether a night quota period is in effect
+	SetNightQuotaActive(active bool)
+
+	// ActivateCurfewRestriction starts a runway-specific or partial curfew
+	ActivateCurfewRestriction(restriction *CurfewRestriction)
+
+	// DeactivateCurfewRestriction ends a previously activated curfew restriction
+	DeactivateCurfewRestriction(restriction *CurfewRestriction)
+
+	// ActivateDisruption starts a total or partial airfield disruption
+	ActivateDisruption(disruption *Disruption)
+
+	// DeactivateDisruption ends a previously activated disruption
+	DeactivateDisruption(disruption *Disruption)
+
+	// ActivateGAReservation starts reserving capacity for general aviation
+	ActivateGAReservation(reservation *GAReservation)
+
+	// DeactivateGAReservation ends a previously activated GA reservation
+	DeactivateGAReservation(reservation *GAReservation)
+
+	// ActivateSeparationAdjustment starts a temporary runway separation adjustment
+	ActivateSeparationAdjustment(adjustment *SeparationAdjustment)
+
+	// DeactivateSeparationAdjustment ends a previously activated separation adjustment
+	DeactivateSeparationAdjustment(adjustment *SeparationAdjustment)
+
+	// SetTemperature sets the current outside air temperature in °C
+	SetTemperature(celsius float64) error
+
+	// SetRunwayCompatibility changes the runways a runway can operate with simultaneously,
+	// notifies the runway manager, and schedules an ActiveRunwayConfigurationChangedEvent
+	SetRunwayCompatibility(runwayID string, compatibleWith []string, timestamp time.Time) error
+
+	// NotifyCompatibilityHours re-selects the runway configuration for the compatibility hours
+	// in effect and schedules an ActiveRunwayConfigurationChangedEvent
+	NotifyCompatibilityHours(timestamp time.Time) error
+
+	// SetNoisePreferences sets the runway configurations, as runway end designations, selected
+	// in order of preference whenever one can operate, and schedules an
+	// ActiveRunwayConfigurationChangedEvent (nil lifts them)
+	SetNoisePreferences(preferences [][]string, timestamp time.Time) error
+
+	// CheckRunwayWear schedules maintenance of the given duration on a runway once it has
+	// handled movementsPerMaintenance movements since its last wear-based maintenance
+	CheckRunwayWear(runwayID string, movementsPerMaintenance float64, duration time.Duration, timestamp time.Time) error
 }
@@ -0,0 +1,95 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// GAReservation describes capacity reserved for general aviation: whole runways used only by
+// GA traffic, and a share of the remaining runways' movements. Reserved movements are not
+// available to commercial traffic. The same reservation value is shared by the start and end
+// events of a policy so the world can identify which reservation to lift.
+type GAReservation struct {
+	Runways []string // Runway designations reserved entirely for GA (empty = none)
+	Share   float64  // Fraction of the other runways' movements reserved for GA (0 = none)
+}
+
+// IsReservedRunway reports whether the reservation reserves the whole runway for GA.
+func (r *GAReservation) IsReservedRunway(runwayID string) bool {
+	for _, id := range r.Runways {
+		if id == runwayID {
+			return true
+		}
+	}
+	return false
+}
+
+// GAReservationStartEvent represents the beginning of a general aviation reservation.
+type GAReservationStartEvent struct {
+	reservation *GAReservation
+	timestamp   time.Time
+}
+
+// NewGAReservationStartEvent creates a new GA reservation start event.
+func NewGAReservationStartEvent(reservation *GAReservation, timestamp time.Time) *GAReservationStartEvent {
+	return &GAReservationStartEvent{
+		reservation: reservation,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the reservation starts.
+func (e *GAReservationStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *GAReservationStartEvent) Type() EventType {
+	return GAReservationStartType
+}
+
+// Reservation returns the reservation being activated.
+func (e *GAReservationStartEvent) Reservation() *GAReservation {
+	return e.reservation
+}
+
+// Apply activates the reservation in the world state.
+func (e *GAReservationStartEvent) Apply(ctx context.Context, world WorldState) error {
+	world.ActivateGAReservation(e.reservation)
+	return nil
+}
+
+// GAReservationEndEvent represents the end of a general aviation reservation.
+type GAReservationEndEvent struct {
+	reservation *GAReservation
+	timestamp   time.Time
+}
+
+// NewGAReservationEndEvent creates a new GA reservation end event.
+func NewGAReservationEndEvent(reservation *GAReservation, timestamp time.Time) *GAReservationEndEvent {
+	return &GAReservationEndEvent{
+		reservation: reservation,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the reservation ends.
+func (e *GAReservationEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *GAReservationEndEvent) Type() EventType {
+	return GAReservationEndType
+}
+
+// Reservation returns the reservation being lifted.
+func (e *GAReservationEndEvent) Reservation() *GAReservation {
+	return e.reservation
+}
+
+// Apply lifts the reservation in the world state.
+func (e *GAReservationEndEvent) Apply(ctx context.Context, world WorldState) error {
+	world.DeactivateGAReservation(e.reservation)
+	return nil
+}
@@ -5,17 +5,20 @@ import (
 	"time"
 )
 
-// GateCapacityConstraintEvent represents a gate capacity constraint being applied.
+// GateCapacityConstraintEvent represents a gate capacity constraint being applied: the gates
+// open for turnarounds and the average time an aircraft occupies one.
 type GateCapacityConstraintEvent struct {
-	maxMovementsPerSecond float32
-	timestamp             time.Time
+	gates      int
+	turnaround time.Duration
+	timestamp  time.Time
 }
 
 // NewGateCapacityConstraintEvent creates a new gate capacity constraint event.
-func NewGateCapacityConstraintEvent(maxMovementsPerSecond float32, timestamp time.Time) *GateCapacityConstraintEvent {
+func NewGateCapacityConstraintEvent(gates int, turnaround time.Duration, timestamp time.Time) *GateCapacityConstraintEvent {
 	return &GateCapacityConstraintEvent{
-		maxMovementsPerSecond: maxMovementsPerSecond,
-		timestamp:             timestamp,
+		gates:      gates,
+		turnaround: turnaround,
+		timestamp:  timestamp,
 	}
 }
 
@@ -29,12 +32,17 @@ func (e *GateCapacityConstraintEvent) Type() EventType {
 	return GateCapacityConstraintType
 }
 
-// MaxMovementsPerSecond returns the maximum movements per second allowed by gate capacity.
-func (e *GateCapacityConstraintEvent) MaxMovementsPerSecond() float32 {
-	return e.maxMovementsPerSecond
+// Gates returns the number of gates open for turnarounds.
+func (e *GateCapacityConstraintEvent) Gates() int {
+	return e.gates
 }
 
-// Apply sets the gate capacity constraint in the world state.
+// Turnaround returns the average time an aircraft occupies a gate.
+func (e *GateCapacityConstraintEvent) Turnaround() time.Duration {
+	return e.turnaround
+}
+
+// Apply sets the gate capacity in the world state.
 func (e *GateCapacityConstraintEvent) Apply(ctx context.Context, world WorldState) error {
-	return world.SetGateCapacityConstraint(e.maxMovementsPerSecond)
+	return world.SetGateCapacity(e.gates, e.turnaround)
 }
@@ -0,0 +1,49 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// GustFactorEvent represents the extra separation applied between movements while the wind
+// is gusty: aircraft fly faster approaches and pilots leave larger gaps in turbulence.
+type GustFactorEvent struct {
+	thresholdKnots  float64
+	extraSeparation time.Duration
+	timestamp       time.Time
+}
+
+// NewGustFactorEvent creates a new gust factor event.
+func NewGustFactorEvent(thresholdKnots float64, extraSeparation time.Duration, timestamp time.Time) *GustFactorEvent {
+	return &GustFactorEvent{
+		thresholdKnots:  thresholdKnots,
+		extraSeparation: extraSeparation,
+		timestamp:       timestamp,
+	}
+}
+
+// Time returns when the gust factor is applied.
+func (e *GustFactorEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *GustFactorEvent) Type() EventType {
+	return GustFactorType
+}
+
+// ThresholdKnots returns the gust spread (gust minus mean wind speed) at which the extra
+// separation applies.
+func (e *GustFactorEvent) ThresholdKnots() float64 {
+	return e.thresholdKnots
+}
+
+// ExtraSeparation returns the separation added to every runway during gusty periods.
+func (e *GustFactorEvent) ExtraSeparation() time.Duration {
+	return e.extraSeparation
+}
+
+// Apply sets the gust factor in the world state.
+func (e *GustFactorEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetGustFactor(e.thresholdKnots, e.extraSeparation)
+}
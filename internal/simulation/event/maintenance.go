@@ -37,7 +37,7 @@ func (e *RunwayMaintenanceStartEvent) RunwayID() string {
 // Apply marks the runway as unavailable and triggers runway configuration recalculation.
 func (e *RunwayMaintenanceStartEvent) Apply(ctx context.Context, world WorldState) error {
 	// Update runway availability (for historical tracking)
-	if err := world.SetRunwayAvailable(e.runwayID, false); err != nil {
+	if err := world.SetRunwayAvailable(e.runwayID, "maintenance", false); err != nil {
 		return err
 	}
 
@@ -74,12 +74,20 @@ func (e *RunwayMaintenanceEndEvent) RunwayID() string {
 	return e.runwayID
 }
 
-// Apply marks the runway as available and triggers runway configuration recalculation.
+// Apply ends the closure and, unless another closure is still active, marks the runway as
+// available and triggers runway configuration recalculation.
 func (e *RunwayMaintenanceEndEvent) Apply(ctx context.Context, world WorldState) error {
 	// Update runway availability (for historical tracking)
-	if err := world.SetRunwayAvailable(e.runwayID, true); err != nil {
+	if err := world.SetRunwayAvailable(e.runwayID, "maintenance", true); err != nil {
 		return err
 	}
+	available, err := world.GetRunwayAvailable(e.runwayID)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return nil // Another closure still holds the runway
+	}
 
 	// Notify RunwayManager and schedule configuration change event
 	return world.NotifyRunwayAvailabilityChange(e.runwayID, true, e.timestamp)
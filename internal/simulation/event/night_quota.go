@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// NightQuotaEvent represents the application of a night movement quota: the movements
+// permitted in each night quota period and, optionally, across all night periods of a
+// calendar year.
+type NightQuotaEvent struct {
+	movementsPerNight float64
+	movementsPerYear  float64
+	timestamp         time.Time
+}
+
+// NewNightQuotaEvent creates a new night quota event.
+func NewNightQuotaEvent(movementsPerNight, movementsPerYear float64, timestamp time.Time) *NightQuotaEvent {
+	return &NightQuotaEvent{
+		movementsPerNight: movementsPerNight,
+		movementsPerYear:  movementsPerYear,
+		timestamp:         timestamp,
+	}
+}
+
+// Time returns when the quota is applied.
+func (e *NightQuotaEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *NightQuotaEvent) Type() EventType {
+	return NightQuotaType
+}
+
+// MovementsPerNight returns the movements permitted in each night quota period.
+func (e *NightQuotaEvent) MovementsPerNight() float64 {
+	return e.movementsPerNight
+}
+
+// MovementsPerYear returns the movements permitted in night quota periods per calendar year
+// (0 means no annual limit).
+func (e *NightQuotaEvent) MovementsPerYear() float64 {
+	return e.movementsPerYear
+}
+
+// Apply sets the night quota in the world state.
+func (e *NightQuotaEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetNightQuota(e.movementsPerNight, e.movementsPerYear)
+}
+
+// NightQuotaStartEvent represents the beginning of a night quota period, in which movements
+// are limited to the nightly allowance instead of stopped by a curfew.
+type NightQuotaStartEvent struct {
+	timestamp time.Time
+}
+
+// NewNightQuotaStartEvent creates a new night quota start event.
+func NewNightQuotaStartEvent(timestamp time.Time) *NightQuotaStartEvent {
+	return &NightQuotaStartEvent{
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the night quota period starts.
+func (e *NightQuotaStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *NightQuotaStartEvent) Type() EventType {
+	return NightQuotaStartType
+}
+
+// Apply starts a night quota period with the full nightly allowance.
+func (e *NightQuotaStartEvent) Apply(ctx context.Context, world WorldState) error {
+	world.SetNightQuotaActive(true)
+	return nil
+}
+
+// NightQuotaEndEvent represents the end of a night quota period.
+type NightQuotaEndEvent struct {
+	timestamp time.Time
+}
+
+// NewNightQuotaEndEvent creates a new night quota end event.
+func NewNightQuotaEndEvent(timestamp time.Time) *NightQuotaEndEvent {
+	return &NightQuotaEndEvent{
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the night quota period ends.
+func (e *NightQuotaEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *NightQuotaEndEvent) Type() EventType {
+	return NightQuotaEndType
+}
+
+// Apply ends the night quota period.
+func (e *NightQuotaEndEvent) Apply(ctx context.Context, world WorldState) error {
+	world.SetNightQuotaActive(false)
+	return nil
+}
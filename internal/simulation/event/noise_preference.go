@@ -0,0 +1,45 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// NoisePreferenceEvent changes the noise-preferential runway configurations: runway
+// configurations, each given as the runway end designations it operates, that the runway
+// manager selects in order of preference whenever one can operate, rather than the
+// configuration with the most capacity (e.g., a published preferential runway schedule
+// favouring departures over open country).
+type NoisePreferenceEvent struct {
+	preferences [][]string // Runway end designations of each configuration, most preferred first (nil = none)
+	timestamp   time.Time
+}
+
+// NewNoisePreferenceEvent creates a new noise preference event. nil lifts the preferences.
+func NewNoisePreferenceEvent(preferences [][]string, timestamp time.Time) *NoisePreferenceEvent {
+	return &NoisePreferenceEvent{
+		preferences: preferences,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the preferences change.
+func (e *NoisePreferenceEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *NoisePreferenceEvent) Type() EventType {
+	return NoisePreferenceType
+}
+
+// Preferences returns the runway end designations of each preferred configuration, most
+// preferred first, or nil if the preferences are lifted.
+func (e *NoisePreferenceEvent) Preferences() [][]string {
+	return e.preferences
+}
+
+// Apply sets the noise-preferential runway configurations and re-selects the active configuration.
+func (e *NoisePreferenceEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetNoisePreferences(e.preferences, e.timestamp)
+}
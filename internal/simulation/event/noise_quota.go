@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// NoiseQuotaEvent represents the application of an annual noise quota with per-runway-end
+// noise weights (QC points per movement).
+type NoiseQuotaEvent struct {
+	weights     map[string]float64
+	quotaPoints float64
+	timestamp   time.Time
+}
+
+// NewNoiseQuotaEvent creates a new noise quota event.
+func NewNoiseQuotaEvent(weights map[string]float64, quotaPoints float64, timestamp time.Time) *NoiseQuotaEvent {
+	return &NoiseQuotaEvent{
+		weights:     weights,
+		quotaPoints: quotaPoints,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the quota is applied.
+func (e *NoiseQuotaEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *NoiseQuotaEvent) Type() EventType {
+	return NoiseQuotaType
+}
+
+// Weights returns the noise points charged per movement on each runway end.
+func (e *NoiseQuotaEvent) Weights() map[string]float64 {
+	return e.weights
+}
+
+// QuotaPoints returns the annual noise quota.
+func (e *NoiseQuotaEvent) QuotaPoints() float64 {
+	return e.quotaPoints
+}
+
+// Apply sets the noise quota in the world state.
+func (e *NoiseQuotaEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetNoiseQuota(e.weights, e.quotaPoints)
+}
@@ -0,0 +1,35 @@
+package event
+
+import "sync"
+
+// Pools for high-volume event types. Long simulations driven by hourly weather data
+// create millions of wind and temperature changes and the configuration changes they
+// trigger, so these events are recycled once the engine has applied them.
+var (
+	windChangePool = sync.Pool{
+		New: func() any { return new(WindChangeEvent) },
+	}
+	temperatureChangePool = sync.Pool{
+		New: func() any { return new(TemperatureChangeEvent) },
+	}
+	configurationChangedPool = sync.Pool{
+		New: func() any { return new(ActiveRunwayConfigurationChangedEvent) },
+	}
+)
+
+// Release returns a processed event to its pool so a later constructor call can reuse it.
+// Events of types that are not pooled are ignored. The caller must not use the event,
+// or any value obtained from it, after releasing it.
+func Release(e Event) {
+	switch evt := e.(type) {
+	case *WindChangeEvent:
+		*evt = WindChangeEvent{}
+		windChangePool.Put(evt)
+	case *TemperatureChangeEvent:
+		*evt = TemperatureChangeEvent{}
+		temperatureChangePool.Put(evt)
+	case *ActiveRunwayConfigurationChangedEvent:
+		*evt = ActiveRunwayConfigurationChangedEvent{}
+		configurationChangedPool.Put(evt)
+	}
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelease_ClearsPooledEvents(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	wind := NewWindChangeEvent(15, 270, timestamp)
+	Release(wind)
+	if wind.GetSpeed() != 0 || wind.GetDirection() != 0 || !wind.Time().IsZero() {
+		t.Errorf("Expected released wind event to be cleared, got %+v", wind)
+	}
+
+	config := NewActiveRunwayConfigurationChangedEvent(map[string]*ActiveRunwayInfo{"09": {RunwayDesignation: "09"}}, "WindChange", timestamp)
+	Release(config)
+	if len(config.ActiveRunways()) != 0 || config.Trigger() != "" || !config.Time().IsZero() {
+		t.Errorf("Expected released configuration event to be cleared, got %+v", config)
+	}
+
+	// Releasing an event type without a pool is a no-op
+	Release(NewCurfewStartEvent(timestamp))
+}
+
+func TestNewWindChangeEvent_AfterRelease(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	Release(NewWindChangeEvent(15, 270, timestamp))
+
+	evt := NewWindChangeEvent(5, 90, timestamp.Add(time.Hour))
+	if evt.GetSpeed() != 5 || evt.GetDirection() != 90 || !evt.Time().Equal(timestamp.Add(time.Hour)) {
+		t.Errorf("Expected fresh values on reused event, got %+v", evt)
+	}
+}
+
+func BenchmarkNewWindChangeEvent(b *testing.B) {
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Release(NewWindChangeEvent(10, 270, timestamp))
+	}
+}
+
+func BenchmarkNewActiveRunwayConfigurationChangedEvent(b *testing.B) {
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := map[string]*ActiveRunwayInfo{"09": {RunwayDesignation: "09"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Release(NewActiveRunwayConfigurationChangedEvent(config, "WindChange", timestamp))
+	}
+}
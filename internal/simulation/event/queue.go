@@ -6,13 +6,43 @@ import (
 )
 
 // EventQueue is a priority queue of events ordered by time.
-// Events are processed chronologically from earliest to latest.
+// Events are processed chronologically from earliest to latest. Events at the same time are
+// ordered by priority class (see priority) and then in the order they were pushed, so the same
+// events always come out in the same order and identical inputs give identical results.
 // This queue is safe for concurrent use by multiple goroutines.
 type EventQueue struct {
 	items *eventHeap
+	seq   uint64 // Number of events pushed, used to order events of the same time and priority
 	mu    sync.Mutex
 }
 
+// Priority classes of events at the same time, applied in increasing order.
+const (
+	prioritySetup         = iota // Parameters set up for the rest of the run (gate, taxi, noise quota, ...)
+	priorityEnd                  // Restrictions ending, so a restriction ending and another starting leaves the new one in force
+	priorityChange               // Other state changes: restrictions starting, wind, runway changes, ...
+	priorityConfiguration        // Active runway configuration changes, which reflect every state change before them
+)
+
+// priority returns the priority class of an event type among events at the same time.
+// Event types not listed (including new ones) are ordinary state changes.
+func priority(t EventType) int {
+	switch t {
+	case GateCapacityConstraintType, TaxiTimeAdjustmentType, TailwindPerformanceType,
+		DirectionChangeoverPenaltyType, NoiseQuotaType, GustFactorType, RotationChangeType,
+		DeclaredCapacityType, NightQuotaType, DeicingConstraintType:
+		return prioritySetup
+	case CurfewEndType, RunwayMaintenanceEndType, TideRestrictionEndType, RunwayClosureEndType,
+		RunwayCurfewEndType, DisruptionEndType, NightQuotaEndType, GAReservationEndType,
+		SeparationAdjustmentEndType:
+		return priorityEnd
+	case ActiveRunwayConfigurationChangedType:
+		return priorityConfiguration
+	default:
+		return priorityChange
+	}
+}
+
 // NewEventQueue creates a new empty event queue.
 func NewEventQueue() *EventQueue {
 	h := &eventHeap{}
@@ -27,7 +57,8 @@ func NewEventQueue() *EventQueue {
 func (q *EventQueue) Push(event Event) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	heap.Push(q.items, event)
+	heap.Push(q.items, queuedEvent{event: event, priority: priority(event.Type()), seq: q.seq})
+	q.seq++
 }
 
 // Pop removes and returns the earliest event from the queue.
@@ -39,7 +70,7 @@ func (q *EventQueue) Pop() Event {
 	if q.items.Len() == 0 {
 		return nil
 	}
-	return heap.Pop(q.items).(Event)
+	return heap.Pop(q.items).(queuedEvent).event
 }
 
 // Peek returns the earliest event without removing it.
@@ -51,7 +82,7 @@ func (q *EventQueue) Peek() Event {
 	if q.items.Len() == 0 {
 		return nil
 	}
-	return (*q.items)[0]
+	return (*q.items)[0].event
 }
 
 // Len returns the number of events in the queue.
@@ -70,8 +101,16 @@ func (q *EventQueue) HasNext() bool {
 	return q.items.Len() > 0
 }
 
-// eventHeap implements heap.Interface for Event items ordered by time.
-type eventHeap []Event
+// queuedEvent is an event in the queue with its ordering keys.
+type queuedEvent struct {
+	event    Event
+	priority int    // Priority class among events at the same time
+	seq      uint64 // Position in the order events were pushed
+}
+
+// eventHeap implements heap.Interface for queued events ordered by time, priority class and
+// insertion order.
+type eventHeap []queuedEvent
 
 func (h eventHeap) Len() int {
 	return len(h)
@@ -79,7 +118,13 @@ func (h eventHeap) Len() int {
 
 func (h eventHeap) Less(i, j int) bool {
 	// Earlier events have higher priority
-	return h[i].Time().Before(h[j].Time())
+	if ti, tj := h[i].event.Time(), h[j].event.Time(); !ti.Equal(tj) {
+		return ti.Before(tj)
+	}
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
 }
 
 func (h eventHeap) Swap(i, j int) {
@@ -87,7 +132,7 @@ func (h eventHeap) Swap(i, j int) {
 }
 
 func (h *eventHeap) Push(x any) {
-	*h = append(*h, x.(Event))
+	*h = append(*h, x.(queuedEvent))
 }
 
 func (h *eventHeap) Pop() any {
@@ -305,3 +305,30 @@ func TestEventQueue_StressTest(t *testing.T) {
 		t.Errorf("Expected empty queue, got length %d", queue.Len())
 	}
 }
+
+func TestEventQueue_SameTimeOrdering(t *testing.T) {
+	at := time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC)
+	pushed := []*mockEvent{
+		{at, ActiveRunwayConfigurationChangedType},
+		{at, WindChangeType},
+		{at, RunwayMaintenanceStartType},
+		{at.Add(-time.Minute), ActiveRunwayConfigurationChangedType},
+		{at, RunwayMaintenanceEndType},
+		{at, GateCapacityConstraintType},
+		{at, CurfewEndType},
+		{at, ActiveRunwayConfigurationChangedType},
+	}
+	// Setup, then restrictions ending, then other changes, then configuration changes, each
+	// in the order pushed
+	expected := []*mockEvent{pushed[3], pushed[5], pushed[4], pushed[6], pushed[1], pushed[2], pushed[0], pushed[7]}
+
+	queue := NewEventQueue()
+	for _, evt := range pushed {
+		queue.Push(evt)
+	}
+	for i, want := range expected {
+		if got := queue.Pop(); got != want {
+			t.Fatalf("Expected event %d to be %v at %v, got %v at %v", i, want.Type(), want.Time(), got.Type(), got.Time())
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package event
+
+import (
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// EventTypes returns every event type in declaration order.
+func EventTypes() []EventType {
+	types := make([]EventType, 0, len(eventTypeNames))
+	for i, name := range eventTypeNames {
+		if name != "" {
+			types = append(types, EventType(i))
+		}
+	}
+	return types
+}
+
+// ParseEventType returns the event type with the given name (see EventType.String).
+// Returns an error wrapping simerrors.ErrInvalidConfiguration if no type has that name.
+func ParseEventType(name string) (EventType, error) {
+	for i, typeName := range eventTypeNames {
+		if typeName != "" && typeName == name {
+			return EventType(i), nil
+		}
+	}
+	return 0, simerrors.Invalidf("unknown event type %q", name)
+}
+
+// EventFields holds the parameters of an event for NewEvent. Each event type reads only the
+// fields its constructor takes; the others are ignored.
+type EventFields struct {
+	RunwayID string // Runway affected (maintenance, closures, tides, modifications, surface, compatibility, wear)
+	Reason   string // Why a runway is closed (closures)
+
+	SpeedKnots     float64 // Mean wind speed (wind changes)
+	GustKnots      float64 // Peak gust speed, 0 for no gusts (wind changes)
+	DirectionTrue  float64 // Wind direction in degrees true (wind changes)
+	ThresholdKnots float64 // Gust spread at which extra separation applies (gust factor)
+
+	TemperatureCelsius float64            // Outside air temperature (temperature changes) or threshold below which de-icing applies (de-icing constraints)
+	Multiplier         float32            // Rotation efficiency multiplier (rotation changes)
+	RunwayMultipliers  map[string]float32 // Rotation efficiency multiplier by runway end, nil for an airport-wide change (rotation changes)
+	MovementsPerSecond float32            // Maximum movements per second (de-icing constraints)
+	MovementsPerHour   float64            // Maximum movements per rolling hour (declared capacity)
+	Duration           time.Duration      // Taxi overhead, gate turnaround, changeover penalty, gust factor separation, or wear maintenance
+	Gates              int                // Gates open for turnarounds (gate capacity constraints)
+
+	Restriction      *CurfewRestriction           // Runway curfew restriction (runway curfews)
+	Disruption       *Disruption                  // Airfield disruption (disruptions)
+	GAReservation    *GAReservation               // General aviation reservation (GA reservations)
+	Adjustment       *SeparationAdjustment        // Temporary separation adjustment (separation adjustments)
+	Modification     airport.RunwayModification   // Runway change (runway modifications)
+	SurfaceCondition airport.SurfaceCondition     // Runway surface condition (surface conditions)
+	CompatibleWith   []string                     // Runways the runway can operate with (compatibility changes)
+	Preferences      [][]string                   // Runway end designations of each configuration, most preferred first (noise preferences)
+	NoiseWeights     map[string]float64           // Quota points per movement by runway end (noise quotas)
+	QuotaPoints      float64                      // Annual noise quota (noise quotas)
+	Fleet            []airport.LandingPerformance // Fleet landing performance (tailwind performance)
+	FactorPerKnot    float64                      // Landing distance factor per knot of tailwind (tailwind performance)
+	Movements        float64                      // Movements between maintenance (wear checks) or per night (night quotas)
+	MovementsPerYear float64                      // Night movements per calendar year, 0 for no limit (night quotas)
+	ActiveRunways    map[string]*ActiveRunwayInfo // New active configuration (configuration changes)
+	Trigger          string                       // Type of the event that caused a configuration change
+}
+
+// NewEvent creates an event of the given type at timestamp from fields, using the type's
+// constructor, so consumers that work with event types (e.g., replaying a recorded event log)
+// can construct any kind of event. The result can be type-switched on its concrete type.
+// Returns an error wrapping simerrors.ErrInvalidConfiguration for an unknown event type.
+func NewEvent(eventType EventType, timestamp time.Time, fields EventFields) (Event, error) {
+	switch eventType {
+	case CurfewStartType:
+		return NewCurfewStartEvent(timestamp), nil
+	case CurfewEndType:
+		return NewCurfewEndEvent(timestamp), nil
+	case RunwayMaintenanceStartType:
+		return NewRunwayMaintenanceStartEvent(fields.RunwayID, timestamp), nil
+	case RunwayMaintenanceEndType:
+		return NewRunwayMaintenanceEndEvent(fields.RunwayID, timestamp), nil
+	case RotationChangeType:
+		if fields.RunwayMultipliers != nil {
+			return NewRunwayRotationChangeEvent(fields.RunwayMultipliers, timestamp), nil
+		}
+		return NewRotationChangeEvent(fields.Multiplier, timestamp), nil
+	case GateCapacityConstraintType:
+		return NewGateCapacityConstraintEvent(fields.Gates, fields.Duration, timestamp), nil
+	case TaxiTimeAdjustmentType:
+		return NewTaxiTimeAdjustmentEvent(fields.Duration, timestamp), nil
+	case ActiveRunwayConfigurationChangedType:
+		return NewActiveRunwayConfigurationChangedEvent(fields.ActiveRunways, fields.Trigger, timestamp), nil
+	case WindChangeType:
+		return NewGustingWindChangeEvent(fields.SpeedKnots, fields.GustKnots, fields.DirectionTrue, timestamp), nil
+	case TailwindPerformanceType:
+		return NewTailwindPerformanceEvent(fields.Fleet, fields.FactorPerKnot, timestamp), nil
+	case TideRestrictionStartType:
+		return NewTideRestrictionStartEvent(fields.RunwayID, timestamp), nil
+	case TideRestrictionEndType:
+		return NewTideRestrictionEndEvent(fields.RunwayID, timestamp), nil
+	case DirectionChangeoverPenaltyType:
+		return NewDirectionChangeoverPenaltyEvent(fields.Duration, timestamp), nil
+	case RunwayClosureStartType:
+		return NewRunwayClosureStartEvent(fields.RunwayID, fields.Reason, timestamp), nil
+	case RunwayClosureEndType:
+		return NewRunwayClosureEndEvent(fields.RunwayID, fields.Reason, timestamp), nil
+	case RunwayModificationType:
+		return NewRunwayModificationEvent(fields.RunwayID, fields.Modification, timestamp), nil
+	case NoiseQuotaType:
+		return NewNoiseQuotaEvent(fields.NoiseWeights, fields.QuotaPoints, timestamp), nil
+	case RunwayCurfewStartType:
+		return NewRunwayCurfewStartEvent(fields.Restriction, timestamp), nil
+	case RunwayCurfewEndType:
+		return NewRunwayCurfewEndEvent(fields.Restriction, timestamp), nil
+	case GAReservationStartType:
+		return NewGAReservationStartEvent(fields.GAReservation, timestamp), nil
+	case GAReservationEndType:
+		return NewGAReservationEndEvent(fields.GAReservation, timestamp), nil
+	case SeparationAdjustmentStartType:
+		return NewSeparationAdjustmentStartEvent(fields.Adjustment, timestamp), nil
+	case SeparationAdjustmentEndType:
+		return NewSeparationAdjustmentEndEvent(fields.Adjustment, timestamp), nil
+	case TemperatureChangeType:
+		return NewTemperatureChangeEvent(fields.TemperatureCelsius, timestamp), nil
+	case RunwaySurfaceConditionType:
+		return NewRunwaySurfaceConditionEvent(fields.RunwayID, fields.SurfaceCondition, timestamp), nil
+	case RunwayCompatibilityChangeType:
+		return NewRunwayCompatibilityChangeEvent(fields.RunwayID, fields.CompatibleWith, timestamp), nil
+	case GustFactorType:
+		return NewGustFactorEvent(fields.ThresholdKnots, fields.Duration, timestamp), nil
+	case DisruptionStartType:
+		return NewDisruptionStartEvent(fields.Disruption, timestamp), nil
+	case DisruptionEndType:
+		return NewDisruptionEndEvent(fields.Disruption, timestamp), nil
+	case RunwayWearCheckType:
+		return NewRunwayWearCheckEvent(fields.RunwayID, fields.Movements, fields.Duration, timestamp), nil
+	case DeclaredCapacityType:
+		return NewDeclaredCapacityEvent(fields.MovementsPerHour, timestamp), nil
+	case NightQuotaType:
+		return NewNightQuotaEvent(fields.Movements, fields.MovementsPerYear, timestamp), nil
+	case NightQuotaStartType:
+		return NewNightQuotaStartEvent(timestamp), nil
+	case NightQuotaEndType:
+		return NewNightQuotaEndEvent(timestamp), nil
+	case CompatibilityHoursType:
+		return NewCompatibilityHoursEvent(timestamp), nil
+	case DeicingConstraintType:
+		return NewDeicingConstraintEvent(fields.MovementsPerSecond, fields.TemperatureCelsius, timestamp), nil
+	case NoisePreferenceType:
+		return NewNoisePreferenceEvent(fields.Preferences, timestamp), nil
+	default:
+		return nil, simerrors.Invalidf("unknown event type %d", eventType)
+	}
+}
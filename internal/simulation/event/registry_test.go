@@ -0,0 +1,80 @@
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestEventTypes_NamesAreUniqueAndParse(t *testing.T) {
+	types := EventTypes()
+	if len(types) != int(SeparationAdjustmentEndType)+1 {
+		t.Fatalf("Expected every declared event type, got %d", len(types))
+	}
+
+	seen := make(map[string]EventType)
+	for _, eventType := range types {
+		name := eventType.String()
+		if name == "Unknown" {
+			t.Errorf("Event type %d has no name", eventType)
+			continue
+		}
+		if other, ok := seen[name]; ok {
+			t.Errorf("Event types %d and %d share the name %s", other, eventType, name)
+		}
+		seen[name] = eventType
+
+		parsed, err := ParseEventType(name)
+		if err != nil || parsed != eventType {
+			t.Errorf("ParseEventType(%q) = %v, %v; expected %v", name, parsed, err, eventType)
+		}
+	}
+
+	if got := EventType(len(types)).String(); got != "Unknown" {
+		t.Errorf("Expected an undeclared type to be Unknown, got %s", got)
+	}
+	if _, err := ParseEventType("Unknown"); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected invalid configuration error for an unknown name, got %v", err)
+	}
+}
+
+func TestNewEvent_ConstructsEveryType(t *testing.T) {
+	timestamp := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, eventType := range EventTypes() {
+		evt, err := NewEvent(eventType, timestamp, EventFields{RunwayID: "09"})
+		if err != nil {
+			t.Errorf("NewEvent(%s): %v", eventType, err)
+			continue
+		}
+		if evt.Type() != eventType {
+			t.Errorf("NewEvent(%s) created a %s event", eventType, evt.Type())
+		}
+		if !evt.Time().Equal(timestamp) {
+			t.Errorf("NewEvent(%s) at %v, expected %v", eventType, evt.Time(), timestamp)
+		}
+	}
+
+	evt, err := NewEvent(WindChangeType, timestamp, EventFields{SpeedKnots: 12, GustKnots: 25, DirectionTrue: 270})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	wind, ok := evt.(*WindChangeEvent)
+	if !ok || wind.GetSpeed() != 12 || wind.GetGust() != 25 || wind.GetDirection() != 270 {
+		t.Errorf("Expected a 12 kt wind from 270 gusting 25, got %+v", evt)
+	}
+
+	evt, err = NewEvent(RotationChangeType, timestamp, EventFields{RunwayMultipliers: map[string]float32{"27L": 0.85}})
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+	if rotation, ok := evt.(*RotationChangeEvent); !ok || rotation.RunwayMultipliers()["27L"] != 0.85 {
+		t.Errorf("Expected a rotation change penalizing 27L, got %+v", evt)
+	}
+
+	if _, err := NewEvent(EventType(-1), timestamp, EventFields{}); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected invalid configuration error for an unknown type, got %v", err)
+	}
+}
@@ -6,10 +6,13 @@ import (
 )
 
 // RotationChangeEvent represents a change in runway rotation strategy efficiency.
-// Different rotation strategies apply different efficiency multipliers to capacity.
+// Different rotation strategies apply different efficiency multipliers to capacity, either
+// to the whole airport or, for schemes such as preferential runways, to specific runway ends.
 type RotationChangeEvent struct {
-	multiplier float32
-	timestamp  time.Time
+	multiplier        float32
+	runwayMultipliers map[string]float32 // Runway end designation -> multiplier (nil unless perRunway)
+	perRunway         bool               // Whether the event sets runwayMultipliers rather than multiplier
+	timestamp         time.Time
 }
 
 // NewRotationChangeEvent creates a new rotation change event.
@@ -20,6 +23,17 @@ func NewRotationChangeEvent(multiplier float32, timestamp time.Time) *RotationCh
 	}
 }
 
+// NewRunwayRotationChangeEvent creates a rotation change event that sets the efficiency
+// multiplier of each runway end in multipliers, leaving the airport-wide multiplier as it is.
+// Runway ends not in multipliers are not penalized; nil lifts every per-runway penalty.
+func NewRunwayRotationChangeEvent(multipliers map[string]float32, timestamp time.Time) *RotationChangeEvent {
+	return &RotationChangeEvent{
+		runwayMultipliers: multipliers,
+		perRunway:         true,
+		timestamp:         timestamp,
+	}
+}
+
 // Time returns when the rotation change occurs.
 func (e *RotationChangeEvent) Time() time.Time {
 	return e.timestamp
@@ -35,8 +49,18 @@ func (e *RotationChangeEvent) Multiplier() float32 {
 	return e.multiplier
 }
 
-// Apply updates the rotation efficiency multiplier.
+// RunwayMultipliers returns the new efficiency multiplier of each runway end, or nil for an
+// airport-wide change.
+func (e *RotationChangeEvent) RunwayMultipliers() map[string]float32 {
+	return e.runwayMultipliers
+}
+
+// Apply updates the rotation efficiency multiplier, or the runway ends' multipliers for a
+// per-runway change.
 func (e *RotationChangeEvent) Apply(ctx context.Context, world WorldState) error {
+	if e.perRunway {
+		return world.SetRunwayRotationMultipliers(e.runwayMultipliers)
+	}
 	world.SetRotationMultiplier(e.multiplier)
 	return nil
 }
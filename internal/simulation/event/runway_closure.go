@@ -0,0 +1,191 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// RunwayClosureStartEvent represents a runway being closed for a reason other than
+// maintenance (e.g., not yet commissioned, outside permitted operating hours).
+type RunwayClosureStartEvent struct {
+	runwayID  string
+	reason    string
+	timestamp time.Time
+}
+
+// NewRunwayClosureStartEvent creates a new runway closure start event.
+func NewRunwayClosureStartEvent(runwayID, reason string, timestamp time.Time) *RunwayClosureStartEvent {
+	return &RunwayClosureStartEvent{
+		runwayID:  runwayID,
+		reason:    reason,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the closure starts.
+func (e *RunwayClosureStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayClosureStartEvent) Type() EventType {
+	return RunwayClosureStartType
+}
+
+// RunwayID returns the ID of the closed runway.
+func (e *RunwayClosureStartEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Reason returns a short description of why the runway is closed.
+func (e *RunwayClosureStartEvent) Reason() string {
+	return e.reason
+}
+
+// Apply marks the runway as unavailable and triggers runway configuration recalculation.
+func (e *RunwayClosureStartEvent) Apply(ctx context.Context, world WorldState) error {
+	if err := world.SetRunwayAvailable(e.runwayID, e.reason, false); err != nil {
+		return err
+	}
+
+	return world.NotifyRunwayAvailabilityChange(e.runwayID, false, e.timestamp)
+}
+
+// RunwayClosureEndEvent represents a closed runway reopening.
+type RunwayClosureEndEvent struct {
+	runwayID  string
+	reason    string
+	timestamp time.Time
+}
+
+// NewRunwayClosureEndEvent creates a new runway closure end event.
+func NewRunwayClosureEndEvent(runwayID, reason string, timestamp time.Time) *RunwayClosureEndEvent {
+	return &RunwayClosureEndEvent{
+		runwayID:  runwayID,
+		reason:    reason,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the closure ends.
+func (e *RunwayClosureEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayClosureEndEvent) Type() EventType {
+	return RunwayClosureEndType
+}
+
+// RunwayID returns the ID of the reopening runway.
+func (e *RunwayClosureEndEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Reason returns a short description of why the runway was closed.
+func (e *RunwayClosureEndEvent) Reason() string {
+	return e.reason
+}
+
+// Apply ends the closure and, unless another closure is still active, marks the runway as
+// available and triggers runway configuration recalculation.
+func (e *RunwayClosureEndEvent) Apply(ctx context.Context, world WorldState) error {
+	if err := world.SetRunwayAvailable(e.runwayID, e.reason, true); err != nil {
+		return err
+	}
+	available, err := world.GetRunwayAvailable(e.runwayID)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return nil // Another closure still holds the runway
+	}
+
+	return world.NotifyRunwayAvailabilityChange(e.runwayID, true, e.timestamp)
+}
+
+// RunwayModificationEvent represents a change to a runway's physical or procedural
+// characteristics (e.g., length extension, approach category upgrade).
+type RunwayModificationEvent struct {
+	runwayID     string
+	modification airport.RunwayModification
+	timestamp    time.Time
+}
+
+// NewRunwayModificationEvent creates a new runway modification event.
+func NewRunwayModificationEvent(runwayID string, modification airport.RunwayModification, timestamp time.Time) *RunwayModificationEvent {
+	return &RunwayModificationEvent{
+		runwayID:     runwayID,
+		modification: modification,
+		timestamp:    timestamp,
+	}
+}
+
+// Time returns when the modification takes effect.
+func (e *RunwayModificationEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayModificationEvent) Type() EventType {
+	return RunwayModificationType
+}
+
+// RunwayID returns the ID of the modified runway.
+func (e *RunwayModificationEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Modification returns the change being applied.
+func (e *RunwayModificationEvent) Modification() airport.RunwayModification {
+	return e.modification
+}
+
+// Apply updates the runway's characteristics and triggers runway configuration recalculation.
+func (e *RunwayModificationEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.ModifyRunway(e.runwayID, e.modification, e.timestamp)
+}
+
+// RunwayCompatibilityChangeEvent represents a change to the runways a runway can operate
+// with simultaneously (e.g., a rebuilt runway no longer crossing its neighbours).
+type RunwayCompatibilityChangeEvent struct {
+	runwayID       string
+	compatibleWith []string
+	timestamp      time.Time
+}
+
+// NewRunwayCompatibilityChangeEvent creates a new runway compatibility change event.
+func NewRunwayCompatibilityChangeEvent(runwayID string, compatibleWith []string, timestamp time.Time) *RunwayCompatibilityChangeEvent {
+	return &RunwayCompatibilityChangeEvent{
+		runwayID:       runwayID,
+		compatibleWith: compatibleWith,
+		timestamp:      timestamp,
+	}
+}
+
+// Time returns when the change takes effect.
+func (e *RunwayCompatibilityChangeEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayCompatibilityChangeEvent) Type() EventType {
+	return RunwayCompatibilityChangeType
+}
+
+// RunwayID returns the ID of the runway whose compatibility changes.
+func (e *RunwayCompatibilityChangeEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// CompatibleWith returns the runways the runway can operate with after the change.
+func (e *RunwayCompatibilityChangeEvent) CompatibleWith() []string {
+	return e.compatibleWith
+}
+
+// Apply updates the compatibility graph and triggers runway configuration recalculation.
+func (e *RunwayCompatibilityChangeEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetRunwayCompatibility(e.runwayID, e.compatibleWith, e.timestamp)
+}
@@ -33,6 +33,18 @@ func (ot OperationType) String() string {
 	}
 }
 
+// SeparationMode returns the mode whose separation applies to a runway handling the operation type.
+func (ot OperationType) SeparationMode() airport.SeparationMode {
+	switch ot {
+	case TakeoffOnly:
+		return airport.DepartureMode
+	case LandingOnly:
+		return airport.ArrivalMode
+	default:
+		return airport.MixedMode
+	}
+}
+
 // Direction defines the direction a runway is being used.
 type Direction int
 
@@ -57,10 +69,26 @@ func (d Direction) String() string {
 
 // ActiveRunwayInfo contains information about an active runway in the current configuration.
 type ActiveRunwayInfo struct {
-	RunwayDesignation string          // Runway identifier (e.g., "09L")
-	OperationType     OperationType   // Type of operations (Mixed, TakeoffOnly, LandingOnly)
-	Direction         Direction       // Direction being used (Forward, Reverse)
-	Runway            airport.Runway  // Full runway configuration
+	RunwayDesignation string         // Runway identifier (e.g., "09L")
+	OperationType     OperationType  // Type of operations (Mixed, TakeoffOnly, LandingOnly)
+	Direction         Direction      // Direction being used (Forward, Reverse)
+	Runway            airport.Runway // Full runway configuration
+}
+
+// End returns the runway end in use (see airport.Runway.End). Information without the full
+// runway configuration is given the end derived from the runway designation.
+func (a *ActiveRunwayInfo) End() airport.RunwayEnd {
+	runway := a.Runway
+	if runway.RunwayDesignation == "" {
+		runway = airport.Runway{RunwayDesignation: a.RunwayDesignation}
+	}
+	return runway.End(a.Direction == Reverse)
+}
+
+// RunwayEnd returns the designation of the runway end in use: the runway's own designation
+// when operating Forward, or its reciprocal end (e.g., "27R" for "09L") when Reverse.
+func (a *ActiveRunwayInfo) RunwayEnd() string {
+	return a.End().Designation
 }
 
 // ActiveRunwayConfigurationChangedEvent represents a change in the active runway configuration.
@@ -68,15 +96,20 @@ type ActiveRunwayInfo struct {
 // Generated by the RunwayManager when runway availability or curfew status changes.
 type ActiveRunwayConfigurationChangedEvent struct {
 	activeRunways map[string]*ActiveRunwayInfo // Map of runway ID to active runway info
-	timestamp     time.Time                     // When this configuration becomes active
+	trigger       string                       // Type of the event that caused the change ("" if none)
+	timestamp     time.Time                    // When this configuration becomes active
 }
 
 // NewActiveRunwayConfigurationChangedEvent creates a new runway configuration change event.
-func NewActiveRunwayConfigurationChangedEvent(activeRunways map[string]*ActiveRunwayInfo, timestamp time.Time) *ActiveRunwayConfigurationChangedEvent {
-	return &ActiveRunwayConfigurationChangedEvent{
-		activeRunways: activeRunways,
-		timestamp:     timestamp,
-	}
+// The trigger names the type of the event whose application caused the change
+// (e.g., "WindChange"), or is empty if the change was not caused by an event.
+// Events are taken from a pool and may be recycled by Release once processed.
+func NewActiveRunwayConfigurationChangedEvent(activeRunways map[string]*ActiveRunwayInfo, trigger string, timestamp time.Time) *ActiveRunwayConfigurationChangedEvent {
+	e := configurationChangedPool.Get().(*ActiveRunwayConfigurationChangedEvent)
+	e.activeRunways = activeRunways
+	e.trigger = trigger
+	e.timestamp = timestamp
+	return e
 }
 
 // Time returns the time when this event occurs.
@@ -93,7 +126,12 @@ func (e *ActiveRunwayConfigurationChangedEvent) Type() EventType {
 // This becomes the single source of truth for which runways the engine should use
 // for capacity calculations.
 func (e *ActiveRunwayConfigurationChangedEvent) Apply(ctx context.Context, world WorldState) error {
-	return world.SetActiveRunwayConfiguration(e.activeRunways)
+	return world.SetActiveRunwayConfiguration(e.activeRunways, e.trigger)
+}
+
+// Trigger returns the type of the event that caused the change, or "" if none.
+func (e *ActiveRunwayConfigurationChangedEvent) Trigger() string {
+	return e.trigger
 }
 
 // ActiveRunways returns the active runway configuration.
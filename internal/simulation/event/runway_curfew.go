@@ -0,0 +1,141 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// CurfewRestriction describes a curfew that applies to specific runway ends or operations,
+// or that caps movements instead of stopping them (a partial curfew).
+// The same restriction value is shared by the start and end events of a policy so the
+// world can identify which restriction to lift.
+type CurfewRestriction struct {
+	RunwayEnds          []string                  // Runway end designations affected (empty = all runway ends)
+	Operations          []airport.RunwayOperation // Operations affected (empty = arrivals and departures)
+	MaxMovementsPerHour float64                   // Movements per hour still allowed (0 = no affected movements)
+}
+
+// AppliesToRunwayEnd reports whether the restriction covers the given runway end.
+func (r *CurfewRestriction) AppliesToRunwayEnd(runwayEnd string) bool {
+	if len(r.RunwayEnds) == 0 {
+		return true
+	}
+	for _, end := range r.RunwayEnds {
+		if end == runwayEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictedShare returns the fraction of a runway's movements covered by the restriction
+// given the runway's operation type. Mixed runways are assumed to split movements evenly
+// between arrivals and departures.
+func (r *CurfewRestriction) RestrictedShare(opType OperationType) float32 {
+	if len(r.Operations) == 0 {
+		return 1
+	}
+
+	arrivals, departures := false, false
+	for _, op := range r.Operations {
+		switch op {
+		case airport.Arrivals:
+			arrivals = true
+		case airport.Departures:
+			departures = true
+		}
+	}
+
+	switch opType {
+	case LandingOnly:
+		if arrivals {
+			return 1
+		}
+	case TakeoffOnly:
+		if departures {
+			return 1
+		}
+	case Mixed:
+		share := float32(0)
+		if arrivals {
+			share += 0.5
+		}
+		if departures {
+			share += 0.5
+		}
+		return share
+	}
+	return 0
+}
+
+// RunwayCurfewStartEvent represents the beginning of a runway-specific or partial curfew.
+type RunwayCurfewStartEvent struct {
+	restriction *CurfewRestriction
+	timestamp   time.Time
+}
+
+// NewRunwayCurfewStartEvent creates a new runway curfew start event.
+func NewRunwayCurfewStartEvent(restriction *CurfewRestriction, timestamp time.Time) *RunwayCurfewStartEvent {
+	return &RunwayCurfewStartEvent{
+		restriction: restriction,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the curfew starts.
+func (e *RunwayCurfewStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayCurfewStartEvent) Type() EventType {
+	return RunwayCurfewStartType
+}
+
+// Restriction returns the curfew restriction being activated.
+func (e *RunwayCurfewStartEvent) Restriction() *CurfewRestriction {
+	return e.restriction
+}
+
+// Apply activates the curfew restriction in the world state.
+func (e *RunwayCurfewStartEvent) Apply(ctx context.Context, world WorldState) error {
+	world.ActivateCurfewRestriction(e.restriction)
+	return nil
+}
+
+// RunwayCurfewEndEvent represents the end of a runway-specific or partial curfew.
+type RunwayCurfewEndEvent struct {
+	restriction *CurfewRestriction
+	timestamp   time.Time
+}
+
+// NewRunwayCurfewEndEvent creates a new runway curfew end event.
+func NewRunwayCurfewEndEvent(restriction *CurfewRestriction, timestamp time.Time) *RunwayCurfewEndEvent {
+	return &RunwayCurfewEndEvent{
+		restriction: restriction,
+		timestamp:   timestamp,
+	}
+}
+
+// Time returns when the curfew ends.
+func (e *RunwayCurfewEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayCurfewEndEvent) Type() EventType {
+	return RunwayCurfewEndType
+}
+
+// Restriction returns the curfew restriction being lifted.
+func (e *RunwayCurfewEndEvent) Restriction() *CurfewRestriction {
+	return e.restriction
+}
+
+// Apply lifts the curfew restriction in the world state.
+func (e *RunwayCurfewEndEvent) Apply(ctx context.Context, world WorldState) error {
+	world.DeactivateCurfewRestriction(e.restriction)
+	return nil
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// SeparationAdjustment describes a temporary change to runway separation, such as trainee
+// controllers or a runway inspection slowing operations: every movement on the runways takes
+// Factor times its usual separation. The same adjustment value is shared by the start and end
+// events of a policy so the world can identify which adjustment to lift.
+type SeparationAdjustment struct {
+	Reason  string   // Why separation is adjusted (e.g., "trainee controllers")
+	Runways []string // Runway designations adjusted (empty = every runway)
+	Factor  float64  // Multiplier applied to separation (e.g., 1.2 = 20% more separation)
+}
+
+// AppliesTo reports whether the adjustment applies to the runway.
+func (a *SeparationAdjustment) AppliesTo(runwayID string) bool {
+	return len(a.Runways) == 0 || slices.Contains(a.Runways, runwayID)
+}
+
+// SeparationAdjustmentStartEvent represents the beginning of a temporary separation adjustment.
+type SeparationAdjustmentStartEvent struct {
+	adjustment *SeparationAdjustment
+	timestamp  time.Time
+}
+
+// NewSeparationAdjustmentStartEvent creates a new separation adjustment start event.
+func NewSeparationAdjustmentStartEvent(adjustment *SeparationAdjustment, timestamp time.Time) *SeparationAdjustmentStartEvent {
+	return &SeparationAdjustmentStartEvent{
+		adjustment: adjustment,
+		timestamp:  timestamp,
+	}
+}
+
+// Time returns when the adjustment starts.
+func (e *SeparationAdjustmentStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *SeparationAdjustmentStartEvent) Type() EventType {
+	return SeparationAdjustmentStartType
+}
+
+// Adjustment returns the adjustment being activated.
+func (e *SeparationAdjustmentStartEvent) Adjustment() *SeparationAdjustment {
+	return e.adjustment
+}
+
+// Apply activates the adjustment in the world state.
+func (e *SeparationAdjustmentStartEvent) Apply(ctx context.Context, world WorldState) error {
+	world.ActivateSeparationAdjustment(e.adjustment)
+	return nil
+}
+
+// SeparationAdjustmentEndEvent represents the end of a temporary separation adjustment.
+type SeparationAdjustmentEndEvent struct {
+	adjustment *SeparationAdjustment
+	timestamp  time.Time
+}
+
+// NewSeparationAdjustmentEndEvent creates a new separation adjustment end event.
+func NewSeparationAdjustmentEndEvent(adjustment *SeparationAdjustment, timestamp time.Time) *SeparationAdjustmentEndEvent {
+	return &SeparationAdjustmentEndEvent{
+		adjustment: adjustment,
+		timestamp:  timestamp,
+	}
+}
+
+// Time returns when the adjustment ends.
+func (e *SeparationAdjustmentEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *SeparationAdjustmentEndEvent) Type() EventType {
+	return SeparationAdjustmentEndType
+}
+
+// Adjustment returns the adjustment being lifted.
+func (e *SeparationAdjustmentEndEvent) Adjustment() *SeparationAdjustment {
+	return e.adjustment
+}
+
+// Apply lifts the adjustment in the world state.
+func (e *SeparationAdjustmentEndEvent) Apply(ctx context.Context, world WorldState) error {
+	world.DeactivateSeparationAdjustment(e.adjustment)
+	return nil
+}
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// RunwaySurfaceConditionEvent represents a runway surface becoming wet, contaminated or dry
+// again. The condition reduces the runway's wind limits and stretches its separation.
+type RunwaySurfaceConditionEvent struct {
+	runwayID  string
+	condition airport.SurfaceCondition
+	timestamp time.Time
+}
+
+// NewRunwaySurfaceConditionEvent creates a new runway surface condition event.
+func NewRunwaySurfaceConditionEvent(runwayID string, condition airport.SurfaceCondition, timestamp time.Time) *RunwaySurfaceConditionEvent {
+	return &RunwaySurfaceConditionEvent{
+		runwayID:  runwayID,
+		condition: condition,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the condition takes effect.
+func (e *RunwaySurfaceConditionEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwaySurfaceConditionEvent) Type() EventType {
+	return RunwaySurfaceConditionType
+}
+
+// RunwayID returns the ID of the affected runway.
+func (e *RunwaySurfaceConditionEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Condition returns the new surface condition.
+func (e *RunwaySurfaceConditionEvent) Condition() airport.SurfaceCondition {
+	return e.condition
+}
+
+// Apply updates the runway's surface condition and triggers runway configuration recalculation.
+func (e *RunwaySurfaceConditionEvent) Apply(ctx context.Context, world WorldState) error {
+	condition := e.condition
+	return world.ModifyRunway(e.runwayID, airport.RunwayModification{SurfaceCondition: &condition}, e.timestamp)
+}
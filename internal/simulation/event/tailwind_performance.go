@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// TailwindPerformanceEvent represents fleet landing performance data being applied so that
+// tailwind operations reduce the share of arrivals a runway can accept.
+type TailwindPerformanceEvent struct {
+	fleet         []airport.LandingPerformance
+	factorPerKnot float64
+	timestamp     time.Time
+}
+
+// NewTailwindPerformanceEvent creates a new tailwind performance event.
+func NewTailwindPerformanceEvent(fleet []airport.LandingPerformance, factorPerKnot float64, timestamp time.Time) *TailwindPerformanceEvent {
+	return &TailwindPerformanceEvent{
+		fleet:         fleet,
+		factorPerKnot: factorPerKnot,
+		timestamp:     timestamp,
+	}
+}
+
+// Time returns when the performance data is applied.
+func (e *TailwindPerformanceEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *TailwindPerformanceEvent) Type() EventType {
+	return TailwindPerformanceType
+}
+
+// Fleet returns a copy of the fleet landing performance data.
+func (e *TailwindPerformanceEvent) Fleet() []airport.LandingPerformance {
+	fleet := make([]airport.LandingPerformance, len(e.fleet))
+	copy(fleet, e.fleet)
+	return fleet
+}
+
+// FactorPerKnot returns the fractional landing distance increase per knot of tailwind.
+func (e *TailwindPerformanceEvent) FactorPerKnot() float64 {
+	return e.factorPerKnot
+}
+
+// Apply sets the landing performance data in the world state.
+func (e *TailwindPerformanceEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetLandingPerformance(e.fleet, e.factorPerKnot)
+}
@@ -0,0 +1,43 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// TemperatureChangeEvent represents a change in outside air temperature during the simulation.
+// When applied, it updates the world's temperature so runway length requirements are assessed
+// at the resulting density altitude.
+type TemperatureChangeEvent struct {
+	temperatureCelsius float64   // Outside air temperature in °C
+	timestamp          time.Time // When this temperature takes effect
+}
+
+// NewTemperatureChangeEvent creates a new temperature change event.
+// Events are taken from a pool and may be recycled by Release once processed.
+func NewTemperatureChangeEvent(temperatureCelsius float64, timestamp time.Time) *TemperatureChangeEvent {
+	e := temperatureChangePool.Get().(*TemperatureChangeEvent)
+	e.temperatureCelsius = temperatureCelsius
+	e.timestamp = timestamp
+	return e
+}
+
+// Time returns when the temperature change occurs.
+func (e *TemperatureChangeEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *TemperatureChangeEvent) Type() EventType {
+	return TemperatureChangeType
+}
+
+// Apply updates the world's outside air temperature.
+func (e *TemperatureChangeEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.SetTemperature(e.temperatureCelsius)
+}
+
+// GetTemperature returns the outside air temperature in °C.
+func (e *TemperatureChangeEvent) GetTemperature() float64 {
+	return e.temperatureCelsius
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// TideRestrictionStartEvent represents a water runway becoming unusable because the tide
+// has fallen below its minimum operating height.
+type TideRestrictionStartEvent struct {
+	runwayID  string
+	timestamp time.Time
+}
+
+// NewTideRestrictionStartEvent creates a new tide restriction start event.
+func NewTideRestrictionStartEvent(runwayID string, timestamp time.Time) *TideRestrictionStartEvent {
+	return &TideRestrictionStartEvent{
+		runwayID:  runwayID,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the restriction starts.
+func (e *TideRestrictionStartEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *TideRestrictionStartEvent) Type() EventType {
+	return TideRestrictionStartType
+}
+
+// RunwayID returns the ID of the restricted water runway.
+func (e *TideRestrictionStartEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Apply marks the runway as unavailable and triggers runway configuration recalculation.
+func (e *TideRestrictionStartEvent) Apply(ctx context.Context, world WorldState) error {
+	if err := world.SetRunwayAvailable(e.runwayID, "tide restriction", false); err != nil {
+		return err
+	}
+
+	return world.NotifyRunwayAvailabilityChange(e.runwayID, false, e.timestamp)
+}
+
+// TideRestrictionEndEvent represents a water runway becoming usable again because the tide
+// has risen back above its minimum operating height.
+type TideRestrictionEndEvent struct {
+	runwayID  string
+	timestamp time.Time
+}
+
+// NewTideRestrictionEndEvent creates a new tide restriction end event.
+func NewTideRestrictionEndEvent(runwayID string, timestamp time.Time) *TideRestrictionEndEvent {
+	return &TideRestrictionEndEvent{
+		runwayID:  runwayID,
+		timestamp: timestamp,
+	}
+}
+
+// Time returns when the restriction ends.
+func (e *TideRestrictionEndEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *TideRestrictionEndEvent) Type() EventType {
+	return TideRestrictionEndType
+}
+
+// RunwayID returns the ID of the water runway becoming usable.
+func (e *TideRestrictionEndEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// Apply ends the closure and, unless another closure is still active, marks the runway as
+// available and triggers runway configuration recalculation.
+func (e *TideRestrictionEndEvent) Apply(ctx context.Context, world WorldState) error {
+	if err := world.SetRunwayAvailable(e.runwayID, "tide restriction", true); err != nil {
+		return err
+	}
+	available, err := world.GetRunwayAvailable(e.runwayID)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return nil // Another closure still holds the runway
+	}
+
+	return world.NotifyRunwayAvailabilityChange(e.runwayID, true, e.timestamp)
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// RunwayWearCheckEvent represents a check of a runway's wear: once the runway has handled a
+// number of movements since its last wear-based maintenance, it is closed for maintenance.
+type RunwayWearCheckEvent struct {
+	runwayID                string
+	movementsPerMaintenance float64
+	duration                time.Duration
+	timestamp               time.Time
+}
+
+// NewRunwayWearCheckEvent creates a new runway wear check event.
+func NewRunwayWearCheckEvent(runwayID string, movementsPerMaintenance float64, duration time.Duration, timestamp time.Time) *RunwayWearCheckEvent {
+	return &RunwayWearCheckEvent{
+		runwayID:                runwayID,
+		movementsPerMaintenance: movementsPerMaintenance,
+		duration:                duration,
+		timestamp:               timestamp,
+	}
+}
+
+// Time returns when the runway's wear is checked.
+func (e *RunwayWearCheckEvent) Time() time.Time {
+	return e.timestamp
+}
+
+// Type returns the event type.
+func (e *RunwayWearCheckEvent) Type() EventType {
+	return RunwayWearCheckType
+}
+
+// RunwayID returns the ID of the runway being checked.
+func (e *RunwayWearCheckEvent) RunwayID() string {
+	return e.runwayID
+}
+
+// MovementsPerMaintenance returns the movements the runway handles between maintenance.
+func (e *RunwayWearCheckEvent) MovementsPerMaintenance() float64 {
+	return e.movementsPerMaintenance
+}
+
+// Duration returns how long the runway is closed for maintenance.
+func (e *RunwayWearCheckEvent) Duration() time.Duration {
+	return e.duration
+}
+
+// Apply checks the runway's wear, scheduling maintenance if it is due.
+func (e *RunwayWearCheckEvent) Apply(ctx context.Context, world WorldState) error {
+	return world.CheckRunwayWear(e.runwayID, e.movementsPerMaintenance, e.duration, e.timestamp)
+}
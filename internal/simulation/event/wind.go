@@ -10,6 +10,7 @@ import (
 // to recalculate the active runway configuration based on new wind constraints.
 type WindChangeEvent struct {
 	speedKnots    float64   // Wind speed in knots
+	gustKnots     float64   // Peak gust speed in knots (0 = no gusts)
 	directionTrue float64   // Wind direction in degrees true (0-360)
 	timestamp     time.Time // When this wind change occurs
 }
@@ -27,12 +28,22 @@ type WindChangeEvent struct {
 //   - Filters runways by crosswind/tailwind limits
 //   - Selects optimal runway directions (forward/reverse)
 //   - Schedules ActiveRunwayConfigurationChangedEvent
+//
+// Events are taken from a pool and may be recycled by Release once processed.
 func NewWindChangeEvent(speedKnots, directionTrue float64, timestamp time.Time) *WindChangeEvent {
-	return &WindChangeEvent{
-		speedKnots:    speedKnots,
-		directionTrue: directionTrue,
-		timestamp:     timestamp,
-	}
+	return NewGustingWindChangeEvent(speedKnots, 0, directionTrue, timestamp)
+}
+
+// NewGustingWindChangeEvent creates a new wind change event with gusts up to gustKnots.
+// Runways are then checked against their crosswind limits at the gust speed, and a gust
+// factor policy may add separation while the gusts last. A gustKnots of 0 means no gusts.
+func NewGustingWindChangeEvent(speedKnots, gustKnots, directionTrue float64, timestamp time.Time) *WindChangeEvent {
+	e := windChangePool.Get().(*WindChangeEvent)
+	e.speedKnots = speedKnots
+	e.gustKnots = gustKnots
+	e.directionTrue = directionTrue
+	e.timestamp = timestamp
+	return e
 }
 
 // Time returns when the wind change occurs.
@@ -47,11 +58,14 @@ func (e *WindChangeEvent) Type() EventType {
 
 // Apply updates the world's wind conditions and triggers runway reconfiguration.
 // This will cause the RunwayManager to:
-//   1. Filter runways by new wind constraints (crosswind/tailwind limits)
-//   2. Determine optimal runway directions (prefer maximum headwind)
-//   3. Select maximum-capacity configuration from usable runways
-//   4. Generate ActiveRunwayConfigurationChangedEvent
+//  1. Filter runways by new wind constraints (crosswind/tailwind limits)
+//  2. Determine optimal runway directions (prefer maximum headwind)
+//  3. Select maximum-capacity configuration from usable runways
+//  4. Generate ActiveRunwayConfigurationChangedEvent
 func (e *WindChangeEvent) Apply(ctx context.Context, world WorldState) error {
+	if e.gustKnots > 0 {
+		return world.SetGustingWind(e.speedKnots, e.gustKnots, e.directionTrue)
+	}
 	return world.SetWind(e.speedKnots, e.directionTrue)
 }
 
@@ -60,6 +74,11 @@ func (e *WindChangeEvent) GetSpeed() float64 {
 	return e.speedKnots
 }
 
+// GetGust returns the peak gust speed in knots (0 means no gusts).
+func (e *WindChangeEvent) GetGust() float64 {
+	return e.gustKnots
+}
+
 // GetDirection returns the wind direction in degrees true.
 func (e *WindChangeEvent) GetDirection() float64 {
 	return e.directionTrue
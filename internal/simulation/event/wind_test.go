@@ -4,11 +4,14 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 )
 
 // mockWorldState for testing wind events
 type mockWindWorldState struct {
 	windSpeed     float64
+	windGust      float64
 	windDirection float64
 	setWindCalled bool
 	setWindError  error
@@ -21,19 +24,27 @@ func (m *mockWindWorldState) SetWind(speed, direction float64) error {
 	return m.setWindError
 }
 
-func (m *mockWindWorldState) GetWindSpeed() float64              { return m.windSpeed }
-func (m *mockWindWorldState) GetWindDirection() float64          { return m.windDirection }
-func (m *mockWindWorldState) SetCurfewActive(active bool)        {}
-func (m *mockWindWorldState) GetCurfewActive() bool              { return false }
-func (m *mockWindWorldState) SetRunwayAvailable(id string, a bool) error { return nil }
-func (m *mockWindWorldState) GetRunwayAvailable(id string) (bool, error) { return true, nil }
-func (m *mockWindWorldState) SetRotationMultiplier(multiplier float32)    {}
-func (m *mockWindWorldState) GetRotationMultiplier() float32     { return 1.0 }
-func (m *mockWindWorldState) SetGateCapacityConstraint(constraint float32) error { return nil }
-func (m *mockWindWorldState) GetGateCapacityConstraint() float32 { return 0 }
-func (m *mockWindWorldState) SetTaxiTimeOverhead(d time.Duration) error { return nil }
-func (m *mockWindWorldState) GetTaxiTimeOverhead() time.Duration { return 0 }
-func (m *mockWindWorldState) SetActiveRunwayConfiguration(c map[string]*ActiveRunwayInfo) error {
+func (m *mockWindWorldState) SetGustingWind(speed, gust, direction float64) error {
+	m.windGust = gust
+	return m.SetWind(speed, direction)
+}
+
+func (m *mockWindWorldState) GetWindSpeed() float64                         { return m.windSpeed }
+func (m *mockWindWorldState) GetWindDirection() float64                     { return m.windDirection }
+func (m *mockWindWorldState) SetCurfewActive(active bool)                   {}
+func (m *mockWindWorldState) GetCurfewActive() bool                         { return false }
+func (m *mockWindWorldState) SetRunwayAvailable(id, r string, a bool) error { return nil }
+func (m *mockWindWorldState) GetRunwayAvailable(id string) (bool, error)    { return true, nil }
+func (m *mockWindWorldState) SetRotationMultiplier(multiplier float32)      {}
+func (m *mockWindWorldState) GetRotationMultiplier() float32                { return 1.0 }
+func (m *mockWindWorldState) SetRunwayRotationMultipliers(multipliers map[string]float32) error {
+	return nil
+}
+func (m *mockWindWorldState) SetGateCapacity(gates int, turnaround time.Duration) error { return nil }
+func (m *mockWindWorldState) GetGateCapacity() (int, time.Duration)                     { return 0, 0 }
+func (m *mockWindWorldState) SetTaxiTimeOverhead(d time.Duration) error                 { return nil }
+func (m *mockWindWorldState) GetTaxiTimeOverhead() time.Duration                        { return 0 }
+func (m *mockWindWorldState) SetActiveRunwayConfiguration(c map[string]*ActiveRunwayInfo, trigger string) error {
 	return nil
 }
 func (m *mockWindWorldState) GetActiveRunwayConfiguration() map[string]*ActiveRunwayInfo {
@@ -45,6 +56,52 @@ func (m *mockWindWorldState) NotifyRunwayAvailabilityChange(id string, a bool, t
 func (m *mockWindWorldState) NotifyCurfewChange(a bool, t time.Time) error {
 	return nil
 }
+func (m *mockWindWorldState) SetLandingPerformance(f []airport.LandingPerformance, k float64) error {
+	return nil
+}
+func (m *mockWindWorldState) SetDirectionChangeoverPenalty(d time.Duration) error {
+	return nil
+}
+func (m *mockWindWorldState) SetGustFactor(threshold float64, d time.Duration) error {
+	return nil
+}
+func (m *mockWindWorldState) SetDeclaredCapacity(movementsPerHour float64) error {
+	return nil
+}
+func (m *mockWindWorldState) SetDeicingConstraint(movementsPerSecond float32, threshold float64) error {
+	return nil
+}
+func (m *mockWindWorldState) ModifyRunway(id string, mod airport.RunwayModification, t time.Time) error {
+	return nil
+}
+func (m *mockWindWorldState) SetRunwayCompatibility(id string, compatibleWith []string, t time.Time) error {
+	return nil
+}
+func (m *mockWindWorldState) SetNoisePreferences(p [][]string, t time.Time) error {
+	return nil
+}
+func (m *mockWindWorldState) NotifyCompatibilityHours(t time.Time) error {
+	return nil
+}
+func (m *mockWindWorldState) CheckRunwayWear(id string, movements float64, d time.Duration, t time.Time) error {
+	return nil
+}
+func (m *mockWindWorldState) SetNoiseQuota(w map[string]float64, q float64) error {
+	return nil
+}
+func (m *mockWindWorldState) SetNightQuota(perNight, perYear float64) error {
+	return nil
+}
+func (m *mockWindWorldState) SetNightQuotaActive(active bool)                        {}
+func (m *mockWindWorldState) ActivateCurfewRestriction(r *CurfewRestriction)         {}
+func (m *mockWindWorldState) DeactivateCurfewRestriction(r *CurfewRestriction)       {}
+func (m *mockWindWorldState) ActivateDisruption(d *Disruption)                       {}
+func (m *mockWindWorldState) DeactivateDisruption(d *Disruption)                     {}
+func (m *mockWindWorldState) ActivateGAReservation(r *GAReservation)                 {}
+func (m *mockWindWorldState) DeactivateGAReservation(r *GAReservation)               {}
+func (m *mockWindWorldState) ActivateSeparationAdjustment(a *SeparationAdjustment)   {}
+func (m *mockWindWorldState) DeactivateSeparationAdjustment(a *SeparationAdjustment) {}
+func (m *mockWindWorldState) SetTemperature(celsius float64) error                   { return nil }
 
 // TestNewWindChangeEvent tests the constructor
 func TestNewWindChangeEvent(t *testing.T) {
@@ -144,6 +201,26 @@ func TestWindChangeEventGetters(t *testing.T) {
 	}
 }
 
+// TestGustingWindChangeEventApply tests that gusts reach the world state
+func TestGustingWindChangeEventApply(t *testing.T) {
+	mockWorld := &mockWindWorldState{}
+	event := NewGustingWindChangeEvent(18, 32, 240, time.Now())
+
+	if event.GetGust() != 32 {
+		t.Errorf("GetGust: expected 32, got %f", event.GetGust())
+	}
+	if err := event.Apply(context.Background(), mockWorld); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if mockWorld.windSpeed != 18 || mockWorld.windGust != 32 || mockWorld.windDirection != 240 {
+		t.Errorf("Expected 18G32kt from 240, got %fG%fkt from %f", mockWorld.windSpeed, mockWorld.windGust, mockWorld.windDirection)
+	}
+
+	if NewWindChangeEvent(18, 240, time.Now()).GetGust() != 0 {
+		t.Error("Expected no gust from NewWindChangeEvent")
+	}
+}
+
 // TestWindChangeEventMultipleChanges tests a sequence of wind changes
 func TestWindChangeEventMultipleChanges(t *testing.T) {
 	mockWorld := &mockWindWorldState{}
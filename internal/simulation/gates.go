@@ -0,0 +1,139 @@
+package simulation
+
+import (
+	"slices"
+	"time"
+)
+
+// GateSample is the aircraft on an airport's gates at one instant.
+type GateSample struct {
+	Time  time.Time // Instant of the sample
+	Open  int       // Gates in service
+	InUse float64   // Aircraft on gates, including those ready to depart
+}
+
+// Utilization returns the share of the open gates in use (0 with no gates open).
+func (s GateSample) Utilization() float64 {
+	if s.Open <= 0 {
+		return 0
+	}
+	return s.InUse / float64(s.Open)
+}
+
+// gateCohort is the aircraft that arrived on gates over a period. They become ready to depart
+// evenly over the same period an occupancy time later.
+type gateCohort struct {
+	readyFrom, readyTo time.Time // Aircraft become ready to depart evenly over [readyFrom, readyTo)
+	aircraft           float64   // Aircraft in the cohort
+	released           float64   // Aircraft already ready to depart
+}
+
+// gateOccupancy tracks the aircraft on an airport's gates as a continuous flow: arrivals take
+// a free gate and become ready to depart once their turnaround and taxi time have passed, and
+// departures free their gate. By Little's law the gates sustain 2 × gates / occupancy time
+// movements, but the flow also captures transients: gates filled during a bank of arrivals
+// or by aircraft held on the ground through a curfew limit the arrivals that follow.
+type gateOccupancy struct {
+	seeded   bool         // Whether the gates have been filled to their steady state
+	occupied float64      // Aircraft on gates, including those ready to depart
+	ready    float64      // Aircraft on gates ready to depart
+	cohorts  []gateCohort // Arrivals not yet all ready to depart, in arrival order
+}
+
+// gateOccupancyTime returns how long each aircraft occupies a gate: the turnaround plus the
+// taxi time overhead, during which the gate is held for it.
+func (w *World) gateOccupancyTime() time.Duration {
+	return w.GateTurnaround + w.TaxiTimeOverhead
+}
+
+// gateSamples returns the aircraft on gates over the window [start, end) from the samples the
+// window's gate advance recorded, or at the start and end of a window without movements, in
+// which the aircraft stay on their gates. Returns nil before the gates are first filled.
+func (w *World) gateSamples(start, end time.Time, samples []GateSample) []GateSample {
+	if w.gateOccupancy == nil || !w.gateOccupancy.seeded {
+		return nil
+	}
+	if len(samples) == 0 {
+		occupied := w.gateOccupancy.occupied
+		return []GateSample{{Time: start, Open: w.OpenGates, InUse: occupied}, {Time: end, Open: w.OpenGates, InUse: occupied}}
+	}
+	return slices.Clone(samples)
+}
+
+// clone returns a copy of the occupancy that can be advanced independently.
+func (g *gateOccupancy) clone() *gateOccupancy {
+	c := *g
+	c.cohorts = slices.Clone(g.cohorts)
+	return &c
+}
+
+// advance moves the occupancy through a window in which the runways can handle capacity
+// movements, returning the movements the gates allow. The window is stepped in periods no
+// longer than the occupancy time, so aircraft arriving early in a long window depart within
+// it. Within each step departures take up to half of the runway capacity, arrivals take the
+// gates then free and any capacity left over clears further departures.
+//
+// The first window with capacity fills the gates to the steady state of the airport arriving
+// at the rate the window sustains (half its capacity, or gates / occupancy time if lower) for
+// an occupancy time beforehand, so a run does not start with empty gates.
+//
+// The aircraft on gates at the window start and the end of each step are appended to samples;
+// they change linearly in between.
+func (g *gateOccupancy) advance(start time.Time, duration time.Duration, capacity float32, gates int, occupancy time.Duration, samples []GateSample) (float32, []GateSample) {
+	if duration <= 0 || capacity <= 0 || occupancy <= 0 {
+		return 0, samples
+	}
+
+	perSecond := float64(capacity) / duration.Seconds()
+	if !g.seeded {
+		arrivalsPerSecond := min(perSecond/2, float64(gates)/occupancy.Seconds())
+		g.seeded = true
+		g.occupied = arrivalsPerSecond * occupancy.Seconds()
+		g.cohorts = append(g.cohorts, gateCohort{readyFrom: start, readyTo: start.Add(occupancy), aircraft: g.occupied})
+	}
+	samples = append(samples, GateSample{Time: start, Open: gates, InUse: g.occupied})
+
+	movements := 0.0
+	for from := start; from.Before(start.Add(duration)); {
+		to := minTime(from.Add(occupancy), start.Add(duration))
+		step := perSecond * to.Sub(from).Seconds()
+
+		g.release(to)
+
+		departures := min(g.ready, step/2)
+		arrivals := max(0, min(float64(gates)-g.occupied+departures, step-departures))
+		departures += min(g.ready-departures, step-departures-arrivals)
+
+		g.ready -= departures
+		g.occupied += arrivals - departures
+		if arrivals > 0 {
+			g.cohorts = append(g.cohorts, gateCohort{readyFrom: from.Add(occupancy), readyTo: to.Add(occupancy), aircraft: arrivals})
+		}
+		movements += arrivals + departures
+		samples = append(samples, GateSample{Time: to, Open: gates, InUse: g.occupied})
+		from = to
+	}
+	return float32(movements), samples
+}
+
+// release makes the aircraft whose turnaround completes before t ready to depart.
+func (g *gateOccupancy) release(t time.Time) {
+	done := 0
+	for i := range g.cohorts {
+		c := &g.cohorts[i]
+		if !t.After(c.readyFrom) {
+			break
+		}
+		fraction := 1.0
+		if t.Before(c.readyTo) {
+			fraction = t.Sub(c.readyFrom).Seconds() / c.readyTo.Sub(c.readyFrom).Seconds()
+		}
+		ready := c.aircraft*fraction - c.released
+		c.released += ready
+		g.ready += ready
+		if fraction == 1 {
+			done = i + 1
+		}
+	}
+	g.cohorts = slices.Delete(g.cohorts, 0, done)
+}
@@ -0,0 +1,110 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+func TestGateOccupancy_SteadyState(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		window      time.Duration
+		capacity    float64 // Runway movements per hour
+		gates       int
+		occupancy   time.Duration
+		wantPerHour float64
+	}{
+		// 10 gates held for an hour sustain 20 movements per hour, below the runways' 100
+		{"gate limited, hourly windows", time.Hour, 100, 10, time.Hour, 20},
+		{"gate limited, quarter hour windows", 15 * time.Minute, 100, 10, time.Hour, 20},
+		{"gate limited, daily windows", 24 * time.Hour, 100, 10, time.Hour, 20},
+		{"gate limited, long occupancy", time.Hour, 100, 30, 3 * time.Hour, 20},
+		// 40 gates sustain 80, so the runways' 60 is not limited
+		{"runway limited", time.Hour, 60, 40, time.Hour, 60},
+		{"runway limited, long occupancy", 30 * time.Minute, 60, 100, 2 * time.Hour, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &gateOccupancy{}
+			windowCapacity := float32(tt.capacity * tt.window.Hours())
+			for at := start; at.Before(start.AddDate(0, 0, 2)); at = at.Add(tt.window) {
+				movements, _ := g.advance(at, tt.window, windowCapacity, tt.gates, tt.occupancy, nil)
+				got := float64(movements)
+				if want := tt.wantPerHour * tt.window.Hours(); math.Abs(got-want) > 1e-3 {
+					t.Fatalf("Window at %v: expected %.2f movements, got %.2f", at, want, got)
+				}
+			}
+			if g.occupied > float64(tt.gates)+1e-9 {
+				t.Errorf("Expected at most %d aircraft on gates, got %.2f", tt.gates, g.occupied)
+			}
+		})
+	}
+}
+
+func TestGateOccupancy_Saturation(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := &gateOccupancy{}
+
+	// 60 gates held for two hours: at 40 movements per hour, 20 arrivals an hour keep 40 on gates
+	if got, _ := g.advance(start, time.Hour, 40, 60, 2*time.Hour, nil); math.Abs(float64(got-40)) > 1e-3 {
+		t.Fatalf("Expected 40 movements, got %.2f", got)
+	}
+
+	// A bank of runway capacity fills the gates: the 20 aircraft ready depart and 40 arrivals
+	// take the 20 free gates and the departing aircraft's
+	if got, _ := g.advance(start.Add(time.Hour), time.Hour, 200, 60, 2*time.Hour, nil); math.Abs(float64(got-60)) > 1e-3 {
+		t.Errorf("Expected 60 movements in the bank, got %.2f", got)
+	}
+	if math.Abs(g.occupied-60) > 1e-9 {
+		t.Errorf("Expected the gates full after the bank, got %.2f aircraft", g.occupied)
+	}
+
+	// With the gates full, arrivals wait for the 20 aircraft ready to depart in the next hour
+	if got, _ := g.advance(start.Add(2*time.Hour), time.Hour, 200, 60, 2*time.Hour, nil); math.Abs(float64(got-40)) > 1e-3 {
+		t.Errorf("Expected the saturated gates to allow 40 movements, got %.2f", got)
+	}
+}
+
+func TestSimulation_GateOccupancyTaxiTime(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 30 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	run := func(parallelism int) *Result {
+		t.Helper()
+		sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 3, 0)).WithParallelism(parallelism).
+			AddGateCapacityPolicy(GateCapacityConstraint{TotalGates: 20, AverageTurnaroundTime: 45 * time.Minute})
+		if err != nil {
+			t.Fatalf("AddGateCapacityPolicy failed: %v", err)
+		}
+		if sim, err = sim.AddTaxiTimePolicy(TaxiTimeConfiguration{AverageTaxiInTime: 5 * time.Minute, AverageTaxiOutTime: 10 * time.Minute}); err != nil {
+			t.Fatalf("AddTaxiTimePolicy failed: %v", err)
+		}
+		result, err := sim.RunResult(context.Background())
+		if err != nil {
+			t.Fatalf("RunResult failed: %v", err)
+		}
+		return result
+	}
+
+	// Taxi time extends each gate's occupancy to an hour: 20 gates sustain 40 movements per
+	// hour, below the runway's 120
+	result := run(1)
+	hours := result.EndTime.Sub(result.StartTime).Hours()
+	if want := 40 * hours; math.Abs(float64(result.TotalCapacity)-want) > 1 {
+		t.Errorf("Expected %.0f movements, got %.1f", want, result.TotalCapacity)
+	}
+
+	// Aircraft on gates carry across months, so a parallel run processes them in sequence
+	if parallel := run(4); parallel.TotalCapacity != result.TotalCapacity {
+		t.Errorf("Expected a parallel run to match the sequential %.1f movements, got %.1f", result.TotalCapacity, parallel.TotalCapacity)
+	}
+}
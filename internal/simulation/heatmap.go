@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// CapacityHeatmap is the achieved capacity of a run by day and hour of day, as a fraction of
+// the theoretical maximum in the same hour. With one row per day of a year it is a 365×24
+// matrix for heatmap visualization: seasonal patterns run down the rows, diurnal ones (e.g.,
+// a night curfew) across the columns.
+//
+// Hours are local clock hours in the simulation's location. On the day clocks go forward the
+// skipped hour has no movements and a NaN ratio; on the day they go back the repeated hour's
+// movements are added together.
+type CapacityHeatmap struct {
+	Days     []time.Time   // Midnight at the start of each row's day, in chronological order
+	Achieved [][24]float64 // Movements in each hour with all policies
+	Baseline [][24]float64 // Movements in each hour with no policies
+	Ratio    [][24]float64 // Achieved / Baseline in each hour (NaN where the baseline is zero or the hour was not simulated)
+}
+
+// CapacityHeatmap returns the achieved capacity ratio of the constrained run by day and hour.
+// Windows spanning an hour boundary are split proportionally by duration.
+func (b *BaselineResult) CapacityHeatmap() *CapacityHeatmap {
+	h := &CapacityHeatmap{}
+	r := b.Constrained
+	if !r.EndTime.After(r.StartTime) {
+		return h
+	}
+
+	achieved, baseline := r.cumulativeCapacity(), b.Baseline.cumulativeCapacity()
+	loc := r.StartTime.Location()
+	index := map[time.Time]int{}
+
+	// Hours are stepped in absolute time from the start of the first local hour, so
+	// repeated and skipped clock hours fall where the location's clock puts them
+	start := r.StartTime
+	hourStart := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, loc)
+	for t := hourStart; t.Before(r.EndTime); t = t.Add(time.Hour) {
+		from, to := maxTime(t, r.StartTime), minTime(t.Add(time.Hour), r.EndTime)
+		if !to.After(from) {
+			continue
+		}
+		local := from.In(loc)
+		day := startOfDay(local)
+		i, ok := index[day]
+		if !ok {
+			i = len(h.Days)
+			index[day] = i
+			h.Days = append(h.Days, day)
+			h.Achieved = append(h.Achieved, [24]float64{})
+			h.Baseline = append(h.Baseline, [24]float64{})
+		}
+		h.Achieved[i][local.Hour()] += achieved(to) - achieved(from)
+		h.Baseline[i][local.Hour()] += baseline(to) - baseline(from)
+	}
+
+	h.Ratio = make([][24]float64, len(h.Days))
+	for i := range h.Days {
+		for hour := range 24 {
+			h.Ratio[i][hour] = math.NaN()
+			if h.Baseline[i][hour] > 0 {
+				h.Ratio[i][hour] = h.Achieved[i][hour] / h.Baseline[i][hour]
+			}
+		}
+	}
+	return h
+}
+
+// WriteCapacityHeatmapCSV writes the ratios of a capacity heatmap as CSV with a header row and
+// one row per day: the date, then the ratio in each hour from 00 to 23 to three decimal
+// places. Hours without a ratio are left empty.
+func WriteCapacityHeatmapCSV(w io.Writer, h *CapacityHeatmap) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, 25)
+	header[0] = "date"
+	for hour := range 24 {
+		header[hour+1] = fmt.Sprintf("h%02d", hour)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, 25)
+	for i, day := range h.Days {
+		record[0] = day.Format(time.DateOnly)
+		for hour, ratio := range h.Ratio[i] {
+			record[hour+1] = ""
+			if !math.IsNaN(ratio) {
+				record[hour+1] = strconv.FormatFloat(ratio, 'f', 3, 64)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
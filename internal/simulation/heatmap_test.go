@@ -0,0 +1,81 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCapacityHeatmap_Curfew(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim, err := NewSimulation(capacityTableAirport(), testEngineLogger()).
+		WithPeriod(start, start.AddDate(0, 0, 3)).
+		AddCurfewPolicy(start.Add(23*time.Hour), start.Add(30*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := sim.RunWithBaseline(context.Background())
+	if err != nil {
+		t.Fatalf("RunWithBaseline failed: %v", err)
+	}
+
+	heatmap := baseline.CapacityHeatmap()
+	if len(heatmap.Days) != 3 || !heatmap.Days[2].Equal(start.AddDate(0, 0, 2)) {
+		t.Fatalf("Expected rows for 3 days, got %v", heatmap.Days)
+	}
+	for day := range heatmap.Days {
+		for hour, ratio := range heatmap.Ratio[day] {
+			curfew := hour == 23 || (day > 0 && hour < 6)
+			if want := map[bool]float64{true: 0, false: 1}[curfew]; math.Abs(ratio-want) > 1e-6 {
+				t.Errorf("Day %d hour %02d: expected ratio %v, got %v", day, hour, want, ratio)
+			}
+		}
+	}
+
+	var total float64
+	for _, hours := range heatmap.Achieved {
+		for _, movements := range hours {
+			total += movements
+		}
+	}
+	if math.Abs(total-float64(baseline.Constrained.TotalCapacity)) > 0.01 {
+		t.Errorf("Expected the cells to sum to the total capacity %v, got %v", baseline.Constrained.TotalCapacity, total)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCapacityHeatmapCSV(&buf, heatmap); err != nil {
+		t.Fatalf("WriteCapacityHeatmapCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "date,h00,h01") || !strings.HasPrefix(lines[2], "2024-01-02,0.000,") {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+}
+
+func TestCapacityHeatmap_DaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	start := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	sim := NewSimulation(capacityTableAirport(), testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1))
+	baseline, err := sim.RunWithBaseline(context.Background())
+	if err != nil {
+		t.Fatalf("RunWithBaseline failed: %v", err)
+	}
+
+	heatmap := baseline.CapacityHeatmap()
+	if len(heatmap.Days) != 1 {
+		t.Fatalf("Expected one day, got %v", heatmap.Days)
+	}
+	// Clocks go forward from 02:00 to 03:00
+	if !math.IsNaN(heatmap.Ratio[0][2]) || heatmap.Achieved[0][2] != 0 {
+		t.Errorf("Expected no movements in the skipped hour, got %v", heatmap.Achieved[0][2])
+	}
+	if heatmap.Ratio[0][3] != 1 || heatmap.Achieved[0][3] != heatmap.Achieved[0][1] {
+		t.Errorf("Expected full hours either side of the skipped hour, got %v and %v", heatmap.Achieved[0][1], heatmap.Achieved[0][3])
+	}
+}
@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// ClosedConfigurationLabel labels a configuration with no active runways.
+const ClosedConfigurationLabel = "Closed"
+
+// ConfigurationChange records the active runway configuration taking effect at a point in time.
+type ConfigurationChange struct {
+	Time          time.Time                          // When the configuration became active
+	Configuration map[string]*event.ActiveRunwayInfo // Active runways (must not be modified)
+	Label         string                             // Configuration label (see ConfigurationLabel)
+	Trigger       string                             // Type of the event that caused the change (e.g., "WindChange"); "" for the initial configuration
+}
+
+// ConfigurationLabel returns a readable label identifying a runway configuration: the runway
+// ends in use in sorted order, with the operation for runways restricted to arrivals or
+// departures (e.g., "09L arrivals, 09R departures"). Returns ClosedConfigurationLabel for
+// an empty configuration.
+func ConfigurationLabel(config map[string]*event.ActiveRunwayInfo) string {
+	if len(config) == 0 {
+		return ClosedConfigurationLabel
+	}
+
+	parts := make([]string, 0, len(config))
+	for _, info := range config {
+		part := info.RunwayEnd()
+		switch info.OperationType {
+		case event.LandingOnly:
+			part += " arrivals"
+		case event.TakeoffOnly:
+			part += " departures"
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// ConfigurationHistory returns every distinct active runway configuration of the run in
+// chronological order, starting with the configuration at the simulation start.
+// Re-applying the configuration already in effect is not recorded.
+//
+// Thread-safe: Uses read lock.
+func (w *World) ConfigurationHistory() []ConfigurationChange {
+	w.activeConfigMu.RLock()
+	defer w.activeConfigMu.RUnlock()
+
+	history := make([]ConfigurationChange, len(w.configurationHistory))
+	copy(history, w.configurationHistory)
+	return history
+}
+
+// recordConfigurationChange appends the current active configuration to the history if it
+// differs from the last recorded one. A change at the same time as the last record replaces it,
+// so only the configuration that was actually in effect is kept.
+//
+// Call summarizeActiveConfiguration first so the label is current.
+//
+// NOT thread-safe: Must be called while holding the write lock (or before the world is shared).
+func (w *World) recordConfigurationChange(trigger string) {
+	change := ConfigurationChange{
+		Time:          w.CurrentTime,
+		Configuration: w.ActiveRunwayConfiguration,
+		Label:         w.activeLabel,
+		Trigger:       trigger,
+	}
+
+	n := len(w.configurationHistory)
+	if n > 0 && w.configurationHistory[n-1].Label == change.Label {
+		return
+	}
+	if n > 0 && w.configurationHistory[n-1].Time.Equal(change.Time) {
+		w.configurationHistory = w.configurationHistory[:n-1]
+		if n > 1 && w.configurationHistory[n-2].Label == change.Label {
+			return
+		}
+	}
+	w.configurationHistory = append(w.configurationHistory, change)
+}
+
+// ConfigurationDurations returns how long each runway configuration was active during the run,
+// keyed by configuration label.
+func (r *Result) ConfigurationDurations() map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for i, change := range r.ConfigurationHistory {
+		end := r.EndTime
+		if i+1 < len(r.ConfigurationHistory) {
+			end = r.ConfigurationHistory[i+1].Time
+		}
+		if end.After(change.Time) {
+			durations[change.Label] += end.Sub(change.Time)
+		}
+	}
+	return durations
+}
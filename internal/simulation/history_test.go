@@ -0,0 +1,77 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestWorld_ConfigurationHistory(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, TailwindLimitKnots: 5, MinimumSeparation: 60 * time.Second},
+		},
+	}
+	world := NewWorld(ap, start, start.Add(10*time.Hour))
+
+	// Westerly wind reverses the runway at 02:00, a second westerly changes nothing,
+	// maintenance closes it from 06:00 to 08:00, and an easterly restores 09 at 09:00
+	world.ScheduleEvent(event.NewWindChangeEvent(15, 270, start.Add(2*time.Hour)))
+	world.ScheduleEvent(event.NewWindChangeEvent(10, 260, start.Add(4*time.Hour)))
+	world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09", start.Add(6*time.Hour)))
+	world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09", start.Add(8*time.Hour)))
+	world.ScheduleEvent(event.NewWindChangeEvent(15, 90, start.Add(9*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	expected := []struct {
+		hour    int
+		label   string
+		trigger string
+	}{
+		{0, "09", ""},
+		{2, "27", "WindChange"},
+		{6, ClosedConfigurationLabel, "RunwayMaintenanceStart"},
+		{8, "27", "RunwayMaintenanceEnd"},
+		{9, "09", "WindChange"},
+	}
+
+	history := world.ConfigurationHistory()
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d configuration changes, got %+v", len(expected), history)
+	}
+	for i, want := range expected {
+		got := history[i]
+		if !got.Time.Equal(start.Add(time.Duration(want.hour)*time.Hour)) || got.Label != want.label || got.Trigger != want.trigger {
+			t.Errorf("Change %d: expected %s by %q at %02d:00, got %s by %q at %v", i, want.label, want.trigger, want.hour, got.Label, got.Trigger, got.Time)
+		}
+	}
+
+	durations := result.ConfigurationDurations()
+	if durations["09"] != 3*time.Hour || durations["27"] != 5*time.Hour || durations[ClosedConfigurationLabel] != 2*time.Hour {
+		t.Errorf("Unexpected configuration durations: %v", durations)
+	}
+}
+
+func TestConfigurationLabel(t *testing.T) {
+	config := map[string]*event.ActiveRunwayInfo{
+		"09R": {RunwayDesignation: "09R", OperationType: event.TakeoffOnly, Direction: event.Reverse},
+		"09L": {RunwayDesignation: "09L", OperationType: event.LandingOnly, Direction: event.Reverse},
+		"18":  {RunwayDesignation: "18", OperationType: event.Mixed},
+	}
+
+	if got := ConfigurationLabel(config); got != "18, 27L departures, 27R arrivals" {
+		t.Errorf("Unexpected label %q", got)
+	}
+	if got := ConfigurationLabel(nil); got != ClosedConfigurationLabel {
+		t.Errorf("Expected %q for no runways, got %q", ClosedConfigurationLabel, got)
+	}
+}
@@ -0,0 +1,86 @@
+package simulation
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RunIDKey is the log attribute key under which records logged during a run carry its run ID
+// (see ContextWithRunID).
+const RunIDKey = "run"
+
+type runIDContextKey struct{}
+
+type loggerContextKey struct{}
+
+// ContextWithRunID returns a copy of ctx identifying the run it is passed to: every record the
+// simulation and engine log during the run carries the ID under RunIDKey, so the logs of
+// concurrent runs, such as a Monte Carlo batch or requests to a server, can be told apart.
+// Runs without a run ID log as before.
+func ContextWithRunID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, runIDContextKey{}, id)
+}
+
+// RunIDFromContext returns the run ID of ctx set by ContextWithRunID, or "" if it has none.
+func RunIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(runIDContextKey{}).(string)
+	return id
+}
+
+// ContextWithLogger returns a copy of ctx whose runs log to logger instead of the logger the
+// simulation or engine was created with, so each run can write to its own destination.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// runLogger returns the logger for the run ctx belongs to: the logger of ctx, or fallback if it
+// has none, tagging records with the run ID when ctx has one.
+func runLogger(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	logger := fallback
+	if l, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok && l != nil {
+		logger = l
+	}
+	if RunIDFromContext(ctx) == "" {
+		return logger
+	}
+	if _, ok := logger.Handler().(runIDHandler); ok {
+		return logger
+	}
+	return slog.New(runIDHandler{logger.Handler()})
+}
+
+// log returns the logger for the run ctx belongs to (see ContextWithLogger and ContextWithRunID).
+func (s *Simulation) log(ctx context.Context) *slog.Logger {
+	return runLogger(ctx, s.logger)
+}
+
+// log returns the logger for the run ctx belongs to (see ContextWithLogger and ContextWithRunID).
+func (e *Engine) log(ctx context.Context) *slog.Logger {
+	return runLogger(ctx, e.logger)
+}
+
+// runIDHandler adds the run ID of the context a record is logged with to the record. It reads
+// the ID when each record is handled, so a record is tagged once however many components
+// resolved the logger for the run.
+type runIDHandler struct {
+	slog.Handler
+}
+
+// Handle adds the run ID of ctx, if any, to the record and passes it on.
+func (h runIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RunIDFromContext(ctx); id != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String(RunIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler adding attrs that still tags records with the run ID.
+func (h runIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return runIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler opening the group that still tags records with the run ID.
+func (h runIDHandler) WithGroup(name string) slog.Handler {
+	return runIDHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,85 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a buffer concurrent runs can log to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestSimulation_RunIDLogging(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var shared syncBuffer
+	logger := slog.New(slog.NewJSONHandler(&shared, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// Concurrent runs sharing a logger tag every record, engine ones included, with their own ID
+	var wg sync.WaitGroup
+	for run := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sim := NewSimulation(capacityTableAirport(), logger).WithPeriod(start, start.AddDate(0, 0, 1)).WithSeed(int64(run))
+			ctx := ContextWithRunID(context.Background(), fmt.Sprintf("run-%d", run))
+			if _, err := sim.RunWithBaseline(ctx); err != nil {
+				t.Errorf("RunWithBaseline failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(shared.buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid log line %q: %v", line, err)
+		}
+		id, _ := record[RunIDKey].(string)
+		if id == "" || strings.Count(line, `"`+RunIDKey+`":`) != 1 {
+			t.Fatalf("Expected one run ID on every record, got %s", line)
+		}
+		counts[id]++
+	}
+	for run := range 4 {
+		for _, id := range []string{fmt.Sprintf("run-%d", run), fmt.Sprintf("run-%d/baseline", run)} {
+			if counts[id] == 0 {
+				t.Errorf("Expected records from %s, got %v", id, counts)
+			}
+		}
+	}
+
+	// A context logger replaces the simulation's for the run
+	var own, fallback bytes.Buffer
+	sim := NewSimulation(capacityTableAirport(), slog.New(slog.NewTextHandler(&fallback, nil))).WithPeriod(start, start.AddDate(0, 0, 1))
+	ctx := ContextWithLogger(ContextWithRunID(context.Background(), "mine"), slog.New(slog.NewTextHandler(&own, nil)))
+	if _, err := sim.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if fallback.Len() != 0 || !strings.Contains(own.String(), "run=mine") {
+		t.Errorf("Expected the run to log only to its own logger, got %q and %q", own.String(), fallback.String())
+	}
+
+	// Without a run ID nothing is added
+	if _, err := sim.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if fallback.Len() == 0 || strings.Contains(fallback.String(), "run=") {
+		t.Errorf("Expected untagged records on the simulation's logger, got %q", fallback.String())
+	}
+}
@@ -0,0 +1,394 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// defaultAnnealingIterations is the number of moves simulated annealing tries when
+// MaintenanceOptimization.Iterations is zero.
+const defaultAnnealingIterations = 20_000
+
+// OptimizationStrategy selects how OptimizeMaintenance searches for a schedule.
+type OptimizationStrategy int
+
+const (
+	// GreedyOptimization places each maintenance block in turn at the start losing the least
+	// capacity that the blocks already placed leave free.
+	GreedyOptimization OptimizationStrategy = iota
+	// AnnealingOptimization improves the greedy schedule by simulated annealing: blocks are
+	// moved to random starts, accepting moves that lose more capacity with a probability that
+	// falls as the search cools, so the search can escape where constraints bind.
+	AnnealingOptimization
+)
+
+// String returns the strategy name.
+func (o OptimizationStrategy) String() string {
+	switch o {
+	case GreedyOptimization:
+		return "Greedy"
+	case AnnealingOptimization:
+		return "Annealing"
+	default:
+		return "Unknown"
+	}
+}
+
+// MaintenanceRequirement is the maintenance one runway needs.
+type MaintenanceRequirement struct {
+	RunwayDesignation string        // Runway to maintain
+	PerYear           time.Duration // Maintenance needed per year, prorated to the simulation period
+}
+
+// MaintenanceOptimization describes the maintenance to schedule and the constraints on it.
+type MaintenanceOptimization struct {
+	Requirements []MaintenanceRequirement // Maintenance each runway needs
+
+	// Block is the length of each maintenance block, a whole number of hours. Requirements
+	// are met in whole blocks, each starting on the hour.
+	Block time.Duration
+
+	MinimumOperationalRunways int                     // Runways that must stay out of maintenance at all times (default: 1)
+	WithinCurfew              bool                    // Only schedule maintenance while the airport curfew is in effect
+	Restrictions              MaintenanceRestrictions // Blackout dates and allowed windows (zero value = any time)
+
+	Strategy   OptimizationStrategy // Search strategy (default: GreedyOptimization)
+	Iterations int                  // Moves tried by simulated annealing (0 = 20,000)
+}
+
+// MaintenancePlan is a maintenance schedule with the capacity loss a simulation of it
+// projects.
+type MaintenancePlan struct {
+	Windows     []MaintenanceWindow // Maintenance blocks in chronological order (adjacent blocks of a runway merged)
+	Baseline    float32             // Capacity without the planned maintenance
+	Capacity    float32             // Capacity with the planned maintenance
+	Loss        float32             // Movements lost to the planned maintenance
+	LossPercent float64             // Loss as a percentage of the baseline (0 when the baseline is zero)
+}
+
+// maintenanceBlock is one block of a runway's maintenance placed by the optimizer.
+type maintenanceBlock struct {
+	runway int // Index of the runway in the optimizer's runways
+	start  int // Hour of the period the block starts in (-1 = not placed)
+}
+
+// maintenanceSearch is the state of a maintenance schedule search over the hours of the
+// simulation period.
+type maintenanceSearch struct {
+	runways       []string    // Runways to maintain
+	hours         int         // Block length in hours
+	maxConcurrent int         // Runways that may be in maintenance at once
+	costs         [][]float64 // Prefix sums of the movements each runway's closure loses, by hour
+	starts        [][]int     // Hours each runway's blocks may start in, in order
+	busy          []int       // Runways in maintenance in each hour
+	closed        [][]bool    // Whether each runway is in maintenance in each hour
+	blocks        []maintenanceBlock
+}
+
+// OptimizeMaintenance searches for the maintenance schedule meeting the requirements that
+// loses the least capacity, and projects its loss by simulating it.
+//
+// The capacity each hour of maintenance costs comes from counterfactual runs of the
+// simulation, with the same seed and policies, in which each runway is closed throughout:
+// the movements lost in each hour are its cost. Hours in which the runway is not needed, such
+// as during a curfew, cost nothing, so the search favours them. Blocks closing runways at the
+// same time are costed independently, so the projected loss, from a final run with the
+// schedule, can differ from the search's estimate.
+//
+// Blocks must fit the restrictions, keep MinimumOperationalRunways of the airport's runways
+// out of maintenance, and not overlap the same runway's other blocks. Returns an error if the
+// optimization is invalid or the blocks cannot all be placed.
+func (s *Simulation) OptimizeMaintenance(ctx context.Context, optimization MaintenanceOptimization) (*MaintenancePlan, error) {
+	s = s.enabledPolicies()
+	ap := s.applyPlugins(ctx, nil)
+	if err := optimization.validate(len(ap.Runways), func(id string) bool { return hasRunway(ap, id) }); err != nil {
+		return nil, err
+	}
+	if optimization.MinimumOperationalRunways <= 0 {
+		optimization.MinimumOperationalRunways = 1
+	}
+
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	all := make([]int, len(s.policies))
+	for i := range all {
+		all[i] = i
+	}
+	start, end := s.period()
+	periodHours := int(end.Sub(start) / time.Hour)
+
+	s.log(ctx).InfoContext(ctx, "Optimizing maintenance", "strategy", optimization.Strategy, "requirements", len(optimization.Requirements))
+	baseline, err := s.withPolicies(all).WithSeed(seed).RunResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baselineCapacity := baseline.cumulativeCapacity()
+	curfew := curfewHours(baseline, start, periodHours)
+
+	search := &maintenanceSearch{
+		hours:         int(optimization.Block / time.Hour),
+		maxConcurrent: len(ap.Runways) - optimization.MinimumOperationalRunways,
+		busy:          make([]int, periodHours),
+	}
+	for i, requirement := range optimization.Requirements {
+		// Close the runway throughout to cost each hour of its maintenance
+		closure, err := policy.NewPlannedMaintenancePolicy([]MaintenanceWindow{{RunwayDesignation: requirement.RunwayDesignation, Start: start, End: end}})
+		if err != nil {
+			return nil, err
+		}
+		closed, err := s.withPolicies(all).AddPolicy(closure).WithSeed(seed).RunResult(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("costing maintenance of %s: %w", requirement.RunwayDesignation, err)
+		}
+		closedCapacity := closed.cumulativeCapacity()
+
+		costs := make([]float64, periodHours+1)
+		for h := range periodHours {
+			from, to := start.Add(time.Duration(h)*time.Hour), start.Add(time.Duration(h+1)*time.Hour)
+			loss := (baselineCapacity(to) - baselineCapacity(from)) - (closedCapacity(to) - closedCapacity(from))
+			costs[h+1] = costs[h] + max(0, loss)
+		}
+		search.runways = append(search.runways, requirement.RunwayDesignation)
+		search.costs = append(search.costs, costs)
+
+		var starts []int
+		for h := 0; h+search.hours <= periodHours; h++ {
+			blockStart := start.Add(time.Duration(h) * time.Hour)
+			if !optimization.Restrictions.Permits(blockStart, optimization.Block) {
+				continue
+			}
+			if optimization.WithinCurfew && slices.Contains(curfew[h:h+search.hours], false) {
+				continue
+			}
+			starts = append(starts, h)
+		}
+		search.starts = append(search.starts, starts)
+		search.closed = append(search.closed, make([]bool, periodHours))
+
+		// Prorate the yearly requirement to the period, in whole blocks
+		required := requirement.PerYear.Hours() * Period{Start: start, End: end}.Years()
+		for range int(math.Ceil(required/float64(search.hours) - 1e-9)) {
+			search.blocks = append(search.blocks, maintenanceBlock{runway: i, start: -1})
+		}
+	}
+
+	if err := search.greedy(); err != nil {
+		return nil, err
+	}
+	if optimization.Strategy == AnnealingOptimization {
+		iterations := optimization.Iterations
+		if iterations == 0 {
+			iterations = defaultAnnealingIterations
+		}
+		search.anneal(iterations, policy.NewRandomSource(seed, uint64(len(s.policies))))
+	}
+
+	// Project the loss by simulating the schedule
+	var windows []MaintenanceWindow
+	for _, block := range search.blocks {
+		blockStart := start.Add(time.Duration(block.start) * time.Hour)
+		windows = append(windows, MaintenanceWindow{
+			RunwayDesignation: search.runways[block.runway],
+			Start:             blockStart,
+			End:               blockStart.Add(optimization.Block),
+		})
+	}
+	plan := &MaintenancePlan{Baseline: baseline.TotalCapacity, Capacity: baseline.TotalCapacity}
+	if len(windows) > 0 {
+		planned, err := policy.NewPlannedMaintenancePolicy(windows)
+		if err != nil {
+			return nil, err
+		}
+		plan.Windows = planned.GetWindows()
+		slices.SortStableFunc(plan.Windows, func(a, b MaintenanceWindow) int { return a.Start.Compare(b.Start) })
+		if plan.Capacity, err = s.withPolicies(all).AddPolicy(planned).WithSeed(seed).Run(ctx); err != nil {
+			return nil, err
+		}
+	}
+	plan.Loss = plan.Baseline - plan.Capacity
+	if plan.Baseline > 0 {
+		plan.LossPercent = float64(plan.Loss) / float64(plan.Baseline) * 100
+	}
+	return plan, nil
+}
+
+// validate checks that there are requirements for known runways, each positive and for a
+// runway at most once, that the block is a positive whole number of hours, that the
+// minimum operational runways leave one to maintain, and that the strategy, iterations and
+// restrictions are valid.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (o MaintenanceOptimization) validate(runways int, exists func(id string) bool) error {
+	var problems []error
+	if len(o.Requirements) == 0 {
+		problems = append(problems, simerrors.Invalidf("maintenance optimization needs at least one requirement"))
+	}
+	seen := map[string]bool{}
+	for _, requirement := range o.Requirements {
+		if !exists(requirement.RunwayDesignation) {
+			problems = append(problems, &simerrors.RunwayNotFoundError{RunwayID: requirement.RunwayDesignation})
+		}
+		if seen[requirement.RunwayDesignation] {
+			problems = append(problems, simerrors.Invalidf("runway %s has more than one maintenance requirement", requirement.RunwayDesignation))
+		}
+		seen[requirement.RunwayDesignation] = true
+		if requirement.PerYear <= 0 {
+			problems = append(problems, simerrors.Invalidf("maintenance needed per year for %s must be positive, got %v", requirement.RunwayDesignation, requirement.PerYear))
+		}
+	}
+	if o.Block <= 0 || o.Block%time.Hour != 0 {
+		problems = append(problems, simerrors.Invalidf("maintenance block must be a positive whole number of hours, got %v", o.Block))
+	}
+	if o.MinimumOperationalRunways < 0 || o.MinimumOperationalRunways >= runways {
+		problems = append(problems, simerrors.Invalidf("minimum operational runways must leave a runway of %d to maintain, got %d", runways, o.MinimumOperationalRunways))
+	}
+	if o.Strategy != GreedyOptimization && o.Strategy != AnnealingOptimization {
+		problems = append(problems, simerrors.Invalidf("unknown optimization strategy %d", o.Strategy))
+	}
+	if o.Iterations < 0 {
+		problems = append(problems, simerrors.Invalidf("annealing iterations cannot be negative, got %d", o.Iterations))
+	}
+	if o.Block > 0 {
+		if err := o.Restrictions.Validate(o.Block); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// curfewHours reports, for each hour of the period, whether the result has the airport curfew
+// in effect throughout it.
+func curfewHours(result *Result, start time.Time, hours int) []bool {
+	covered := make([]time.Duration, hours)
+	for _, w := range result.Windows {
+		if !w.CurfewActive {
+			continue
+		}
+		for h := max(0, int(w.Start.Sub(start)/time.Hour)); h < hours; h++ {
+			from := start.Add(time.Duration(h) * time.Hour)
+			if !from.Before(w.End) {
+				break
+			}
+			covered[h] += minTime(w.End, from.Add(time.Hour)).Sub(maxTime(w.Start, from))
+		}
+	}
+	curfew := make([]bool, hours)
+	for h, d := range covered {
+		curfew[h] = d >= time.Hour
+	}
+	return curfew
+}
+
+// cost returns the movements a block of the runway starting in hour h loses.
+func (m *maintenanceSearch) cost(runway, h int) float64 {
+	return m.costs[runway][h+m.hours] - m.costs[runway][h]
+}
+
+// fits reports whether a block of the runway can start in hour h: the runway is not already
+// in maintenance and other runways leave room for it throughout.
+func (m *maintenanceSearch) fits(runway, h int) bool {
+	for i := h; i < h+m.hours; i++ {
+		if m.closed[runway][i] || m.busy[i] >= m.maxConcurrent {
+			return false
+		}
+	}
+	return true
+}
+
+// place puts the block in or takes it out of maintenance from hour h.
+func (m *maintenanceSearch) place(block *maintenanceBlock, h int, in bool) {
+	delta := 1
+	if !in {
+		delta = -1
+	}
+	for i := h; i < h+m.hours; i++ {
+		m.closed[block.runway][i] = in
+		m.busy[i] += delta
+	}
+	if in {
+		block.start = h
+	} else {
+		block.start = -1
+	}
+}
+
+// greedy places each block in turn at the cheapest start it fits (the earliest if several
+// cost the same). Returns an error if a block fits nowhere.
+func (m *maintenanceSearch) greedy() error {
+	for i := range m.blocks {
+		block := &m.blocks[i]
+		best, bestCost := -1, math.Inf(1)
+		for _, h := range m.starts[block.runway] {
+			if cost := m.cost(block.runway, h); cost < bestCost && m.fits(block.runway, h) {
+				best, bestCost = h, cost
+			}
+		}
+		if best < 0 {
+			placed := 0
+			for _, b := range m.blocks[:i] {
+				if b.runway == block.runway {
+					placed++
+				}
+			}
+			return simerrors.Invalidf("cannot fit maintenance block %d of %s within the restrictions", placed+1, m.runways[block.runway])
+		}
+		m.place(block, best, true)
+	}
+	return nil
+}
+
+// anneal improves the placed blocks by simulated annealing over the given number of moves,
+// keeping the cheapest schedule found. Each move takes a random block to a random start it
+// fits; a move losing more capacity is accepted with probability exp(-increase/temperature),
+// the temperature falling geometrically from the average block cost.
+func (m *maintenanceSearch) anneal(iterations int, rng *rand.Rand) {
+	if len(m.blocks) == 0 {
+		return
+	}
+	total := 0.0
+	for _, block := range m.blocks {
+		total += m.cost(block.runway, block.start)
+	}
+	initial := max(total/float64(len(m.blocks)), 1)
+	cooling := math.Pow(1e-3, 1/float64(iterations))
+
+	best, bestTotal := slices.Clone(m.blocks), total
+	temperature := initial
+	for range iterations {
+		block := &m.blocks[rng.IntN(len(m.blocks))]
+		starts := m.starts[block.runway]
+		to := starts[rng.IntN(len(starts))]
+		from := block.start
+
+		m.place(block, from, false)
+		delta := m.cost(block.runway, to) - m.cost(block.runway, from)
+		if m.fits(block.runway, to) && (delta <= 0 || rng.Float64() < math.Exp(-delta/temperature)) {
+			m.place(block, to, true)
+			total += delta
+			if total < bestTotal-1e-9 {
+				copy(best, m.blocks)
+				bestTotal = total
+			}
+		} else {
+			m.place(block, from, true)
+		}
+		temperature *= cooling
+	}
+
+	// Restore the cheapest schedule found
+	for i := range m.blocks {
+		m.place(&m.blocks[i], m.blocks[i].start, false)
+	}
+	for i := range m.blocks {
+		m.place(&m.blocks[i], best[i].start, true)
+	}
+}
@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// optimizerSimulation returns a 36 hour simulation of two independent runways, each of 60
+// movements per hour, with a 23:00-06:00 curfew: the first night and 23:00 on the second.
+func optimizerSimulation(t *testing.T) *Simulation {
+	t.Helper()
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: time.Minute},
+			{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: time.Minute},
+		},
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sim, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(start, start.Add(36*time.Hour)).
+		WithSeed(1).
+		AddCurfewPolicy(start.Add(11*time.Hour), start.Add(18*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sim
+}
+
+// perYear returns the yearly maintenance prorated to the given hours of a 36 hour period in
+// 2024, a leap year.
+func perYear(hours int) time.Duration {
+	return time.Duration(hours) * 366 * 24 * time.Hour / 36
+}
+
+func TestSimulation_OptimizeMaintenance(t *testing.T) {
+	sim := optimizerSimulation(t)
+	night := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	// Curfew hours cost nothing, so both runways' blocks fit in the curfew, one runway at a time
+	plan, err := sim.OptimizeMaintenance(context.Background(), MaintenanceOptimization{
+		Requirements: []MaintenanceRequirement{{RunwayDesignation: "09L", PerYear: perYear(4)}, {RunwayDesignation: "09R", PerYear: perYear(3)}},
+		Block:        time.Hour,
+		WithinCurfew: true,
+	})
+	if err != nil {
+		t.Fatalf("OptimizeMaintenance failed: %v", err)
+	}
+	want := []MaintenanceWindow{
+		{RunwayDesignation: "09L", Start: night, End: night.Add(4 * time.Hour)},
+		{RunwayDesignation: "09R", Start: night.Add(4 * time.Hour), End: night.Add(7 * time.Hour)},
+	}
+	if len(plan.Windows) != len(want) || plan.Windows[0] != want[0] || plan.Windows[1] != want[1] {
+		t.Errorf("Expected windows %+v, got %+v", want, plan.Windows)
+	}
+	if plan.Baseline != 3360 || plan.Capacity != 3360 || plan.Loss != 0 || plan.LossPercent != 0 {
+		t.Errorf("Expected no loss from 3360 movements, got %+v", plan)
+	}
+
+	// Two hour blocks cannot use the lone curfew hour on the second night, so eight hours of
+	// maintenance need an hour outside the seven hour night
+	for _, strategy := range []OptimizationStrategy{GreedyOptimization, AnnealingOptimization} {
+		plan, err := sim.OptimizeMaintenance(context.Background(), MaintenanceOptimization{
+			Requirements: []MaintenanceRequirement{{RunwayDesignation: "09L", PerYear: perYear(4)}, {RunwayDesignation: "09R", PerYear: perYear(4)}},
+			Block:        2 * time.Hour,
+			Strategy:     strategy,
+			Iterations:   2000,
+		})
+		if err != nil {
+			t.Fatalf("%v: OptimizeMaintenance failed: %v", strategy, err)
+		}
+		if plan.Loss != 60 || plan.Capacity != 3300 {
+			t.Errorf("%v: expected a loss of 60 movements, got %+v", strategy, plan)
+		}
+		for i, w := range plan.Windows[1:] {
+			if w.Start.Before(plan.Windows[i].End) {
+				t.Errorf("%v: windows %+v and %+v overlap with only one runway to spare", strategy, plan.Windows[i], w)
+			}
+		}
+	}
+}
+
+func TestSimulation_OptimizeMaintenanceErrors(t *testing.T) {
+	sim := optimizerSimulation(t)
+	tests := []struct {
+		name         string
+		optimization MaintenanceOptimization
+		want         error
+	}{
+		{"no requirements", MaintenanceOptimization{Block: time.Hour}, simerrors.ErrInvalidConfiguration},
+		{"unknown runway", MaintenanceOptimization{Requirements: []MaintenanceRequirement{{RunwayDesignation: "27", PerYear: perYear(1)}}, Block: time.Hour}, simerrors.ErrRunwayNotFound},
+		{"partial hour block", MaintenanceOptimization{Requirements: []MaintenanceRequirement{{RunwayDesignation: "09L", PerYear: perYear(1)}}, Block: 90 * time.Minute}, simerrors.ErrInvalidConfiguration},
+		{"no runway to maintain", MaintenanceOptimization{Requirements: []MaintenanceRequirement{{RunwayDesignation: "09L", PerYear: perYear(1)}}, Block: time.Hour, MinimumOperationalRunways: 2}, simerrors.ErrInvalidConfiguration},
+		// Nine hours of maintenance, one runway at a time, cannot fit in eight hours of curfew
+		{"does not fit", MaintenanceOptimization{
+			Requirements: []MaintenanceRequirement{{RunwayDesignation: "09L", PerYear: perYear(5)}, {RunwayDesignation: "09R", PerYear: perYear(4)}},
+			Block:        time.Hour,
+			WithinCurfew: true,
+		}, simerrors.ErrInvalidConfiguration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := sim.OptimizeMaintenance(context.Background(), tt.optimization); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
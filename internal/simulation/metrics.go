@@ -0,0 +1,29 @@
+package simulation
+
+import "time"
+
+// Metrics receives instrumentation from the engine, so a server or batch runner can export it
+// to a monitoring system (e.g., a Prometheus or OpenTelemetry adapter implementing Metrics).
+// The engine reports each run once its timeline is complete, so speculative work discarded by
+// parallel processing is never counted. Implementations must be safe for concurrent use if
+// simulations sharing them run concurrently.
+type Metrics interface {
+	// EventsProcessed adds n to the count of events applied (a counter).
+	EventsProcessed(n int)
+
+	// WindowProcessed records one capacity window: its simulated duration (a histogram) and
+	// the number of runways active during it (a gauge, reported in chronological order).
+	WindowProcessed(duration time.Duration, activeRunways int)
+
+	// RunCompleted records the wall-clock time one timeline calculation took.
+	RunCompleted(wallClock time.Duration)
+}
+
+// recordMetrics reports a completed run to m.
+func recordMetrics(m Metrics, result *Result, wallClock time.Duration) {
+	m.EventsProcessed(result.EventsProcessed)
+	for _, window := range result.Windows {
+		m.WindowProcessed(window.Duration(), len(window.ActiveRunways))
+	}
+	m.RunCompleted(wallClock)
+}
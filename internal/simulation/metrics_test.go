@@ -0,0 +1,90 @@
+package simulation
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// recordingMetrics is a Metrics implementation that records what it receives.
+type recordingMetrics struct {
+	mu            sync.Mutex
+	events        int
+	windows       []time.Duration
+	activeRunways []int
+	runs          int
+}
+
+func (m *recordingMetrics) EventsProcessed(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events += n
+}
+
+func (m *recordingMetrics) WindowProcessed(duration time.Duration, activeRunways int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.windows = append(m.windows, duration)
+	m.activeRunways = append(m.activeRunways, activeRunways)
+}
+
+func (m *recordingMetrics) RunCompleted(wallClock time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs++
+}
+
+func TestEngine_WithMetrics(t *testing.T) {
+	world := createTestWorld(1)
+	world.ScheduleEvent(event.NewCurfewStartEvent(world.StartTime.Add(23 * time.Hour)))
+
+	metrics := &recordingMetrics{}
+	result, err := NewEngine(testEngineLogger()).WithMetrics(metrics).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	// The curfew start and the configuration change it triggers
+	if metrics.events != 2 || result.EventsProcessed != 2 {
+		t.Errorf("Expected 2 events processed, got %d (result %d)", metrics.events, result.EventsProcessed)
+	}
+	if len(metrics.windows) != len(result.Windows) {
+		t.Fatalf("Expected %d windows, got %d", len(result.Windows), len(metrics.windows))
+	}
+
+	var total time.Duration
+	for _, d := range metrics.windows {
+		total += d
+	}
+	if total != 24*time.Hour {
+		t.Errorf("Expected window durations to cover the day, got %v", total)
+	}
+	if first, last := metrics.activeRunways[0], metrics.activeRunways[len(metrics.activeRunways)-1]; first != 3 || last != 0 {
+		t.Errorf("Expected 3 active runways before the curfew and none during it, got %v", metrics.activeRunways)
+	}
+	if metrics.runs != 1 {
+		t.Errorf("Expected 1 completed run, got %d", metrics.runs)
+	}
+}
+
+func TestEngine_WithMetricsParallel(t *testing.T) {
+	world := createTestWorld(90)
+	for day := 0; day < 90; day++ {
+		world.ScheduleEvent(event.NewRotationChangeEvent(1.0, world.StartTime.AddDate(0, 0, day).Add(12*time.Hour)))
+	}
+
+	metrics := &recordingMetrics{}
+	result, err := NewEngine(testEngineLogger()).WithParallelism(3).WithMetrics(metrics).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	// Speculative work is not counted: metrics match the merged result
+	if metrics.events != result.EventsProcessed || len(metrics.windows) != len(result.Windows) {
+		t.Errorf("Expected %d events and %d windows, got %d and %d",
+			result.EventsProcessed, len(result.Windows), metrics.events, len(metrics.windows))
+	}
+}
@@ -0,0 +1,176 @@
+package simulation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// OnNoisePreferencesChanged sets the noise-preferential runway configurations in effect: runway
+// configurations, each given as the runway end designations it operates (e.g., {"27L", "27R"}),
+// in order of preference. While any are set, the first one that can operate - every runway
+// available, each end within its wind limits in the current wind, and the runways compatible -
+// is selected regardless of capacity, in the directions given. When none can operate, the
+// configuration is selected by capacity as usual. nil lifts the preferences.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) OnNoisePreferencesChanged(preferences [][]string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	rm.noisePreferences = clonePreferences(preferences)
+	rm.calculateActiveConfiguration(ReasonNoisePreference)
+}
+
+// NoisePreferences returns the noise-preferential runway configurations in effect (see
+// OnNoisePreferencesChanged).
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) NoisePreferences() [][]string {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return clonePreferences(rm.noisePreferences)
+}
+
+// clonePreferences returns a deep copy of noise-preferential runway configurations.
+func clonePreferences(preferences [][]string) [][]string {
+	if preferences == nil {
+		return nil
+	}
+	c := make([][]string, len(preferences))
+	for i, preference := range preferences {
+		c[i] = slices.Clone(preference)
+	}
+	return c
+}
+
+// selectNoisePreferredConfig returns the first noise-preferential configuration that can
+// operate, built with its runways in the directions of the preferred ends, or nil if none can.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) selectNoisePreferredConfig() map[string]*event.ActiveRunwayInfo {
+	for _, preference := range rm.noisePreferences {
+		directions, ok := rm.preferenceDirections(preference)
+		if !ok {
+			continue
+		}
+		runwayIDs := make([]string, 0, len(directions))
+		for runwayID := range directions {
+			runwayIDs = append(runwayIDs, runwayID)
+		}
+		if !rm.runwaysCompatible(runwayIDs) {
+			continue
+		}
+		return rm.buildConfigurationInDirections(runwayIDs, directions)
+	}
+	return nil
+}
+
+// preferenceDirections returns the direction of each runway of a noise-preferential
+// configuration, and whether it can operate: each runway end belongs to an available runway
+// and is within its wind limits in the current wind.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) preferenceDirections(runwayEnds []string) (map[string]event.Direction, bool) {
+	directions := make(map[string]event.Direction, len(runwayEnds))
+	for _, end := range runwayEnds {
+		runway, direction, found := rm.findRunwayEnd(end)
+		if !found || !rm.availableRunways[runway.RunwayDesignation] {
+			return nil, false
+		}
+		if rm.windSpeed > 0 {
+			if _, usable := runwayDirectionInWind(runway, direction, rm.windSpeed, rm.windGust, rm.windDirection); !usable {
+				return nil, false
+			}
+		}
+		directions[runway.RunwayDesignation] = direction
+	}
+	return directions, len(directions) > 0
+}
+
+// findRunwayEnd finds the runway with an end designated end, and the direction operating
+// towards that end. Returns false if no runway has the end.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) findRunwayEnd(end string) (airport.Runway, event.Direction, bool) {
+	for _, runway := range rm.allRunways {
+		if runway.End(false).Designation == end {
+			return runway, event.Forward, true
+		}
+		if runway.End(true).Designation == end {
+			return runway, event.Reverse, true
+		}
+	}
+	return airport.Runway{}, event.Forward, false
+}
+
+// runwaysCompatible reports whether the runways can operate together under the compatibility
+// graph in effect, that is, they belong to a common maximal clique.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) runwaysCompatible(runwayIDs []string) bool {
+	if rm.compatibility == nil || len(runwayIDs) < 2 {
+		return true
+	}
+	if !rm.maximalCliquesComputed {
+		rm.computeMaximalCliques()
+	}
+
+	set := newRunwaySet(len(rm.allRunways))
+	for _, runwayID := range runwayIDs {
+		set.add(rm.runwayIndex[runwayID])
+	}
+	for _, clique := range rm.cliqueSets {
+		if set.isSubsetOf(clique) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNoisePreferences sets the noise-preferential runway configurations, in order of
+// preference, that the runway manager selects whenever one can operate (see
+// RunwayManager.OnNoisePreferencesChanged), and schedules an
+// ActiveRunwayConfigurationChangedEvent. Called by NoisePreferenceEvent; nil lifts them.
+// Returns an error if a runway end does not belong to a runway at the airport or a
+// configuration uses a runway more than once.
+func (w *World) SetNoisePreferences(preferences [][]string, timestamp time.Time) error {
+	for _, preference := range preferences {
+		if len(preference) == 0 {
+			return simerrors.Invalidf("noise-preferential runway configuration cannot be empty")
+		}
+		runways := make(map[string]bool, len(preference))
+		for _, end := range preference {
+			runwayID, found := w.runwayWithEnd(end)
+			if !found {
+				return &simerrors.RunwayNotFoundError{RunwayID: end}
+			}
+			if runways[runwayID] {
+				return simerrors.Invalidf("noise-preferential runway configuration %v uses runway %s more than once", preference, runwayID)
+			}
+			runways[runwayID] = true
+		}
+	}
+
+	w.RunwayManager.OnNoisePreferencesChanged(preferences)
+
+	newConfig := w.RunwayManager.GetActiveConfiguration()
+	w.ScheduleEvent(event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp))
+
+	return nil
+}
+
+// runwayWithEnd returns the ID of the runway with an end designated end.
+func (w *World) runwayWithEnd(end string) (string, bool) {
+	for runwayID, state := range w.RunwayStates {
+		if state.Runway.End(false).Designation == end || state.Runway.End(true).Designation == end {
+			return runwayID, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,112 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+func TestSimulation_NoisePreferentialPolicy(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 90 * time.Second, TailwindLimitKnots: 10},
+		{RunwayDesignation: "09R", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 90 * time.Second, TailwindLimitKnots: 10},
+	}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Only 27R between 06:00 and 22:00, until an easterly wind from noon puts it out of limits
+	wind, err := policy.NewScheduledWindPolicy([]policy.WindChange{{Timestamp: start.Add(12 * time.Hour), SpeedKnots: 15, DirectionTrue: 90}})
+	if err != nil {
+		t.Fatalf("NewScheduledWindPolicy failed: %v", err)
+	}
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+		AddNoisePreferentialPolicy([]NoisePreferentialBand{{From: 6 * time.Hour, Until: 22 * time.Hour, Preferences: [][]string{{"27R"}}}})
+	if err != nil {
+		t.Fatalf("AddNoisePreferentialPolicy failed: %v", err)
+	}
+	result, err := sim.AddPolicy(wind).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// 6h at 80 + 6h at 40 on 27R alone + 10h and 2h at 80
+	if result.TotalCapacity != 1680 {
+		t.Errorf("Expected 1680 movements, got %.1f", result.TotalCapacity)
+	}
+	for _, w := range result.Windows {
+		if w.Start.Equal(start.Add(6*time.Hour)) && (len(w.ActiveRunways) != 1 || w.ActiveRunways[0] != "09L") {
+			t.Errorf("Expected only 09L, operated towards 27R, from 06:00, got %v", w.ActiveRunways)
+		}
+	}
+}
+
+func TestWorld_SetNoisePreferences(t *testing.T) {
+	world := createTestWorld(1)
+	if err := world.SetNoisePreferences([][]string{{"27R", "36"}}, world.StartTime); err != nil {
+		t.Fatalf("SetNoisePreferences failed: %v", err)
+	}
+	config := world.RunwayManager.GetActiveConfiguration()
+	if len(config) != 2 || config["09L"].Direction != event.Reverse || config["18"].RunwayEnd() != "36" {
+		t.Errorf("Expected 09L towards 27R and 18 towards 36, got %v", configurationRunwayIDs(config))
+	}
+
+	// A closed runway rules the preference out, leaving the capacity-based selection
+	world.RunwayManager.OnRunwayUnavailable("18")
+	if config := world.RunwayManager.GetActiveConfiguration(); len(config) != 2 || config["09R"] == nil {
+		t.Errorf("Expected the two remaining runways, got %v", configurationRunwayIDs(config))
+	}
+
+	for _, preferences := range [][][]string{{{"27X"}}, {{"09L", "27R"}}, {{}}} {
+		if err := world.SetNoisePreferences(preferences, world.StartTime); err == nil {
+			t.Errorf("Expected an error for %v", preferences)
+		} else if !errors.Is(err, simerrors.ErrInvalidConfiguration) && !errors.As(err, new(*simerrors.RunwayNotFoundError)) {
+			t.Errorf("Expected a configuration error for %v, got %v", preferences, err)
+		}
+	}
+}
+
+func TestRunwayManager_NoisePreferencesHeldByDwell(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+	}
+	rm := NewRunwayManager(runways, nil)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rm.SetClock(clock)
+	rm.SetOptions(RunwayManagerOptions{MinimumDwell: time.Hour, WindDeadbandKnots: 5})
+
+	rm.OnNoisePreferencesChanged([][]string{{"27R"}})
+	if config := rm.GetActiveConfiguration(); len(config) != 1 || config["09L"] == nil {
+		t.Fatalf("Expected only 09L, operated towards 27R, got %v", configurationRunwayIDs(config))
+	}
+
+	// The band ends within the dwell: the preferred configuration is held
+	clock.Advance(20 * time.Minute)
+	rm.OnNoisePreferencesChanged(nil)
+	if config := rm.GetActiveConfiguration(); len(config) != 1 {
+		t.Errorf("Expected the preferred configuration to be held by the dwell, got %v", configurationRunwayIDs(config))
+	}
+	// A wind change within the deadband after the dwell is held too
+	clock.Advance(time.Hour)
+	rm.OnWindChanged(2, 270)
+	if config := rm.GetActiveConfiguration(); len(config) != 1 {
+		t.Errorf("Expected the preferred configuration to be held within the deadband, got %v", configurationRunwayIDs(config))
+	}
+	// Beyond the deadband the capacity-based selection takes over
+	rm.OnWindChanged(8, 270)
+	if config := rm.GetActiveConfiguration(); len(config) != 2 {
+		t.Errorf("Expected both runways once the hold ended, got %v", configurationRunwayIDs(config))
+	}
+
+	// A new band after the dwell applies straight away
+	clock.Advance(2 * time.Hour)
+	rm.OnNoisePreferencesChanged([][]string{{"27R"}})
+	if config := rm.GetActiveConfiguration(); len(config) != 1 {
+		t.Errorf("Expected the preferred configuration once the dwell elapsed, got %v", configurationRunwayIDs(config))
+	}
+}
@@ -0,0 +1,163 @@
+package simulation
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/parquet"
+)
+
+// WindowSchemaVersion is the version of the window table written by WindowParquetWriter,
+// recorded in each file's metadata under WindowSchemaVersionKey. Version 1 has the columns:
+//
+//	run                     int64      Run number given to WriteResult (e.g., the Monte Carlo iteration)
+//	start, end              timestamp  Window start (inclusive) and end (exclusive), UTC
+//	capacity                float      Movements calculated for the window
+//	configuration, flow     string     Active configuration label and traffic flow
+//	active_runways          string     Runways in the active configuration, space separated
+//	unavailable_runways     string     Runways closed by restrictions, space separated
+//	curfew_active           boolean
+//	wind_speed_knots        double
+//	wind_direction_degrees  double     Degrees true
+//	rotation_multiplier     float
+//	disruption_factor       float
+//	noise_quota_used        double     Noise points used in the calendar year up to the window end
+//	night_quota_used        double     Night quota movements made in the calendar year up to the window end
+//	helipad_movements       float      Included in capacity
+//	ga_movements            float      Not included in capacity
+//
+// Columns are never renamed or removed within a version; a change that would do so, or add
+// columns, increments the version so readers can check it before relying on the layout.
+const WindowSchemaVersion = 1
+
+// WindowSchemaVersionKey is the file metadata key holding WindowSchemaVersion.
+const WindowSchemaVersionKey = "airport_capacity_calculator.window_schema_version"
+
+// windowColumns are the columns of the window table (see WindowSchemaVersion).
+var windowColumns = []parquet.Column{
+	{Name: "run", Type: parquet.Int64},
+	{Name: "start", Type: parquet.Timestamp},
+	{Name: "end", Type: parquet.Timestamp},
+	{Name: "capacity", Type: parquet.Float},
+	{Name: "configuration", Type: parquet.String},
+	{Name: "flow", Type: parquet.String},
+	{Name: "active_runways", Type: parquet.String},
+	{Name: "unavailable_runways", Type: parquet.String},
+	{Name: "curfew_active", Type: parquet.Boolean},
+	{Name: "wind_speed_knots", Type: parquet.Double},
+	{Name: "wind_direction_degrees", Type: parquet.Double},
+	{Name: "rotation_multiplier", Type: parquet.Float},
+	{Name: "disruption_factor", Type: parquet.Float},
+	{Name: "noise_quota_used", Type: parquet.Double},
+	{Name: "night_quota_used", Type: parquet.Double},
+	{Name: "helipad_movements", Type: parquet.Float},
+	{Name: "ga_movements", Type: parquet.Float},
+}
+
+// ParquetOptions configures a WindowParquetWriter.
+type ParquetOptions struct {
+	RowGroupRows int  // Windows buffered per row group (0 = parquet.DefaultRowGroupRows)
+	Gzip         bool // Compress the file's pages with gzip
+
+	// Metadata is added to the file's key-value metadata, e.g. the Provenance.Metadata of the
+	// results written
+	Metadata map[string]string
+}
+
+// WindowParquetWriter writes the windows of simulation results to a Parquet file, one row per
+// window, for analysis in pandas, Polars or DuckDB. Windows are written out a row group at a
+// time, so the results of a long Monte Carlo batch can be written as each run completes without
+// holding them all in memory. Call Close to finish the file.
+type WindowParquetWriter struct {
+	w *parquet.Writer
+}
+
+// NewWindowParquetWriter starts a Parquet file of windows on out, using the schema described
+// by WindowSchemaVersion.
+func NewWindowParquetWriter(out io.Writer, options ParquetOptions) (*WindowParquetWriter, error) {
+	metadata := maps.Clone(options.Metadata)
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata[WindowSchemaVersionKey] = strconv.Itoa(WindowSchemaVersion)
+	w, err := parquet.NewWriter(out, windowColumns, parquet.Options{
+		RowGroupRows: options.RowGroupRows,
+		Gzip:         options.Gzip,
+		Metadata:     metadata,
+		CreatedBy:    "AirportCapacityCalculator",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WindowParquetWriter{w: w}, nil
+}
+
+// WriteResult writes every window of result, identified by run.
+func (pw *WindowParquetWriter) WriteResult(run int64, result *Result) error {
+	for _, w := range result.Windows {
+		if err := pw.w.WriteRow(
+			run,
+			w.Start,
+			w.End,
+			w.Capacity,
+			w.Configuration,
+			w.Flow,
+			strings.Join(w.ActiveRunways, " "),
+			strings.Join(w.UnavailableRunways, " "),
+			w.CurfewActive,
+			w.WindSpeed,
+			w.WindDirection,
+			w.RotationMultiplier,
+			w.DisruptionFactor,
+			w.NoiseQuotaUsed,
+			w.NightQuotaUsed,
+			w.HelipadMovements,
+			w.GAMovements,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rows returns the number of windows written so far.
+func (pw *WindowParquetWriter) Rows() int64 {
+	return pw.w.Rows()
+}
+
+// Close writes any buffered windows and the file footer. It does not close the underlying writer.
+func (pw *WindowParquetWriter) Close() error {
+	return pw.w.Close()
+}
+
+// WriteWindowsParquet writes the windows of results to out as a Parquet file, numbering the
+// runs from 0 in the order given. When the results share a known provenance (e.g., there is
+// only one), its metadata is added to the file's. See WindowParquetWriter for writing results
+// as they complete.
+func WriteWindowsParquet(out io.Writer, options ParquetOptions, results ...*Result) error {
+	if len(results) > 0 && !results[0].Provenance.IsZero() && !slices.ContainsFunc(results, func(r *Result) bool { return r.Provenance != results[0].Provenance }) {
+		metadata := maps.Clone(options.Metadata)
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+		for k, v := range results[0].Provenance.Metadata() {
+			if _, ok := metadata[k]; !ok {
+				metadata[k] = v
+			}
+		}
+		options.Metadata = metadata
+	}
+	pw, err := NewWindowParquetWriter(out, options)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if err := pw.WriteResult(int64(i), result); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
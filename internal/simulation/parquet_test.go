@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestWindowParquetWriter(t *testing.T) {
+	ap := capacityTableAirport()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(start, start.AddDate(0, 0, 2)).
+		AddCurfewPolicy(start.Add(23*time.Hour), start.Add(30*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	pw, err := NewWindowParquetWriter(&buf, ParquetOptions{RowGroupRows: 2, Gzip: true})
+	if err != nil {
+		t.Fatalf("NewWindowParquetWriter failed: %v", err)
+	}
+	for run := range 3 {
+		if err := pw.WriteResult(int64(run), result); err != nil {
+			t.Fatalf("WriteResult failed: %v", err)
+		}
+	}
+	if want := int64(3 * len(result.Windows)); pw.Rows() != want {
+		t.Errorf("Expected %d rows, got %d", want, pw.Rows())
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatal("Expected a Parquet file")
+	}
+	footer := data[len(data)-8-int(binary.LittleEndian.Uint32(data[len(data)-8:])) : len(data)-8]
+	for _, name := range []string{WindowSchemaVersionKey, "capacity", "ga_movements"} {
+		if !bytes.Contains(footer, []byte(name)) {
+			t.Errorf("Expected %q in the file footer", name)
+		}
+	}
+}
+
+func TestWriteWindowsParquet(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &Result{StartTime: start, EndTime: start.Add(time.Hour), Windows: []WindowResult{{Start: start, End: start.Add(time.Hour), Capacity: 40}}}
+
+	var buf bytes.Buffer
+	if err := WriteWindowsParquet(&buf, ParquetOptions{}, result, result); err != nil {
+		t.Fatalf("WriteWindowsParquet failed: %v", err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("PAR1")) {
+		t.Error("Expected a complete Parquet file")
+	}
+}
@@ -0,0 +1,332 @@
+package simulation
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// timelinePartition is one calendar month of a timeline processed on its own world.
+type timelinePartition struct {
+	start, end time.Time
+	events     []event.Event // Events in [start, end), or [start, end] for the last partition
+	world      *World        // World the partition was processed on (a fork of the initial state)
+	result     *Result
+	eventCount int
+	err        error
+}
+
+// processPartitioned processes the timeline in calendar month partitions on up to
+// e.parallelism goroutines (see WithParallelism) and merges them into one Result.
+func (e *Engine) processPartitioned(ctx context.Context, world *World) (*Result, error) {
+	result := &Result{
+		StartTime: world.StartTime,
+		EndTime:   world.EndTime,
+		Windows:   make([]WindowResult, 0, world.Events.Len()+1),
+	}
+
+	// Settle the state set up at the start time (e.g., gate or taxi configuration events), so
+	// every partition starts from the state the first month actually starts in
+	eventCount, err := e.processWindows(ctx, world, result, world.StartTime, world.StartTime, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// State set up at the start time (e.g., a noise quota) may carry across every month
+	if !world.partitionable() {
+		n, err := e.processWindows(ctx, world, result, world.StartTime, world.EndTime, false, false)
+		if err != nil {
+			return nil, err
+		}
+		e.skipEventsAfterEnd(ctx, world, result)
+		e.reportProgress(eventCount+n, eventCount+n, world.EndTime)
+		result.ConfigurationHistory = world.ConfigurationHistory()
+		result.EventsProcessed = eventCount + n
+		return result, nil
+	}
+
+	partitions, skipped := partitionTimeline(world)
+	result.EventsSkipped += skipped
+	reference := world.fork(world.StartTime, world.EndTime)
+
+	// Process every partition speculatively from the settled state
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, e.parallelism)
+	for _, p := range partitions {
+		wg.Add(1)
+		go func(p *timelinePartition) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			p.world = world.fork(p.start, p.end)
+			for _, evt := range p.events {
+				p.world.ScheduleEvent(evt)
+			}
+			p.result = &Result{Windows: make([]WindowResult, 0, len(p.events)+1)}
+			p.eventCount, p.err = e.processWindows(ctx, p.world, p.result, p.start, p.end, true, false)
+		}(p)
+	}
+	wg.Wait()
+
+	// Merge in order, reprocessing any partition whose predecessor ended in a different state
+	scratch := newWindowScratch(len(world.Airport.Runways))
+	var histories [][]ConfigurationChange
+	current := world
+	for _, p := range partitions {
+		if p.err != nil {
+			return nil, p.err
+		}
+
+		// Rejoin the window the partition boundary split, from the state at the boundary
+		joined, rejoin := e.joinBoundaryWindow(ctx, current, scratch, result, p)
+
+		windows := p.result.Windows
+		if current.sameState(reference, p.start) {
+			histories = append(histories, current.ConfigurationHistory())
+			current = p.world
+			eventCount += p.eventCount
+		} else {
+			e.log(ctx).DebugContext(ctx, "State carries across partition boundary; processing sequentially",
+				"partitionStart", p.start)
+
+			for _, evt := range p.events {
+				current.ScheduleEvent(evt)
+			}
+			current.EndTime = p.end
+			sequential := &Result{Windows: make([]WindowResult, 0, len(p.events)+1)}
+			n, err := e.processWindows(ctx, current, sequential, p.start, p.end, false, false)
+			if err != nil {
+				return nil, err
+			}
+			windows = sequential.Windows
+			eventCount += n
+		}
+
+		if rejoin {
+			result.Windows[len(result.Windows)-1] = joined
+			windows = windows[1:]
+		}
+		result.Windows = append(result.Windows, windows...)
+
+		e.reportProgress(eventCount, eventCount, p.end)
+	}
+
+	// Total window by window so it rounds exactly as a sequential run's would
+	result.TotalCapacity = 0
+	for _, window := range result.Windows {
+		result.TotalCapacity += window.Capacity
+	}
+
+	e.skipEventsAfterEnd(ctx, current, result)
+
+	histories = append(histories, current.ConfigurationHistory())
+	result.ConfigurationHistory = mergeConfigurationHistories(histories)
+	result.EventsProcessed = eventCount
+
+	e.log(ctx).InfoContext(ctx, "Timeline processing complete",
+		"eventsProcessed", eventCount,
+		"partitions", len(partitions),
+		"totalCapacity", result.TotalCapacity)
+
+	return result, nil
+}
+
+// joinBoundaryWindow returns the window a sequential run records across the start of p when
+// no event falls on the boundary: the last window merged so far, extended to the first event
+// after the boundary. Its capacity is calculated over the whole span from world, whose state
+// at the boundary holds throughout. Returns false if an event falls on the boundary.
+func (e *Engine) joinBoundaryWindow(ctx context.Context, world *World, scratch *windowScratch, result *Result, p *timelinePartition) (WindowResult, bool) {
+	n := len(result.Windows)
+	if n == 0 || !result.Windows[n-1].End.Equal(p.start) {
+		return WindowResult{}, false
+	}
+
+	end := p.end
+	if len(p.events) > 0 {
+		end = p.events[0].Time()
+	}
+	if world.Events.HasNext() {
+		end = minTime(end, world.Events.Peek().Time())
+	}
+	if !end.After(p.start) {
+		return WindowResult{}, false
+	}
+
+	window := result.Windows[n-1]
+	window.End = end
+	window.Capacity = e.calculateWindowCapacity(ctx, world, scratch, window.Start, end.Sub(window.Start))
+	window.RunwayMovements = activeRunwayMovements(window.ActiveRunways, scratch.runwayMovements)
+	window.RunwayGAMovements = activeRunwayGAMovements(window.ActiveRunways, scratch.gaMovements)
+	window.GAMovements = totalMovements(scratch.gaMovements)
+	window.HelipadMovements = world.helipadMovements(end.Sub(window.Start))
+	return window, true
+}
+
+// partitionTimeline drains the world's event queue into one partition per calendar month
+// (or part month) of the simulation period. Events outside the period are dropped and
+// counted.
+func partitionTimeline(world *World) ([]*timelinePartition, int) {
+	var partitions []*timelinePartition
+	for start := world.StartTime; start.Before(world.EndTime); {
+		monthStart := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+		end := minTime(monthStart.AddDate(0, 1, 0), world.EndTime)
+		partitions = append(partitions, &timelinePartition{start: start, end: end})
+		start = end
+	}
+
+	i, skipped := 0, 0
+	for world.Events.HasNext() {
+		evt := world.Events.Pop()
+		if evt.Time().Before(world.StartTime) || evt.Time().After(world.EndTime) {
+			skipped++
+			continue
+		}
+		for i < len(partitions)-1 && !evt.Time().Before(partitions[i].end) {
+			i++
+		}
+		partitions[i].events = append(partitions[i].events, evt)
+	}
+	return partitions, skipped
+}
+
+// mergeConfigurationHistories concatenates the configuration histories of consecutive
+// partitions, dropping records that repeat the configuration already in effect.
+func mergeConfigurationHistories(histories [][]ConfigurationChange) []ConfigurationChange {
+	var merged []ConfigurationChange
+	for _, history := range histories {
+		for _, change := range history {
+			if n := len(merged); n > 0 && merged[n-1].Label == change.Label {
+				continue
+			}
+			merged = append(merged, change)
+		}
+	}
+	return merged
+}
+
+// partitionable reports whether the world's timeline can be split into independently
+// processed partitions. An annual noise quota carries usage across every month, as do a night
+// movement quota, runway wear checks and the aircraft on gates.
+func (w *World) partitionable() bool {
+	return len(w.NoiseQuotaWeights) == 0 && w.NightQuotaMovements == 0 && w.wearBaselines == nil && w.gateOccupancy == nil
+}
+
+// fork returns a new world over [start, end) in the same operational state as w, with an
+// empty event queue and no configuration history before start.
+func (w *World) fork(start, end time.Time) *World {
+	f := NewWorld(w.Airport, start, end)
+
+	for runwayID, state := range w.RunwayStates {
+		f.RunwayStates[runwayID] = &RunwayState{Runway: state.Runway, Available: state.Available, Closures: maps.Clone(state.Closures)}
+	}
+	f.CurfewActive = w.CurfewActive
+	f.CurfewRestrictions = slices.Clone(w.CurfewRestrictions)
+	f.Disruptions = slices.Clone(w.Disruptions)
+	f.GAReservations = slices.Clone(w.GAReservations)
+	f.SeparationAdjustments = slices.Clone(w.SeparationAdjustments)
+	f.WindSpeed = w.WindSpeed
+	f.WindDirection = w.WindDirection
+	f.WindGust = w.WindGust
+	f.TemperatureCelsius = w.TemperatureCelsius
+	f.TemperatureKnown = w.TemperatureKnown
+
+	f.RotationMultiplier = w.RotationMultiplier
+	f.RunwayRotationMultipliers = w.RunwayRotationMultipliers
+	f.OpenGates = w.OpenGates
+	f.GateTurnaround = w.GateTurnaround
+	if w.gateOccupancy != nil {
+		f.gateOccupancy = w.gateOccupancy.clone()
+	}
+	f.DeclaredCapacity = w.DeclaredCapacity
+	f.DeicingConstraint = w.DeicingConstraint
+	f.DeicingThresholdCelsius = w.DeicingThresholdCelsius
+	f.TaxiTimeOverhead = w.TaxiTimeOverhead
+	f.LandingPerformance = w.LandingPerformance
+	f.TailwindDistanceFactorPerKnot = w.TailwindDistanceFactorPerKnot
+	f.DirectionChangeoverPenalty = w.DirectionChangeoverPenalty
+	f.GustFactorThresholdKnots = w.GustFactorThresholdKnots
+	f.GustFactorSeparation = w.GustFactorSeparation
+	for runwayID, done := range w.directionChangeovers {
+		f.directionChangeovers[runwayID] = done
+	}
+
+	f.RunwayManager = w.RunwayManager.clone()
+	w.activeConfigMu.RLock()
+	f.ActiveRunwayConfiguration = w.ActiveRunwayConfiguration
+	w.activeConfigMu.RUnlock()
+	f.summarizeActiveConfiguration()
+	f.configurationHistory = nil
+	f.recordConfigurationChange("")
+
+	return f
+}
+
+// sameState reports whether processing can continue from w at time t exactly as it would
+// from other: the operational state matches, no changeover is still in progress and no event
+// is waiting to be applied. Compatibility graphs are replaced whenever they change, so they
+// are compared by identity.
+func (w *World) sameState(other *World, t time.Time) bool {
+	if w.Events.Len() > 0 {
+		return false
+	}
+	for _, done := range w.directionChangeovers {
+		if done.After(t) {
+			return false
+		}
+	}
+
+	if w.CurfewActive != other.CurfewActive ||
+		!slices.Equal(w.CurfewRestrictions, other.CurfewRestrictions) ||
+		!slices.Equal(w.Disruptions, other.Disruptions) ||
+		!slices.Equal(w.GAReservations, other.GAReservations) ||
+		!slices.Equal(w.SeparationAdjustments, other.SeparationAdjustments) ||
+		w.WindSpeed != other.WindSpeed ||
+		w.WindDirection != other.WindDirection ||
+		w.WindGust != other.WindGust ||
+		w.TemperatureCelsius != other.TemperatureCelsius ||
+		w.TemperatureKnown != other.TemperatureKnown ||
+		w.RotationMultiplier != other.RotationMultiplier ||
+		!maps.Equal(w.RunwayRotationMultipliers, other.RunwayRotationMultipliers) ||
+		w.OpenGates != other.OpenGates ||
+		w.GateTurnaround != other.GateTurnaround ||
+		w.DeclaredCapacity != other.DeclaredCapacity ||
+		w.DeicingConstraint != other.DeicingConstraint ||
+		w.DeicingThresholdCelsius != other.DeicingThresholdCelsius ||
+		w.TaxiTimeOverhead != other.TaxiTimeOverhead ||
+		!slices.Equal(w.LandingPerformance, other.LandingPerformance) ||
+		w.TailwindDistanceFactorPerKnot != other.TailwindDistanceFactorPerKnot ||
+		w.DirectionChangeoverPenalty != other.DirectionChangeoverPenalty ||
+		w.GustFactorThresholdKnots != other.GustFactorThresholdKnots ||
+		w.GustFactorSeparation != other.GustFactorSeparation ||
+		w.Airport.RunwayCompatibility != other.Airport.RunwayCompatibility ||
+		!slices.Equal(w.RunwayManager.compatibilityHoursInEffect(), other.RunwayManager.compatibilityHoursInEffect()) ||
+		!slices.EqualFunc(w.RunwayManager.NoisePreferences(), other.RunwayManager.NoisePreferences(), slices.Equal[[]string]) ||
+		!w.RunwayManager.sameHysteresis(other.RunwayManager, t) {
+		return false
+	}
+
+	if len(w.RunwayStates) != len(other.RunwayStates) {
+		return false
+	}
+	for runwayID, state := range w.RunwayStates {
+		if otherState, ok := other.RunwayStates[runwayID]; !ok || state.Runway != otherState.Runway || state.Available != otherState.Available || !maps.Equal(state.Closures, otherState.Closures) {
+			return false
+		}
+	}
+
+	config, otherConfig := w.activeConfiguration(), other.activeConfiguration()
+	if len(config) != len(otherConfig) {
+		return false
+	}
+	for runwayID, info := range config {
+		if otherInfo, ok := otherConfig[runwayID]; !ok || *info != *otherInfo {
+			return false
+		}
+	}
+	return true
+}
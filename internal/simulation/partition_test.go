@@ -0,0 +1,185 @@
+package simulation
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// assertSameResult fails the test unless parallel matches the sequential result window for window.
+func assertSameResult(t *testing.T, sequential, parallel *Result) {
+	t.Helper()
+
+	if parallel.TotalCapacity != sequential.TotalCapacity {
+		t.Errorf("Expected total %.2f, got %.2f", sequential.TotalCapacity, parallel.TotalCapacity)
+	}
+	if parallel.EventsProcessed != sequential.EventsProcessed {
+		t.Errorf("Expected %d events processed, got %d", sequential.EventsProcessed, parallel.EventsProcessed)
+	}
+	if parallel.EventsSkipped != sequential.EventsSkipped {
+		t.Errorf("Expected %d events skipped, got %d", sequential.EventsSkipped, parallel.EventsSkipped)
+	}
+	if len(parallel.Windows) != len(sequential.Windows) {
+		t.Fatalf("Expected %d windows, got %d", len(sequential.Windows), len(parallel.Windows))
+	}
+	for i, want := range sequential.Windows {
+		got := parallel.Windows[i]
+		if !got.Start.Equal(want.Start) || !got.End.Equal(want.End) || got.Capacity != want.Capacity || got.Configuration != want.Configuration {
+			t.Fatalf("Window %d: expected %v-%v %.2f %q, got %v-%v %.2f %q", i,
+				want.Start, want.End, want.Capacity, want.Configuration,
+				got.Start, got.End, got.Capacity, got.Configuration)
+		}
+		if !slices.Equal(got.RunwayMovements, want.RunwayMovements) {
+			t.Fatalf("Window %d: expected runway movements %v, got %v", i, want.RunwayMovements, got.RunwayMovements)
+		}
+	}
+	if len(parallel.ConfigurationHistory) != len(sequential.ConfigurationHistory) {
+		t.Fatalf("Expected %d configuration changes, got %d", len(sequential.ConfigurationHistory), len(parallel.ConfigurationHistory))
+	}
+	for i, want := range sequential.ConfigurationHistory {
+		if got := parallel.ConfigurationHistory[i]; !got.Time.Equal(want.Time) || got.Label != want.Label {
+			t.Errorf("Change %d: expected %s at %v, got %s at %v", i, want.Label, want.Time, got.Label, got.Time)
+		}
+	}
+}
+
+func TestEngine_ParallelMatchesSequential(t *testing.T) {
+	start, _ := simulationPeriod()
+
+	// Independent months: the curfew ends before midnight and the wind never changes
+	independent := func() *Simulation {
+		sim, err := NewSimulation(createSixRunwayAirport(), testEngineLogger()).
+			AddCurfewPolicy(start.Add(20*time.Hour), start.Add(23*time.Hour))
+		if err != nil {
+			t.Fatalf("AddCurfewPolicy failed: %v", err)
+		}
+		if sim, err = sim.AddWindPolicy(12, 270); err != nil {
+			t.Fatalf("AddWindPolicy failed: %v", err)
+		}
+		return sim
+	}
+
+	tests := []struct {
+		name string
+		sim  func() *Simulation
+	}{
+		{"independent months", independent},
+		{"state spanning month boundaries", func() *Simulation { return newYearSimulation(t) }},
+		{"configuration hysteresis", func() *Simulation { return newOscillatingWindSimulation(t) }},
+		{"noise-preferential bands", func() *Simulation { return newNoisePreferentialSimulation(t) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequential, err := tt.sim().WithSeed(7).RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("Sequential run failed: %v", err)
+			}
+			parallel, err := tt.sim().WithSeed(7).WithParallelism(4).RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("Parallel run failed: %v", err)
+			}
+			assertSameResult(t, sequential, parallel)
+		})
+	}
+}
+
+// newOscillatingWindSimulation returns a simulation whose wind swings between two directions
+// every 40 minutes all year, with hysteresis holding configurations across month boundaries.
+func newOscillatingWindSimulation(t *testing.T) *Simulation {
+	t.Helper()
+	start, end := simulationPeriod()
+
+	var changes []WindChange
+	for i, ts := 0, start; ts.Before(end); i, ts = i+1, ts.Add(40*time.Minute) {
+		changes = append(changes, WindChange{Timestamp: ts, SpeedKnots: 8, DirectionTrue: float64(60 + 180*(i%2))})
+	}
+	sim, err := NewSimulation(createSixRunwayAirport(), testEngineLogger()).AddScheduledWindPolicy(changes)
+	if err != nil {
+		t.Fatalf("AddScheduledWindPolicy failed: %v", err)
+	}
+	return sim.WithRunwayManagerOptions(RunwayManagerOptions{MinimumDwell: 3 * time.Hour, WindDeadbandKnots: 5})
+}
+
+// newNoisePreferentialSimulation returns a simulation preferring 27R on Monday mornings, with
+// an easterly wind ruling it out until the wind drops early on the first day of February.
+func newNoisePreferentialSimulation(t *testing.T) *Simulation {
+	t.Helper()
+	start, _ := simulationPeriod()
+
+	ap := airport.Airport{Name: "Test", Runways: []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 90 * time.Second, TailwindLimitKnots: 10},
+		{RunwayDesignation: "09R", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 90 * time.Second, TailwindLimitKnots: 10},
+	}}
+	sim, err := NewSimulation(ap, testEngineLogger()).AddNoisePreferentialPolicy([]NoisePreferentialBand{
+		{Days: []time.Weekday{time.Monday}, From: 0, Until: 12 * time.Hour, Preferences: [][]string{{"27R"}}},
+	})
+	if err != nil {
+		t.Fatalf("AddNoisePreferentialPolicy failed: %v", err)
+	}
+	sim, err = sim.AddScheduledWindPolicy([]WindChange{
+		{Timestamp: start, SpeedKnots: 15, DirectionTrue: 90},
+		{Timestamp: time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC), SpeedKnots: 2, DirectionTrue: 90},
+	})
+	if err != nil {
+		t.Fatalf("AddScheduledWindPolicy failed: %v", err)
+	}
+	return sim
+}
+
+func TestWorld_SameState(t *testing.T) {
+	start, end := simulationPeriod()
+	world := NewWorld(createSixRunwayAirport(), start, end)
+	boundary := start.AddDate(0, 1, 0)
+
+	fork := world.fork(boundary, end)
+	if !fork.sameState(world, boundary) {
+		t.Error("Expected a fork to be in the same state as its origin")
+	}
+
+	if err := fork.SetWind(20, 180); err != nil {
+		t.Fatalf("SetWind failed: %v", err)
+	}
+	if fork.sameState(world, boundary) {
+		t.Error("Expected a wind change to carry state across the boundary")
+	}
+}
+
+func TestSimulation_CompatibilityHours(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: &airport.RunwayCompatibility{
+			CompatibleWith: map[string][]string{"09": {"18"}, "18": {"09"}},
+			Hours:          []airport.CompatibilityHours{{Runway: "09", OtherRunway: "18", From: 6 * time.Hour, Until: 20 * time.Hour}},
+		},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	// Both runways for 14 hours, one for the other 10
+	if want := float32(14*120 + 10*60); result.TotalCapacity != want {
+		t.Errorf("Expected %.0f movements, got %.1f", want, result.TotalCapacity)
+	}
+
+	// Starting at noon, within the hours, while every month starts outside them
+	sim := NewSimulation(ap, testEngineLogger()).WithPeriod(start.Add(12*time.Hour), start.AddDate(0, 3, 0))
+	sequential, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Sequential run failed: %v", err)
+	}
+	parallel, err := sim.WithParallelism(4).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Parallel run failed: %v", err)
+	}
+	assertSameResult(t, sequential, parallel)
+}
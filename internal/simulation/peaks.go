@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DefaultPeakPeriods are the rolling period lengths used by Result.Peaks and Result.DailyPeaks
+// when none are given: the busiest hour, half hour and quarter hour.
+var DefaultPeakPeriods = []time.Duration{time.Hour, 30 * time.Minute, 15 * time.Minute}
+
+// peakTolerance is the difference in movements below which rolling periods are considered
+// equally busy, so rounding in the cumulative sums over a long run does not decide ties.
+const peakTolerance = 1e-6
+
+// RollingPeak is the busiest rolling period of one length: the period, starting at any
+// instant, with the most movements.
+type RollingPeak struct {
+	Period    time.Duration // Rolling period length
+	Start     time.Time     // Start of the busiest period (the earliest if several are equally busy)
+	Movements float64       // Movements in the busiest period
+}
+
+// DailyPeaks are the busiest rolling periods starting on one calendar day.
+type DailyPeaks struct {
+	Date  time.Time     // Midnight at the start of the day (in the simulation's location)
+	Peaks []RollingPeak // Busiest period of each length, in the order the lengths were given
+}
+
+// DailyPeaks returns the busiest rolling periods of each length (default: DefaultPeakPeriods)
+// starting on each day of the run, for peak-hour planning. Movements are assumed to be spread
+// evenly within each window, and the maximum is exact rather than sampled: the movements in a
+// rolling period change linearly between the points where its start or end crosses a window
+// boundary, so the busiest period starts at one of them.
+//
+// Rolling periods lie entirely within the simulated period, so a period may end on the next
+// day, and a day too close to the end of the run for a period to start on it has a peak with
+// a zero Start. Returns an error if a period length is not positive or is longer than the run.
+func (r *Result) DailyPeaks(periods ...time.Duration) ([]DailyPeaks, error) {
+	periods, err := r.peakPeriods(periods)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := r.StartTime.Location()
+	var days []DailyPeaks
+	for day := startOfDay(r.StartTime); day.Before(r.EndTime); day = day.AddDate(0, 0, 1) {
+		peaks := make([]RollingPeak, len(periods))
+		for i, period := range periods {
+			peaks[i].Period = period
+		}
+		days = append(days, DailyPeaks{Date: day, Peaks: peaks})
+	}
+	dayIndex := func(t time.Time) int {
+		t = t.In(loc)
+		i, _ := slices.BinarySearchFunc(days, t, func(d DailyPeaks, t time.Time) int {
+			return d.Date.Compare(startOfDay(t))
+		})
+		return i
+	}
+
+	cumulative := r.cumulativeCapacity()
+	for p, period := range periods {
+		for _, start := range r.rollingPeakCandidates(period, days) {
+			movements := cumulative(start.Add(period)) - cumulative(start)
+			peak := &days[dayIndex(start)].Peaks[p]
+			if peak.Start.IsZero() || movements > peak.Movements+peakTolerance {
+				*peak = RollingPeak{Period: period, Start: start, Movements: movements}
+			}
+		}
+	}
+	return days, nil
+}
+
+// Peaks returns the busiest rolling period of each length (default: DefaultPeakPeriods) over
+// the whole run. See DailyPeaks.
+func (r *Result) Peaks(periods ...time.Duration) ([]RollingPeak, error) {
+	days, err := r.DailyPeaks(periods...)
+	if err != nil {
+		return nil, err
+	}
+
+	var peaks []RollingPeak
+	for _, day := range days {
+		if peaks == nil {
+			peaks = slices.Clone(day.Peaks)
+			continue
+		}
+		for i, peak := range day.Peaks {
+			if !peak.Start.IsZero() && (peaks[i].Start.IsZero() || peak.Movements > peaks[i].Movements+peakTolerance) {
+				peaks[i] = peak
+			}
+		}
+	}
+	return peaks, nil
+}
+
+// peakPeriods returns the rolling period lengths to use, checking they fit in the run.
+func (r *Result) peakPeriods(periods []time.Duration) ([]time.Duration, error) {
+	if len(periods) == 0 {
+		periods = DefaultPeakPeriods
+	}
+	for _, period := range periods {
+		if period <= 0 {
+			return nil, simerrors.Invalidf("rolling period must be positive, got %v", period)
+		}
+		if r.StartTime.Add(period).After(r.EndTime) {
+			return nil, simerrors.Invalidf("rolling period %v is longer than the simulated period %v to %v", period, r.StartTime, r.EndTime)
+		}
+	}
+	return periods, nil
+}
+
+// rollingPeakCandidates returns the start times, in chronological order, at which the busiest
+// rolling period of the given length on each day can start: the start of each day, and the
+// times at which the period's start or end meets a window boundary. Only periods within the
+// run are included.
+func (r *Result) rollingPeakCandidates(period time.Duration, days []DailyPeaks) []time.Time {
+	last := r.EndTime.Add(-period)
+	candidates := make([]time.Time, 0, 2*len(r.Windows)+len(days)+1)
+	add := func(t time.Time) {
+		if !t.Before(r.StartTime) && !t.After(last) {
+			candidates = append(candidates, t)
+		}
+	}
+
+	add(r.StartTime)
+	add(last)
+	for _, day := range days {
+		add(day.Date)
+	}
+	for _, w := range r.Windows {
+		add(w.Start)
+		add(w.Start.Add(-period))
+	}
+	slices.SortFunc(candidates, time.Time.Compare)
+	return slices.CompactFunc(candidates, time.Time.Equal)
+}
@@ -0,0 +1,110 @@
+package simulation
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// peakWindow is a window length and the movements per hour within it.
+type peakWindow struct {
+	length  time.Duration
+	perHour float64
+}
+
+// peakResult returns a result with consecutive windows from start.
+func peakResult(start time.Time, windows ...peakWindow) *Result {
+	r := &Result{StartTime: start}
+	t := start
+	for _, w := range windows {
+		capacity := float32(w.perHour * w.length.Hours())
+		r.Windows = append(r.Windows, WindowResult{Start: t, End: t.Add(w.length), Capacity: capacity})
+		r.TotalCapacity += capacity
+		t = t.Add(w.length)
+	}
+	r.EndTime = t
+	return r
+}
+
+func TestResult_Peaks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 40 an hour, then a 20 minute burst at 150 an hour, then closed, then 90 an hour
+	result := peakResult(start,
+		peakWindow{2 * time.Hour, 40},
+		peakWindow{20 * time.Minute, 150},
+		peakWindow{40 * time.Minute, 0},
+		peakWindow{3 * time.Hour, 90},
+	)
+
+	peaks, err := result.Peaks()
+	if err != nil {
+		t.Fatalf("Peaks failed: %v", err)
+	}
+	want := []RollingPeak{
+		{Period: time.Hour, Start: start.Add(3 * time.Hour), Movements: 90},                     // A full hour at 90 an hour beats 40 minutes at 40 and the burst
+		{Period: 30 * time.Minute, Start: start.Add(110 * time.Minute), Movements: 50 + 40.0/6}, // 10 minutes at 40 an hour and the whole burst
+		{Period: 15 * time.Minute, Start: start.Add(2 * time.Hour), Movements: 37.5},            // Inside the burst
+	}
+	for i, w := range want {
+		got := peaks[i]
+		if got.Period != w.Period || !got.Start.Equal(w.Start) || math.Abs(got.Movements-w.Movements) > 1e-4 {
+			t.Errorf("Expected peak %v at %v with %v movements, got %v at %v with %v",
+				w.Period, w.Start.Format("15:04"), w.Movements, got.Period, got.Start.Format("15:04"), got.Movements)
+		}
+	}
+}
+
+func TestResult_DailyPeaks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 30 an hour on the first day; 60 an hour from 23:30 to 00:30; 45 an hour on the second day
+	result := peakResult(start,
+		peakWindow{23*time.Hour + 30*time.Minute, 30},
+		peakWindow{time.Hour, 60},
+		peakWindow{23*time.Hour + 30*time.Minute, 45},
+	)
+
+	days, err := result.DailyPeaks(time.Hour)
+	if err != nil {
+		t.Fatalf("DailyPeaks failed: %v", err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(days))
+	}
+	// The busiest hour of the first day runs past midnight
+	if peak := days[0].Peaks[0]; !peak.Start.Equal(start.Add(23*time.Hour+30*time.Minute)) || peak.Movements != 60 {
+		t.Errorf("Expected the first day's peak hour to start at 23:30 with 60 movements, got %v with %v", peak.Start, peak.Movements)
+	}
+	if peak := days[1].Peaks[0]; !days[1].Date.Equal(start.AddDate(0, 0, 1)) || !peak.Start.Equal(days[1].Date) || math.Abs(peak.Movements-52.5) > 1e-4 {
+		t.Errorf("Expected the second day's peak hour at midnight with 52.5 movements, got %v with %v", peak.Start, peak.Movements)
+	}
+}
+
+func TestResult_PeaksErrors(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := peakResult(start, peakWindow{time.Hour, 60})
+
+	for _, period := range []time.Duration{0, -time.Minute, 2 * time.Hour} {
+		if _, err := result.Peaks(period); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("Period %v: expected ErrInvalidConfiguration, got %v", period, err)
+		}
+	}
+}
+
+func TestResult_PeaksEarliestOfEqual(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := make([]peakWindow, 24*365)
+	for i := range windows {
+		windows[i] = peakWindow{time.Hour, 188.3}
+	}
+
+	peaks, err := peakResult(start, windows...).Peaks(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !peaks[0].Start.Equal(start) {
+		t.Errorf("Expected the earliest of equally busy hours, got %v", peaks[0].Start)
+	}
+}
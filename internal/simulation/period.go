@@ -0,0 +1,81 @@
+package simulation
+
+import "time"
+
+// Period is a span of simulated time. It normalizes capacity totals to rates that do not
+// depend on the length of the simulation, so runs over leap years, partial years or several
+// years can be compared with each other.
+type Period struct {
+	Start time.Time // Period start (inclusive)
+	End   time.Time // Period end (exclusive)
+}
+
+// Duration returns the length of the period.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Days returns the length of the period in 24-hour days (366 for a leap year).
+func (p Period) Days() float64 {
+	return p.Duration().Hours() / HoursPerDay
+}
+
+// Years returns the length of the period in calendar years. Each calendar year the period
+// overlaps counts in proportion to its own length, so a leap year and a common year are both
+// exactly one year, and the six months to July 1st are 182/366 of a year in 2024.
+func (p Period) Years() float64 {
+	years := 0.0
+	for year := p.Start.Year(); year <= p.End.Year(); year++ {
+		yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, p.Start.Location())
+		yearEnd := yearStart.AddDate(1, 0, 0)
+
+		from, to := yearStart, yearEnd
+		if p.Start.After(from) {
+			from = p.Start
+		}
+		if p.End.Before(to) {
+			to = p.End
+		}
+		if to.After(from) {
+			years += float64(to.Sub(from)) / float64(yearEnd.Sub(yearStart))
+		}
+	}
+	return years
+}
+
+// PerDay returns the average number of movements per day for a total over the period
+// (0 for an empty period).
+func (p Period) PerDay(movements float32) float64 {
+	return rate(movements, p.Days())
+}
+
+// PerHour returns the average number of movements per hour for a total over the period
+// (0 for an empty period).
+func (p Period) PerHour(movements float32) float64 {
+	return rate(movements, p.Duration().Hours())
+}
+
+// Annualized returns the number of movements per calendar year for a total over the period
+// (0 for an empty period). For a period of exactly one calendar year it is the total itself.
+func (p Period) Annualized(movements float32) float64 {
+	return rate(movements, p.Years())
+}
+
+// rate divides movements by a length, returning 0 when the length is not positive.
+func rate(movements float32, length float64) float64 {
+	if length <= 0 {
+		return 0
+	}
+	return float64(movements) / length
+}
+
+// Period returns the simulated period of the result.
+func (r *Result) Period() Period {
+	return Period{Start: r.StartTime, End: r.EndTime}
+}
+
+// Period returns the period the simulation covers (see WithPeriod).
+func (s *Simulation) Period() Period {
+	start, end := s.period()
+	return Period{Start: start, End: end}
+}
@@ -0,0 +1,85 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+func TestPeriod(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		days  float64
+		years float64
+	}{
+		{"leap year", date(2024, 1, 1), date(2025, 1, 1), 366, 1},
+		{"common year", date(2023, 1, 1), date(2024, 1, 1), 365, 1},
+		{"first half of leap year", date(2024, 1, 1), date(2024, 7, 1), 182, 182.0 / 366},
+		{"year across a leap day", date(2023, 7, 1), date(2024, 7, 1), 366, 184.0/365 + 182.0/366},
+		{"two years", date(2023, 1, 1), date(2025, 1, 1), 731, 2},
+		{"empty", date(2024, 1, 1), date(2024, 1, 1), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Period{Start: tt.start, End: tt.end}
+			if got := p.Days(); got != tt.days {
+				t.Errorf("Expected %v days, got %v", tt.days, got)
+			}
+			if got := p.Years(); math.Abs(got-tt.years) > 1e-9 {
+				t.Errorf("Expected %v years, got %v", tt.years, got)
+			}
+		})
+	}
+}
+
+func TestPeriod_Rates(t *testing.T) {
+	leapYear := Period{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if got := leapYear.PerDay(366000); got != 1000 {
+		t.Errorf("Expected 1000 movements per day, got %v", got)
+	}
+	if got := leapYear.PerHour(366000 * 24); got != 1000 {
+		t.Errorf("Expected 1000 movements per hour, got %v", got)
+	}
+	if got := leapYear.Annualized(366000); got != 366000 {
+		t.Errorf("Expected a full year to annualize to its total, got %v", got)
+	}
+
+	january := Period{Start: leapYear.Start, End: leapYear.Start.AddDate(0, 1, 0)}
+	if got := january.Annualized(31000); math.Abs(got-366000) > 1e-6 {
+		t.Errorf("Expected 1000 a day to annualize to 366000 in 2024, got %v", got)
+	}
+
+	var empty Period
+	if empty.PerDay(100) != 0 || empty.PerHour(100) != 0 || empty.Annualized(100) != 0 {
+		t.Error("Expected zero rates for an empty period")
+	}
+}
+
+func TestResult_Period(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulation(airport.Airport{Name: "Test", Runways: createTestRunways()}, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 10))
+
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if got := result.Period(); got != sim.Period() || got.Days() != 10 {
+		t.Errorf("Expected the simulated 10 days, got %v to %v", got.Start, got.End)
+	}
+	if perDay, daily := result.Period().PerDay(result.TotalCapacity), result.DailyCapacity(); math.Abs(perDay-float64(daily[0].Capacity)) > 1 {
+		t.Errorf("Expected the daily rate of an unconstrained run to match each day, got %v and %v", perDay, daily[0].Capacity)
+	}
+}
@@ -0,0 +1,337 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// ValidatingPlugin is implemented by pre-simulation plugins that can check they apply to the
+// airport they receive (e.g., that a runway to remove exists). Validate receives the airport
+// as modified by the plugins added before it and returns all problems found, or nil.
+type ValidatingPlugin interface {
+	Validate(ap airport.Airport) error
+}
+
+// applyPlugins returns the airport with every pre-simulation plugin applied, in the order the
+// plugins were added: each plugin receives the airport as modified by the plugins before it.
+// Policies implementing policy.AirportPolicy then prepare the airport, in policy order.
+// Plugins receive a copy, so repeated runs always start from the simulation's own airport.
+// When logger is non-nil, the changes each plugin made are logged.
+func (s *Simulation) applyPlugins(ctx context.Context, logger *slog.Logger) airport.Airport {
+	ap := copyAirport(s.airport)
+	for _, plugin := range s.preSimulationPlugins {
+		before := ap
+		ap = plugin.Apply(copyAirport(ap))
+		if logger != nil {
+			logger.InfoContext(ctx, "Applied pre-simulation plugin",
+				"plugin", pluginName(plugin),
+				"changes", airportDelta(before, ap))
+		}
+	}
+	for _, p := range s.policies {
+		if ap2, ok := p.(policy.AirportPolicy); ok {
+			before := ap
+			ap = ap2.PrepareAirport(copyAirport(ap))
+			if logger != nil {
+				logger.InfoContext(ctx, "Prepared airport for policy",
+					"policy", p.Name(),
+					"changes", airportDelta(before, ap))
+			}
+		}
+	}
+	return ap
+}
+
+// validatePlugins applies the pre-simulation plugins in order, validating each that
+// implements ValidatingPlugin against the airport it receives.
+// Returns the resulting airport and the problems found.
+func (s *Simulation) validatePlugins() (airport.Airport, []error) {
+	var problems []error
+	ap := copyAirport(s.airport)
+	for _, plugin := range s.preSimulationPlugins {
+		if vp, ok := plugin.(ValidatingPlugin); ok {
+			for _, problem := range appendProblems(nil, vp.Validate(ap)) {
+				problems = append(problems, fmt.Errorf("%s: %w", pluginName(plugin), problem))
+			}
+		}
+		ap = plugin.Apply(copyAirport(ap))
+	}
+	for _, p := range s.policies {
+		if ap2, ok := p.(policy.AirportPolicy); ok {
+			ap = ap2.PrepareAirport(copyAirport(ap))
+		}
+	}
+	return ap, problems
+}
+
+// pluginName returns the plugin's Name if it has one, or its type name.
+func pluginName(plugin PreSimulationPlugin) string {
+	if named, ok := plugin.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", plugin)
+}
+
+// copyAirport returns a copy of ap whose runway list and compatibility graph can be modified
+// without affecting ap.
+func copyAirport(ap airport.Airport) airport.Airport {
+	ap.Runways = slices.Clone(ap.Runways)
+	ap.Helipads = slices.Clone(ap.Helipads)
+	ap.RunwayCompatibility = ap.RunwayCompatibility.Clone()
+	return ap
+}
+
+// airportDelta describes how after differs from before: runways added, removed or
+// modified, and gate count changes. Returns "none" if nothing the simulation uses changed.
+func airportDelta(before, after airport.Airport) []string {
+	var changes []string
+
+	previous := make(map[string]airport.Runway, len(before.Runways))
+	for _, runway := range before.Runways {
+		previous[runway.RunwayDesignation] = runway
+	}
+	current := make(map[string]bool, len(after.Runways))
+	for _, runway := range after.Runways {
+		current[runway.RunwayDesignation] = true
+		old, ok := previous[runway.RunwayDesignation]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("added runway %s (%.0fm)", runway.RunwayDesignation, runway.LengthMeters))
+		case old.LengthMeters != runway.LengthMeters:
+			changes = append(changes, fmt.Sprintf("runway %s length %.0fm -> %.0fm", runway.RunwayDesignation, old.LengthMeters, runway.LengthMeters))
+		case old != runway:
+			changes = append(changes, fmt.Sprintf("modified runway %s", runway.RunwayDesignation))
+		}
+	}
+	for _, runway := range before.Runways {
+		if !current[runway.RunwayDesignation] {
+			changes = append(changes, fmt.Sprintf("removed runway %s", runway.RunwayDesignation))
+		}
+	}
+
+	for i, runway := range after.Runways {
+		for _, other := range after.Runways[i+1:] {
+			if !previousPair(previous, runway.RunwayDesignation, other.RunwayDesignation) {
+				continue
+			}
+			was := before.RunwayCompatibility.IsCompatible(runway.RunwayDesignation, other.RunwayDesignation)
+			if is := after.RunwayCompatibility.IsCompatible(runway.RunwayDesignation, other.RunwayDesignation); is != was {
+				change := "made incompatible"
+				if is {
+					change = "made compatible"
+				}
+				changes = append(changes, fmt.Sprintf("runways %s and %s %s", runway.RunwayDesignation, other.RunwayDesignation, change))
+			}
+		}
+	}
+
+	if before.Gates != after.Gates {
+		changes = append(changes, fmt.Sprintf("gates %d -> %d", before.Gates, after.Gates))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "none")
+	}
+	return changes
+}
+
+// previousPair reports whether both runways were in the airport before.
+func previousPair(previous map[string]airport.Runway, runway, other string) bool {
+	_, ok := previous[runway]
+	_, otherOK := previous[other]
+	return ok && otherOK
+}
+
+// hasRunway reports whether the airport has a runway with the given designation.
+func hasRunway(ap airport.Airport, designation string) bool {
+	return slices.ContainsFunc(ap.Runways, func(r airport.Runway) bool { return r.RunwayDesignation == designation })
+}
+
+// RunwayExtensionPlugin lengthens or otherwise modifies an existing runway, or adds a new one.
+type RunwayExtensionPlugin struct {
+	RunwayDesignation string                     // Runway to modify or add
+	Modification      airport.RunwayModification // Changes to apply (e.g., a longer LengthMeters)
+	NewRunway         *airport.Runway            // Runway to add when RunwayDesignation does not exist (nil requires it to exist)
+
+	// CompatibleWith lists the runways an added runway can operate with simultaneously.
+	// It is only used when the airport has a compatibility graph.
+	CompatibleWith []string
+}
+
+// Name returns the plugin name.
+func (p RunwayExtensionPlugin) Name() string {
+	return "RunwayExtensionPlugin(" + p.RunwayDesignation + ")"
+}
+
+// Validate checks that the runway exists or a new runway is given, and that the runways an
+// added runway is compatible with exist.
+func (p RunwayExtensionPlugin) Validate(ap airport.Airport) error {
+	if hasRunway(ap, p.RunwayDesignation) {
+		if p.Modification.IsEmpty() {
+			return simerrors.Invalidf("no modification given for existing runway %s", p.RunwayDesignation)
+		}
+		return nil
+	}
+	if p.NewRunway == nil {
+		return &simerrors.RunwayNotFoundError{RunwayID: p.RunwayDesignation}
+	}
+
+	var problems []error
+	if p.NewRunway.RunwayDesignation != p.RunwayDesignation {
+		problems = append(problems, simerrors.Invalidf("new runway designation %s does not match %s", p.NewRunway.RunwayDesignation, p.RunwayDesignation))
+	}
+	for _, id := range p.CompatibleWith {
+		if !hasRunway(ap, id) {
+			problems = append(problems, &simerrors.RunwayNotFoundError{RunwayID: id})
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// Apply modifies the runway, or adds it together with its compatibility.
+func (p RunwayExtensionPlugin) Apply(ap airport.Airport) airport.Airport {
+	id := p.RunwayDesignation
+	if i := slices.IndexFunc(ap.Runways, func(r airport.Runway) bool { return r.RunwayDesignation == id }); i >= 0 {
+		ap.Runways[i] = p.Modification.Apply(ap.Runways[i])
+		return ap
+	}
+	if p.NewRunway == nil {
+		return ap
+	}
+	ap.Runways = append(ap.Runways, p.Modification.Apply(*p.NewRunway))
+
+	if rc := ap.RunwayCompatibility; rc != nil && rc.CompatibleWith != nil {
+		rc.CompatibleWith[id] = slices.Clone(p.CompatibleWith)
+		for _, other := range p.CompatibleWith {
+			rc.CompatibleWith[other] = append(rc.CompatibleWith[other], id)
+		}
+	}
+	return ap
+}
+
+// RemoveRunwayPlugin removes a runway from the airport (e.g., to model a permanent closure),
+// together with its compatibility entries, airspace conflicts and compatibility hours.
+type RemoveRunwayPlugin struct {
+	RunwayDesignation string // Runway to remove
+}
+
+// Name returns the plugin name.
+func (p RemoveRunwayPlugin) Name() string {
+	return "RemoveRunwayPlugin(" + p.RunwayDesignation + ")"
+}
+
+// Validate checks that the runway exists.
+func (p RemoveRunwayPlugin) Validate(ap airport.Airport) error {
+	if !hasRunway(ap, p.RunwayDesignation) {
+		return &simerrors.RunwayNotFoundError{RunwayID: p.RunwayDesignation}
+	}
+	return nil
+}
+
+// Apply removes the runway.
+func (p RemoveRunwayPlugin) Apply(ap airport.Airport) airport.Airport {
+	id := p.RunwayDesignation
+	ends := airport.Runway{RunwayDesignation: id}.EndDesignations()
+	if i := slices.IndexFunc(ap.Runways, func(r airport.Runway) bool { return r.RunwayDesignation == id }); i >= 0 {
+		ends = ap.Runways[i].EndDesignations()
+	}
+	ap.Runways = slices.DeleteFunc(ap.Runways, func(r airport.Runway) bool { return r.RunwayDesignation == id })
+
+	rc := ap.RunwayCompatibility
+	if rc == nil {
+		return ap
+	}
+	if rc.CompatibleWith != nil {
+		delete(rc.CompatibleWith, id)
+		for other, compatible := range rc.CompatibleWith {
+			rc.CompatibleWith[other] = slices.DeleteFunc(compatible, func(c string) bool { return c == id })
+		}
+	}
+	rc.AirspaceConflicts = slices.DeleteFunc(rc.AirspaceConflicts, func(c airport.AirspaceConflict) bool {
+		return slices.Contains(ends, c.RunwayEnd) || slices.Contains(ends, c.ConflictingRunwayEnd)
+	})
+	rc.Hours = slices.DeleteFunc(rc.Hours, func(h airport.CompatibilityHours) bool {
+		return h.Runway == id || h.OtherRunway == id
+	})
+	return ap
+}
+
+// GateExpansionPlugin adds gates to the airport. It affects gate capacity constraints that
+// use the airport's gate count (GateCapacityConstraint.UseAirportGates).
+type GateExpansionPlugin struct {
+	AdditionalGates int // Gates to add (negative to model gates taken out of service)
+}
+
+// Name returns the plugin name.
+func (p GateExpansionPlugin) Name() string {
+	return fmt.Sprintf("GateExpansionPlugin(%+d)", p.AdditionalGates)
+}
+
+// Validate checks that the airport is left with a non-negative gate count.
+func (p GateExpansionPlugin) Validate(ap airport.Airport) error {
+	if ap.Gates+p.AdditionalGates < 0 {
+		return simerrors.Invalidf("removing %d gates leaves a negative gate count (airport has %d)", -p.AdditionalGates, ap.Gates)
+	}
+	return nil
+}
+
+// Apply adds the gates.
+func (p GateExpansionPlugin) Apply(ap airport.Airport) airport.Airport {
+	ap.Gates += p.AdditionalGates
+	return ap
+}
+
+// RunwayCompatibilityPlugin makes two runways compatible at all hours (e.g., to model new
+// procedures letting them operate together), removing any compatibility hours limiting the
+// pair. Airspace conflicts and capacity interactions between them are kept.
+type RunwayCompatibilityPlugin struct {
+	RunwayDesignation      string // Runway to make compatible
+	OtherRunwayDesignation string // Runway to make it compatible with
+}
+
+// Name returns the plugin name.
+func (p RunwayCompatibilityPlugin) Name() string {
+	return "RunwayCompatibilityPlugin(" + p.RunwayDesignation + "," + p.OtherRunwayDesignation + ")"
+}
+
+// Validate checks that both runways exist and differ.
+func (p RunwayCompatibilityPlugin) Validate(ap airport.Airport) error {
+	var problems []error
+	for _, id := range []string{p.RunwayDesignation, p.OtherRunwayDesignation} {
+		if !hasRunway(ap, id) {
+			problems = append(problems, &simerrors.RunwayNotFoundError{RunwayID: id})
+		}
+	}
+	if p.RunwayDesignation == p.OtherRunwayDesignation {
+		problems = append(problems, simerrors.Invalidf("runway %s cannot be made compatible with itself", p.RunwayDesignation))
+	}
+	return errors.Join(problems...)
+}
+
+// Apply makes the runways compatible.
+func (p RunwayCompatibilityPlugin) Apply(ap airport.Airport) airport.Airport {
+	id, other := p.RunwayDesignation, p.OtherRunwayDesignation
+	rc := ap.RunwayCompatibility
+	if rc == nil || rc.CompatibleWith == nil {
+		return ap
+	}
+
+	if !rc.IsCompatible(id, other) {
+		ids := make([]string, len(ap.Runways))
+		for i, runway := range ap.Runways {
+			ids[i] = runway.RunwayDesignation
+		}
+		rc = rc.WithRunway(id, append(rc.GetCompatibleRunways(id, ids), other), ids)
+	}
+	rc.Hours = slices.DeleteFunc(rc.Hours, func(h airport.CompatibilityHours) bool {
+		return (h.Runway == id && h.OtherRunway == other) || (h.Runway == other && h.OtherRunway == id)
+	})
+	ap.RunwayCompatibility = rc
+	return ap
+}
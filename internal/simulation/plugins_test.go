@@ -0,0 +1,169 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// createParallelRunways returns the parallel 09L/09R pair from createTestRunways.
+func createParallelRunways() []airport.Runway {
+	return createTestRunways()[:2]
+}
+
+func TestPlugins_AppliedInOrder(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: createParallelRunways(),
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{
+			"09L": {"09R"},
+			"09R": {"09L"},
+		}),
+	}
+	runway18 := airport.Runway{RunwayDesignation: "18", TrueBearing: 180, LengthMeters: 2500, MinimumSeparation: 60 * time.Second}
+
+	// The extension must see 18 added before lengthening it, and the removal must see 09R
+	sim := NewSimulation(ap, testEngineLogger()).
+		AddPreSimulationPlugin(RunwayExtensionPlugin{RunwayDesignation: "18", NewRunway: &runway18, CompatibleWith: []string{"09L"}}).
+		AddPreSimulationPlugin(RunwayExtensionPlugin{RunwayDesignation: "18", Modification: airport.RunwayModification{LengthMeters: 3200}}).
+		AddPreSimulationPlugin(RemoveRunwayPlugin{RunwayDesignation: "09R"})
+
+	if err := sim.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	got := sim.applyPlugins(context.Background(), nil)
+	ids := make([]string, 0, len(got.Runways))
+	for _, runway := range got.Runways {
+		ids = append(ids, runway.RunwayDesignation)
+	}
+	if !slices.Equal(ids, []string{"09L", "18"}) {
+		t.Fatalf("Expected runways [09L 18], got %v", ids)
+	}
+	if got.Runways[1].LengthMeters != 3200 {
+		t.Errorf("Expected 18 extended to 3200m, got %.0f", got.Runways[1].LengthMeters)
+	}
+	if want := map[string][]string{"09L": {"18"}, "18": {"09L"}}; len(got.RunwayCompatibility.CompatibleWith) != 2 ||
+		!slices.Equal(got.RunwayCompatibility.CompatibleWith["09L"], want["09L"]) ||
+		!slices.Equal(got.RunwayCompatibility.CompatibleWith["18"], want["18"]) {
+		t.Errorf("Expected compatibility %v, got %v", want, got.RunwayCompatibility.CompatibleWith)
+	}
+
+	// The simulation's own airport is unchanged
+	if len(ap.Runways) != 2 || !slices.Equal(ap.RunwayCompatibility.CompatibleWith["09L"], []string{"09R"}) {
+		t.Errorf("Expected plugins to leave the original airport unchanged, got %v %v", ap.Runways, ap.RunwayCompatibility.CompatibleWith)
+	}
+}
+
+func TestPlugins_ValidationErrors(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createParallelRunways(), Gates: 10}
+
+	sim := NewSimulation(ap, testEngineLogger()).
+		AddPreSimulationPlugin(RemoveRunwayPlugin{RunwayDesignation: "09R"}).
+		AddPreSimulationPlugin(RemoveRunwayPlugin{RunwayDesignation: "09R"}). // Already removed
+		AddPreSimulationPlugin(RunwayExtensionPlugin{RunwayDesignation: "18"}).
+		AddPreSimulationPlugin(GateExpansionPlugin{AdditionalGates: -20})
+
+	var validationErr *ValidationError
+	if err := sim.Validate(); !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *ValidationError, got %v", err)
+	}
+	// Three plugin problems, plus the negative gate count left on the airport
+	if len(validationErr.Problems) != 4 {
+		t.Fatalf("Expected 4 problems, got %d: %v", len(validationErr.Problems), validationErr)
+	}
+	if !errors.Is(validationErr, simerrors.ErrRunwayNotFound) {
+		t.Errorf("Expected ErrRunwayNotFound, got %v", validationErr)
+	}
+	if !errors.Is(validationErr, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", validationErr)
+	}
+}
+
+func TestPlugins_GateExpansion(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createParallelRunways(), Gates: 10}
+
+	run := func(plugins ...PreSimulationPlugin) float32 {
+		t.Helper()
+		sim := NewSimulation(ap, testEngineLogger())
+		for _, plugin := range plugins {
+			sim = sim.AddPreSimulationPlugin(plugin)
+		}
+		sim, err := sim.AddGateCapacityPolicy(GateCapacityConstraint{UseAirportGates: true, AverageTurnaroundTime: time.Hour})
+		if err != nil {
+			t.Fatalf("AddGateCapacityPolicy failed: %v", err)
+		}
+		capacity, err := sim.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return capacity
+	}
+
+	// 10 gates with a one hour turnaround sustain 20 movements per hour, well below the runways
+	current := run()
+	expanded := run(GateExpansionPlugin{AdditionalGates: 10})
+	if expected := float32(366 * 24 * 20); current != expected {
+		t.Errorf("Expected %.0f movements with 10 gates, got %.0f", expected, current)
+	}
+	if expanded != 2*current {
+		t.Errorf("Expected doubling the gates to double capacity, got %.0f and %.0f", current, expanded)
+	}
+}
+
+func TestPlugins_RunwayCompatibility(t *testing.T) {
+	compat := airport.NewRunwayCompatibility(map[string][]string{"09L": {"18"}, "09R": {}, "18": {"09L"}})
+	compat.Hours = []airport.CompatibilityHours{{Runway: "18", OtherRunway: "09L", From: 6 * time.Hour, Until: 20 * time.Hour}}
+	ap := airport.Airport{
+		Name:                "Test",
+		Runways:             append(createParallelRunways(), airport.Runway{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: time.Minute}),
+		RunwayCompatibility: compat,
+	}
+	sim := NewSimulation(ap, testEngineLogger()).
+		AddPreSimulationPlugin(RunwayCompatibilityPlugin{RunwayDesignation: "09R", OtherRunwayDesignation: "18"}).
+		AddPreSimulationPlugin(RunwayCompatibilityPlugin{RunwayDesignation: "09L", OtherRunwayDesignation: "18"})
+	if err := sim.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	got := sim.applyPlugins(context.Background(), nil).RunwayCompatibility
+	if !got.IsCompatible("09R", "18") || !got.IsCompatible("09L", "18") || got.IsCompatible("09L", "09R") {
+		t.Errorf("Expected 18 compatible with both parallels only, got %v", got)
+	}
+	if len(got.Hours) != 0 {
+		t.Errorf("Expected the 09L/18 hours removed, got %v", got.Hours)
+	}
+	if len(compat.Hours) != 1 || compat.IsCompatible("09R", "18") {
+		t.Errorf("Expected the original compatibility unchanged, got %v", compat)
+	}
+
+	invalid := NewSimulation(ap, testEngineLogger()).
+		AddPreSimulationPlugin(RunwayCompatibilityPlugin{RunwayDesignation: "18", OtherRunwayDesignation: "36"})
+	if err := invalid.Validate(); !errors.Is(err, simerrors.ErrRunwayNotFound) {
+		t.Errorf("Expected ErrRunwayNotFound, got %v", err)
+	}
+}
+
+func TestAirportDelta(t *testing.T) {
+	before := airport.Airport{Runways: createParallelRunways(), Gates: 10}
+	after := copyAirport(before)
+	after.Runways[0].LengthMeters = 4000
+	after.Runways[1].ApproachCategory = airport.PrecisionCatI
+	after.Runways = append(after.Runways, airport.Runway{RunwayDesignation: "18", LengthMeters: 2500})
+	after.Gates = 12
+
+	after.RunwayCompatibility = airport.NewRunwayCompatibility(map[string][]string{"09L": {}, "09R": {}, "18": {}})
+
+	want := []string{"runway 09L length 3000m -> 4000m", "modified runway 09R", "added runway 18 (2500m)", "runways 09L and 09R made incompatible", "gates 10 -> 12"}
+	if got := airportDelta(before, after); !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := airportDelta(before, before); !slices.Equal(got, []string{"none"}) {
+		t.Errorf("Expected no changes, got %v", got)
+	}
+}
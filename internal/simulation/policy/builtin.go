@@ -0,0 +1,246 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// Duration is a time.Duration that decodes from a Go duration string such as "8h" or "90m",
+// so policy parameters in configuration files stay readable.
+type Duration time.Duration
+
+// UnmarshalJSON decodes a duration string, or a number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var ns int64
+		if err := json.Unmarshal(data, &ns); err != nil {
+			return simerrors.Invalidf("duration must be a string such as \"8h\", got %s", data)
+		}
+		*d = Duration(ns)
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return simerrors.Invalidf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// curfewParams are the parameters of the "curfew" policy.
+type curfewParams struct {
+	Start time.Time `json:"start"` // RFC 3339 start of the first curfew
+	End   time.Time `json:"end"`   // RFC 3339 end of the first curfew
+}
+
+// windParams are the parameters of the "wind" policy.
+type windParams struct {
+	SpeedKnots    float64 `json:"speedKnots"`
+	DirectionTrue float64 `json:"directionTrue"`
+}
+
+// maintenanceParams are the parameters of the "maintenance" policy.
+type maintenanceParams struct {
+	Runways   []string `json:"runways"`
+	Duration  Duration `json:"duration"`
+	Frequency Duration `json:"frequency"`
+	Jitter    Duration `json:"jitter"`
+}
+
+// conditionMaintenanceParams are the parameters of the "condition-maintenance" policy.
+type conditionMaintenanceParams struct {
+	Runways                 []string `json:"runways"`
+	MovementsPerMaintenance float64  `json:"movementsPerMaintenance"`
+	Duration                Duration `json:"duration"`
+	CheckInterval           Duration `json:"checkInterval"`
+}
+
+// gateCapacityParams are the parameters of the "gate-capacity" policy.
+type gateCapacityParams struct {
+	TotalGates            int                 `json:"totalGates"`
+	UseAirportGates       bool                `json:"useAirportGates"`
+	AverageTurnaroundTime Duration            `json:"averageTurnaroundTime"`
+	Turnaround            *turnaroundParams   `json:"turnaround"` // Turnaround distribution, instead of averageTurnaroundTime
+	Closures              []gateClosureParams `json:"closures"`
+}
+
+// turnaroundParams are the parameters of the turnaround distribution of the "gate-capacity"
+// policy: either P50 and P90, or a histogram starting at start.
+type turnaroundParams struct {
+	Percentile float64              `json:"percentile"` // Percentile used as the turnaround, in (0, 100)
+	P50        Duration             `json:"p50"`
+	P90        Duration             `json:"p90"`
+	Start      Duration             `json:"start"`
+	Histogram  []histogramBinParams `json:"histogram"`
+}
+
+// histogramBinParams are the parameters of one bin of a turnaround histogram.
+type histogramBinParams struct {
+	End    Duration `json:"end"`
+	Weight float64  `json:"weight"`
+}
+
+// gateClosureParams are the parameters of one gate closure of the "gate-capacity" policy.
+type gateClosureParams struct {
+	Gates int       `json:"gates"`
+	Start time.Time `json:"start"` // RFC 3339 time the gates close
+	End   time.Time `json:"end"`   // RFC 3339 time the gates reopen
+}
+
+// taxiTimeParams are the parameters of the "taxi-time" policy.
+type taxiTimeParams struct {
+	AverageTaxiInTime  Duration `json:"averageTaxiInTime"`
+	AverageTaxiOutTime Duration `json:"averageTaxiOutTime"`
+}
+
+// rotationParams are the parameters of the "rotation" policy.
+type rotationParams struct {
+	Strategy string `json:"strategy"` // RotationStrategy name, e.g. "TimeBasedRotation"
+}
+
+// directionChangeoverParams are the parameters of the "direction-changeover" policy.
+type directionChangeoverParams struct {
+	Penalty Duration `json:"penalty"`
+}
+
+// declaredCapacityParams are the parameters of the "declared-capacity" policy.
+type declaredCapacityParams struct {
+	MovementsPerHour float64 `json:"movementsPerHour"`
+}
+
+// noisePreferentialParams are the parameters of the "noise-preferential" policy.
+type noisePreferentialParams struct {
+	Schedule string `json:"schedule"` // Path to the schedule CSV file (see ReadNoisePreferentialSchedule)
+}
+
+// The built-in policies, available to scenario files and the -policy flag by name.
+func init() {
+	Register("curfew", func(decode Decoder) (Policy, error) {
+		var params curfewParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewCurfewPolicy(params.Start, params.End)
+	})
+	Register("wind", func(decode Decoder) (Policy, error) {
+		var params windParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewWindPolicy(params.SpeedKnots, params.DirectionTrue)
+	})
+	Register("maintenance", func(decode Decoder) (Policy, error) {
+		var params maintenanceParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewMaintenancePolicy(MaintenanceSchedule{
+			RunwayDesignations: params.Runways,
+			Duration:           time.Duration(params.Duration),
+			Frequency:          time.Duration(params.Frequency),
+			Jitter:             time.Duration(params.Jitter),
+		}), nil
+	})
+	Register("condition-maintenance", func(decode Decoder) (Policy, error) {
+		var params conditionMaintenanceParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewConditionMaintenancePolicy(ConditionMaintenanceSchedule{
+			RunwayDesignations:      params.Runways,
+			MovementsPerMaintenance: params.MovementsPerMaintenance,
+			Duration:                time.Duration(params.Duration),
+			CheckInterval:           time.Duration(params.CheckInterval),
+		})
+	})
+	Register("gate-capacity", func(decode Decoder) (Policy, error) {
+		var params gateCapacityParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		var closures []GateClosure
+		for _, closure := range params.Closures {
+			closures = append(closures, GateClosure{
+				Gates: closure.Gates,
+				Start: closure.Start,
+				End:   closure.End,
+			})
+		}
+		constraint := GateCapacityConstraint{
+			TotalGates:            params.TotalGates,
+			UseAirportGates:       params.UseAirportGates,
+			AverageTurnaroundTime: time.Duration(params.AverageTurnaroundTime),
+			Closures:              closures,
+		}
+		if t := params.Turnaround; t != nil {
+			if len(t.Histogram) > 0 {
+				histogram := HistogramDuration{Start: time.Duration(t.Start)}
+				for _, bin := range t.Histogram {
+					histogram.Bins = append(histogram.Bins, HistogramBin{End: time.Duration(bin.End), Weight: bin.Weight})
+				}
+				constraint.TurnaroundDistribution = histogram
+			} else {
+				constraint.TurnaroundDistribution = PercentileDuration{P50: time.Duration(t.P50), P90: time.Duration(t.P90)}
+			}
+			constraint.TurnaroundPercentile = t.Percentile
+		}
+		return NewGateCapacityPolicy(constraint)
+	})
+	Register("taxi-time", func(decode Decoder) (Policy, error) {
+		var params taxiTimeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewTaxiTimePolicy(TaxiTimeConfiguration{
+			AverageTaxiInTime:  time.Duration(params.AverageTaxiInTime),
+			AverageTaxiOutTime: time.Duration(params.AverageTaxiOutTime),
+		})
+	})
+	Register("rotation", func(decode Decoder) (Policy, error) {
+		var params rotationParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		for _, strategy := range []RotationStrategy{NoRotation, TimeBasedRotation, PreferentialRunway, NoiseOptimizedRotation} {
+			if strategy.String() == params.Strategy {
+				return NewDefaultRunwayRotationPolicy(strategy), nil
+			}
+		}
+		return nil, simerrors.Invalidf("unknown rotation strategy %q", params.Strategy)
+	})
+	Register("direction-changeover", func(decode Decoder) (Policy, error) {
+		var params directionChangeoverParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewDirectionChangeoverPolicy(time.Duration(params.Penalty))
+	})
+	Register("declared-capacity", func(decode Decoder) (Policy, error) {
+		var params declaredCapacityParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return NewDeclaredCapacityPolicy(params.MovementsPerHour)
+	})
+	Register("noise-preferential", func(decode Decoder) (Policy, error) {
+		var params noisePreferentialParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		f, err := os.Open(params.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		bands, err := ReadNoisePreferentialSchedule(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", params.Schedule, err)
+		}
+		return NewNoisePreferentialPolicy(bands)
+	})
+}
@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestBuiltin_Policies(t *testing.T) {
+	tests := []struct {
+		policy string
+		params string
+		want   string
+	}{
+		{"curfew", `{"start": "2024-01-01T23:00:00Z", "end": "2024-01-02T06:00:00Z"}`, "CurfewPolicy"},
+		{"wind", `{"speedKnots": 12, "directionTrue": 270}`, "WindPolicy"},
+		{"maintenance", `{"runways": ["09L"], "duration": "4h", "frequency": "168h"}`, "MaintenancePolicy"},
+		{"condition-maintenance", `{"runways": ["09L"], "movementsPerMaintenance": 5000, "duration": "6h"}`, "ConditionMaintenancePolicy"},
+		{"gate-capacity", `{"totalGates": 40, "averageTurnaroundTime": "45m"}`, "GateCapacityPolicy"},
+		{"gate-capacity", `{"totalGates": 40, "averageTurnaroundTime": "45m", "closures": [{"gates": 10, "start": "2024-01-01T00:00:00Z", "end": "2024-04-01T00:00:00Z"}]}`, "GateCapacityPolicy"},
+		{"gate-capacity", `{"totalGates": 40, "turnaround": {"percentile": 90, "p50": "45m", "p90": "80m"}}`, "GateCapacityPolicy"},
+		{"gate-capacity", `{"totalGates": 40, "turnaround": {"percentile": 90, "start": "30m", "histogram": [{"end": "1h", "weight": 8}, {"end": "2h", "weight": 2}]}}`, "GateCapacityPolicy"},
+		{"taxi-time", `{"averageTaxiInTime": "8m", "averageTaxiOutTime": "12m"}`, "TaxiTimePolicy"},
+		{"rotation", `{"strategy": "TimeBasedRotation"}`, "RunwayRotationPolicy(TimeBasedRotation)"},
+		{"direction-changeover", `{"penalty": "10m"}`, "DirectionChangeoverPolicy"},
+		{"declared-capacity", `{"movementsPerHour": 88}`, "DeclaredCapacityPolicy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			p, err := NewRegistered(tt.policy, JSONDecoder([]byte(tt.params)))
+			if err != nil {
+				t.Fatalf("NewRegistered failed: %v", err)
+			}
+			if p.Name() != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, p.Name())
+			}
+
+			// Every built-in policy describes its parameters for the result provenance
+			described, ok := p.(DescribedPolicy)
+			if !ok {
+				t.Fatalf("Expected %s to describe its parameters", p.Name())
+			}
+			if _, err := json.Marshal(described.Parameters()); err != nil {
+				t.Errorf("Expected %s parameters to marshal, got %v", p.Name(), err)
+			}
+		})
+	}
+}
+
+func TestBuiltin_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		params string
+	}{
+		{"bad duration", "maintenance", `{"runways": ["09L"], "duration": "four hours", "frequency": "168h"}`},
+		{"unknown strategy", "rotation", `{"strategy": "Alphabetical"}`},
+		{"rejected by constructor", "condition-maintenance", `{"runways": ["09L"], "duration": "6h"}`},
+		{"turnaround without percentile", "gate-capacity", `{"totalGates": 40, "turnaround": {"p50": "45m", "p90": "80m"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistered(tt.policy, JSONDecoder([]byte(tt.params)))
+			if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{`"90m"`, 90 * time.Minute, false},
+		{`"1h30m"`, 90 * time.Minute, false},
+		{`60000000000`, time.Minute, false},
+		{`"soon"`, 0, true},
+		{`true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && time.Duration(d) != tt.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, time.Duration(d), tt.want)
+			}
+		})
+	}
+}
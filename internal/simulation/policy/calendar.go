@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// ErrEmptyCalendar is returned when a calendar policy has no overrides.
+var ErrEmptyCalendar = simerrors.Invalidf("calendar must contain at least one override")
+
+// CalendarOverrideKind defines how a calendar date changes normal operations.
+type CalendarOverrideKind int
+
+const (
+	// CalendarClosure closes the airport for the whole day (e.g., a public holiday)
+	CalendarClosure CalendarOverrideKind = iota
+
+	// CalendarCurfew closes the airport during an extra window on the day
+	// (e.g., extending the normal curfew on New Year's Eve)
+	CalendarCurfew
+
+	// CalendarReducedCapacity caps movements per hour during a window on the day
+	// (e.g., an airshow occupying the airspace)
+	CalendarReducedCapacity
+)
+
+// String returns the string representation of the override kind.
+func (k CalendarOverrideKind) String() string {
+	switch k {
+	case CalendarClosure:
+		return "Closure"
+	case CalendarCurfew:
+		return "Curfew"
+	case CalendarReducedCapacity:
+		return "ReducedCapacity"
+	default:
+		return "Unknown"
+	}
+}
+
+// CalendarOverride changes operations on a specific date.
+type CalendarOverride struct {
+	Name                string               // Description (e.g., "Christmas Day", "Airshow")
+	Date                time.Time            // Calendar day the override applies to (time of day is ignored)
+	Kind                CalendarOverrideKind // How operations change
+	StartTime           time.Time            // Window start for Curfew and ReducedCapacity (only the time of day is used)
+	EndTime             time.Time            // Window end for Curfew and ReducedCapacity (only the time of day is used; wraps past midnight)
+	MaxMovementsPerHour float64              // Movements per hour allowed for ReducedCapacity
+}
+
+// window returns the period the override covers.
+func (o CalendarOverride) window() (time.Time, time.Time) {
+	day := time.Date(o.Date.Year(), o.Date.Month(), o.Date.Day(), 0, 0, 0, 0, o.Date.Location())
+	if o.Kind == CalendarClosure {
+		return day, day.AddDate(0, 0, 1)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), o.StartTime.Hour(), o.StartTime.Minute(), 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), o.EndTime.Hour(), o.EndTime.Minute(), 0, 0, day.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// CalendarPolicy applies holiday and special-event overrides on specific dates.
+// Overrides are modelled as airport-wide curfew restrictions, so they stack with the
+// normal CurfewPolicy rather than replacing it: a daily curfew ending at 06:00 does not
+// reopen an airport closed for the whole day.
+type CalendarPolicy struct {
+	overrides    []CalendarOverride
+	restrictions []*event.CurfewRestriction // One restriction per override
+}
+
+// NewCalendarPolicy creates a new calendar policy.
+// Returns an error if no overrides are given, an override kind is unknown,
+// or a reduced capacity override does not allow a positive number of movements.
+func NewCalendarPolicy(overrides []CalendarOverride) (*CalendarPolicy, error) {
+	if len(overrides) == 0 {
+		return nil, ErrEmptyCalendar
+	}
+
+	restrictions := make([]*event.CurfewRestriction, len(overrides))
+	for i, override := range overrides {
+		restriction := &event.CurfewRestriction{}
+		switch override.Kind {
+		case CalendarClosure, CalendarCurfew:
+			// Zero movements on all runway ends
+		case CalendarReducedCapacity:
+			if override.MaxMovementsPerHour <= 0 {
+				return nil, simerrors.Invalidf("calendar override %q: reduced capacity must allow a positive number of movements, got %f",
+					override.Name, override.MaxMovementsPerHour)
+			}
+			restriction.MaxMovementsPerHour = override.MaxMovementsPerHour
+		default:
+			return nil, simerrors.Invalidf("calendar override %q: unknown kind %d", override.Name, override.Kind)
+		}
+		restrictions[i] = restriction
+	}
+
+	return &CalendarPolicy{
+		overrides:    append([]CalendarOverride(nil), overrides...),
+		restrictions: restrictions,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *CalendarPolicy) Name() string {
+	return "CalendarPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *CalendarPolicy) Parameters() any {
+	return p.overrides
+}
+
+// Validate checks that every override falls on a day within the simulation period.
+func (p *CalendarPolicy) Validate(world EventWorld) error {
+	var problems []error
+	for _, override := range p.overrides {
+		start, end := override.window()
+		if !end.After(world.GetStartTime()) || !start.Before(world.GetEndTime()) {
+			problems = append(problems, simerrors.Invalidf("%s: override %q on %s is outside the simulation period",
+				p.Name(), override.Name, override.Date.Format("2006-01-02")))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// GenerateEvents generates restriction start and end events for every override that falls
+// within the simulation period. Windows are clipped to the simulation period.
+func (p *CalendarPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	simStart := world.GetStartTime()
+	simEnd := world.GetEndTime()
+
+	for i, override := range p.overrides {
+		start, end := override.window()
+		if start.Before(simStart) {
+			start = simStart
+		}
+		if end.After(simEnd) {
+			end = simEnd
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		world.ScheduleEvent(event.NewRunwayCurfewStartEvent(p.restrictions[i], start))
+		world.ScheduleEvent(event.NewRunwayCurfewEndEvent(p.restrictions[i], end))
+	}
+
+	return nil
+}
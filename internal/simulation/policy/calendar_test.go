@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewCalendarPolicy(t *testing.T) {
+	day := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		overrides   []CalendarOverride
+		expectError bool
+	}{
+		{"closure", []CalendarOverride{{Name: "Christmas", Date: day, Kind: CalendarClosure}}, false},
+		{"reduced capacity", []CalendarOverride{{Name: "Airshow", Date: day, Kind: CalendarReducedCapacity, MaxMovementsPerHour: 10}}, false},
+		{"no overrides", nil, true},
+		{"reduced capacity without cap", []CalendarOverride{{Name: "Airshow", Date: day, Kind: CalendarReducedCapacity}}, true},
+		{"unknown kind", []CalendarOverride{{Name: "Mystery", Date: day, Kind: 9}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCalendarPolicy(tt.overrides)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	if _, err := NewCalendarPolicy(nil); !errors.Is(err, ErrEmptyCalendar) {
+		t.Errorf("Expected ErrEmptyCalendar, got %v", err)
+	}
+}
+
+func TestCalendarPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 10)
+
+	policy, err := NewCalendarPolicy([]CalendarOverride{
+		{Name: "Holiday", Date: time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC), Kind: CalendarClosure},
+		{
+			Name:      "New Year's Eve",
+			Date:      time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			Kind:      CalendarCurfew,
+			StartTime: time.Date(0, 1, 1, 21, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(0, 1, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{Name: "Outside simulation", Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Kind: CalendarClosure},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if starts := world.CountEventsByType(event.RunwayCurfewStartType); starts != 2 {
+		t.Fatalf("Expected 2 start events, got %d", starts)
+	}
+	if ends := world.CountEventsByType(event.RunwayCurfewEndType); ends != 2 {
+		t.Fatalf("Expected 2 end events, got %d", ends)
+	}
+
+	expected := map[time.Time]event.EventType{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC):  event.RunwayCurfewStartType,
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC):  event.RunwayCurfewEndType,
+		time.Date(2024, 1, 5, 21, 0, 0, 0, time.UTC): event.RunwayCurfewStartType,
+		time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC):  event.RunwayCurfewEndType,
+	}
+	for _, evt := range world.GetEvents() {
+		if want, ok := expected[evt.Time()]; !ok || want != evt.Type() {
+			t.Errorf("Unexpected %s event at %v", evt.Type(), evt.Time())
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// commissioningClosureReason labels closure events generated by the commissioning policy.
+const commissioningClosureReason = "commissioning"
+
+// CommissioningMode defines how a runway may be used during a commissioning phase.
+type CommissioningMode int
+
+const (
+	// CommissioningClosed means the runway is not yet usable
+	CommissioningClosed CommissioningMode = iota
+
+	// CommissioningRestricted means the runway is usable only during daily operating hours
+	// (e.g., daylight VFR operations)
+	CommissioningRestricted
+
+	// CommissioningFull means the runway is available around the clock
+	CommissioningFull
+)
+
+// String returns the string representation of the commissioning mode.
+func (m CommissioningMode) String() string {
+	switch m {
+	case CommissioningClosed:
+		return "Closed"
+	case CommissioningRestricted:
+		return "Restricted"
+	case CommissioningFull:
+		return "Full"
+	default:
+		return "Unknown"
+	}
+}
+
+// CommissioningPhase describes one stage of a runway's opening.
+// A phase lasts until the next phase starts (or the simulation ends).
+type CommissioningPhase struct {
+	Start        time.Time                  // When this phase begins
+	Mode         CommissioningMode          // How the runway may be used
+	OpenHour     int                        // Daily opening hour for Restricted mode (0-23, local time at the airport)
+	CloseHour    int                        // Daily closing hour for Restricted mode (1-24, local time at the airport)
+	Modification airport.RunwayModification // Characteristic changes taking effect at phase start (e.g., length, approach category)
+}
+
+// CommissioningPlan defines the phased opening of a single runway.
+type CommissioningPlan struct {
+	RunwayDesignation string               // Runway being commissioned
+	Phases            []CommissioningPhase // Phases in chronological order
+}
+
+// CommissioningPolicy models a new runway opening in phases, for example daylight VFR
+// operations only for three months followed by full operations with a precision approach.
+// The runway is closed from simulation start until the first phase begins.
+//
+// The policy combines time-bounded availability (closure events), approach-category
+// upgrades and length changes (runway modification events) into a single event sequence.
+type CommissioningPolicy struct {
+	plan CommissioningPlan
+}
+
+// NewCommissioningPolicy creates a new commissioning policy with validation.
+// Returns an error if the plan has no phases, phases are not chronological, or
+// restricted hours are invalid.
+func NewCommissioningPolicy(plan CommissioningPlan) (*CommissioningPolicy, error) {
+	if plan.RunwayDesignation == "" {
+		return nil, simerrors.Invalidf("commissioning plan must name a runway")
+	}
+	if len(plan.Phases) == 0 {
+		return nil, simerrors.Invalidf("commissioning plan must have at least one phase")
+	}
+
+	for i, phase := range plan.Phases {
+		if i > 0 && !phase.Start.After(plan.Phases[i-1].Start) {
+			return nil, simerrors.Invalidf("commissioning phase %d must start after phase %d", i, i-1)
+		}
+		if phase.Mode == CommissioningRestricted {
+			if phase.OpenHour < 0 || phase.OpenHour > 23 || phase.CloseHour < 1 || phase.CloseHour > 24 || phase.CloseHour <= phase.OpenHour {
+				return nil, simerrors.Invalidf("commissioning phase %d has invalid operating hours %d-%d", i, phase.OpenHour, phase.CloseHour)
+			}
+		}
+	}
+
+	return &CommissioningPolicy{
+		plan: plan,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *CommissioningPolicy) Name() string {
+	return "CommissioningPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *CommissioningPolicy) Parameters() any {
+	return p.plan
+}
+
+// Validate checks that the runway being commissioned exists.
+func (p *CommissioningPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), []string{p.plan.RunwayDesignation})...)
+}
+
+// SetInitialState applies the modifications of phases started before the simulation, and
+// closes the runway if it may not be used at the start (see InitialStatePolicy).
+func (p *CommissioningPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+	runwayID := p.plan.RunwayDesignation
+
+	if !slices.Contains(world.GetRunwayIDs(), runwayID) {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+
+	// Modifications before the simulation starts take effect at simulation start
+	for _, phase := range p.plan.Phases {
+		if phase.Modification.IsEmpty() || phase.Start.After(startTime) {
+			continue
+		}
+		if err := event.NewRunwayModificationEvent(runwayID, phase.Modification, startTime).Apply(ctx, world); err != nil {
+			return err
+		}
+	}
+
+	closures := p.ClosedWindows(startTime, endTime, location(world))
+	if len(closures) > 0 && !closures[0].Start.After(startTime) {
+		return event.NewRunwayClosureStartEvent(runwayID, commissioningClosureReason, startTime).Apply(ctx, world)
+	}
+	return nil
+}
+
+// GenerateEvents generates runway closure events for every period the runway may not be used,
+// and runway modification events at the start of each phase that changes the runway. The
+// state at the start is set by SetInitialState.
+func (p *CommissioningPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+	runwayID := p.plan.RunwayDesignation
+
+	if !slices.Contains(world.GetRunwayIDs(), runwayID) {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+
+	for _, phase := range p.plan.Phases {
+		if phase.Modification.IsEmpty() || !phase.Start.After(startTime) || phase.Start.After(endTime) {
+			continue
+		}
+		world.ScheduleEvent(event.NewRunwayModificationEvent(runwayID, phase.Modification, phase.Start))
+	}
+
+	for _, closure := range p.ClosedWindows(startTime, endTime, location(world)) {
+		if closure.Start.After(startTime) {
+			world.ScheduleEvent(event.NewRunwayClosureStartEvent(runwayID, commissioningClosureReason, closure.Start))
+		}
+		if closure.End.Before(endTime) {
+			world.ScheduleEvent(event.NewRunwayClosureEndEvent(runwayID, commissioningClosureReason, closure.End))
+		}
+	}
+
+	return nil
+}
+
+// ClosedWindows returns the merged periods within [startTime, endTime) during which the
+// runway may not be used under the commissioning plan. Daily operating hours of restricted
+// phases are local times in loc, the airport's time zone.
+func (p *CommissioningPolicy) ClosedWindows(startTime, endTime time.Time, loc *time.Location) []TimeWindow {
+	closed := []TimeWindow{}
+	phases := p.plan.Phases
+
+	// Not yet commissioned before the first phase
+	if phases[0].Start.After(startTime) {
+		closed = append(closed, TimeWindow{Start: startTime, End: phases[0].Start})
+	}
+
+	for i, phase := range phases {
+		phaseEnd := endTime
+		if i+1 < len(phases) && phases[i+1].Start.Before(endTime) {
+			phaseEnd = phases[i+1].Start
+		}
+		phaseStart := phase.Start
+		if phaseStart.Before(startTime) {
+			phaseStart = startTime
+		}
+		if !phaseEnd.After(phaseStart) {
+			continue
+		}
+
+		switch phase.Mode {
+		case CommissioningClosed:
+			closed = append(closed, TimeWindow{Start: phaseStart, End: phaseEnd})
+		case CommissioningRestricted:
+			closed = append(closed, restrictedClosures(phase, phaseStart, phaseEnd, loc)...)
+		}
+	}
+
+	return Union(closed)
+}
+
+// restrictedClosures returns the periods outside daily operating hours, local times in loc,
+// within [from, to). The periods are in the location of from.
+func restrictedClosures(phase CommissioningPhase, from, to time.Time, loc *time.Location) []TimeWindow {
+	closed := []TimeWindow{}
+
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for day.Before(to) {
+		open := time.Date(day.Year(), day.Month(), day.Day(), phase.OpenHour, 0, 0, 0, loc)
+		closing := time.Date(day.Year(), day.Month(), day.Day(), phase.CloseHour, 0, 0, 0, loc)
+		nextDay := day.AddDate(0, 0, 1)
+
+		for _, w := range []TimeWindow{{Start: day, End: open}, {Start: closing, End: nextDay}} {
+			w.Start, w.End = w.Start.In(from.Location()), w.End.In(from.Location())
+			if w.Start.Before(from) {
+				w.Start = from
+			}
+			if w.End.After(to) {
+				w.End = to
+			}
+			if w.End.After(w.Start) {
+				closed = append(closed, w)
+			}
+		}
+
+		day = nextDay
+	}
+
+	return closed
+}
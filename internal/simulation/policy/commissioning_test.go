@@ -0,0 +1,210 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewCommissioningPolicy(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		plan        CommissioningPlan
+		expectError bool
+	}{
+		{
+			name: "valid two-phase plan",
+			plan: CommissioningPlan{
+				RunwayDesignation: "09N",
+				Phases: []CommissioningPhase{
+					{Start: base, Mode: CommissioningRestricted, OpenHour: 7, CloseHour: 19},
+					{Start: base.AddDate(0, 3, 0), Mode: CommissioningFull},
+				},
+			},
+		},
+		{
+			name:        "no runway",
+			plan:        CommissioningPlan{Phases: []CommissioningPhase{{Start: base, Mode: CommissioningFull}}},
+			expectError: true,
+		},
+		{
+			name:        "no phases",
+			plan:        CommissioningPlan{RunwayDesignation: "09N"},
+			expectError: true,
+		},
+		{
+			name: "phases out of order",
+			plan: CommissioningPlan{
+				RunwayDesignation: "09N",
+				Phases: []CommissioningPhase{
+					{Start: base.AddDate(0, 1, 0), Mode: CommissioningFull},
+					{Start: base, Mode: CommissioningRestricted, OpenHour: 7, CloseHour: 19},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid restricted hours",
+			plan: CommissioningPlan{
+				RunwayDesignation: "09N",
+				Phases:            []CommissioningPhase{{Start: base, Mode: CommissioningRestricted, OpenHour: 19, CloseHour: 7}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCommissioningPolicy(tt.plan)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCommissioningPolicy_ClosedWindows(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 5)
+
+	// Closed for 2 days, daylight-only for 2 days, then full operations
+	policy, err := NewCommissioningPolicy(CommissioningPlan{
+		RunwayDesignation: "09N",
+		Phases: []CommissioningPhase{
+			{Start: simStart.AddDate(0, 0, 2), Mode: CommissioningRestricted, OpenHour: 7, CloseHour: 19},
+			{Start: simStart.AddDate(0, 0, 4), Mode: CommissioningFull},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	windows := policy.ClosedWindows(simStart, simEnd, time.UTC)
+	expected := []TimeWindow{
+		{Start: simStart, End: simStart.AddDate(0, 0, 2).Add(7 * time.Hour)},
+		{Start: simStart.AddDate(0, 0, 2).Add(19 * time.Hour), End: simStart.AddDate(0, 0, 3).Add(7 * time.Hour)},
+		{Start: simStart.AddDate(0, 0, 3).Add(19 * time.Hour), End: simStart.AddDate(0, 0, 4)},
+	}
+
+	if len(windows) != len(expected) {
+		t.Fatalf("Expected %d closed windows, got %d: %+v", len(expected), len(windows), windows)
+	}
+	for i := range expected {
+		if !windows[i].Start.Equal(expected[i].Start) || !windows[i].End.Equal(expected[i].End) {
+			t.Errorf("Window %d: expected %v-%v, got %v-%v", i, expected[i].Start, expected[i].End, windows[i].Start, windows[i].End)
+		}
+	}
+}
+
+func TestCommissioningPolicy_ClosedWindowsLocalHours(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Daylight saving time starts on 10 March, a 23-hour day
+	simStart := time.Date(2024, 3, 9, 0, 0, 0, 0, loc).UTC()
+	simEnd := time.Date(2024, 3, 11, 0, 0, 0, 0, loc).UTC()
+
+	policy, err := NewCommissioningPolicy(CommissioningPlan{
+		RunwayDesignation: "09N",
+		Phases:            []CommissioningPhase{{Start: simStart, Mode: CommissioningRestricted, OpenHour: 7, CloseHour: 19}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	// Open from 07:00 to 19:00 local time on both days
+	windows := policy.ClosedWindows(simStart, simEnd, loc)
+	expected := []TimeWindow{
+		{Start: time.Date(2024, 3, 9, 0, 0, 0, 0, loc), End: time.Date(2024, 3, 9, 7, 0, 0, 0, loc)},
+		{Start: time.Date(2024, 3, 9, 19, 0, 0, 0, loc), End: time.Date(2024, 3, 10, 7, 0, 0, 0, loc)},
+		{Start: time.Date(2024, 3, 10, 19, 0, 0, 0, loc), End: time.Date(2024, 3, 11, 0, 0, 0, 0, loc)},
+	}
+
+	if len(windows) != len(expected) {
+		t.Fatalf("Expected %d closed windows, got %d: %+v", len(expected), len(windows), windows)
+	}
+	for i := range expected {
+		if !windows[i].Start.Equal(expected[i].Start) || !windows[i].End.Equal(expected[i].End) {
+			t.Errorf("Window %d: expected %v-%v, got %v-%v", i, expected[i].Start, expected[i].End, windows[i].Start, windows[i].End)
+		}
+	}
+}
+
+func TestCommissioningPolicy_SetInitialState(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 3)
+	catI := airport.PrecisionCatI
+
+	policy, err := NewCommissioningPolicy(CommissioningPlan{
+		RunwayDesignation: "09N",
+		Phases: []CommissioningPhase{
+			{Start: simStart.AddDate(0, 0, 1), Mode: CommissioningFull, Modification: airport.RunwayModification{
+				LengthMeters:     3200,
+				ApproachCategory: &catI,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockInitialStateWorld(simStart, simEnd)
+	world.runwayIDs = []string{"09N", "09L"}
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// Not yet commissioned, the runway is closed as initial state rather than by an event
+	if !slices.Equal(world.closedRunways, []string{"09N"}) {
+		t.Errorf("Expected 09N closed at the start, got %v", world.closedRunways)
+	}
+	if events := world.eventsAt(simStart); len(events) != 0 {
+		t.Errorf("Expected no events at the start time, got %d", len(events))
+	}
+	if got := world.CountEventsByType(event.RunwayClosureStartType); got != 0 {
+		t.Errorf("Expected no closure start events, got %d", got)
+	}
+	if got := world.CountEventsByType(event.RunwayClosureEndType); got != 1 {
+		t.Errorf("Expected 1 closure end, got %d", got)
+	}
+	if got := world.CountEventsByType(event.RunwayModificationType); got != 1 {
+		t.Fatalf("Expected 1 modification, got %d", got)
+	}
+
+	for _, evt := range world.GetEvents() {
+		if mod, ok := evt.(*event.RunwayModificationEvent); ok {
+			if !mod.Time().Equal(simStart.AddDate(0, 0, 1)) {
+				t.Errorf("Expected modification at phase start, got %v", mod.Time())
+			}
+			if mod.Modification().LengthMeters != 3200 {
+				t.Errorf("Expected length 3200, got %f", mod.Modification().LengthMeters)
+			}
+		}
+	}
+}
+
+func TestCommissioningPolicy_GenerateEvents_UnknownRunway(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy, _ := NewCommissioningPolicy(CommissioningPlan{
+		RunwayDesignation: "99",
+		Phases:            []CommissioningPhase{{Start: simStart, Mode: CommissioningFull}},
+	})
+
+	world := newMockEventWorld(simStart, simStart.AddDate(0, 0, 1), []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err == nil {
+		t.Error("Expected error for unknown runway")
+	}
+}
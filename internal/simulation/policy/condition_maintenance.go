@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// defaultWearCheckInterval is how often runway wear is checked when
+// ConditionMaintenanceSchedule.CheckInterval is not set.
+const defaultWearCheckInterval = time.Hour
+
+// ConditionMaintenanceSchedule defines wear-based maintenance: runways are closed for
+// maintenance after handling a number of movements rather than at a fixed frequency.
+type ConditionMaintenanceSchedule struct {
+	RunwayDesignations      []string      // Runway identifiers to maintain
+	MovementsPerMaintenance float64       // Movements a runway handles between maintenance
+	Duration                time.Duration // Duration of each maintenance closure
+	CheckInterval           time.Duration // How often wear is checked (0 = hourly)
+}
+
+// ConditionMaintenancePolicy models condition-based runway maintenance, where pavement wear
+// rather than the calendar decides when a runway is closed. The movements each runway
+// actually handles during the simulation are counted; once a runway has handled
+// MovementsPerMaintenance movements since its last maintenance, it is closed for Duration at
+// the next wear check. Busy runways are therefore maintained more often than lightly used
+// ones, and closures in turn slow their wear.
+type ConditionMaintenancePolicy struct {
+	schedule ConditionMaintenanceSchedule
+}
+
+// NewConditionMaintenancePolicy creates a new condition-based maintenance policy.
+// Returns an error if the movements between maintenance or the duration is not positive, or
+// the check interval is negative.
+func NewConditionMaintenancePolicy(schedule ConditionMaintenanceSchedule) (*ConditionMaintenancePolicy, error) {
+	if schedule.MovementsPerMaintenance <= 0 {
+		return nil, simerrors.Invalidf("movements per maintenance must be positive, got %g", schedule.MovementsPerMaintenance)
+	}
+	if schedule.Duration <= 0 {
+		return nil, simerrors.Invalidf("maintenance duration must be positive, got %v", schedule.Duration)
+	}
+	if schedule.CheckInterval < 0 {
+		return nil, simerrors.Invalidf("wear check interval cannot be negative, got %v", schedule.CheckInterval)
+	}
+	if schedule.CheckInterval == 0 {
+		schedule.CheckInterval = defaultWearCheckInterval
+	}
+
+	return &ConditionMaintenancePolicy{
+		schedule: schedule,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *ConditionMaintenancePolicy) Name() string {
+	return "ConditionMaintenancePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *ConditionMaintenancePolicy) Parameters() any {
+	return p.schedule
+}
+
+// Validate checks that every runway exists.
+func (p *ConditionMaintenancePolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.schedule.RunwayDesignations)...)
+}
+
+// GenerateEvents generates a RunwayWearCheckEvent for each runway at the start of the
+// simulation and every check interval after it. The checks schedule the maintenance
+// closures as wear accumulates during the run.
+func (p *ConditionMaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	for _, runwayDesignation := range p.schedule.RunwayDesignations {
+		for t := startTime; t.Before(endTime); t = t.Add(p.schedule.CheckInterval) {
+			world.ScheduleEvent(event.NewRunwayWearCheckEvent(runwayDesignation, p.schedule.MovementsPerMaintenance, p.schedule.Duration, t))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestConditionMaintenancePolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 1)
+
+	policy, err := NewConditionMaintenancePolicy(ConditionMaintenanceSchedule{
+		RunwayDesignations:      []string{"09L", "09R"},
+		MovementsPerMaintenance: 5000,
+		Duration:                6 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// Hourly checks for each runway
+	if got := world.CountEventsByType(event.RunwayWearCheckType); got != 48 {
+		t.Errorf("Expected 48 wear checks, got %d", got)
+	}
+	for _, evt := range world.GetEvents() {
+		check, ok := evt.(*event.RunwayWearCheckEvent)
+		if !ok {
+			t.Fatalf("Unexpected %s event", evt.Type())
+		}
+		if check.MovementsPerMaintenance() != 5000 || check.Duration() != 6*time.Hour {
+			t.Errorf("Expected checks for 5000 movements and 6h maintenance, got %g and %v", check.MovementsPerMaintenance(), check.Duration())
+		}
+	}
+}
+
+func TestConditionMaintenancePolicy_InvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule ConditionMaintenanceSchedule
+	}{
+		{"zero movements", ConditionMaintenanceSchedule{RunwayDesignations: []string{"09L"}, Duration: time.Hour}},
+		{"zero duration", ConditionMaintenanceSchedule{RunwayDesignations: []string{"09L"}, MovementsPerMaintenance: 5000}},
+		{"negative check interval", ConditionMaintenanceSchedule{RunwayDesignations: []string{"09L"}, MovementsPerMaintenance: 5000, Duration: time.Hour, CheckInterval: -time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewConditionMaintenancePolicy(tt.schedule); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConditionMaintenancePolicy_Validate(t *testing.T) {
+	policy, err := NewConditionMaintenancePolicy(ConditionMaintenanceSchedule{
+		RunwayDesignations:      []string{"27L"},
+		MovementsPerMaintenance: 5000,
+		Duration:                time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(time.Now(), time.Now().Add(time.Hour), []string{"09L"})
+	var notFound *simerrors.RunwayNotFoundError
+	if err := policy.Validate(world); !errors.As(err, &notFound) {
+		t.Errorf("Expected runway not found error, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// constructionClosureReason labels closure events generated by the construction policy.
+const constructionClosureReason = "construction"
+
+// AirportPolicy is implemented by policies that need runways the airport does not have yet
+// (e.g., a replacement runway opening mid-simulation). The simulation applies PrepareAirport
+// after pre-simulation plugins, so the runways only exist in runs that include the policy.
+type AirportPolicy interface {
+	PrepareAirport(ap airport.Airport) airport.Airport
+}
+
+// ConstructionPlan describes a runway closed for works and what opens when the works end.
+type ConstructionPlan struct {
+	RunwayDesignation string    // Runway closed for the works
+	Start             time.Time // When the runway closes
+	End               time.Time // When the works end and the rebuilt or replacement runway opens
+
+	// Modification lists changes to the runway taking effect when it reopens (e.g., a longer
+	// LengthMeters or a new MinimumSeparation). Not allowed with a Replacement.
+	Modification airport.RunwayModification
+
+	// Replacement is a new runway that opens at End in place of RunwayDesignation, which then
+	// stays closed (e.g., 18/36 rebuilt on a new alignment as 17/35). The policy adds it to the
+	// airport (see AirportPolicy). nil reopens RunwayDesignation.
+	Replacement *airport.Runway
+
+	// CompatibleWith lists the runways the reopened or replacement runway can operate with
+	// from End. nil leaves the compatibility graph unchanged.
+	CompatibleWith []string
+}
+
+// opening returns the designation of the runway that opens when the works end.
+func (plan ConstructionPlan) opening() string {
+	if plan.Replacement != nil {
+		return plan.Replacement.RunwayDesignation
+	}
+	return plan.RunwayDesignation
+}
+
+// ConstructionPolicy models a runway rebuild or extension: the runway is closed for an
+// extended period, then either reopens with modified characteristics and compatibility or is
+// replaced by a new runway. This lets "capacity during the rebuild" studies run as a single
+// simulation rather than two simulations stitched together.
+type ConstructionPolicy struct {
+	plan ConstructionPlan
+}
+
+// NewConstructionPolicy creates a new construction policy with validation.
+// Returns an error if the plan names no runway, the works end before they start, or a
+// modification is combined with a replacement.
+func NewConstructionPolicy(plan ConstructionPlan) (*ConstructionPolicy, error) {
+	if plan.RunwayDesignation == "" {
+		return nil, simerrors.Invalidf("construction plan must name a runway")
+	}
+	if !plan.End.After(plan.Start) {
+		return nil, simerrors.Invalidf("construction must end after it starts, got %v to %v", plan.Start, plan.End)
+	}
+	if plan.Replacement != nil {
+		if !plan.Modification.IsEmpty() {
+			return nil, simerrors.Invalidf("construction modification cannot be combined with a replacement runway; set the replacement's characteristics instead")
+		}
+		if plan.Replacement.RunwayDesignation == plan.RunwayDesignation {
+			return nil, simerrors.Invalidf("replacement runway must have a new designation, got %s", plan.RunwayDesignation)
+		}
+	}
+
+	return &ConstructionPolicy{
+		plan: plan,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *ConstructionPolicy) Name() string {
+	return "ConstructionPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *ConstructionPolicy) Parameters() any {
+	return p.plan
+}
+
+// PrepareAirport adds the replacement runway, if any, to the airport. Until the works end it
+// is closed and, in a compatibility graph, compatible with no other runway.
+func (p *ConstructionPolicy) PrepareAirport(ap airport.Airport) airport.Airport {
+	replacement := p.plan.Replacement
+	if replacement == nil || slices.ContainsFunc(ap.Runways, func(r airport.Runway) bool { return r.RunwayDesignation == replacement.RunwayDesignation }) {
+		return ap
+	}
+
+	ap.Runways = append(ap.Runways, *replacement)
+	if rc := ap.RunwayCompatibility; rc != nil && rc.CompatibleWith != nil {
+		rc.CompatibleWith[replacement.RunwayDesignation] = []string{}
+	}
+	return ap
+}
+
+// Validate checks that the runway under construction, any replacement, and the runways the
+// opening runway is compatible with all exist.
+func (p *ConstructionPolicy) Validate(world EventWorld) error {
+	designations := append([]string{p.plan.RunwayDesignation}, p.plan.CompatibleWith...)
+	if p.plan.Replacement != nil {
+		designations = append(designations, p.plan.Replacement.RunwayDesignation)
+	}
+	return errors.Join(unknownRunways(world, p.Name(), designations)...)
+}
+
+// SetInitialState sets the state of the works at the simulation start (see
+// InitialStatePolicy): the runway is closed if the works have started, a replacement is closed
+// until the works end, and works that ended before the start have opened the rebuilt runway
+// or the replacement.
+func (p *ConstructionPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	if err := p.Validate(world); err != nil {
+		return err
+	}
+	for _, evt := range p.worksEvents(world) {
+		if evt.Time().After(world.GetStartTime()) {
+			break
+		}
+		if err := evt.Apply(ctx, world); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateEvents closes the runway from the start of the works. When the works end within
+// the simulation, it applies the runway modification and compatibility changes and opens the
+// rebuilt runway, or opens the replacement, which is closed until then. The state at the
+// start is set by SetInitialState.
+func (p *ConstructionPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	if err := p.Validate(world); err != nil {
+		return err
+	}
+	for _, evt := range p.worksEvents(world) {
+		if evt.Time().After(world.GetStartTime()) {
+			world.ScheduleEvent(evt)
+		}
+	}
+	return nil
+}
+
+// worksEvents returns the events of the works within the simulation period in the order they
+// apply. Works (and modifications) before the simulation starts take effect at simulation
+// start.
+func (p *ConstructionPolicy) worksEvents(world EventWorld) []event.Event {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	closing := p.plan.Start
+	if closing.Before(startTime) {
+		closing = startTime
+	}
+	opening := p.plan.End
+	if opening.Before(startTime) {
+		opening = startTime
+	}
+	openingRunway := p.plan.opening()
+
+	var events []event.Event
+	// The runway under construction closes; a replaced runway never reopens
+	if closing.Before(endTime) && (p.plan.Replacement != nil || opening.After(closing)) {
+		events = append(events, event.NewRunwayClosureStartEvent(p.plan.RunwayDesignation, constructionClosureReason, closing))
+	}
+	// A replacement is not usable until the works end
+	if p.plan.Replacement != nil && opening.After(startTime) {
+		events = append(events, event.NewRunwayClosureStartEvent(openingRunway, constructionClosureReason, startTime))
+	}
+
+	if opening.Before(endTime) {
+		if !p.plan.Modification.IsEmpty() {
+			events = append(events, event.NewRunwayModificationEvent(openingRunway, p.plan.Modification, opening))
+		}
+		if p.plan.CompatibleWith != nil {
+			events = append(events, event.NewRunwayCompatibilityChangeEvent(openingRunway, p.plan.CompatibleWith, opening))
+		}
+		if opening.After(startTime) {
+			events = append(events, event.NewRunwayClosureEndEvent(openingRunway, constructionClosureReason, opening))
+		}
+	}
+	return events
+}
@@ -0,0 +1,203 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewConstructionPolicy(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	replacement := &airport.Runway{RunwayDesignation: "17", TrueBearing: 170, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+
+	tests := []struct {
+		name        string
+		plan        ConstructionPlan
+		expectError bool
+	}{
+		{
+			name: "valid rebuild",
+			plan: ConstructionPlan{
+				RunwayDesignation: "18",
+				Start:             base,
+				End:               base.AddDate(0, 4, 0),
+				Modification:      airport.RunwayModification{LengthMeters: 3500},
+			},
+		},
+		{
+			name:        "valid replacement",
+			plan:        ConstructionPlan{RunwayDesignation: "18", Start: base, End: base.AddDate(0, 4, 0), Replacement: replacement},
+			expectError: false,
+		},
+		{
+			name:        "no runway",
+			plan:        ConstructionPlan{Start: base, End: base.AddDate(0, 4, 0)},
+			expectError: true,
+		},
+		{
+			name:        "ends before it starts",
+			plan:        ConstructionPlan{RunwayDesignation: "18", Start: base, End: base},
+			expectError: true,
+		},
+		{
+			name: "modification with replacement",
+			plan: ConstructionPlan{
+				RunwayDesignation: "18",
+				Start:             base,
+				End:               base.AddDate(0, 4, 0),
+				Modification:      airport.RunwayModification{LengthMeters: 3500},
+				Replacement:       replacement,
+			},
+			expectError: true,
+		},
+		{
+			name: "replacement with the same designation",
+			plan: ConstructionPlan{
+				RunwayDesignation: "17",
+				Start:             base,
+				End:               base.AddDate(0, 4, 0),
+				Replacement:       replacement,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConstructionPolicy(tt.plan)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConstructionPolicy_GenerateEvents_Rebuild(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(1, 0, 0)
+	worksStart := simStart.AddDate(0, 2, 0)
+	worksEnd := simStart.AddDate(0, 6, 0)
+
+	policy, err := NewConstructionPolicy(ConstructionPlan{
+		RunwayDesignation: "18",
+		Start:             worksStart,
+		End:               worksEnd,
+		Modification:      airport.RunwayModification{LengthMeters: 3500},
+		CompatibleWith:    []string{"09"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09", "18"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	expected := []struct {
+		eventType event.EventType
+		time      time.Time
+	}{
+		{event.RunwayClosureStartType, worksStart},
+		{event.RunwayModificationType, worksEnd},
+		{event.RunwayCompatibilityChangeType, worksEnd},
+		{event.RunwayClosureEndType, worksEnd},
+	}
+	if len(world.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(world.events))
+	}
+	for i, want := range expected {
+		if got := world.events[i]; got.Type() != want.eventType || !got.Time().Equal(want.time) {
+			t.Errorf("Event %d: expected %v at %v, got %v at %v", i, want.eventType, want.time, got.Type(), got.Time())
+		}
+	}
+	if compat := world.events[2].(*event.RunwayCompatibilityChangeEvent); !slices.Equal(compat.CompatibleWith(), []string{"09"}) {
+		t.Errorf("Expected 18 compatible with [09], got %v", compat.CompatibleWith())
+	}
+}
+
+func TestConstructionPolicy_GenerateEvents_Replacement(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(1, 0, 0)
+	worksEnd := simStart.AddDate(0, 6, 0)
+
+	// Works started before the simulation, so 18 is closed from the start
+	policy, err := NewConstructionPolicy(ConstructionPlan{
+		RunwayDesignation: "18",
+		Start:             simStart.AddDate(0, -3, 0),
+		End:               worksEnd,
+		Replacement:       &airport.Runway{RunwayDesignation: "17", TrueBearing: 170, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	// The replacement must be in the airport
+	world := newMockEventWorld(simStart, simEnd, []string{"09", "18"})
+	if err := policy.Validate(world); err == nil {
+		t.Error("Expected validation error for a replacement missing from the airport")
+	}
+
+	initial := newMockInitialStateWorld(simStart, simEnd)
+	initial.runwayIDs = []string{"09", "18", "17"}
+	if err := policy.SetInitialState(context.Background(), initial); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := policy.GenerateEvents(context.Background(), initial); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// 18 closes for good and 17 is closed until the works end, both from the initial state
+	if !slices.Equal(initial.closedRunways, []string{"18", "17"}) {
+		t.Errorf("Expected 18 and 17 closed at the start, got %v", initial.closedRunways)
+	}
+	if events := initial.eventsAt(simStart); len(events) != 0 {
+		t.Errorf("Expected no events at the start time, got %d", len(events))
+	}
+	if len(initial.events) != 1 {
+		t.Fatalf("Expected only the closure end, got %d events", len(initial.events))
+	}
+	if e, ok := initial.events[0].(*event.RunwayClosureEndEvent); !ok || e.RunwayID() != "17" || !e.Time().Equal(worksEnd) {
+		t.Errorf("Expected 17 to open at %v, got %v at %v", worksEnd, initial.events[0].Type(), initial.events[0].Time())
+	}
+}
+
+func TestConstructionPolicy_PrepareAirport(t *testing.T) {
+	policy, err := NewConstructionPolicy(ConstructionPlan{
+		RunwayDesignation: "18",
+		Start:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:               time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		Replacement:       &airport.Runway{RunwayDesignation: "17", TrueBearing: 170, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	ap := airport.Airport{
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, LengthMeters: 2500, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{"09": {}, "18": {}}),
+	}
+
+	prepared := policy.PrepareAirport(ap)
+	if len(prepared.Runways) != 3 || prepared.Runways[2].RunwayDesignation != "17" {
+		t.Fatalf("Expected 17 added to the airport, got %v", prepared.Runways)
+	}
+	if err := prepared.Validate(); err != nil {
+		t.Errorf("Expected prepared airport to be valid, got %v", err)
+	}
+
+	// Preparing twice does not add the runway again
+	if again := policy.PrepareAirport(prepared); len(again.Runways) != 3 {
+		t.Errorf("Expected 3 runways after preparing twice, got %d", len(again.Runways))
+	}
+}
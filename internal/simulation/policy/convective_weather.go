@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Reasons recorded on disruptions generated by the convective weather policy.
+const (
+	convectiveClosureReason   = "convective weather closure"
+	convectiveReductionReason = "convective weather"
+)
+
+// hoursPerYear is the mean length of a year in hours, used to turn annual frequencies into rates.
+const hoursPerYear = 365.25 * 24
+
+// ConvectiveWeatherConfiguration defines how often convective weather disrupts the airfield,
+// for how long, and how severely.
+type ConvectiveWeatherConfiguration struct {
+	EventsPerYear float64 // Mean number of disruptions per year
+
+	// MonthlyWeights optionally gives the relative frequency of disruptions in each month
+	// (12 values, January first), e.g., to concentrate thunderstorms in summer. nil spreads
+	// them evenly.
+	MonthlyWeights []float64
+
+	// HourlyWeights optionally gives the relative frequency of disruptions starting in each
+	// hour of the day (24 values, UTC), e.g., to favour afternoon convection. nil spreads
+	// them evenly.
+	HourlyWeights []float64
+
+	Duration DurationDistribution // Distribution of each disruption's duration
+
+	TotalClosureProbability float64 // Share [0, 1] of disruptions that close the airfield completely
+	PartialCapacityFactor   float64 // Fraction (0, 1) of capacity remaining in a partial disruption
+}
+
+// ConvectiveWeatherPolicy models short-duration disruptions from convective weather, such as
+// wind shear and microburst alerts or thunderstorms overhead, which suspend or reduce
+// movements across the whole airfield for minutes to hours.
+//
+// Disruptions start at random as a Poisson process whose rate follows the configured monthly
+// and hourly weights, so EventsPerYear is the expected number in a year. Each lasts a duration
+// drawn from the configured distribution and either closes the airfield or leaves
+// PartialCapacityFactor of its capacity. Disruptions are drawn from the simulation's random
+// source, so seeded runs are reproducible.
+type ConvectiveWeatherPolicy struct {
+	config ConvectiveWeatherConfiguration
+	rng    *rand.Rand // Random source for disruptions (set by the simulation)
+}
+
+// NewConvectiveWeatherPolicy creates a new convective weather policy with validation.
+// Returns an error if the frequency is not positive, the weights have the wrong length or are
+// negative, the duration distribution is missing or invalid, or the severity is out of range.
+func NewConvectiveWeatherPolicy(config ConvectiveWeatherConfiguration) (*ConvectiveWeatherPolicy, error) {
+	if config.EventsPerYear <= 0 {
+		return nil, simerrors.Invalidf("convective weather events per year must be positive, got %g", config.EventsPerYear)
+	}
+	if err := validateWeights("monthly", config.MonthlyWeights, 12); err != nil {
+		return nil, err
+	}
+	if err := validateWeights("hourly", config.HourlyWeights, 24); err != nil {
+		return nil, err
+	}
+	if config.Duration == nil {
+		return nil, simerrors.Invalidf("convective weather duration distribution is required")
+	}
+	if err := config.Duration.Validate(); err != nil {
+		return nil, err
+	}
+	if config.TotalClosureProbability < 0 || config.TotalClosureProbability > 1 {
+		return nil, simerrors.Invalidf("total closure probability must be in [0, 1], got %g", config.TotalClosureProbability)
+	}
+	if config.TotalClosureProbability < 1 && (config.PartialCapacityFactor <= 0 || config.PartialCapacityFactor >= 1) {
+		return nil, simerrors.Invalidf("partial capacity factor must be in (0, 1), got %g", config.PartialCapacityFactor)
+	}
+
+	return &ConvectiveWeatherPolicy{
+		config: config,
+	}, nil
+}
+
+// validateWeights checks that optional relative weights have the expected length, no
+// negative values and a positive total.
+func validateWeights(kind string, weights []float64, length int) error {
+	if weights == nil {
+		return nil
+	}
+	if len(weights) != length {
+		return simerrors.Invalidf("%s weights need %d values, got %d", kind, length, len(weights))
+	}
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return simerrors.Invalidf("%s weights cannot be negative, got %g", kind, weight)
+		}
+		total += weight
+	}
+	if total <= 0 {
+		return simerrors.Invalidf("%s weights must sum to a positive total", kind)
+	}
+	return nil
+}
+
+// relativeWeight returns weights[i] relative to the mean weight and the largest such value
+// (1 and 1 when weights is nil).
+func relativeWeight(weights []float64, i int) (weight, peak float64) {
+	if weights == nil {
+		return 1, 1
+	}
+	mean := 0.0
+	for _, w := range weights {
+		mean += w
+	}
+	mean /= float64(len(weights))
+	return weights[i] / mean, slices.Max(weights) / mean
+}
+
+// Name returns the policy name.
+func (p *ConvectiveWeatherPolicy) Name() string {
+	return "ConvectiveWeatherPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *ConvectiveWeatherPolicy) Parameters() any {
+	return p.config
+}
+
+// SetRandomSource sets the random source used to generate disruptions.
+// This implements the StochasticPolicy interface.
+func (p *ConvectiveWeatherPolicy) SetRandomSource(rng *rand.Rand) {
+	p.rng = rng
+}
+
+// GenerateEvents generates a DisruptionStartEvent and DisruptionEndEvent for every disruption
+// starting within the simulation period. Disruptions still in progress at the end of the
+// simulation have no end event.
+func (p *ConvectiveWeatherPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	if p.rng == nil {
+		p.rng = NewRandomSource(0, 0)
+	}
+
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	// Sample the time-varying Poisson process by thinning: draw candidates at the peak rate
+	// and keep each with probability rate/peak rate
+	_, peakMonth := relativeWeight(p.config.MonthlyWeights, 0)
+	_, peakHour := relativeWeight(p.config.HourlyWeights, 0)
+	peakRatePerHour := p.config.EventsPerYear / hoursPerYear * peakMonth * peakHour
+
+	for t := startTime; ; {
+		t = t.Add(time.Duration(p.rng.ExpFloat64() / peakRatePerHour * float64(time.Hour)))
+		if !t.Before(endTime) {
+			break
+		}
+		monthWeight, _ := relativeWeight(p.config.MonthlyWeights, int(t.Month())-1)
+		hourWeight, _ := relativeWeight(p.config.HourlyWeights, t.UTC().Hour())
+		if p.rng.Float64()*peakMonth*peakHour >= monthWeight*hourWeight {
+			continue
+		}
+
+		duration := p.config.Duration.Sample(p.rng)
+		if duration <= 0 {
+			continue
+		}
+		disruption := &event.Disruption{Reason: convectiveReductionReason, CapacityFactor: p.config.PartialCapacityFactor}
+		if p.rng.Float64() < p.config.TotalClosureProbability {
+			disruption = &event.Disruption{Reason: convectiveClosureReason, CapacityFactor: 0}
+		}
+
+		world.ScheduleEvent(event.NewDisruptionStartEvent(disruption, t))
+		if end := t.Add(duration); end.Before(endTime) {
+			world.ScheduleEvent(event.NewDisruptionEndEvent(disruption, end))
+		}
+	}
+
+	return nil
+}
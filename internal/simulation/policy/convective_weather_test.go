@@ -0,0 +1,202 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewConvectiveWeatherPolicy(t *testing.T) {
+	valid := ConvectiveWeatherConfiguration{
+		EventsPerYear:           50,
+		Duration:                FixedDuration(20 * time.Minute),
+		TotalClosureProbability: 0.5,
+		PartialCapacityFactor:   0.5,
+	}
+	if _, err := NewConvectiveWeatherPolicy(valid); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*ConvectiveWeatherConfiguration)
+	}{
+		{"zero frequency", func(c *ConvectiveWeatherConfiguration) { c.EventsPerYear = 0 }},
+		{"short monthly weights", func(c *ConvectiveWeatherConfiguration) { c.MonthlyWeights = []float64{1, 2} }},
+		{"negative hourly weight", func(c *ConvectiveWeatherConfiguration) {
+			c.HourlyWeights = make([]float64, 24)
+			c.HourlyWeights[0] = -1
+		}},
+		{"zero hourly weights", func(c *ConvectiveWeatherConfiguration) { c.HourlyWeights = make([]float64, 24) }},
+		{"missing duration", func(c *ConvectiveWeatherConfiguration) { c.Duration = nil }},
+		{"invalid duration", func(c *ConvectiveWeatherConfiguration) {
+			c.Duration = UniformDuration{Min: time.Hour, Max: time.Minute}
+		}},
+		{"closure probability above 1", func(c *ConvectiveWeatherConfiguration) { c.TotalClosureProbability = 1.5 }},
+		{"missing partial factor", func(c *ConvectiveWeatherConfiguration) { c.PartialCapacityFactor = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if _, err := NewConvectiveWeatherPolicy(config); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+
+	// Partial capacity is irrelevant when every disruption closes the airfield
+	closuresOnly := valid
+	closuresOnly.TotalClosureProbability = 1
+	closuresOnly.PartialCapacityFactor = 0
+	if _, err := NewConvectiveWeatherPolicy(closuresOnly); err != nil {
+		t.Errorf("Unexpected error for closures only: %v", err)
+	}
+}
+
+func TestConvectiveWeatherPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(1, 0, 0)
+
+	// Summer afternoon storms only
+	monthly := make([]float64, 12)
+	monthly[time.June-1], monthly[time.July-1] = 1, 1
+	hourly := make([]float64, 24)
+	for hour := 14; hour < 18; hour++ {
+		hourly[hour] = 1
+	}
+
+	p, err := NewConvectiveWeatherPolicy(ConvectiveWeatherConfiguration{
+		EventsPerYear:           500,
+		MonthlyWeights:          monthly,
+		HourlyWeights:           hourly,
+		Duration:                ExponentialDuration{Mean: 30 * time.Minute, Max: 2 * time.Hour},
+		TotalClosureProbability: 0.3,
+		PartialCapacityFactor:   0.6,
+	})
+	if err != nil {
+		t.Fatalf("NewConvectiveWeatherPolicy: %v", err)
+	}
+	p.SetRandomSource(NewRandomSource(3, 0))
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	starts := world.CountEventsByType(event.DisruptionStartType)
+	if math.Abs(float64(starts)-500) > 75 {
+		t.Errorf("Expected about 500 disruptions, got %d", starts)
+	}
+	if ends := world.CountEventsByType(event.DisruptionEndType); ends != starts {
+		t.Errorf("Expected an end for each of %d disruptions, got %d", starts, ends)
+	}
+
+	closures := 0
+	for _, e := range world.events {
+		start, ok := e.(*event.DisruptionStartEvent)
+		if !ok {
+			continue
+		}
+		if month, hour := start.Time().Month(), start.Time().Hour(); (month != time.June && month != time.July) || hour < 14 || hour >= 18 {
+			t.Fatalf("Disruption at %v outside summer afternoons", start.Time())
+		}
+		switch start.Disruption().CapacityFactor {
+		case 0:
+			closures++
+		case 0.6:
+		default:
+			t.Fatalf("Unexpected capacity factor %g", start.Disruption().CapacityFactor)
+		}
+	}
+	if share := float64(closures) / float64(starts); math.Abs(share-0.3) > 0.06 {
+		t.Errorf("Expected about 30%% total closures, got %.0f%%", share*100)
+	}
+}
+
+func TestDurationDistributions(t *testing.T) {
+	rng := NewRandomSource(1, 0)
+	const samples = 20000
+
+	mean := func(d DurationDistribution) time.Duration {
+		var total time.Duration
+		for range samples {
+			total += d.Sample(rng)
+		}
+		return total / samples
+	}
+
+	if got := mean(FixedDuration(10 * time.Minute)); got != 10*time.Minute {
+		t.Errorf("Fixed: expected 10m, got %v", got)
+	}
+	if got := mean(UniformDuration{Min: 10 * time.Minute, Max: 30 * time.Minute}); (got - 20*time.Minute).Abs() > time.Minute {
+		t.Errorf("Uniform: expected mean 20m, got %v", got)
+	}
+	if got := mean(ExponentialDuration{Mean: 20 * time.Minute}); (got - 20*time.Minute).Abs() > time.Minute {
+		t.Errorf("Exponential: expected mean 20m, got %v", got)
+	}
+	// Mean of a log-normal is median * exp(sigma^2 / 2)
+	if got, want := mean(LogNormalDuration{Median: 20 * time.Minute, Sigma: 0.5}), time.Duration(float64(20*time.Minute)*math.Exp(0.125)); (got - want).Abs() > time.Minute {
+		t.Errorf("Log-normal: expected mean %v, got %v", want, got)
+	}
+
+	for _, d := range []DurationDistribution{
+		FixedDuration(0),
+		UniformDuration{Min: time.Minute, Max: time.Minute},
+		ExponentialDuration{Mean: time.Hour, Max: time.Minute},
+		LogNormalDuration{Median: time.Minute, Sigma: -1},
+		PercentileDuration{P50: time.Hour, P90: time.Minute},
+		HistogramDuration{Bins: []HistogramBin{{End: time.Hour, Weight: 0}}},
+		HistogramDuration{Start: time.Hour, Bins: []HistogramBin{{End: time.Minute, Weight: 1}}},
+	} {
+		if err := d.Validate(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%T: expected invalid configuration error, got %v", d, err)
+		}
+	}
+}
+
+func TestDurationQuantile(t *testing.T) {
+	rng := NewRandomSource(1, 0)
+	histogram := HistogramDuration{Start: 20 * time.Minute, Bins: []HistogramBin{
+		{End: 40 * time.Minute, Weight: 1},
+		{End: time.Hour, Weight: 2},
+		{End: 2 * time.Hour, Weight: 1},
+	}}
+
+	tests := []struct {
+		name         string
+		distribution DurationDistribution
+		p            float64
+		want         time.Duration
+	}{
+		{"fixed", FixedDuration(10 * time.Minute), 0.9, 10 * time.Minute},
+		{"uniform", UniformDuration{Min: 10 * time.Minute, Max: 30 * time.Minute}, 0.25, 15 * time.Minute},
+		{"exponential median", ExponentialDuration{Mean: time.Hour}, 0.5, time.Duration(math.Round(math.Ln2 * float64(time.Hour)))},
+		{"exponential capped", ExponentialDuration{Mean: time.Hour, Max: 90 * time.Minute}, 0.99, 90 * time.Minute},
+		{"log-normal median", LogNormalDuration{Median: 20 * time.Minute, Sigma: 0.5}, 0.5, 20 * time.Minute},
+		{"percentiles P50", PercentileDuration{P50: 45 * time.Minute, P90: 90 * time.Minute}, 0.5, 45 * time.Minute},
+		{"percentiles P90", PercentileDuration{P50: 45 * time.Minute, P90: 90 * time.Minute}, 0.9, 90 * time.Minute},
+		// Half the weight lies below the middle of the second bin
+		{"histogram median", histogram, 0.5, 50 * time.Minute},
+		{"histogram P25", histogram, 0.25, 40 * time.Minute},
+		{"histogram P90", histogram, 0.9, 96 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DurationQuantile(tt.distribution, tt.p, rng); (got - tt.want).Abs() > time.Second {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Distributions without a quantile function are sampled; the histogram's samples follow
+	// its quantiles
+	if got := DurationQuantile(struct{ DurationDistribution }{histogram}, 0.9, rng); (got - 96*time.Minute).Abs() > 2*time.Minute {
+		t.Errorf("Sampled histogram: expected about 1h36m, got %v", got)
+	}
+}
@@ -2,19 +2,19 @@ package policy
 
 import (
 	"context"
-	"errors"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 // Common errors for curfew policy validation
 var (
 	// ErrInvalidCurfewTime indicates the curfew time range is invalid
-	ErrInvalidCurfewTime = errors.New("curfew end time must be after start time")
+	ErrInvalidCurfewTime = simerrors.Invalidf("curfew end time must be after start time")
 
 	// ErrCurfewTooLong indicates the curfew duration exceeds reasonable limits
-	ErrCurfewTooLong = errors.New("curfew duration exceeds maximum allowed duration")
+	ErrCurfewTooLong = simerrors.Invalidf("curfew duration exceeds maximum allowed duration")
 )
 
 const (
@@ -38,8 +38,26 @@ type EventWorld interface {
 	GetRunwayIDs() []string
 }
 
+// LocationWorld is implemented by worlds that expose the airport's local time zone.
+type LocationWorld interface {
+	GetLocation() *time.Location
+}
+
+// location returns the time zone daily schedules are laid out in: the airport's time zone
+// if the world has one, otherwise the time zone of the simulation start time.
+func location(world EventWorld) *time.Location {
+	if lw, ok := world.(LocationWorld); ok {
+		if loc := lw.GetLocation(); loc != nil {
+			return loc
+		}
+	}
+	return world.GetStartTime().Location()
+}
+
 // CurfewPolicy restricts airport operations during specified time ranges.
 // It reduces the effective operating hours of the airport.
+// The curfew's clock times are local times at the airport when the airport has a time zone,
+// so the curfew follows daylight saving time changes.
 type CurfewPolicy struct {
 	startTime time.Time // Start of curfew period
 	endTime   time.Time // End of curfew period
@@ -65,59 +83,55 @@ func NewCurfewPolicy(startTime, endTime time.Time) (*CurfewPolicy, error) {
 	}, nil
 }
 
+// GetStartTime returns the start of the first curfew.
+func (p *CurfewPolicy) GetStartTime() time.Time {
+	return p.startTime
+}
+
+// GetEndTime returns the end of the first curfew.
+func (p *CurfewPolicy) GetEndTime() time.Time {
+	return p.endTime
+}
+
 // Name returns the policy name.
 func (p *CurfewPolicy) Name() string {
 	return "CurfewPolicy"
 }
 
+// Parameters returns the policy configuration.
+func (p *CurfewPolicy) Parameters() any {
+	return struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	}{p.startTime, p.endTime}
+}
+
+// Validate checks that the curfew starts within the simulation period.
+func (p *CurfewPolicy) Validate(world EventWorld) error {
+	if outsideSimulation(world, p.startTime) {
+		return simerrors.Invalidf("%s: curfew start %v is outside the simulation period %v to %v",
+			p.Name(), p.startTime, world.GetStartTime(), world.GetEndTime())
+	}
+	return nil
+}
+
 // GenerateEvents generates curfew start and end events for every day in the simulation period.
 // This implements the EventGeneratingPolicy interface for event-driven simulations.
 func (p *CurfewPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	startTime := world.GetStartTime()
 	endTime := world.GetEndTime()
 
-	// Extract hour and minute from the curfew times
-	curfewStartHour, curfewStartMinute := p.startTime.Hour(), p.startTime.Minute()
-	curfewEndHour, curfewEndMinute := p.endTime.Hour(), p.endTime.Minute()
-
-	// Generate daily curfew events for the entire simulation period
-	currentDate := startTime
-	eventCount := 0
-
-	for currentDate.Before(endTime) {
-		// Create curfew start event for this day
-		curfewStart := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			curfewStartHour, curfewStartMinute, 0, 0,
-			currentDate.Location(),
-		)
-
+	// Generate daily curfew events for the entire simulation period, laid out in local time
+	for _, curfew := range DailyWindows(startTime, endTime, p.startTime, p.endTime, location(world)) {
 		// Only schedule if within simulation period
-		if !curfewStart.Before(startTime) && !curfewStart.After(endTime) {
-			world.ScheduleEvent(event.NewCurfewStartEvent(curfewStart))
-			eventCount++
+		if !curfew.Start.Before(startTime) && !curfew.Start.After(endTime) {
+			world.ScheduleEvent(event.NewCurfewStartEvent(curfew.Start))
 		}
 
-		// Create curfew end event for this day (might be next day if overnight curfew)
-		curfewEnd := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			curfewEndHour, curfewEndMinute, 0, 0,
-			currentDate.Location(),
-		)
-
-		// Handle overnight curfews (end time is before start time)
-		if curfewEndHour < curfewStartHour || (curfewEndHour == curfewStartHour && curfewEndMinute < curfewStartMinute) {
-			curfewEnd = curfewEnd.AddDate(0, 0, 1)
+		// The end might be the next day for overnight curfews (inclusive of end time)
+		if !curfew.End.Before(startTime) && !curfew.End.After(endTime) {
+			world.ScheduleEvent(event.NewCurfewEndEvent(curfew.End))
 		}
-
-		// Only schedule if within simulation period (inclusive of end time)
-		if !curfewEnd.Before(startTime) && !curfewEnd.After(endTime) {
-			world.ScheduleEvent(event.NewCurfewEndEvent(curfewEnd))
-			eventCount++
-		}
-
-		// Move to next day
-		currentDate = currentDate.AddDate(0, 0, 1)
 	}
 
 	return nil
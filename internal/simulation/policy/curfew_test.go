@@ -44,50 +44,50 @@ func TestCurfewPolicy_Name(t *testing.T) {
 
 func TestCurfewPolicy_GenerateEvents(t *testing.T) {
 	tests := []struct {
-		name                    string
-		curfewStartTime         time.Time
-		curfewEndTime           time.Time
-		simStartTime            time.Time
-		simEndTime              time.Time
-		expectedCurfewStarts    int
-		expectedCurfewEnds      int
-		verifyFirstEventTime    bool
-		expectedFirstEventHour  int
-		expectedFirstEventMin   int
+		name                   string
+		curfewStartTime        time.Time
+		curfewEndTime          time.Time
+		simStartTime           time.Time
+		simEndTime             time.Time
+		expectedCurfewStarts   int
+		expectedCurfewEnds     int
+		verifyFirstEventTime   bool
+		expectedFirstEventHour int
+		expectedFirstEventMin  int
 	}{
 		{
-			name:                    "7 hour nightly curfew (11pm-6am) for 1 week",
-			curfewStartTime:         time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
-			curfewEndTime:           time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
-			simStartTime:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			simEndTime:              time.Date(2024, 1, 8, 6, 0, 0, 0, time.UTC), // Extended to include last curfew end
-			expectedCurfewStarts:    7,
-			expectedCurfewEnds:      7,
-			verifyFirstEventTime:    true,
-			expectedFirstEventHour:  23,
-			expectedFirstEventMin:   0,
+			name:                   "7 hour nightly curfew (11pm-6am) for 1 week",
+			curfewStartTime:        time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			curfewEndTime:          time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+			simStartTime:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			simEndTime:             time.Date(2024, 1, 8, 6, 0, 0, 0, time.UTC), // Extended to include last curfew end
+			expectedCurfewStarts:   7,
+			expectedCurfewEnds:     7,
+			verifyFirstEventTime:   true,
+			expectedFirstEventHour: 23,
+			expectedFirstEventMin:  0,
 		},
 		{
-			name:                    "Full year simulation",
-			curfewStartTime:         time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
-			curfewEndTime:           time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
-			simStartTime:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			simEndTime:              time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC), // Extended to include last curfew end
-			expectedCurfewStarts:    366, // 2024 is a leap year
-			expectedCurfewEnds:      366,
-			verifyFirstEventTime:    false,
+			name:                 "Full year simulation",
+			curfewStartTime:      time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			curfewEndTime:        time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+			simStartTime:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			simEndTime:           time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC), // Extended to include last curfew end
+			expectedCurfewStarts: 366,                                         // 2024 is a leap year
+			expectedCurfewEnds:   366,
+			verifyFirstEventTime: false,
 		},
 		{
-			name:                    "4 hour curfew (midnight-4am)",
-			curfewStartTime:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			curfewEndTime:           time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
-			simStartTime:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			simEndTime:              time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), // 3 days
-			expectedCurfewStarts:    3,
-			expectedCurfewEnds:      3,
-			verifyFirstEventTime:    true,
-			expectedFirstEventHour:  0,
-			expectedFirstEventMin:   0,
+			name:                   "4 hour curfew (midnight-4am)",
+			curfewStartTime:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			curfewEndTime:          time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
+			simStartTime:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			simEndTime:             time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), // 3 days
+			expectedCurfewStarts:   3,
+			expectedCurfewEnds:     3,
+			verifyFirstEventTime:   true,
+			expectedFirstEventHour: 0,
+			expectedFirstEventMin:  0,
 		},
 	}
 
@@ -256,3 +256,70 @@ func TestNewCurfewPolicy_Validation(t *testing.T) {
 		})
 	}
 }
+
+// locatedEventWorld is a mock event world with an airport time zone.
+type locatedEventWorld struct {
+	*mockEventWorld
+	location *time.Location
+}
+
+func (w *locatedEventWorld) GetLocation() *time.Location {
+	return w.location
+}
+
+func TestCurfewPolicy_FollowsDaylightSavingTime(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 23:00-06:00 local; British Summer Time starts on March 31st 2024
+	policy, err := NewCurfewPolicy(
+		time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatalf("NewCurfewPolicy failed: %v", err)
+	}
+
+	simStart := time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)
+	world := &locatedEventWorld{
+		mockEventWorld: newMockEventWorld(simStart, simStart.AddDate(0, 0, 4), []string{"09L"}),
+		location:       london,
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	expected := map[event.EventType][]time.Time{
+		event.CurfewStartType: {
+			time.Date(2024, 3, 29, 23, 0, 0, 0, time.UTC), // GMT
+			time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC), // BST
+			time.Date(2024, 4, 1, 22, 0, 0, 0, time.UTC),  // Ends after the simulation period
+		},
+		event.CurfewEndType: {
+			time.Date(2024, 3, 30, 6, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 31, 5, 0, 0, 0, time.UTC), // The night the clocks go forward
+			time.Date(2024, 4, 1, 5, 0, 0, 0, time.UTC),
+		},
+	}
+	actual := map[event.EventType][]time.Time{}
+	for _, evt := range world.GetEvents() {
+		if evt.Time().Location() != time.UTC {
+			t.Errorf("Expected event times in the simulation's time zone, got %v", evt.Time())
+		}
+		actual[evt.Type()] = append(actual[evt.Type()], evt.Time())
+	}
+
+	for eventType, times := range expected {
+		if len(actual[eventType]) != len(times) {
+			t.Fatalf("Expected %d %v events, got %v", len(times), eventType, actual[eventType])
+		}
+		for i, want := range times {
+			if got := actual[eventType][i]; !got.Equal(want) {
+				t.Errorf("%v event %d: expected %v, got %v", eventType, i, want, got)
+			}
+		}
+	}
+}
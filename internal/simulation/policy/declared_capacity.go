@@ -0,0 +1,44 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// DeclaredCapacityPolicy models the declared capacity of a slot-coordinated airport: a
+// regulatory limit on movements per rolling hour (e.g., 88) that the coordinator will schedule,
+// applied on top of the physical capacity of the runways. Windows whose physical capacity
+// exceeds the declared rate are clamped to it.
+type DeclaredCapacityPolicy struct {
+	movementsPerHour float64
+}
+
+// NewDeclaredCapacityPolicy creates a new declared capacity policy.
+// Returns an error if the declared capacity is not positive.
+func NewDeclaredCapacityPolicy(movementsPerHour float64) (*DeclaredCapacityPolicy, error) {
+	if movementsPerHour <= 0 {
+		return nil, simerrors.Invalidf("declared capacity must be positive, got %g", movementsPerHour)
+	}
+
+	return &DeclaredCapacityPolicy{
+		movementsPerHour: movementsPerHour,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *DeclaredCapacityPolicy) Name() string {
+	return "DeclaredCapacityPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *DeclaredCapacityPolicy) Parameters() any {
+	return p.movementsPerHour
+}
+
+// GenerateEvents generates a declared capacity event at simulation start.
+func (p *DeclaredCapacityPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	world.ScheduleEvent(event.NewDeclaredCapacityEvent(p.movementsPerHour, world.GetStartTime()))
+	return nil
+}
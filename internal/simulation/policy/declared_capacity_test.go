@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewDeclaredCapacityPolicy(t *testing.T) {
+	for _, movementsPerHour := range []float64{0, -88} {
+		if _, err := NewDeclaredCapacityPolicy(movementsPerHour); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("Expected invalid configuration error for %g, got %v", movementsPerHour, err)
+		}
+	}
+
+	p, err := NewDeclaredCapacityPolicy(88)
+	if err != nil {
+		t.Fatalf("NewDeclaredCapacityPolicy: %v", err)
+	}
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(1, 0, 0), []string{"09"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+	if got := world.CountEventsByType(event.DeclaredCapacityType); got != 1 {
+		t.Errorf("Expected 1 declared capacity event, got %d", got)
+	}
+}
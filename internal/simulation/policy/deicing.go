@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// DeicingConfiguration defines the airport's de-icing facilities and when they are needed.
+type DeicingConfiguration struct {
+	Pads             int           // Number of de-icing pads
+	DeicingTime      time.Duration // Time each departing aircraft occupies a pad
+	ThresholdCelsius float64       // Temperature below which departures need de-icing (e.g., 3 °C)
+}
+
+// DeicingPolicy models the throughput cap de-icing places on departures in cold weather.
+// Every departure occupies one of a limited number of pads for the de-icing time, so while
+// the outside air temperature is below the threshold the pads sustain at most
+// Pads / DeicingTime departures, matched by as many arrivals. Like the gate capacity
+// constraint, this caps the airport's total movements when it is below the runways' capacity.
+//
+// The temperature comes from a temperature schedule (see TemperaturePolicy); without one the
+// standard atmosphere applies and the constraint is never active.
+type DeicingPolicy struct {
+	config DeicingConfiguration
+}
+
+// NewDeicingPolicy creates a new de-icing policy with validation.
+// Returns an error if the number of pads or the de-icing time is not positive, or the
+// threshold is outside MinTemperatureCelsius to MaxTemperatureCelsius.
+func NewDeicingPolicy(config DeicingConfiguration) (*DeicingPolicy, error) {
+	if config.Pads <= 0 {
+		return nil, simerrors.Invalidf("de-icing pads must be positive, got %d", config.Pads)
+	}
+	if config.DeicingTime <= 0 {
+		return nil, simerrors.Invalidf("de-icing time must be positive, got %v", config.DeicingTime)
+	}
+	if config.ThresholdCelsius < MinTemperatureCelsius || config.ThresholdCelsius > MaxTemperatureCelsius {
+		return nil, simerrors.Invalidf("de-icing threshold must be between %.0f and %.0f °C, got %g",
+			MinTemperatureCelsius, MaxTemperatureCelsius, config.ThresholdCelsius)
+	}
+
+	return &DeicingPolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *DeicingPolicy) Name() string {
+	return "DeicingPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *DeicingPolicy) Parameters() any {
+	return p.config
+}
+
+// GenerateEvents generates a de-icing constraint event at simulation start. The world then
+// caps capacity whenever the current temperature is below the threshold.
+func (p *DeicingPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	// Each pad de-ices one departure per de-icing time, and in steady state arrivals match
+	// departures, so total movements are twice the de-iced departures
+	departuresPerSecond := float32(p.config.Pads) / float32(p.config.DeicingTime.Seconds())
+
+	world.ScheduleEvent(event.NewDeicingConstraintEvent(
+		departuresPerSecond*2,
+		p.config.ThresholdCelsius,
+		world.GetStartTime(),
+	))
+	return nil
+}
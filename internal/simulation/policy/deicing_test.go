@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewDeicingPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  DeicingConfiguration
+		wantErr bool
+	}{
+		{"valid", DeicingConfiguration{Pads: 4, DeicingTime: 12 * time.Minute, ThresholdCelsius: 3}, false},
+		{"sub-zero threshold", DeicingConfiguration{Pads: 4, DeicingTime: 12 * time.Minute, ThresholdCelsius: -2}, false},
+		{"no pads", DeicingConfiguration{DeicingTime: 12 * time.Minute, ThresholdCelsius: 3}, true},
+		{"zero de-icing time", DeicingConfiguration{Pads: 4, ThresholdCelsius: 3}, true},
+		{"threshold too high", DeicingConfiguration{Pads: 4, DeicingTime: 12 * time.Minute, ThresholdCelsius: 70}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDeicingPolicy(tt.config)
+			if tt.wantErr && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeicingPolicy_GenerateEvents(t *testing.T) {
+	p, err := NewDeicingPolicy(DeicingConfiguration{Pads: 4, DeicingTime: 12 * time.Minute, ThresholdCelsius: 3})
+	if err != nil {
+		t.Fatalf("NewDeicingPolicy: %v", err)
+	}
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(1, 0, 0), []string{"09"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+	if len(world.events) != 1 {
+		t.Fatalf("Expected 1 de-icing event, got %d", len(world.events))
+	}
+
+	evt, ok := world.events[0].(*event.DeicingConstraintEvent)
+	if !ok {
+		t.Fatalf("Expected DeicingConstraintEvent, got %T", world.events[0])
+	}
+	// 4 pads de-icing one departure every 12 minutes each sustain 20 departures and 20 arrivals an hour
+	if got := evt.MaxMovementsPerSecond() * 3600; math.Abs(float64(got-40)) > 0.01 {
+		t.Errorf("Expected 40 movements per hour, got %.2f", got)
+	}
+	if evt.ThresholdCelsius() != 3 || !evt.Time().Equal(simStart) {
+		t.Errorf("Expected a 3 °C threshold at simulation start, got %g at %v", evt.ThresholdCelsius(), evt.Time())
+	}
+}
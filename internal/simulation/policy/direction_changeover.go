@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// MaxDirectionChangeoverPenalty defines the maximum allowed changeover penalty (1 hour).
+// Real-world changeovers typically cost 5-15 minutes of throughput.
+const MaxDirectionChangeoverPenalty = time.Hour
+
+// DirectionChangeoverPolicy models the throughput lost when wind shifts force an active runway
+// to reverse its operating direction (e.g., 09 to 27). Arrival streams must be re-sequenced
+// and departures re-taxied, so the runway handles no movements for the penalty duration
+// after each direction change instead of switching instantaneously.
+type DirectionChangeoverPolicy struct {
+	penalty time.Duration
+}
+
+// NewDirectionChangeoverPolicy creates a new direction changeover policy.
+// Returns an error if the penalty is not positive or exceeds MaxDirectionChangeoverPenalty.
+func NewDirectionChangeoverPolicy(penalty time.Duration) (*DirectionChangeoverPolicy, error) {
+	if penalty <= 0 {
+		return nil, simerrors.Invalidf("direction changeover penalty must be positive, got %v", penalty)
+	}
+	if penalty > MaxDirectionChangeoverPenalty {
+		return nil, simerrors.Invalidf("direction changeover penalty %v exceeds maximum %v", penalty, MaxDirectionChangeoverPenalty)
+	}
+
+	return &DirectionChangeoverPolicy{
+		penalty: penalty,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *DirectionChangeoverPolicy) Name() string {
+	return "DirectionChangeoverPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *DirectionChangeoverPolicy) Parameters() any {
+	return p.penalty
+}
+
+// GenerateEvents generates a direction changeover penalty event at simulation start.
+// The world then opens a capacity loss window whenever a configuration change
+// reverses the direction of a runway that stays active.
+func (p *DirectionChangeoverPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	world.ScheduleEvent(event.NewDirectionChangeoverPenaltyEvent(p.penalty, world.GetStartTime()))
+	return nil
+}
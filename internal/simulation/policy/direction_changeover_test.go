@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewDirectionChangeoverPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		penalty     time.Duration
+		expectError bool
+	}{
+		{"typical penalty", 10 * time.Minute, false},
+		{"maximum penalty", MaxDirectionChangeoverPenalty, false},
+		{"zero penalty", 0, true},
+		{"negative penalty", -5 * time.Minute, true},
+		{"excessive penalty", 2 * time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDirectionChangeoverPolicy(tt.penalty)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDirectionChangeoverPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy, err := NewDirectionChangeoverPolicy(10 * time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simStart.AddDate(0, 0, 1), []string{"09"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if count := world.CountEventsByType(event.DirectionChangeoverPenaltyType); count != 1 {
+		t.Fatalf("Expected 1 changeover event, got %d", count)
+	}
+	evt := world.GetEvents()[0].(*event.DirectionChangeoverPenaltyEvent)
+	if evt.Penalty() != 10*time.Minute {
+		t.Errorf("Expected 10m penalty, got %v", evt.Penalty())
+	}
+	if !evt.Time().Equal(simStart) {
+		t.Errorf("Expected event at %v, got %v", simStart, evt.Time())
+	}
+}
@@ -0,0 +1,148 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// unplannedClosureReason is the reason recorded on runway closures generated by the
+// disruption policy.
+const unplannedClosureReason = "unplanned closure"
+
+// DisruptionConfiguration defines how often unplanned runway closures occur and how long
+// they last.
+type DisruptionConfiguration struct {
+	EventsPer10kMovements float64              // Mean closures per 10,000 movements
+	MovementsPerHour      float64              // Traffic the rate applies to (e.g., the airport's typical hourly movements)
+	Duration              DurationDistribution // Distribution of each closure's duration
+	Runways               []string             // Runways that can be closed (nil = every runway)
+}
+
+// DisruptionPolicy models unplanned short runway closures, such as a disabled aircraft on the
+// runway or an inspection after a bird strike, to measure how resilient capacity is to them.
+//
+// Closures occur at random as a Poisson process at EventsPer10kMovements per 10,000 movements
+// of MovementsPerHour traffic, each closing one of the runways chosen at random for a duration
+// drawn from the configured distribution. A closure starting while its runway is already
+// closed by the policy extends that closure. Closures are drawn from the simulation's random
+// source, so seeded runs are reproducible.
+type DisruptionPolicy struct {
+	config DisruptionConfiguration
+	rng    *rand.Rand // Random source for closures (set by the simulation)
+}
+
+// NewDisruptionPolicy creates a new disruption policy with validation.
+// Returns an error if the rate or traffic is not positive, the duration distribution is
+// missing or invalid, or a runway is empty or listed twice.
+func NewDisruptionPolicy(config DisruptionConfiguration) (*DisruptionPolicy, error) {
+	if config.EventsPer10kMovements <= 0 {
+		return nil, simerrors.Invalidf("disruption events per 10,000 movements must be positive, got %g", config.EventsPer10kMovements)
+	}
+	if config.MovementsPerHour <= 0 {
+		return nil, simerrors.Invalidf("disruption movements per hour must be positive, got %g", config.MovementsPerHour)
+	}
+	if config.Duration == nil {
+		return nil, simerrors.Invalidf("disruption duration distribution is required")
+	}
+	if err := config.Duration.Validate(); err != nil {
+		return nil, err
+	}
+	for i, runwayID := range config.Runways {
+		if runwayID == "" {
+			return nil, simerrors.Invalidf("disruption runway %d is empty", i+1)
+		}
+		if slices.Contains(config.Runways[:i], runwayID) {
+			return nil, simerrors.Invalidf("disruption runway %s is listed twice", runwayID)
+		}
+	}
+
+	return &DisruptionPolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *DisruptionPolicy) Name() string {
+	return "DisruptionPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *DisruptionPolicy) Parameters() any {
+	return p.config
+}
+
+// SetRandomSource sets the random source used to generate closures.
+// This implements the StochasticPolicy interface.
+func (p *DisruptionPolicy) SetRandomSource(rng *rand.Rand) {
+	p.rng = rng
+}
+
+// Validate checks that every configured runway exists.
+func (p *DisruptionPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.config.Runways)...)
+}
+
+// GenerateEvents generates a RunwayClosureStartEvent and RunwayClosureEndEvent for every
+// closure starting within the simulation period. Closures still in progress at the end of
+// the simulation have no end event.
+func (p *DisruptionPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	if p.rng == nil {
+		p.rng = NewRandomSource(0, 0)
+	}
+
+	runwayIDs := p.config.Runways
+	if runwayIDs == nil {
+		// Sorted so that seeded runs choose the same runways
+		runwayIDs = slices.Sorted(slices.Values(world.GetRunwayIDs()))
+	}
+	if len(runwayIDs) == 0 {
+		return nil
+	}
+
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+	ratePerHour := p.config.EventsPer10kMovements / 10000 * p.config.MovementsPerHour
+
+	// Draw every closure first, merging overlapping closures of the same runway so that an
+	// earlier closure ending does not reopen a runway that is still closed
+	type closure struct{ start, end time.Time }
+	closures := make(map[string][]closure, len(runwayIDs))
+	for t := startTime; ; {
+		t = t.Add(time.Duration(p.rng.ExpFloat64() / ratePerHour * float64(time.Hour)))
+		if !t.Before(endTime) {
+			break
+		}
+		runwayID := runwayIDs[p.rng.IntN(len(runwayIDs))]
+		duration := p.config.Duration.Sample(p.rng)
+		if duration <= 0 {
+			continue
+		}
+
+		end := t.Add(duration)
+		if n := len(closures[runwayID]); n > 0 && !t.After(closures[runwayID][n-1].end) {
+			last := &closures[runwayID][n-1]
+			if end.After(last.end) {
+				last.end = end
+			}
+			continue
+		}
+		closures[runwayID] = append(closures[runwayID], closure{start: t, end: end})
+	}
+
+	for _, runwayID := range runwayIDs {
+		for _, c := range closures[runwayID] {
+			world.ScheduleEvent(event.NewRunwayClosureStartEvent(runwayID, unplannedClosureReason, c.start))
+			if c.end.Before(endTime) {
+				world.ScheduleEvent(event.NewRunwayClosureEndEvent(runwayID, unplannedClosureReason, c.end))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewDisruptionPolicy(t *testing.T) {
+	valid := DisruptionConfiguration{
+		EventsPer10kMovements: 2,
+		MovementsPerHour:      40,
+		Duration:              FixedDuration(15 * time.Minute),
+	}
+	if _, err := NewDisruptionPolicy(valid); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*DisruptionConfiguration)
+	}{
+		{"zero rate", func(c *DisruptionConfiguration) { c.EventsPer10kMovements = 0 }},
+		{"zero traffic", func(c *DisruptionConfiguration) { c.MovementsPerHour = 0 }},
+		{"missing duration", func(c *DisruptionConfiguration) { c.Duration = nil }},
+		{"invalid duration", func(c *DisruptionConfiguration) { c.Duration = FixedDuration(0) }},
+		{"empty runway", func(c *DisruptionConfiguration) { c.Runways = []string{""} }},
+		{"duplicate runway", func(c *DisruptionConfiguration) { c.Runways = []string{"09", "09"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			if _, err := NewDisruptionPolicy(config); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDisruptionPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(1, 0, 0)
+
+	// 5 per 10,000 movements at 40 movements an hour is about 175 closures a year
+	p, err := NewDisruptionPolicy(DisruptionConfiguration{
+		EventsPer10kMovements: 5,
+		MovementsPerHour:      40,
+		Duration:              ExponentialDuration{Mean: 20 * time.Minute, Max: 2 * time.Hour},
+		Runways:               []string{"09L", "09R"},
+	})
+	if err != nil {
+		t.Fatalf("NewDisruptionPolicy: %v", err)
+	}
+	p.SetRandomSource(NewRandomSource(5, 0))
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R", "18"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	starts := world.CountEventsByType(event.RunwayClosureStartType)
+	if want := 5.0 / 10000 * 40 * 24 * 366; math.Abs(float64(starts)-want) > 0.15*want {
+		t.Errorf("Expected about %.0f closures, got %d", want, starts)
+	}
+	if ends := world.CountEventsByType(event.RunwayClosureEndType); ends != starts {
+		t.Errorf("Expected an end for each of %d closures, got %d", starts, ends)
+	}
+
+	// Closures of each runway never overlap, and only the configured runways close
+	closedUntil := make(map[string]time.Time)
+	for _, e := range world.events {
+		switch e := e.(type) {
+		case *event.RunwayClosureStartEvent:
+			if e.RunwayID() == "18" {
+				t.Fatalf("Unexpected closure of runway 18 at %v", e.Time())
+			}
+			if e.Time().Before(closedUntil[e.RunwayID()]) {
+				t.Fatalf("Closure of %s at %v overlaps the previous one", e.RunwayID(), e.Time())
+			}
+		case *event.RunwayClosureEndEvent:
+			closedUntil[e.RunwayID()] = e.Time()
+		}
+	}
+}
+
+func TestDisruptionPolicy_Validate(t *testing.T) {
+	p, err := NewDisruptionPolicy(DisruptionConfiguration{
+		EventsPer10kMovements: 1,
+		MovementsPerHour:      40,
+		Duration:              FixedDuration(time.Minute),
+		Runways:               []string{"27"},
+	})
+	if err != nil {
+		t.Fatalf("NewDisruptionPolicy: %v", err)
+	}
+
+	var notFound *simerrors.RunwayNotFoundError
+	world := newMockEventWorld(time.Now(), time.Now().Add(time.Hour), []string{"09"})
+	if err := p.Validate(world); !errors.As(err, &notFound) {
+		t.Errorf("Expected RunwayNotFoundError, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package policy
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DurationDistribution is a probability distribution of durations that stochastic policies
+// sample from (e.g., how long a thunderstorm closes the airfield).
+type DurationDistribution interface {
+	// Sample draws a duration from the distribution.
+	Sample(rng *rand.Rand) time.Duration
+
+	// Validate returns an error if the distribution's parameters are invalid.
+	Validate() error
+}
+
+// QuantileDistribution is a DurationDistribution whose quantiles can be calculated exactly.
+// Quantiles of other distributions are estimated by sampling (see DurationQuantile).
+type QuantileDistribution interface {
+	DurationDistribution
+
+	// Quantile returns the duration below which a fraction p, in (0, 1), of samples fall.
+	Quantile(p float64) time.Duration
+}
+
+// quantileSamples is the number of samples DurationQuantile draws from distributions without
+// exact quantiles.
+const quantileSamples = 10_000
+
+// DurationQuantile returns the duration below which a fraction p, in (0, 1), of the
+// distribution's samples fall: exactly for a QuantileDistribution, otherwise estimated from
+// samples drawn from rng.
+func DurationQuantile(d DurationDistribution, p float64, rng *rand.Rand) time.Duration {
+	if q, ok := d.(QuantileDistribution); ok {
+		return q.Quantile(p)
+	}
+	samples := make([]time.Duration, quantileSamples)
+	for i := range samples {
+		samples[i] = d.Sample(rng)
+	}
+	slices.Sort(samples)
+	return samples[min(int(p*quantileSamples), quantileSamples-1)]
+}
+
+// FixedDuration is a distribution that always returns the same duration.
+type FixedDuration time.Duration
+
+// Sample returns the fixed duration.
+func (d FixedDuration) Sample(rng *rand.Rand) time.Duration {
+	return time.Duration(d)
+}
+
+// Quantile returns the fixed duration.
+func (d FixedDuration) Quantile(p float64) time.Duration {
+	return time.Duration(d)
+}
+
+// Validate checks that the duration is positive.
+func (d FixedDuration) Validate() error {
+	if d <= 0 {
+		return simerrors.Invalidf("fixed duration must be positive, got %v", time.Duration(d))
+	}
+	return nil
+}
+
+// UniformDuration is a distribution of durations equally likely between Min and Max.
+type UniformDuration struct {
+	Min time.Duration // Shortest duration (inclusive)
+	Max time.Duration // Longest duration (exclusive)
+}
+
+// Sample draws a duration uniformly from [Min, Max).
+func (d UniformDuration) Sample(rng *rand.Rand) time.Duration {
+	return d.Min + time.Duration(rng.Int64N(int64(d.Max-d.Min)))
+}
+
+// Quantile returns the duration a fraction p of the way from Min to Max.
+func (d UniformDuration) Quantile(p float64) time.Duration {
+	return d.Min + time.Duration(p*float64(d.Max-d.Min))
+}
+
+// Validate checks that Min is positive and below Max.
+func (d UniformDuration) Validate() error {
+	if d.Min <= 0 || d.Max <= d.Min {
+		return simerrors.Invalidf("uniform duration needs 0 < min < max, got %v to %v", d.Min, d.Max)
+	}
+	return nil
+}
+
+// ExponentialDuration is a memoryless distribution of durations with the given mean, suited
+// to events that are usually short but occasionally long. Samples are capped at Max, if set.
+type ExponentialDuration struct {
+	Mean time.Duration // Mean duration
+	Max  time.Duration // Longest duration returned (0 = no cap)
+}
+
+// Sample draws an exponentially distributed duration.
+func (d ExponentialDuration) Sample(rng *rand.Rand) time.Duration {
+	sample := time.Duration(rng.ExpFloat64() * float64(d.Mean))
+	if d.Max > 0 && sample > d.Max {
+		return d.Max
+	}
+	return sample
+}
+
+// Quantile returns the duration below which a fraction p of exponential samples fall, capped
+// at Max.
+func (d ExponentialDuration) Quantile(p float64) time.Duration {
+	quantile := time.Duration(-math.Log1p(-p) * float64(d.Mean))
+	if d.Max > 0 && quantile > d.Max {
+		return d.Max
+	}
+	return quantile
+}
+
+// Validate checks that the mean is positive and the cap, if set, is not below it.
+func (d ExponentialDuration) Validate() error {
+	if d.Mean <= 0 {
+		return simerrors.Invalidf("exponential duration mean must be positive, got %v", d.Mean)
+	}
+	if d.Max < 0 || (d.Max > 0 && d.Max < d.Mean) {
+		return simerrors.Invalidf("exponential duration cap %v must be at least the mean %v", d.Max, d.Mean)
+	}
+	return nil
+}
+
+// LogNormalDuration is a right-skewed distribution of durations whose logarithm is normally
+// distributed, commonly fitted to observed weather event durations.
+type LogNormalDuration struct {
+	Median time.Duration // Median duration
+	Sigma  float64       // Standard deviation of the duration's natural logarithm
+}
+
+// Sample draws a log-normally distributed duration.
+func (d LogNormalDuration) Sample(rng *rand.Rand) time.Duration {
+	return time.Duration(float64(d.Median) * math.Exp(d.Sigma*rng.NormFloat64()))
+}
+
+// Quantile returns the duration below which a fraction p of log-normal samples fall.
+func (d LogNormalDuration) Quantile(p float64) time.Duration {
+	return time.Duration(float64(d.Median) * math.Exp(d.Sigma*math.Sqrt2*math.Erfinv(2*p-1)))
+}
+
+// Validate checks that the median is positive and sigma is not negative.
+func (d LogNormalDuration) Validate() error {
+	if d.Median <= 0 {
+		return simerrors.Invalidf("log-normal duration median must be positive, got %v", d.Median)
+	}
+	if d.Sigma < 0 {
+		return simerrors.Invalidf("log-normal duration sigma cannot be negative, got %g", d.Sigma)
+	}
+	return nil
+}
+
+// z90 is the standard normal quantile at 0.9.
+const z90 = 1.2815515655446004
+
+// PercentileDuration is a right-skewed distribution of durations given by its 50th and 90th
+// percentiles, as commonly published for turnaround times. It is the log-normal distribution
+// through both.
+type PercentileDuration struct {
+	P50 time.Duration // Median duration
+	P90 time.Duration // Duration 90% of samples fall below
+}
+
+// logNormal returns the log-normal distribution with the same P50 and P90.
+func (d PercentileDuration) logNormal() LogNormalDuration {
+	return LogNormalDuration{Median: d.P50, Sigma: math.Log(float64(d.P90)/float64(d.P50)) / z90}
+}
+
+// Sample draws a duration from the distribution.
+func (d PercentileDuration) Sample(rng *rand.Rand) time.Duration {
+	return d.logNormal().Sample(rng)
+}
+
+// Quantile returns the duration below which a fraction p of samples fall.
+func (d PercentileDuration) Quantile(p float64) time.Duration {
+	return d.logNormal().Quantile(p)
+}
+
+// Validate checks that P50 is positive and P90 is not below it.
+func (d PercentileDuration) Validate() error {
+	if d.P50 <= 0 || d.P90 < d.P50 {
+		return simerrors.Invalidf("percentile duration needs 0 < P50 <= P90, got %v and %v", d.P50, d.P90)
+	}
+	return nil
+}
+
+// HistogramBin is one bin of a HistogramDuration: durations from the previous bin's End (or
+// the histogram's Start) up to End, with a relative weight.
+type HistogramBin struct {
+	End    time.Duration // Longest duration in the bin (exclusive)
+	Weight float64       // Relative frequency of durations in the bin
+}
+
+// HistogramDuration is an empirical distribution of durations, such as observed turnaround
+// times, given as a histogram. Durations are spread evenly within each bin.
+type HistogramDuration struct {
+	Start time.Duration  // Shortest duration (the start of the first bin)
+	Bins  []HistogramBin // Bins in order of increasing End
+}
+
+// Sample draws a duration from the histogram.
+func (d HistogramDuration) Sample(rng *rand.Rand) time.Duration {
+	return d.Quantile(rng.Float64())
+}
+
+// Quantile returns the duration below which a fraction p of the histogram's weight falls.
+func (d HistogramDuration) Quantile(p float64) time.Duration {
+	total := 0.0
+	for _, bin := range d.Bins {
+		total += bin.Weight
+	}
+
+	target, start := p*total, d.Start
+	for _, bin := range d.Bins {
+		if bin.Weight > 0 && target < bin.Weight {
+			return start + time.Duration(target/bin.Weight*float64(bin.End-start))
+		}
+		target -= bin.Weight
+		start = bin.End
+	}
+	return d.Bins[len(d.Bins)-1].End
+}
+
+// Validate checks that the histogram has bins of increasing, positive durations with
+// non-negative weights, not all zero.
+func (d HistogramDuration) Validate() error {
+	if len(d.Bins) == 0 {
+		return simerrors.Invalidf("histogram duration needs at least one bin")
+	}
+	if d.Start < 0 {
+		return simerrors.Invalidf("histogram duration start cannot be negative, got %v", d.Start)
+	}
+	total, start := 0.0, d.Start
+	for i, bin := range d.Bins {
+		if bin.End <= start {
+			return simerrors.Invalidf("histogram bin %d: end %v must be after %v", i+1, bin.End, start)
+		}
+		if bin.Weight < 0 || math.IsNaN(bin.Weight) {
+			return simerrors.Invalidf("histogram bin %d: weight cannot be negative, got %g", i+1, bin.Weight)
+		}
+		total += bin.Weight
+		start = bin.End
+	}
+	if total <= 0 {
+		return simerrors.Invalidf("histogram duration weights must not all be zero")
+	}
+	return nil
+}
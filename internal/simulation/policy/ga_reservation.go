@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
+)
+
+// GAReservationConfiguration defines daily hours in which capacity is reserved for general
+// aviation: whole runways used only by GA traffic (e.g., runway 08 GA-only at weekends), a
+// share of the other runways' movements, or both.
+type GAReservationConfiguration struct {
+	StartTime time.Time      // Start of the reservation (only the time of day is used)
+	EndTime   time.Time      // End of the reservation (only the time of day is used; overnight hours wrap, and the same time as StartTime reserves the whole day)
+	Weekdays  []time.Weekday // Days the reservation starts, in local time (empty = every day)
+	Runways   []string       // Runway designations reserved entirely for GA (empty = none)
+	Share     float64        // Fraction of the other runways' movements reserved for GA, in [0, 1) (0 = none)
+}
+
+// GAReservationPolicy reserves capacity for general aviation during daily hours. Reserved
+// movements are not available to commercial traffic: they are removed from the window
+// capacity and reported separately (see WindowResult.GAMovements).
+type GAReservationPolicy struct {
+	config      GAReservationConfiguration
+	reservation *event.GAReservation
+}
+
+// NewGAReservationPolicy creates a new GA reservation policy with validation.
+// Returns an error if nothing is reserved, the share is outside [0, 1), a runway designation
+// is empty or repeated, or a weekday is unknown.
+func NewGAReservationPolicy(config GAReservationConfiguration) (*GAReservationPolicy, error) {
+	if len(config.Runways) == 0 && config.Share == 0 {
+		return nil, simerrors.Invalidf("GA reservation must reserve runways or a share of capacity")
+	}
+	if config.Share < 0 || config.Share >= 1 {
+		return nil, simerrors.Invalidf("GA reservation share must be in [0, 1), got %f", config.Share)
+	}
+	for i, runwayID := range config.Runways {
+		if runwayID == "" {
+			return nil, simerrors.Invalidf("GA reservation runway designation cannot be empty")
+		}
+		if slices.Contains(config.Runways[:i], runwayID) {
+			return nil, simerrors.Invalidf("duplicate GA reservation runway: %s", runwayID)
+		}
+	}
+	for _, day := range config.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, simerrors.Invalidf("unknown GA reservation weekday: %d", day)
+		}
+	}
+
+	config.Runways = slices.Clone(config.Runways)
+	config.Weekdays = slices.Clone(config.Weekdays)
+	return &GAReservationPolicy{
+		config: config,
+		reservation: &event.GAReservation{
+			Runways: config.Runways,
+			Share:   config.Share,
+		},
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *GAReservationPolicy) Name() string {
+	return "GAReservationPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *GAReservationPolicy) Parameters() any {
+	return p.config
+}
+
+// Validate checks that every reserved runway is a runway at the airport.
+func (p *GAReservationPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.config.Runways)...)
+}
+
+// GenerateEvents generates reservation start and end events for every day in the simulation
+// period on which the reservation starts. A reservation already in progress when the
+// simulation starts begins at the simulation start.
+func (p *GAReservationPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	// Start one day early so a reservation running over the simulation start is included.
+	// Days are laid out in local time so reservations follow daylight saving time changes.
+	loc := location(world)
+	var reservations []timewin.Window
+	for _, reservation := range timewin.Daily(startTime.AddDate(0, 0, -1), endTime, p.config.StartTime, p.config.EndTime, loc) {
+		if len(p.config.Weekdays) == 0 || slices.Contains(p.config.Weekdays, reservation.Start.In(loc).Weekday()) {
+			reservations = append(reservations, reservation)
+		}
+	}
+
+	// Clip the reservations to the simulation period
+	for _, reservation := range timewin.Clip(reservations, timewin.Window{Start: startTime, End: endTime}) {
+		world.ScheduleEvent(event.NewGAReservationStartEvent(p.reservation, reservation.Start))
+		world.ScheduleEvent(event.NewGAReservationEndEvent(p.reservation, reservation.End))
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewGAReservationPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		config  GAReservationConfiguration
+		wantErr bool
+	}{
+		{"runway", GAReservationConfiguration{StartTime: start, EndTime: end, Runways: []string{"08"}}, false},
+		{"share", GAReservationConfiguration{StartTime: start, EndTime: end, Share: 0.1}, false},
+		{"weekends", GAReservationConfiguration{StartTime: start, EndTime: start, Weekdays: []time.Weekday{time.Saturday, time.Sunday}, Runways: []string{"08"}}, false},
+		{"nothing reserved", GAReservationConfiguration{StartTime: start, EndTime: end}, true},
+		{"negative share", GAReservationConfiguration{StartTime: start, EndTime: end, Share: -0.1}, true},
+		{"whole capacity", GAReservationConfiguration{StartTime: start, EndTime: end, Share: 1}, true},
+		{"empty runway", GAReservationConfiguration{StartTime: start, EndTime: end, Runways: []string{""}}, true},
+		{"duplicate runway", GAReservationConfiguration{StartTime: start, EndTime: end, Runways: []string{"08", "08"}}, true},
+		{"unknown weekday", GAReservationConfiguration{StartTime: start, EndTime: end, Share: 0.1, Weekdays: []time.Weekday{7}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGAReservationPolicy(tt.config)
+			if tt.wantErr && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGAReservationPolicy_GenerateEvents(t *testing.T) {
+	// Runway 08 GA-only all weekend
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p, err := NewGAReservationPolicy(GAReservationConfiguration{
+		StartTime: midnight,
+		EndTime:   midnight,
+		Weekdays:  []time.Weekday{time.Saturday, time.Sunday},
+		Runways:   []string{"08"},
+	})
+	if err != nil {
+		t.Fatalf("NewGAReservationPolicy: %v", err)
+	}
+
+	// 2024-01-01 is a Monday: two weekends in 14 days
+	world := newMockEventWorld(midnight, midnight.AddDate(0, 0, 14), []string{"08", "26"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	var starts []time.Time
+	for _, evt := range world.events {
+		if evt.Type() == event.GAReservationStartType {
+			starts = append(starts, evt.Time())
+		}
+	}
+	want := []time.Time{midnight.AddDate(0, 0, 5), midnight.AddDate(0, 0, 6), midnight.AddDate(0, 0, 12), midnight.AddDate(0, 0, 13)}
+	if len(starts) != len(want) {
+		t.Fatalf("Expected %d reservations, got %v", len(want), starts)
+	}
+	for i := range want {
+		if !starts[i].Equal(want[i]) {
+			t.Errorf("Reservation %d: expected start %v, got %v", i, want[i], starts[i])
+		}
+	}
+	if got := world.CountEventsByType(event.GAReservationEndType); got != len(want) {
+		t.Errorf("Expected %d reservation ends, got %d", len(want), got)
+	}
+}
+
+func TestGAReservationPolicy_Validate(t *testing.T) {
+	p, err := NewGAReservationPolicy(GAReservationConfiguration{Runways: []string{"08", "99"}})
+	if err != nil {
+		t.Fatalf("NewGAReservationPolicy: %v", err)
+	}
+
+	world := newMockEventWorld(time.Now(), time.Now().Add(time.Hour), []string{"08", "26"})
+	var notFound *simerrors.RunwayNotFoundError
+	if err := p.Validate(world); !errors.As(err, &notFound) {
+		t.Errorf("Expected RunwayNotFoundError, got %v", err)
+	}
+}
@@ -2,32 +2,99 @@ package policy
 
 import (
 	"context"
-	"fmt"
+	"math/rand/v2"
+	"slices"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 // GateCapacityConstraint defines gate capacity limitations.
 type GateCapacityConstraint struct {
-	TotalGates          int           // Total number of gates at the airport
-	AverageTurnaroundTime time.Duration // Average time aircraft occupies a gate
+	TotalGates             int                  // Total number of gates at the airport
+	UseAirportGates        bool                 // Take the gate count from the airport (Airport.Gates) instead of TotalGates
+	AverageTurnaroundTime  time.Duration        // Average time aircraft occupies a gate
+	TurnaroundDistribution DurationDistribution // Distribution of the time aircraft occupy a gate, instead of AverageTurnaroundTime
+	TurnaroundPercentile   float64              // Percentile of TurnaroundDistribution used as the turnaround, in (0, 100)
+	Closures               []GateClosure        // Scheduled closures taking gates out of service for a period
 }
 
-// GateCapacityPolicy models the constraint that gate availability places on sustained throughput.
-// When gates are fully utilized, they limit the airport's ability to accept new arrivals,
-// effectively capping the sustained capacity below what runways could theoretically handle.
+// GateClosure takes gates out of service for a period, such as a pier closed for terminal
+// refurbishment. Closures may overlap, in which case their gates add up.
+type GateClosure struct {
+	Gates int       // Number of gates closed
+	Start time.Time // When the gates close
+	End   time.Time // When the gates reopen
+}
+
+// FleetMixWorld is implemented by worlds that expose the airport's fleet mix.
+type FleetMixWorld interface {
+	GetFleetMix() *airport.FleetMix
+}
+
+// GateCountWorld is implemented by worlds that expose the airport's gate count.
+type GateCountWorld interface {
+	GetGateCount() int
+}
+
+// GateCapacityPolicy models the constraint that gate availability places on throughput.
+// Arriving aircraft occupy a gate for their turnaround (and any taxi time, see TaxiTimePolicy)
+// before departing, so when the gates are full they limit the airport's ability to accept new
+// arrivals below what the runways could theoretically handle.
+//
+// Averages overestimate gate capacity, since planners hold gates for the long turnarounds too:
+// with a TurnaroundDistribution (e.g., PercentileDuration or HistogramDuration of observed
+// turnarounds) the gates are occupied for its TurnaroundPercentile instead, calculated exactly
+// where the distribution allows (see QuantileDistribution) and otherwise by sampling.
 type GateCapacityPolicy struct {
 	constraint GateCapacityConstraint
+	rng        *rand.Rand    // Random source for estimating turnaround percentiles (set by the simulation)
+	percentile time.Duration // Turnaround percentile estimated from rng (0 = not yet estimated)
 }
 
 // NewGateCapacityPolicy creates a new gate capacity policy.
+// With UseAirportGates, the gate count is read from the airport when events are generated, so
+// pre-simulation plugins that change it (see GateExpansionPlugin) take effect.
 func NewGateCapacityPolicy(constraint GateCapacityConstraint) (*GateCapacityPolicy, error) {
-	if constraint.TotalGates <= 0 {
-		return nil, fmt.Errorf("total gates must be positive, got %d", constraint.TotalGates)
+	if constraint.UseAirportGates {
+		if constraint.TotalGates != 0 {
+			return nil, simerrors.Invalidf("total gates must not be set when using the airport's gates, got %d", constraint.TotalGates)
+		}
+	} else if constraint.TotalGates <= 0 {
+		return nil, simerrors.Invalidf("total gates must be positive, got %d", constraint.TotalGates)
+	}
+	if constraint.TurnaroundDistribution != nil {
+		if constraint.AverageTurnaroundTime != 0 {
+			return nil, simerrors.Invalidf("average turnaround time must not be set with a turnaround distribution, got %v", constraint.AverageTurnaroundTime)
+		}
+		if err := constraint.TurnaroundDistribution.Validate(); err != nil {
+			return nil, err
+		}
+		if constraint.TurnaroundPercentile <= 0 || constraint.TurnaroundPercentile >= 100 {
+			return nil, simerrors.Invalidf("turnaround percentile must be in (0, 100), got %g", constraint.TurnaroundPercentile)
+		}
+	} else {
+		if constraint.AverageTurnaroundTime <= 0 {
+			return nil, simerrors.Invalidf("average turnaround time must be positive, got %v", constraint.AverageTurnaroundTime)
+		}
+		if constraint.TurnaroundPercentile != 0 {
+			return nil, simerrors.Invalidf("turnaround percentile needs a turnaround distribution, got %g", constraint.TurnaroundPercentile)
+		}
+	}
+	for i, closure := range constraint.Closures {
+		if closure.Gates <= 0 {
+			return nil, simerrors.Invalidf("gate closure %d: gates must be positive, got %d", i+1, closure.Gates)
+		}
+		if !closure.End.After(closure.Start) {
+			return nil, simerrors.Invalidf("gate closure %d: end %v must be after start %v", i+1, closure.End, closure.Start)
+		}
 	}
-	if constraint.AverageTurnaroundTime <= 0 {
-		return nil, fmt.Errorf("average turnaround time must be positive, got %v", constraint.AverageTurnaroundTime)
+	if !constraint.UseAirportGates {
+		if err := checkGateClosures(constraint.Closures, constraint.TotalGates); err != nil {
+			return nil, err
+		}
 	}
 
 	return &GateCapacityPolicy{
@@ -40,37 +107,152 @@ func (p *GateCapacityPolicy) Name() string {
 	return "GateCapacityPolicy"
 }
 
-// GenerateEvents generates a gate capacity constraint event at simulation start.
-// This event applies a capacity multiplier that represents the limitation gates
-// place on sustained throughput.
-//
-// The multiplier is calculated as:
-// - Sustained arrival rate = gates / turnaround_time
-// - This becomes a cap on total movements if it's lower than runway capacity
+// Parameters returns the policy configuration.
+func (p *GateCapacityPolicy) Parameters() any {
+	return p.constraint
+}
+
+// SetRandomSource sets the random source used to estimate turnaround percentiles by sampling.
+// Implements StochasticPolicy.
+func (p *GateCapacityPolicy) SetRandomSource(rng *rand.Rand) {
+	p.rng = rng
+	p.percentile = 0
+}
+
+// Validate checks that the airport has a gate count when the constraint uses the airport's gates,
+// and that the closures always leave a gate open.
+func (p *GateCapacityPolicy) Validate(world EventWorld) error {
+	gates := p.gates(world)
+	if gates <= 0 {
+		return simerrors.Invalidf("%s: airport has no gate count", p.Name())
+	}
+	return checkGateClosures(p.constraint.Closures, gates)
+}
+
+// gates returns the number of gates the constraint applies to.
+func (p *GateCapacityPolicy) gates(world EventWorld) int {
+	if !p.constraint.UseAirportGates {
+		return p.constraint.TotalGates
+	}
+	if gw, ok := world.(GateCountWorld); ok {
+		return gw.GetGateCount()
+	}
+	return 0
+}
+
+// SetInitialState sets the gates open at the simulation start and the average turnaround
+// (see InitialStatePolicy).
+func (p *GateCapacityPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	gates, turnaround, err := p.gateConstraint(world)
+	if err != nil {
+		return err
+	}
+	startTime := world.GetStartTime()
+	open := gates - closedGatesAt(p.constraint.Closures, startTime)
+	return event.NewGateCapacityConstraintEvent(open, turnaround, startTime).Apply(ctx, world)
+}
+
+// GenerateEvents generates a gate capacity constraint event whenever a gate closure starts or
+// ends within the simulation period and changes the gates open, with the gates then open and
+// the average turnaround. The gates open at the start are set by SetInitialState.
 //
-// Note: This is a simplified model for v0.3.0. Future versions may implement
-// more sophisticated gate utilization tracking with per-flight occupancy.
+// The engine tracks gate occupancy continuously from these: arrivals take a free gate and
+// depart once their turnaround and taxi time have passed. In steady state the gates sustain
+// gates / turnaround arrivals, and as many departures, by Little's law; transient saturation,
+// such as aircraft held on gates through a curfew, constrains the movements that follow.
 func (p *GateCapacityPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	startTime := world.GetStartTime()
 
-	// Calculate the gate-limited sustained capacity
-	// If we have N gates and average turnaround of T hours,
-	// we can handle at most N/T arrivals per hour sustained
-	turnaroundHours := p.constraint.AverageTurnaroundTime.Hours()
-	sustainedArrivalsPerHour := float32(p.constraint.TotalGates) / float32(turnaroundHours)
+	gates, turnaround, err := p.gateConstraint(world)
+	if err != nil {
+		return err
+	}
+
+	// Schedule the constraint at every closure boundary where the number of open gates changes
+	var changes []time.Time
+	for _, closure := range p.constraint.Closures {
+		for _, t := range []time.Time{closure.Start, closure.End} {
+			if t.After(startTime) && t.Before(world.GetEndTime()) {
+				changes = append(changes, t)
+			}
+		}
+	}
+	slices.SortFunc(changes, time.Time.Compare)
+
+	lastOpen := gates - closedGatesAt(p.constraint.Closures, startTime)
+	for _, t := range changes {
+		open := gates - closedGatesAt(p.constraint.Closures, t)
+		if open == lastOpen {
+			continue
+		}
+		lastOpen = open
 
-	// Since movements include both arrivals and departures, and in steady state
-	// they're equal, the total movement capacity is 2x arrivals
-	gateConstrainedMovementsPerHour := sustainedArrivalsPerHour * 2
+		world.ScheduleEvent(event.NewGateCapacityConstraintEvent(open, turnaround, t))
+	}
 
-	// Convert to movements per second for consistency with runway separation
-	gateConstrainedMovementsPerSecond := gateConstrainedMovementsPerHour / 3600.0
+	return nil
+}
 
-	// Schedule the gate capacity constraint event
-	world.ScheduleEvent(event.NewGateCapacityConstraintEvent(
-		gateConstrainedMovementsPerSecond,
-		startTime,
-	))
+// gateConstraint returns the gates the constraint applies to and the turnaround, or an error
+// if the airport has no gate count, the closures leave no gate open, or the turnaround is not
+// positive.
+func (p *GateCapacityPolicy) gateConstraint(world EventWorld) (int, time.Duration, error) {
+	gates := p.gates(world)
+	if gates <= 0 {
+		return 0, 0, simerrors.Invalidf("%s: airport has no gate count", p.Name())
+	}
+	if err := checkGateClosures(p.constraint.Closures, gates); err != nil {
+		return 0, 0, err
+	}
 
+	turnaround := p.turnaround(world)
+	if turnaround <= 0 {
+		return 0, 0, simerrors.Invalidf("%s: turnaround percentile %g is not positive", p.Name(), p.constraint.TurnaroundPercentile)
+	}
+	return gates, turnaround, nil
+}
+
+// turnaround returns the time each aircraft occupies a gate: the distribution's percentile,
+// or the average, which the fleet mix's per-class turnaround times replace where available.
+// The percentile is estimated once per random source, so the initial state and the events
+// of a run share it.
+func (p *GateCapacityPolicy) turnaround(world EventWorld) time.Duration {
+	if p.constraint.TurnaroundDistribution != nil {
+		if p.rng == nil {
+			p.rng = NewRandomSource(0, 0)
+		}
+		if p.percentile == 0 {
+			p.percentile = DurationQuantile(p.constraint.TurnaroundDistribution, p.constraint.TurnaroundPercentile/100, p.rng)
+		}
+		return p.percentile
+	}
+
+	turnaround := p.constraint.AverageTurnaroundTime
+	if fw, ok := world.(FleetMixWorld); ok {
+		turnaround = fw.GetFleetMix().AverageTurnaround(turnaround)
+	}
+	return turnaround
+}
+
+// closedGatesAt returns the number of gates closed at time t.
+func closedGatesAt(closures []GateClosure, t time.Time) int {
+	closed := 0
+	for _, closure := range closures {
+		if !t.Before(closure.Start) && t.Before(closure.End) {
+			closed += closure.Gates
+		}
+	}
+	return closed
+}
+
+// checkGateClosures returns an error if the closures ever close all of the gates, which would
+// leave no gate constraint rather than no capacity.
+func checkGateClosures(closures []GateClosure, gates int) error {
+	// The most gates are closed when some closure starts
+	for _, closure := range closures {
+		if closed := closedGatesAt(closures, closure.Start); closed >= gates {
+			return simerrors.Invalidf("gate closures close %d of %d gates at %v, leaving none open", closed, gates, closure.Start)
+		}
+	}
 	return nil
 }
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"context"
+	"math/rand/v2"
 	"testing"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -54,6 +56,96 @@ func TestNewGateCapacityPolicy(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "turnaround distribution",
+			constraint: GateCapacityConstraint{
+				TotalGates:             50,
+				TurnaroundDistribution: PercentileDuration{P50: time.Hour, P90: 2 * time.Hour},
+				TurnaroundPercentile:   90,
+			},
+			expectError: false,
+		},
+		{
+			name: "turnaround distribution and average",
+			constraint: GateCapacityConstraint{
+				TotalGates:             50,
+				AverageTurnaroundTime:  time.Hour,
+				TurnaroundDistribution: PercentileDuration{P50: time.Hour, P90: 2 * time.Hour},
+				TurnaroundPercentile:   90,
+			},
+			expectError: true,
+		},
+		{
+			name: "turnaround distribution without percentile",
+			constraint: GateCapacityConstraint{
+				TotalGates:             50,
+				TurnaroundDistribution: PercentileDuration{P50: time.Hour, P90: 2 * time.Hour},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid turnaround distribution",
+			constraint: GateCapacityConstraint{
+				TotalGates:             50,
+				TurnaroundDistribution: PercentileDuration{P50: 2 * time.Hour, P90: time.Hour},
+				TurnaroundPercentile:   90,
+			},
+			expectError: true,
+		},
+		{
+			name: "turnaround percentile without distribution",
+			constraint: GateCapacityConstraint{
+				TotalGates:            50,
+				AverageTurnaroundTime: time.Hour,
+				TurnaroundPercentile:  90,
+			},
+			expectError: true,
+		},
+		{
+			name: "valid closures",
+			constraint: GateCapacityConstraint{
+				TotalGates:            50,
+				AverageTurnaroundTime: 2 * time.Hour,
+				Closures: []GateClosure{
+					{Gates: 10, Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "closure of no gates",
+			constraint: GateCapacityConstraint{
+				TotalGates:            50,
+				AverageTurnaroundTime: 2 * time.Hour,
+				Closures: []GateClosure{
+					{Gates: 0, Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "closure ending before it starts",
+			constraint: GateCapacityConstraint{
+				TotalGates:            50,
+				AverageTurnaroundTime: 2 * time.Hour,
+				Closures: []GateClosure{
+					{Gates: 10, Start: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "overlapping closures close every gate",
+			constraint: GateCapacityConstraint{
+				TotalGates:            50,
+				AverageTurnaroundTime: 2 * time.Hour,
+				Closures: []GateClosure{
+					{Gates: 30, Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+					{Gates: 20, Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,49 +181,17 @@ func TestGateCapacityPolicy_Name(t *testing.T) {
 	}
 }
 
-func TestGateCapacityPolicy_GenerateEvents(t *testing.T) {
+func TestGateCapacityPolicy_SetInitialState(t *testing.T) {
 	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	simEnd := simStart.AddDate(0, 0, 7)
 
 	tests := []struct {
-		name                      string
-		constraint                GateCapacityConstraint
-		expectedMovementsPerHour  float32
-		tolerance                 float32
+		name       string
+		constraint GateCapacityConstraint
 	}{
-		{
-			name: "50 gates, 2 hour turnaround",
-			constraint: GateCapacityConstraint{
-				TotalGates:            50,
-				AverageTurnaroundTime: 2 * time.Hour,
-			},
-			// 50 gates / 2 hours = 25 arrivals/hour
-			// Total movements = 25 * 2 = 50 movements/hour
-			expectedMovementsPerHour: 50,
-			tolerance:                0.01,
-		},
-		{
-			name: "100 gates, 1 hour turnaround",
-			constraint: GateCapacityConstraint{
-				TotalGates:            100,
-				AverageTurnaroundTime: 1 * time.Hour,
-			},
-			// 100 gates / 1 hour = 100 arrivals/hour
-			// Total movements = 100 * 2 = 200 movements/hour
-			expectedMovementsPerHour: 200,
-			tolerance:                0.01,
-		},
-		{
-			name: "30 gates, 3 hour turnaround",
-			constraint: GateCapacityConstraint{
-				TotalGates:            30,
-				AverageTurnaroundTime: 3 * time.Hour,
-			},
-			// 30 gates / 3 hours = 10 arrivals/hour
-			// Total movements = 10 * 2 = 20 movements/hour
-			expectedMovementsPerHour: 20,
-			tolerance:                0.01,
-		},
+		{"50 gates, 2 hour turnaround", GateCapacityConstraint{TotalGates: 50, AverageTurnaroundTime: 2 * time.Hour}},
+		{"100 gates, 1 hour turnaround", GateCapacityConstraint{TotalGates: 100, AverageTurnaroundTime: 1 * time.Hour}},
+		{"30 gates, 3 hour turnaround", GateCapacityConstraint{TotalGates: 30, AverageTurnaroundTime: 3 * time.Hour}},
 	}
 
 	for _, tt := range tests {
@@ -141,84 +201,240 @@ func TestGateCapacityPolicy_GenerateEvents(t *testing.T) {
 				t.Fatalf("Failed to create policy: %v", err)
 			}
 
-			world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
-			err = policy.GenerateEvents(context.Background(), world)
-			if err != nil {
+			world := newMockInitialStateWorld(simStart, simEnd)
+			if err := policy.SetInitialState(context.Background(), world); err != nil {
+				t.Fatalf("SetInitialState failed: %v", err)
+			}
+			if err := policy.GenerateEvents(context.Background(), world); err != nil {
 				t.Fatalf("GenerateEvents failed: %v", err)
 			}
 
-			// Should generate exactly one gate capacity constraint event
-			gateEvents := world.CountEventsByType(event.GateCapacityConstraintType)
-			if gateEvents != 1 {
-				t.Errorf("Expected 1 gate capacity event, got %d", gateEvents)
+			// The constraint is set as initial state, with no event at the start time
+			if world.gates != tt.constraint.TotalGates || world.turnaround != tt.constraint.AverageTurnaroundTime {
+				t.Errorf("Expected %d gates with a %v turnaround, got %d with %v",
+					tt.constraint.TotalGates, tt.constraint.AverageTurnaroundTime, world.gates, world.turnaround)
+			}
+			if events := world.GetEvents(); len(events) != 0 {
+				t.Errorf("Expected no events, got %d", len(events))
+			}
+		})
+	}
+}
+
+// fleetMixWorld is a mock initial state world that exposes a fleet mix
+type fleetMixWorld struct {
+	*mockInitialStateWorld
+	fleetMix *airport.FleetMix
+}
+
+func (w *fleetMixWorld) GetFleetMix() *airport.FleetMix {
+	return w.fleetMix
+}
+
+func TestGateCapacityPolicy_FleetMixTurnaround(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := &fleetMixWorld{
+		mockInitialStateWorld: newMockInitialStateWorld(simStart, simStart.AddDate(0, 0, 1)),
+		// Half heavies (90 min) and half lights (30 min) average a 1 hour turnaround
+		fleetMix: airport.NewStandardFleetMix(1, 0, 1),
+	}
+
+	policy, err := NewGateCapacityPolicy(GateCapacityConstraint{TotalGates: 50, AverageTurnaroundTime: 2 * time.Hour})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+
+	if world.turnaround != time.Hour {
+		t.Errorf("Expected the fleet mix's 1 hour turnaround, got %v", world.turnaround)
+	}
+}
+
+// bimodalDuration is a turnaround distribution without a quantile function: half of the
+// turnarounds are short and half long
+type bimodalDuration struct {
+	short, long time.Duration
+}
+
+func (d bimodalDuration) Sample(rng *rand.Rand) time.Duration {
+	if rng.IntN(2) == 0 {
+		return d.short
+	}
+	return d.long
+}
+
+func (d bimodalDuration) Validate() error { return nil }
+
+func TestGateCapacityPolicy_TurnaroundDistribution(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		distribution DurationDistribution
+		percentile   float64
+		want         time.Duration
+	}{
+		{"P90 of percentiles", PercentileDuration{P50: 45 * time.Minute, P90: 90 * time.Minute}, 90, 90 * time.Minute},
+		{"median of percentiles", PercentileDuration{P50: 45 * time.Minute, P90: 90 * time.Minute}, 50, 45 * time.Minute},
+		// 80% of turnarounds within the hour, the rest within two
+		{"P90 of histogram", HistogramDuration{Start: 30 * time.Minute, Bins: []HistogramBin{{End: time.Hour, Weight: 8}, {End: 2 * time.Hour, Weight: 2}}}, 90, 90 * time.Minute},
+		{"P75 by sampling", bimodalDuration{short: 30 * time.Minute, long: 2 * time.Hour}, 75, 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The fleet mix's average turnaround does not replace the distribution
+			world := &fleetMixWorld{
+				mockInitialStateWorld: newMockInitialStateWorld(simStart, simStart.AddDate(0, 0, 1)),
+				fleetMix:              airport.NewStandardFleetMix(1, 0, 1),
+			}
+			policy, err := NewGateCapacityPolicy(GateCapacityConstraint{
+				TotalGates:             50,
+				TurnaroundDistribution: tt.distribution,
+				TurnaroundPercentile:   tt.percentile,
+				Closures:               []GateClosure{{Gates: 10, Start: simStart.Add(6 * time.Hour), End: simStart.Add(12 * time.Hour)}},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create policy: %v", err)
+			}
+			policy.SetRandomSource(NewRandomSource(1, 0))
+			if err := policy.SetInitialState(context.Background(), world); err != nil {
+				t.Fatalf("SetInitialState failed: %v", err)
+			}
+			if err := policy.GenerateEvents(context.Background(), world); err != nil {
+				t.Fatalf("GenerateEvents failed: %v", err)
 			}
 
-			// Verify the constraint value
+			if got := world.turnaround; (got - tt.want).Abs() > time.Second {
+				t.Errorf("Expected a %v turnaround, got %v", tt.want, got)
+			}
+			// The closures share the turnaround estimated for the initial state
 			for _, evt := range world.events {
-				if evt.Type() == event.GateCapacityConstraintType {
-					gateEvt, ok := evt.(*event.GateCapacityConstraintEvent)
-					if !ok {
-						t.Fatal("Failed to cast event to GateCapacityConstraintEvent")
-					}
-
-					// Convert movements per second to movements per hour
-					movementsPerHour := gateEvt.MaxMovementsPerSecond() * 3600
-
-					diff := movementsPerHour - tt.expectedMovementsPerHour
-					if diff < 0 {
-						diff = -diff
-					}
-
-					if diff > tt.tolerance {
-						t.Errorf("Expected ~%.2f movements/hour, got %.2f (diff: %.2f)",
-							tt.expectedMovementsPerHour, movementsPerHour, diff)
-					}
-
-					// Event should be at simulation start
-					if !evt.Time().Equal(simStart) {
-						t.Errorf("Expected event at %v, got %v", simStart, evt.Time())
-					}
+				if got := evt.(*event.GateCapacityConstraintEvent).Turnaround(); got != world.turnaround {
+					t.Errorf("Expected the closure at %v to keep the %v turnaround, got %v", evt.Time(), world.turnaround, got)
 				}
 			}
 		})
 	}
 }
 
-func TestGateCapacityPolicy_IntegrationWithWorld(t *testing.T) {
-	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-	simEnd := simStart.AddDate(0, 0, 1)
+// gateCountWorld is a mock initial state world that exposes an airport gate count
+type gateCountWorld struct {
+	*mockInitialStateWorld
+	gateCount int
+}
 
-	constraint := GateCapacityConstraint{
-		TotalGates:            50,
-		AverageTurnaroundTime: 2 * time.Hour,
+func (w *gateCountWorld) GetGateCount() int {
+	return w.gateCount
+}
+
+func TestGateCapacityPolicy_UseAirportGates(t *testing.T) {
+	if _, err := NewGateCapacityPolicy(GateCapacityConstraint{UseAirportGates: true, TotalGates: 10, AverageTurnaroundTime: time.Hour}); err == nil {
+		t.Error("Expected error when setting total gates with UseAirportGates")
 	}
 
-	policy, err := NewGateCapacityPolicy(constraint)
+	policy, err := NewGateCapacityPolicy(GateCapacityConstraint{UseAirportGates: true, AverageTurnaroundTime: time.Hour})
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
-	err = policy.GenerateEvents(context.Background(), world)
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(24 * time.Hour)
+
+	// Without a gate count there is nothing to constrain
+	noGates := &gateCountWorld{mockInitialStateWorld: newMockInitialStateWorld(startTime, endTime)}
+	if err := policy.Validate(noGates); err == nil {
+		t.Error("Expected validation error when the airport has no gate count")
+	}
+	if err := policy.SetInitialState(context.Background(), noGates); err == nil {
+		t.Error("Expected SetInitialState error when the airport has no gate count")
+	}
+	if err := policy.GenerateEvents(context.Background(), noGates); err == nil {
+		t.Error("Expected GenerateEvents error when the airport has no gate count")
+	}
+
+	world := &gateCountWorld{mockInitialStateWorld: newMockInitialStateWorld(startTime, endTime), gateCount: 30}
+	if err := policy.Validate(world); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+
+	if world.gates != 30 {
+		t.Errorf("Expected the airport's 30 gates, got %d", world.gates)
+	}
+}
+
+func TestGateCapacityPolicy_Closures(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.AddDate(0, 6, 0)
+
+	// A terminal refurbishment closing 10 gates for three months, overlapped for a month by
+	// 5 more gates, and a closure ending before the simulation starts
+	policy, err := NewGateCapacityPolicy(GateCapacityConstraint{
+		TotalGates:            40,
+		AverageTurnaroundTime: time.Hour,
+		Closures: []GateClosure{
+			{Gates: 10, Start: startTime.AddDate(0, 1, 0), End: startTime.AddDate(0, 4, 0)},
+			{Gates: 5, Start: startTime.AddDate(0, 2, 0), End: startTime.AddDate(0, 3, 0)},
+			{Gates: 20, Start: startTime.AddDate(-1, 0, 0), End: startTime},
+		},
+	})
 	if err != nil {
-		t.Fatalf("GenerateEvents failed: %v", err)
+		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	// Verify the gate capacity event
-	foundGateEvent := false
-	for _, evt := range world.events {
-		if evt.Type() == event.GateCapacityConstraintType {
-			foundGateEvent = true
+	world := newMockInitialStateWorld(startTime, endTime)
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
 
-			// The event should have the Apply method that sets the constraint
-			gateEvt := evt.(*event.GateCapacityConstraintEvent)
-			if gateEvt.MaxMovementsPerSecond() <= 0 {
-				t.Error("Expected positive gate capacity constraint")
-			}
+	if world.gates != 40 {
+		t.Errorf("Expected all 40 gates open at the start, got %d", world.gates)
+	}
+	want := []struct {
+		time  time.Time
+		gates int
+	}{
+		{startTime.AddDate(0, 1, 0), 30},
+		{startTime.AddDate(0, 2, 0), 25},
+		{startTime.AddDate(0, 3, 0), 30},
+		{startTime.AddDate(0, 4, 0), 40},
+	}
+	if len(world.events) != len(want) {
+		t.Fatalf("Expected %d gate capacity events, got %d", len(want), len(world.events))
+	}
+	for i, w := range want {
+		evt, ok := world.events[i].(*event.GateCapacityConstraintEvent)
+		if !ok {
+			t.Fatalf("Event %d: expected GateCapacityConstraintEvent, got %T", i, world.events[i])
+		}
+		if !evt.Time().Equal(w.time) {
+			t.Errorf("Event %d: expected time %v, got %v", i, w.time, evt.Time())
+		}
+		if evt.Gates() != w.gates {
+			t.Errorf("Event %d: expected %d open gates, got %d", i, w.gates, evt.Gates())
 		}
 	}
 
-	if !foundGateEvent {
-		t.Error("Expected gate capacity event to be generated")
+	// Closures are checked against the airport's gates when using them
+	airportGates, err := NewGateCapacityPolicy(GateCapacityConstraint{
+		UseAirportGates:       true,
+		AverageTurnaroundTime: time.Hour,
+		Closures:              []GateClosure{{Gates: 10, Start: startTime, End: endTime}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+	if err := airportGates.Validate(&gateCountWorld{mockInitialStateWorld: newMockInitialStateWorld(startTime, endTime), gateCount: 10}); err == nil {
+		t.Error("Expected validation error when closures close every airport gate")
+	}
+	if err := airportGates.Validate(&gateCountWorld{mockInitialStateWorld: newMockInitialStateWorld(startTime, endTime), gateCount: 11}); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
 	}
 }
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// MaxGustFactorSeparation defines the maximum extra separation a gust factor may add (2 minutes).
+const MaxGustFactorSeparation = 2 * time.Minute
+
+// GustFactorConfiguration defines when gusts reduce runway throughput and by how much.
+type GustFactorConfiguration struct {
+	ThresholdKnots  float64       // Gust spread (gust minus mean wind speed) at which the extra separation applies
+	ExtraSeparation time.Duration // Separation added to every movement while gusty (e.g., 10s)
+}
+
+// GustFactorPolicy models the throughput lost in gusty conditions: approach speeds are
+// increased by the gust factor and pilots and controllers leave larger gaps, so every runway
+// needs extra separation while the gusts reported by a wind schedule (see WindChange.GustKnots)
+// exceed the mean wind by at least the threshold.
+type GustFactorPolicy struct {
+	config GustFactorConfiguration
+}
+
+// NewGustFactorPolicy creates a new gust factor policy with validation.
+// Returns an error if the threshold is negative or the extra separation is not positive or
+// exceeds MaxGustFactorSeparation.
+func NewGustFactorPolicy(config GustFactorConfiguration) (*GustFactorPolicy, error) {
+	if config.ThresholdKnots < 0 {
+		return nil, simerrors.Invalidf("gust factor threshold cannot be negative, got %g", config.ThresholdKnots)
+	}
+	if config.ExtraSeparation <= 0 {
+		return nil, simerrors.Invalidf("gust factor separation must be positive, got %v", config.ExtraSeparation)
+	}
+	if config.ExtraSeparation > MaxGustFactorSeparation {
+		return nil, simerrors.Invalidf("gust factor separation %v exceeds maximum %v", config.ExtraSeparation, MaxGustFactorSeparation)
+	}
+
+	return &GustFactorPolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *GustFactorPolicy) Name() string {
+	return "GustFactorPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *GustFactorPolicy) Parameters() any {
+	return p.config
+}
+
+// GenerateEvents generates a gust factor event at simulation start. The world then adds the
+// extra separation whenever the current wind is gusty enough.
+func (p *GustFactorPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	world.ScheduleEvent(event.NewGustFactorEvent(p.config.ThresholdKnots, p.config.ExtraSeparation, world.GetStartTime()))
+	return nil
+}
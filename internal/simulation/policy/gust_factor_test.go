@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewGustFactorPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  GustFactorConfiguration
+		wantErr bool
+	}{
+		{"valid", GustFactorConfiguration{ThresholdKnots: 10, ExtraSeparation: 15 * time.Second}, false},
+		{"zero threshold", GustFactorConfiguration{ExtraSeparation: 15 * time.Second}, false},
+		{"negative threshold", GustFactorConfiguration{ThresholdKnots: -1, ExtraSeparation: 15 * time.Second}, true},
+		{"zero separation", GustFactorConfiguration{ThresholdKnots: 10}, true},
+		{"separation too long", GustFactorConfiguration{ThresholdKnots: 10, ExtraSeparation: 3 * time.Minute}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGustFactorPolicy(tt.config)
+			if tt.wantErr && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGustFactorPolicy_GenerateEvents(t *testing.T) {
+	p, err := NewGustFactorPolicy(GustFactorConfiguration{ThresholdKnots: 10, ExtraSeparation: 15 * time.Second})
+	if err != nil {
+		t.Fatalf("NewGustFactorPolicy: %v", err)
+	}
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(1, 0, 0), []string{"09"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+	if got := world.CountEventsByType(event.GustFactorType); got != 1 {
+		t.Errorf("Expected 1 gust factor event, got %d", got)
+	}
+}
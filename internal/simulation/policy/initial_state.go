@@ -0,0 +1,27 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// InitialStateWorld is the world an InitialStatePolicy establishes starting conditions in: the
+// simulation world itself, so state set through it is in force from the start time without
+// any event. Events can be applied to it directly (evt.Apply(ctx, world)) to reuse their logic.
+type InitialStateWorld interface {
+	EventWorld
+	event.WorldState
+}
+
+// InitialStatePolicy is implemented by policies that establish conditions in force when the
+// simulation starts (e.g., a static wind, a runway already closed, a rotation multiplier)
+// rather than scheduling events at the start time, which race with the other events there.
+//
+// The simulation calls SetInitialState on each such policy in turn, in the order their events
+// would be applied (see PrioritizedPolicy), before any policy generates events; events at the
+// start time are then applied on top of the initial state. Policies added when resuming from a
+// checkpoint are not asked, since the run has already started.
+type InitialStatePolicy interface {
+	SetInitialState(ctx context.Context, world InitialStateWorld) error
+}
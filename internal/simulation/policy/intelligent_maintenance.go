@@ -2,38 +2,94 @@ package policy
 
 import (
 	"context"
-	"fmt"
-	"sort"
+	"errors"
+	"slices"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
-// TimeWindow represents a time period.
-type TimeWindow struct {
-	Start time.Time
-	End   time.Time
-}
-
 // IntelligentMaintenanceSchedule defines an intelligent maintenance schedule that coordinates with operational constraints.
 type IntelligentMaintenanceSchedule struct {
-	RunwayDesignations       []string      // Runway identifiers to maintain
-	Duration                 time.Duration // Duration of maintenance window
-	Frequency                time.Duration // How often maintenance must occur
+	RunwayDesignations        []string      // Runway identifiers to maintain
+	Duration                  time.Duration // Duration of maintenance window
+	Frequency                 time.Duration // How often maintenance must occur
 	MinimumOperationalRunways int           // Minimum runways that must remain operational (default: 1)
-	CurfewStart              *time.Time    // Optional: daily curfew start time (for coordination)
-	CurfewEnd                *time.Time    // Optional: daily curfew end time
+	CurfewStart               *time.Time    // Optional: daily curfew start time (for coordination)
+	CurfewEnd                 *time.Time    // Optional: daily curfew end time
+
+	Restrictions MaintenanceRestrictions // Blackout periods and allowed windows (zero value = any time)
+
+	// PeakHours are recurring periods of peak traffic that maintenance should avoid, in the
+	// simulation's time zone. Unlike restrictions, they are a preference: maintenance overlaps
+	// peak hours only when no off-peak start can be found within one frequency period.
+	PeakHours []PeakPeriod
+
+	// Chunked splits each maintenance task into chunks performed inside successive curfew
+	// windows (e.g., 40 hours of resurfacing done over several nights), so Duration is the
+	// total work rather than one contiguous block. Requires CurfewStart and CurfewEnd.
+	Chunked bool
+	// MinimumChunk is the shortest chunk worth scheduling when Chunked, given crew set-up and
+	// hand-back time; shorter curfew time is left unused (0 = any length).
+	MinimumChunk time.Duration
+}
+
+// PeakPeriod is a recurring period of the week with peak traffic (e.g., the morning bank).
+type PeakPeriod struct {
+	Weekdays  []time.Weekday // Days the period starts (empty = every day)
+	StartHour int            // Hour the period starts [0, 23]
+	EndHour   int            // Hour the period ends [0, 24]; before StartHour means the next day
+}
+
+// length returns how long each occurrence of the period lasts.
+func (pp PeakPeriod) length() time.Duration {
+	hours := pp.EndHour - pp.StartHour
+	if hours <= 0 {
+		hours += 24
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// startsOn reports whether the period starts on the given day of the week.
+func (pp PeakPeriod) startsOn(day time.Weekday) bool {
+	return len(pp.Weekdays) == 0 || slices.Contains(pp.Weekdays, day)
 }
 
 // IntelligentMaintenancePolicy schedules runway maintenance intelligently by:
 // - Preferring maintenance during or adjacent to curfew periods
 // - Coordinating across runways to maintain minimum operational capacity
+// - Respecting blackout periods and allowed windows
+// - Avoiding peak traffic hours where possible
 type IntelligentMaintenancePolicy struct {
 	schedule IntelligentMaintenanceSchedule
 }
 
 // NewIntelligentMaintenancePolicy creates a new intelligent maintenance policy.
+// Returns an error if a peak period has invalid hours or weekdays, or covers the whole day, or
+// if chunked maintenance has no curfew or an invalid minimum chunk.
 func NewIntelligentMaintenancePolicy(schedule IntelligentMaintenanceSchedule) (*IntelligentMaintenancePolicy, error) {
+	if schedule.MinimumChunk < 0 || schedule.MinimumChunk > schedule.Duration {
+		return nil, simerrors.Invalidf("minimum maintenance chunk must be in [0, %v], got %v", schedule.Duration, schedule.MinimumChunk)
+	}
+	if schedule.Chunked && (schedule.CurfewStart == nil || schedule.CurfewEnd == nil) {
+		return nil, simerrors.Invalidf("chunked maintenance requires curfew start and end times")
+	}
+	for _, peak := range schedule.PeakHours {
+		if peak.StartHour < 0 || peak.StartHour > 23 || peak.EndHour < 0 || peak.EndHour > 24 {
+			return nil, simerrors.Invalidf("peak hours must be in [0, 23] and [0, 24], got %d to %d", peak.StartHour, peak.EndHour)
+		}
+		if peak.StartHour == peak.EndHour%24 {
+			return nil, simerrors.Invalidf("peak period %02d:00-%02d:00 cannot cover the whole day", peak.StartHour, peak.EndHour)
+		}
+		for _, day := range peak.Weekdays {
+			if day < time.Sunday || day > time.Saturday {
+				return nil, simerrors.Invalidf("invalid weekday %d in peak period", day)
+			}
+		}
+	}
+	schedule.PeakHours = slices.Clone(schedule.PeakHours)
+
 	// Set defaults
 	if schedule.MinimumOperationalRunways <= 0 {
 		schedule.MinimumOperationalRunways = 1
@@ -49,6 +105,23 @@ func (p *IntelligentMaintenancePolicy) Name() string {
 	return "IntelligentMaintenancePolicy"
 }
 
+// Parameters returns the policy configuration.
+func (p *IntelligentMaintenancePolicy) Parameters() any {
+	return p.schedule
+}
+
+// Validate checks that every runway exists and that the restrictions are valid.
+func (p *IntelligentMaintenancePolicy) Validate(world EventWorld) error {
+	problems := unknownRunways(world, p.Name(), p.schedule.RunwayDesignations)
+	// Chunks only need to fit the shortest chunk into an allowed window
+	duration := p.schedule.Duration
+	if p.schedule.Chunked {
+		duration = p.schedule.MinimumChunk
+	}
+	problems = append(problems, p.schedule.Restrictions.validate(p.Name(), duration)...)
+	return errors.Join(problems...)
+}
+
 // maintenanceWindow represents a scheduled maintenance period for a runway.
 type maintenanceWindow struct {
 	RunwayID string
@@ -79,12 +152,12 @@ func (p *IntelligentMaintenancePolicy) GenerateEvents(ctx context.Context, world
 			}
 		}
 		if !runwayExists {
-			return fmt.Errorf("runway %s not found in airport", runwayDesignation)
+			return &simerrors.RunwayNotFoundError{RunwayID: runwayDesignation}
 		}
 	}
 
 	// Build curfew windows for the entire simulation period
-	curfewWindows := p.buildCurfewWindows(startTime, endTime)
+	curfewWindows := p.buildCurfewWindows(startTime, endTime, location(world))
 
 	// Track maintenance schedules for runway coordination
 	scheduledMaintenance := []maintenanceWindow{}
@@ -96,6 +169,19 @@ func (p *IntelligentMaintenancePolicy) GenerateEvents(ctx context.Context, world
 		currentTime := startTime.Add(offset)
 
 		for i := 0; i < maintenanceWindows; i++ {
+			if p.schedule.Chunked {
+				// Each task starts after the previous one's chunks are complete
+				lastEnd, complete := p.scheduleChunks(world, runwayDesignation, currentTime, endTime, curfewWindows, &scheduledMaintenance)
+				if !complete {
+					break
+				}
+				currentTime = currentTime.Add(p.schedule.Frequency)
+				if lastEnd.After(currentTime) {
+					currentTime = lastEnd
+				}
+				continue
+			}
+
 			// Find optimal maintenance window
 			maintenanceStart := p.findOptimalWindow(
 				currentTime,
@@ -104,9 +190,13 @@ func (p *IntelligentMaintenancePolicy) GenerateEvents(ctx context.Context, world
 				scheduledMaintenance,
 			)
 
-			// If we couldn't find an optimal window, use current time
+			// If we couldn't find an optimal window, use the first time the restrictions permit
 			if maintenanceStart.IsZero() {
-				maintenanceStart = currentTime
+				permittedStart, ok := p.schedule.Restrictions.nextPermittedStart(currentTime, p.schedule.Duration, endTime)
+				if !ok {
+					break
+				}
+				maintenanceStart = permittedStart
 			}
 
 			// Ensure we don't exceed simulation end
@@ -134,44 +224,68 @@ func (p *IntelligentMaintenancePolicy) GenerateEvents(ctx context.Context, world
 	return nil
 }
 
-// buildCurfewWindows builds all curfew time windows for the simulation period.
-func (p *IntelligentMaintenancePolicy) buildCurfewWindows(startTime, endTime time.Time) []TimeWindow {
-	if p.schedule.CurfewStart == nil || p.schedule.CurfewEnd == nil {
-		return nil
-	}
-
-	windows := []TimeWindow{}
-	currentDate := startTime
-
-	curfewStartHour, curfewStartMinute := p.schedule.CurfewStart.Hour(), p.schedule.CurfewStart.Minute()
-	curfewEndHour, curfewEndMinute := p.schedule.CurfewEnd.Hour(), p.schedule.CurfewEnd.Minute()
-
-	for currentDate.Before(endTime) {
-		curfewStart := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			curfewStartHour, curfewStartMinute, 0, 0,
-			currentDate.Location(),
-		)
-
-		curfewEnd := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			curfewEndHour, curfewEndMinute, 0, 0,
-			currentDate.Location(),
-		)
+// scheduleChunks schedules one chunked maintenance task for a runway in the curfew windows
+// from the given time, filling each window the restrictions permit and coordination allows
+// until Duration of work is done. A chunk still in progress at the end of the simulation has
+// no end event. Returns the end of the last chunk, and whether all the work was scheduled.
+func (p *IntelligentMaintenancePolicy) scheduleChunks(
+	world EventWorld,
+	runwayID string,
+	from, endTime time.Time,
+	curfewWindows []TimeWindow,
+	scheduledMaintenance *[]maintenanceWindow,
+) (time.Time, bool) {
+	remaining := p.schedule.Duration
+	lastEnd := from
 
-		// Handle overnight curfews
-		if curfewEndHour < curfewStartHour || (curfewEndHour == curfewStartHour && curfewEndMinute < curfewStartMinute) {
-			curfewEnd = curfewEnd.AddDate(0, 0, 1)
+	for _, curfew := range curfewWindows {
+		if remaining <= 0 {
+			break
+		}
+		chunkStart := curfew.Start
+		if chunkStart.Before(from) {
+			chunkStart = from
+		}
+		if !chunkStart.Before(endTime) {
+			break
+		}
+		chunkEnd := curfew.End
+		if chunkEnd.After(chunkStart.Add(remaining)) {
+			chunkEnd = chunkStart.Add(remaining)
 		}
 
-		if !curfewStart.After(endTime) && !curfewEnd.Before(startTime) {
-			windows = append(windows, TimeWindow{Start: curfewStart, End: curfewEnd})
+		chunk := chunkEnd.Sub(chunkStart)
+		if chunk <= 0 || chunk < p.schedule.MinimumChunk {
+			continue
+		}
+		if !p.schedule.Restrictions.Permits(chunkStart, chunk) ||
+			!p.checkRunwayCoordination(chunkStart, chunkEnd, *scheduledMaintenance) {
+			continue
 		}
 
-		currentDate = currentDate.AddDate(0, 0, 1)
+		world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent(runwayID, chunkStart))
+		if chunkEnd.Before(endTime) {
+			world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent(runwayID, chunkEnd))
+		}
+		*scheduledMaintenance = append(*scheduledMaintenance, maintenanceWindow{
+			RunwayID: runwayID,
+			Start:    chunkStart,
+			End:      chunkEnd,
+		})
+		remaining -= chunk
+		lastEnd = chunkEnd
 	}
 
-	return windows
+	return lastEnd, remaining <= 0
+}
+
+// buildCurfewWindows builds all curfew time windows for the simulation period. The curfew's
+// clock times are laid out daily in loc, so the windows follow daylight saving time changes.
+func (p *IntelligentMaintenancePolicy) buildCurfewWindows(startTime, endTime time.Time, loc *time.Location) []TimeWindow {
+	if p.schedule.CurfewStart == nil || p.schedule.CurfewEnd == nil {
+		return nil
+	}
+	return DailyWindows(startTime, endTime, *p.schedule.CurfewStart, *p.schedule.CurfewEnd, loc)
 }
 
 // findOptimalWindow finds the best time to schedule maintenance based on constraints.
@@ -188,7 +302,7 @@ func (p *IntelligentMaintenancePolicy) findOptimalWindow(
 		if curfew.Start.After(preferredStart) || curfew.Start.Equal(preferredStart) {
 			if curfew.End.Sub(curfew.Start) >= duration {
 				// Check runway coordination
-				if p.checkRunwayCoordination(curfew.Start, curfew.Start.Add(duration), existingMaintenance) {
+				if p.acceptable(curfew.Start, existingMaintenance) {
 					return curfew.Start
 				}
 			}
@@ -199,7 +313,7 @@ func (p *IntelligentMaintenancePolicy) findOptimalWindow(
 	for _, curfew := range curfewWindows {
 		adjacentStart := curfew.Start.Add(-duration)
 		if !adjacentStart.Before(preferredStart) && adjacentStart.Add(duration).Before(endTime) {
-			if p.checkRunwayCoordination(adjacentStart, adjacentStart.Add(duration), existingMaintenance) {
+			if p.acceptable(adjacentStart, existingMaintenance) {
 				return adjacentStart
 			}
 		}
@@ -208,21 +322,89 @@ func (p *IntelligentMaintenancePolicy) findOptimalWindow(
 	// Try 3: Adjacent to curfew end (maintenance starts when curfew ends)
 	for _, curfew := range curfewWindows {
 		if !curfew.End.Before(preferredStart) && curfew.End.Add(duration).Before(endTime) {
-			if p.checkRunwayCoordination(curfew.End, curfew.End.Add(duration), existingMaintenance) {
+			if p.acceptable(curfew.End, existingMaintenance) {
 				return curfew.End
 			}
 		}
 	}
 
-	// Try 4: Fallback to preferred start if coordination allows
-	if p.checkRunwayCoordination(preferredStart, preferredStart.Add(duration), existingMaintenance) {
-		return preferredStart
+	// Try 4: The first off-peak start the restrictions permit within one frequency period, if
+	// coordination allows
+	if offPeakStart, ok := p.nextOffPeakStart(preferredStart, endTime); ok {
+		if p.checkRunwayCoordination(offPeakStart, offPeakStart.Add(duration), existingMaintenance) {
+			return offPeakStart
+		}
+		return time.Time{}
+	}
+
+	// Try 5: Fallback to the first start the restrictions permit if coordination allows
+	if permittedStart, ok := p.schedule.Restrictions.nextPermittedStart(preferredStart, duration, endTime); ok &&
+		p.checkRunwayCoordination(permittedStart, permittedStart.Add(duration), existingMaintenance) {
+		return permittedStart
 	}
 
 	// If all else fails, return zero time (caller will use current time)
 	return time.Time{}
 }
 
+// acceptable reports whether maintenance starting at start is permitted by the restrictions,
+// avoids peak hours and keeps the minimum operational runways.
+func (p *IntelligentMaintenancePolicy) acceptable(start time.Time, existingMaintenance []maintenanceWindow) bool {
+	end := start.Add(p.schedule.Duration)
+	if _, peak := p.peakOverlap(start, end); peak {
+		return false
+	}
+	return p.schedule.Restrictions.Permits(start, p.schedule.Duration) && p.checkRunwayCoordination(start, end, existingMaintenance)
+}
+
+// nextOffPeakStart returns the earliest time at or after from, and within one frequency
+// period of it, at which maintenance is permitted by the restrictions and avoids peak hours,
+// or false if there is none before then or endTime.
+func (p *IntelligentMaintenancePolicy) nextOffPeakStart(from, endTime time.Time) (time.Time, bool) {
+	limit := from.Add(p.schedule.Frequency)
+	if endTime.Before(limit) {
+		limit = endTime
+	}
+
+	t := from
+	for {
+		permittedStart, ok := p.schedule.Restrictions.nextPermittedStart(t, p.schedule.Duration, limit)
+		if !ok {
+			return time.Time{}, false
+		}
+		peakEnd, peak := p.peakOverlap(permittedStart, permittedStart.Add(p.schedule.Duration))
+		if !peak {
+			return permittedStart, true
+		}
+		t = peakEnd
+	}
+}
+
+// peakOverlap reports whether [start, end) overlaps an occurrence of a peak period, and
+// returns the end of the first overlapping occurrence found.
+func (p *IntelligentMaintenancePolicy) peakOverlap(start, end time.Time) (time.Time, bool) {
+	if len(p.schedule.PeakHours) == 0 {
+		return time.Time{}, false
+	}
+
+	// Start a day early for overnight periods that started the day before
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, -1)
+	for day.Before(end) {
+		for _, peak := range p.schedule.PeakHours {
+			if !peak.startsOn(day.Weekday()) {
+				continue
+			}
+			peakStart := day.Add(time.Duration(peak.StartHour) * time.Hour)
+			occurrence := TimeWindow{Start: peakStart, End: peakStart.Add(peak.length())}
+			if occurrence.Overlaps(TimeWindow{Start: start, End: end}) {
+				return occurrence.End, true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
 // checkRunwayCoordination ensures minimum operational runways are maintained.
 func (p *IntelligentMaintenancePolicy) checkRunwayCoordination(
 	proposedStart, proposedEnd time.Time,
@@ -240,7 +422,7 @@ func (p *IntelligentMaintenancePolicy) checkRunwayCoordination(
 
 	for _, maint := range existingMaintenance {
 		// Check if windows overlap
-		if proposedStart.Before(maint.End) && proposedEnd.After(maint.Start) {
+		if (TimeWindow{Start: proposedStart, End: proposedEnd}).Overlaps(TimeWindow{Start: maint.Start, End: maint.End}) {
 			concurrentMaintenance++
 		}
 	}
@@ -250,14 +432,3 @@ func (p *IntelligentMaintenancePolicy) checkRunwayCoordination(
 
 	return concurrentMaintenance < maxConcurrentMaintenance
 }
-
-// Helper to sort maintenance windows by start time
-type byStartTime []TimeWindow
-
-func (a byStartTime) Len() int           { return len(a) }
-func (a byStartTime) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a byStartTime) Less(i, j int) bool { return a[i].Start.Before(a[j].Start) }
-
-func sortTimeWindows(windows []TimeWindow) {
-	sort.Sort(byStartTime(windows))
-}
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -16,12 +18,12 @@ func TestIntelligentMaintenancePolicy_CurfewCoordination(t *testing.T) {
 	curfewEnd := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
 
 	schedule := IntelligentMaintenanceSchedule{
-		RunwayDesignations:       []string{"09L"},
-		Duration:                 4 * time.Hour, // 4-hour maintenance fits in 7-hour curfew
-		Frequency:                7 * 24 * time.Hour, // Once per week
+		RunwayDesignations:        []string{"09L"},
+		Duration:                  4 * time.Hour,      // 4-hour maintenance fits in 7-hour curfew
+		Frequency:                 7 * 24 * time.Hour, // Once per week
 		MinimumOperationalRunways: 1,
-		CurfewStart:              &curfewStart,
-		CurfewEnd:                &curfewEnd,
+		CurfewStart:               &curfewStart,
+		CurfewEnd:                 &curfewEnd,
 	}
 
 	policy, err := NewIntelligentMaintenancePolicy(schedule)
@@ -54,16 +56,60 @@ func TestIntelligentMaintenancePolicy_CurfewCoordination(t *testing.T) {
 	}
 }
 
+func TestIntelligentMaintenancePolicy_CurfewInLocalTime(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// A 23:00-06:00 local curfew is 22:00-05:00 UTC during British Summer Time
+	simStart := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	curfewStart := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	curfewEnd := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	policy, err := NewIntelligentMaintenancePolicy(IntelligentMaintenanceSchedule{
+		RunwayDesignations:        []string{"09L"},
+		Duration:                  7 * time.Hour,
+		Frequency:                 7 * 24 * time.Hour,
+		MinimumOperationalRunways: 1,
+		CurfewStart:               &curfewStart,
+		CurfewEnd:                 &curfewEnd,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := &locatedEventWorld{
+		mockEventWorld: newMockEventWorld(simStart, simStart.AddDate(0, 0, 7), []string{"09L"}),
+		location:       london,
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if world.CountEventsByType(event.RunwayMaintenanceStartType) == 0 {
+		t.Fatal("Expected at least one maintenance start event")
+	}
+	for _, evt := range world.events {
+		if evt.Type() != event.RunwayMaintenanceStartType {
+			continue
+		}
+		if local := evt.Time().In(london); local.Hour() != 23 || local.Minute() != 0 {
+			t.Errorf("Expected maintenance to start with the local curfew at 23:00, got %v", local)
+		}
+	}
+}
+
 func TestIntelligentMaintenancePolicy_RunwayCoordination(t *testing.T) {
 	// Setup: 2 runways, minimum 1 operational
 	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	simEnd := simStart.AddDate(0, 0, 7)
 
 	schedule := IntelligentMaintenanceSchedule{
-		RunwayDesignations:       []string{"09L", "09R"},
-		Duration:                 2 * time.Hour,
-		Frequency:                24 * time.Hour, // Daily maintenance
-		MinimumOperationalRunways: 1, // At least 1 runway must stay operational
+		RunwayDesignations:        []string{"09L", "09R"},
+		Duration:                  2 * time.Hour,
+		Frequency:                 24 * time.Hour, // Daily maintenance
+		MinimumOperationalRunways: 1,              // At least 1 runway must stay operational
 	}
 
 	policy, err := NewIntelligentMaintenancePolicy(schedule)
@@ -128,12 +174,12 @@ func TestIntelligentMaintenancePolicy_CurfewAdjacent(t *testing.T) {
 	curfewEnd := time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC) // Short 2-hour curfew
 
 	schedule := IntelligentMaintenanceSchedule{
-		RunwayDesignations:       []string{"09L"},
-		Duration:                 4 * time.Hour, // Too long for curfew, should be adjacent
-		Frequency:                24 * time.Hour,
+		RunwayDesignations:        []string{"09L"},
+		Duration:                  4 * time.Hour, // Too long for curfew, should be adjacent
+		Frequency:                 24 * time.Hour,
 		MinimumOperationalRunways: 1,
-		CurfewStart:              &curfewStart,
-		CurfewEnd:                &curfewEnd,
+		CurfewStart:               &curfewStart,
+		CurfewEnd:                 &curfewEnd,
 	}
 
 	policy, err := NewIntelligentMaintenancePolicy(schedule)
@@ -180,10 +226,10 @@ func TestIntelligentMaintenancePolicy_MultipleRunwaysStaggered(t *testing.T) {
 	simEnd := simStart.AddDate(0, 0, 30)
 
 	schedule := IntelligentMaintenanceSchedule{
-		RunwayDesignations:       []string{"09L", "09R", "18"},
-		Duration:                 4 * time.Hour,
-		Frequency:                30 * 24 * time.Hour, // Once per month
-		MinimumOperationalRunways: 2, // At least 2 runways must stay operational
+		RunwayDesignations:        []string{"09L", "09R", "18"},
+		Duration:                  4 * time.Hour,
+		Frequency:                 30 * 24 * time.Hour, // Once per month
+		MinimumOperationalRunways: 2,                   // At least 2 runways must stay operational
 	}
 
 	policy, err := NewIntelligentMaintenancePolicy(schedule)
@@ -231,13 +277,73 @@ func TestIntelligentMaintenancePolicy_InvalidConfiguration(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid peak hours",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           2 * time.Hour,
+				Frequency:          24 * time.Hour,
+				PeakHours:          []PeakPeriod{{StartHour: 6, EndHour: 10}, {Weekdays: []time.Weekday{time.Friday}, StartHour: 16, EndHour: 24}},
+			},
+			expectError: false,
+		},
+		{
+			name: "chunked without curfew",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           40 * time.Hour,
+				Frequency:          14 * 24 * time.Hour,
+				Chunked:            true,
+			},
+			expectError: true,
+		},
+		{
+			name: "negative minimum chunk",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           2 * time.Hour,
+				Frequency:          24 * time.Hour,
+				MinimumChunk:       -time.Hour,
+			},
+			expectError: true,
+		},
+		{
+			name: "peak hour out of range",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           2 * time.Hour,
+				Frequency:          24 * time.Hour,
+				PeakHours:          []PeakPeriod{{StartHour: 6, EndHour: 25}},
+			},
+			expectError: true,
+		},
+		{
+			name: "peak period covering whole day",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           2 * time.Hour,
+				Frequency:          24 * time.Hour,
+				PeakHours:          []PeakPeriod{{StartHour: 0, EndHour: 24}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid peak weekday",
+			schedule: IntelligentMaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           2 * time.Hour,
+				Frequency:          24 * time.Hour,
+				PeakHours:          []PeakPeriod{{Weekdays: []time.Weekday{7}, StartHour: 6, EndHour: 10}},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := NewIntelligentMaintenancePolicy(tt.schedule)
-			if tt.expectError && err == nil {
-				t.Error("Expected error but got none")
+			if tt.expectError && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
 			}
 			if !tt.expectError && err != nil {
 				t.Errorf("Unexpected error: %v", err)
@@ -251,9 +357,9 @@ func TestIntelligentMaintenancePolicy_NonexistentRunway(t *testing.T) {
 	simEnd := simStart.AddDate(0, 0, 7)
 
 	schedule := IntelligentMaintenanceSchedule{
-		RunwayDesignations:       []string{"27L"}, // Doesn't exist in mock
-		Duration:                 2 * time.Hour,
-		Frequency:                24 * time.Hour,
+		RunwayDesignations:        []string{"27L"}, // Doesn't exist in mock
+		Duration:                  2 * time.Hour,
+		Frequency:                 24 * time.Hour,
 		MinimumOperationalRunways: 1,
 	}
 
@@ -268,3 +374,155 @@ func TestIntelligentMaintenancePolicy_NonexistentRunway(t *testing.T) {
 		t.Error("Expected error for nonexistent runway, got nil")
 	}
 }
+
+func TestIntelligentMaintenancePolicy_PeakHours(t *testing.T) {
+	// Daily maintenance with no curfew would start at midnight each day; the 22:00-08:00
+	// peak pushes it to 08:00
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 7)
+
+	schedule := IntelligentMaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           4 * time.Hour,
+		Frequency:          24 * time.Hour,
+		PeakHours:          []PeakPeriod{{StartHour: 22, EndHour: 8}},
+	}
+
+	policy, err := NewIntelligentMaintenancePolicy(schedule)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	starts := 0
+	for _, evt := range world.GetEvents() {
+		if evt.Type() != event.RunwayMaintenanceStartType {
+			continue
+		}
+		starts++
+		if hour := evt.Time().Hour(); hour < 8 || hour+4 > 22 {
+			t.Errorf("Maintenance at %v overlaps peak hours", evt.Time())
+		}
+	}
+	if starts != 7 {
+		t.Errorf("Expected 7 maintenance windows, got %d", starts)
+	}
+}
+
+func TestIntelligentMaintenancePolicy_PeakHoursUnavoidable(t *testing.T) {
+	// Off-peak gaps are only 2 hours, too short for 4-hour maintenance, so maintenance still
+	// takes place during peak hours rather than not at all
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 3)
+
+	schedule := IntelligentMaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           4 * time.Hour,
+		Frequency:          24 * time.Hour,
+		PeakHours:          []PeakPeriod{{StartHour: 2, EndHour: 12}, {StartHour: 14, EndHour: 24}},
+	}
+
+	policy, err := NewIntelligentMaintenancePolicy(schedule)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if got := world.CountEventsByType(event.RunwayMaintenanceStartType); got != 3 {
+		t.Errorf("Expected 3 maintenance windows, got %d", got)
+	}
+}
+
+func TestIntelligentMaintenancePolicy_Chunked(t *testing.T) {
+	// 40 hours of work in nightly 7-hour curfews (23:00-06:00): five full nights and a 5-hour chunk
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 14)
+	curfewStart := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	curfewEnd := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	schedule := IntelligentMaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           40 * time.Hour,
+		Frequency:          14 * 24 * time.Hour,
+		CurfewStart:        &curfewStart,
+		CurfewEnd:          &curfewEnd,
+		Chunked:            true,
+	}
+
+	policy, err := NewIntelligentMaintenancePolicy(schedule)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	var starts, ends []time.Time
+	for _, evt := range world.GetEvents() {
+		switch evt.Type() {
+		case event.RunwayMaintenanceStartType:
+			starts = append(starts, evt.Time())
+		case event.RunwayMaintenanceEndType:
+			ends = append(ends, evt.Time())
+		}
+	}
+	if len(starts) != 6 || len(ends) != 6 {
+		t.Fatalf("Expected 6 chunks, got %d starts and %d ends", len(starts), len(ends))
+	}
+
+	var total time.Duration
+	for i := range starts {
+		if starts[i].Hour() != 23 {
+			t.Errorf("Chunk %d starts at %v, want curfew start", i, starts[i])
+		}
+		if ends[i].Hour() > 6 && ends[i].Hour() < 23 {
+			t.Errorf("Chunk %d ends at %v, outside curfew", i, ends[i])
+		}
+		total += ends[i].Sub(starts[i])
+	}
+	if total != 40*time.Hour {
+		t.Errorf("Expected 40 hours of maintenance, got %v", total)
+	}
+}
+
+func TestIntelligentMaintenancePolicy_ChunkedMinimumChunk(t *testing.T) {
+	// Curfews shorter than the minimum chunk are left unused, so no maintenance is scheduled
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 7)
+	curfewStart := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	curfewEnd := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	schedule := IntelligentMaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           40 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+		CurfewStart:        &curfewStart,
+		CurfewEnd:          &curfewEnd,
+		Chunked:            true,
+		MinimumChunk:       8 * time.Hour,
+	}
+
+	policy, err := NewIntelligentMaintenancePolicy(schedule)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if got := world.CountEventsByType(event.RunwayMaintenanceStartType); got != 0 {
+		t.Errorf("Expected no maintenance, got %d chunks", got)
+	}
+}
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"math/rand/v2"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 	"slices"
 )
@@ -14,11 +16,15 @@ type MaintenanceSchedule struct {
 	RunwayDesignations []string      // Runway identifiers to maintain
 	Duration           time.Duration // Duration of maintenance window
 	Frequency          time.Duration // How often maintenance occurs
+	Jitter             time.Duration // Maximum random delay applied to each window start (0 = deterministic)
+
+	Restrictions MaintenanceRestrictions // Blackout periods and allowed windows (zero value = any time)
 }
 
 // MaintenancePolicy schedules runway maintenance that temporarily removes runways from operation.
 type MaintenancePolicy struct {
 	schedule MaintenanceSchedule
+	rng      *rand.Rand // Random source for jitter (set by the simulation)
 }
 
 // NewMaintenancePolicy creates a new maintenance policy.
@@ -33,8 +39,40 @@ func (p *MaintenancePolicy) Name() string {
 	return "MaintenancePolicy"
 }
 
+// Parameters returns the policy configuration.
+func (p *MaintenancePolicy) Parameters() any {
+	return p.schedule
+}
+
+// SetRandomSource sets the random source used to jitter maintenance windows.
+// This implements the StochasticPolicy interface.
+func (p *MaintenancePolicy) SetRandomSource(rng *rand.Rand) {
+	p.rng = rng
+}
+
+// Validate checks that every runway exists, that the duration and frequency are positive, and
+// that the restrictions are valid.
+func (p *MaintenancePolicy) Validate(world EventWorld) error {
+	problems := unknownRunways(world, p.Name(), p.schedule.RunwayDesignations)
+	if p.schedule.Duration <= 0 {
+		problems = append(problems, simerrors.Invalidf("%s: maintenance duration must be positive, got %v", p.Name(), p.schedule.Duration))
+	}
+	if p.schedule.Frequency <= 0 {
+		problems = append(problems, simerrors.Invalidf("%s: maintenance frequency must be positive, got %v", p.Name(), p.schedule.Frequency))
+	}
+	if p.schedule.Jitter < 0 {
+		problems = append(problems, simerrors.Invalidf("%s: maintenance jitter cannot be negative, got %v", p.Name(), p.schedule.Jitter))
+	}
+	problems = append(problems, p.schedule.Restrictions.validate(p.Name(), p.schedule.Duration)...)
+	return errors.Join(problems...)
+}
+
 // GenerateEvents generates maintenance start and end events for each runway according to the schedule.
 // Maintenance windows are distributed evenly across the simulation period.
+// If the schedule has a Jitter, each window start is delayed by a random amount in [0, Jitter).
+// Windows are then moved to the next time the restrictions permit, never overlapping the
+// runway's previous window, and dropped if no such time falls within the simulation period.
+// Without a random source from the simulation, a fixed seed of 0 is used.
 func (p *MaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	startTime := world.GetStartTime()
 	endTime := world.GetEndTime()
@@ -46,6 +84,10 @@ func (p *MaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld
 		maintenanceWindows = 1 // At least one maintenance window
 	}
 
+	if p.schedule.Jitter > 0 && p.rng == nil {
+		p.rng = NewRandomSource(0, 0)
+	}
+
 	// Get all runway IDs from world
 	allRunwayIDs := world.GetRunwayIDs()
 
@@ -55,14 +97,26 @@ func (p *MaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld
 		runwayExists := slices.Contains(allRunwayIDs, runwayDesignation)
 
 		if !runwayExists {
-			return fmt.Errorf("runway %s not found in airport", runwayDesignation)
+			return &simerrors.RunwayNotFoundError{RunwayID: runwayDesignation}
 		}
 
 		// Schedule maintenance windows evenly across the year
 		currentTime := startTime
+		var previousEnd time.Time
 		for range maintenanceWindows {
 			// Schedule maintenance start event
 			maintenanceStart := currentTime
+			if p.schedule.Jitter > 0 {
+				maintenanceStart = maintenanceStart.Add(time.Duration(p.rng.Int64N(int64(p.schedule.Jitter))))
+			}
+			if maintenanceStart.Before(previousEnd) {
+				maintenanceStart = previousEnd
+			}
+			permittedStart, ok := p.schedule.Restrictions.nextPermittedStart(maintenanceStart, p.schedule.Duration, endTime)
+			if !ok {
+				break
+			}
+			maintenanceStart = permittedStart
 			if maintenanceStart.Before(endTime) {
 				world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent(runwayDesignation, maintenanceStart))
 			}
@@ -72,6 +126,7 @@ func (p *MaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld
 			if maintenanceEnd.Before(endTime) {
 				world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent(runwayDesignation, maintenanceEnd))
 			}
+			previousEnd = maintenanceEnd
 
 			// Move to next maintenance window
 			currentTime = currentTime.Add(p.schedule.Frequency)
@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// AllowedMaintenanceWindow is a recurring period of the week in which maintenance may take place
+// (e.g., contractual night shifts), in the simulation's time zone.
+type AllowedMaintenanceWindow struct {
+	Weekdays  []time.Weekday // Days the window opens (empty = every day)
+	StartHour int            // Hour the window opens [0, 23]
+	EndHour   int            // Hour the window closes [0, 24]; at or before StartHour means the next day
+}
+
+// length returns how long each occurrence of the window lasts.
+func (w AllowedMaintenanceWindow) length() time.Duration {
+	hours := w.EndHour - w.StartHour
+	if hours <= 0 {
+		hours += 24
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// opensOn reports whether the window opens on the given day of the week.
+func (w AllowedMaintenanceWindow) opensOn(day time.Weekday) bool {
+	return len(w.Weekdays) == 0 || slices.Contains(w.Weekdays, day)
+}
+
+// MaintenanceRestrictions limits when maintenance may take place, reflecting contractual and
+// operational restrictions. The zero value allows maintenance at any time.
+type MaintenanceRestrictions struct {
+	// BlackoutPeriods are periods in which no maintenance may take place (e.g., holiday
+	// peaks). Maintenance may not overlap a blackout period at all.
+	BlackoutPeriods []TimeWindow
+
+	// AllowedWindows optionally restrict maintenance to recurring weekday and hour windows.
+	// Each maintenance period must fit entirely within a single occurrence of one window.
+	// nil allows maintenance at any time outside blackout periods.
+	AllowedWindows []AllowedMaintenanceWindow
+}
+
+// validate checks that every blackout period ends after it starts, and that every allowed
+// window has valid days and hours and is long enough for maintenance of the given duration.
+func (r MaintenanceRestrictions) validate(policyName string, duration time.Duration) []error {
+	var problems []error
+	for _, blackout := range r.BlackoutPeriods {
+		if !blackout.End.After(blackout.Start) {
+			problems = append(problems, simerrors.Invalidf("%s: blackout period must end after it starts, got %v to %v", policyName, blackout.Start, blackout.End))
+		}
+	}
+	for _, window := range r.AllowedWindows {
+		if window.StartHour < 0 || window.StartHour > 23 || window.EndHour < 0 || window.EndHour > 24 {
+			problems = append(problems, simerrors.Invalidf("%s: allowed window hours must be in [0, 23] and [0, 24], got %d to %d", policyName, window.StartHour, window.EndHour))
+			continue
+		}
+		for _, day := range window.Weekdays {
+			if day < time.Sunday || day > time.Saturday {
+				problems = append(problems, simerrors.Invalidf("%s: invalid weekday %d in allowed window", policyName, day))
+			}
+		}
+		if window.length() < duration {
+			problems = append(problems, simerrors.Invalidf("%s: allowed window %02d:00-%02d:00 is shorter than the maintenance duration %v", policyName, window.StartHour, window.EndHour, duration))
+		}
+	}
+	return problems
+}
+
+// Validate checks that every blackout period ends after it starts, and that every allowed
+// window has valid days and hours and is long enough for maintenance of the given duration.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (r MaintenanceRestrictions) Validate(duration time.Duration) error {
+	return errors.Join(r.validate("maintenance restrictions", duration)...)
+}
+
+// Permits reports whether maintenance may take place over [start, start+duration).
+func (r MaintenanceRestrictions) Permits(start time.Time, duration time.Duration) bool {
+	next, ok := r.nextPermittedStart(start, duration, start.Add(time.Nanosecond))
+	return ok && next.Equal(start)
+}
+
+// nextPermittedStart returns the earliest time at or after from at which maintenance of the
+// given duration may start, or false if there is none before limit.
+func (r MaintenanceRestrictions) nextPermittedStart(from time.Time, duration time.Duration, limit time.Time) (time.Time, bool) {
+	t := from
+	for t.Before(limit) {
+		end := t.Add(duration)
+
+		// Move past any blackout period the maintenance would overlap
+		blocked := false
+		for _, blackout := range r.BlackoutPeriods {
+			if blackout.Overlaps(TimeWindow{Start: t, End: end}) {
+				t = blackout.End
+				blocked = true
+				break
+			}
+		}
+		if blocked {
+			continue
+		}
+
+		next, ok := r.nextAllowedStart(t, duration)
+		if !ok {
+			return time.Time{}, false
+		}
+		if next.Equal(t) {
+			return t, true
+		}
+		t = next
+	}
+	return time.Time{}, false
+}
+
+// nextAllowedStart returns t if maintenance of the given duration starting at t fits within
+// an occurrence of an allowed window, and otherwise the start of the next occurrence that is
+// long enough. Returns false if no allowed window is long enough.
+func (r MaintenanceRestrictions) nextAllowedStart(t time.Time, duration time.Duration) (time.Time, bool) {
+	if len(r.AllowedWindows) == 0 {
+		return t, true
+	}
+
+	var next time.Time
+	// Start a day early for overnight windows that opened the day before; a week ahead
+	// covers every weekday
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, -1)
+	for range 9 {
+		for _, window := range r.AllowedWindows {
+			if !window.opensOn(day.Weekday()) || window.length() < duration {
+				continue
+			}
+			opens := day.Add(time.Duration(window.StartHour) * time.Hour)
+			closes := opens.Add(window.length())
+			if !opens.After(t) && !t.Add(duration).After(closes) {
+				return t, true
+			}
+			if opens.After(t) && (next.IsZero() || opens.Before(next)) {
+				next = opens
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return next, !next.IsZero()
+}
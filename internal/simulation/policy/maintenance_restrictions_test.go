@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestMaintenanceRestrictions_NextPermittedStart(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // 1 January 2024 is a Monday
+	limit := monday.AddDate(0, 1, 0)
+
+	nights := []AllowedMaintenanceWindow{{Weekdays: []time.Weekday{time.Tuesday, time.Thursday}, StartHour: 23, EndHour: 5}}
+	tests := []struct {
+		name         string
+		restrictions MaintenanceRestrictions
+		from         time.Time
+		duration     time.Duration
+		expected     time.Time
+	}{
+		{"unrestricted", MaintenanceRestrictions{}, monday.Add(10 * time.Hour), 4 * time.Hour, monday.Add(10 * time.Hour)},
+		{"next overnight window", MaintenanceRestrictions{AllowedWindows: nights}, monday, 4 * time.Hour, monday.AddDate(0, 0, 1).Add(23 * time.Hour)},
+		{"within an overnight window that opened the day before", MaintenanceRestrictions{AllowedWindows: nights}, monday.AddDate(0, 0, 2).Add(time.Hour), 4 * time.Hour, monday.AddDate(0, 0, 2).Add(time.Hour)},
+		{"too late in the window", MaintenanceRestrictions{AllowedWindows: nights}, monday.AddDate(0, 0, 2).Add(2 * time.Hour), 4 * time.Hour, monday.AddDate(0, 0, 3).Add(23 * time.Hour)},
+		{"after a blackout", MaintenanceRestrictions{
+			BlackoutPeriods: []TimeWindow{{Start: monday, End: monday.AddDate(0, 0, 7)}},
+		}, monday.Add(-time.Hour), 2 * time.Hour, monday.AddDate(0, 0, 7)},
+		{"first window after a blackout", MaintenanceRestrictions{
+			BlackoutPeriods: []TimeWindow{{Start: monday, End: monday.AddDate(0, 0, 5)}},
+			AllowedWindows:  nights,
+		}, monday, 4 * time.Hour, monday.AddDate(0, 0, 8).Add(23 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.restrictions.nextPermittedStart(tt.from, tt.duration, limit)
+			if !ok || !got.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v (found %v)", tt.expected, got, ok)
+			}
+		})
+	}
+
+	blackedOut := MaintenanceRestrictions{BlackoutPeriods: []TimeWindow{{Start: monday, End: limit}}}
+	if _, ok := blackedOut.nextPermittedStart(monday, time.Hour, limit); ok {
+		t.Error("expected no permitted start when the whole period is blacked out")
+	}
+}
+
+func TestMaintenanceRestrictions_Validate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		restrictions MaintenanceRestrictions
+	}{
+		{"blackout ends before start", MaintenanceRestrictions{BlackoutPeriods: []TimeWindow{{Start: start, End: start.Add(-time.Hour)}}}},
+		{"hour out of range", MaintenanceRestrictions{AllowedWindows: []AllowedMaintenanceWindow{{StartHour: 22, EndHour: 25}}}},
+		{"invalid weekday", MaintenanceRestrictions{AllowedWindows: []AllowedMaintenanceWindow{{Weekdays: []time.Weekday{7}, StartHour: 0, EndHour: 6}}}},
+		{"window shorter than maintenance", MaintenanceRestrictions{AllowedWindows: []AllowedMaintenanceWindow{{StartHour: 1, EndHour: 3}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMaintenancePolicy(MaintenanceSchedule{
+				RunwayDesignations: []string{"09L"},
+				Duration:           4 * time.Hour,
+				Frequency:          7 * 24 * time.Hour,
+				Restrictions:       tt.restrictions,
+			})
+			world := newMockEventWorld(start, start.AddDate(1, 0, 0), []string{"09L"})
+			if err := p.Validate(world); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMaintenancePolicy_Restrictions(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(1, 0, 0)
+	christmas := TimeWindow{Start: time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	p := NewMaintenancePolicy(MaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           4 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+		Restrictions: MaintenanceRestrictions{
+			BlackoutPeriods: []TimeWindow{christmas},
+			AllowedWindows:  []AllowedMaintenanceWindow{{Weekdays: []time.Weekday{time.Wednesday}, StartHour: 1, EndHour: 5}},
+		},
+	})
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	starts := 0
+	for _, evt := range world.GetEvents() {
+		if evt.Type() != event.RunwayMaintenanceStartType {
+			continue
+		}
+		starts++
+		ts := evt.Time()
+		if ts.Weekday() != time.Wednesday || ts.Hour() != 1 || ts.Minute() != 0 {
+			t.Errorf("maintenance at %v is outside the Wednesday 01:00-05:00 window", ts)
+		}
+		if !ts.Before(christmas.Start) {
+			t.Errorf("maintenance at %v is in the blackout period", ts)
+		}
+	}
+	// One window a week until the blackout begins in the 50th week
+	if starts != 50 {
+		t.Errorf("expected 50 maintenance windows, got %d", starts)
+	}
+	if ends := world.CountEventsByType(event.RunwayMaintenanceEndType); ends != starts {
+		t.Errorf("expected %d end events, got %d", starts, ends)
+	}
+}
+
+func TestIntelligentMaintenancePolicy_Restrictions(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 1, 0)
+	curfewStart := time.Date(0, 1, 1, 23, 0, 0, 0, time.UTC)
+	curfewEnd := time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC)
+
+	p, err := NewIntelligentMaintenancePolicy(IntelligentMaintenanceSchedule{
+		RunwayDesignations: []string{"09L", "09R"},
+		Duration:           4 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+		CurfewStart:        &curfewStart,
+		CurfewEnd:          &curfewEnd,
+		Restrictions: MaintenanceRestrictions{
+			BlackoutPeriods: []TimeWindow{{Start: simStart, End: simStart.AddDate(0, 0, 10)}},
+			AllowedWindows:  []AllowedMaintenanceWindow{{Weekdays: []time.Weekday{time.Saturday, time.Sunday}, StartHour: 0, EndHour: 24}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewIntelligentMaintenancePolicy: %v", err)
+	}
+	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	if world.CountEventsByType(event.RunwayMaintenanceStartType) == 0 {
+		t.Fatal("expected maintenance to be scheduled")
+	}
+	for _, evt := range world.GetEvents() {
+		ts := evt.Time()
+		if evt.Type() == event.RunwayMaintenanceStartType && (ts.Before(simStart.AddDate(0, 0, 10)) || (ts.Weekday() != time.Saturday && ts.Weekday() != time.Sunday)) {
+			t.Errorf("maintenance at %v (%v) breaks the restrictions", ts, ts.Weekday())
+		}
+	}
+}
@@ -2,9 +2,11 @@ package policy
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -40,14 +42,14 @@ func TestMaintenancePolicy_Name(t *testing.T) {
 
 func TestMaintenancePolicy_GenerateEvents(t *testing.T) {
 	tests := []struct {
-		name                  string
-		runways               []string
-		duration              time.Duration
-		frequency             time.Duration
-		simStart              time.Time
-		simEnd                time.Time
-		expectedStartEvents   int
-		expectedEndEvents     int
+		name                string
+		runways             []string
+		duration            time.Duration
+		frequency           time.Duration
+		simStart            time.Time
+		simEnd              time.Time
+		expectedStartEvents int
+		expectedEndEvents   int
 	}{
 		{
 			name:                "Monthly maintenance for one runway over one year",
@@ -66,7 +68,7 @@ func TestMaintenancePolicy_GenerateEvents(t *testing.T) {
 			frequency:           7 * 24 * time.Hour, // weekly
 			simStart:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 			simEnd:              time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
-			expectedStartEvents: 8,  // 4 weeks * 2 runways
+			expectedStartEvents: 8, // 4 weeks * 2 runways
 			expectedEndEvents:   8,
 		},
 		{
@@ -126,7 +128,67 @@ func TestMaintenancePolicy_GenerateEvents_InvalidRunway(t *testing.T) {
 	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
 
 	err := policy.GenerateEvents(context.Background(), world)
-	if err == nil {
-		t.Error("expected error for invalid runway, got nil")
+	if !errors.Is(err, simerrors.ErrRunwayNotFound) {
+		t.Errorf("expected ErrRunwayNotFound, got %v", err)
+	}
+	var notFound *simerrors.RunwayNotFoundError
+	if !errors.As(err, &notFound) || notFound.RunwayID != "INVALID" {
+		t.Errorf("expected RunwayNotFoundError for INVALID, got %v", err)
+	}
+}
+
+func TestMaintenancePolicy_Jitter(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 1, 0)
+	schedule := MaintenanceSchedule{
+		RunwayDesignations: []string{"09L"},
+		Duration:           2 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+		Jitter:             12 * time.Hour,
+	}
+
+	generate := func(seed int64) []time.Time {
+		p := NewMaintenancePolicy(schedule)
+		p.SetRandomSource(NewRandomSource(seed, 0))
+		world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+		if err := p.GenerateEvents(context.Background(), world); err != nil {
+			t.Fatalf("GenerateEvents failed: %v", err)
+		}
+		starts := []time.Time{}
+		for _, evt := range world.GetEvents() {
+			if evt.Type() == event.RunwayMaintenanceStartType {
+				starts = append(starts, evt.Time())
+			}
+		}
+		return starts
+	}
+
+	first := generate(42)
+	second := generate(42)
+	if len(first) != len(second) {
+		t.Fatalf("Expected same number of events for same seed, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Errorf("Window %d differs for same seed: %v vs %v", i, first[i], second[i])
+		}
+
+		// Jitter only delays each window within [0, Jitter)
+		nominal := simStart.Add(time.Duration(i) * schedule.Frequency)
+		offset := first[i].Sub(nominal)
+		if offset < 0 || offset >= schedule.Jitter {
+			t.Errorf("Window %d offset %v outside [0, %v)", i, offset, schedule.Jitter)
+		}
+	}
+
+	other := generate(7)
+	same := true
+	for i := range first {
+		if i < len(other) && !first[i].Equal(other[i]) {
+			same = false
+		}
+	}
+	if same {
+		t.Error("Expected different seeds to produce different jitter")
 	}
 }
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// NightQuotaConfiguration defines a nightly period in which only a limited number of
+// movements are permitted (e.g., 16 movements between 23:30 and 06:00), optionally with an
+// allowance across all night periods of a calendar year.
+type NightQuotaConfiguration struct {
+	StartTime         time.Time // Start of the night period (only the time of day is used)
+	EndTime           time.Time // End of the night period (only the time of day is used; overnight periods wrap)
+	MovementsPerNight int       // Movements permitted in each night period
+	MovementsPerYear  int       // Movements permitted in night periods per calendar year (0 = no annual limit)
+}
+
+// NightQuotaPolicy permits a limited number of movements at night instead of closing the
+// airport with a curfew. Movements in each night period are charged against the nightly
+// allowance, and against the annual allowance of the calendar year if one is set; once either
+// is used up, no further movements are made until the next night period or calendar year.
+type NightQuotaPolicy struct {
+	config NightQuotaConfiguration
+}
+
+// NewNightQuotaPolicy creates a new night quota policy with validation.
+// Returns an error if the time range is invalid, the nightly allowance is not positive, or the
+// annual allowance is negative.
+func NewNightQuotaPolicy(config NightQuotaConfiguration) (*NightQuotaPolicy, error) {
+	if !config.EndTime.After(config.StartTime) {
+		return nil, ErrInvalidCurfewTime
+	}
+	if config.EndTime.Sub(config.StartTime) > MaxCurfewDuration {
+		return nil, ErrCurfewTooLong
+	}
+	if config.MovementsPerNight <= 0 {
+		return nil, simerrors.Invalidf("night quota must be positive, got %d", config.MovementsPerNight)
+	}
+	if config.MovementsPerYear < 0 {
+		return nil, simerrors.Invalidf("annual night quota cannot be negative, got %d", config.MovementsPerYear)
+	}
+
+	return &NightQuotaPolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *NightQuotaPolicy) Name() string {
+	return "NightQuotaPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *NightQuotaPolicy) Parameters() any {
+	return p.config
+}
+
+// GenerateEvents generates a night quota event at simulation start, and night period start and
+// end events for every day in the simulation period. A night period already in progress when
+// the simulation starts begins at the simulation start, with the full nightly allowance.
+func (p *NightQuotaPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	world.ScheduleEvent(event.NewNightQuotaEvent(float64(p.config.MovementsPerNight), float64(p.config.MovementsPerYear), startTime))
+
+	startHour, startMinute := p.config.StartTime.Hour(), p.config.StartTime.Minute()
+	endHour, endMinute := p.config.EndTime.Hour(), p.config.EndTime.Minute()
+	overnight := endHour < startHour || (endHour == startHour && endMinute <= startMinute)
+
+	// Start one day early so a night period running over the simulation start is included.
+	// Days are laid out in local time so night periods follow daylight saving time changes.
+	currentDate := startTime.In(location(world)).AddDate(0, 0, -1)
+	for currentDate.Before(endTime) {
+		nightStart := time.Date(
+			currentDate.Year(), currentDate.Month(), currentDate.Day(),
+			startHour, startMinute, 0, 0,
+			currentDate.Location(),
+		)
+		nightEnd := time.Date(
+			currentDate.Year(), currentDate.Month(), currentDate.Day(),
+			endHour, endMinute, 0, 0,
+			currentDate.Location(),
+		)
+		if overnight {
+			nightEnd = nightEnd.AddDate(0, 0, 1)
+		}
+		nightStart, nightEnd = nightStart.In(startTime.Location()), nightEnd.In(startTime.Location())
+
+		// Clip the night period to the simulation period
+		if nightStart.Before(startTime) {
+			nightStart = startTime
+		}
+		if nightEnd.After(endTime) {
+			nightEnd = endTime
+		}
+
+		if nightEnd.After(nightStart) {
+			world.ScheduleEvent(event.NewNightQuotaStartEvent(nightStart))
+			world.ScheduleEvent(event.NewNightQuotaEndEvent(nightEnd))
+		}
+
+		currentDate = currentDate.AddDate(0, 0, 1)
+	}
+
+	return nil
+}
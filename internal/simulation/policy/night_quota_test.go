@@ -0,0 +1,68 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewNightQuotaPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC)
+	end := start.Add(6*time.Hour + 30*time.Minute)
+
+	tests := []struct {
+		name    string
+		config  NightQuotaConfiguration
+		wantErr bool
+	}{
+		{"valid", NightQuotaConfiguration{StartTime: start, EndTime: end, MovementsPerNight: 16}, false},
+		{"with annual quota", NightQuotaConfiguration{StartTime: start, EndTime: end, MovementsPerNight: 16, MovementsPerYear: 5800}, false},
+		{"end before start", NightQuotaConfiguration{StartTime: end, EndTime: start, MovementsPerNight: 16}, true},
+		{"zero movements", NightQuotaConfiguration{StartTime: start, EndTime: end}, true},
+		{"negative annual quota", NightQuotaConfiguration{StartTime: start, EndTime: end, MovementsPerNight: 16, MovementsPerYear: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNightQuotaPolicy(tt.config)
+			if tt.wantErr && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNightQuotaPolicy_GenerateEvents(t *testing.T) {
+	p, err := NewNightQuotaPolicy(NightQuotaConfiguration{
+		StartTime:         time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC),
+		EndTime:           time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+		MovementsPerNight: 16,
+	})
+	if err != nil {
+		t.Fatalf("NewNightQuotaPolicy: %v", err)
+	}
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(0, 0, 7), []string{"09"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	if got := world.CountEventsByType(event.NightQuotaType); got != 1 {
+		t.Errorf("Expected 1 night quota event, got %d", got)
+	}
+	// The night in progress at the start, six full nights and the night cut off at the end
+	if got := world.CountEventsByType(event.NightQuotaStartType); got != 8 {
+		t.Errorf("Expected 8 night period starts, got %d", got)
+	}
+	if got := world.CountEventsByType(event.NightQuotaEndType); got != 8 {
+		t.Errorf("Expected 8 night period ends, got %d", got)
+	}
+}
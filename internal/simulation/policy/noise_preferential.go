@@ -0,0 +1,332 @@
+package policy
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// ErrEmptyNoisePreferentialSchedule is returned when a noise-preferential schedule has no bands.
+var ErrEmptyNoisePreferentialSchedule = simerrors.Invalidf("noise-preferential schedule must contain at least one band")
+
+// NoisePreferentialBand is one row of a noise-preferential runway schedule: the runway
+// configurations to use, in order of preference, during a band of the day on some days of
+// the week.
+type NoisePreferentialBand struct {
+	Days        []time.Weekday // Days the band starts on (nil = every day)
+	From        time.Duration  // Local time of day the band starts (e.g., 6h)
+	Until       time.Duration  // Local time of day the band ends (e.g., 23h); before From wraps past midnight
+	Preferences [][]string     // Runway end designations of each configuration, most preferred first (e.g., {{"27L", "27R"}, {"09L", "09R"}})
+}
+
+// appliesOn reports whether the band starts on the given day.
+func (b NoisePreferentialBand) appliesOn(day time.Weekday) bool {
+	return b.Days == nil || slices.Contains(b.Days, day)
+}
+
+// NoisePreferentialPolicy applies a published noise-preferential runway schedule (a noise
+// preferential runway system): during each band the runway manager selects the first of the
+// band's configurations that can operate - its runways available, within their wind limits
+// and compatible - even where another configuration has more capacity, and falls back to the
+// configuration with the most capacity when none can. Outside every band configurations are
+// selected by capacity alone. Where bands overlap, the one listed first applies.
+//
+// It models the capacity cost of preferential runway use explicitly, replacing the flat
+// multiplier the deprecated PreferentialRunway rotation strategy used to apply.
+type NoisePreferentialPolicy struct {
+	bands []NoisePreferentialBand
+}
+
+// NewNoisePreferentialPolicy creates a new noise-preferential runway policy.
+// Returns an error if no bands are given, a band's times are outside the day or equal, or a
+// band has no configurations or an empty one.
+func NewNoisePreferentialPolicy(bands []NoisePreferentialBand) (*NoisePreferentialPolicy, error) {
+	if len(bands) == 0 {
+		return nil, ErrEmptyNoisePreferentialSchedule
+	}
+
+	copied := make([]NoisePreferentialBand, len(bands))
+	for i, band := range bands {
+		if band.From < 0 || band.From >= 24*time.Hour || band.Until < 0 || band.Until > 24*time.Hour {
+			return nil, simerrors.Invalidf("noise-preferential band %d must start and end within the day, got %v to %v", i+1, band.From, band.Until)
+		}
+		if band.From == band.Until {
+			return nil, simerrors.Invalidf("noise-preferential band %d cannot start and end at the same time, got %v", i+1, band.From)
+		}
+		if len(band.Preferences) == 0 {
+			return nil, simerrors.Invalidf("noise-preferential band %d has no runway configurations", i+1)
+		}
+
+		copied[i] = NoisePreferentialBand{Days: slices.Clone(band.Days), From: band.From, Until: band.Until}
+		for _, preference := range band.Preferences {
+			if len(preference) == 0 {
+				return nil, simerrors.Invalidf("noise-preferential band %d has an empty runway configuration", i+1)
+			}
+			copied[i].Preferences = append(copied[i].Preferences, slices.Clone(preference))
+		}
+	}
+
+	return &NoisePreferentialPolicy{bands: copied}, nil
+}
+
+// Name returns the policy name.
+func (p *NoisePreferentialPolicy) Name() string {
+	return "NoisePreferentialPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *NoisePreferentialPolicy) Parameters() any {
+	return p.bands
+}
+
+// Validate checks that every preferred runway end belongs to a runway at the airport.
+func (p *NoisePreferentialPolicy) Validate(world EventWorld) error {
+	var ends []string
+	for _, band := range p.bands {
+		for _, preference := range band.Preferences {
+			for _, end := range preference {
+				if !slices.Contains(ends, end) {
+					ends = append(ends, end)
+				}
+			}
+		}
+	}
+	sort.Strings(ends)
+	return errors.Join(unknownRunwayEnds(world, p.Name(), ends)...)
+}
+
+// SetInitialState sets the preferences of the band in effect at the simulation start, if any.
+func (p *NoisePreferentialPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	startTime := world.GetStartTime()
+	band := p.bandAt(startTime, location(world))
+	if band < 0 {
+		return nil
+	}
+	return world.SetNoisePreferences(p.bands[band].Preferences, startTime)
+}
+
+// GenerateEvents generates a NoisePreferenceEvent at every band boundary in the simulation
+// period at which the band in effect changes, lifting the preferences between bands. Days are
+// laid out in the airport's local time, so bands follow daylight saving time changes.
+func (p *NoisePreferentialPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+	loc := location(world)
+
+	var boundaries []time.Time
+	day := startTime.In(loc)
+	for midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc); midnight.Before(endTime); midnight = midnight.AddDate(0, 0, 1) {
+		for _, band := range p.bands {
+			for _, timeOfDay := range []time.Duration{band.From, band.Until} {
+				t := time.Date(midnight.Year(), midnight.Month(), midnight.Day(),
+					int(timeOfDay/time.Hour), int(timeOfDay%time.Hour/time.Minute), int(timeOfDay%time.Minute/time.Second), 0, loc)
+				if t.After(startTime) && t.Before(endTime) {
+					boundaries = append(boundaries, t)
+				}
+			}
+		}
+	}
+	slices.SortFunc(boundaries, func(a, b time.Time) int { return a.Compare(b) })
+	boundaries = slices.CompactFunc(boundaries, time.Time.Equal)
+
+	current := p.bandAt(startTime, loc)
+	for _, t := range boundaries {
+		band := p.bandAt(t, loc)
+		if band == current {
+			continue
+		}
+		current = band
+
+		var preferences [][]string
+		if band >= 0 {
+			preferences = p.bands[band].Preferences
+		}
+		world.ScheduleEvent(event.NewNoisePreferenceEvent(preferences, t.In(startTime.Location())))
+	}
+
+	return nil
+}
+
+// bandAt returns the index of the first band in effect at t, or -1 if none is. A band that
+// wraps past midnight is in effect until its end on the day after it starts.
+func (p *NoisePreferentialPolicy) bandAt(t time.Time, loc *time.Location) int {
+	local := t.In(loc)
+	timeOfDay := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	yesterday := (local.Weekday() + 6) % 7
+
+	for i, band := range p.bands {
+		wraps := band.Until < band.From
+		if band.appliesOn(local.Weekday()) && timeOfDay >= band.From && (wraps || timeOfDay < band.Until) {
+			return i
+		}
+		if wraps && band.appliesOn(yesterday) && timeOfDay < band.Until {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadNoisePreferentialSchedule imports a published noise-preferential runway schedule from
+// CSV: a table of preferred runway configurations by time band and day type. The header row
+// names the columns days, from and until, followed by one column per preference rank:
+//
+//	days,from,until,first,second,third
+//	Mon-Fri,06:00,23:00,27L/27R,09L/09R
+//	Weekends,07:00,23:00,27R,09L
+//	Daily,23:00,06:00,09L
+//
+// Days are day names or their first three letters, ranges such as Mon-Fri, lists such as
+// Sat/Sun, or Daily, Weekdays or Weekends. Times are local HH:MM, with 24:00 for the end of
+// the day. Each configuration lists the runway ends it operates separated by "/", and empty
+// cells are skipped. Lines starting with # are comments.
+// Returns an error naming the line of the first problem found.
+func ReadNoisePreferentialSchedule(r io.Reader) ([]NoisePreferentialBand, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyNoisePreferentialSchedule
+	}
+	if err != nil {
+		return nil, simerrors.Invalidf("reading noise-preferential schedule: %w", err)
+	}
+	if len(header) < 4 || !strings.EqualFold(header[0], "days") || !strings.EqualFold(header[1], "from") || !strings.EqualFold(header[2], "until") {
+		return nil, simerrors.Invalidf("noise-preferential schedule header must be days,from,until followed by preference columns, got %q", strings.Join(header, ","))
+	}
+
+	var bands []NoisePreferentialBand
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, simerrors.Invalidf("reading noise-preferential schedule: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		band, err := parseNoisePreferentialBand(record)
+		if err != nil {
+			return nil, simerrors.Invalidf("noise-preferential schedule line %d: %w", line, err)
+		}
+		bands = append(bands, band)
+	}
+	if len(bands) == 0 {
+		return nil, ErrEmptyNoisePreferentialSchedule
+	}
+	return bands, nil
+}
+
+// parseNoisePreferentialBand parses a row of a noise-preferential schedule.
+func parseNoisePreferentialBand(record []string) (NoisePreferentialBand, error) {
+	if len(record) < 4 {
+		return NoisePreferentialBand{}, simerrors.Invalidf("expected days, from, until and at least one runway configuration, got %d columns", len(record))
+	}
+
+	var band NoisePreferentialBand
+	var err error
+	if band.Days, err = parseDays(record[0]); err != nil {
+		return NoisePreferentialBand{}, err
+	}
+	if band.From, err = parseTimeOfDay(record[1]); err != nil {
+		return NoisePreferentialBand{}, err
+	}
+	if band.Until, err = parseTimeOfDay(record[2]); err != nil {
+		return NoisePreferentialBand{}, err
+	}
+
+	for _, cell := range record[3:] {
+		var preference []string
+		for _, end := range strings.Split(cell, "/") {
+			if end = strings.TrimSpace(end); end != "" {
+				preference = append(preference, end)
+			}
+		}
+		if preference != nil {
+			band.Preferences = append(band.Preferences, preference)
+		}
+	}
+	if len(band.Preferences) == 0 {
+		return NoisePreferentialBand{}, simerrors.Invalidf("no runway configurations given")
+	}
+	return band, nil
+}
+
+// parseDays parses the days a band applies to (see ReadNoisePreferentialSchedule), returning
+// nil for every day.
+func parseDays(s string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, token := range strings.FieldsFunc(s, func(r rune) bool { return r == '/' || r == ' ' || r == ',' }) {
+		switch strings.ToLower(token) {
+		case "daily", "all":
+			return nil, nil
+		case "weekdays":
+			days = append(days, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+			continue
+		case "weekends":
+			days = append(days, time.Saturday, time.Sunday)
+			continue
+		}
+
+		first, last, isRange := strings.Cut(token, "-")
+		from, err := parseWeekday(first)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			if to, err = parseWeekday(last); err != nil {
+				return nil, err
+			}
+		}
+		for day := from; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == to {
+				break
+			}
+		}
+	}
+	if len(days) == 0 {
+		return nil, simerrors.Invalidf("no days given")
+	}
+
+	slices.Sort(days)
+	days = slices.Compact(days)
+	if len(days) == 7 {
+		return nil, nil
+	}
+	return days, nil
+}
+
+// parseWeekday parses a day name or an abbreviation of at least its first three letters.
+func parseWeekday(s string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if len(s) >= 3 && strings.HasPrefix(strings.ToLower(day.String()), strings.ToLower(s)) {
+			return day, nil
+		}
+	}
+	return 0, simerrors.Invalidf("unknown day %q", s)
+}
+
+// parseTimeOfDay parses a local HH:MM time of day, allowing 24:00 for the end of the day.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	if s == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, simerrors.Invalidf("invalid time of day %q, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+const testNoisePreferentialSchedule = `# Westerly preference by day, easterly departures over the estuary at night
+days,from,until,first,second
+Mon-Fri,06:00,23:00,27L/27R,09L/09R
+Daily,23:00,06:00,09L,
+`
+
+func TestReadNoisePreferentialSchedule(t *testing.T) {
+	bands, err := ReadNoisePreferentialSchedule(strings.NewReader(testNoisePreferentialSchedule))
+	if err != nil {
+		t.Fatalf("ReadNoisePreferentialSchedule failed: %v", err)
+	}
+	want := []NoisePreferentialBand{
+		{Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, From: 6 * time.Hour, Until: 23 * time.Hour,
+			Preferences: [][]string{{"27L", "27R"}, {"09L", "09R"}}},
+		{From: 23 * time.Hour, Until: 6 * time.Hour, Preferences: [][]string{{"09L"}}},
+	}
+	if !reflect.DeepEqual(bands, want) {
+		t.Errorf("Expected %+v, got %+v", want, bands)
+	}
+
+	days, err := parseDays("Fri-Mon")
+	if err != nil || !reflect.DeepEqual(days, []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday}) {
+		t.Errorf("Expected Fri-Mon to wrap through the weekend, got %v (%v)", days, err)
+	}
+	if days, err := parseDays("Weekends/Wednesday"); err != nil || !reflect.DeepEqual(days, []time.Weekday{time.Sunday, time.Wednesday, time.Saturday}) {
+		t.Errorf("Expected weekends and Wednesday, got %v (%v)", days, err)
+	}
+
+	invalid := map[string]string{
+		"no bands":         "days,from,until,first\n",
+		"bad header":       "day,start,end,first\nDaily,06:00,23:00,27L\n",
+		"unknown day":      "days,from,until,first\nMo-Fr,06:00,23:00,27L\n",
+		"bad time":         "days,from,until,first\nDaily,6am,23:00,27L\n",
+		"no configuration": "days,from,until,first\nDaily,06:00,23:00,\n",
+	}
+	for name, schedule := range invalid {
+		if _, err := ReadNoisePreferentialSchedule(strings.NewReader(schedule)); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected ErrInvalidConfiguration, got %v", name, err)
+		}
+	}
+	_, err = ReadNoisePreferentialSchedule(strings.NewReader("days,from,until,first\nDaily,06:00,23:00,27L\nDaily,23:00,25:00,09L\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected an error naming line 3, got %v", err)
+	}
+}
+
+func TestNewNoisePreferentialPolicy_Invalid(t *testing.T) {
+	tests := map[string][]NoisePreferentialBand{
+		"no bands":            nil,
+		"outside the day":     {{From: 25 * time.Hour, Until: 6 * time.Hour, Preferences: [][]string{{"09L"}}}},
+		"empty band":          {{From: 6 * time.Hour, Until: 6 * time.Hour, Preferences: [][]string{{"09L"}}}},
+		"no configurations":   {{From: 6 * time.Hour, Until: 23 * time.Hour}},
+		"empty configuration": {{From: 6 * time.Hour, Until: 23 * time.Hour, Preferences: [][]string{{}}}},
+	}
+	for name, bands := range tests {
+		if _, err := NewNoisePreferentialPolicy(bands); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected ErrInvalidConfiguration, got %v", name, err)
+		}
+	}
+}
+
+func TestNoisePreferentialPolicy_GenerateEvents(t *testing.T) {
+	bands, err := ReadNoisePreferentialSchedule(strings.NewReader(testNoisePreferentialSchedule))
+	if err != nil {
+		t.Fatalf("ReadNoisePreferentialSchedule failed: %v", err)
+	}
+	p, err := NewNoisePreferentialPolicy(bands)
+	if err != nil {
+		t.Fatalf("NewNoisePreferentialPolicy failed: %v", err)
+	}
+
+	// Friday to Monday: the night band runs into Friday, then no band applies on weekend days
+	start := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	world := newMockInitialStateWorld(start, start.AddDate(0, 0, 3))
+	if err := p.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if !reflect.DeepEqual(world.preferences, [][]string{{"09L"}}) {
+		t.Errorf("Expected the night preference at the start, got %v", world.preferences)
+	}
+
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+	day, night := bands[0].Preferences, bands[1].Preferences
+	want := []struct {
+		at          time.Duration
+		preferences [][]string
+	}{
+		{6 * time.Hour, day}, {23 * time.Hour, night},
+		{30 * time.Hour, nil}, {47 * time.Hour, night},
+		{54 * time.Hour, nil}, {71 * time.Hour, night},
+	}
+	events := world.GetEvents()
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		evt, ok := events[i].(*event.NoisePreferenceEvent)
+		if !ok || !evt.Time().Equal(start.Add(w.at)) || !reflect.DeepEqual(evt.Preferences(), w.preferences) {
+			t.Errorf("Event %d: expected %v at %v, got %+v", i, w.preferences, start.Add(w.at), events[i])
+		}
+	}
+
+	if err := p.Validate(newMockEventWorld(start, start.AddDate(0, 0, 3), []string{"09L"})); err == nil {
+		t.Error("Expected an error for runway ends 09R and 27L of a missing runway")
+	}
+}
+
+func TestBuiltin_NoisePreferential(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nprs.csv")
+	if err := os.WriteFile(path, []byte(testNoisePreferentialSchedule), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewRegistered("noise-preferential", JSONDecoder([]byte(`{"schedule": "`+path+`"}`)))
+	if err != nil {
+		t.Fatalf("NewRegistered failed: %v", err)
+	}
+	if p.Name() != "NoisePreferentialPolicy" {
+		t.Errorf("Expected NoisePreferentialPolicy, got %s", p.Name())
+	}
+}
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+var (
+	// ErrEmptyNoiseWeights is returned when a noise quota has no runway end weights.
+	ErrEmptyNoiseWeights = simerrors.Invalidf("noise quota requires at least one runway end weight")
+)
+
+// NoiseQuotaConfiguration defines an annual noise budget and the noise points charged per
+// movement on each runway end (e.g., "27R" for departures over a residential area).
+// Weights are typically quota count (QC) points per movement; a plain movement limit on
+// noise-critical runway ends is expressed by giving each of them a weight of 1.
+// Runway ends without a weight are not limited by the quota.
+type NoiseQuotaConfiguration struct {
+	RunwayEndWeights map[string]float64 // Runway end designation -> noise points per movement
+	QuotaPoints      float64            // Noise points available per calendar year
+}
+
+// NoiseQuotaPolicy caps movements on noise-critical runway ends once the annual noise
+// quota is exhausted. Consumption resets at the start of each calendar year.
+type NoiseQuotaPolicy struct {
+	config NoiseQuotaConfiguration
+}
+
+// NewNoiseQuotaPolicy creates a new noise quota policy.
+// Returns an error if no weights are given, any weight is not positive, or the quota is negative.
+func NewNoiseQuotaPolicy(config NoiseQuotaConfiguration) (*NoiseQuotaPolicy, error) {
+	if len(config.RunwayEndWeights) == 0 {
+		return nil, ErrEmptyNoiseWeights
+	}
+	for end, weight := range config.RunwayEndWeights {
+		if weight <= 0 {
+			return nil, simerrors.Invalidf("noise weight for runway end %s must be positive, got %f", end, weight)
+		}
+	}
+	if config.QuotaPoints < 0 {
+		return nil, simerrors.Invalidf("noise quota cannot be negative, got %f", config.QuotaPoints)
+	}
+
+	weights := make(map[string]float64, len(config.RunwayEndWeights))
+	for end, weight := range config.RunwayEndWeights {
+		weights[end] = weight
+	}
+
+	return &NoiseQuotaPolicy{
+		config: NoiseQuotaConfiguration{
+			RunwayEndWeights: weights,
+			QuotaPoints:      config.QuotaPoints,
+		},
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *NoiseQuotaPolicy) Name() string {
+	return "NoiseQuotaPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *NoiseQuotaPolicy) Parameters() any {
+	return p.config
+}
+
+// Validate checks that every weighted runway end belongs to a runway at the airport.
+func (p *NoiseQuotaPolicy) Validate(world EventWorld) error {
+	ends := make([]string, 0, len(p.config.RunwayEndWeights))
+	for end := range p.config.RunwayEndWeights {
+		ends = append(ends, end)
+	}
+	sort.Strings(ends)
+	return errors.Join(unknownRunwayEnds(world, p.Name(), ends)...)
+}
+
+// GenerateEvents generates a noise quota event at simulation start.
+// The engine then charges each window's movements against the quota and caps
+// weighted runway ends once it is used up.
+func (p *NoiseQuotaPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	world.ScheduleEvent(event.NewNoiseQuotaEvent(p.config.RunwayEndWeights, p.config.QuotaPoints, world.GetStartTime()))
+	return nil
+}
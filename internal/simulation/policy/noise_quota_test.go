@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewNoiseQuotaPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      NoiseQuotaConfiguration
+		expectError bool
+	}{
+		{"valid quota", NoiseQuotaConfiguration{RunwayEndWeights: map[string]float64{"27R": 2}, QuotaPoints: 1000}, false},
+		{"zero quota closes weighted ends", NoiseQuotaConfiguration{RunwayEndWeights: map[string]float64{"27R": 1}, QuotaPoints: 0}, false},
+		{"no weights", NoiseQuotaConfiguration{QuotaPoints: 1000}, true},
+		{"zero weight", NoiseQuotaConfiguration{RunwayEndWeights: map[string]float64{"27R": 0}, QuotaPoints: 1000}, true},
+		{"negative quota", NoiseQuotaConfiguration{RunwayEndWeights: map[string]float64{"27R": 1}, QuotaPoints: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNoiseQuotaPolicy(tt.config)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	if _, err := NewNoiseQuotaPolicy(NoiseQuotaConfiguration{}); !errors.Is(err, ErrEmptyNoiseWeights) {
+		t.Errorf("Expected ErrEmptyNoiseWeights, got %v", err)
+	}
+}
+
+func TestNoiseQuotaPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	weights := map[string]float64{"27R": 2}
+	policy, err := NewNoiseQuotaPolicy(NoiseQuotaConfiguration{RunwayEndWeights: weights, QuotaPoints: 500})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	// Mutating the caller's map must not affect the policy
+	weights["27R"] = 10
+
+	world := newMockEventWorld(simStart, simStart.AddDate(0, 0, 1), []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if count := world.CountEventsByType(event.NoiseQuotaType); count != 1 {
+		t.Fatalf("Expected 1 noise quota event, got %d", count)
+	}
+	evt := world.GetEvents()[0].(*event.NoiseQuotaEvent)
+	if evt.QuotaPoints() != 500 {
+		t.Errorf("Expected quota 500, got %f", evt.QuotaPoints())
+	}
+	if evt.Weights()["27R"] != 2 {
+		t.Errorf("Expected weight 2 for 27R, got %f", evt.Weights()["27R"])
+	}
+	if !evt.Time().Equal(simStart) {
+		t.Errorf("Expected event at %v, got %v", simStart, evt.Time())
+	}
+}
@@ -0,0 +1,10 @@
+package policy
+
+// DescribedPolicy is implemented by policies that can describe their configuration, so that
+// the provenance of results covers it rather than just the policy name (e.g., curfews from
+// 23:00 and from 20:00 hash differently). Parameters returns a value encoding/json can
+// marshal; it must be the same for policies configured the same way. Every built-in policy
+// implements it; results of runs with policies that don't have a partial provenance.
+type DescribedPolicy interface {
+	Parameters() any
+}
@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// MaintenanceWindow is a period in which a runway is closed for maintenance.
+type MaintenanceWindow struct {
+	RunwayDesignation string    // Runway closed
+	Start             time.Time // When the maintenance starts
+	End               time.Time // When the runway reopens
+}
+
+// PlannedMaintenancePolicy closes runways for an explicit list of maintenance windows, such
+// as a schedule produced by a maintenance optimizer or agreed with contractors.
+type PlannedMaintenancePolicy struct {
+	windows []MaintenanceWindow
+}
+
+// NewPlannedMaintenancePolicy creates a new planned maintenance policy. Overlapping or
+// adjacent windows of the same runway are merged into one.
+// Returns an error if there are no windows, or a window names no runway or does not end
+// after it starts.
+func NewPlannedMaintenancePolicy(windows []MaintenanceWindow) (*PlannedMaintenancePolicy, error) {
+	if len(windows) == 0 {
+		return nil, simerrors.Invalidf("planned maintenance needs at least one window")
+	}
+	for _, w := range windows {
+		if w.RunwayDesignation == "" {
+			return nil, simerrors.Invalidf("planned maintenance window must name a runway")
+		}
+		if !w.End.After(w.Start) {
+			return nil, simerrors.Invalidf("planned maintenance of %s must end after it starts, got %v to %v", w.RunwayDesignation, w.Start, w.End)
+		}
+	}
+
+	sorted := slices.Clone(windows)
+	slices.SortFunc(sorted, func(a, b MaintenanceWindow) int {
+		return cmp.Or(cmp.Compare(a.RunwayDesignation, b.RunwayDesignation), a.Start.Compare(b.Start))
+	})
+	merged := sorted[:1]
+	for _, w := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if w.RunwayDesignation == last.RunwayDesignation && !w.Start.After(last.End) {
+			if w.End.After(last.End) {
+				last.End = w.End
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+
+	return &PlannedMaintenancePolicy{windows: merged}, nil
+}
+
+// Name returns the policy name.
+func (p *PlannedMaintenancePolicy) Name() string {
+	return "PlannedMaintenancePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *PlannedMaintenancePolicy) Parameters() any {
+	return p.windows
+}
+
+// GetWindows returns the maintenance windows, merged and sorted by runway and start.
+func (p *PlannedMaintenancePolicy) GetWindows() []MaintenanceWindow {
+	return slices.Clone(p.windows)
+}
+
+// Validate checks that every runway exists.
+func (p *PlannedMaintenancePolicy) Validate(world EventWorld) error {
+	runways := make([]string, len(p.windows))
+	for i, w := range p.windows {
+		runways[i] = w.RunwayDesignation
+	}
+	return errors.Join(unknownRunways(world, p.Name(), slices.Compact(runways))...)
+}
+
+// SetInitialState closes the runways whose maintenance is under way when the simulation starts.
+func (p *PlannedMaintenancePolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	startTime := world.GetStartTime()
+
+	for _, w := range p.windows {
+		if w.Start.After(startTime) || !w.End.After(startTime) {
+			continue
+		}
+		if err := event.NewRunwayMaintenanceStartEvent(w.RunwayDesignation, startTime).Apply(ctx, world); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateEvents schedules the start and end of each maintenance window that falls within
+// the simulation period. Windows under way at the start are closed by SetInitialState.
+func (p *PlannedMaintenancePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	for _, w := range p.windows {
+		if !w.End.After(startTime) || !w.Start.Before(endTime) {
+			continue
+		}
+		if w.Start.After(startTime) {
+			world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent(w.RunwayDesignation, w.Start))
+		}
+		if w.End.Before(endTime) {
+			world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent(w.RunwayDesignation, w.End))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewPlannedMaintenancePolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	p, err := NewPlannedMaintenancePolicy([]MaintenanceWindow{
+		{RunwayDesignation: "27L", Start: hour(4), End: hour(6)},
+		{RunwayDesignation: "09R", Start: hour(1), End: hour(3)},
+		{RunwayDesignation: "09R", Start: hour(3), End: hour(5)}, // Adjacent
+		{RunwayDesignation: "09R", Start: hour(2), End: hour(4)}, // Overlapping
+		{RunwayDesignation: "27L", Start: hour(8), End: hour(9)},
+	})
+	if err != nil {
+		t.Fatalf("NewPlannedMaintenancePolicy failed: %v", err)
+	}
+	want := []MaintenanceWindow{
+		{RunwayDesignation: "09R", Start: hour(1), End: hour(5)},
+		{RunwayDesignation: "27L", Start: hour(4), End: hour(6)},
+		{RunwayDesignation: "27L", Start: hour(8), End: hour(9)},
+	}
+	got := p.GetWindows()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d windows, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("window %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	for name, windows := range map[string][]MaintenanceWindow{
+		"no windows":    nil,
+		"no runway":     {{Start: hour(1), End: hour(2)}},
+		"ends at start": {{RunwayDesignation: "09R", Start: hour(1), End: hour(1)}},
+	} {
+		if _, err := NewPlannedMaintenancePolicy(windows); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected invalid configuration error, got %v", name, err)
+		}
+	}
+}
+
+func TestPlannedMaintenancePolicy_GenerateEvents(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	p, err := NewPlannedMaintenancePolicy([]MaintenanceWindow{
+		{RunwayDesignation: "09L", Start: start.Add(-2 * time.Hour), End: start.Add(time.Hour)},      // Under way at the start
+		{RunwayDesignation: "09L", Start: start.Add(10 * time.Hour), End: start.Add(12 * time.Hour)}, // Within the period
+		{RunwayDesignation: "09R", Start: end.Add(-time.Hour), End: end.Add(time.Hour)},              // Still under way at the end
+		{RunwayDesignation: "09R", Start: end.Add(time.Hour), End: end.Add(2 * time.Hour)},           // After the period
+	})
+	if err != nil {
+		t.Fatalf("NewPlannedMaintenancePolicy failed: %v", err)
+	}
+	world := newMockInitialStateWorld(start, end)
+	world.runwayIDs = []string{"09L", "09R"}
+	if err := p.Validate(world); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := p.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// The window under way at the start closes 09L without an event
+	if !slices.Equal(world.closedRunways, []string{"09L"}) {
+		t.Errorf("expected 09L closed at the start, got %v", world.closedRunways)
+	}
+	if evts := world.eventsAt(start); len(evts) != 0 {
+		t.Errorf("expected no events at the start, got %d", len(evts))
+	}
+	if starts := world.CountEventsByType(event.RunwayMaintenanceStartType); starts != 2 {
+		t.Errorf("expected 2 maintenance start events, got %d", starts)
+	}
+	if ends := world.CountEventsByType(event.RunwayMaintenanceEndType); ends != 2 {
+		t.Errorf("expected 2 maintenance end events, got %d", ends)
+	}
+	for _, evt := range world.GetEvents() {
+		if evt.Time().Before(start) || !evt.Time().Before(end) {
+			t.Errorf("event at %v is outside the simulation period", evt.Time())
+		}
+	}
+
+	unknown, _ := NewPlannedMaintenancePolicy([]MaintenanceWindow{{RunwayDesignation: "27", Start: start, End: end}})
+	if err := unknown.Validate(world); !errors.Is(err, simerrors.ErrRunwayNotFound) {
+		t.Errorf("expected ErrRunwayNotFound, got %v", err)
+	}
+}
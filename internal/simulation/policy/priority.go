@@ -0,0 +1,10 @@
+package policy
+
+// PrioritizedPolicy is implemented by policies whose events should be applied before those of
+// other policies at the same time. Policies without a priority have priority 0. Among events
+// at the same time and of the same kind, those of higher priority policies are applied first,
+// then those of policies added to the simulation earlier; each policy's own events stay in
+// the order it scheduled them.
+type PrioritizedPolicy interface {
+	Priority() int
+}
@@ -0,0 +1,21 @@
+package policy
+
+import "math/rand/v2"
+
+// StochasticPolicy is implemented by policies that draw random numbers when generating events
+// (e.g., wind sampling, maintenance jitter, demand noise).
+//
+// The simulation hands each stochastic policy its own random source derived from the
+// simulation seed before event generation begins. Policies generate events concurrently,
+// so sources are never shared between policies; this keeps runs reproducible regardless
+// of goroutine scheduling.
+type StochasticPolicy interface {
+	SetRandomSource(rng *rand.Rand)
+}
+
+// NewRandomSource creates a deterministic random source for the given seed and stream.
+// Different streams with the same seed produce independent sequences, allowing each
+// policy in a simulation to receive its own reproducible source.
+func NewRandomSource(seed int64, stream uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), stream))
+}
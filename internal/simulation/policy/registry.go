@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// Policy is a runtime policy that generates events for the simulation. It matches the
+// simulation package's Policy interface, so registered policies can be added to any simulation.
+type Policy interface {
+	Name() string
+	GenerateEvents(ctx context.Context, world EventWorld) error
+}
+
+// Decoder decodes a policy's parameters into v, typically a pointer to a parameter struct.
+// It lets factories stay independent of the configuration format: a YAML loader can pass a
+// node's Decode method, and JSONDecoder adapts raw JSON.
+type Decoder func(v any) error
+
+// Factory creates a policy from its configured parameters.
+type Factory func(decode Decoder) (Policy, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a policy factory available by name, so scenarios in configuration files and
+// on the command line can refer to it. It is intended to be called from the init function of
+// the package providing the policy. Register panics if the name is empty, the factory is nil,
+// or the name is already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("policy: Register called with an empty name")
+	}
+	if factory == nil {
+		panic("policy: Register factory for " + name + " is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("policy: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// Registered returns the names of all registered policies in sorted order.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewRegistered creates the policy registered under name from its parameters.
+// Returns an error if no policy is registered under name or the factory rejects the parameters.
+func NewRegistered(name string, decode Decoder) (Policy, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, simerrors.Invalidf("unknown policy %q (registered: %v)", name, Registered())
+	}
+	p, err := factory(decode)
+	if err != nil {
+		return nil, fmt.Errorf("creating policy %q: %w", name, err)
+	}
+	return p, nil
+}
+
+// JSONDecoder returns a Decoder for parameters given as JSON. Unknown fields are rejected so
+// misspelled parameters are reported rather than silently ignored. Empty input decodes as no
+// parameters.
+func JSONDecoder(data []byte) Decoder {
+	return func(v any) error {
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return simerrors.Invalidf("decoding policy parameters: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// registryTestParams are the parameters of the policy registered by these tests.
+type registryTestParams struct {
+	Start string `json:"start"`
+	Hours int    `json:"hours"`
+}
+
+func init() {
+	Register("test-curfew", func(decode Decoder) (Policy, error) {
+		var params registryTestParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		start, err := time.Parse(time.RFC3339, params.Start)
+		if err != nil {
+			return nil, simerrors.Invalidf("start: %w", err)
+		}
+		return NewCurfewPolicy(start, start.Add(time.Duration(params.Hours)*time.Hour))
+	})
+}
+
+func TestRegistry_NewRegistered(t *testing.T) {
+	if !slices.Contains(Registered(), "test-curfew") {
+		t.Fatalf("Expected test-curfew to be registered, got %v", Registered())
+	}
+
+	p, err := NewRegistered("test-curfew", JSONDecoder([]byte(`{"start": "2024-01-01T23:00:00Z", "hours": 7}`)))
+	if err != nil {
+		t.Fatalf("NewRegistered failed: %v", err)
+	}
+	if p.Name() != "CurfewPolicy" {
+		t.Errorf("Expected CurfewPolicy, got %s", p.Name())
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(start, start.AddDate(0, 0, 2), []string{"09L"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+	if len(world.events) == 0 {
+		t.Error("Expected the registered policy to generate events")
+	}
+}
+
+func TestRegistry_NewRegisteredErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		params string
+	}{
+		{"unknown policy", "no-such-policy", ``},
+		{"unknown parameter", "test-curfew", `{"start": "2024-01-01T23:00:00Z", "hours": 7, "minutes": 5}`},
+		{"malformed JSON", "test-curfew", `{"start": `},
+		{"rejected by factory", "test-curfew", `{"start": "2024-01-01T23:00:00Z", "hours": 0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistered(tt.policy, JSONDecoder([]byte(tt.params)))
+			if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterPanics(t *testing.T) {
+	factory := func(Decoder) (Policy, error) { return nil, nil }
+	tests := []struct {
+		name    string
+		policy  string
+		factory Factory
+	}{
+		{"empty name", "", factory},
+		{"nil factory", "test-nil", nil},
+		{"duplicate", "test-curfew", factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected Register to panic")
+				}
+			}()
+			Register(tt.policy, tt.factory)
+		})
+	}
+}
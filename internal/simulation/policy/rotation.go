@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
 )
 
 // RotationStrategy defines how runways are rotated to minimize noise impact.
@@ -18,7 +19,12 @@ const (
 	// TimeBasedRotation rotates runway usage at fixed time intervals
 	TimeBasedRotation
 
-	// PreferentialRunway designates specific runways for noise abatement
+	// PreferentialRunway designates specific runways for noise abatement. By default it applies
+	// no airport-wide penalty; only runway efficiencies configured with WithRunwayEfficiency
+	// or a custom multiplier reduce capacity.
+	//
+	// Deprecated: Use NoisePreferentialPolicy, which selects the preferred runway
+	// configurations themselves so their capacity cost follows from the runways in use.
 	PreferentialRunway
 
 	// NoiseOptimizedRotation rotates to minimize noise impact on communities
@@ -45,14 +51,15 @@ func (rs RotationStrategy) String() string {
 // This allows rotation to be active only during specific hours or days (e.g., weekends).
 // If nil, rotation applies for the entire simulation period.
 type RotationSchedule struct {
-	StartHour  int              // Hour of day when rotation starts (0-23)
-	EndHour    int              // Hour of day when rotation ends (0-23)
-	DaysOfWeek []time.Weekday   // Days when rotation applies (nil = all days)
+	StartHour  int            // Hour of day when rotation starts (0-23)
+	EndHour    int            // Hour of day when rotation ends (0-23)
+	DaysOfWeek []time.Weekday // Days when rotation applies (nil = all days)
 }
 
 // RotationPolicyConfiguration holds configuration for runway rotation policies.
 type RotationPolicyConfiguration struct {
-	efficiencyMap map[RotationStrategy]float32
+	efficiencyMap       map[RotationStrategy]float32
+	runwayEfficiencyMap map[RotationStrategy]map[string]float32 // Strategy -> runway end designation -> multiplier
 }
 
 // NewDefaultRotationPolicyConfiguration creates a new default rotation policy configuration
@@ -61,7 +68,7 @@ func NewDefaultRotationPolicyConfiguration() *RotationPolicyConfiguration {
 		efficiencyMap: map[RotationStrategy]float32{
 			NoRotation:             1.0,
 			TimeBasedRotation:      0.95,
-			PreferentialRunway:     0.90,
+			PreferentialRunway:     1.0, // Modelled by NoisePreferentialPolicy instead
 			NoiseOptimizedRotation: 0.80,
 		},
 	}
@@ -74,6 +81,18 @@ func NewRotationPolicyConfiguration(efficiencyMap map[RotationStrategy]float32)
 	}
 }
 
+// WithRunwayEfficiency makes the strategy penalize only the given runway ends, each by its own
+// multiplier, instead of applying its airport-wide multiplier. Preferential runway schemes
+// typically only cost capacity on the runways or directions they steer traffic away from, e.g.
+// {"27L": 0.85, "09R": 0.85}; runway ends not listed are not penalized.
+func (c *RotationPolicyConfiguration) WithRunwayEfficiency(strategy RotationStrategy, multipliers map[string]float32) *RotationPolicyConfiguration {
+	if c.runwayEfficiencyMap == nil {
+		c.runwayEfficiencyMap = make(map[RotationStrategy]map[string]float32)
+	}
+	c.runwayEfficiencyMap[strategy] = multipliers
+	return c
+}
+
 // RunwayRotationPolicy implements runway rotation strategies to distribute
 // aircraft movements across different runways over time.
 type RunwayRotationPolicy struct {
@@ -114,17 +133,86 @@ func (p *RunwayRotationPolicy) Name() string {
 	return fmt.Sprintf("RunwayRotationPolicy(%s)", p.strategy.String())
 }
 
+// Parameters returns the policy configuration: the strategy with its multipliers and the
+// schedule.
+func (p *RunwayRotationPolicy) Parameters() any {
+	return struct {
+		Strategy          RotationStrategy   `json:"strategy"`
+		Multiplier        float32            `json:"multiplier"`
+		RunwayMultipliers map[string]float32 `json:"runwayMultipliers,omitempty"`
+		Schedule          *RotationSchedule  `json:"schedule"`
+	}{p.strategy, p.config.efficiencyMap[p.strategy], p.config.runwayEfficiencyMap[p.strategy], p.schedule}
+}
+
+// SetInitialState sets the rotation multiplier in effect at the simulation start (see
+// InitialStatePolicy): the strategy's multiplier when there is no schedule, or when a
+// scheduled rotation window is already running at the start.
+func (p *RunwayRotationPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	rotationStart, _, err := p.rotationEvents()
+	if err != nil {
+		return err
+	}
+	startTime := world.GetStartTime()
+
+	if p.schedule == nil {
+		return rotationStart(startTime).Apply(ctx, world)
+	}
+
+	// Windows from the day before may run past midnight into the start
+	for _, rotation := range p.dailyWindows(startTime.AddDate(0, 0, -1), startTime) {
+		if !rotation.Start.After(startTime) && rotation.End.After(startTime) {
+			return rotationStart(startTime).Apply(ctx, world)
+		}
+	}
+	return nil
+}
+
 // GenerateEvents generates rotation change events based on the policy configuration.
 // Different strategies affect capacity by applying efficiency multipliers.
 // Rotation strategies introduce overhead and constraints that reduce theoretical maximum capacity.
 //
-// If no schedule is provided, rotation is active for the entire simulation period.
-// If a schedule is provided, rotation change events are generated to enable/disable
-// the rotation multiplier during specified time windows.
+// If no schedule is provided, rotation is active for the entire simulation period and set by
+// SetInitialState, so no events are generated. If a schedule is provided, rotation change
+// events are generated to enable/disable the rotation multiplier during specified time
+// windows; a window already running at the start is set by SetInitialState.
+//
+// If the configuration has runway efficiencies for the strategy (see WithRunwayEfficiency),
+// the events set those per-runway multipliers instead of the airport-wide one.
 func (p *RunwayRotationPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	startTime := world.GetStartTime()
 	endTime := world.GetEndTime()
 
+	rotationStart, rotationEnd, err := p.rotationEvents()
+	if err != nil {
+		return err
+	}
+
+	// If no schedule, rotation is always active from the initial state
+	if p.schedule == nil {
+		return nil
+	}
+
+	// Generate time-bounded rotation events for each day the schedule applies, ending any
+	// window from the day before that runs into the start
+	for _, rotation := range p.dailyWindows(startTime.AddDate(0, 0, -1), endTime) {
+		// Ensure times are within simulation bounds
+		if rotation.Start.After(startTime) && rotation.Start.Before(endTime) {
+			world.ScheduleEvent(rotationStart(rotation.Start))
+		}
+
+		if rotation.End.After(startTime) && rotation.End.Before(endTime) {
+			// Return to 1.0 (no rotation penalty) when rotation window ends
+			world.ScheduleEvent(rotationEnd(rotation.End))
+		}
+	}
+
+	return nil
+}
+
+// rotationEvents returns constructors of the events starting and ending the rotation: the
+// strategy's airport-wide multiplier, or its runway efficiencies when the configuration has
+// them (see WithRunwayEfficiency). Returns an error if the strategy is unknown.
+func (p *RunwayRotationPolicy) rotationEvents() (start, end func(time.Time) event.Event, err error) {
 	// Get efficiency multiplier based on rotation strategy
 	var efficiencyMultiplier float32
 	switch p.strategy {
@@ -143,8 +231,9 @@ func (p *RunwayRotationPolicy) GenerateEvents(ctx context.Context, world EventWo
 	case PreferentialRunway:
 		// Preferential runway systems designate specific runways for noise abatement,
 		// which may not always align with optimal wind conditions or traffic flow.
-		// This introduces moderate efficiency penalties.
-		// Efficiency: 90% (10% capacity reduction)
+		// NoisePreferentialPolicy selects those runways directly, so the default applies
+		// no flat penalty on top of it.
+		// Efficiency: 100% by default (deprecated strategy)
 		efficiencyMultiplier = p.config.efficiencyMap[PreferentialRunway]
 
 	case NoiseOptimizedRotation:
@@ -155,50 +244,32 @@ func (p *RunwayRotationPolicy) GenerateEvents(ctx context.Context, world EventWo
 		efficiencyMultiplier = p.config.efficiencyMap[NoiseOptimizedRotation]
 
 	default:
-		return fmt.Errorf("unknown rotation strategy: %v", p.strategy)
+		return nil, nil, fmt.Errorf("unknown rotation strategy: %v", p.strategy)
 	}
 
-	// If no schedule, rotation is always active
-	if p.schedule == nil {
-		// Schedule a rotation change event at the start of the simulation
-		// This sets the efficiency multiplier for the entire simulation period
-		world.ScheduleEvent(event.NewRotationChangeEvent(efficiencyMultiplier, startTime))
-		return nil
+	// Penalize only the configured runway ends when the strategy has runway efficiencies
+	start = func(t time.Time) event.Event { return event.NewRotationChangeEvent(efficiencyMultiplier, t) }
+	end = func(t time.Time) event.Event { return event.NewRotationChangeEvent(1.0, t) }
+	if runwayMultipliers, ok := p.config.runwayEfficiencyMap[p.strategy]; ok {
+		start = func(t time.Time) event.Event { return event.NewRunwayRotationChangeEvent(runwayMultipliers, t) }
+		end = func(t time.Time) event.Event { return event.NewRunwayRotationChangeEvent(nil, t) }
 	}
+	return start, end, nil
+}
 
-	// Generate time-bounded rotation events
-	currentTime := startTime
-	for currentTime.Before(endTime) {
-		// Check if current day matches schedule
-		if p.shouldApplyOnDay(currentTime.Weekday()) {
-			// Calculate rotation start time for this day
-			rotationStart := time.Date(
-				currentTime.Year(), currentTime.Month(), currentTime.Day(),
-				p.schedule.StartHour, 0, 0, 0, currentTime.Location(),
-			)
-
-			// Calculate rotation end time for this day
-			rotationEnd := time.Date(
-				currentTime.Year(), currentTime.Month(), currentTime.Day(),
-				p.schedule.EndHour, 0, 0, 0, currentTime.Location(),
-			)
-
-			// Ensure times are within simulation bounds
-			if rotationStart.After(startTime) && rotationStart.Before(endTime) {
-				world.ScheduleEvent(event.NewRotationChangeEvent(efficiencyMultiplier, rotationStart))
-			}
-
-			if rotationEnd.After(startTime) && rotationEnd.Before(endTime) {
-				// Return to 1.0 (no rotation penalty) when rotation window ends
-				world.ScheduleEvent(event.NewRotationChangeEvent(1.0, rotationEnd))
-			}
-		}
+// dailyWindows returns the scheduled rotation windows on the days the schedule applies, from
+// the date of startTime until endTime (see timewin.Daily).
+func (p *RunwayRotationPolicy) dailyWindows(startTime, endTime time.Time) []timewin.Window {
+	from := time.Date(0, 1, 1, p.schedule.StartHour, 0, 0, 0, time.UTC)
+	to := time.Date(0, 1, 1, p.schedule.EndHour, 0, 0, 0, time.UTC)
 
-		// Move to next day
-		currentTime = currentTime.AddDate(0, 0, 1)
+	var windows []timewin.Window
+	for _, rotation := range timewin.Daily(startTime, endTime, from, to, startTime.Location()) {
+		if p.shouldApplyOnDay(rotation.Start.Weekday()) {
+			windows = append(windows, rotation)
+		}
 	}
-
-	return nil
+	return windows
 }
 
 // shouldApplyOnDay checks if rotation should apply on the given weekday.
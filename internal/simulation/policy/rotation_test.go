@@ -2,6 +2,7 @@ package policy
 
 import (
 	"context"
+	"maps"
 	"testing"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
@@ -40,7 +41,7 @@ func TestRunwayRotationPolicy_Name(t *testing.T) {
 	}
 }
 
-func TestRunwayRotationPolicy_GenerateEvents(t *testing.T) {
+func TestRunwayRotationPolicy_SetInitialState(t *testing.T) {
 	tests := []struct {
 		name               string
 		strategy           RotationStrategy
@@ -48,7 +49,7 @@ func TestRunwayRotationPolicy_GenerateEvents(t *testing.T) {
 	}{
 		{"NoRotation", NoRotation, 1.0},
 		{"TimeBasedRotation", TimeBasedRotation, 0.95},
-		{"PreferentialRunway", PreferentialRunway, 0.90},
+		{"PreferentialRunway", PreferentialRunway, 1.0}, // Modelled by NoisePreferentialPolicy instead
 		{"NoiseOptimizedRotation", NoiseOptimizedRotation, 0.80},
 	}
 
@@ -58,41 +59,21 @@ func TestRunwayRotationPolicy_GenerateEvents(t *testing.T) {
 
 			simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 			simEnd := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-			world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
+			world := newMockInitialStateWorld(simStart, simEnd)
 
-			err := policy.GenerateEvents(context.Background(), world)
-			if err != nil {
-				t.Fatalf("GenerateEvents failed: %v", err)
-			}
-
-			// Should generate exactly one rotation change event
-			rotationEvents := world.CountEventsByType(event.RotationChangeType)
-			if rotationEvents != 1 {
-				t.Errorf("expected 1 rotation event, got %d", rotationEvents)
+			if err := policy.SetInitialState(context.Background(), world); err != nil {
+				t.Fatalf("SetInitialState failed: %v", err)
 			}
-
-			// Verify the event is at the start time
-			events := world.GetEvents()
-			if len(events) == 0 {
-				t.Fatal("expected at least one event")
-			}
-
-			rotEvent := events[0]
-			if rotEvent.Type() != event.RotationChangeType {
-				t.Errorf("expected RotationChange event, got %s", rotEvent.Type())
+			if err := policy.GenerateEvents(context.Background(), world); err != nil {
+				t.Fatalf("GenerateEvents failed: %v", err)
 			}
 
-			if !rotEvent.Time().Equal(simStart) {
-				t.Errorf("expected event at sim start (%v), got %v", simStart, rotEvent.Time())
+			// The multiplier is set as initial state, with no event at the start time
+			if !world.rotationSet || world.rotationMultiplier != tt.expectedMultiplier {
+				t.Errorf("expected initial multiplier %f, got %f (set: %v)", tt.expectedMultiplier, world.rotationMultiplier, world.rotationSet)
 			}
-
-			// Verify the multiplier (cast to concrete type to check)
-			if rotChangeEvent, ok := rotEvent.(*event.RotationChangeEvent); ok {
-				if rotChangeEvent.Multiplier() != tt.expectedMultiplier {
-					t.Errorf("expected multiplier %f, got %f", tt.expectedMultiplier, rotChangeEvent.Multiplier())
-				}
-			} else {
-				t.Error("could not cast event to RotationChangeEvent")
+			if events := world.GetEvents(); len(events) != 0 {
+				t.Errorf("expected no events, got %d", len(events))
 			}
 		})
 	}
@@ -110,23 +91,84 @@ func TestRunwayRotationPolicy_CustomConfiguration(t *testing.T) {
 
 	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	simEnd := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
-	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	world := newMockInitialStateWorld(simStart, simEnd)
 
-	err := policy.GenerateEvents(context.Background(), world)
-	if err != nil {
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+
+	expectedMultiplier := float32(0.75)
+	if world.rotationMultiplier != expectedMultiplier {
+		t.Errorf("expected custom multiplier %f, got %f", expectedMultiplier, world.rotationMultiplier)
+	}
+}
+
+func TestRunwayRotationPolicy_RunwayEfficiency(t *testing.T) {
+	// Preferential runways penalize only the westerly ends, and only during the day
+	multipliers := map[string]float32{"27L": 0.85, "27R": 0.9}
+	config := NewDefaultRotationPolicyConfiguration().WithRunwayEfficiency(PreferentialRunway, multipliers)
+	policy := NewRunwayRotationPolicyWithSchedule(PreferentialRunway, config, &RotationSchedule{StartHour: 6, EndHour: 23})
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(0, 0, 1), []string{"09L", "09R"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
 		t.Fatalf("GenerateEvents failed: %v", err)
 	}
 
 	events := world.GetEvents()
-	if len(events) == 0 {
-		t.Fatal("expected at least one event")
+	if len(events) != 2 {
+		t.Fatalf("expected a start and an end event, got %d", len(events))
 	}
-
-	if rotChangeEvent, ok := events[0].(*event.RotationChangeEvent); ok {
-		expectedMultiplier := float32(0.75)
-		if rotChangeEvent.Multiplier() != expectedMultiplier {
-			t.Errorf("expected custom multiplier %f, got %f", expectedMultiplier, rotChangeEvent.Multiplier())
+	for i, expected := range []map[string]float32{multipliers, nil} {
+		rotEvent, ok := events[i].(*event.RotationChangeEvent)
+		if !ok {
+			t.Fatalf("event %d is not a RotationChangeEvent", i)
 		}
+		if !maps.Equal(rotEvent.RunwayMultipliers(), expected) || (expected == nil) != (rotEvent.RunwayMultipliers() == nil) {
+			t.Errorf("event %d: expected runway multipliers %v, got %v", i, expected, rotEvent.RunwayMultipliers())
+		}
+		if rotEvent.Multiplier() != 0 {
+			t.Errorf("event %d: expected the airport-wide multiplier to be left alone, got %f", i, rotEvent.Multiplier())
+		}
+	}
+
+	// Other strategies keep their airport-wide multiplier
+	initial := newMockInitialStateWorld(simStart, simStart.AddDate(0, 0, 1))
+	if err := NewRunwayRotationPolicy(TimeBasedRotation, config).SetInitialState(context.Background(), initial); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if initial.rotationMultiplier != 0.95 || initial.runwayRotationMultipliers != nil {
+		t.Errorf("expected an airport-wide multiplier of 0.95, got %f and %v", initial.rotationMultiplier, initial.runwayRotationMultipliers)
+	}
+}
+
+func TestRunwayRotationPolicy_ScheduleRunningAtStart(t *testing.T) {
+	// A night-time rotation from 22:00 to 06:00 is already running at midnight
+	policy := NewRunwayRotationPolicyWithSchedule(TimeBasedRotation, NewDefaultRotationPolicyConfiguration(), &RotationSchedule{StartHour: 22, EndHour: 6})
+
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockInitialStateWorld(simStart, simStart.AddDate(0, 0, 1))
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if world.rotationMultiplier != 0.95 {
+		t.Errorf("expected the rotation running at the start to set 0.95, got %f", world.rotationMultiplier)
+	}
+	if events := world.eventsAt(simStart); len(events) != 0 {
+		t.Errorf("expected no event at the start time, got %d", len(events))
+	}
+
+	// It ends at 06:00, and the next one starts at 22:00
+	events := world.GetEvents()
+	if len(events) != 2 || !events[0].Time().Equal(simStart.Add(6*time.Hour)) || !events[1].Time().Equal(simStart.Add(22*time.Hour)) {
+		t.Fatalf("expected an end at 06:00 and a start at 22:00, got %d events", len(events))
+	}
+	if events[0].(*event.RotationChangeEvent).Multiplier() != 1.0 || events[1].(*event.RotationChangeEvent).Multiplier() != 0.95 {
+		t.Error("expected the 06:00 event to end the rotation and the 22:00 event to start it")
 	}
 }
 
@@ -187,13 +229,13 @@ func TestRunwayRotationPolicy_TimeBoundedSchedule(t *testing.T) {
 func TestRunwayRotationPolicy_TimeBoundedSchedule_AllDays(t *testing.T) {
 	// Test time-bounded rotation applied every day (9 AM - 5 PM)
 	schedule := &RotationSchedule{
-		StartHour:  9,  // 9 AM
-		EndHour:    17, // 5 PM
+		StartHour:  9,   // 9 AM
+		EndHour:    17,  // 5 PM
 		DaysOfWeek: nil, // All days
 	}
 
 	config := NewDefaultRotationPolicyConfiguration()
-	policy := NewRunwayRotationPolicyWithSchedule(PreferentialRunway, config, schedule)
+	policy := NewRunwayRotationPolicyWithSchedule(TimeBasedRotation, config, schedule)
 
 	// Simulate 3 days
 	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -218,7 +260,7 @@ func TestRunwayRotationPolicy_TimeBoundedSchedule_AllDays(t *testing.T) {
 
 	events := world.GetEvents()
 
-	// Verify the first event is at 9 AM on Jan 1 with multiplier 0.90 (PreferentialRunway)
+	// Verify the first event is at 9 AM on Jan 1 with multiplier 0.95 (TimeBasedRotation)
 	if len(events) > 0 {
 		firstEvent, ok := events[0].(*event.RotationChangeEvent)
 		if !ok {
@@ -230,7 +272,7 @@ func TestRunwayRotationPolicy_TimeBoundedSchedule_AllDays(t *testing.T) {
 			t.Errorf("first event time: expected %v, got %v", expectedTime, firstEvent.Time())
 		}
 
-		expectedMult := float32(0.90)
+		expectedMult := float32(0.95)
 		if firstEvent.Multiplier() != expectedMult {
 			t.Errorf("first event multiplier: expected %f, got %f", expectedMult, firstEvent.Multiplier())
 		}
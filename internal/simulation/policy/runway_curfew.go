@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
+)
+
+// RunwayCurfewConfiguration defines a daily curfew that applies to specific runway ends or
+// operations (e.g., no departures off 27R after 23:00), or that caps movements per hour
+// instead of stopping them.
+type RunwayCurfewConfiguration struct {
+	StartTime           time.Time                 // Start of the curfew (only the time of day is used)
+	EndTime             time.Time                 // End of the curfew (only the time of day is used; overnight curfews wrap)
+	RunwayEnds          []string                  // Runway end designations affected (empty = all runway ends)
+	Operations          []airport.RunwayOperation // Operations affected (empty = arrivals and departures)
+	MaxMovementsPerHour float64                   // Movements per hour still allowed on the affected runway ends (0 = none)
+}
+
+// RunwayCurfewPolicy restricts operations on selected runway ends during a daily time range.
+// Unlike CurfewPolicy, which closes the whole airport, it can target individual runway ends
+// and operations, and can be a partial curfew that caps movements rather than zeroing them.
+type RunwayCurfewPolicy struct {
+	config      RunwayCurfewConfiguration
+	restriction *event.CurfewRestriction
+}
+
+// NewRunwayCurfewPolicy creates a new runway curfew policy with validation.
+// Returns an error if the time range is invalid, the movement cap is negative,
+// or an operation is unknown.
+func NewRunwayCurfewPolicy(config RunwayCurfewConfiguration) (*RunwayCurfewPolicy, error) {
+	if !config.EndTime.After(config.StartTime) {
+		return nil, ErrInvalidCurfewTime
+	}
+	if config.EndTime.Sub(config.StartTime) > MaxCurfewDuration {
+		return nil, ErrCurfewTooLong
+	}
+	if config.MaxMovementsPerHour < 0 {
+		return nil, simerrors.Invalidf("curfew movement cap cannot be negative, got %f", config.MaxMovementsPerHour)
+	}
+	for _, end := range config.RunwayEnds {
+		if end == "" {
+			return nil, simerrors.Invalidf("curfew runway end designation cannot be empty")
+		}
+	}
+	for _, op := range config.Operations {
+		if op != airport.Arrivals && op != airport.Departures {
+			return nil, simerrors.Invalidf("unknown curfew operation: %d", op)
+		}
+	}
+
+	restriction := &event.CurfewRestriction{
+		RunwayEnds:          append([]string(nil), config.RunwayEnds...),
+		Operations:          append([]airport.RunwayOperation(nil), config.Operations...),
+		MaxMovementsPerHour: config.MaxMovementsPerHour,
+	}
+
+	return &RunwayCurfewPolicy{
+		config:      config,
+		restriction: restriction,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *RunwayCurfewPolicy) Name() string {
+	return "RunwayCurfewPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *RunwayCurfewPolicy) Parameters() any {
+	return p.config
+}
+
+// Validate checks that every curfew runway end belongs to a runway at the airport.
+func (p *RunwayCurfewPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunwayEnds(world, p.Name(), p.config.RunwayEnds)...)
+}
+
+// GenerateEvents generates curfew start and end events for every day in the simulation period.
+// A curfew already in progress when the simulation starts begins at the simulation start.
+func (p *RunwayCurfewPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	// Start one day early so an overnight curfew running over the simulation start is included.
+	// Days are laid out in local time so curfews follow daylight saving time changes.
+	curfews := timewin.Daily(startTime.AddDate(0, 0, -1), endTime, p.config.StartTime, p.config.EndTime, location(world))
+
+	// Clip the curfews to the simulation period
+	for _, curfew := range timewin.Clip(curfews, timewin.Window{Start: startTime, End: endTime}) {
+		world.ScheduleEvent(event.NewRunwayCurfewStartEvent(p.restriction, curfew.Start))
+		world.ScheduleEvent(event.NewRunwayCurfewEndEvent(p.restriction, curfew.End))
+	}
+
+	return nil
+}
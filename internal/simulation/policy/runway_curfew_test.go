@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewRunwayCurfewPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		config      RunwayCurfewConfiguration
+		expectedErr error
+		expectError bool
+	}{
+		{"departures off 27R", RunwayCurfewConfiguration{StartTime: start, EndTime: end, RunwayEnds: []string{"27R"}, Operations: []airport.RunwayOperation{airport.Departures}}, nil, false},
+		{"partial airport curfew", RunwayCurfewConfiguration{StartTime: start, EndTime: end, MaxMovementsPerHour: 10}, nil, false},
+		{"end before start", RunwayCurfewConfiguration{StartTime: end, EndTime: start}, ErrInvalidCurfewTime, true},
+		{"too long", RunwayCurfewConfiguration{StartTime: start, EndTime: start.Add(MaxCurfewDuration + time.Hour)}, ErrCurfewTooLong, true},
+		{"negative cap", RunwayCurfewConfiguration{StartTime: start, EndTime: end, MaxMovementsPerHour: -1}, nil, true},
+		{"empty runway end", RunwayCurfewConfiguration{StartTime: start, EndTime: end, RunwayEnds: []string{""}}, nil, true},
+		{"unknown operation", RunwayCurfewConfiguration{StartTime: start, EndTime: end, Operations: []airport.RunwayOperation{7}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRunwayCurfewPolicy(tt.config)
+			if tt.expectError && err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRunwayCurfewPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 3)
+
+	policy, err := NewRunwayCurfewPolicy(RunwayCurfewConfiguration{
+		StartTime:  time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		EndTime:    time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+		RunwayEnds: []string{"27R"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// The curfew running over midnight at the start is clipped to begin at simStart,
+	// then one curfew starts each night (the last one is clipped to simEnd)
+	starts := world.CountEventsByType(event.RunwayCurfewStartType)
+	ends := world.CountEventsByType(event.RunwayCurfewEndType)
+	if starts != 4 || ends != 4 {
+		t.Fatalf("Expected 4 start and 4 end events, got %d and %d", starts, ends)
+	}
+
+	var first *event.RunwayCurfewStartEvent
+	for _, evt := range world.GetEvents() {
+		if start, ok := evt.(*event.RunwayCurfewStartEvent); ok && (first == nil || start.Time().Before(first.Time())) {
+			first = start
+		}
+	}
+	if !first.Time().Equal(simStart) {
+		t.Errorf("Expected first curfew to start at %v, got %v", simStart, first.Time())
+	}
+	if !first.Restriction().AppliesToRunwayEnd("27R") || first.Restriction().AppliesToRunwayEnd("09L") {
+		t.Error("Expected restriction to apply only to 27R")
+	}
+}
@@ -2,22 +2,21 @@ package policy
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"math"
 	"sort"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 // Common errors for scheduled wind policy validation
 var (
 	// ErrEmptyWindSchedule indicates no wind changes were provided
-	ErrEmptyWindSchedule = errors.New("wind schedule cannot be empty")
+	ErrEmptyWindSchedule = simerrors.Invalidf("wind schedule cannot be empty")
 
 	// ErrWindScheduleNotChronological indicates wind changes are not in time order
-	ErrWindScheduleNotChronological = errors.New("wind schedule must be in chronological order")
+	ErrWindScheduleNotChronological = simerrors.Invalidf("wind schedule must be in chronological order")
 )
 
 // WindChange represents a discrete wind condition change at a specific time.
@@ -25,6 +24,7 @@ type WindChange struct {
 	Timestamp     time.Time // When this wind condition takes effect
 	SpeedKnots    float64   // Wind speed in knots
 	DirectionTrue float64   // Wind direction in degrees true (0-360)
+	GustKnots     float64   // Peak gust speed in knots (0 = no gusts)
 }
 
 // ScheduledWindPolicy implements time-varying wind conditions based on an explicit schedule.
@@ -52,6 +52,7 @@ type ScheduledWindPolicy struct {
 //   - Schedule cannot be empty
 //   - Wind changes must be in chronological order
 //   - Wind speeds must be non-negative
+//   - Gusts, when reported, must be at least the wind speed
 //   - Wind directions are automatically normalized to 0-360 range
 //
 // Returns an error if validation fails.
@@ -64,7 +65,10 @@ func NewScheduledWindPolicy(windSchedule []WindChange) (*ScheduledWindPolicy, er
 	for i, change := range windSchedule {
 		// Validate speed
 		if change.SpeedKnots < 0 {
-			return nil, fmt.Errorf("wind change %d: %w", i, ErrInvalidWindSpeed)
+			return nil, simerrors.Invalidf("wind change %d: %w", i, ErrInvalidWindSpeed)
+		}
+		if change.GustKnots != 0 && change.GustKnots < change.SpeedKnots {
+			return nil, simerrors.Invalidf("wind change %d: gust %g kt is below wind speed %g kt", i, change.GustKnots, change.SpeedKnots)
 		}
 
 		// Normalize direction to 0-360 range
@@ -90,12 +94,34 @@ func (p *ScheduledWindPolicy) Name() string {
 	return "ScheduledWindPolicy"
 }
 
+// Parameters returns the policy configuration.
+func (p *ScheduledWindPolicy) Parameters() any {
+	return p.windSchedule
+}
+
+// SetInitialState sets the wind of the latest change before the simulation start, so the
+// simulation starts in the scheduled wind (see InitialStatePolicy). If the schedule has no
+// change before the start, the simulation starts with calm wind (0 knots) until the first
+// scheduled change.
+func (p *ScheduledWindPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	startTime := world.GetStartTime()
+
+	var initial *WindChange
+	for i, change := range p.windSchedule {
+		if !change.Timestamp.Before(startTime) {
+			break
+		}
+		initial = &p.windSchedule[i]
+	}
+	if initial == nil {
+		return nil
+	}
+	return world.SetGustingWind(initial.SpeedKnots, initial.GustKnots, initial.DirectionTrue)
+}
+
 // GenerateEvents creates WindChangeEvents for each scheduled wind change.
-// Only generates events that fall within the simulation time period.
-//
-// The first wind change in the schedule sets the initial wind condition if it occurs
-// at or before the simulation start time. Otherwise, the simulation starts with calm wind
-// (0 knots) until the first scheduled change.
+// Only generates events that fall within the simulation time period; the wind of earlier
+// changes is set by SetInitialState.
 func (p *ScheduledWindPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	startTime := world.GetStartTime()
 	endTime := world.GetEndTime()
@@ -109,8 +135,9 @@ func (p *ScheduledWindPolicy) GenerateEvents(ctx context.Context, world EventWor
 		}
 
 		// Create and schedule wind change event
-		windEvent := event.NewWindChangeEvent(
+		windEvent := event.NewGustingWindChangeEvent(
 			change.SpeedKnots,
+			change.GustKnots,
 			change.DirectionTrue,
 			change.Timestamp,
 		)
@@ -19,16 +19,16 @@ func TestNewScheduledWindPolicy(t *testing.T) {
 		{
 			name: "valid single change",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 			},
 			expectError: false,
 		},
 		{
 			name: "valid multiple changes",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 90},
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
-				{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 20, 270},
+				{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
+				{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270},
 			},
 			expectError: false,
 		},
@@ -41,7 +41,7 @@ func TestNewScheduledWindPolicy(t *testing.T) {
 		{
 			name: "negative wind speed",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), -5, 270},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: -5, DirectionTrue: 270},
 			},
 			expectError: true,
 			errorType:   ErrInvalidWindSpeed,
@@ -49,23 +49,30 @@ func TestNewScheduledWindPolicy(t *testing.T) {
 		{
 			name: "not chronological",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 20, 270},
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
+				{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 			},
 			expectError: true,
 			errorType:   ErrWindScheduleNotChronological,
 		},
+		{
+			name: "gust below wind speed",
+			schedule: []WindChange{
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270, GustKnots: 15},
+			},
+			expectError: true,
+		},
 		{
 			name: "direction normalization",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 450}, // Should normalize to 90
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 450}, // Should normalize to 90
 			},
 			expectError: false,
 		},
 		{
 			name: "negative direction normalization",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, -90}, // Should normalize to 270
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: -90}, // Should normalize to 270
 			},
 			expectError: false,
 		},
@@ -97,7 +104,7 @@ func TestNewScheduledWindPolicy(t *testing.T) {
 // TestScheduledWindPolicyName tests the Name method
 func TestScheduledWindPolicyName(t *testing.T) {
 	policy, _ := NewScheduledWindPolicy([]WindChange{
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 	})
 
 	if policy.Name() != "ScheduledWindPolicy" {
@@ -118,26 +125,26 @@ func TestScheduledWindPolicyGenerateEvents(t *testing.T) {
 		{
 			name: "all events within period",
 			schedule: []WindChange{
-				{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 90},
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
-				{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 20, 270},
+				{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
+				{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270},
 			},
 			expectedCount: 3,
 		},
 		{
 			name: "some events outside period",
 			schedule: []WindChange{
-				{time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), 5, 90},  // Before
-				{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},  // Within
-				{time.Date(2024, 1, 3, 1, 0, 0, 0, time.UTC), 20, 270},   // After
+				{Timestamp: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90}, // Before
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270}, // Within
+				{Timestamp: time.Date(2024, 1, 3, 1, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270},  // After
 			},
 			expectedCount: 1,
 		},
 		{
 			name: "all events outside period",
 			schedule: []WindChange{
-				{time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), 5, 90},
-				{time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), 15, 270},
+				{Timestamp: time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+				{Timestamp: time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 			},
 			expectedCount: 0,
 		},
@@ -175,8 +182,8 @@ func TestScheduledWindPolicyGenerateEvents(t *testing.T) {
 // TestScheduledWindPolicyGetSchedule tests the GetSchedule method
 func TestScheduledWindPolicyGetSchedule(t *testing.T) {
 	original := []WindChange{
-		{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 90},
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
+		{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 	}
 
 	policy, err := NewScheduledWindPolicy(original)
@@ -201,9 +208,9 @@ func TestScheduledWindPolicyGetSchedule(t *testing.T) {
 // TestScheduledWindPolicyGetWindAt tests the GetWindAt method
 func TestScheduledWindPolicyGetWindAt(t *testing.T) {
 	schedule := []WindChange{
-		{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 90},
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
-		{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 25, 270},
+		{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
+		{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 25, DirectionTrue: 270},
 	}
 
 	policy, err := NewScheduledWindPolicy(schedule)
@@ -267,9 +274,9 @@ func TestScheduledWindPolicyGetWindAt(t *testing.T) {
 // TestSortSchedule tests the sort utility function
 func TestSortSchedule(t *testing.T) {
 	schedule := []WindChange{
-		{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 25, 270},
-		{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 90},
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 15, 270},
+		{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 25, DirectionTrue: 270},
+		{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
 	}
 
 	SortSchedule(schedule)
@@ -287,3 +294,51 @@ func TestSortSchedule(t *testing.T) {
 		t.Error("Schedule not sorted correctly by timestamp")
 	}
 }
+
+// TestScheduledWindPolicySetInitialState tests that the latest change before the start sets the initial wind
+func TestScheduledWindPolicySetInitialState(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		schedule  []WindChange
+		wantSet   bool
+		wantSpeed float64
+		wantGust  float64
+	}{
+		{
+			name: "latest change before start",
+			schedule: []WindChange{
+				{Timestamp: time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+				{Timestamp: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), SpeedKnots: 25, DirectionTrue: 90, GustKnots: 35},
+				{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 270},
+			},
+			wantSet:   true,
+			wantSpeed: 25,
+			wantGust:  35,
+		},
+		{
+			name: "no change before start",
+			schedule: []WindChange{
+				{Timestamp: simStart, SpeedKnots: 10, DirectionTrue: 270},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewScheduledWindPolicy(tt.schedule)
+			if err != nil {
+				t.Fatalf("Failed to create policy: %v", err)
+			}
+			world := newMockInitialStateWorld(simStart, simEnd)
+			if err := policy.SetInitialState(context.Background(), world); err != nil {
+				t.Fatalf("SetInitialState failed: %v", err)
+			}
+			if world.windSet != tt.wantSet || world.windSpeed != tt.wantSpeed || world.windGust != tt.wantGust {
+				t.Errorf("Expected wind set %v at %g kt gusting %g, got %+v", tt.wantSet, tt.wantSpeed, tt.wantGust, world)
+			}
+		})
+	}
+}
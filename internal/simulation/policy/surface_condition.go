@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Defaults for SurfaceConditionsFromRainfall
+const (
+	DefaultWetRainfallRate          = 0.1       // Rainfall in mm/h from which runways are wet
+	DefaultContaminatedRainfallRate = 7.6       // Rainfall in mm/h (heavy rain) from which runways are contaminated by standing water
+	DefaultRunwayDryingTime         = time.Hour // Time a runway stays wet after rain stops
+)
+
+// SurfaceConditionPeriod is a period during which runways are wet or contaminated.
+type SurfaceConditionPeriod struct {
+	Start     time.Time                // When the condition begins
+	End       time.Time                // When the runways return to dry
+	Condition airport.SurfaceCondition // Condition during the period (Wet or Contaminated)
+}
+
+// RunwaySurfaceConditionSchedule defines when runway surfaces are degraded.
+type RunwaySurfaceConditionSchedule struct {
+	RunwayDesignations []string                 // Affected runways (empty = all runways)
+	Periods            []SurfaceConditionPeriod // Non-overlapping periods in chronological order
+}
+
+// RainfallSample is an observed or forecast rainfall rate, holding until the next sample.
+type RainfallSample struct {
+	Timestamp          time.Time // When this rate begins
+	MillimetersPerHour float64   // Rainfall rate in mm/h
+}
+
+// RunwaySurfaceConditionPolicy models runways becoming wet or contaminated, for example during
+// rainfall. While degraded, a runway's crosswind and tailwind limits are reduced and its
+// separation and runway occupancy times are stretched according to its SurfaceType
+// (see airport.SurfaceCondition.Effect), so unpaved runways suffer most.
+type RunwaySurfaceConditionPolicy struct {
+	schedule RunwaySurfaceConditionSchedule
+}
+
+// NewRunwaySurfaceConditionPolicy creates a new runway surface condition policy with validation.
+// Returns an error if the schedule has no periods, or a period is empty, overlaps the previous
+// one, or has a condition other than Wet or Contaminated.
+func NewRunwaySurfaceConditionPolicy(schedule RunwaySurfaceConditionSchedule) (*RunwaySurfaceConditionPolicy, error) {
+	if len(schedule.Periods) == 0 {
+		return nil, simerrors.Invalidf("surface condition schedule cannot be empty")
+	}
+
+	for i, period := range schedule.Periods {
+		if !period.End.After(period.Start) {
+			return nil, simerrors.Invalidf("surface condition period %d: end must be after start", i)
+		}
+		if period.Condition != airport.Wet && period.Condition != airport.Contaminated {
+			return nil, simerrors.Invalidf("surface condition period %d: condition must be Wet or Contaminated, got %s", i, period.Condition)
+		}
+		if i > 0 && period.Start.Before(schedule.Periods[i-1].End) {
+			return nil, simerrors.Invalidf("surface condition periods must be in chronological order without overlaps")
+		}
+	}
+
+	return &RunwaySurfaceConditionPolicy{
+		schedule: schedule,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *RunwaySurfaceConditionPolicy) Name() string {
+	return "RunwaySurfaceConditionPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *RunwaySurfaceConditionPolicy) Parameters() any {
+	return p.schedule
+}
+
+// Validate checks that every affected runway exists.
+func (p *RunwaySurfaceConditionPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.schedule.RunwayDesignations)...)
+}
+
+// GenerateEvents generates surface condition events for each affected runway at the start of
+// every period within the simulation period, and a return to Dry at its end unless the next
+// period begins at the same time.
+func (p *RunwaySurfaceConditionPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	runwayIDs := p.schedule.RunwayDesignations
+	if len(runwayIDs) == 0 {
+		runwayIDs = world.GetRunwayIDs()
+	}
+
+	periods := p.schedule.Periods
+	for i, period := range periods {
+		if !period.End.After(startTime) || period.Start.After(endTime) {
+			continue
+		}
+		start := period.Start
+		if start.Before(startTime) {
+			start = startTime
+		}
+		continues := i+1 < len(periods) && periods[i+1].Start.Equal(period.End)
+
+		for _, runwayID := range runwayIDs {
+			world.ScheduleEvent(event.NewRunwaySurfaceConditionEvent(runwayID, period.Condition, start))
+			if period.End.Before(endTime) && !continues {
+				world.ScheduleEvent(event.NewRunwaySurfaceConditionEvent(runwayID, airport.Dry, period.End))
+			}
+		}
+	}
+
+	return nil
+}
+
+// SurfaceConditionsFromRainfall converts a chronological rainfall record into surface condition
+// periods. Runways are wet from DefaultWetRainfallRate and contaminated from
+// DefaultContaminatedRainfallRate, and stay wet for dryingTime after the rain eases
+// (0 = DefaultRunwayDryingTime). The last sample's rate holds for one hour.
+func SurfaceConditionsFromRainfall(samples []RainfallSample, dryingTime time.Duration) []SurfaceConditionPeriod {
+	if dryingTime == 0 {
+		dryingTime = DefaultRunwayDryingTime
+	}
+
+	// Each rainy sample makes runways wet until dryingTime after it ends, and contaminated while
+	// heavy rain falls; the worst condition in effect wins
+	type change struct {
+		at        time.Time
+		condition airport.SurfaceCondition
+		delta     int
+	}
+	var changes []change
+	for i, sample := range samples {
+		end := sample.Timestamp.Add(time.Hour)
+		if i+1 < len(samples) {
+			end = samples[i+1].Timestamp
+		}
+
+		if sample.MillimetersPerHour >= DefaultWetRainfallRate {
+			changes = append(changes,
+				change{sample.Timestamp, airport.Wet, 1},
+				change{end.Add(dryingTime), airport.Wet, -1})
+		}
+		if sample.MillimetersPerHour >= DefaultContaminatedRainfallRate {
+			changes = append(changes,
+				change{sample.Timestamp, airport.Contaminated, 1},
+				change{end, airport.Contaminated, -1})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].at.Before(changes[j].at) })
+
+	var periods []SurfaceConditionPeriod
+	active := map[airport.SurfaceCondition]int{}
+	current, since := airport.Dry, time.Time{}
+	for i, c := range changes {
+		active[c.condition] += c.delta
+		if i+1 < len(changes) && changes[i+1].at.Equal(c.at) {
+			continue
+		}
+
+		condition := airport.Dry
+		if active[airport.Contaminated] > 0 {
+			condition = airport.Contaminated
+		} else if active[airport.Wet] > 0 {
+			condition = airport.Wet
+		}
+		if condition == current {
+			continue
+		}
+
+		if current != airport.Dry {
+			periods = append(periods, SurfaceConditionPeriod{Start: since, End: c.at, Condition: current})
+		}
+		current, since = condition, c.at
+	}
+
+	return periods
+}
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewRunwaySurfaceConditionPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	period := func(from, to int, condition airport.SurfaceCondition) SurfaceConditionPeriod {
+		return SurfaceConditionPeriod{start.Add(time.Duration(from) * time.Hour), start.Add(time.Duration(to) * time.Hour), condition}
+	}
+
+	tests := []struct {
+		name        string
+		periods     []SurfaceConditionPeriod
+		expectError bool
+	}{
+		{"valid periods", []SurfaceConditionPeriod{period(0, 2, airport.Wet), period(2, 3, airport.Contaminated)}, false},
+		{"empty schedule", nil, true},
+		{"end before start", []SurfaceConditionPeriod{period(2, 1, airport.Wet)}, true},
+		{"dry period", []SurfaceConditionPeriod{period(0, 1, airport.Dry)}, true},
+		{"overlapping periods", []SurfaceConditionPeriod{period(0, 2, airport.Wet), period(1, 3, airport.Wet)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRunwaySurfaceConditionPolicy(RunwaySurfaceConditionSchedule{Periods: tt.periods})
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunwaySurfaceConditionPolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.Add(24 * time.Hour)
+
+	// Wet 02:00-04:00 then contaminated until 05:00 on every runway
+	policy, err := NewRunwaySurfaceConditionPolicy(RunwaySurfaceConditionSchedule{Periods: []SurfaceConditionPeriod{
+		{simStart.Add(2 * time.Hour), simStart.Add(4 * time.Hour), airport.Wet},
+		{simStart.Add(4 * time.Hour), simStart.Add(5 * time.Hour), airport.Contaminated},
+	}})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// No return to Dry at 04:00, since the contaminated period follows immediately
+	expected := map[time.Time]airport.SurfaceCondition{
+		simStart.Add(2 * time.Hour): airport.Wet,
+		simStart.Add(4 * time.Hour): airport.Contaminated,
+		simStart.Add(5 * time.Hour): airport.Dry,
+	}
+	if got := world.CountEventsByType(event.RunwaySurfaceConditionType); got != 6 {
+		t.Errorf("Expected 6 surface condition events, got %d", got)
+	}
+	for _, evt := range world.GetEvents() {
+		conditionEvent := evt.(*event.RunwaySurfaceConditionEvent)
+		if want, ok := expected[evt.Time()]; !ok || want != conditionEvent.Condition() {
+			t.Errorf("Unexpected %s on %s at %v", conditionEvent.Condition(), conditionEvent.RunwayID(), evt.Time())
+		}
+	}
+}
+
+func TestSurfaceConditionsFromRainfall(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+
+	// Light rain 01:00, heavy rain 02:00, dry from 03:00, light shower 08:00 (last sample)
+	samples := []RainfallSample{
+		{hour(0), 0},
+		{hour(1), 2},
+		{hour(2), 10},
+		{hour(3), 0},
+		{hour(8), 1},
+	}
+
+	periods := SurfaceConditionsFromRainfall(samples, 0)
+	expected := []SurfaceConditionPeriod{
+		{hour(1), hour(2), airport.Wet},
+		{hour(2), hour(3), airport.Contaminated},
+		{hour(3), hour(4), airport.Wet}, // Drying after the heavy rain
+		{hour(8), hour(10), airport.Wet},
+	}
+
+	if len(periods) != len(expected) {
+		t.Fatalf("Expected %d periods, got %+v", len(expected), periods)
+	}
+	for i, want := range expected {
+		if periods[i] != want {
+			t.Errorf("Period %d: expected %+v, got %+v", i, want, periods[i])
+		}
+	}
+
+	if _, err := NewRunwaySurfaceConditionPolicy(RunwaySurfaceConditionSchedule{Periods: periods}); err != nil {
+		t.Errorf("Expected derived periods to be a valid schedule, got %v", err)
+	}
+}
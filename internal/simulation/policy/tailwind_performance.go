@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// TailwindPerformanceConfiguration defines the fleet landing performance used to
+// assess tailwind operations.
+type TailwindPerformanceConfiguration struct {
+	Fleet                   []airport.LandingPerformance // Landing distance requirements by aircraft class
+	DistanceIncreasePerKnot float64                      // Fractional landing distance increase per knot of tailwind (0 = default 5%)
+}
+
+// TailwindPerformancePolicy models the increase in required landing distance when a runway
+// is operated with a tailwind component that is still within its tailwind limit.
+// Arrivals whose corrected landing distance exceeds the runway length become infeasible,
+// so tailwind operations carry a capacity penalty rather than parity with headwind operations.
+type TailwindPerformancePolicy struct {
+	config TailwindPerformanceConfiguration
+}
+
+// NewTailwindPerformancePolicy creates a new tailwind performance policy.
+// Returns an error if the fleet is empty or contains invalid entries.
+func NewTailwindPerformancePolicy(config TailwindPerformanceConfiguration) (*TailwindPerformancePolicy, error) {
+	if len(config.Fleet) == 0 {
+		return nil, simerrors.Invalidf("fleet landing performance cannot be empty")
+	}
+	if config.DistanceIncreasePerKnot < 0 {
+		return nil, simerrors.Invalidf("distance increase per knot cannot be negative: %f", config.DistanceIncreasePerKnot)
+	}
+
+	totalShare := 0.0
+	for _, lp := range config.Fleet {
+		if lp.Share < 0 {
+			return nil, simerrors.Invalidf("fleet class %q share cannot be negative: %f", lp.Class, lp.Share)
+		}
+		if lp.LandingDistanceMeters <= 0 {
+			return nil, simerrors.Invalidf("fleet class %q landing distance must be positive, got %f", lp.Class, lp.LandingDistanceMeters)
+		}
+		totalShare += lp.Share
+	}
+	if totalShare <= 0 {
+		return nil, simerrors.Invalidf("fleet shares must sum to a positive value")
+	}
+
+	// Set defaults
+	if config.DistanceIncreasePerKnot == 0 {
+		config.DistanceIncreasePerKnot = airport.DefaultTailwindDistanceFactorPerKnot
+	}
+
+	return &TailwindPerformancePolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *TailwindPerformancePolicy) Name() string {
+	return "TailwindPerformancePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *TailwindPerformancePolicy) Parameters() any {
+	return p.config
+}
+
+// SetInitialState sets the fleet landing performance for the whole simulation (see
+// InitialStatePolicy). The engine uses the fleet data together with the current wind and each
+// active runway's direction and length to scale down arrival capacity under tailwind.
+func (p *TailwindPerformancePolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	return event.NewTailwindPerformanceEvent(
+		p.config.Fleet,
+		p.config.DistanceIncreasePerKnot,
+		world.GetStartTime(),
+	).Apply(ctx, world)
+}
+
+// GenerateEvents generates no events: the landing performance is set as initial state by
+// SetInitialState and remains constant.
+func (p *TailwindPerformancePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	return nil
+}
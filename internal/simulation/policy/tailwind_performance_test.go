@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+func TestNewTailwindPerformancePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      TailwindPerformanceConfiguration
+		expectError bool
+	}{
+		{
+			name: "valid configuration",
+			config: TailwindPerformanceConfiguration{
+				Fleet: []airport.LandingPerformance{
+					{Class: "Heavy", Share: 0.3, LandingDistanceMeters: 2500},
+					{Class: "Medium", Share: 0.7, LandingDistanceMeters: 1800},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "empty fleet",
+			config:      TailwindPerformanceConfiguration{},
+			expectError: true,
+		},
+		{
+			name: "negative share",
+			config: TailwindPerformanceConfiguration{
+				Fleet: []airport.LandingPerformance{{Class: "Heavy", Share: -1, LandingDistanceMeters: 2500}},
+			},
+			expectError: true,
+		},
+		{
+			name: "zero total share",
+			config: TailwindPerformanceConfiguration{
+				Fleet: []airport.LandingPerformance{{Class: "Heavy", Share: 0, LandingDistanceMeters: 2500}},
+			},
+			expectError: true,
+		},
+		{
+			name: "zero landing distance",
+			config: TailwindPerformanceConfiguration{
+				Fleet: []airport.LandingPerformance{{Class: "Heavy", Share: 1, LandingDistanceMeters: 0}},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative distance factor",
+			config: TailwindPerformanceConfiguration{
+				Fleet:                   []airport.LandingPerformance{{Class: "Heavy", Share: 1, LandingDistanceMeters: 2500}},
+				DistanceIncreasePerKnot: -0.1,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTailwindPerformancePolicy(tt.config)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTailwindPerformancePolicy_SetInitialState(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 0, 1)
+
+	policy, err := NewTailwindPerformancePolicy(TailwindPerformanceConfiguration{
+		Fleet: []airport.LandingPerformance{{Class: "Medium", Share: 1, LandingDistanceMeters: 1800}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockInitialStateWorld(simStart, simEnd)
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	// The fleet is set as initial state, with no event at the start time
+	if events := world.GetEvents(); len(events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events))
+	}
+	if world.factorPerKnot != airport.DefaultTailwindDistanceFactorPerKnot {
+		t.Errorf("Expected default factor %f, got %f", airport.DefaultTailwindDistanceFactorPerKnot, world.factorPerKnot)
+	}
+	if len(world.landingPerformance) != 1 {
+		t.Errorf("Expected 1 fleet entry, got %d", len(world.landingPerformance))
+	}
+}
@@ -2,9 +2,9 @@ package policy
 
 import (
 	"context"
-	"fmt"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -28,10 +28,10 @@ type TaxiTimePolicy struct {
 // NewTaxiTimePolicy creates a new taxi time policy.
 func NewTaxiTimePolicy(config TaxiTimeConfiguration) (*TaxiTimePolicy, error) {
 	if config.AverageTaxiInTime < 0 {
-		return nil, fmt.Errorf("average taxi-in time cannot be negative: %v", config.AverageTaxiInTime)
+		return nil, simerrors.Invalidf("average taxi-in time cannot be negative: %v", config.AverageTaxiInTime)
 	}
 	if config.AverageTaxiOutTime < 0 {
-		return nil, fmt.Errorf("average taxi-out time cannot be negative: %v", config.AverageTaxiOutTime)
+		return nil, simerrors.Invalidf("average taxi-out time cannot be negative: %v", config.AverageTaxiOutTime)
 	}
 
 	return &TaxiTimePolicy{
@@ -44,31 +44,34 @@ func (p *TaxiTimePolicy) Name() string {
 	return "TaxiTimePolicy"
 }
 
-// GenerateEvents generates a taxi time adjustment event at simulation start.
+// Parameters returns the policy configuration.
+func (p *TaxiTimePolicy) Parameters() any {
+	return p.config
+}
+
+// SetInitialState sets the taxi time overhead for the whole simulation (see
+// InitialStatePolicy).
 //
 // Taxi time extends the effective time an aircraft occupies the airport system:
 // - Arrival: lands, taxis in (taxi-in time), occupies gate, taxis out (taxi-out time), departs
 // - Total taxi overhead = taxi-in + taxi-out
 //
 // This overhead reduces sustainable throughput by extending the effective
-// turnaround time. The policy generates an event that can be used by the
-// engine to adjust capacity calculations.
+// turnaround time, which the engine uses to adjust capacity calculations.
 //
 // Note: This is a simplified model. Future versions may implement:
 // - Taxiway capacity constraints (max aircraft on taxiways)
 // - Runway exit efficiency modeling
 // - Hot spot and conflict point detection
-func (p *TaxiTimePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
-	startTime := world.GetStartTime()
-
+func (p *TaxiTimePolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
 	// Total taxi time overhead per aircraft cycle
 	totalTaxiTimeOverhead := p.config.AverageTaxiInTime + p.config.AverageTaxiOutTime
 
-	// Generate taxi time adjustment event
-	world.ScheduleEvent(event.NewTaxiTimeAdjustmentEvent(
-		totalTaxiTimeOverhead,
-		startTime,
-	))
+	return event.NewTaxiTimeAdjustmentEvent(totalTaxiTimeOverhead, world.GetStartTime()).Apply(ctx, world)
+}
 
+// GenerateEvents generates no events: the taxi time overhead is set as initial state by
+// SetInitialState and remains constant.
+func (p *TaxiTimePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	return nil
 }
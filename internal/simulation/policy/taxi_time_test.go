@@ -4,8 +4,6 @@ import (
 	"context"
 	"testing"
 	"time"
-
-	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 func TestNewTaxiTimePolicy(t *testing.T) {
@@ -89,14 +87,14 @@ func TestTaxiTimePolicy_Name(t *testing.T) {
 	}
 }
 
-func TestTaxiTimePolicy_GenerateEvents(t *testing.T) {
+func TestTaxiTimePolicy_SetInitialState(t *testing.T) {
 	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	simEnd := simStart.AddDate(0, 0, 7)
 
 	tests := []struct {
-		name                    string
-		config                  TaxiTimeConfiguration
-		expectedTotalOverhead   time.Duration
+		name                  string
+		config                TaxiTimeConfiguration
+		expectedTotalOverhead time.Duration
 	}{
 		{
 			name: "5 min in, 5 min out",
@@ -131,36 +129,20 @@ func TestTaxiTimePolicy_GenerateEvents(t *testing.T) {
 				t.Fatalf("Failed to create policy: %v", err)
 			}
 
-			world := newMockEventWorld(simStart, simEnd, []string{"09L"})
-			err = policy.GenerateEvents(context.Background(), world)
-			if err != nil {
+			world := newMockInitialStateWorld(simStart, simEnd)
+			if err := policy.SetInitialState(context.Background(), world); err != nil {
+				t.Fatalf("SetInitialState failed: %v", err)
+			}
+			if err := policy.GenerateEvents(context.Background(), world); err != nil {
 				t.Fatalf("GenerateEvents failed: %v", err)
 			}
 
-			// Should generate exactly one taxi time adjustment event
-			taxiEvents := world.CountEventsByType(event.TaxiTimeAdjustmentType)
-			if taxiEvents != 1 {
-				t.Errorf("Expected 1 taxi time event, got %d", taxiEvents)
+			// The overhead is set as initial state, with no event at the start time
+			if world.taxiTimeOverhead != tt.expectedTotalOverhead {
+				t.Errorf("Expected %v overhead, got %v", tt.expectedTotalOverhead, world.taxiTimeOverhead)
 			}
-
-			// Verify the overhead value
-			for _, evt := range world.events {
-				if evt.Type() == event.TaxiTimeAdjustmentType {
-					taxiEvt, ok := evt.(*event.TaxiTimeAdjustmentEvent)
-					if !ok {
-						t.Fatal("Failed to cast event to TaxiTimeAdjustmentEvent")
-					}
-
-					if taxiEvt.TotalTaxiTimeOverhead() != tt.expectedTotalOverhead {
-						t.Errorf("Expected %v overhead, got %v",
-							tt.expectedTotalOverhead, taxiEvt.TotalTaxiTimeOverhead())
-					}
-
-					// Event should be at simulation start
-					if !evt.Time().Equal(simStart) {
-						t.Errorf("Expected event at %v, got %v", simStart, evt.Time())
-					}
-				}
+			if events := world.GetEvents(); len(events) != 0 {
+				t.Errorf("Expected no events, got %d", len(events))
 			}
 		})
 	}
@@ -180,29 +162,13 @@ func TestTaxiTimePolicy_Integration(t *testing.T) {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	world := newMockEventWorld(simStart, simEnd, []string{"09L", "09R"})
-	err = policy.GenerateEvents(context.Background(), world)
-	if err != nil {
-		t.Fatalf("GenerateEvents failed: %v", err)
-	}
-
-	// Verify the taxi time event exists
-	foundTaxiEvent := false
-	for _, evt := range world.events {
-		if evt.Type() == event.TaxiTimeAdjustmentType {
-			foundTaxiEvent = true
-
-			taxiEvt := evt.(*event.TaxiTimeAdjustmentEvent)
-			expectedOverhead := 15 * time.Minute // 7 + 8
-
-			if taxiEvt.TotalTaxiTimeOverhead() != expectedOverhead {
-				t.Errorf("Expected %v total overhead, got %v",
-					expectedOverhead, taxiEvt.TotalTaxiTimeOverhead())
-			}
-		}
+	world := newMockInitialStateWorld(simStart, simEnd)
+	if err := policy.SetInitialState(context.Background(), world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
 	}
 
-	if !foundTaxiEvent {
-		t.Error("Expected taxi time event to be generated")
+	expectedOverhead := 15 * time.Minute // 7 + 8
+	if world.taxiTimeOverhead != expectedOverhead {
+		t.Errorf("Expected %v total overhead, got %v", expectedOverhead, world.taxiTimeOverhead)
 	}
 }
@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Common errors for temperature policy validation
+var (
+	// ErrEmptyTemperatureSchedule indicates no temperature changes were provided
+	ErrEmptyTemperatureSchedule = simerrors.Invalidf("temperature schedule cannot be empty")
+
+	// ErrTemperatureScheduleNotChronological indicates temperature changes are not in time order
+	ErrTemperatureScheduleNotChronological = simerrors.Invalidf("temperature schedule must be in chronological order")
+)
+
+// Plausible outside air temperature range for airport operations in °C
+const (
+	MinTemperatureCelsius = -90.0
+	MaxTemperatureCelsius = 60.0
+)
+
+// TemperatureChange represents a discrete outside air temperature change at a specific time.
+type TemperatureChange struct {
+	Timestamp          time.Time // When this temperature takes effect
+	TemperatureCelsius float64   // Outside air temperature in °C
+}
+
+// TemperaturePolicy models time-varying outside air temperature. Hot air raises the density
+// altitude of every runway above its elevation, lengthening the runway aircraft need, so
+// aircraft classes in the airport's fleet mix whose length requirement is no longer met are
+// restricted off short runways (see airport.FleetMix.ServableMovementsAt) while it is hot.
+//
+// The policy has no effect unless the airport has a fleet mix with runway length requirements.
+// Until the first scheduled change, runways are assessed in the standard atmosphere.
+type TemperaturePolicy struct {
+	schedule []TemperatureChange
+}
+
+// NewTemperaturePolicy creates a new temperature policy with validation.
+// Returns an error if the schedule is empty, not chronological, or contains temperatures
+// outside MinTemperatureCelsius to MaxTemperatureCelsius.
+func NewTemperaturePolicy(schedule []TemperatureChange) (*TemperaturePolicy, error) {
+	if len(schedule) == 0 {
+		return nil, ErrEmptyTemperatureSchedule
+	}
+
+	for i, change := range schedule {
+		if change.TemperatureCelsius < MinTemperatureCelsius || change.TemperatureCelsius > MaxTemperatureCelsius {
+			return nil, simerrors.Invalidf("temperature change %d: temperature must be between %.0f and %.0f °C, got %f",
+				i, MinTemperatureCelsius, MaxTemperatureCelsius, change.TemperatureCelsius)
+		}
+		if i > 0 && !change.Timestamp.After(schedule[i-1].Timestamp) {
+			return nil, ErrTemperatureScheduleNotChronological
+		}
+	}
+
+	return &TemperaturePolicy{
+		schedule: schedule,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *TemperaturePolicy) Name() string {
+	return "TemperaturePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *TemperaturePolicy) Parameters() any {
+	return p.schedule
+}
+
+// SetInitialState sets the temperature of the latest change before the simulation start, so
+// the simulation starts at the scheduled temperature (see InitialStatePolicy).
+func (p *TemperaturePolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	startTime := world.GetStartTime()
+
+	var initial *TemperatureChange
+	for i, change := range p.schedule {
+		if !change.Timestamp.Before(startTime) {
+			break
+		}
+		initial = &p.schedule[i]
+	}
+	if initial == nil {
+		return nil
+	}
+	return world.SetTemperature(initial.TemperatureCelsius)
+}
+
+// GenerateEvents creates a TemperatureChangeEvent for each scheduled change within the
+// simulation period; the temperature of earlier changes is set by SetInitialState.
+func (p *TemperaturePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	for _, change := range p.schedule {
+		if change.Timestamp.Before(startTime) || change.Timestamp.After(endTime) {
+			continue
+		}
+		world.ScheduleEvent(event.NewTemperatureChangeEvent(change.TemperatureCelsius, change.Timestamp))
+	}
+
+	return nil
+}
+
+// GetSchedule returns a copy of the temperature schedule.
+func (p *TemperaturePolicy) GetSchedule() []TemperatureChange {
+	schedule := make([]TemperatureChange, len(p.schedule))
+	copy(schedule, p.schedule)
+	return schedule
+}
+
+// GetTemperatureAt returns the temperature of the most recent change at or before the given
+// time. The boolean is false if no change has occurred yet.
+func (p *TemperaturePolicy) GetTemperatureAt(timestamp time.Time) (float64, bool) {
+	temperature, known := 0.0, false
+	for _, change := range p.schedule {
+		if change.Timestamp.After(timestamp) {
+			break
+		}
+		temperature, known = change.TemperatureCelsius, true
+	}
+	return temperature, known
+}
+
+// DiurnalTemperaturePattern generates hourly temperatures following daily and seasonal cycles.
+// The daily cycle peaks at 15:00 and the seasonal cycle at the start of July (UTC); pass a
+// negative seasonal range for southern hemisphere airports.
+//
+// Parameters:
+//   - startTime: Time of the first sample
+//   - duration: Length of the generated schedule
+//   - annualMeanCelsius: Mean temperature over the year
+//   - seasonalRangeCelsius: Difference between the mean temperatures of the hottest and coldest days
+//   - dailyRangeCelsius: Difference between the daily maximum and minimum
+func DiurnalTemperaturePattern(startTime time.Time, duration time.Duration, annualMeanCelsius, seasonalRangeCelsius, dailyRangeCelsius float64) []TemperatureChange {
+	const (
+		dailyPeakHour   = 15
+		seasonalPeakDay = 182 // Day of year of the seasonal maximum (1 July)
+		daysPerYear     = 365.25
+	)
+
+	steps := int(duration / time.Hour)
+	changes := make([]TemperatureChange, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		t := startTime.Add(time.Duration(i) * time.Hour).UTC()
+		hour := float64(t.Hour()) + float64(t.Minute())/60
+		day := float64(t.YearDay()-1) + hour/24
+
+		seasonal := seasonalRangeCelsius / 2 * math.Cos(2*math.Pi*(day-seasonalPeakDay)/daysPerYear)
+		daily := dailyRangeCelsius / 2 * math.Cos(2*math.Pi*(hour-dailyPeakHour)/24)
+		changes = append(changes, TemperatureChange{
+			Timestamp:          startTime.Add(time.Duration(i) * time.Hour),
+			TemperatureCelsius: annualMeanCelsius + seasonal + daily,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewTemperaturePolicy(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		schedule    []TemperatureChange
+		expectError error
+	}{
+		{"valid schedule", []TemperatureChange{{start, 20}, {start.Add(time.Hour), 25}}, nil},
+		{"empty schedule", nil, ErrEmptyTemperatureSchedule},
+		{"not chronological", []TemperatureChange{{start.Add(time.Hour), 20}, {start, 25}}, ErrTemperatureScheduleNotChronological},
+		{"implausible temperature", []TemperatureChange{{start, 80}}, simerrors.ErrInvalidConfiguration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTemperaturePolicy(tt.schedule)
+			if tt.expectError == nil && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.expectError != nil && !errors.Is(err, tt.expectError) {
+				t.Errorf("Expected %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestTemperaturePolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.Add(24 * time.Hour)
+
+	policy, err := NewTemperaturePolicy([]TemperatureChange{
+		{simStart.Add(-time.Hour), 18}, // Before the simulation period
+		{simStart.Add(6 * time.Hour), 22},
+		{simStart.Add(15 * time.Hour), 35},
+		{simEnd.Add(time.Hour), 20}, // After the simulation period
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"09"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if got := world.CountEventsByType(event.TemperatureChangeType); got != 2 {
+		t.Fatalf("Expected 2 temperature change events, got %d", got)
+	}
+
+	// The change before the period sets the temperature at the start
+	initial := newMockInitialStateWorld(simStart, simEnd)
+	if err := policy.SetInitialState(context.Background(), initial); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
+	}
+	if !initial.temperatureSet || initial.temperature != 18 {
+		t.Errorf("Expected 18 °C set at the start, got %+v", initial)
+	}
+	last := world.GetEvents()[1].(*event.TemperatureChangeEvent)
+	if last.GetTemperature() != 35 || !last.Time().Equal(simStart.Add(15*time.Hour)) {
+		t.Errorf("Expected 35 °C at 15:00, got %.1f at %v", last.GetTemperature(), last.Time())
+	}
+
+	if temperature, known := policy.GetTemperatureAt(simStart.Add(16 * time.Hour)); !known || temperature != 35 {
+		t.Errorf("Expected 35 °C at 16:00, got %.1f (known %v)", temperature, known)
+	}
+	if _, known := policy.GetTemperatureAt(simStart.Add(-2 * time.Hour)); known {
+		t.Error("Expected no temperature before the first change")
+	}
+}
+
+func TestDiurnalTemperaturePattern(t *testing.T) {
+	start := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	changes := DiurnalTemperaturePattern(start, 24*time.Hour, 15, 20, 10)
+
+	if len(changes) != 25 {
+		t.Fatalf("Expected 25 hourly samples, got %d", len(changes))
+	}
+
+	// Near the seasonal peak: mean 15 + 10 seasonal, +5 at 15:00 and -5 at 03:00
+	if got := changes[15].TemperatureCelsius; math.Abs(got-30) > 0.1 {
+		t.Errorf("Expected about 30 °C at 15:00, got %.2f", got)
+	}
+	if got := changes[3].TemperatureCelsius; math.Abs(got-20) > 0.1 {
+		t.Errorf("Expected about 20 °C at 03:00, got %.2f", got)
+	}
+
+	if _, err := NewTemperaturePolicy(changes); err != nil {
+		t.Errorf("Expected generated pattern to be a valid schedule, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
+)
+
+// TemporarySeparationConfiguration defines daily hours in which runway separation is
+// increased, such as trainee controllers on position (09:00–12:00 on Tuesdays) or slow
+// operations around runway inspections, without modelling a different airport.
+type TemporarySeparationConfiguration struct {
+	Reason    string         // Why separation is increased (e.g., "trainee controllers")
+	StartTime time.Time      // Start of the period (only the time of day is used)
+	EndTime   time.Time      // End of the period (only the time of day is used; overnight hours wrap, and the same time as StartTime covers the whole day)
+	Weekdays  []time.Weekday // Days the period starts, in local time (empty = every day)
+	Runways   []string       // Runway designations affected (empty = every runway)
+	Factor    float64        // Multiplier applied to separation, greater than 1 (e.g., 1.25 = 25% more separation)
+}
+
+// TemporarySeparationPolicy increases runway separation during daily hours. Each affected
+// runway handles movements at its usual separation multiplied by the factor, so its capacity
+// is divided by the factor; overlapping periods multiply.
+type TemporarySeparationPolicy struct {
+	config     TemporarySeparationConfiguration
+	adjustment *event.SeparationAdjustment
+}
+
+// NewTemporarySeparationPolicy creates a new temporary separation policy with validation.
+// Returns an error if the factor is not greater than 1, a runway designation is empty or
+// repeated, or a weekday is unknown.
+func NewTemporarySeparationPolicy(config TemporarySeparationConfiguration) (*TemporarySeparationPolicy, error) {
+	if !(config.Factor > 1) {
+		return nil, simerrors.Invalidf("temporary separation factor must be greater than 1, got %f", config.Factor)
+	}
+	for i, runwayID := range config.Runways {
+		if runwayID == "" {
+			return nil, simerrors.Invalidf("temporary separation runway designation cannot be empty")
+		}
+		if slices.Contains(config.Runways[:i], runwayID) {
+			return nil, simerrors.Invalidf("duplicate temporary separation runway: %s", runwayID)
+		}
+	}
+	for _, day := range config.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, simerrors.Invalidf("unknown temporary separation weekday: %d", day)
+		}
+	}
+
+	config.Runways = slices.Clone(config.Runways)
+	config.Weekdays = slices.Clone(config.Weekdays)
+	return &TemporarySeparationPolicy{
+		config: config,
+		adjustment: &event.SeparationAdjustment{
+			Reason:  config.Reason,
+			Runways: config.Runways,
+			Factor:  config.Factor,
+		},
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *TemporarySeparationPolicy) Name() string {
+	return "TemporarySeparationPolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *TemporarySeparationPolicy) Parameters() any {
+	return p.config
+}
+
+// Validate checks that every affected runway is a runway at the airport.
+func (p *TemporarySeparationPolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.config.Runways)...)
+}
+
+// GenerateEvents generates adjustment start and end events for every day in the simulation
+// period on which the period starts. A period already in progress when the simulation starts
+// begins at the simulation start.
+func (p *TemporarySeparationPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	// Start one day early so a period running over the simulation start is included. Days are
+	// laid out in local time so periods follow daylight saving time changes.
+	loc := location(world)
+	var periods []timewin.Window
+	for _, period := range timewin.Daily(startTime.AddDate(0, 0, -1), endTime, p.config.StartTime, p.config.EndTime, loc) {
+		if len(p.config.Weekdays) == 0 || slices.Contains(p.config.Weekdays, period.Start.In(loc).Weekday()) {
+			periods = append(periods, period)
+		}
+	}
+
+	// Clip the periods to the simulation period
+	for _, period := range timewin.Clip(periods, timewin.Window{Start: startTime, End: endTime}) {
+		world.ScheduleEvent(event.NewSeparationAdjustmentStartEvent(p.adjustment, period.Start))
+		world.ScheduleEvent(event.NewSeparationAdjustmentEndEvent(p.adjustment, period.End))
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewTemporarySeparationPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		config  TemporarySeparationConfiguration
+		wantErr bool
+	}{
+		{"every runway", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Factor: 1.25}, false},
+		{"tuesdays", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Weekdays: []time.Weekday{time.Tuesday}, Runways: []string{"08"}, Factor: 1.25}, false},
+		{"no factor", TemporarySeparationConfiguration{StartTime: start, EndTime: end}, true},
+		{"reduced separation", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Factor: 0.9}, true},
+		{"unchanged separation", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Factor: 1}, true},
+		{"empty runway", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Runways: []string{""}, Factor: 1.25}, true},
+		{"duplicate runway", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Runways: []string{"08", "08"}, Factor: 1.25}, true},
+		{"unknown weekday", TemporarySeparationConfiguration{StartTime: start, EndTime: end, Weekdays: []time.Weekday{7}, Factor: 1.25}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTemporarySeparationPolicy(tt.config)
+			if tt.wantErr && !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTemporarySeparationPolicy_GenerateEvents(t *testing.T) {
+	// Trainee controllers 09:00-12:00 on Tuesdays
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p, err := NewTemporarySeparationPolicy(TemporarySeparationConfiguration{
+		Reason:    "trainee controllers",
+		StartTime: midnight.Add(9 * time.Hour),
+		EndTime:   midnight.Add(12 * time.Hour),
+		Weekdays:  []time.Weekday{time.Tuesday},
+		Factor:    1.25,
+	})
+	if err != nil {
+		t.Fatalf("NewTemporarySeparationPolicy: %v", err)
+	}
+
+	// 2024-01-01 is a Monday: two Tuesdays in 14 days
+	world := newMockEventWorld(midnight, midnight.AddDate(0, 0, 14), []string{"08", "26"})
+	if err := p.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents: %v", err)
+	}
+
+	want := []time.Time{midnight.Add(33 * time.Hour), midnight.Add(36 * time.Hour), midnight.Add(201 * time.Hour), midnight.Add(204 * time.Hour)}
+	events := world.GetEvents()
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, evt := range events {
+		wantType := event.SeparationAdjustmentStartType
+		if i%2 == 1 {
+			wantType = event.SeparationAdjustmentEndType
+		}
+		if evt.Type() != wantType || !evt.Time().Equal(want[i]) {
+			t.Errorf("Event %d: expected %v at %v, got %v at %v", i, wantType, want[i], evt.Type(), evt.Time())
+		}
+	}
+	if start, ok := events[0].(*event.SeparationAdjustmentStartEvent); !ok || start.Adjustment().Factor != 1.25 || start.Adjustment().Reason != "trainee controllers" {
+		t.Errorf("Expected the trainee adjustment, got %+v", events[0])
+	}
+}
+
+func TestTemporarySeparationPolicy_Validate(t *testing.T) {
+	p, err := NewTemporarySeparationPolicy(TemporarySeparationConfiguration{Runways: []string{"08", "99"}, Factor: 1.25})
+	if err != nil {
+		t.Fatalf("NewTemporarySeparationPolicy: %v", err)
+	}
+
+	world := newMockEventWorld(time.Now(), time.Now().Add(time.Hour), []string{"08", "26"})
+	var notFound *simerrors.RunwayNotFoundError
+	if err := p.Validate(world); !errors.As(err, &notFound) {
+		t.Errorf("Expected RunwayNotFoundError, got %v", err)
+	}
+}
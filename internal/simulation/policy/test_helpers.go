@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -70,3 +71,121 @@ func (m *mockEventWorld) CountEventsByType(eventType event.EventType) int {
 func (m *mockEventWorld) GetEvents() []event.Event {
 	return m.events
 }
+
+// mockInitialStateWorld is a mock InitialStateWorld recording the wind, temperature, noise
+// preferences, rotation multipliers, gate capacity, taxi time, landing performance and runway
+// changes set.
+// Calling any other world state method panics.
+type mockInitialStateWorld struct {
+	*mockEventWorld
+	event.WorldState
+
+	windSet       bool
+	windSpeed     float64
+	windGust      float64
+	windDirection float64
+
+	temperatureSet bool
+	temperature    float64
+
+	preferences [][]string
+
+	rotationSet               bool
+	rotationMultiplier        float32
+	runwayRotationMultipliers map[string]float32
+
+	gates      int
+	turnaround time.Duration
+
+	taxiTimeOverhead time.Duration
+
+	landingPerformance []airport.LandingPerformance
+	factorPerKnot      float64
+
+	closedRunways     []string
+	modifiedRunways   []string
+	compatibleRunways map[string][]string
+}
+
+// newMockInitialStateWorld creates a new mock initial state world
+func newMockInitialStateWorld(startTime, endTime time.Time) *mockInitialStateWorld {
+	return &mockInitialStateWorld{mockEventWorld: newMockEventWorld(startTime, endTime, nil)}
+}
+
+func (m *mockInitialStateWorld) SetWind(speed, direction float64) error {
+	return m.SetGustingWind(speed, 0, direction)
+}
+
+func (m *mockInitialStateWorld) SetGustingWind(speed, gust, direction float64) error {
+	m.windSet, m.windSpeed, m.windGust, m.windDirection = true, speed, gust, direction
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetTemperature(celsius float64) error {
+	m.temperatureSet, m.temperature = true, celsius
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetNoisePreferences(preferences [][]string, timestamp time.Time) error {
+	m.preferences = preferences
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetRotationMultiplier(multiplier float32) {
+	m.rotationSet, m.rotationMultiplier = true, multiplier
+}
+
+func (m *mockInitialStateWorld) SetRunwayRotationMultipliers(multipliers map[string]float32) error {
+	m.rotationSet, m.runwayRotationMultipliers = true, multipliers
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetGateCapacity(gates int, turnaround time.Duration) error {
+	m.gates, m.turnaround = gates, turnaround
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetTaxiTimeOverhead(overhead time.Duration) error {
+	m.taxiTimeOverhead = overhead
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetLandingPerformance(fleet []airport.LandingPerformance, factorPerKnot float64) error {
+	m.landingPerformance, m.factorPerKnot = fleet, factorPerKnot
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetRunwayAvailable(runwayID, reason string, available bool) error {
+	if !available {
+		m.closedRunways = append(m.closedRunways, runwayID)
+	}
+	return nil
+}
+
+func (m *mockInitialStateWorld) NotifyRunwayAvailabilityChange(runwayID string, available bool, timestamp time.Time) error {
+	return nil
+}
+
+func (m *mockInitialStateWorld) ModifyRunway(runwayID string, modification airport.RunwayModification, timestamp time.Time) error {
+	m.modifiedRunways = append(m.modifiedRunways, runwayID)
+	return nil
+}
+
+func (m *mockInitialStateWorld) SetRunwayCompatibility(runwayID string, compatibleWith []string, timestamp time.Time) error {
+	if m.compatibleRunways == nil {
+		m.compatibleRunways = make(map[string][]string)
+	}
+	m.compatibleRunways[runwayID] = compatibleWith
+	return nil
+}
+
+// eventsAt returns the events scheduled at t.
+func (m *mockEventWorld) eventsAt(t time.Time) []event.Event {
+	var events []event.Event
+	for _, evt := range m.events {
+		if evt.Time().Equal(t) {
+			events = append(events, evt)
+		}
+	}
+	return events
+}
@@ -0,0 +1,200 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Common errors for tide policy validation
+var (
+	// ErrEmptyTideSchedule indicates no tide levels were provided
+	ErrEmptyTideSchedule = simerrors.Invalidf("tide schedule cannot be empty")
+
+	// ErrTideScheduleNotChronological indicates tide levels are not in time order
+	ErrTideScheduleNotChronological = simerrors.Invalidf("tide schedule must be in chronological order")
+)
+
+// TideLevel represents an observed or predicted water level at a specific time.
+type TideLevel struct {
+	Timestamp    time.Time // When this level applies
+	HeightMeters float64   // Tide height relative to chart datum in meters
+}
+
+// TideSchedule defines tide-dependent availability for water runways.
+type TideSchedule struct {
+	RunwayDesignations      []string    // Water runway identifiers affected by the tide
+	MinimumTideHeightMeters float64     // Minimum tide height required for operations
+	Levels                  []TideLevel // Tide levels in chronological order
+}
+
+// TidePolicy models seaplane operating areas that are only usable when the tide is high enough.
+// Tide levels are linearly interpolated between samples to find the exact times the level
+// crosses the minimum operating height, and restriction events are generated at each crossing.
+// Before the first sample and after the last sample the nearest sample's level is assumed.
+type TidePolicy struct {
+	schedule TideSchedule
+}
+
+// NewTidePolicy creates a new tide policy with validation.
+// Returns an error if the schedule is empty, not chronological, or names no runways.
+func NewTidePolicy(schedule TideSchedule) (*TidePolicy, error) {
+	if len(schedule.RunwayDesignations) == 0 {
+		return nil, simerrors.Invalidf("tide schedule must name at least one runway")
+	}
+	if len(schedule.Levels) == 0 {
+		return nil, ErrEmptyTideSchedule
+	}
+	for i := 1; i < len(schedule.Levels); i++ {
+		if !schedule.Levels[i].Timestamp.After(schedule.Levels[i-1].Timestamp) {
+			return nil, ErrTideScheduleNotChronological
+		}
+	}
+
+	return &TidePolicy{
+		schedule: schedule,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *TidePolicy) Name() string {
+	return "TidePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *TidePolicy) Parameters() any {
+	return p.schedule
+}
+
+// Validate checks that every tide-dependent runway exists.
+func (p *TidePolicy) Validate(world EventWorld) error {
+	return errors.Join(unknownRunways(world, p.Name(), p.schedule.RunwayDesignations)...)
+}
+
+// GenerateEvents generates tide restriction start and end events for each water runway
+// whenever the tide crosses the minimum operating height within the simulation period.
+func (p *TidePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	startTime := world.GetStartTime()
+	endTime := world.GetEndTime()
+
+	allRunwayIDs := world.GetRunwayIDs()
+	for _, runwayDesignation := range p.schedule.RunwayDesignations {
+		if !slices.Contains(allRunwayIDs, runwayDesignation) {
+			return &simerrors.RunwayNotFoundError{RunwayID: runwayDesignation}
+		}
+	}
+
+	for _, window := range p.RestrictedWindows(startTime, endTime) {
+		for _, runwayDesignation := range p.schedule.RunwayDesignations {
+			world.ScheduleEvent(event.NewTideRestrictionStartEvent(runwayDesignation, window.Start))
+			if window.End.Before(endTime) {
+				world.ScheduleEvent(event.NewTideRestrictionEndEvent(runwayDesignation, window.End))
+			}
+		}
+	}
+
+	return nil
+}
+
+// RestrictedWindows returns the periods within [startTime, endTime] during which the tide
+// is below the minimum operating height. A window still open at endTime ends at endTime.
+func (p *TidePolicy) RestrictedWindows(startTime, endTime time.Time) []TimeWindow {
+	windows := []TimeWindow{}
+	minHeight := p.schedule.MinimumTideHeightMeters
+
+	restricted := p.HeightAt(startTime) < minHeight
+	windowStart := startTime
+
+	levels := p.schedule.Levels
+	for i := 1; i < len(levels); i++ {
+		prev, next := levels[i-1], levels[i]
+		if !next.Timestamp.After(startTime) || prev.Timestamp.After(endTime) {
+			continue
+		}
+
+		prevBelow := prev.HeightMeters < minHeight
+		nextBelow := next.HeightMeters < minHeight
+		if prevBelow == nextBelow {
+			continue
+		}
+
+		crossing := interpolateCrossing(prev, next, minHeight)
+		if crossing.Before(startTime) || crossing.After(endTime) {
+			continue
+		}
+
+		if nextBelow && !restricted {
+			windowStart = crossing
+			restricted = true
+		} else if !nextBelow && restricted {
+			windows = append(windows, TimeWindow{Start: windowStart, End: crossing})
+			restricted = false
+		}
+	}
+
+	if restricted {
+		windows = append(windows, TimeWindow{Start: windowStart, End: endTime})
+	}
+
+	return windows
+}
+
+// HeightAt returns the linearly interpolated tide height at the given time.
+func (p *TidePolicy) HeightAt(timestamp time.Time) float64 {
+	levels := p.schedule.Levels
+	if !timestamp.After(levels[0].Timestamp) {
+		return levels[0].HeightMeters
+	}
+
+	for i := 1; i < len(levels); i++ {
+		prev, next := levels[i-1], levels[i]
+		if timestamp.After(next.Timestamp) {
+			continue
+		}
+		fraction := float64(timestamp.Sub(prev.Timestamp)) / float64(next.Timestamp.Sub(prev.Timestamp))
+		return prev.HeightMeters + fraction*(next.HeightMeters-prev.HeightMeters)
+	}
+
+	return levels[len(levels)-1].HeightMeters
+}
+
+// interpolateCrossing returns the time between two tide samples at which the level equals height.
+func interpolateCrossing(prev, next TideLevel, height float64) time.Time {
+	delta := next.HeightMeters - prev.HeightMeters
+	if delta == 0 {
+		return prev.Timestamp
+	}
+	fraction := (height - prev.HeightMeters) / delta
+	offset := time.Duration(math.Round(fraction * float64(next.Timestamp.Sub(prev.Timestamp))))
+	return prev.Timestamp.Add(offset)
+}
+
+// SemiDiurnalTidePattern generates hourly tide levels following a simple semi-diurnal cycle
+// (two high and two low tides per lunar day, period 12h25m).
+//
+// Parameters:
+//   - startTime: Time of the first sample (a high tide occurs at startTime + phase)
+//   - duration: Length of the generated schedule
+//   - meanLevelMeters: Mean sea level relative to chart datum
+//   - amplitudeMeters: Half the tidal range
+//   - phase: Offset of the first high tide from startTime
+func SemiDiurnalTidePattern(startTime time.Time, duration time.Duration, meanLevelMeters, amplitudeMeters float64, phase time.Duration) []TideLevel {
+	const tidalPeriod = 12*time.Hour + 25*time.Minute
+
+	steps := int(duration / time.Hour)
+	levels := make([]TideLevel, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		t := startTime.Add(time.Duration(i) * time.Hour)
+		angle := 2 * math.Pi * float64(t.Sub(startTime)-phase) / float64(tidalPeriod)
+		levels = append(levels, TideLevel{
+			Timestamp:    t,
+			HeightMeters: meanLevelMeters + amplitudeMeters*math.Cos(angle),
+		})
+	}
+	return levels
+}
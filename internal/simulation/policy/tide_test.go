@@ -0,0 +1,183 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestNewTidePolicy(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		schedule    TideSchedule
+		expectedErr error
+		expectError bool
+	}{
+		{
+			name: "valid schedule",
+			schedule: TideSchedule{
+				RunwayDesignations:      []string{"W1"},
+				MinimumTideHeightMeters: 1.0,
+				Levels: []TideLevel{
+					{Timestamp: base, HeightMeters: 2.0},
+					{Timestamp: base.Add(6 * time.Hour), HeightMeters: 0.5},
+				},
+			},
+		},
+		{
+			name: "no runways",
+			schedule: TideSchedule{
+				Levels: []TideLevel{{Timestamp: base, HeightMeters: 2.0}},
+			},
+			expectError: true,
+		},
+		{
+			name:        "empty levels",
+			schedule:    TideSchedule{RunwayDesignations: []string{"W1"}},
+			expectedErr: ErrEmptyTideSchedule,
+			expectError: true,
+		},
+		{
+			name: "not chronological",
+			schedule: TideSchedule{
+				RunwayDesignations: []string{"W1"},
+				Levels: []TideLevel{
+					{Timestamp: base.Add(time.Hour), HeightMeters: 2.0},
+					{Timestamp: base, HeightMeters: 1.0},
+				},
+			},
+			expectedErr: ErrTideScheduleNotChronological,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTidePolicy(tt.schedule)
+			if tt.expectError && err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestTidePolicy_HeightAt(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy, err := NewTidePolicy(TideSchedule{
+		RunwayDesignations: []string{"W1"},
+		Levels: []TideLevel{
+			{Timestamp: base, HeightMeters: 2.0},
+			{Timestamp: base.Add(4 * time.Hour), HeightMeters: 0.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	tests := []struct {
+		offset   time.Duration
+		expected float64
+	}{
+		{-time.Hour, 2.0},
+		{0, 2.0},
+		{time.Hour, 1.5},
+		{2 * time.Hour, 1.0},
+		{4 * time.Hour, 0.0},
+		{10 * time.Hour, 0.0},
+	}
+
+	for _, tt := range tests {
+		got := policy.HeightAt(base.Add(tt.offset))
+		if math.Abs(got-tt.expected) > 0.0001 {
+			t.Errorf("At %v expected %.2fm, got %.2fm", tt.offset, tt.expected, got)
+		}
+	}
+}
+
+func TestTidePolicy_GenerateEvents(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.Add(24 * time.Hour)
+
+	// Falls through 1.0m at 02:00, rises through 1.0m at 10:00, falls again at 18:00 (open at end)
+	policy, err := NewTidePolicy(TideSchedule{
+		RunwayDesignations:      []string{"W1"},
+		MinimumTideHeightMeters: 1.0,
+		Levels: []TideLevel{
+			{Timestamp: simStart, HeightMeters: 2.0},
+			{Timestamp: simStart.Add(4 * time.Hour), HeightMeters: 0.0},
+			{Timestamp: simStart.Add(8 * time.Hour), HeightMeters: 0.0},
+			{Timestamp: simStart.Add(12 * time.Hour), HeightMeters: 2.0},
+			{Timestamp: simStart.Add(16 * time.Hour), HeightMeters: 2.0},
+			{Timestamp: simStart.Add(20 * time.Hour), HeightMeters: 0.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	world := newMockEventWorld(simStart, simEnd, []string{"W1", "09"})
+	if err := policy.GenerateEvents(context.Background(), world); err != nil {
+		t.Fatalf("GenerateEvents failed: %v", err)
+	}
+
+	if got := world.CountEventsByType(event.TideRestrictionStartType); got != 2 {
+		t.Errorf("Expected 2 restriction start events, got %d", got)
+	}
+	if got := world.CountEventsByType(event.TideRestrictionEndType); got != 1 {
+		t.Errorf("Expected 1 restriction end event, got %d", got)
+	}
+
+	expected := map[time.Time]event.EventType{
+		simStart.Add(2 * time.Hour):  event.TideRestrictionStartType,
+		simStart.Add(10 * time.Hour): event.TideRestrictionEndType,
+		simStart.Add(18 * time.Hour): event.TideRestrictionStartType,
+	}
+	for _, evt := range world.GetEvents() {
+		want, ok := expected[evt.Time()]
+		if !ok || want != evt.Type() {
+			t.Errorf("Unexpected %s event at %v", evt.Type(), evt.Time())
+		}
+	}
+}
+
+func TestTidePolicy_GenerateEvents_UnknownRunway(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy, _ := NewTidePolicy(TideSchedule{
+		RunwayDesignations: []string{"W9"},
+		Levels:             []TideLevel{{Timestamp: simStart, HeightMeters: 2.0}},
+	})
+
+	world := newMockEventWorld(simStart, simStart.Add(24*time.Hour), []string{"W1"})
+	if err := policy.GenerateEvents(context.Background(), world); err == nil {
+		t.Error("Expected error for unknown runway")
+	}
+}
+
+func TestSemiDiurnalTidePattern(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	levels := SemiDiurnalTidePattern(start, 24*time.Hour, 2.0, 1.5, 0)
+
+	if len(levels) != 25 {
+		t.Fatalf("Expected 25 hourly samples, got %d", len(levels))
+	}
+	if math.Abs(levels[0].HeightMeters-3.5) > 0.0001 {
+		t.Errorf("Expected high tide of 3.5m at start, got %.2fm", levels[0].HeightMeters)
+	}
+	for _, level := range levels {
+		if level.HeightMeters < 0.5-0.0001 || level.HeightMeters > 3.5+0.0001 {
+			t.Errorf("Level %.2fm outside tidal range", level.HeightMeters)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
+)
+
+// TimeWindow represents a time period (see timewin.Window).
+type TimeWindow = timewin.Window
+
+// DailyWindows returns the window between the clock times of from and to on every day from
+// the date of startTime until endTime, laid out in loc (see timewin.Daily).
+func DailyWindows(startTime, endTime, from, to time.Time, loc *time.Location) []TimeWindow {
+	return timewin.Daily(startTime, endTime, from, to, loc)
+}
+
+// WeekdayWindows returns the whole days within [startTime, endTime) that fall on one of the
+// given weekdays in loc (see timewin.Weekdays).
+func WeekdayWindows(startTime, endTime time.Time, loc *time.Location, days ...time.Weekday) []TimeWindow {
+	return timewin.Weekdays(startTime, endTime, loc, days...)
+}
+
+// Union returns the periods covered by any of the windows (OR), sorted and merged (see
+// timewin.Merge).
+func Union(windows ...[]TimeWindow) []TimeWindow {
+	return timewin.Merge(windows...)
+}
+
+// Intersect returns the periods covered by at least one window of every set (AND), sorted
+// and merged (see timewin.Intersect).
+func Intersect(sets ...[]TimeWindow) []TimeWindow {
+	return timewin.Intersect(sets...)
+}
+
+// Subtract returns the periods of windows not covered by any of the excluded windows
+// (AND NOT), sorted and merged (see timewin.Subtract).
+func Subtract(windows, excluded []TimeWindow) []TimeWindow {
+	return timewin.Subtract(windows, excluded)
+}
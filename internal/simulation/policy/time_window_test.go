@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestUnion(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	got := Union(
+		[]TimeWindow{{Start: at(4), End: at(6)}, {Start: at(0), End: at(2)}},
+		[]TimeWindow{{Start: at(1), End: at(3)}, {Start: at(6), End: at(7)}, {Start: at(9), End: at(9)}},
+	)
+	// Overlapping and touching windows merge; the empty window is dropped
+	want := []TimeWindow{{Start: at(0), End: at(3)}, {Start: at(4), End: at(7)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Union = %v, expected %v", got, want)
+	}
+
+	if got := Union(); len(got) != 0 {
+		t.Errorf("Expected no windows from an empty union, got %v", got)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	got := Intersect(
+		[]TimeWindow{{Start: at(0), End: at(5)}, {Start: at(8), End: at(12)}},
+		[]TimeWindow{{Start: at(3), End: at(10)}, {Start: at(11), End: at(20)}},
+		[]TimeWindow{{Start: at(0), End: at(24)}},
+	)
+	want := []TimeWindow{{Start: at(3), End: at(5)}, {Start: at(8), End: at(10)}, {Start: at(11), End: at(12)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Intersect = %v, expected %v", got, want)
+	}
+
+	// Windows that only touch have no common period
+	if got := Intersect([]TimeWindow{{Start: at(0), End: at(2)}}, []TimeWindow{{Start: at(2), End: at(4)}}); len(got) != 0 {
+		t.Errorf("Expected no intersection of touching windows, got %v", got)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	got := Subtract(
+		[]TimeWindow{{Start: at(0), End: at(10)}, {Start: at(12), End: at(14)}},
+		[]TimeWindow{{Start: at(2), End: at(3)}, {Start: at(5), End: at(13)}},
+	)
+	want := []TimeWindow{{Start: at(0), End: at(2)}, {Start: at(3), End: at(5)}, {Start: at(13), End: at(14)}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Subtract = %v, expected %v", got, want)
+	}
+}
+
+func TestDailyAndWeekdayWindows(t *testing.T) {
+	// 2024-01-05 is a Friday
+	start := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 4)
+
+	curfews := DailyWindows(start, end,
+		time.Date(0, 1, 1, 23, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 6, 0, 0, 0, time.UTC), time.UTC)
+	if len(curfews) != 4 {
+		t.Fatalf("Expected 4 overnight curfews, got %v", curfews)
+	}
+	if first := curfews[0]; !first.Start.Equal(start.Add(23*time.Hour)) || first.Duration() != 7*time.Hour {
+		t.Errorf("Expected the first curfew from 23:00 for 7 hours, got %v", first)
+	}
+
+	weekend := WeekdayWindows(start, end, time.UTC, time.Saturday, time.Sunday)
+	want := []TimeWindow{{Start: start.AddDate(0, 0, 1), End: start.AddDate(0, 0, 3)}}
+	if !slices.Equal(weekend, want) {
+		t.Fatalf("WeekdayWindows = %v, expected %v", weekend, want)
+	}
+
+	// Curfews on weekend days: from Saturday 00:00 to Monday 00:00, clipped from the curfews
+	// of Friday to Sunday nights
+	got := Intersect(curfews, weekend)
+	wantCurfews := []TimeWindow{
+		{Start: start.AddDate(0, 0, 1), End: start.AddDate(0, 0, 1).Add(6 * time.Hour)},
+		{Start: start.AddDate(0, 0, 1).Add(23 * time.Hour), End: start.AddDate(0, 0, 2).Add(6 * time.Hour)},
+		{Start: start.AddDate(0, 0, 2).Add(23 * time.Hour), End: start.AddDate(0, 0, 3)},
+	}
+	if !slices.Equal(got, wantCurfews) {
+		t.Errorf("Weekend curfews = %v, expected %v", got, wantCurfews)
+	}
+	if !got[1].Contains(start.AddDate(0, 0, 2)) || got[1].Contains(got[1].End) {
+		t.Errorf("Expected the Saturday night curfew to contain Sunday midnight but not its end")
+	}
+}
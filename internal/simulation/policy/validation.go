@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// ValidatingPolicy is implemented by policies that can check their configuration against
+// the airport and simulation period before any events are generated. Validate returns all
+// problems found (see errors.Join), or nil if the policy is consistent with the world.
+type ValidatingPolicy interface {
+	Validate(world EventWorld) error
+}
+
+// unknownRunways returns an error for each designation that is not a runway at the airport.
+func unknownRunways(world EventWorld, policyName string, designations []string) []error {
+	runwayIDs := world.GetRunwayIDs()
+
+	var problems []error
+	for _, designation := range designations {
+		if !slices.Contains(runwayIDs, designation) {
+			problems = append(problems, fmt.Errorf("%s: %w", policyName, &simerrors.RunwayNotFoundError{RunwayID: designation}))
+		}
+	}
+	return problems
+}
+
+// RunwayEndWorld is implemented by worlds that expose the designations of every runway end,
+// for airports whose runway ends are given explicitly (see airport.Runway.End).
+type RunwayEndWorld interface {
+	GetRunwayEnds() []string
+}
+
+// unknownRunwayEnds returns an error for each runway end that does not belong to a runway
+// at the airport in either direction.
+func unknownRunwayEnds(world EventWorld, policyName string, runwayEnds []string) []error {
+	validEnds := make(map[string]bool)
+	if ew, ok := world.(RunwayEndWorld); ok {
+		for _, end := range ew.GetRunwayEnds() {
+			validEnds[end] = true
+		}
+	} else {
+		for _, id := range world.GetRunwayIDs() {
+			validEnds[id] = true
+			validEnds[airport.ReciprocalDesignation(id)] = true
+		}
+	}
+
+	var problems []error
+	for _, end := range runwayEnds {
+		if !validEnds[end] {
+			problems = append(problems, fmt.Errorf("%s: %w", policyName, &simerrors.RunwayNotFoundError{RunwayID: end}))
+		}
+	}
+	return problems
+}
+
+// outsideSimulation reports whether t falls outside the simulation period.
+func outsideSimulation(world EventWorld, t time.Time) bool {
+	return t.Before(world.GetStartTime()) || !t.Before(world.GetEndTime())
+}
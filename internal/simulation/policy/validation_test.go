@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockEventWorld(simStart, simStart.AddDate(1, 0, 0), []string{"09L", "18"})
+
+	mustRunwayCurfew := func(ends ...string) ValidatingPolicy {
+		p, err := NewRunwayCurfewPolicy(RunwayCurfewConfiguration{
+			StartTime:  time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			EndTime:    time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+			RunwayEnds: ends,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create policy: %v", err)
+		}
+		return p
+	}
+	mustCurfew := func(start time.Time) ValidatingPolicy {
+		p, err := NewCurfewPolicy(start, start.Add(7*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to create policy: %v", err)
+		}
+		return p
+	}
+	mustCalendar := func(date time.Time) ValidatingPolicy {
+		p, err := NewCalendarPolicy([]CalendarOverride{{Name: "Holiday", Date: date, Kind: CalendarClosure}})
+		if err != nil {
+			t.Fatalf("Failed to create policy: %v", err)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name        string
+		policy      ValidatingPolicy
+		expectError bool
+	}{
+		{"maintenance on known runway", NewMaintenancePolicy(MaintenanceSchedule{RunwayDesignations: []string{"09L"}, Duration: 8 * time.Hour, Frequency: 30 * 24 * time.Hour}), false},
+		{"maintenance on unknown runway", NewMaintenancePolicy(MaintenanceSchedule{RunwayDesignations: []string{"04"}, Duration: 8 * time.Hour, Frequency: 30 * 24 * time.Hour}), true},
+		{"maintenance without frequency", NewMaintenancePolicy(MaintenanceSchedule{RunwayDesignations: []string{"09L"}, Duration: 8 * time.Hour}), true},
+		{"runway curfew on reciprocal end", mustRunwayCurfew("27R"), false},
+		{"runway curfew on unknown end", mustRunwayCurfew("04"), true},
+		{"curfew within simulation", mustCurfew(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)), false},
+		{"curfew outside simulation", mustCurfew(time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)), true},
+		{"calendar within simulation", mustCalendar(time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)), false},
+		{"calendar outside simulation", mustCalendar(time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(world)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConstructorErrorsAreInvalidConfiguration(t *testing.T) {
+	_, gateErr := NewGateCapacityPolicy(GateCapacityConstraint{TotalGates: 0, AverageTurnaroundTime: time.Hour})
+	_, curfewErr := NewCurfewPolicy(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	_, windErr := NewScheduledWindPolicy([]WindChange{{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), SpeedKnots: -1}})
+
+	for name, err := range map[string]error{"gate capacity": gateErr, "curfew": curfewErr, "scheduled wind": windErr} {
+		if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected ErrInvalidConfiguration, got %v", name, err)
+		}
+	}
+	if !errors.Is(curfewErr, ErrInvalidCurfewTime) {
+		t.Errorf("Expected ErrInvalidCurfewTime, got %v", curfewErr)
+	}
+	if !errors.Is(windErr, ErrInvalidWindSpeed) {
+		t.Errorf("Expected wrapped ErrInvalidWindSpeed, got %v", windErr)
+	}
+}
@@ -2,33 +2,28 @@ package policy
 
 import (
 	"context"
-	"errors"
 	"math"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 )
 
 // Common errors for wind policy validation
 var (
 	// ErrInvalidWindSpeed indicates the wind speed is invalid
-	ErrInvalidWindSpeed = errors.New("wind speed cannot be negative")
+	ErrInvalidWindSpeed = simerrors.Invalidf("wind speed cannot be negative")
 
 	// ErrInvalidWindDirection indicates the wind direction is invalid
-	ErrInvalidWindDirection = errors.New("wind direction must be between 0 and 360 degrees")
+	ErrInvalidWindDirection = simerrors.Invalidf("wind direction must be between 0 and 360 degrees")
 )
 
-// WorldState defines the interface for policies to modify world state.
-// This allows the WindPolicy to set wind conditions in the simulation world.
-type WorldState interface {
-	SetWind(speed, direction float64) error
-}
-
 // WindPolicy models wind conditions that affect runway usability.
 // Wind determines which runways can operate based on crosswind and tailwind limits.
 //
 // For static wind (current implementation), the wind conditions remain constant
 // throughout the simulation. Future enhancements may add time-varying wind.
 type WindPolicy struct {
-	speedKnots     float64 // Wind speed in knots
-	directionTrue  float64 // Wind direction in degrees true (0-360, where 0/360 = north, 90 = east, etc.)
+	speedKnots    float64 // Wind speed in knots
+	directionTrue float64 // Wind direction in degrees true (0-360, where 0/360 = north, 90 = east, etc.)
 }
 
 // NewWindPolicy creates a new wind policy with validation.
@@ -57,23 +52,22 @@ func (p *WindPolicy) Name() string {
 	return "WindPolicy"
 }
 
-// GenerateEvents sets the initial wind state in the world.
-// For static wind, no events are generated - the wind remains constant.
-func (p *WindPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
-	// Type assert to get access to SetWind method
-	worldState, ok := world.(WorldState)
-	if !ok {
-		return errors.New("world does not implement WorldState interface")
-	}
-
-	// Set the initial wind conditions
-	if err := worldState.SetWind(p.speedKnots, p.directionTrue); err != nil {
-		return err
-	}
+// Parameters returns the policy configuration.
+func (p *WindPolicy) Parameters() any {
+	return struct {
+		SpeedKnots    float64 `json:"speedKnots"`
+		DirectionTrue float64 `json:"directionTrue"`
+	}{p.speedKnots, p.directionTrue}
+}
 
-	// For static wind, no events are scheduled
-	// Future: Could schedule WindChangeEvents for time-varying wind here
+// SetInitialState sets the wind the simulation starts in (see InitialStatePolicy).
+func (p *WindPolicy) SetInitialState(ctx context.Context, world InitialStateWorld) error {
+	return world.SetWind(p.speedKnots, p.directionTrue)
+}
 
+// GenerateEvents generates no events: static wind is set as initial state by SetInitialState
+// and remains constant.
+func (p *WindPolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
 	return nil
 }
 
@@ -102,10 +96,11 @@ func (p *WindPolicy) GetDirection() float64 {
 //   - crosswind: Component perpendicular to runway (always positive) in knots
 //
 // Example:
-//   Runway 09 (bearing 090°), Wind 120° at 20kt
-//   Angle difference = 30°
-//   Headwind = 20 * cos(30°) = 17.3kt (headwind)
-//   Crosswind = 20 * |sin(30°)| = 10.0kt
+//
+//	Runway 09 (bearing 090°), Wind 120° at 20kt
+//	Angle difference = 30°
+//	Headwind = 20 * cos(30°) = 17.3kt (headwind)
+//	Crosswind = 20 * |sin(30°)| = 10.0kt
 func CalculateWindComponents(runwayBearing, windSpeed, windDirection float64) (headwind, crosswind float64) {
 	// Calculate the angle between runway and wind direction
 	// Wind direction is where wind comes FROM, so we use it directly
@@ -1,9 +1,10 @@
 package policy
 
 import (
-	"fmt"
 	"math"
 	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 )
 
 // DiurnalWindPattern generates a realistic daily wind pattern with morning calm,
@@ -118,7 +119,7 @@ func FrontalPassagePattern(passageTime time.Time, preFrontalSpeed, preFrontalDir
 // Note: Direction transitions always take the shortest angular path (e.g., 350° to 10° goes through 360°, not backwards through 180°).
 func LinearWindTransition(startTime time.Time, duration time.Duration, steps int, initialSpeed, initialDirection, finalSpeed, finalDirection float64) ([]WindChange, error) {
 	if steps < 2 {
-		return nil, fmt.Errorf("steps must be at least 2, got %d", steps)
+		return nil, simerrors.Invalidf("steps must be at least 2, got %d", steps)
 	}
 
 	schedule := make([]WindChange, steps)
@@ -253,9 +253,9 @@ func TestSeasonalWindPattern(t *testing.T) {
 		speed     float64
 		direction float64
 	}{
-		{time.January, 1, 15, 270},   // Winter
-		{time.March, 20, 10, 180},    // Spring
-		{time.June, 21, 5, 90},       // Summer
+		{time.January, 1, 15, 270},    // Winter
+		{time.March, 20, 10, 180},     // Spring
+		{time.June, 21, 5, 90},        // Summer
 		{time.September, 22, 12, 225}, // Fall
 	}
 
@@ -283,13 +283,13 @@ func TestSeasonalWindPattern(t *testing.T) {
 // TestCombineWindSchedules tests combining multiple wind schedules
 func TestCombineWindSchedules(t *testing.T) {
 	schedule1 := []WindChange{
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 10, 90},
-		{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), 15, 180},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 10, DirectionTrue: 90},
+		{Timestamp: time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), SpeedKnots: 15, DirectionTrue: 180},
 	}
 
 	schedule2 := []WindChange{
-		{time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), 5, 270},
-		{time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC), 20, 270},
+		{Timestamp: time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 270},
+		{Timestamp: time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC), SpeedKnots: 20, DirectionTrue: 270},
 	}
 
 	combined := CombineWindSchedules(schedule1, schedule2)
@@ -312,7 +312,7 @@ func TestCombineWindSchedules(t *testing.T) {
 // TestCombineWindSchedulesEmpty tests combining with empty schedules
 func TestCombineWindSchedulesEmpty(t *testing.T) {
 	schedule1 := []WindChange{
-		{time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), 10, 90},
+		{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), SpeedKnots: 10, DirectionTrue: 90},
 	}
 
 	combined := CombineWindSchedules(schedule1, []WindChange{}, nil)
@@ -0,0 +1,204 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// WindRose is the climatological frequency of wind by direction and speed, as published for
+// an aerodrome (e.g., from an ICAO Annex 14 usability study or a METAR climatology).
+//
+// Directions are divided into equal sectors centred on north: with 16 sectors, sector 0
+// covers 348.75°-11.25°, sector 1 covers 11.25°-33.75°, and so on clockwise. Speeds are
+// divided into bins by SpeedBinEdges; bin i covers [SpeedBinEdges[i], SpeedBinEdges[i+1]).
+// Frequencies need not sum to one; they are normalized when sampling.
+type WindRose struct {
+	SpeedBinEdges []float64   // Ascending speed bin edges in knots (at least two)
+	Frequencies   [][]float64 // Frequency per direction sector (outer) and speed bin (inner)
+	Calm          float64     // Frequency of calm wind (reported as 0 knots)
+}
+
+// Validate checks that the rose has at least one sector, ascending non-negative speed bin
+// edges, a frequency for every bin, no negative frequencies, and a positive total.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (r WindRose) Validate() error {
+	var problems []error
+
+	if len(r.SpeedBinEdges) < 2 {
+		problems = append(problems, simerrors.Invalidf("wind rose needs at least two speed bin edges, got %d", len(r.SpeedBinEdges)))
+	}
+	for i, edge := range r.SpeedBinEdges {
+		if edge < 0 || (i > 0 && edge <= r.SpeedBinEdges[i-1]) {
+			problems = append(problems, simerrors.Invalidf("wind rose speed bin edges must be non-negative and ascending, got %v", r.SpeedBinEdges))
+			break
+		}
+	}
+	if len(r.Frequencies) == 0 {
+		problems = append(problems, simerrors.Invalidf("wind rose needs at least one direction sector"))
+	}
+
+	total := r.Calm
+	if r.Calm < 0 {
+		problems = append(problems, simerrors.Invalidf("wind rose calm frequency must not be negative, got %g", r.Calm))
+	}
+	for sector, bins := range r.Frequencies {
+		if len(bins) != len(r.SpeedBinEdges)-1 {
+			problems = append(problems, simerrors.Invalidf("wind rose sector %d has %d speed bins, expected %d", sector, len(bins), len(r.SpeedBinEdges)-1))
+		}
+		for _, frequency := range bins {
+			if frequency < 0 {
+				problems = append(problems, simerrors.Invalidf("wind rose sector %d has a negative frequency %g", sector, frequency))
+			}
+			total += frequency
+		}
+	}
+	if total <= 0 {
+		problems = append(problems, simerrors.Invalidf("wind rose frequencies must sum to a positive total"))
+	}
+
+	return errors.Join(problems...)
+}
+
+// sectorWidth returns the width of each direction sector in degrees.
+func (r WindRose) sectorWidth() float64 {
+	return 360 / float64(len(r.Frequencies))
+}
+
+// Sample draws a wind from the rose: a sector and speed bin chosen by frequency, with the
+// direction and speed uniform within them. The rose must be valid.
+func (r WindRose) Sample(rng *rand.Rand) (speedKnots, directionTrue float64) {
+	total := r.Calm
+	for _, bins := range r.Frequencies {
+		for _, frequency := range bins {
+			total += frequency
+		}
+	}
+
+	x := rng.Float64() * total
+	if x < r.Calm {
+		return 0, 0
+	}
+	x -= r.Calm
+
+	width := r.sectorWidth()
+	for sector, bins := range r.Frequencies {
+		for bin, frequency := range bins {
+			if x >= frequency {
+				x -= frequency
+				continue
+			}
+			low, high := r.SpeedBinEdges[bin], r.SpeedBinEdges[bin+1]
+			speedKnots = low + rng.Float64()*(high-low)
+			directionTrue = float64(sector)*width - width/2 + rng.Float64()*width
+			if directionTrue < 0 {
+				directionTrue += 360
+			}
+			return speedKnots, directionTrue
+		}
+	}
+
+	// Only reachable through floating point rounding at the very top of the range
+	return r.SpeedBinEdges[len(r.SpeedBinEdges)-1], 0
+}
+
+// GenerateSchedule samples a wind schedule over [start, end) with a wind drawn every interval.
+// At each interval the wind persists unchanged with probability persistence, and is otherwise
+// redrawn from the rose, so over a long schedule winds occur with the rose's frequencies while
+// the lag-k autocorrelation of the wind is persistence^k (0 draws independently every interval).
+// Only changes are included in the schedule, starting with the wind at start.
+// Returns an error if the rose is invalid, the interval is not positive, or persistence is
+// outside [0, 1).
+func (r WindRose) GenerateSchedule(start, end time.Time, interval time.Duration, persistence float64, rng *rand.Rand) ([]WindChange, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, simerrors.Invalidf("wind rose sampling interval must be positive, got %v", interval)
+	}
+	if persistence < 0 || persistence >= 1 {
+		return nil, simerrors.Invalidf("wind persistence must be in [0, 1), got %g", persistence)
+	}
+
+	var schedule []WindChange
+	for t := start; t.Before(end); t = t.Add(interval) {
+		if len(schedule) > 0 && rng.Float64() < persistence {
+			continue
+		}
+		speed, direction := r.Sample(rng)
+		schedule = append(schedule, WindChange{Timestamp: t, SpeedKnots: speed, DirectionTrue: direction})
+	}
+	return schedule, nil
+}
+
+// WindRoseConfiguration defines how a wind rose policy samples the wind.
+type WindRoseConfiguration struct {
+	Rose        WindRose      // Climatological wind frequencies
+	Interval    time.Duration // How often the wind may change (e.g., hourly, like METAR reports)
+	Persistence float64       // Probability [0, 1) that the wind persists from one interval to the next
+}
+
+// WindRosePolicy generates a year of wind changes sampled from a wind rose (see
+// WindRose.GenerateSchedule), between static wind and a full METAR replay: the wind has
+// realistic frequencies and persistence without historical data. The sample is drawn from
+// the simulation's random source, so seeded runs are reproducible.
+type WindRosePolicy struct {
+	config WindRoseConfiguration
+	rng    *rand.Rand // Random source for sampling (set by the simulation)
+}
+
+// NewWindRosePolicy creates a new wind rose policy with validation.
+// Returns an error if the rose is invalid, the interval is not positive, or the persistence
+// is outside [0, 1).
+func NewWindRosePolicy(config WindRoseConfiguration) (*WindRosePolicy, error) {
+	if err := config.Rose.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Interval <= 0 {
+		return nil, simerrors.Invalidf("wind rose sampling interval must be positive, got %v", config.Interval)
+	}
+	if config.Persistence < 0 || config.Persistence >= 1 {
+		return nil, simerrors.Invalidf("wind persistence must be in [0, 1), got %g", config.Persistence)
+	}
+
+	return &WindRosePolicy{
+		config: config,
+	}, nil
+}
+
+// Name returns the policy name.
+func (p *WindRosePolicy) Name() string {
+	return "WindRosePolicy"
+}
+
+// Parameters returns the policy configuration.
+func (p *WindRosePolicy) Parameters() any {
+	return p.config
+}
+
+// SetRandomSource sets the random source used to sample the wind.
+// This implements the StochasticPolicy interface.
+func (p *WindRosePolicy) SetRandomSource(rng *rand.Rand) {
+	p.rng = rng
+}
+
+// GenerateEvents samples a wind schedule over the simulation period and generates a
+// WindChangeEvent for every change.
+func (p *WindRosePolicy) GenerateEvents(ctx context.Context, world EventWorld) error {
+	if p.rng == nil {
+		p.rng = NewRandomSource(0, 0)
+	}
+
+	schedule, err := p.config.Rose.GenerateSchedule(world.GetStartTime(), world.GetEndTime(), p.config.Interval, p.config.Persistence, p.rng)
+	if err != nil {
+		return err
+	}
+	for _, change := range schedule {
+		world.ScheduleEvent(event.NewWindChangeEvent(change.SpeedKnots, change.DirectionTrue, change.Timestamp))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// testWindRose has four sectors: calm 10%, north 60% (split over two speed bins),
+// east 20%, south 10%, west 0%.
+func testWindRose() WindRose {
+	return WindRose{
+		SpeedBinEdges: []float64{0, 10, 20},
+		Frequencies: [][]float64{
+			{0.3, 0.3},
+			{0.2, 0},
+			{0.1, 0},
+			{0, 0},
+		},
+		Calm: 0.1,
+	}
+}
+
+func TestWindRose_Validate(t *testing.T) {
+	if err := testWindRose().Validate(); err != nil {
+		t.Fatalf("valid rose: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		rose WindRose
+	}{
+		{"no speed bins", WindRose{SpeedBinEdges: []float64{0}, Frequencies: [][]float64{{}}}},
+		{"descending edges", WindRose{SpeedBinEdges: []float64{10, 0}, Frequencies: [][]float64{{1}}}},
+		{"no sectors", WindRose{SpeedBinEdges: []float64{0, 10}, Calm: 1}},
+		{"wrong bin count", WindRose{SpeedBinEdges: []float64{0, 10}, Frequencies: [][]float64{{1, 1}}}},
+		{"negative frequency", WindRose{SpeedBinEdges: []float64{0, 10}, Frequencies: [][]float64{{-1}}, Calm: 2}},
+		{"all zero", WindRose{SpeedBinEdges: []float64{0, 10}, Frequencies: [][]float64{{0}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rose.Validate(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWindRose_SampleMatchesFrequencies(t *testing.T) {
+	rose := testWindRose()
+	rng := NewRandomSource(1, 0)
+
+	const samples = 100000
+	counts := make(map[string]int)
+	for range samples {
+		speed, direction := rose.Sample(rng)
+		switch {
+		case speed == 0:
+			counts["calm"]++
+		case direction >= 315 || direction < 45:
+			counts["north"]++
+			if speed >= 20 {
+				t.Fatalf("speed %g outside the rose's bins", speed)
+			}
+		case direction < 135:
+			counts["east"]++
+			if speed >= 10 {
+				t.Fatalf("east speed %g outside its bin", speed)
+			}
+		case direction < 225:
+			counts["south"]++
+		default:
+			counts["west"]++
+		}
+	}
+
+	expected := map[string]float64{"calm": 0.1, "north": 0.6, "east": 0.2, "south": 0.1, "west": 0}
+	for sector, want := range expected {
+		got := float64(counts[sector]) / samples
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("%s frequency = %.3f, expected %.3f", sector, got, want)
+		}
+	}
+}
+
+func TestWindRose_GenerateSchedulePersistence(t *testing.T) {
+	rose := testWindRose()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	hours := int(end.Sub(start).Hours())
+
+	independent, err := rose.GenerateSchedule(start, end, time.Hour, 0, NewRandomSource(1, 0))
+	if err != nil {
+		t.Fatalf("GenerateSchedule: %v", err)
+	}
+	if len(independent) != hours {
+		t.Errorf("without persistence expected a change every hour (%d), got %d", hours, len(independent))
+	}
+
+	persistent, err := rose.GenerateSchedule(start, end, time.Hour, 0.9, NewRandomSource(1, 0))
+	if err != nil {
+		t.Fatalf("GenerateSchedule: %v", err)
+	}
+	// Mean persistence is 1/(1-0.9) = 10 hours
+	if got, want := float64(len(persistent)), float64(hours)/10; math.Abs(got-want) > want*0.1 {
+		t.Errorf("with persistence 0.9 expected about %.0f changes, got %.0f", want, got)
+	}
+	if !persistent[0].Timestamp.Equal(start) {
+		t.Errorf("schedule should start at %v, got %v", start, persistent[0].Timestamp)
+	}
+	for i := 1; i < len(persistent); i++ {
+		if !persistent[i].Timestamp.After(persistent[i-1].Timestamp) {
+			t.Fatalf("schedule not in chronological order at %d", i)
+		}
+	}
+	if _, err := NewScheduledWindPolicy(persistent); err != nil {
+		t.Errorf("generated schedule rejected by scheduled wind policy: %v", err)
+	}
+
+	if _, err := rose.GenerateSchedule(start, end, time.Hour, 1, NewRandomSource(1, 0)); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("expected invalid configuration error for persistence 1, got %v", err)
+	}
+}
+
+func TestWindRosePolicy_GenerateEvents(t *testing.T) {
+	if _, err := NewWindRosePolicy(WindRoseConfiguration{Rose: testWindRose()}); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("expected invalid configuration error for zero interval, got %v", err)
+	}
+
+	config := WindRoseConfiguration{Rose: testWindRose(), Interval: time.Hour, Persistence: 0.8}
+	simStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	simEnd := simStart.AddDate(0, 1, 0)
+
+	run := func(seed int64) *mockEventWorld {
+		p, err := NewWindRosePolicy(config)
+		if err != nil {
+			t.Fatalf("NewWindRosePolicy: %v", err)
+		}
+		p.SetRandomSource(NewRandomSource(seed, 0))
+		world := newMockEventWorld(simStart, simEnd, []string{"09"})
+		if err := p.GenerateEvents(context.Background(), world); err != nil {
+			t.Fatalf("GenerateEvents: %v", err)
+		}
+		return world
+	}
+
+	first, second := run(7), run(7)
+	count := first.CountEventsByType(event.WindChangeType)
+	if count == 0 {
+		t.Fatal("expected wind change events")
+	}
+	if other := second.CountEventsByType(event.WindChangeType); other != count {
+		t.Errorf("same seed produced %d and %d wind changes", count, other)
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"math"
 	"testing"
 	"time"
-
-	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 // TestNewWindPolicy tests the wind policy constructor
@@ -99,85 +97,85 @@ func TestNewWindPolicy(t *testing.T) {
 // TestCalculateWindComponents tests wind component calculations
 func TestCalculateWindComponents(t *testing.T) {
 	tests := []struct {
-		name            string
-		runwayBearing   float64
-		windSpeed       float64
-		windDirection   float64
-		expectedHeadwind float64
+		name              string
+		runwayBearing     float64
+		windSpeed         float64
+		windDirection     float64
+		expectedHeadwind  float64
 		expectedCrosswind float64
-		tolerance       float64
+		tolerance         float64
 	}{
 		{
-			name:            "direct headwind - runway 09, wind 090",
-			runwayBearing:   90,
-			windSpeed:       20,
-			windDirection:   90,
-			expectedHeadwind: 20,
+			name:              "direct headwind - runway 09, wind 090",
+			runwayBearing:     90,
+			windSpeed:         20,
+			windDirection:     90,
+			expectedHeadwind:  20,
 			expectedCrosswind: 0,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "direct tailwind - runway 09, wind 270",
-			runwayBearing:   90,
-			windSpeed:       20,
-			windDirection:   270,
-			expectedHeadwind: -20,
+			name:              "direct tailwind - runway 09, wind 270",
+			runwayBearing:     90,
+			windSpeed:         20,
+			windDirection:     270,
+			expectedHeadwind:  -20,
 			expectedCrosswind: 0,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "direct crosswind - runway 09, wind 360",
-			runwayBearing:   90,
-			windSpeed:       20,
-			windDirection:   0, // North
-			expectedHeadwind: 0,
+			name:              "direct crosswind - runway 09, wind 360",
+			runwayBearing:     90,
+			windSpeed:         20,
+			windDirection:     0, // North
+			expectedHeadwind:  0,
 			expectedCrosswind: 20,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "direct crosswind - runway 09, wind 180",
-			runwayBearing:   90,
-			windSpeed:       20,
-			windDirection:   180, // South
-			expectedHeadwind: 0,
+			name:              "direct crosswind - runway 09, wind 180",
+			runwayBearing:     90,
+			windSpeed:         20,
+			windDirection:     180, // South
+			expectedHeadwind:  0,
 			expectedCrosswind: 20,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "30 degree angle - runway 09, wind 120",
-			runwayBearing:   90,
-			windSpeed:       20,
-			windDirection:   120,
-			expectedHeadwind: 17.32, // 20 * cos(30°)
+			name:              "30 degree angle - runway 09, wind 120",
+			runwayBearing:     90,
+			windSpeed:         20,
+			windDirection:     120,
+			expectedHeadwind:  17.32, // 20 * cos(30°)
 			expectedCrosswind: 10,    // 20 * sin(30°)
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "45 degree angle - runway 27, wind 315",
-			runwayBearing:   270,
-			windSpeed:       20,
-			windDirection:   315,
-			expectedHeadwind: 14.14, // 20 * cos(45°)
+			name:              "45 degree angle - runway 27, wind 315",
+			runwayBearing:     270,
+			windSpeed:         20,
+			windDirection:     315,
+			expectedHeadwind:  14.14, // 20 * cos(45°)
 			expectedCrosswind: 14.14, // 20 * sin(45°)
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "calm wind",
-			runwayBearing:   180,
-			windSpeed:       0,
-			windDirection:   0,
-			expectedHeadwind: 0,
+			name:              "calm wind",
+			runwayBearing:     180,
+			windSpeed:         0,
+			windDirection:     0,
+			expectedHeadwind:  0,
 			expectedCrosswind: 0,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 		{
-			name:            "runway 36, wind 270 (westerly)",
-			runwayBearing:   360,
-			windSpeed:       15,
-			windDirection:   270,
-			expectedHeadwind: 0,
+			name:              "runway 36, wind 270 (westerly)",
+			runwayBearing:     360,
+			windSpeed:         15,
+			windDirection:     270,
+			expectedHeadwind:  0,
 			expectedCrosswind: 15,
-			tolerance:       0.01,
+			tolerance:         0.01,
 		},
 	}
 
@@ -206,13 +204,13 @@ func TestCalculateWindComponents(t *testing.T) {
 // TestIsRunwayUsableInWind tests runway usability checks
 func TestIsRunwayUsableInWind(t *testing.T) {
 	tests := []struct {
-		name            string
-		windSpeed       float64
-		windDirection   float64
-		runwayBearing   float64
-		crosswindLimit  float64
-		tailwindLimit   float64
-		expectedUsable  bool
+		name           string
+		windSpeed      float64
+		windDirection  float64
+		runwayBearing  float64
+		crosswindLimit float64
+		tailwindLimit  float64
+		expectedUsable bool
 	}{
 		{
 			name:           "usable - direct headwind within limits",
@@ -318,51 +316,29 @@ func TestWindPolicyName(t *testing.T) {
 	}
 }
 
-// mockWorldState is a mock implementation of WorldState for testing
-type mockWorldState struct {
-	windSpeed     float64
-	windDirection float64
-	setWindCalled bool
-}
-
-func (m *mockWorldState) SetWind(speed, direction float64) error {
-	m.windSpeed = speed
-	m.windDirection = direction
-	m.setWindCalled = true
-	return nil
-}
-
-func (m *mockWorldState) ScheduleEvent(evt event.Event)     {}
-func (m *mockWorldState) GetEventQueue() *event.EventQueue  { return event.NewEventQueue() }
-func (m *mockWorldState) GetStartTime() time.Time           { return time.Time{} }
-func (m *mockWorldState) GetEndTime() time.Time             { return time.Time{} }
-func (m *mockWorldState) GetRunwayIDs() []string            { return nil }
-
-// TestWindPolicyGenerateEvents tests event generation
-func TestWindPolicyGenerateEvents(t *testing.T) {
+// TestWindPolicySetInitialState tests that static wind is set as initial state
+func TestWindPolicySetInitialState(t *testing.T) {
 	policy, err := NewWindPolicy(15, 270)
 	if err != nil {
 		t.Fatalf("Failed to create policy: %v", err)
 	}
 
-	mockWorld := &mockWorldState{}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	world := newMockInitialStateWorld(start, start.AddDate(0, 0, 1))
 	ctx := context.Background()
 
-	err = policy.GenerateEvents(ctx, mockWorld)
-	if err != nil {
-		t.Errorf("GenerateEvents failed: %v", err)
+	if err := policy.SetInitialState(ctx, world); err != nil {
+		t.Fatalf("SetInitialState failed: %v", err)
 	}
-
-	if !mockWorld.setWindCalled {
-		t.Error("SetWind was not called")
+	if !world.windSet || world.windSpeed != 15 || world.windDirection != 270 {
+		t.Errorf("Expected 15 kt from 270 set, got %+v", world)
 	}
 
-	if mockWorld.windSpeed != 15 {
-		t.Errorf("Expected wind speed 15, got %f", mockWorld.windSpeed)
+	if err := policy.GenerateEvents(ctx, world); err != nil {
+		t.Errorf("GenerateEvents failed: %v", err)
 	}
-
-	if mockWorld.windDirection != 270 {
-		t.Errorf("Expected wind direction 270, got %f", mockWorld.windDirection)
+	if len(world.GetEvents()) != 0 {
+		t.Errorf("Expected no events for static wind, got %d", len(world.GetEvents()))
 	}
 }
 
@@ -0,0 +1,166 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// Version is the version of the simulation package, recorded in the provenance of every
+// result. It changes with each release, since results of the same inputs may differ between
+// versions.
+const Version = "0.6.0-dev"
+
+// Provenance identifies the inputs a result was produced from, so reports produced from
+// different inputs, or by different versions, can be told apart once shared. Equal hashes
+// mean the same version simulated the same airport, scenario, period, seed and settings,
+// unless the provenance is Partial.
+type Provenance struct {
+	Version      string `json:"version"`           // Package version that produced the result (see Version)
+	Hash         string `json:"hash"`              // Hex-encoded SHA-256 digest of every input below and the simulation settings
+	AirportHash  string `json:"airportHash"`       // Hex-encoded SHA-256 digest of the airport simulated, after pre-simulation plugins
+	ScenarioHash string `json:"scenarioHash"`      // Scenario.Hash of the scenario the simulation was created from ("" if none)
+	Seed         int64  `json:"seed"`              // Seed the stochastic policies used, random or not
+	Partial      bool   `json:"partial,omitempty"` // Some policy could not describe its parameters (see policy.DescribedPolicy), so the hash covers only its name
+}
+
+// IsZero reports whether the provenance is unknown, as for results built by hand or loaded
+// from before provenance was recorded.
+func (p Provenance) IsZero() bool {
+	return p == Provenance{}
+}
+
+// Short returns the first 12 characters of the hash with the version, e.g.
+// "3f2a9c0d41b7 (0.6.0-dev)", for reports and file names.
+func (p Provenance) Short() string {
+	return fmt.Sprintf("%.12s (%s)", p.Hash, p.Version)
+}
+
+// ProvenanceKeyPrefix prefixes the metadata keys of Provenance.Metadata.
+const ProvenanceKeyPrefix = "airport_capacity_calculator."
+
+// Metadata returns the provenance as key-value metadata for exported files, with keys
+// prefixed by ProvenanceKeyPrefix.
+func (p Provenance) Metadata() map[string]string {
+	metadata := map[string]string{
+		ProvenanceKeyPrefix + "version":      p.Version,
+		ProvenanceKeyPrefix + "hash":         p.Hash,
+		ProvenanceKeyPrefix + "airport_hash": p.AirportHash,
+		ProvenanceKeyPrefix + "seed":         fmt.Sprint(p.Seed),
+	}
+	if p.ScenarioHash != "" {
+		metadata[ProvenanceKeyPrefix+"scenario_hash"] = p.ScenarioHash
+	}
+	if p.Partial {
+		metadata[ProvenanceKeyPrefix+"partial"] = "true"
+	}
+	return metadata
+}
+
+// WithScenarioHash records the hash identifying the definition the simulation was built from
+// in the provenance of its results (see Provenance). Scenario.Simulation sets it to the
+// scenario's hash; simulations built in code can set their own, e.g. when they add policies
+// that can't describe their parameters (see policy.DescribedPolicy).
+func (s *Simulation) WithScenarioHash(hash string) *Simulation {
+	s.scenarioHash = hash
+	return s
+}
+
+// provenance returns the provenance of a run of the simulation on the prepared airport with
+// the given seed: its hash covers the version, the airport, the scenario hash, the period and
+// its time zone, the seed, the policies by name, priority and parameters, the runway manager
+// options, the capacity model and the minimum window.
+func (s *Simulation) provenance(ap airport.Airport, seed int64) (Provenance, error) {
+	airportData, err := json.Marshal(ap)
+	if err != nil {
+		return Provenance{}, fmt.Errorf("hashing airport %s: %w", ap.Name, err)
+	}
+	airportSum := sha256.Sum256(airportData)
+
+	p := Provenance{
+		Version:      Version,
+		AirportHash:  hex.EncodeToString(airportSum[:]),
+		ScenarioHash: s.scenarioHash,
+		Seed:         seed,
+	}
+
+	policies, described := describePolicies(s.policies, s.policyPriority)
+	p.Partial = !described
+	start, end := s.period()
+	capacityModel := ""
+	if s.capacityModel != nil {
+		capacityModel = fmt.Sprintf("%T", s.capacityModel)
+	}
+
+	inputs, err := json.Marshal(struct {
+		Version       string               `json:"version"`
+		Airport       string               `json:"airport"`
+		Scenario      string               `json:"scenario"`
+		Start         time.Time            `json:"start"`
+		End           time.Time            `json:"end"`
+		Location      string               `json:"location"`
+		Seed          int64                `json:"seed"`
+		Policies      []policyInput        `json:"policies"`
+		RunwayOptions RunwayManagerOptions `json:"runwayOptions"`
+		CapacityModel string               `json:"capacityModel"`
+		MinimumWindow time.Duration        `json:"minimumWindow"`
+	}{p.Version, p.AirportHash, p.ScenarioHash, start.UTC(), end.UTC(), start.Location().String(), seed, policies, s.runwayOptions, capacityModel, s.minimumWindow})
+	if err != nil {
+		return Provenance{}, err
+	}
+	sum := sha256.Sum256(inputs)
+	p.Hash = hex.EncodeToString(sum[:])
+	return p, nil
+}
+
+// policyInput identifies a policy in the provenance hash.
+type policyInput struct {
+	Name       string          `json:"name"`
+	Priority   int             `json:"priority"`
+	Parameters json.RawMessage `json:"parameters,omitempty"` // nil if the policy can't describe them
+}
+
+// describePolicies returns the provenance inputs of the policies, with the priority of the
+// i-th policy given by priority, and whether every policy described its parameters.
+func describePolicies(policies []Policy, priority func(i int) int) ([]policyInput, bool) {
+	inputs := make([]policyInput, len(policies))
+	described := true
+	for i, pol := range policies {
+		inputs[i] = policyInput{Name: pol.Name(), Priority: priority(i)}
+		if dp, ok := pol.(policy.DescribedPolicy); ok {
+			if parameters, err := json.Marshal(dp.Parameters()); err == nil {
+				inputs[i].Parameters = parameters
+				continue
+			}
+		}
+		described = false
+	}
+	return inputs, described
+}
+
+// resumed returns the provenance of a run resumed from a checkpoint with additional policies,
+// which are hashed after the checkpoint's own hash.
+func (p Provenance) resumed(policies []Policy) Provenance {
+	if len(policies) == 0 {
+		return p
+	}
+	inputs, described := describePolicies(policies, func(i int) int {
+		if pp, ok := policies[i].(policy.PrioritizedPolicy); ok {
+			return pp.Priority()
+		}
+		return 0
+	})
+	p.Partial = p.Partial || !described
+	data, _ := json.Marshal(struct {
+		Checkpoint string        `json:"checkpoint"`
+		Policies   []policyInput `json:"policies"`
+	}{p.Hash, inputs})
+	sum := sha256.Sum256(data)
+	p.Hash = hex.EncodeToString(sum[:])
+	return p
+}
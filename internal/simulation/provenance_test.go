@@ -0,0 +1,141 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+func TestSimulation_Provenance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	buildCurfew := func(curfewStart time.Duration) *Simulation {
+		sim, err := NewSimulation(capacityTableAirport(), testEngineLogger()).
+			WithPeriod(start, start.AddDate(0, 0, 1)).
+			WithSeed(7).
+			AddCurfewPolicy(start.Add(curfewStart), start.Add(30*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sim
+	}
+	build := func() *Simulation { return buildCurfew(23 * time.Hour) }
+	run := func(sim *Simulation) Provenance {
+		t.Helper()
+		result, err := sim.RunResult(context.Background())
+		if err != nil {
+			t.Fatalf("RunResult failed: %v", err)
+		}
+		return result.Provenance
+	}
+
+	base := run(build())
+	if base.Version != Version || len(base.Hash) != 64 || len(base.AirportHash) != 64 || base.Seed != 7 || base.ScenarioHash != "" || base.Partial {
+		t.Fatalf("Unexpected provenance %+v", base)
+	}
+	if again := run(build()); again != base {
+		t.Errorf("Expected the same inputs to give the same provenance, got %+v and %+v", base, again)
+	}
+	if got := base.Short(); got != base.Hash[:12]+" ("+Version+")" {
+		t.Errorf("Unexpected short provenance %q", got)
+	}
+
+	changed := map[string]*Simulation{
+		"seed":     build().WithSeed(8),
+		"period":   build().WithPeriod(start, start.AddDate(0, 0, 2)),
+		"airport":  build().AddPreSimulationPlugin(GateExpansionPlugin{AdditionalGates: 10}),
+		"policies": build().DisablePolicy("CurfewPolicy"),
+		"options":  build().WithRunwayManagerOptions(RunwayManagerOptions{MinimumDwell: time.Hour}),
+		"scenario": build().WithScenarioHash("abc"),
+		"curfew":   buildCurfew(20 * time.Hour),
+	}
+	for name, sim := range changed {
+		if p := run(sim); p.Hash == base.Hash {
+			t.Errorf("%s: expected a different hash from changed inputs", name)
+		}
+	}
+	if p := run(build().AddPreSimulationPlugin(GateExpansionPlugin{AdditionalGates: 10})); p.AirportHash == base.AirportHash {
+		t.Error("Expected a plugin changing the airport to change the airport hash")
+	}
+
+	// A policy that can't describe its parameters only makes the provenance partial
+	undescribed := run(build().AddPolicy(&scheduledPolicy{name: "Scheduled"}))
+	if !undescribed.Partial || undescribed.Hash == base.Hash {
+		t.Errorf("Expected a partial provenance with a different hash, got %+v", undescribed)
+	}
+	if got := undescribed.Metadata()[ProvenanceKeyPrefix+"partial"]; got != "true" {
+		t.Errorf("Expected the partial provenance in the metadata, got %q", got)
+	}
+
+	// An unseeded run records the seed it used, which reproduces it
+	unseeded := run(NewSimulation(capacityTableAirport(), testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)))
+	if reseeded := run(NewSimulation(capacityTableAirport(), testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).WithSeed(unseeded.Seed)); reseeded != unseeded {
+		t.Errorf("Expected seeding with the recorded seed to reproduce %+v, got %+v", unseeded, reseeded)
+	}
+
+	// A run resumed with additional policies has different inputs
+	checkpoint, err := build().RunUntil(context.Background(), start.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("RunUntil failed: %v", err)
+	}
+	resumed, err := build().Resume(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	maintenance, err := policy.NewPlannedMaintenancePolicy([]MaintenanceWindow{{RunwayDesignation: "09L", Start: start.Add(13 * time.Hour), End: start.Add(15 * time.Hour)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closure, err := build().Resume(context.Background(), checkpoint, maintenance)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if resumed.Provenance != base || closure.Provenance.Hash == base.Hash || closure.Provenance.AirportHash != base.AirportHash {
+		t.Errorf("Expected resuming to keep the provenance %+v unless policies are added, got %+v and %+v", base, resumed.Provenance, closure.Provenance)
+	}
+}
+
+func TestScenario_Provenance(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.json")
+	definition := `{"name": "Test", "airport": "preset:LHR-like", "start": "2024-01-01T00:00:00Z", "end": "2024-01-02T00:00:00Z", "seed": 1}`
+	if err := os.WriteFile(path, []byte(definition), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	scenario, err := LoadScenario(path)
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+	hash, err := scenario.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim, err := scenario.Simulation(testEngineLogger())
+	if err != nil {
+		t.Fatalf("Simulation failed: %v", err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if result.Provenance.ScenarioHash != hash {
+		t.Errorf("Expected the scenario hash %s in the provenance, got %+v", hash, result.Provenance)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWindowsParquet(&buf, ParquetOptions{}, result); err != nil {
+		t.Fatalf("WriteWindowsParquet failed: %v", err)
+	}
+	data := buf.Bytes()
+	footer := data[len(data)-8-int(binary.LittleEndian.Uint32(data[len(data)-8:])) : len(data)-8]
+	for key, value := range result.Provenance.Metadata() {
+		if !bytes.Contains(footer, []byte(key)) || !bytes.Contains(footer, []byte(value)) {
+			t.Errorf("Expected %s = %s in the file footer", key, value)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package simulation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/timewin"
+)
+
+// WindowResult records the capacity calculated for one state window together with a
+// snapshot of the world state that produced it.
+type WindowResult struct {
+	Start              time.Time             // Window start (inclusive)
+	End                time.Time             // Window end (exclusive)
+	Capacity           float32               // Movements calculated for the window
+	ActiveRunways      []string              // Runways in the active configuration (sorted)
+	RunwayMovements    []float32             // Movements handled by each runway in ActiveRunways (sums to Capacity)
+	RunwayEnds         []string              // Runway end in use by each runway in ActiveRunways (e.g., "27R" for 09L operating Reverse)
+	RunwayOperations   []event.OperationType // Operations handled by each runway in ActiveRunways
+	Configuration      string                // Active configuration label (see ConfigurationLabel)
+	Flow               string                // Traffic flow of the active configuration (see ConfigurationFlow)
+	UnavailableRunways []string              // Runways closed for maintenance or other restrictions (sorted)
+	CurfewActive       bool                  // Whether curfew was in effect
+	WindSpeed          float64               // Wind speed in knots
+	WindDirection      float64               // Wind direction in degrees true
+	RotationMultiplier float32               // Rotation efficiency multiplier in effect
+	DisruptionFactor   float32               // Fraction of capacity remaining under airfield disruptions (1 = none)
+	NoiseQuotaUsed     float64               // Noise points consumed in the calendar year up to the window end
+	NightQuotaUsed     float64               // Night quota movements made in the calendar year up to the window end
+	HelipadMovements   float32               // Helicopter movements on helipads (included in Capacity)
+	GAMovements        float32               // Movements reserved for general aviation (not included in Capacity)
+	RunwayGAMovements  []float32             // Movements reserved for general aviation on each runway in ActiveRunways (nil if none)
+	GateSamples        []GateSample          // Aircraft on gates through the window, changing linearly between samples (nil without a gate capacity constraint)
+}
+
+// Duration returns the length of the window.
+func (w WindowResult) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Result is the detailed outcome of a simulation run.
+type Result struct {
+	StartTime       time.Time      // Simulation start time
+	EndTime         time.Time      // Simulation end time
+	TotalCapacity   float32        // Total movements across all windows
+	Windows         []WindowResult // Per-window results in chronological order
+	EventsProcessed int            // Events applied during the run
+	EventsSkipped   int            // Events outside the simulation period, which were not applied
+
+	ConfigurationHistory []ConfigurationChange // Distinct active runway configurations in chronological order
+
+	Provenance Provenance // Inputs the result was produced from (zero if unknown, e.g. for results built directly by an Engine)
+}
+
+// addWindow appends a window with a snapshot of the current world state and the movements
+// attributed to each runway. Zero-length windows contribute nothing and are not recorded.
+func (r *Result) addWindow(world *World, start, end time.Time, capacity float32, scratch *windowScratch) {
+	r.TotalCapacity += capacity
+	if !end.After(start) {
+		return
+	}
+
+	active := world.activeRunwayIDs()
+	configuration, flow := world.activeConfigurationLabels()
+	ends, operations := activeRunwayEnds(active, world.activeConfiguration())
+
+	unavailable := []string{}
+	for id, state := range world.RunwayStates {
+		if !state.Available {
+			unavailable = append(unavailable, id)
+		}
+	}
+	sort.Strings(unavailable)
+
+	r.Windows = append(r.Windows, WindowResult{
+		Start:              start,
+		End:                end,
+		Capacity:           capacity,
+		ActiveRunways:      active,
+		RunwayMovements:    activeRunwayMovements(active, scratch.runwayMovements),
+		RunwayEnds:         ends,
+		RunwayOperations:   operations,
+		Configuration:      configuration,
+		Flow:               flow,
+		UnavailableRunways: unavailable,
+		CurfewActive:       world.CurfewActive,
+		WindSpeed:          world.WindSpeed,
+		WindDirection:      world.WindDirection,
+		RotationMultiplier: world.RotationMultiplier,
+		DisruptionFactor:   world.DisruptionFactor(),
+		NoiseQuotaUsed:     world.NoiseQuotaUsed(start),
+		NightQuotaUsed:     world.NightQuotaUsed(start),
+		HelipadMovements:   world.helipadMovements(end.Sub(start)),
+		GAMovements:        totalMovements(scratch.gaMovements),
+		RunwayGAMovements:  activeRunwayGAMovements(active, scratch.gaMovements),
+		GateSamples:        world.gateSamples(start, end, scratch.gateSamples),
+	})
+}
+
+// activeRunwayMovements returns the movements attributed to each of the active runways, in
+// the same order (nil if there are none).
+func activeRunwayMovements(active []string, runwayMovements map[string]float32) []float32 {
+	if len(active) == 0 {
+		return nil
+	}
+	movements := make([]float32, len(active))
+	for i, runwayID := range active {
+		movements[i] = runwayMovements[runwayID]
+	}
+	return movements
+}
+
+// activeRunwayGAMovements returns the movements reserved for general aviation on each of the
+// active runways, in the same order, or nil if none are reserved.
+func activeRunwayGAMovements(active []string, gaMovements map[string]float32) []float32 {
+	if len(gaMovements) == 0 {
+		return nil
+	}
+	return activeRunwayMovements(active, gaMovements)
+}
+
+// totalMovements returns the sum of movements across runways.
+func totalMovements(movements map[string]float32) float32 {
+	total := float32(0)
+	for _, m := range movements {
+		total += m
+	}
+	return total
+}
+
+// activeRunwayEnds returns the runway end in use by each of the active runways and the
+// operations it handles, in the same order (nil if there are none).
+func activeRunwayEnds(active []string, config map[string]*event.ActiveRunwayInfo) ([]string, []event.OperationType) {
+	if len(active) == 0 {
+		return nil, nil
+	}
+	ends := make([]string, len(active))
+	operations := make([]event.OperationType, len(active))
+	for i, runwayID := range active {
+		if info, ok := config[runwayID]; ok {
+			ends[i] = info.RunwayEnd()
+			operations[i] = info.OperationType
+		}
+	}
+	return ends, operations
+}
+
+// BaselineResult pairs a constrained run with its unconstrained theoretical maximum.
+type BaselineResult struct {
+	Constrained        *Result // Run with all configured policies
+	Baseline           *Result // Run with no policies (theoretical maximum)
+	UtilizationPercent float64 // Constrained capacity as a percentage of the baseline (0 when the baseline is zero)
+	RealismGapPercent  float64 // Capacity lost to the policies as a percentage of the baseline (0 when the baseline is zero)
+}
+
+// newBaselineResult builds a BaselineResult and computes utilization.
+func newBaselineResult(constrained, baseline *Result) *BaselineResult {
+	utilization, gap := 0.0, 0.0
+	if baseline.TotalCapacity > 0 {
+		utilization = float64(constrained.TotalCapacity) / float64(baseline.TotalCapacity) * 100
+		gap = 100 - utilization
+	}
+	return &BaselineResult{
+		Constrained:        constrained,
+		Baseline:           baseline,
+		UtilizationPercent: utilization,
+		RealismGapPercent:  gap,
+	}
+}
+
+// DailyCapacity is the capacity accumulated over one calendar day.
+type DailyCapacity struct {
+	Date     time.Time // Midnight at the start of the day (in the simulation's location)
+	Capacity float32   // Movements during the day
+}
+
+// DailyCapacity returns the capacity per calendar day. Windows spanning midnight are split
+// proportionally by duration.
+func (r *Result) DailyCapacity() []DailyCapacity {
+	days := []DailyCapacity{}
+	index := map[time.Time]int{}
+
+	for _, w := range r.Windows {
+		forEachDaySegment(w, func(day time.Time, fraction float64) {
+			i, ok := index[day]
+			if !ok {
+				i = len(days)
+				index[day] = i
+				days = append(days, DailyCapacity{Date: day})
+			}
+			days[i].Capacity += w.Capacity * float32(fraction)
+		})
+	}
+
+	return days
+}
+
+// forEachDaySegment splits a window at midnight boundaries and calls fn with the day and the
+// fraction of the window's duration that falls on that day.
+func forEachDaySegment(w WindowResult, fn func(day time.Time, fraction float64)) {
+	total := w.Duration()
+	for _, segment := range timewin.SplitByDay(timewin.Window{Start: w.Start, End: w.End}, w.Start.Location()) {
+		fn(startOfDay(segment.Start), float64(segment.Duration())/float64(total))
+	}
+}
+
+// startOfDay returns midnight at the start of t's day in t's location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
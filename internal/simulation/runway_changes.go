@@ -0,0 +1,154 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// Clock supplies the current time to a runway manager (see RunwayManager.SetClock).
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// ConfigurationChangeReason identifies the notification that changed the active runway
+// configuration.
+type ConfigurationChangeReason int
+
+const (
+	// ReasonRunwayAvailability is a runway becoming available or unavailable.
+	ReasonRunwayAvailability ConfigurationChangeReason = iota
+	// ReasonCurfew is a curfew starting or ending.
+	ReasonCurfew
+	// ReasonWind is a change in wind or gusts.
+	ReasonWind
+	// ReasonRunwayModified is a change to a runway's characteristics.
+	ReasonRunwayModified
+	// ReasonCompatibility is a replacement of the compatibility graph.
+	ReasonCompatibility
+	// ReasonCompatibilityHours is the time of day entering or leaving compatibility hours.
+	ReasonCompatibilityHours
+	// ReasonFleetMix is a change in the fleet mix used to rate configurations.
+	ReasonFleetMix
+	// ReasonOptions is a change in the runway manager's options.
+	ReasonOptions
+	// ReasonCapacityModel is a change in the capacity model used to rate configurations.
+	ReasonCapacityModel
+	// ReasonNoisePreference is a change in the noise-preferential runway configurations.
+	ReasonNoisePreference
+)
+
+// String returns the name of the reason.
+func (r ConfigurationChangeReason) String() string {
+	switch r {
+	case ReasonRunwayAvailability:
+		return "RunwayAvailability"
+	case ReasonCurfew:
+		return "Curfew"
+	case ReasonWind:
+		return "Wind"
+	case ReasonRunwayModified:
+		return "RunwayModified"
+	case ReasonCompatibility:
+		return "Compatibility"
+	case ReasonCompatibilityHours:
+		return "CompatibilityHours"
+	case ReasonFleetMix:
+		return "FleetMix"
+	case ReasonOptions:
+		return "Options"
+	case ReasonCapacityModel:
+		return "CapacityModel"
+	case ReasonNoisePreference:
+		return "NoisePreference"
+	default:
+		return "Unknown"
+	}
+}
+
+// RunwayConfigurationChange records a runway manager's change in the runways, directions or
+// operations of the active runway configuration, with the notification that caused it.
+type RunwayConfigurationChange struct {
+	Time     time.Time                          // Simulation time of the change
+	Previous map[string]*event.ActiveRunwayInfo // Configuration before the change
+	Current  map[string]*event.ActiveRunwayInfo // Configuration after the change
+	Reason   ConfigurationChangeReason          // Notification that caused the change
+}
+
+// SetClock sets the clock the manager reads the current time from at each notification, in
+// place of the time last passed to SetTime. Time-dependent behaviour (minimum dwell and
+// compatibility hours) and the time of configuration changes then follow the clock, so the
+// manager can be driven directly, e.g. with a fake clock in tests. A nil clock reverts to
+// SetTime.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetClock(clock Clock) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.clock = clock
+	rm.syncClock()
+}
+
+// SetConfigurationChangeHandler sets a function called with a RunwayConfigurationChange
+// whenever a notification changes the active runway configuration (nil stops the records).
+// The handler is called while the manager is locked, so it must not call back into the
+// manager; the record carries the new configuration. Clones of the manager made when
+// forking a world do not inherit the handler.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetConfigurationChangeHandler(handler func(RunwayConfigurationChange)) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.onChange = handler
+}
+
+// syncClock takes the current time from the clock, if one is set.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) syncClock() {
+	if rm.clock == nil {
+		return
+	}
+	rm.now = rm.clock.Now()
+	rm.updateHoursInEffect()
+}
+
+// configurationSelected is called after each recalculation of the active configuration with
+// the configuration before it. It records the selection for hysteresis and reports a change
+// in runway use to the change handler.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) configurationSelected(previous map[string]*event.ActiveRunwayInfo, reason ConfigurationChangeReason) {
+	if rm.options.hasHysteresis() {
+		rm.recordConfigurationSelected(previous)
+	}
+	if rm.onChange == nil || sameRunwayUse(previous, rm.currentConfiguration) {
+		return
+	}
+	rm.onChange(RunwayConfigurationChange{
+		Time:     rm.now,
+		Previous: previous,
+		Current:  copyConfiguration(rm.currentConfiguration),
+		Reason:   reason,
+	})
+}
+
+// copyConfiguration returns a deep copy of a runway configuration.
+func copyConfiguration(config map[string]*event.ActiveRunwayInfo) map[string]*event.ActiveRunwayInfo {
+	c := make(map[string]*event.ActiveRunwayInfo, len(config))
+	for runwayID, info := range config {
+		infoCopy := *info
+		c[runwayID] = &infoCopy
+	}
+	return c
+}
@@ -1,7 +1,9 @@
 package simulation
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
@@ -29,6 +31,9 @@ type RunwayManager struct {
 	// windDirection is the current wind direction in degrees true
 	windDirection float64
 
+	// windGust is the current peak gust speed in knots (0 means no gusts)
+	windGust float64
+
 	// allRunways contains the complete runway inventory for this airport
 	allRunways []airport.Runway
 
@@ -44,6 +49,58 @@ type RunwayManager struct {
 
 	// maximalCliquesComputed indicates whether maximal cliques have been computed
 	maximalCliquesComputed bool
+
+	// runwayIndex maps each runway ID to its index in allRunways (its bit in a runwaySet)
+	runwayIndex map[string]int
+
+	// cliqueSets holds maximalCliques as runway sets, in the same order
+	cliqueSets []runwaySet
+
+	// candidateCliques memoizes, per set of usable runways, the indices of the maximal
+	// cliques contained in it. Depends only on the compatibility graph, so never invalidated.
+	candidateCliques map[string][]int
+
+	// selectedConfigs memoizes, per set of usable runways, the selected configuration.
+	// Invalidated whenever wind, fleet mix, or runway characteristics change.
+	selectedConfigs map[string][]string
+
+	// fleetMix determines the expected separation on each runway (nil means each runway's minimum separation)
+	fleetMix *airport.FleetMix
+
+	// options encodes the airport's preferences when selecting among configurations
+	options RunwayManagerOptions
+
+	// noisePreferences are runway configurations, as runway end designations, selected in
+	// order of preference whenever one can operate (nil means none; see OnNoisePreferencesChanged)
+	noisePreferences [][]string
+
+	// model rates configuration capacity (nil means SeparationModel)
+	model CapacityModel
+
+	// now is the current simulation time (see SetTime)
+	now time.Time
+
+	// location is the time zone compatibility hours are laid out in (nil means the time zone
+	// of the current simulation time; see SetLocation)
+	location *time.Location
+
+	// hoursInEffect records which of the compatibility graph's hours permit their runways to
+	// operate together at the current time of day (nil if the graph has none)
+	hoursInEffect []bool
+
+	// clock supplies the current time at each notification (nil means the time set by SetTime)
+	clock Clock
+
+	// onChange is called with each change of the active configuration (nil means none)
+	onChange func(RunwayConfigurationChange)
+
+	// configSince is when the current configuration became active (tracked with hysteresis)
+	configSince time.Time
+
+	// configWindSpeed and configWindDirection are the mean wind when the current
+	// configuration was selected (tracked with hysteresis)
+	configWindSpeed     float64
+	configWindDirection float64
 }
 
 // NewRunwayManager creates a new thread-safe runway manager initialized with
@@ -63,20 +120,67 @@ func NewRunwayManager(runways []airport.Runway, compatibility *airport.RunwayCom
 		compatibility:          compatibility,
 		maximalCliques:         nil,
 		maximalCliquesComputed: false,
+		runwayIndex:            make(map[string]int, len(runways)),
+		candidateCliques:       make(map[string][]int),
+		selectedConfigs:        make(map[string][]string),
 	}
 
 	// Copy runways and initialize all as available
 	copy(rm.allRunways, runways)
-	for _, runway := range runways {
+	for i, runway := range runways {
 		rm.availableRunways[runway.RunwayDesignation] = true
+		rm.runwayIndex[runway.RunwayDesignation] = i
 	}
 
+	rm.hoursInEffect = compatibility.HoursInEffect(rm.timeOfDay())
+
 	// Calculate initial configuration
-	rm.calculateActiveConfiguration()
+	rm.calculateActiveConfiguration(ReasonRunwayAvailability)
 
 	return rm
 }
 
+// clone returns an independent runway manager in the same state. Maximal cliques are shared,
+// since they depend only on the compatibility graph and are never modified once computed.
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) clone() *RunwayManager {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	c := &RunwayManager{
+		availableRunways:       make(map[string]bool, len(rm.availableRunways)),
+		curfewActive:           rm.curfewActive,
+		windSpeed:              rm.windSpeed,
+		windDirection:          rm.windDirection,
+		windGust:               rm.windGust,
+		allRunways:             append([]airport.Runway(nil), rm.allRunways...),
+		currentConfiguration:   copyConfiguration(rm.currentConfiguration),
+		compatibility:          rm.compatibility,
+		maximalCliques:         rm.maximalCliques,
+		maximalCliquesComputed: rm.maximalCliquesComputed,
+		runwayIndex:            rm.runwayIndex,
+		cliqueSets:             rm.cliqueSets,
+		candidateCliques:       make(map[string][]int),
+		selectedConfigs:        make(map[string][]string),
+		fleetMix:               rm.fleetMix,
+		options:                rm.options,
+		noisePreferences:       rm.noisePreferences,
+		model:                  rm.model,
+		now:                    rm.now,
+		location:               rm.location,
+		hoursInEffect:          rm.hoursInEffect,
+		clock:                  rm.clock,
+		configSince:            rm.configSince,
+		configWindSpeed:        rm.configWindSpeed,
+		configWindDirection:    rm.configWindDirection,
+	}
+	for runwayID, available := range rm.availableRunways {
+		c.availableRunways[runwayID] = available
+	}
+	return c
+}
+
 // OnRunwayAvailable notifies the manager that a runway has become available.
 // This triggers recalculation of the active runway configuration.
 //
@@ -85,8 +189,9 @@ func (rm *RunwayManager) OnRunwayAvailable(runwayID string) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.syncClock()
 	rm.availableRunways[runwayID] = true
-	rm.calculateActiveConfiguration()
+	rm.calculateActiveConfiguration(ReasonRunwayAvailability)
 }
 
 // OnRunwayUnavailable notifies the manager that a runway has become unavailable.
@@ -97,8 +202,9 @@ func (rm *RunwayManager) OnRunwayUnavailable(runwayID string) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.syncClock()
 	rm.availableRunways[runwayID] = false
-	rm.calculateActiveConfiguration()
+	rm.calculateActiveConfiguration(ReasonRunwayAvailability)
 }
 
 // OnCurfewChanged notifies the manager that curfew status has changed.
@@ -109,8 +215,9 @@ func (rm *RunwayManager) OnCurfewChanged(active bool) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.syncClock()
 	rm.curfewActive = active
-	rm.calculateActiveConfiguration()
+	rm.calculateActiveConfiguration(ReasonCurfew)
 }
 
 // OnWindChanged notifies the manager that wind conditions have changed.
@@ -119,12 +226,93 @@ func (rm *RunwayManager) OnCurfewChanged(active bool) {
 //
 // Thread-safe: Uses write lock.
 func (rm *RunwayManager) OnWindChanged(speedKnots, directionTrue float64) {
+	rm.OnGustingWindChanged(speedKnots, 0, directionTrue)
+}
+
+// OnGustingWindChanged notifies the manager that wind conditions have changed, with gusts
+// up to gustKnots (0 means no gusts). Crosswind and tailwind limits are checked at the gust
+// speed, while the preferred direction still follows the mean wind.
+//
+// With hysteresis options set, the current configuration is kept while it remains usable in
+// the new wind and either its minimum dwell time has not elapsed or the wind is within the
+// deadband of the wind it was selected in (see RunwayManagerOptions).
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) OnGustingWindChanged(speedKnots, gustKnots, directionTrue float64) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	rm.syncClock()
+	if speedKnots != rm.windSpeed || gustKnots != rm.windGust || directionTrue != rm.windDirection {
+		clear(rm.selectedConfigs)
+	}
 	rm.windSpeed = speedKnots
+	rm.windGust = gustKnots
 	rm.windDirection = directionTrue
-	rm.calculateActiveConfiguration()
+	rm.calculateActiveConfiguration(ReasonWind)
+}
+
+// OnRunwayModified notifies the manager that a runway's characteristics have changed
+// (e.g., length extension or new separation). The runway is matched by designation;
+// unknown runways are ignored. Cached maximal cliques remain valid because the
+// compatibility graph is unchanged, but memoized configuration selections are discarded.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) OnRunwayModified(runway airport.Runway) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	for i := range rm.allRunways {
+		if rm.allRunways[i].RunwayDesignation == runway.RunwayDesignation {
+			rm.allRunways[i] = runway
+			clear(rm.selectedConfigs)
+			rm.calculateActiveConfiguration(ReasonRunwayModified)
+			return
+		}
+	}
+}
+
+// OnCompatibilityChanged replaces the compatibility graph (e.g., when a rebuilt runway
+// opens). Cached maximal cliques and memoized selections are discarded, since both depend
+// on the graph. This triggers recalculation of the active runway configuration.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) OnCompatibilityChanged(compatibility *airport.RunwayCompatibility) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	rm.compatibility = compatibility
+	rm.hoursInEffect = compatibility.HoursInEffect(rm.timeOfDay())
+	rm.resetCliques()
+	rm.calculateActiveConfiguration(ReasonCompatibility)
+}
+
+// resetCliques discards the cached maximal cliques and memoized selections, which depend on
+// the compatibility graph in effect.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) resetCliques() {
+	rm.maximalCliques = nil
+	rm.cliqueSets = nil
+	rm.maximalCliquesComputed = false
+	rm.candidateCliques = make(map[string][]int)
+	clear(rm.selectedConfigs)
+}
+
+// SetFleetMix sets the fleet mix used to rate configuration capacity.
+// This triggers recalculation of the active runway configuration.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetFleetMix(fleetMix *airport.FleetMix) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	rm.fleetMix = fleetMix
+	clear(rm.selectedConfigs)
+	rm.calculateActiveConfiguration(ReasonFleetMix)
 }
 
 // GetActiveConfiguration returns the current active runway configuration.
@@ -135,15 +323,27 @@ func (rm *RunwayManager) GetActiveConfiguration() map[string]*event.ActiveRunway
 	rm.mu.RLock()
 	defer rm.mu.RUnlock()
 
-	// Return a deep copy to prevent external mutation
-	config := make(map[string]*event.ActiveRunwayInfo, len(rm.currentConfiguration))
-	for k, v := range rm.currentConfiguration {
-		// Copy the struct (not just the pointer)
-		infoCopy := *v
-		config[k] = &infoCopy
+	return copyConfiguration(rm.currentConfiguration)
+}
+
+// CandidateConfigurations returns the runway information for every maximal compatible
+// runway set, regardless of current availability, with directions chosen from the current
+// wind and operation types restricted to resolve terminal airspace conflicts.
+//
+// Thread-safe: Uses write lock (maximal cliques are computed lazily).
+func (rm *RunwayManager) CandidateConfigurations() []map[string]*event.ActiveRunwayInfo {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if !rm.maximalCliquesComputed {
+		rm.computeMaximalCliques()
 	}
 
-	return config
+	configs := make([]map[string]*event.ActiveRunwayInfo, 0, len(rm.maximalCliques))
+	for _, clique := range rm.maximalCliques {
+		configs = append(configs, rm.buildConfiguration(clique))
+	}
+	return configs
 }
 
 // computeMaximalCliques finds all maximal compatible runway sets using Bron-Kerbosch algorithm.
@@ -152,79 +352,91 @@ func (rm *RunwayManager) GetActiveConfiguration() map[string]*event.ActiveRunway
 //
 // NOT thread-safe: Must be called while holding write lock.
 func (rm *RunwayManager) computeMaximalCliques() {
-	if rm.compatibility == nil {
-		// No compatibility defined, all runways form one maximal clique
-		allIDs := make([]string, 0, len(rm.allRunways))
-		for _, runway := range rm.allRunways {
-			allIDs = append(allIDs, runway.RunwayDesignation)
-		}
-		rm.maximalCliques = [][]string{allIDs}
-		rm.maximalCliquesComputed = true
-		return
+	all := newRunwaySet(len(rm.allRunways))
+	for i := range rm.allRunways {
+		all.add(i)
 	}
 
-	// Build initial sets for Bron-Kerbosch
-	// R = empty (current clique being built)
-	// P = all vertices (candidates)
-	// X = empty (already processed)
-	R := []string{}
-	P := make([]string, 0, len(rm.allRunways))
-	X := []string{}
+	// Runway pairs outside their compatibility hours are incompatible until the hours return
+	compatibility := rm.compatibility.At(rm.timeOfDay())
 
-	for _, runway := range rm.allRunways {
-		P = append(P, runway.RunwayDesignation)
+	var result [][]int
+	if compatibility == nil {
+		// No compatibility defined, all runways form one maximal clique
+		result = [][]int{all.members()}
+	} else {
+		// Build the adjacency of each runway once, so the recursion is pure set arithmetic
+		allIDs := rm.getAllRunwayIDs()
+		adjacency := make([]runwaySet, len(rm.allRunways))
+		for i, runwayID := range allIDs {
+			adjacency[i] = newRunwaySet(len(rm.allRunways))
+			for _, neighbor := range compatibility.GetCompatibleRunways(runwayID, allIDs) {
+				if j, ok := rm.runwayIndex[neighbor]; ok && j != i {
+					adjacency[i].add(j)
+				}
+			}
+		}
+
+		// R = empty (current clique being built)
+		// P = all vertices (candidates)
+		// X = empty (already processed)
+		bronKerbosch(nil, all, newRunwaySet(len(rm.allRunways)), adjacency, &result)
 	}
 
-	result := make([][]string, 0)
-	rm.bronKerbosch(R, P, X, &result)
-	rm.maximalCliques = result
+	rm.maximalCliques = make([][]string, 0, len(result))
+	rm.cliqueSets = make([]runwaySet, 0, len(result))
+	for _, indices := range result {
+		clique := make([]string, len(indices))
+		set := newRunwaySet(len(rm.allRunways))
+		for k, i := range indices {
+			clique[k] = rm.allRunways[i].RunwayDesignation
+			set.add(i)
+		}
+		rm.maximalCliques = append(rm.maximalCliques, clique)
+		rm.cliqueSets = append(rm.cliqueSets, set)
+	}
 	rm.maximalCliquesComputed = true
 }
 
-// bronKerbosch implements the Bron-Kerbosch algorithm for finding all maximal cliques.
-// This is a recursive backtracking algorithm.
+// bronKerbosch implements the Bron-Kerbosch algorithm with pivoting for finding all
+// maximal cliques. Choosing the pivot with the most candidate neighbours means only
+// candidates outside its neighbourhood are branched on, which keeps dense graphs with many
+// runway entries tractable.
 //
 // Parameters:
-//   - R: Current clique being built
+//   - R: Current clique being built (runway indices)
 //   - P: Candidate vertices that could extend R
 //   - X: Vertices already processed (excluded from further consideration)
+//   - adjacency: Compatible runways of each runway
 //   - result: Accumulator for all maximal cliques found
-//
-// NOT thread-safe: Must be called while holding write lock.
-func (rm *RunwayManager) bronKerbosch(R, P, X []string, result *[][]string) {
+func bronKerbosch(R []int, P, X runwaySet, adjacency []runwaySet, result *[][]int) {
 	// Base case: if P and X are both empty, R is a maximal clique
-	if len(P) == 0 && len(X) == 0 {
+	if P.isEmpty() && X.isEmpty() {
 		// Copy R to result (avoid reference issues)
-		clique := make([]string, len(R))
-		copy(clique, R)
-		*result = append(*result, clique)
+		*result = append(*result, append([]int(nil), R...))
 		return
 	}
 
-	// Iterate over a copy of P since we'll be modifying it
-	PCopy := make([]string, len(P))
-	copy(PCopy, P)
-
-	for _, v := range PCopy {
-		// Get neighbors of v (runways compatible with v)
-		neighbors := rm.compatibility.GetCompatibleRunways(v, rm.getAllRunwayIDs())
-
-		// R ∪ {v}
-		newR := append([]string{}, R...)
-		newR = append(newR, v)
-
-		// P ∩ N(v)
-		newP := intersection(P, neighbors)
-
-		// X ∩ N(v)
-		newX := intersection(X, neighbors)
+	// Pivot on the vertex in P ∪ X with the most neighbours in P
+	pivot, pivotDegree := -1, -1
+	for _, set := range []runwaySet{P, X} {
+		for _, u := range set.members() {
+			if degree := P.intersectionCount(adjacency[u]); degree > pivotDegree {
+				pivot, pivotDegree = u, degree
+			}
+		}
+	}
 
-		// Recursive call
-		rm.bronKerbosch(newR, newP, newX, result)
+	P = P.clone()
+	X = X.clone()
+	for _, v := range P.difference(adjacency[pivot]).members() {
+		// R ∪ {v}, P ∩ N(v), X ∩ N(v)
+		newR := append(append([]int(nil), R...), v)
+		bronKerbosch(newR, P.intersect(adjacency[v]), X.intersect(adjacency[v]), adjacency, result)
 
 		// Move v from P to X
-		P = removeElement(P, v)
-		X = append(X, v)
+		P.remove(v)
+		X.add(v)
 	}
 }
 
@@ -233,8 +445,13 @@ func (rm *RunwayManager) bronKerbosch(R, P, X []string, result *[][]string) {
 //
 // Algorithm:
 //  1. Filter maximal cliques to only include those that are subsets of available runways
-//  2. For each valid clique, calculate total capacity
-//  3. Select the clique with highest capacity (prefer fewer runways on tie)
+//  2. For each valid clique, calculate its score (capacity, weighted by headwind if configured)
+//  3. Select the clique with the highest score, breaking ties by the manager's options
+//     (preferred runways, then fewer or more runways; see RunwayManagerOptions)
+//
+// Both the valid cliques and the selection are memoized by the set of available runways,
+// so revisiting an availability state (e.g., a runway reopening after maintenance) costs a
+// map lookup.
 //
 // Returns the runway IDs that should be active, or empty slice if no valid configuration.
 //
@@ -254,54 +471,76 @@ func (rm *RunwayManager) selectMaxCapacityConfig(availableIDs []string) []string
 		rm.computeMaximalCliques()
 	}
 
+	// Reuse the selection if these runways were usable together before in the same conditions
+	available := newRunwaySet(len(rm.allRunways))
+	for _, runwayID := range availableIDs {
+		if i, ok := rm.runwayIndex[runwayID]; ok {
+			available.add(i)
+		}
+	}
+	key := available.key()
+	if config, ok := rm.selectedConfigs[key]; ok {
+		return config
+	}
+
 	// Find valid cliques (subsets of available runways)
+	candidates, ok := rm.candidateCliques[key]
+	if !ok {
+		for i, set := range rm.cliqueSets {
+			if set.isSubsetOf(available) {
+				candidates = append(candidates, i)
+			}
+		}
+		rm.candidateCliques[key] = candidates
+	}
+
 	var bestConfig []string
-	var bestCapacity float32 = 0
+	var bestScore float32
 
-	for _, clique := range rm.maximalCliques {
-		// Check if this clique is a subset of available runways
-		if !isSubset(clique, availableIDs) {
-			continue
-		}
+	for _, i := range candidates {
+		clique := rm.maximalCliques[i]
 
-		// Calculate capacity for this configuration
-		capacity := rm.calculateConfigCapacity(clique)
+		// Calculate capacity for this configuration once airspace conflicts are resolved
+		// (only airspace conflicts can drop runways, so other cliques are rated directly)
+		runwayIDs := clique
+		if len(rm.compatibility.AirspaceConflicts) > 0 {
+			runwayIDs = configurationRunwayIDs(rm.buildConfiguration(clique))
+		}
+		score := rm.calculateConfigScore(runwayIDs)
 
 		// Select this config if:
-		// 1. It has higher capacity, OR
-		// 2. It has same capacity but fewer runways (simpler operations)
-		if capacity > bestCapacity || (capacity == bestCapacity && len(clique) < len(bestConfig)) {
-			bestCapacity = capacity
+		// 1. It is the first candidate, OR
+		// 2. It has a higher score, OR
+		// 3. It has the same score and is preferred by the tie-break options
+		if bestConfig == nil || score > bestScore || (score == bestScore && rm.preferConfig(clique, bestConfig)) {
+			bestScore = score
 			bestConfig = clique
 		}
 	}
 
+	rm.selectedConfigs[key] = bestConfig
 	return bestConfig
 }
 
-// calculateConfigCapacity calculates the total theoretical capacity for a runway configuration.
-// Capacity is based on the sum of individual runway capacities (duration / separation time).
+// calculateConfigCapacity calculates the total theoretical capacity for a runway configuration
+// with every runway operating mixed mode in its forward direction, using the manager's
+// capacity model (by default the sum of individual runway capacities, duration / separation
+// time, with separations adjusted for the fleet mix and de-rated for runways that interact
+// when operating together; see SeparationModel).
 //
-// For this calculation, we use a standard reference duration of 1 hour.
+// Capacity is rated over the options' reference duration (1 hour by default).
 //
 // NOT thread-safe: Must be called while holding write lock.
 func (rm *RunwayManager) calculateConfigCapacity(runwayIDs []string) float32 {
-	capacity := float32(0)
-	const referenceDurationSeconds = 3600.0 // 1 hour
-
+	config := make(map[string]*event.ActiveRunwayInfo, len(runwayIDs))
 	for _, runwayID := range runwayIDs {
 		runway, found := rm.findRunwayByID(runwayID)
 		if !found {
 			continue
 		}
-
-		separationSeconds := float32(runway.MinimumSeparation.Seconds())
-		if separationSeconds > 0 {
-			capacity += referenceDurationSeconds / separationSeconds
-		}
+		config[runwayID] = &event.ActiveRunwayInfo{RunwayDesignation: runwayID, Runway: runway}
 	}
-
-	return capacity
+	return rm.rateConfiguration(config)
 }
 
 // getAvailableRunwayIDs returns a list of currently available runway IDs.
@@ -341,49 +580,6 @@ func (rm *RunwayManager) findRunwayByID(runwayID string) (airport.Runway, bool)
 	return airport.Runway{}, false
 }
 
-// Helper functions for set operations
-
-// intersection returns elements that appear in both slices.
-func intersection(a, b []string) []string {
-	set := make(map[string]bool)
-	for _, item := range b {
-		set[item] = true
-	}
-
-	result := make([]string, 0)
-	for _, item := range a {
-		if set[item] {
-			result = append(result, item)
-		}
-	}
-	return result
-}
-
-// isSubset checks if all elements of subset are in superset.
-func isSubset(subset, superset []string) bool {
-	superMap := make(map[string]bool)
-	for _, item := range superset {
-		superMap[item] = true
-	}
-
-	for _, item := range subset {
-		if !superMap[item] {
-			return false
-		}
-	}
-	return true
-}
-
-// removeElement removes the first occurrence of an element from a slice.
-func removeElement(slice []string, element string) []string {
-	for i, item := range slice {
-		if item == element {
-			return append(slice[:i], slice[i+1:]...)
-		}
-	}
-	return slice
-}
-
 // filterRunwaysByWind filters the provided runway IDs to only include runways
 // that are usable under current wind conditions based on their crosswind and tailwind limits.
 //
@@ -408,7 +604,7 @@ func (rm *RunwayManager) filterRunwaysByWind(runwayIDs []string) []string {
 		}
 
 		// Skip if runway has no limits set (0 means no limit, so always usable)
-		if runway.CrosswindLimitKnots == 0 && runway.TailwindLimitKnots == 0 {
+		if !runway.HasWindLimits() {
 			usable = append(usable, runwayID)
 			continue
 		}
@@ -427,48 +623,45 @@ func (rm *RunwayManager) filterRunwaysByWind(runwayIDs []string) []string {
 //
 // NOT thread-safe: Must be called while holding read or write lock.
 func (rm *RunwayManager) isRunwayUsableInEitherDirection(runway airport.Runway) bool {
-	// Check forward direction
-	headwind, crosswind := policy.CalculateWindComponents(
-		runway.TrueBearing,
-		rm.windSpeed,
-		rm.windDirection,
-	)
-
-	// Forward direction is usable if within limits
-	forwardUsable := true
-	if runway.CrosswindLimitKnots > 0 && crosswind > runway.CrosswindLimitKnots {
-		forwardUsable = false
-	}
-	if runway.TailwindLimitKnots > 0 && headwind < -runway.TailwindLimitKnots {
-		forwardUsable = false
-	}
+	return runwayUsableInWind(runway, rm.windSpeed, rm.windGust, rm.windDirection)
+}
 
+// runwayUsableInWind checks if a runway can operate in at least one direction
+// (forward or reverse) in the given wind without exceeding its crosswind or tailwind limits,
+// as reduced for its current surface condition. Each end is checked against its own limits.
+// Limits are checked at the gust speed when gusts are reported (gustKnots > 0).
+func runwayUsableInWind(runway airport.Runway, speedKnots, gustKnots, directionTrue float64) bool {
+	_, forwardUsable := runwayDirectionInWind(runway, event.Forward, speedKnots, gustKnots, directionTrue)
 	if forwardUsable {
 		return true
 	}
 
-	// Check reverse direction (reciprocal bearing: +/- 180 degrees)
-	reverseBearing := runway.TrueBearing + 180
-	if reverseBearing >= 360 {
-		reverseBearing -= 360
-	}
+	_, reverseUsable := runwayDirectionInWind(runway, event.Reverse, speedKnots, gustKnots, directionTrue)
+	return reverseUsable
+}
 
-	headwindRev, crosswindRev := policy.CalculateWindComponents(
-		reverseBearing,
-		rm.windSpeed,
-		rm.windDirection,
-	)
+// runwayDirectionInWind returns the mean headwind component when operating the runway in
+// direction, and whether the crosswind and tailwind components, including gusts, are within
+// the limits of the runway end in use (0 means no limit).
+func runwayDirectionInWind(runway airport.Runway, direction event.Direction, speedKnots, gustKnots, directionTrue float64) (headwind float64, usable bool) {
+	reverse := direction == event.Reverse
+	crosswindLimit, tailwindLimit := runway.EndWindLimitsKnots(reverse)
+	bearing := runway.End(reverse).TrueBearing
 
-	// Reverse direction is usable if within limits
-	reverseUsable := true
-	if runway.CrosswindLimitKnots > 0 && crosswindRev > runway.CrosswindLimitKnots {
-		reverseUsable = false
-	}
-	if runway.TailwindLimitKnots > 0 && headwindRev < -runway.TailwindLimitKnots {
-		reverseUsable = false
+	headwind, crosswind := policy.CalculateWindComponents(bearing, speedKnots, directionTrue)
+	limitHeadwind := headwind
+	if gustKnots > speedKnots {
+		limitHeadwind, crosswind = policy.CalculateWindComponents(bearing, gustKnots, directionTrue)
 	}
 
-	return reverseUsable
+	usable = true
+	if crosswindLimit > 0 && crosswind > crosswindLimit {
+		usable = false
+	}
+	if tailwindLimit > 0 && limitHeadwind < -tailwindLimit {
+		usable = false
+	}
+	return headwind, usable
 }
 
 // determineRunwayDirection determines the optimal direction (Forward or Reverse) for a runway
@@ -483,42 +676,8 @@ func (rm *RunwayManager) determineRunwayDirection(runway airport.Runway) event.D
 		return event.Forward
 	}
 
-	// Calculate headwind for forward direction
-	headwindForward, crosswindForward := policy.CalculateWindComponents(
-		runway.TrueBearing,
-		rm.windSpeed,
-		rm.windDirection,
-	)
-
-	// Check if forward direction violates limits
-	forwardUsable := true
-	if runway.CrosswindLimitKnots > 0 && crosswindForward > runway.CrosswindLimitKnots {
-		forwardUsable = false
-	}
-	if runway.TailwindLimitKnots > 0 && headwindForward < -runway.TailwindLimitKnots {
-		forwardUsable = false
-	}
-
-	// Calculate headwind for reverse direction
-	reverseBearing := runway.TrueBearing + 180
-	if reverseBearing >= 360 {
-		reverseBearing -= 360
-	}
-
-	headwindReverse, crosswindReverse := policy.CalculateWindComponents(
-		reverseBearing,
-		rm.windSpeed,
-		rm.windDirection,
-	)
-
-	// Check if reverse direction violates limits
-	reverseUsable := true
-	if runway.CrosswindLimitKnots > 0 && crosswindReverse > runway.CrosswindLimitKnots {
-		reverseUsable = false
-	}
-	if runway.TailwindLimitKnots > 0 && headwindReverse < -runway.TailwindLimitKnots {
-		reverseUsable = false
-	}
+	headwindForward, forwardUsable := runwayDirectionInWind(runway, event.Forward, rm.windSpeed, rm.windGust, rm.windDirection)
+	headwindReverse, reverseUsable := runwayDirectionInWind(runway, event.Reverse, rm.windSpeed, rm.windGust, rm.windDirection)
 
 	// If only one direction is usable, use that
 	if forwardUsable && !reverseUsable {
@@ -544,12 +703,25 @@ func (rm *RunwayManager) determineRunwayDirection(runway airport.Runway) event.D
 //  1. If curfew is active, no runways are active (return empty)
 //  2. Get all available runways
 //  3. Filter runways by wind constraints (crosswind/tailwind limits)
-//  4. Use compatibility graph to select maximum capacity configuration
-//  5. Build active configuration with operation type and direction (wind-based)
+//  4. Use the first noise-preferential configuration that can operate, if any, in the
+//     directions it gives (see OnNoisePreferencesChanged)
+//  5. Otherwise use compatibility graph to select maximum capacity configuration
+//  6. Build active configuration with operation type and direction (wind-based),
+//     restricting operation types to resolve terminal airspace conflicts
+//
+// The reason identifies the notification in any resulting RunwayConfigurationChange record.
+// Wind and noise-preference changes are discretionary, so the current configuration is kept
+// instead while hysteresis holds it (see holdConfiguration), whichever way the new one would
+// be selected.
 //
 // NOT thread-safe: Must be called while holding write lock (mu.Lock).
 // This is a private method always called by lock-holding public methods.
-func (rm *RunwayManager) calculateActiveConfiguration() {
+func (rm *RunwayManager) calculateActiveConfiguration(reason ConfigurationChangeReason) {
+	if rm.holdConfiguration(reason) {
+		return
+	}
+	defer rm.configurationSelected(rm.currentConfiguration, reason)
+
 	// Clear current configuration
 	rm.currentConfiguration = make(map[string]*event.ActiveRunwayInfo)
 
@@ -558,6 +730,12 @@ func (rm *RunwayManager) calculateActiveConfiguration() {
 		return
 	}
 
+	// Use the most preferred noise-preferential configuration that can operate
+	if config := rm.selectNoisePreferredConfig(); config != nil {
+		rm.currentConfiguration = config
+		return
+	}
+
 	// Get available runway IDs (not under maintenance)
 	availableIDs := rm.getAvailableRunwayIDs()
 
@@ -568,7 +746,27 @@ func (rm *RunwayManager) calculateActiveConfiguration() {
 	optimalConfig := rm.selectMaxCapacityConfig(windUsableIDs)
 
 	// Build active configuration for the selected runways
-	for _, runwayID := range optimalConfig {
+	rm.currentConfiguration = rm.buildConfiguration(optimalConfig)
+}
+
+// buildConfiguration builds the active runway information for a set of runways, choosing
+// each runway's direction from the wind and restricting operation types where terminal
+// airspace conflicts prevent runway ends from operating together.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) buildConfiguration(runwayIDs []string) map[string]*event.ActiveRunwayInfo {
+	return rm.buildConfigurationInDirections(runwayIDs, nil)
+}
+
+// buildConfigurationInDirections builds the active runway information for a set of runways
+// like buildConfiguration, operating each runway in directions in the given direction instead
+// of choosing it from the wind.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) buildConfigurationInDirections(runwayIDs []string, directions map[string]event.Direction) map[string]*event.ActiveRunwayInfo {
+	config := make(map[string]*event.ActiveRunwayInfo, len(runwayIDs))
+
+	for _, runwayID := range runwayIDs {
 		runway, found := rm.findRunwayByID(runwayID)
 		if !found {
 			continue
@@ -578,13 +776,102 @@ func (rm *RunwayManager) calculateActiveConfiguration() {
 		// For now, all runways handle mixed operations
 
 		// Determine optimal direction based on wind (prefer maximum headwind)
-		direction := rm.determineRunwayDirection(runway)
+		direction, given := directions[runwayID]
+		if !given {
+			direction = rm.determineRunwayDirection(runway)
+		}
 
-		rm.currentConfiguration[runwayID] = &event.ActiveRunwayInfo{
+		config[runwayID] = &event.ActiveRunwayInfo{
 			RunwayDesignation: runwayID,
 			OperationType:     event.Mixed, // Default: handle both takeoffs and landings
 			Direction:         direction,   // Wind-based direction selection
 			Runway:            runway,
 		}
 	}
+
+	rm.resolveAirspaceConflicts(config)
+
+	return config
+}
+
+// resolveAirspaceConflicts restricts operation types so that no two active runway ends
+// perform operations declared to conflict in terminal airspace.
+//
+// For each conflict that applies, the conflicting runway is restricted to its other operation
+// if it is Mixed; otherwise the declaring runway is restricted if it is Mixed; if both already
+// handle a single operation, the conflicting runway is removed from the configuration.
+// Conflicts are applied in declaration order until none remain. Every step removes an
+// operation, so resolution always terminates.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) resolveAirspaceConflicts(config map[string]*event.ActiveRunwayInfo) {
+	if rm.compatibility == nil || len(rm.compatibility.AirspaceConflicts) == 0 {
+		return
+	}
+
+	findEnd := func(runwayEnd string) *event.ActiveRunwayInfo {
+		for _, info := range config {
+			if info.RunwayEnd() == runwayEnd {
+				return info
+			}
+		}
+		return nil
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, conflict := range rm.compatibility.AirspaceConflicts {
+			first := findEnd(conflict.RunwayEnd)
+			second := findEnd(conflict.ConflictingRunwayEnd)
+			if first == nil || second == nil || first == second {
+				continue
+			}
+			if !handlesOperation(first.OperationType, conflict.Operation) ||
+				!handlesOperation(second.OperationType, conflict.ConflictingOperation) {
+				continue
+			}
+
+			switch {
+			case second.OperationType == event.Mixed:
+				second.OperationType = withoutOperation(conflict.ConflictingOperation)
+			case first.OperationType == event.Mixed:
+				first.OperationType = withoutOperation(conflict.Operation)
+			default:
+				delete(config, second.RunwayDesignation)
+			}
+			changed = true
+		}
+	}
+}
+
+// handlesOperation reports whether a runway with the given operation type performs op.
+func handlesOperation(opType event.OperationType, op airport.RunwayOperation) bool {
+	switch opType {
+	case event.Mixed:
+		return true
+	case event.LandingOnly:
+		return op == airport.Arrivals
+	case event.TakeoffOnly:
+		return op == airport.Departures
+	default:
+		return false
+	}
+}
+
+// withoutOperation returns the single-operation type that excludes op.
+func withoutOperation(op airport.RunwayOperation) event.OperationType {
+	if op == airport.Arrivals {
+		return event.TakeoffOnly
+	}
+	return event.LandingOnly
+}
+
+// configurationRunwayIDs returns the runway IDs in a configuration in sorted order.
+func configurationRunwayIDs(config map[string]*event.ActiveRunwayInfo) []string {
+	ids := make([]string, 0, len(config))
+	for id := range config {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
 }
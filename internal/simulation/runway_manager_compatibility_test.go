@@ -1,11 +1,15 @@
 package simulation
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
 // Helper function to compare string slices without regard to order
@@ -177,9 +181,9 @@ func TestRunwayManager_Compatibility_CapacityBasedSelection(t *testing.T) {
 // Multiple configurations with same capacity - prefer simpler one
 func TestRunwayManager_Compatibility_TieBreaking(t *testing.T) {
 	runways := []airport.Runway{
-		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 120 * time.Second},  // 30 mvmt/hr
-		{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 120 * time.Second},  // 30 mvmt/hr
-		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},   // 60 mvmt/hr
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 120 * time.Second}, // 30 mvmt/hr
+		{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 120 * time.Second}, // 30 mvmt/hr
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},  // 60 mvmt/hr
 	}
 
 	// 09L and 09R parallel, 18 crosses both
@@ -484,3 +488,333 @@ func TestRunwayManager_Compatibility_ThreadSafety(t *testing.T) {
 		t.Error("Final configuration should not be nil")
 	}
 }
+
+// Test: Airspace conflict restricts operation type
+// 09L and 18 are compatible on the ground, but the 09L missed approach conflicts with 18 departures
+func TestRunwayManager_Compatibility_AirspaceConflict(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second},
+	}
+
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"18"},
+		"18":  {"09L"},
+	})
+	compat.AirspaceConflicts = []airport.AirspaceConflict{
+		{RunwayEnd: "09L", Operation: airport.Arrivals, ConflictingRunwayEnd: "18", ConflictingOperation: airport.Departures},
+	}
+
+	rm := NewRunwayManager(runways, compat)
+	config := rm.GetActiveConfiguration()
+
+	if len(config) != 2 {
+		t.Fatalf("Expected 2 active runways, got %d", len(config))
+	}
+	if config["09L"].OperationType != event.Mixed {
+		t.Errorf("Expected 09L to stay Mixed, got %s", config["09L"].OperationType)
+	}
+	if config["18"].OperationType != event.LandingOnly {
+		t.Errorf("Expected 18 restricted to LandingOnly, got %s", config["18"].OperationType)
+	}
+
+	// Westerly wind turns 09L into 27R, so the conflict on the 09L end no longer applies
+	rm.OnWindChanged(10, 270)
+	config = rm.GetActiveConfiguration()
+	if config["09L"].RunwayEnd() != "27R" {
+		t.Fatalf("Expected 09L operating as 27R, got %s", config["09L"].RunwayEnd())
+	}
+	for id, info := range config {
+		if info.OperationType != event.Mixed {
+			t.Errorf("Expected %s to be Mixed, got %s", id, info.OperationType)
+		}
+	}
+}
+
+// Test: Airspace conflicts that cannot be resolved by restricting operations drop a runway
+func TestRunwayManager_Compatibility_AirspaceConflictDropsRunway(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 90 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second},
+	}
+
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"18"},
+		"18":  {"09L"},
+	})
+	for _, first := range []airport.RunwayOperation{airport.Arrivals, airport.Departures} {
+		for _, second := range []airport.RunwayOperation{airport.Arrivals, airport.Departures} {
+			compat.AirspaceConflicts = append(compat.AirspaceConflicts, airport.AirspaceConflict{
+				RunwayEnd: "09L", Operation: first, ConflictingRunwayEnd: "18", ConflictingOperation: second,
+			})
+		}
+	}
+
+	rm := NewRunwayManager(runways, compat)
+	config := rm.GetActiveConfiguration()
+
+	if len(config) != 1 {
+		t.Fatalf("Expected 1 active runway, got %d", len(config))
+	}
+	if _, ok := config["09L"]; !ok {
+		t.Errorf("Expected 09L to remain active, got %v", configurationRunwayIDs(config))
+	}
+}
+
+// createLargeCompatibilityAirport builds n runway ends where ends sharing a bearing group
+// operate together and ends in neighbouring groups cross, giving many maximal cliques.
+func createLargeCompatibilityAirport(n int) ([]airport.Runway, *airport.RunwayCompatibility) {
+	const groups = 6
+	runways := make([]airport.Runway, n)
+	for i := range runways {
+		runways[i] = airport.Runway{
+			RunwayDesignation: fmt.Sprintf("R%02d", i),
+			TrueBearing:       float64(i%groups) * 30,
+			MinimumSeparation: time.Duration(60+i%7*5) * time.Second,
+		}
+	}
+
+	compatibleWith := make(map[string][]string, n)
+	for i := range runways {
+		compatibleWith[runways[i].RunwayDesignation] = []string{}
+		for j := range runways {
+			gap := (i%groups - j%groups + groups) % groups
+			if i != j && gap != 1 && gap != groups-1 {
+				compatibleWith[runways[i].RunwayDesignation] = append(compatibleWith[runways[i].RunwayDesignation], runways[j].RunwayDesignation)
+			}
+		}
+	}
+	return runways, airport.NewRunwayCompatibility(compatibleWith)
+}
+
+// Test: Pivoted enumeration on a large airport finds exactly the maximal cliques
+// Bearing groups form a 6-cycle of crossings, whose maximal non-crossing group sets are
+// {0,2,4}, {1,3,5}, {0,3}, {1,4} and {2,5}
+func TestRunwayManager_Compatibility_LargeAirportCliques(t *testing.T) {
+	runways, compat := createLargeCompatibilityAirport(36)
+	rm := NewRunwayManager(runways, compat)
+
+	rm.mu.Lock()
+	rm.computeMaximalCliques()
+	cliques := rm.maximalCliques
+	rm.mu.Unlock()
+
+	if len(cliques) != 5 {
+		t.Fatalf("Expected 5 maximal cliques, got %d", len(cliques))
+	}
+	for _, clique := range cliques {
+		for _, a := range clique {
+			for _, b := range clique {
+				if !compat.IsCompatible(a, b) {
+					t.Errorf("Clique %v contains incompatible runways %s and %s", clique, a, b)
+				}
+			}
+		}
+	}
+}
+
+// Test: Memoized selections are discarded when runway characteristics change
+func TestRunwayManager_Compatibility_MemoizedSelectionInvalidated(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second},
+	}
+	compat := airport.NewRunwayCompatibility(map[string][]string{"09": {}, "18": {}})
+	rm := NewRunwayManager(runways, compat)
+
+	if _, exists := rm.GetActiveConfiguration()["09"]; !exists {
+		t.Fatal("Expected higher capacity 09 to be selected")
+	}
+
+	// 18 becomes the faster runway; revisiting the same availability must reselect
+	faster := runways[1]
+	faster.MinimumSeparation = 45 * time.Second
+	rm.OnRunwayModified(faster)
+	rm.OnRunwayUnavailable("09")
+	rm.OnRunwayAvailable("09")
+
+	if _, exists := rm.GetActiveConfiguration()["18"]; !exists {
+		t.Errorf("Expected 18 to be selected after becoming faster, got %v", rm.GetActiveConfiguration())
+	}
+}
+
+func TestRunwayManager_Options_TieBreaking(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 120 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 120 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+	}
+	compat := airport.NewRunwayCompatibility(map[string][]string{
+		"09L": {"09R"},
+		"09R": {"09L"},
+		"18":  {},
+	})
+	rm := NewRunwayManager(runways, compat)
+
+	// Both configs have 60 mvmt/hr: {09L, 09R} or {18}
+	rm.SetOptions(RunwayManagerOptions{TieBreak: PreferMoreRunways})
+	if config := rm.GetActiveConfiguration(); !containsSameElements(configurationRunwayIDs(config), []string{"09L", "09R"}) {
+		t.Errorf("Expected PreferMoreRunways to select {09L, 09R}, got %v", configurationRunwayIDs(config))
+	}
+
+	// Preferred runways are considered before the tie-break strategy
+	rm.SetOptions(RunwayManagerOptions{TieBreak: PreferMoreRunways, PreferredRunways: []string{"18"}})
+	if config := rm.GetActiveConfiguration(); !containsSameElements(configurationRunwayIDs(config), []string{"18"}) {
+		t.Errorf("Expected preferred runway 18 to be selected, got %v", configurationRunwayIDs(config))
+	}
+
+	// Preferences never override a higher capacity
+	rm.OnRunwayUnavailable("09R")
+	rm.SetOptions(RunwayManagerOptions{PreferredRunways: []string{"09L"}})
+	if config := rm.GetActiveConfiguration(); !containsSameElements(configurationRunwayIDs(config), []string{"18"}) {
+		t.Errorf("Expected higher capacity 18 over preferred 09L, got %v", configurationRunwayIDs(config))
+	}
+}
+
+func TestRunwayManager_Options_HeadwindWeight(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 88 * time.Second},  // ~40.9 mvmt/hr
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second}, // 40 mvmt/hr
+	}
+	compat := airport.NewRunwayCompatibility(map[string][]string{"09": {}, "18": {}})
+	rm := NewRunwayManager(runways, compat)
+
+	// 20 knots straight down runway 18 (no headwind on 09)
+	rm.OnWindChanged(20, 180)
+	if _, exists := rm.GetActiveConfiguration()["09"]; !exists {
+		t.Fatalf("Expected higher capacity 09 without headwind weighting, got %v", rm.GetActiveConfiguration())
+	}
+
+	rm.SetOptions(RunwayManagerOptions{HeadwindWeight: 0.1})
+	if _, exists := rm.GetActiveConfiguration()["18"]; !exists {
+		t.Errorf("Expected headwind weighting to select 18, got %v", rm.GetActiveConfiguration())
+	}
+
+	// Rating capacity over a longer period shrinks the headwind weighting's relative effect
+	rm.SetOptions(RunwayManagerOptions{HeadwindWeight: 0.1, ReferenceDuration: 24 * time.Hour})
+	if _, exists := rm.GetActiveConfiguration()["09"]; !exists {
+		t.Errorf("Expected capacity to dominate over a 24 hour reference, got %v", rm.GetActiveConfiguration())
+	}
+}
+
+func TestRunwayManager_CapacityInteractions(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 55 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+	}
+	compatibility := map[string][]string{
+		"09L": {"09R", "18"},
+		"09R": {"09L"},
+		"18":  {"09L"},
+	}
+
+	// {09L, 09R} handles ~125 mvmt/hr, more than the 120 of {09L, 18}
+	rm := NewRunwayManager(runways, airport.NewRunwayCompatibility(compatibility))
+	if config := rm.GetActiveConfiguration(); !containsSameElements(configurationRunwayIDs(config), []string{"09L", "09R"}) {
+		t.Errorf("Expected {09L, 09R} without interactions, got %v", configurationRunwayIDs(config))
+	}
+
+	// De-rated to ~107 mvmt/hr as dependent parallels, {09L, 18} is better
+	rm = NewRunwayManager(runways, &airport.RunwayCompatibility{
+		CompatibleWith:       compatibility,
+		CapacityInteractions: []airport.CapacityInteraction{{Runway: "09L", OtherRunway: "09R", Factor: 0.85}},
+	})
+	if config := rm.GetActiveConfiguration(); !containsSameElements(configurationRunwayIDs(config), []string{"09L", "18"}) {
+		t.Errorf("Expected {09L, 18} with dependent parallels, got %v", configurationRunwayIDs(config))
+	}
+}
+
+func TestRunwayManager_CompatibilityHours(t *testing.T) {
+	runways := []airport.Runway{
+		{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+		{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 90 * time.Second},
+	}
+	// Land and hold short in daylight only
+	rm := NewRunwayManager(runways, &airport.RunwayCompatibility{
+		CompatibleWith: map[string][]string{"09": {"18"}, "18": {"09"}},
+		Hours:          []airport.CompatibilityHours{{Runway: "09", OtherRunway: "18", From: 6 * time.Hour, Until: 20 * time.Hour}},
+	})
+
+	midnight := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		at   time.Duration
+		want []string
+	}{
+		{5 * time.Hour, []string{"09"}},
+		{6 * time.Hour, []string{"09", "18"}},
+		{19 * time.Hour, []string{"09", "18"}},
+		{20 * time.Hour, []string{"09"}},
+	} {
+		rm.SetTime(midnight.Add(tt.at))
+		if got := configurationRunwayIDs(rm.GetActiveConfiguration()); !containsSameElements(got, tt.want) {
+			t.Errorf("At %v: expected %v, got %v", tt.at, tt.want, got)
+		}
+	}
+
+	// Hours are local times: 06:00 in New York is 10:00 UTC in summer
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	rm.SetLocation(newYork)
+	rm.SetTime(midnight.Add(8 * time.Hour))
+	if got := configurationRunwayIDs(rm.GetActiveConfiguration()); !containsSameElements(got, []string{"09"}) {
+		t.Errorf("Expected 04:00 local to be outside the hours, got %v", got)
+	}
+	rm.SetTime(midnight.Add(10 * time.Hour))
+	if got := configurationRunwayIDs(rm.GetActiveConfiguration()); !containsSameElements(got, []string{"09", "18"}) {
+		t.Errorf("Expected 06:00 local to be within the hours, got %v", got)
+	}
+}
+
+func TestRunwayManagerOptions_Validate(t *testing.T) {
+	runways := []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}}
+
+	if err := (RunwayManagerOptions{PreferredRunways: []string{"09"}, HeadwindWeight: 1}).Validate(runways); err != nil {
+		t.Errorf("Unexpected error for valid options: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options RunwayManagerOptions
+	}{
+		{"negative reference duration", RunwayManagerOptions{ReferenceDuration: -time.Hour}},
+		{"unknown tie-break", RunwayManagerOptions{TieBreak: TieBreakStrategy(7)}},
+		{"unknown preferred runway", RunwayManagerOptions{PreferredRunways: []string{"27"}}},
+		{"negative headwind weight", RunwayManagerOptions{HeadwindWeight: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(runways); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
+
+// BenchmarkRunwayManager_MaximalCliques measures enumerating the maximal compatible runway
+// sets of a large airport.
+func BenchmarkRunwayManager_MaximalCliques(b *testing.B) {
+	runways, compat := createLargeCompatibilityAirport(36)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		NewRunwayManager(runways, compat)
+	}
+}
+
+// BenchmarkRunwayManager_AvailabilityChanges measures configuration selection when runways
+// at a large airport repeatedly close and reopen, revisiting the same availability states.
+func BenchmarkRunwayManager_AvailabilityChanges(b *testing.B) {
+	runways, compat := createLargeCompatibilityAirport(36)
+	rm := NewRunwayManager(runways, compat)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		runwayID := runways[i%len(runways)].RunwayDesignation
+		rm.OnRunwayUnavailable(runwayID)
+		rm.OnRunwayAvailable(runwayID)
+	}
+}
@@ -20,7 +20,7 @@ func createTestRunways() []airport.Runway {
 		{
 			RunwayDesignation: "09R",
 			TrueBearing:       90,
-			LengthMeters:       3200,
+			LengthMeters:      3200,
 			MinimumSeparation: 90 * time.Second,
 		},
 		{
@@ -287,3 +287,208 @@ func TestRunwayManager_ConfigIsCopy(t *testing.T) {
 		t.Error("09L should still exist - external modification affected internal state")
 	}
 }
+
+func TestRunwayManager_OnRunwayModified(t *testing.T) {
+	rm := NewRunwayManager(createTestRunways(), nil)
+
+	modified := createTestRunways()[0]
+	modified.LengthMeters = 4000
+	modified.MinimumSeparation = 60 * time.Second
+	rm.OnRunwayModified(modified)
+
+	config := rm.GetActiveConfiguration()
+	info, exists := config["09L"]
+	if !exists {
+		t.Fatal("09L should remain active after modification")
+	}
+	if info.Runway.LengthMeters != 4000 {
+		t.Errorf("Expected length 4000, got %f", info.Runway.LengthMeters)
+	}
+	if info.Runway.MinimumSeparation != 60*time.Second {
+		t.Errorf("Expected separation 60s, got %v", info.Runway.MinimumSeparation)
+	}
+}
+
+func TestRunwayManager_SurfaceConditionReducesWindLimits(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, SurfaceType: airport.Asphalt, CrosswindLimitKnots: 20, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+
+	// 18 kt direct crosswind is within the dry limit
+	rm.OnWindChanged(18, 180)
+	if len(rm.GetActiveConfiguration()) != 1 {
+		t.Fatal("Expected dry runway to be usable in 18 kt crosswind")
+	}
+
+	// The wet limit of 15 kt closes the runway
+	runway.SurfaceCondition = airport.Wet
+	rm.OnRunwayModified(runway)
+	if len(rm.GetActiveConfiguration()) != 0 {
+		t.Error("Expected wet runway to be unusable in 18 kt crosswind")
+	}
+}
+
+func TestRunwayManager_GustsCheckedAgainstCrosswindLimit(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, CrosswindLimitKnots: 20, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+
+	// 15 kt direct crosswind is within the limit, but not with gusts to 25 kt
+	rm.OnWindChanged(15, 180)
+	if len(rm.GetActiveConfiguration()) != 1 {
+		t.Fatal("Expected runway to be usable in 15 kt crosswind")
+	}
+	rm.OnGustingWindChanged(15, 25, 180)
+	if len(rm.GetActiveConfiguration()) != 0 {
+		t.Error("Expected runway to be unusable in 15 kt crosswind gusting 25 kt")
+	}
+	rm.OnWindChanged(15, 180)
+	if len(rm.GetActiveConfiguration()) != 1 {
+		t.Error("Expected runway to be usable again once the gusts stop")
+	}
+}
+
+func TestRunwayManager_AsymmetricEndWindLimits(t *testing.T) {
+	// 09 has a 15 kt crosswind limit; 27 tolerates 25 kt of crosswind and 12 kt of tailwind
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, CrosswindLimitKnots: 15, ReverseCrosswindLimitKnots: 25, ReverseTailwindLimitKnots: 12, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+
+	// 20 kt from 150: 17.3 kt crosswind, 10 kt headwind on 09 and 10 kt tailwind on 27
+	rm.OnWindChanged(20, 150)
+	info, ok := rm.GetActiveConfiguration()["09"]
+	if !ok || info.Direction != event.Reverse {
+		t.Fatalf("Expected the runway in use as 27, got %v", rm.GetActiveConfiguration())
+	}
+
+	// With the same limits on both ends the runway is closed
+	runway.ReverseCrosswindLimitKnots = 0
+	rm.OnRunwayModified(runway)
+	if len(rm.GetActiveConfiguration()) != 0 {
+		t.Error("Expected the runway to be unusable with symmetric limits")
+	}
+}
+
+func TestRunwayManager_HysteresisMinimumDwell(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, TailwindLimitKnots: 5, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+	rm.SetOptions(RunwayManagerOptions{MinimumDwell: time.Hour})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	direction := func() event.Direction {
+		t.Helper()
+		info, ok := rm.GetActiveConfiguration()["09"]
+		if !ok {
+			t.Fatal("Expected runway 09 to be active")
+		}
+		return info.Direction
+	}
+
+	// The wind turns to favour 27, which becomes active
+	rm.SetTime(start)
+	rm.OnWindChanged(4, 270)
+	if direction() != event.Reverse {
+		t.Fatal("Expected 27 in use with a westerly wind")
+	}
+
+	// A light easterly within 27's tailwind limit is ignored during the dwell...
+	rm.SetTime(start.Add(20 * time.Minute))
+	rm.OnWindChanged(4, 90)
+	if direction() != event.Reverse {
+		t.Error("Expected 27 to be held during the minimum dwell")
+	}
+
+	// ...but not once the dwell has elapsed
+	rm.SetTime(start.Add(time.Hour))
+	rm.OnWindChanged(4, 90)
+	if direction() != event.Forward {
+		t.Error("Expected 09 in use once the minimum dwell elapsed")
+	}
+
+	// Exceeding a limit forces a change even during the dwell
+	rm.SetTime(start.Add(70 * time.Minute))
+	rm.OnWindChanged(10, 270)
+	if direction() != event.Reverse {
+		t.Error("Expected 09's tailwind limit to force a change to 27 during the dwell")
+	}
+}
+
+func TestRunwayManager_HysteresisWindDeadband(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+	rm.SetOptions(RunwayManagerOptions{WindDeadbandKnots: 5})
+
+	direction := func() event.Direction {
+		t.Helper()
+		return rm.GetActiveConfiguration()["09"].Direction
+	}
+
+	// A northerly wobbling either side of the runway's perpendicular: a tiny tailwind on 09...
+	rm.OnWindChanged(10, 355)
+	if direction() != event.Reverse {
+		t.Fatal("Expected 27 in use with a wind from 355")
+	}
+	// ...then a tiny headwind, a change of 1.7 kt, within the deadband
+	rm.OnWindChanged(10, 5)
+	if direction() != event.Reverse {
+		t.Error("Expected 27 to be held within the wind deadband")
+	}
+	// A change of 6 kt from the wind 27 was selected in exceeds it
+	rm.OnWindChanged(10, 30)
+	if direction() != event.Forward {
+		t.Error("Expected 09 in use once the wind moved beyond the deadband")
+	}
+}
+
+// fakeClock is a Clock advanced by hand.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestRunwayManager_ClockAndConfigurationChanges(t *testing.T) {
+	runway := airport.Runway{RunwayDesignation: "09", TrueBearing: 90, TailwindLimitKnots: 5, MinimumSeparation: 60 * time.Second}
+	rm := NewRunwayManager([]airport.Runway{runway}, nil)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rm.SetClock(clock)
+	rm.SetOptions(RunwayManagerOptions{MinimumDwell: time.Hour})
+
+	var changes []RunwayConfigurationChange
+	rm.SetConfigurationChangeHandler(func(change RunwayConfigurationChange) {
+		changes = append(changes, change)
+	})
+
+	rm.OnWindChanged(4, 270) // 09 -> 27
+	clock.Advance(20 * time.Minute)
+	rm.OnWindChanged(4, 90) // held by the dwell, read from the clock
+	clock.Advance(40 * time.Minute)
+	rm.OnWindChanged(4, 90) // 27 -> 09
+	clock.Advance(time.Hour)
+	rm.OnRunwayUnavailable("09")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := []struct {
+		at       time.Duration
+		reason   ConfigurationChangeReason
+		previous string
+		current  string
+	}{
+		{0, ReasonWind, "09", "27"},
+		{time.Hour, ReasonWind, "27", "09"},
+		{2 * time.Hour, ReasonRunwayAvailability, "09", ClosedConfigurationLabel},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if !c.Time.Equal(start.Add(w.at)) || c.Reason != w.reason ||
+			ConfigurationLabel(c.Previous) != w.previous || ConfigurationLabel(c.Current) != w.current {
+			t.Errorf("Change %d: expected %s %s -> %s at %v, got %s %s -> %s at %v", i,
+				w.reason, w.previous, w.current, w.at, c.Reason, ConfigurationLabel(c.Previous), ConfigurationLabel(c.Current), c.Time.Sub(start))
+		}
+	}
+
+	// Records are copies
+	changes[2].Previous["09"].Direction = event.Reverse
+	if rm.clone().onChange != nil {
+		t.Error("Expected clones not to inherit the change handler")
+	}
+}
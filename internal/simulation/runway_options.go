@@ -0,0 +1,356 @@
+package simulation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// defaultReferenceDuration is the period over which configuration capacity is rated when
+// RunwayManagerOptions.ReferenceDuration is not set.
+const defaultReferenceDuration = time.Hour
+
+// TieBreakStrategy decides between runway configurations that score equally.
+type TieBreakStrategy int
+
+const (
+	// PreferFewerRunways selects the configuration with fewer runways (simpler operations).
+	PreferFewerRunways TieBreakStrategy = iota
+	// PreferMoreRunways selects the configuration with more runways (more resilience).
+	PreferMoreRunways
+)
+
+// String returns the name of the tie-break strategy.
+func (s TieBreakStrategy) String() string {
+	switch s {
+	case PreferFewerRunways:
+		return "PreferFewerRunways"
+	case PreferMoreRunways:
+		return "PreferMoreRunways"
+	default:
+		return "Unknown"
+	}
+}
+
+// RunwayManagerOptions encodes an airport's operational preferences when the runway manager
+// selects among compatible runway configurations. The zero value rates configurations by
+// hourly capacity alone and prefers fewer runways on a tie.
+//
+// Each candidate configuration is scored by its capacity over ReferenceDuration plus
+// HeadwindWeight movements per knot of headwind on each runway. The highest score wins;
+// among equal scores the configuration using the most PreferredRunways wins, then TieBreak
+// decides.
+//
+// MinimumDwell and WindDeadbandKnots add hysteresis to wind-driven changes, as ATC does not
+// reconfigure the airfield for every wind report: a configuration that remains usable in the
+// new wind is kept until it has been active for MinimumDwell, and while the mean wind has
+// moved less than WindDeadbandKnots (as a vector) from the wind it was selected in. Changes of
+// noise-preferential configurations are held back for MinimumDwell in the same way. A change
+// held back takes effect at the next wind change after the hold ends. Changes forced by
+// safety limits, runway availability, or curfews are never held back.
+type RunwayManagerOptions struct {
+	ReferenceDuration time.Duration    // Period capacity is rated over (0 = 1 hour)
+	TieBreak          TieBreakStrategy // Choice between equally scored configurations
+	PreferredRunways  []string         // Runways to favour among equally scored configurations
+	HeadwindWeight    float64          // Score per knot of headwind per runway (0 = capacity only)
+	MinimumDwell      time.Duration    // Minimum time in a configuration before a wind-driven change (0 = none)
+	WindDeadbandKnots float64          // Wind change needed for a wind-driven change (0 = any change)
+}
+
+// Validate checks that the reference duration is not negative, the tie-break strategy is
+// known, every preferred runway exists in runways, and the headwind weight, minimum dwell
+// and wind deadband are not negative.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (o RunwayManagerOptions) Validate(runways []airport.Runway) error {
+	var problems []error
+
+	if o.ReferenceDuration < 0 {
+		problems = append(problems, simerrors.Invalidf("runway manager reference duration cannot be negative, got %v", o.ReferenceDuration))
+	}
+	if o.TieBreak != PreferFewerRunways && o.TieBreak != PreferMoreRunways {
+		problems = append(problems, simerrors.Invalidf("unknown runway tie-break strategy %d", o.TieBreak))
+	}
+	for _, runwayID := range o.PreferredRunways {
+		if !slices.ContainsFunc(runways, func(r airport.Runway) bool { return r.RunwayDesignation == runwayID }) {
+			problems = append(problems, simerrors.Invalidf("preferred runway %s does not exist", runwayID))
+		}
+	}
+	if o.HeadwindWeight < 0 {
+		problems = append(problems, simerrors.Invalidf("headwind weight cannot be negative, got %g", o.HeadwindWeight))
+	}
+	if o.MinimumDwell < 0 {
+		problems = append(problems, simerrors.Invalidf("minimum configuration dwell cannot be negative, got %v", o.MinimumDwell))
+	}
+	if o.WindDeadbandKnots < 0 {
+		problems = append(problems, simerrors.Invalidf("wind deadband cannot be negative, got %g", o.WindDeadbandKnots))
+	}
+
+	return errors.Join(problems...)
+}
+
+// referenceDuration returns the period configuration capacity is rated over.
+func (o RunwayManagerOptions) referenceDuration() time.Duration {
+	if o.ReferenceDuration <= 0 {
+		return defaultReferenceDuration
+	}
+	return o.ReferenceDuration
+}
+
+// referenceSeconds returns the period configuration capacity is rated over, in seconds.
+func (o RunwayManagerOptions) referenceSeconds() float32 {
+	return float32(o.referenceDuration().Seconds())
+}
+
+// hasHysteresis reports whether wind-driven configuration changes are held back.
+func (o RunwayManagerOptions) hasHysteresis() bool {
+	return o.MinimumDwell > 0 || o.WindDeadbandKnots > 0
+}
+
+// SetOptions sets the preferences used to select among compatible runway configurations and
+// recalculates the active configuration.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetOptions(options RunwayManagerOptions) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.syncClock()
+	options.PreferredRunways = slices.Clone(options.PreferredRunways)
+	rm.options = options
+	clear(rm.selectedConfigs)
+	rm.calculateActiveConfiguration(ReasonOptions)
+}
+
+// Options returns the preferences used to select among compatible runway configurations.
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) Options() RunwayManagerOptions {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	options := rm.options
+	options.PreferredRunways = slices.Clone(options.PreferredRunways)
+	return options
+}
+
+// SetTime sets the current simulation time, used to hold configurations for the minimum
+// dwell time, to apply compatibility hours (see airport.CompatibilityHours) and to time
+// configuration changes. The engine calls it before applying each event; a clock set with
+// SetClock takes precedence at the next notification. When the time of day enters or leaves the hours of a
+// runway pair, the active runway configuration is recalculated.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetTime(t time.Time) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.now = t
+	rm.updateHoursInEffect()
+}
+
+// SetLocation sets the time zone compatibility hours are laid out in, normally the airport's
+// (see airport.Airport.TimeZone). A nil location uses the time zone of the simulation time.
+//
+// Thread-safe: Uses write lock.
+func (rm *RunwayManager) SetLocation(loc *time.Location) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.location = loc
+	rm.updateHoursInEffect()
+}
+
+// timeOfDay returns the wall clock time since midnight of the current simulation time in the
+// manager's location.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) timeOfDay() time.Duration {
+	t := rm.now
+	if rm.location != nil {
+		t = t.In(rm.location)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// compatibilityHoursInEffect returns which of the compatibility graph's hours permit their
+// runways to operate together at the current time of day.
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) compatibilityHoursInEffect() []bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	return rm.hoursInEffect
+}
+
+// updateHoursInEffect recalculates the active configuration with the compatibility graph in
+// effect if the current time of day changes which compatibility hours permit their runways.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) updateHoursInEffect() {
+	hours := rm.compatibility.HoursInEffect(rm.timeOfDay())
+	if slices.Equal(hours, rm.hoursInEffect) {
+		return
+	}
+	rm.hoursInEffect = hours
+	rm.resetCliques()
+	rm.calculateActiveConfiguration(ReasonCompatibilityHours)
+}
+
+// holdConfiguration reports whether the current configuration should be kept despite a
+// discretionary change for the given reason (a wind or noise-preference change): hysteresis
+// is enabled, every active runway end is still usable in the wind, and the configuration is
+// within its minimum dwell time or, for a wind change, the wind is within the deadband. The
+// deadband does not apply to noise-preference changes, which leave the wind unchanged.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) holdConfiguration(reason ConfigurationChangeReason) bool {
+	if reason != ReasonWind && reason != ReasonNoisePreference {
+		return false
+	}
+	if !rm.options.hasHysteresis() || rm.curfewActive || len(rm.currentConfiguration) == 0 {
+		return false
+	}
+	for _, info := range rm.currentConfiguration {
+		if _, usable := runwayDirectionInWind(info.Runway, info.Direction, rm.windSpeed, rm.windGust, rm.windDirection); !usable {
+			return false
+		}
+	}
+
+	if rm.now.Before(rm.configSince.Add(rm.options.MinimumDwell)) {
+		return true
+	}
+	return reason == ReasonWind &&
+		windVectorChange(rm.configWindSpeed, rm.configWindDirection, rm.windSpeed, rm.windDirection) < rm.options.WindDeadbandKnots
+}
+
+// recordConfigurationSelected records the wind a configuration was selected in, and the time
+// it became active if it differs from previous, for hysteresis.
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) recordConfigurationSelected(previous map[string]*event.ActiveRunwayInfo) {
+	rm.configWindSpeed = rm.windSpeed
+	rm.configWindDirection = rm.windDirection
+	if !sameRunwayUse(previous, rm.currentConfiguration) {
+		rm.configSince = rm.now
+	}
+}
+
+// sameRunwayUse reports whether two configurations use the same runways in the same
+// directions for the same operations.
+func sameRunwayUse(a, b map[string]*event.ActiveRunwayInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for runwayID, info := range a {
+		other, ok := b[runwayID]
+		if !ok || info.Direction != other.Direction || info.OperationType != other.OperationType {
+			return false
+		}
+	}
+	return true
+}
+
+// windVectorChange returns the magnitude in knots of the change from one wind to another,
+// treating each as a vector so both speed and direction changes count.
+func windVectorChange(fromSpeed, fromDirection, toSpeed, toDirection float64) float64 {
+	fromRadians := fromDirection * math.Pi / 180
+	toRadians := toDirection * math.Pi / 180
+	dx := toSpeed*math.Sin(toRadians) - fromSpeed*math.Sin(fromRadians)
+	dy := toSpeed*math.Cos(toRadians) - fromSpeed*math.Cos(fromRadians)
+	return math.Hypot(dx, dy)
+}
+
+// sameHysteresis reports whether rm and other will hold configurations identically from
+// time t: with no hysteresis there is nothing to hold; otherwise any dwell still running
+// and the wind the configurations were selected in must match.
+//
+// Thread-safe: Uses read lock.
+func (rm *RunwayManager) sameHysteresis(other *RunwayManager, t time.Time) bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+
+	if !rm.options.hasHysteresis() {
+		return true
+	}
+	dwelling := t.Before(rm.configSince.Add(rm.options.MinimumDwell))
+	otherDwelling := t.Before(other.configSince.Add(other.options.MinimumDwell))
+	if dwelling != otherDwelling || (dwelling && !rm.configSince.Equal(other.configSince)) {
+		return false
+	}
+	return rm.options.WindDeadbandKnots == 0 ||
+		(rm.configWindSpeed == other.configWindSpeed && rm.configWindDirection == other.configWindDirection)
+}
+
+// calculateConfigScore scores a runway configuration for selection: its capacity plus the
+// headwind weighting (see RunwayManagerOptions).
+//
+// NOT thread-safe: Must be called while holding write lock.
+func (rm *RunwayManager) calculateConfigScore(runwayIDs []string) float32 {
+	score := rm.calculateConfigCapacity(runwayIDs)
+	if rm.options.HeadwindWeight == 0 {
+		return score
+	}
+
+	for _, runwayID := range runwayIDs {
+		runway, found := rm.findRunwayByID(runwayID)
+		if !found {
+			continue
+		}
+		headwind, _ := runwayDirectionInWind(runway, rm.determineRunwayDirection(runway), rm.windSpeed, rm.windGust, rm.windDirection)
+		score += float32(rm.options.HeadwindWeight * headwind)
+	}
+	return score
+}
+
+// preferConfig reports whether candidate should replace best when both score the same:
+// first by the number of preferred runways, then by the tie-break strategy.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) preferConfig(candidate, best []string) bool {
+	if len(rm.options.PreferredRunways) > 0 {
+		candidatePreferred, bestPreferred := rm.countPreferred(candidate), rm.countPreferred(best)
+		if candidatePreferred != bestPreferred {
+			return candidatePreferred > bestPreferred
+		}
+	}
+
+	if rm.options.TieBreak == PreferMoreRunways {
+		return len(candidate) > len(best)
+	}
+	return len(candidate) < len(best)
+}
+
+// countPreferred returns how many of runwayIDs are preferred runways.
+//
+// NOT thread-safe: Must be called while holding read or write lock.
+func (rm *RunwayManager) countPreferred(runwayIDs []string) int {
+	count := 0
+	for _, runwayID := range runwayIDs {
+		if slices.Contains(rm.options.PreferredRunways, runwayID) {
+			count++
+		}
+	}
+	return count
+}
+
+// SetRunwayManagerOptions sets the preferences the runway manager uses to select among
+// compatible runway configurations and resets the active configuration (and its history)
+// accordingly. Call before processing events.
+func (w *World) SetRunwayManagerOptions(options RunwayManagerOptions) {
+	w.RunwayManager.SetOptions(options)
+
+	w.activeConfigMu.Lock()
+	w.ActiveRunwayConfiguration = w.RunwayManager.GetActiveConfiguration()
+	w.activeConfigMu.Unlock()
+	w.summarizeActiveConfiguration()
+	w.configurationHistory = nil
+	w.recordConfigurationChange("")
+}
@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// runwaySet is a set of runways stored as a bitmask, where bit i is the runway at index i
+// of the airport's runway inventory. It grows in 64-runway words, so any number of runways
+// (or runway ends modelled as separate runways) is supported.
+type runwaySet []uint64
+
+// newRunwaySet returns an empty set able to hold n runways.
+func newRunwaySet(n int) runwaySet {
+	return make(runwaySet, (n+63)/64)
+}
+
+// add inserts runway i into the set.
+func (s runwaySet) add(i int) {
+	s[i/64] |= 1 << (i % 64)
+}
+
+// remove deletes runway i from the set.
+func (s runwaySet) remove(i int) {
+	s[i/64] &^= 1 << (i % 64)
+}
+
+// isEmpty reports whether the set contains no runways.
+func (s runwaySet) isEmpty() bool {
+	for _, word := range s {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a copy of the set.
+func (s runwaySet) clone() runwaySet {
+	return append(runwaySet(nil), s...)
+}
+
+// intersect returns the runways in both s and other.
+func (s runwaySet) intersect(other runwaySet) runwaySet {
+	result := make(runwaySet, len(s))
+	for i := range s {
+		result[i] = s[i] & other[i]
+	}
+	return result
+}
+
+// difference returns the runways in s that are not in other.
+func (s runwaySet) difference(other runwaySet) runwaySet {
+	result := make(runwaySet, len(s))
+	for i := range s {
+		result[i] = s[i] &^ other[i]
+	}
+	return result
+}
+
+// intersectionCount returns the number of runways in both s and other.
+func (s runwaySet) intersectionCount(other runwaySet) int {
+	count := 0
+	for i := range s {
+		count += bits.OnesCount64(s[i] & other[i])
+	}
+	return count
+}
+
+// isSubsetOf reports whether every runway in s is also in other.
+func (s runwaySet) isSubsetOf(other runwaySet) bool {
+	for i := range s {
+		if s[i]&^other[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// members returns the indices of the runways in the set in increasing order.
+func (s runwaySet) members() []int {
+	var indices []int
+	for i, word := range s {
+		for word != 0 {
+			bit := bits.TrailingZeros64(word)
+			indices = append(indices, i*64+bit)
+			word &^= 1 << bit
+		}
+	}
+	return indices
+}
+
+// key returns a string uniquely identifying the set, for use as a map key.
+func (s runwaySet) key() string {
+	var b strings.Builder
+	b.Grow(len(s) * 8)
+	for _, word := range s {
+		for shift := 0; shift < 64; shift += 8 {
+			b.WriteByte(byte(word >> shift))
+		}
+	}
+	return b.String()
+}
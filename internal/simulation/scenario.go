@@ -0,0 +1,245 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/weather"
+)
+
+// Scenario is a complete simulation definition loaded from a scenario file: the airport, the
+// ordered list of registered policies with their parameters, the simulated period and the
+// results to report. Scenario files let whole studies be checked into version control and
+// rerun exactly, instead of being written as Go code.
+//
+// A scenario file is JSON:
+//
+//	{
+//	    "name": "Summer 2024 with night curfew",
+//	    "airport": "airports/kmia.json",
+//	    "start": "2024-06-01T00:00:00Z",
+//	    "end": "2024-09-01T00:00:00Z",
+//	    "seed": 42,
+//	    "weather": "live:KMIA:2023",
+//	    "policies": [
+//	        {"name": "curfew", "params": {"start": "2024-06-01T23:00:00Z", "end": "2024-06-02T06:00:00Z"}},
+//	        {"name": "wind", "params": {"speedKnots": 12, "directionTrue": 270}}
+//	    ],
+//	    "output": {"baseline": true, "runwayUsage": true, "parquet": "windows.parquet"}
+//	}
+//
+// The airport file holds an airport.Airport encoded as JSON, with durations in nanoseconds.
+// An airport of the form "preset:LHR-like" uses the named airport.Preset instead of a file.
+// Policies are created by name from the policy registry (see policy.Register) and added in
+// the order listed; run with -list-policies to see the available names.
+//
+// A weather reference of the form "live:<ICAO>:<year>" (see weather.ParseReference) replays
+// the wind observed at that airport over that year as a scheduled wind policy, added before
+// the listed policies; without a start and end, the year is simulated. The observations are
+// fetched by LoadWeather, which must be called before Simulation.
+type Scenario struct {
+	Name          string           `json:"name"`          // Name used when reporting results
+	Airport       string           `json:"airport"`       // Path to the airport JSON file, relative to the scenario file, or "preset:<name>"
+	Start         time.Time        `json:"start"`         // Start of the simulated period (omitted = calendar year 2024)
+	End           time.Time        `json:"end"`           // End of the simulated period (omitted = calendar year 2024)
+	Seed          *int64           `json:"seed"`          // Seed for stochastic policies (omitted = random)
+	Weather       string           `json:"weather"`       // Observed wind to replay, e.g. "live:KJFK:2023" (omitted = none)
+	Parallelism   int              `json:"parallelism"`   // Months the engine may process concurrently (0 = sequential)
+	MinimumWindow policy.Duration  `json:"minimumWindow"` // Shortest window calculated, e.g. "5m" (omitted = every window; see Simulation.WithMinimumWindow)
+	Policies      []ScenarioPolicy `json:"policies"`      // Policies in the order they are added
+	Output        ScenarioOutput   `json:"output"`        // Results to report
+
+	airport airport.Airport     // Airport loaded from the Airport file
+	wind    []policy.WindChange // Wind fetched for the Weather reference by LoadWeather
+}
+
+// ScenarioPolicy is a registered policy and its parameters in a scenario file.
+type ScenarioPolicy struct {
+	Name   string          `json:"name"`   // Registered policy name (e.g., "curfew")
+	Params json.RawMessage `json:"params"` // Parameters decoded by the policy's factory
+}
+
+// ScenarioOutput selects the results reported for a scenario in addition to its total movements.
+type ScenarioOutput struct {
+	Baseline       bool   `json:"baseline"`       // Also run the theoretical maximum baseline and report utilization
+	FlowUsage      bool   `json:"flowUsage"`      // Report the share of time spent in each runway flow
+	RunwayUsage    bool   `json:"runwayUsage"`    // Report the share of movements handled by each runway
+	RunwayEndUsage bool   `json:"runwayEndUsage"` // Report the share of arrivals and departures on each runway end
+	Parquet        string `json:"parquet"`        // Write the window results to this Parquet file (see WindowParquetWriter)
+	Heatmap        string `json:"heatmap"`        // Write the hourly capacity ratio to this CSV file, running the baseline too (see CapacityHeatmap)
+	StandDemand    string `json:"standDemand"`    // Write the gates in use every 15 minutes to this CSV file (see Result.StandDemand)
+	Advice         int    `json:"advice"`         // Report up to this many suggested changes with their capacity gains (see Simulation.Advise)
+	CurfewStudy    string `json:"curfewStudy"`    // Write the capacity of the curfew relaxed in 30 minute steps to this CSV file (see Simulation.CurfewStudy)
+	Trends         string `json:"trends"`         // Write the daily capacity with its 7 and 30 day rolling averages to this CSV file (see Result.CapacityTrends)
+}
+
+// presetPrefix marks a scenario airport that refers to an airport.Preset rather than a file.
+const presetPrefix = "preset:"
+
+// LoadScenario reads the scenario file at path and the airport file it references.
+// Unknown fields are rejected in both files so misspellings are reported rather than
+// silently ignored. Policies are not created until Simulation is called.
+func LoadScenario(path string) (*Scenario, error) {
+	var scenario Scenario
+	if err := decodeJSONFile(path, &scenario); err != nil {
+		return nil, err
+	}
+	if scenario.Airport == "" {
+		return nil, simerrors.Invalidf("scenario %s: no airport given", path)
+	}
+	if scenario.Start.IsZero() != scenario.End.IsZero() {
+		return nil, simerrors.Invalidf("scenario %s: start and end must be given together", path)
+	}
+	if scenario.Weather != "" {
+		ref, err := weather.ParseReference(scenario.Weather)
+		if err != nil {
+			return nil, simerrors.Invalidf("scenario %s: %w", path, err)
+		}
+		if scenario.Start.IsZero() {
+			scenario.Start, scenario.End = ref.Period()
+		}
+	}
+
+	if name, ok := strings.CutPrefix(scenario.Airport, presetPrefix); ok {
+		ap, err := airport.Preset(name)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", path, err)
+		}
+		scenario.airport = ap
+	} else {
+		airportPath := scenario.Airport
+		if !filepath.IsAbs(airportPath) {
+			airportPath = filepath.Join(filepath.Dir(path), airportPath)
+		}
+		if err := decodeJSONFile(airportPath, &scenario.airport); err != nil {
+			return nil, err
+		}
+	}
+
+	if scenario.Name == "" {
+		scenario.Name = scenario.airport.Name
+	}
+	return &scenario, nil
+}
+
+// decodeJSONFile decodes the JSON file at path into v, rejecting unknown fields.
+func decodeJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return simerrors.Invalidf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadWeather fetches the wind observations for the scenario's Weather reference from provider
+// (nil = weather.NewIEMProvider()), to be replayed by the simulations it creates. It does
+// nothing if the scenario has no weather reference.
+func (sc *Scenario) LoadWeather(ctx context.Context, provider weather.WeatherProvider) error {
+	if sc.Weather == "" {
+		return nil
+	}
+	if provider == nil {
+		provider = weather.NewIEMProvider()
+	}
+	wind, err := weather.LoadWindSchedule(ctx, provider, sc.Weather)
+	if err != nil {
+		return fmt.Errorf("scenario %s: %w", sc.Name, err)
+	}
+	sc.wind = wind
+	return nil
+}
+
+// LoadedAirport returns the airport loaded from the scenario's Airport file or preset.
+func (sc *Scenario) LoadedAirport() airport.Airport {
+	return sc.airport
+}
+
+// Hash returns a hex-encoded SHA-256 digest identifying the scenario's definition: its name,
+// period, seed, engine settings, policies and outputs together with the contents of the loaded
+// airport, rather than the path to it. Runs of an unchanged scenario have the same hash, so
+// stored results can be grouped by the scenario that produced them; formatting and key order
+// in the policy parameters do not affect it.
+func (sc *Scenario) Hash() (string, error) {
+	definition := *sc
+	definition.Airport = ""
+	policies := make([]ScenarioPolicy, len(sc.Policies))
+	for i, p := range sc.Policies {
+		policies[i] = ScenarioPolicy{Name: p.Name, Params: p.Params}
+		if len(p.Params) > 0 {
+			// Re-encoding through any sorts object keys
+			var params any
+			if err := json.Unmarshal(p.Params, &params); err != nil {
+				return "", simerrors.Invalidf("scenario %s: policy %d parameters: %w", sc.Name, i+1, err)
+			}
+			canonical, err := json.Marshal(params)
+			if err != nil {
+				return "", err
+			}
+			policies[i].Params = canonical
+		}
+	}
+	definition.Policies = policies
+
+	data, err := json.Marshal(struct {
+		Scenario *Scenario       `json:"scenario"`
+		Airport  airport.Airport `json:"airport"`
+	}{&definition, sc.airport})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Simulation creates the simulation the scenario defines, with its weather and then its policies
+// added in order and the scenario's hash recorded in the provenance of its results.
+// Returns an error if the scenario's weather has not been loaded (see LoadWeather), or a
+// policy is not registered or its parameters are invalid; the rest of the configuration is
+// checked when the simulation is validated or run.
+func (sc *Scenario) Simulation(logger *slog.Logger) (*Simulation, error) {
+	sim := NewSimulation(sc.airport, logger).WithParallelism(sc.Parallelism).WithMinimumWindow(time.Duration(sc.MinimumWindow))
+	if !sc.Start.IsZero() {
+		sim = sim.WithPeriod(sc.Start, sc.End)
+	}
+	if sc.Seed != nil {
+		sim = sim.WithSeed(*sc.Seed)
+	}
+	hash, err := sc.Hash()
+	if err != nil {
+		return nil, err
+	}
+	sim = sim.WithScenarioHash(hash)
+
+	if sc.Weather != "" {
+		if sc.wind == nil {
+			return nil, simerrors.Invalidf("scenario %s: weather %s has not been loaded", sc.Name, sc.Weather)
+		}
+		if sim, err = sim.AddScheduledWindPolicy(sc.wind); err != nil {
+			return nil, fmt.Errorf("scenario %s: weather %s: %w", sc.Name, sc.Weather, err)
+		}
+	}
+	for i, p := range sc.Policies {
+		sim, err = sim.AddRegisteredPolicy(p.Name, policy.JSONDecoder(p.Params))
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: policy %d: %w", sc.Name, i+1, err)
+		}
+	}
+	return sim, nil
+}
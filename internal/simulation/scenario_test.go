@@ -0,0 +1,251 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// scenarioTestAirport is a single-runway airport with 60s separation and a 20 knot crosswind
+// limit, encoded as JSON.
+const scenarioTestAirport = `{
+	"Name": "Scenario Test",
+	"Runways": [{"RunwayDesignation": "09", "TrueBearing": 90, "LengthMeters": 3000, "MinimumSeparation": 60000000000, "CrosswindLimitKnots": 20}]
+}`
+
+// writeScenarioFiles writes the airport and scenario files to a temporary directory and
+// returns the scenario file's path.
+func writeScenarioFiles(t *testing.T, scenario string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "airport.json"), []byte(scenarioTestAirport), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := writeScenarioFiles(t, `{
+		"airport": "airport.json",
+		"start": "2024-03-01T00:00:00Z",
+		"end": "2024-03-03T00:00:00Z",
+		"seed": 7,
+		"policies": [
+			{"name": "curfew", "params": {"start": "2024-03-01T23:00:00Z", "end": "2024-03-02T06:00:00Z"}}
+		],
+		"output": {"baseline": true}
+	}`)
+
+	scenario, err := LoadScenario(path)
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+	if scenario.Name != "Scenario Test" {
+		t.Errorf("Expected the name to default to the airport's, got %q", scenario.Name)
+	}
+	if !scenario.Output.Baseline {
+		t.Error("Expected the baseline output to be requested")
+	}
+
+	sim, err := scenario.Simulation(testEngineLogger())
+	if err != nil {
+		t.Fatalf("Simulation failed: %v", err)
+	}
+	got, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// The same study written in Go
+	ap := airport.Airport{
+		Name:    "Scenario Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second, CrosswindLimitKnots: 20}},
+	}
+	want, err := NewSimulation(ap, testEngineLogger()).
+		WithPeriod(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)).
+		AddCurfewPolicy(time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 6, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+	expected, err := want.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got != expected {
+		t.Errorf("Expected the scenario to match the Go study (%v movements), got %v", expected, got)
+	}
+	// Two days less the first night's 7-hour curfew and the hour of the second before the end
+	if got != 2400 {
+		t.Errorf("Expected 2400 movements, got %v", got)
+	}
+}
+
+func TestLoadScenario_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+	}{
+		{"malformed JSON", `{"airport": `},
+		{"unknown field", `{"airport": "airport.json", "runways": 2}`},
+		{"no airport", `{"policies": []}`},
+		{"start without end", `{"airport": "airport.json", "start": "2024-03-01T00:00:00Z"}`},
+		{"invalid weather", `{"airport": "airport.json", "weather": "live:JFK:2023"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadScenario(writeScenarioFiles(t, tt.scenario))
+			if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+			}
+		})
+	}
+
+	t.Run("missing airport file", func(t *testing.T) {
+		_, err := LoadScenario(writeScenarioFiles(t, `{"airport": "missing.json"}`))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected os.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestLoadScenario_Preset(t *testing.T) {
+	scenario, err := LoadScenario(writeScenarioFiles(t, `{"airport": "preset:LHR-like"}`))
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+	if scenario.Name != "LHR-like Hub" {
+		t.Errorf("Expected the preset airport's name, got %q", scenario.Name)
+	}
+
+	_, err = LoadScenario(writeScenarioFiles(t, `{"airport": "preset:JFK-like"}`))
+	if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an unknown preset, got %v", err)
+	}
+}
+
+// fakeWeatherProvider returns a fixed wind series and records what it was asked for.
+type fakeWeatherProvider struct {
+	wind     []WindChange
+	err      error
+	icao     string
+	from, to time.Time
+}
+
+func (p *fakeWeatherProvider) GetWindSeries(ctx context.Context, icao string, from, to time.Time) ([]WindChange, error) {
+	p.icao, p.from, p.to = icao, from, to
+	return p.wind, p.err
+}
+
+func TestScenario_Weather(t *testing.T) {
+	// A southerly gale on the evening of 1 March closes 09 until midnight
+	evening, midnight := time.Date(2023, 3, 1, 18, 0, 0, 0, time.UTC), time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)
+	wind := []WindChange{
+		{Timestamp: time.Date(2022, 12, 31, 23, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90},
+		{Timestamp: evening, SpeedKnots: 40, DirectionTrue: 180},
+		{Timestamp: midnight, SpeedKnots: 5, DirectionTrue: 90},
+	}
+	scenario, err := LoadScenario(writeScenarioFiles(t, `{
+		"airport": "airport.json",
+		"start": "2023-03-01T00:00:00Z",
+		"end": "2023-03-03T00:00:00Z",
+		"weather": "live:kjfk:2023"
+	}`))
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+
+	if _, err := scenario.Simulation(testEngineLogger()); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration before the weather is loaded, got %v", err)
+	}
+
+	provider := &fakeWeatherProvider{wind: wind}
+	if err := scenario.LoadWeather(context.Background(), provider); err != nil {
+		t.Fatalf("LoadWeather failed: %v", err)
+	}
+	if from, to := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); provider.icao != "KJFK" || !provider.from.Equal(from) || !provider.to.Equal(to) {
+		t.Errorf("Expected KJFK wind for 2023, got %s from %v to %v", provider.icao, provider.from, provider.to)
+	}
+
+	sim, err := scenario.Simulation(testEngineLogger())
+	if err != nil {
+		t.Fatalf("Simulation failed: %v", err)
+	}
+	got, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	// Two days at 60 movements an hour less the 6 hours of the gale
+	if want := float32(42 * 60); got != want {
+		t.Errorf("Expected %v movements, got %v", want, got)
+	}
+
+	t.Run("year simulated without a period", func(t *testing.T) {
+		scenario, err := LoadScenario(writeScenarioFiles(t, `{"airport": "airport.json", "weather": "live:KJFK:2023"}`))
+		if err != nil {
+			t.Fatalf("LoadScenario failed: %v", err)
+		}
+		if !scenario.Start.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)) || !scenario.End.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Expected the calendar year 2023, got %v to %v", scenario.Start, scenario.End)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		unavailable := errors.New("archive unavailable")
+		if err := scenario.LoadWeather(context.Background(), &fakeWeatherProvider{err: unavailable}); !errors.Is(err, unavailable) {
+			t.Errorf("Expected the provider's error, got %v", err)
+		}
+	})
+}
+
+func TestScenario_SimulationUnknownPolicy(t *testing.T) {
+	scenario, err := LoadScenario(writeScenarioFiles(t, `{
+		"airport": "airport.json",
+		"policies": [{"name": "no-such-policy"}]
+	}`))
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+
+	if _, err := scenario.Simulation(testEngineLogger()); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestScenario_Hash(t *testing.T) {
+	load := func(scenario string) string {
+		t.Helper()
+		loaded, err := LoadScenario(writeScenarioFiles(t, scenario))
+		if err != nil {
+			t.Fatalf("LoadScenario failed: %v", err)
+		}
+		hash, err := loaded.Hash()
+		if err != nil {
+			t.Fatalf("Hash failed: %v", err)
+		}
+		return hash
+	}
+
+	hash := load(`{"airport": "airport.json", "policies": [{"name": "wind", "params": {"speedKnots": 12, "directionTrue": 270}}]}`)
+	if len(hash) != 64 {
+		t.Errorf("Expected a hex-encoded SHA-256 digest, got %q", hash)
+	}
+	// Files in other directories with reordered parameters describe the same scenario
+	if same := load(`{"airport": "airport.json", "policies": [{"name": "wind", "params": {"directionTrue": 270,  "speedKnots": 12}}]}`); same != hash {
+		t.Errorf("Expected reordered parameters to give the same hash, got %s and %s", hash, same)
+	}
+	if other := load(`{"airport": "airport.json", "policies": [{"name": "wind", "params": {"speedKnots": 15, "directionTrue": 270}}]}`); other == hash {
+		t.Error("Expected different parameters to give a different hash")
+	}
+}
@@ -3,11 +3,15 @@ package simulation
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
 )
 
@@ -24,13 +28,53 @@ type Policy interface {
 
 // Type aliases for convenience - expose policy package types
 type (
-	MaintenanceSchedule           = policy.MaintenanceSchedule
-	IntelligentMaintenanceSchedule = policy.IntelligentMaintenanceSchedule
-	GateCapacityConstraint         = policy.GateCapacityConstraint
-	TaxiTimeConfiguration          = policy.TaxiTimeConfiguration
-	RotationStrategy              = policy.RotationStrategy
-	RotationSchedule              = policy.RotationSchedule
-	WindChange                    = policy.WindChange
+	MaintenanceSchedule              = policy.MaintenanceSchedule
+	IntelligentMaintenanceSchedule   = policy.IntelligentMaintenanceSchedule
+	ConditionMaintenanceSchedule     = policy.ConditionMaintenanceSchedule
+	GateCapacityConstraint           = policy.GateCapacityConstraint
+	GateClosure                      = policy.GateClosure
+	DeicingConfiguration             = policy.DeicingConfiguration
+	TaxiTimeConfiguration            = policy.TaxiTimeConfiguration
+	RotationStrategy                 = policy.RotationStrategy
+	RotationSchedule                 = policy.RotationSchedule
+	WindChange                       = policy.WindChange
+	TemperatureChange                = policy.TemperatureChange
+	TailwindPerformanceConfiguration = policy.TailwindPerformanceConfiguration
+	TideSchedule                     = policy.TideSchedule
+	TideLevel                        = policy.TideLevel
+	RunwaySurfaceConditionSchedule   = policy.RunwaySurfaceConditionSchedule
+	SurfaceConditionPeriod           = policy.SurfaceConditionPeriod
+	RainfallSample                   = policy.RainfallSample
+	CommissioningPlan                = policy.CommissioningPlan
+	CommissioningPhase               = policy.CommissioningPhase
+	NoiseQuotaConfiguration          = policy.NoiseQuotaConfiguration
+	RunwayCurfewConfiguration        = policy.RunwayCurfewConfiguration
+	NightQuotaConfiguration          = policy.NightQuotaConfiguration
+	GAReservationConfiguration       = policy.GAReservationConfiguration
+	TemporarySeparationConfiguration = policy.TemporarySeparationConfiguration
+	CalendarOverride                 = policy.CalendarOverride
+	NoisePreferentialBand            = policy.NoisePreferentialBand
+	PolicyDecoder                    = policy.Decoder
+	ConstructionPlan                 = policy.ConstructionPlan
+	WindRose                         = policy.WindRose
+	WindRoseConfiguration            = policy.WindRoseConfiguration
+	GustFactorConfiguration          = policy.GustFactorConfiguration
+	ConvectiveWeatherConfiguration   = policy.ConvectiveWeatherConfiguration
+	DisruptionConfiguration          = policy.DisruptionConfiguration
+	DurationDistribution             = policy.DurationDistribution
+	FixedDuration                    = policy.FixedDuration
+	UniformDuration                  = policy.UniformDuration
+	ExponentialDuration              = policy.ExponentialDuration
+	LogNormalDuration                = policy.LogNormalDuration
+	MaintenanceWindow                = policy.MaintenanceWindow
+	QuantileDistribution             = policy.QuantileDistribution
+	PercentileDuration               = policy.PercentileDuration
+	HistogramDuration                = policy.HistogramDuration
+	HistogramBin                     = policy.HistogramBin
+	MaintenanceRestrictions          = policy.MaintenanceRestrictions
+	AllowedMaintenanceWindow         = policy.AllowedMaintenanceWindow
+	TimeWindow                       = policy.TimeWindow
+	PeakPeriod                       = policy.PeakPeriod
 )
 
 // Rotation strategy constants
@@ -41,12 +85,41 @@ const (
 	NoiseOptimizedRotation = policy.NoiseOptimizedRotation
 )
 
+// Commissioning mode constants
+const (
+	CommissioningClosed     = policy.CommissioningClosed
+	CommissioningRestricted = policy.CommissioningRestricted
+	CommissioningFull       = policy.CommissioningFull
+)
+
+// Calendar override kind constants
+const (
+	CalendarClosure         = policy.CalendarClosure
+	CalendarCurfew          = policy.CalendarCurfew
+	CalendarReducedCapacity = policy.CalendarReducedCapacity
+)
+
 // Simulation represents an event-driven simulation that can be run.
 type Simulation struct {
 	airport              airport.Airport       // The airport to simulate.
 	logger               *slog.Logger          // The logger to use for logging.
 	preSimulationPlugins []PreSimulationPlugin // Pre-simulation plugins to modify the airport configuration.
 	policies             []Policy              // Runtime policies affecting simulation behavior.
+	progress             ProgressFunc          // Optional engine progress callback.
+	parallelism          int                   // Months the engine may process concurrently.
+	seed                 int64                 // Seed for stochastic policies.
+	seeded               bool                  // Whether seed was set explicitly.
+	policyStreams        []uint64              // Random stream index per policy, when derived from another simulation.
+	policyPriorities     map[int]int           // Priority per policy index, when set with AddPolicyWithPriority.
+	disabledPolicies     map[string]bool       // Names of policies excluded from runs (see DisablePolicy).
+	runwayOptions        RunwayManagerOptions  // Preferences for selecting runway configurations.
+	capacityModel        CapacityModel         // Runway capacity model (nil = SeparationModel).
+	startTime            time.Time             // Start of the simulated period (zero = default period).
+	endTime              time.Time             // End of the simulated period (zero = default period).
+	quiet                bool                  // Suppress per-event engine logs.
+	metrics              Metrics               // Optional engine instrumentation.
+	minimumWindow        time.Duration         // Shortest window the engine calculates (0 = every window).
+	scenarioHash         string                // Identifies the definition the simulation was built from (see WithScenarioHash).
 }
 
 // NewSimulation creates a new Simulation instance.
@@ -59,81 +132,452 @@ func NewSimulation(airport airport.Airport, logger *slog.Logger) *Simulation {
 	}
 }
 
-// AddPreSimulationPlugin adds a pre-simulation plugin to the simulation.
+// AddPreSimulationPlugin adds a pre-simulation plugin to the simulation. Plugins are applied
+// in the order they are added, each to the airport as modified by the plugins before it.
 func (s *Simulation) AddPreSimulationPlugin(plugin PreSimulationPlugin) *Simulation {
 	s.preSimulationPlugins = append(s.preSimulationPlugins, plugin)
 	return s
 }
 
-// Run executes the event-driven simulation.
+// WithProgress registers a callback that receives engine progress updates during Run.
+func (s *Simulation) WithProgress(fn ProgressFunc) *Simulation {
+	s.progress = fn
+	return s
+}
+
+// WithParallelism lets the engine process up to n calendar months of the run concurrently
+// (see Engine.WithParallelism). Results are identical to a sequential run.
+func (s *Simulation) WithParallelism(n int) *Simulation {
+	s.parallelism = n
+	return s
+}
+
+// WithQuiet suppresses the engine's per-event debug logs (see Engine.WithQuiet), e.g. for
+// Monte Carlo batches of seeded runs.
+func (s *Simulation) WithQuiet(quiet bool) *Simulation {
+	s.quiet = quiet
+	return s
+}
+
+// WithMetrics reports every engine run of the simulation to m (see Metrics), including the
+// baseline and subset runs of RunWithBaseline and CapacityWaterfall.
+func (s *Simulation) WithMetrics(m Metrics) *Simulation {
+	s.metrics = m
+	return s
+}
+
+// WithMinimumWindow coalesces state changes less than d apart into windows of at least d
+// (see Engine.WithMinimumWindow), trading some accuracy for speed on dense event schedules
+// such as METAR replays.
+func (s *Simulation) WithMinimumWindow(d time.Duration) *Simulation {
+	s.minimumWindow = d
+	return s
+}
+
+// WithSeed sets the seed for the simulation-wide random source. Every stochastic policy
+// receives its own source derived from this seed, so runs with the same seed and policies
+// are reproducible. Without a seed, a random one is chosen and logged at the start of Run.
+func (s *Simulation) WithSeed(seed int64) *Simulation {
+	s.seed = seed
+	s.seeded = true
+	return s
+}
+
+// WithRunwayManagerOptions sets the airport's preferences for selecting among compatible
+// runway configurations (see RunwayManagerOptions). Options are validated with the simulation.
+func (s *Simulation) WithRunwayManagerOptions(options RunwayManagerOptions) *Simulation {
+	s.runwayOptions = options
+	return s
+}
+
+// WithCapacityModel sets the model used to calculate runway capacity, both for each window
+// and when rating configurations for selection (see CapacityModel). Without it, the
+// simulation uses SeparationModel.
+func (s *Simulation) WithCapacityModel(model CapacityModel) *Simulation {
+	s.capacityModel = model
+	return s
+}
+
+// WithPeriod sets the period the simulation covers, from start up to end. Without it, the
+// simulation covers the calendar year 2024. The period is validated with the simulation.
+func (s *Simulation) WithPeriod(start, end time.Time) *Simulation {
+	s.startTime = start
+	s.endTime = end
+	return s
+}
+
+// Run executes the event-driven simulation and returns the total movements.
 func (s *Simulation) Run(ctx context.Context) (float32, error) {
-	// Apply pre-simulation plugins
-	for _, plugin := range s.preSimulationPlugins {
-		s.airport = plugin.Apply(s.airport)
+	result, err := s.RunResult(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalCapacity, nil
+}
+
+// RunResult executes the event-driven simulation and returns the detailed per-window Result.
+// The simulation is validated first; configuration problems are returned as a *ValidationError.
+func (s *Simulation) RunResult(ctx context.Context) (*Result, error) {
+	world, provenance, err := s.prepareWorld(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run event-driven simulation
+	result, err := s.engine().CalculateResult(ctx, world)
+	if err != nil {
+		return nil, err
 	}
+	result.Provenance = provenance
+	return result, nil
+}
+
+// RunUntil runs the simulation up to and including the events at until and returns a
+// checkpoint there (see Engine.CalculateUntil). Resume the checkpoint with Resume, once for
+// each alternative to compare.
+func (s *Simulation) RunUntil(ctx context.Context, until time.Time) (*Checkpoint, error) {
+	world, provenance, err := s.prepareWorld(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := s.engine().CalculateUntil(ctx, world, until)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.provenance = provenance
+	return checkpoint, nil
+}
+
+// Resume continues a checkpoint of the simulation to the end of the period and returns the
+// result of the whole run. Additional policies (e.g., a runway closure) apply from the
+// checkpoint onwards: the events they generate before the checkpoint time are ignored.
+// The additional policies are validated first; problems are returned as a *ValidationError.
+func (s *Simulation) Resume(ctx context.Context, c *Checkpoint, policies ...Policy) (*Result, error) {
+	world := c.Fork()
+
+	var problems []error
+	for _, p := range policies {
+		if vp, ok := p.(policy.ValidatingPolicy); ok {
+			problems = appendProblems(problems, vp.Validate(world))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	for _, p := range policies {
+		if err := p.GenerateEvents(ctx, world); err != nil {
+			return nil, fmt.Errorf("generating events for %s: %w", p.Name(), err)
+		}
+	}
+	result, err := s.engine().Resume(ctx, c, world)
+	if err != nil {
+		return nil, err
+	}
+	result.Provenance = c.provenance.resumed(policies)
+	return result, nil
+}
+
+// PreviewEvents generates every enabled policy's events without running the engine and
+// returns them in the order the engine would apply them, including the events the world
+// schedules itself (e.g., compatibility hour boundaries). Events outside the simulation period
+// are included; the engine skips them. The simulation is validated first; configuration
+// problems are returned as a *ValidationError. Stochastic policies only generate the same
+// events as a later run when the simulation is seeded with WithSeed.
+func (s *Simulation) PreviewEvents(ctx context.Context) ([]event.Event, error) {
+	world, _, err := s.prepareWorld(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]event.Event, 0, world.Events.Len())
+	for world.Events.HasNext() {
+		events = append(events, world.Events.Pop())
+	}
+	return events, nil
+}
+
+// engine creates an engine configured with the simulation's options.
+func (s *Simulation) engine() *Engine {
+	return NewEngine(s.logger).WithProgress(s.progress).WithParallelism(s.parallelism).WithQuiet(s.quiet).WithMetrics(s.metrics).
+		WithMinimumWindow(s.minimumWindow)
+}
+
+// prepareWorld validates the simulation and returns its world with every policy's initial
+// state set and events scheduled, and the provenance of a run of it. The configuration problems found are returned
+// as a *ValidationError.
+func (s *Simulation) prepareWorld(ctx context.Context) (*World, Provenance, error) {
+	// Report every configuration problem up front rather than failing mid-run
+	if err := s.Validate(); err != nil {
+		return nil, Provenance{}, err
+	}
+	s = s.enabledPolicies()
+
+	// Apply pre-simulation plugins to a copy so repeated runs start from the same airport
+	ap := s.applyPlugins(ctx, s.log(ctx))
 
 	// Create simulation world
-	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
-	endTime := startTime.AddDate(1, 0, 0) // One year simulation
+	startTime, endTime := s.period()
 
-	world := NewWorld(s.airport, startTime, endTime)
+	world := NewWorld(ap, startTime, endTime)
+	world.SetRunwayManagerOptions(s.runwayOptions)
+	if s.capacityModel != nil {
+		world.SetCapacityModel(s.capacityModel)
+	}
+	world.scheduleCompatibilityHours()
 
-	s.logger.InfoContext(ctx, "Starting event-driven simulation",
-		"airport", s.airport.Name,
+	s.log(ctx).InfoContext(ctx, "Starting event-driven simulation",
+		"airport", ap.Name,
 		"startTime", startTime,
 		"endTime", endTime)
 
-	// Let policies generate events concurrently
-	s.logger.InfoContext(ctx, "Generating events from policies",
+	// Hand each stochastic policy its own deterministic random source
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	s.log(ctx).InfoContext(ctx, "Random source initialised", "seed", seed)
+
+	s.seedPolicies(seed)
+
+	provenance, err := s.provenance(ap, seed)
+	if err != nil {
+		return nil, Provenance{}, err
+	}
+
+	if err := s.setInitialState(ctx, world); err != nil {
+		return nil, Provenance{}, err
+	}
+	if err := s.generateEvents(ctx, world); err != nil {
+		return nil, Provenance{}, err
+	}
+
+	s.log(ctx).InfoContext(ctx, "Events generated",
+		"totalEvents", world.Events.Len())
+
+	return world, provenance, nil
+}
+
+// generateEvents lets every policy generate its events concurrently into its own buffer, then
+// schedules the buffered events on world in a deterministic order: by policy priority, then
+// in the order the policies were added. Events at the same time are therefore applied in the
+// same order, and the same log lines written, however the goroutines were scheduled. If any
+// policy fails, the error of the first failing policy in that order is returned.
+func (s *Simulation) generateEvents(ctx context.Context, world *World) error {
+	s.log(ctx).InfoContext(ctx, "Generating events from policies",
 		"policyCount", len(s.policies))
 
+	buffers := make([]*policyWorld, len(s.policies))
+	errs := make([]error, len(s.policies))
 	var wg sync.WaitGroup
-	var errMu sync.Mutex
-	var firstErr error
-
-	for _, policy := range s.policies {
+	for i, p := range s.policies {
+		buffers[i] = &policyWorld{World: world}
 		wg.Add(1)
-		go func(p Policy) {
+		go func() {
 			defer wg.Done()
+			errs[i] = p.GenerateEvents(ctx, buffers[i])
+		}()
+	}
+	wg.Wait()
 
-			s.logger.InfoContext(ctx, "Generating events for policy", "policy", p.Name())
-			if err := p.GenerateEvents(ctx, world); err != nil {
-				s.logger.ErrorContext(ctx, "Failed to generate events",
-					"policy", p.Name(),
-					"error", err)
-
-				// Capture first error only
-				errMu.Lock()
-				if firstErr == nil {
-					firstErr = err
-				}
-				errMu.Unlock()
+	for _, i := range s.policyOrder() {
+		p := s.policies[i]
+		if errs[i] != nil {
+			s.log(ctx).ErrorContext(ctx, "Failed to generate events",
+				"policy", p.Name(),
+				"error", errs[i])
+			return errs[i]
+		}
+		s.log(ctx).InfoContext(ctx, "Generated events for policy",
+			"policy", p.Name(),
+			"priority", s.policyPriority(i),
+			"events", len(buffers[i].events))
+		for _, evt := range buffers[i].events {
+			world.ScheduleEvent(evt)
+		}
+	}
+	return nil
+}
+
+// setInitialState lets every policy.InitialStatePolicy establish its starting conditions on
+// world, one at a time in the order their events are applied, so the state each sets is
+// deterministic and no event at the start time is needed.
+func (s *Simulation) setInitialState(ctx context.Context, world *World) error {
+	for _, i := range s.policyOrder() {
+		p := s.policies[i]
+		if ip, ok := p.(policy.InitialStatePolicy); ok {
+			if err := ip.SetInitialState(ctx, world); err != nil {
+				return fmt.Errorf("setting initial state for %s: %w", p.Name(), err)
 			}
-		}(policy)
+		}
 	}
+	return nil
+}
 
-	// Wait for all policies to complete
-	wg.Wait()
+// policyOrder returns the indices of the policies in the order their events are applied: by
+// priority, then in the order the policies were added.
+func (s *Simulation) policyOrder() []int {
+	order := make([]int, len(s.policies))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return s.policyPriority(order[a]) > s.policyPriority(order[b])
+	})
+	return order
+}
+
+// policyWorld is the world a policy generates events into: scheduled events are buffered
+// for generateEvents to merge, while every other method is the underlying world's.
+type policyWorld struct {
+	*World
+	events []event.Event
+}
 
-	// Check if any policy failed
-	if firstErr != nil {
-		return 0, firstErr
+// ScheduleEvent buffers an event for scheduling once every policy has generated its events.
+func (w *policyWorld) ScheduleEvent(evt event.Event) {
+	w.events = append(w.events, evt)
+}
+
+// seedPolicies hands each stochastic policy its own random source derived from seed.
+func (s *Simulation) seedPolicies(seed int64) {
+	for i, p := range s.policies {
+		if sp, ok := p.(policy.StochasticPolicy); ok {
+			stream := uint64(i)
+			if s.policyStreams != nil {
+				stream = s.policyStreams[i]
+			}
+			sp.SetRandomSource(policy.NewRandomSource(seed, stream))
+		}
 	}
+}
 
-	s.logger.InfoContext(ctx, "Events generated",
-		"totalEvents", world.Events.Len())
+// withPolicies returns a simulation of the same airport running only the policies at the
+// given indices. Each policy keeps the random stream it has in s, so with the same seed it
+// draws the same values as in the full simulation.
+func (s *Simulation) withPolicies(indices []int) *Simulation {
+	derived := &Simulation{
+		airport:              s.airport,
+		logger:               s.logger,
+		preSimulationPlugins: s.preSimulationPlugins,
+		policies:             make([]Policy, 0, len(indices)),
+		policyStreams:        make([]uint64, 0, len(indices)),
+		progress:             s.progress,
+		parallelism:          s.parallelism,
+		seed:                 s.seed,
+		seeded:               s.seeded,
+		runwayOptions:        s.runwayOptions,
+		capacityModel:        s.capacityModel,
+		startTime:            s.startTime,
+		endTime:              s.endTime,
+		quiet:                s.quiet,
+		metrics:              s.metrics,
+		minimumWindow:        s.minimumWindow,
+		scenarioHash:         s.scenarioHash,
+	}
+	for _, i := range indices {
+		if priority, ok := s.policyPriorities[i]; ok {
+			if derived.policyPriorities == nil {
+				derived.policyPriorities = make(map[int]int)
+			}
+			derived.policyPriorities[len(derived.policies)] = priority
+		}
+		derived.policies = append(derived.policies, s.policies[i])
+		stream := uint64(i)
+		if s.policyStreams != nil {
+			stream = s.policyStreams[i]
+		}
+		derived.policyStreams = append(derived.policyStreams, stream)
+	}
+	return derived
+}
 
-	// Run event-driven simulation
-	engine := NewEngine(s.logger)
-	return engine.Calculate(ctx, world)
+// RunWithBaseline executes the simulation and an automatically derived theoretical maximum
+// baseline: the same airport (including pre-simulation plugins) with no runtime policies,
+// i.e. 24/7 operations in calm wind with no maintenance, rotation, gate, or taxi constraints.
+// The result reports both capacities and the realism gap between them. A run ID of ctx (see
+// ContextWithRunID) is suffixed with "/baseline" in the baseline's logs.
+func (s *Simulation) RunWithBaseline(ctx context.Context) (*BaselineResult, error) {
+	constrained, err := s.RunResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log(ctx).InfoContext(ctx, "Running unconstrained baseline")
+	if id := RunIDFromContext(ctx); id != "" {
+		ctx = ContextWithRunID(ctx, id+"/baseline")
+	}
+	baseline, err := s.withPolicies(nil).RunResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBaselineResult(constrained, baseline), nil
 }
 
 // AddPolicy adds a runtime policy to the simulation.
 func (s *Simulation) AddPolicy(policy Policy) *Simulation {
+	if s.policyStreams != nil {
+		s.policyStreams = append(s.policyStreams, uint64(len(s.policies)))
+	}
 	s.policies = append(s.policies, policy)
 	return s
 }
 
+// DisablePolicy excludes every policy with the given name from runs, validation and analysis
+// of the simulation, without removing it, so its effect can be checked by comparison.
+// Disabling a name no policy has is reported by Validate.
+func (s *Simulation) DisablePolicy(name string) *Simulation {
+	if s.disabledPolicies == nil {
+		s.disabledPolicies = make(map[string]bool)
+	}
+	s.disabledPolicies[name] = true
+	return s
+}
+
+// EnablePolicy includes policies with the given name again after DisablePolicy.
+func (s *Simulation) EnablePolicy(name string) *Simulation {
+	delete(s.disabledPolicies, name)
+	return s
+}
+
+// enabledPolicies returns s if no policy is disabled, otherwise a simulation running only
+// the enabled policies, each keeping its random stream and priority.
+func (s *Simulation) enabledPolicies() *Simulation {
+	if len(s.disabledPolicies) == 0 {
+		return s
+	}
+	indices := make([]int, 0, len(s.policies))
+	for i, p := range s.policies {
+		if !s.disabledPolicies[p.Name()] {
+			indices = append(indices, i)
+		}
+	}
+	return s.withPolicies(indices)
+}
+
+// AddPolicyWithPriority adds a runtime policy to the simulation with the given priority,
+// overriding any priority the policy declares (see policy.PrioritizedPolicy).
+func (s *Simulation) AddPolicyWithPriority(policy Policy, priority int) *Simulation {
+	if s.policyPriorities == nil {
+		s.policyPriorities = make(map[int]int)
+	}
+	s.policyPriorities[len(s.policies)] = priority
+	return s.AddPolicy(policy)
+}
+
+// policyPriority returns the priority of the policy at index i: the priority it was added
+// with, otherwise the priority it declares, otherwise 0.
+func (s *Simulation) policyPriority(i int) int {
+	if priority, ok := s.policyPriorities[i]; ok {
+		return priority
+	}
+	if pp, ok := s.policies[i].(policy.PrioritizedPolicy); ok {
+		return pp.Priority()
+	}
+	return 0
+}
+
 // AddCurfewPolicy adds a curfew policy that restricts airport operations during specified hours.
 // Returns an error if the curfew time range is invalid.
 func (s *Simulation) AddCurfewPolicy(startTime, endTime time.Time) (*Simulation, error) {
@@ -162,7 +606,8 @@ func (s *Simulation) AddIntelligentMaintenancePolicy(schedule IntelligentMainten
 }
 
 // AddGateCapacityPolicy adds a gate capacity constraint that limits sustained throughput
-// based on available gates and aircraft turnaround time.
+// based on available gates and aircraft turnaround time. Scheduled gate closures reduce the
+// available gates for their duration.
 func (s *Simulation) AddGateCapacityPolicy(constraint GateCapacityConstraint) (*Simulation, error) {
 	p, err := policy.NewGateCapacityPolicy(constraint)
 	if err != nil {
@@ -211,3 +656,267 @@ func (s *Simulation) AddScheduledWindPolicy(windSchedule []WindChange) (*Simulat
 	}
 	return s.AddPolicy(p), nil
 }
+
+// AddWindRosePolicy adds a wind policy that samples time-varying wind from a wind rose, with
+// the configured persistence between intervals, instead of replaying a fixed schedule.
+// The sample is drawn from the simulation's random source (see WithSeed).
+// Returns an error if the rose or configuration is invalid.
+func (s *Simulation) AddWindRosePolicy(config WindRoseConfiguration) (*Simulation, error) {
+	p, err := policy.NewWindRosePolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddGustFactorPolicy adds a gust factor policy that adds separation to every runway while
+// the wind schedule reports gusts at least ThresholdKnots above the mean wind.
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddGustFactorPolicy(config GustFactorConfiguration) (*Simulation, error) {
+	p, err := policy.NewGustFactorPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddDeclaredCapacityPolicy adds a declared capacity policy that caps the airport at the
+// movements per rolling hour declared for slot coordination, whatever its runways could handle.
+// Returns an error if the declared capacity is not positive.
+func (s *Simulation) AddDeclaredCapacityPolicy(movementsPerHour float64) (*Simulation, error) {
+	p, err := policy.NewDeclaredCapacityPolicy(movementsPerHour)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddDeicingPolicy adds a de-icing policy that caps throughput at the departures the de-icing
+// pads can handle, matched by arrivals, while the temperature is below the threshold. The
+// temperature comes from a temperature policy (see AddTemperaturePolicy).
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddDeicingPolicy(config DeicingConfiguration) (*Simulation, error) {
+	p, err := policy.NewDeicingPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddConditionMaintenancePolicy adds a condition-based maintenance policy that closes each
+// runway for maintenance after it has handled a number of movements, modelling wear-based
+// rather than calendar-based maintenance. Such simulations are processed sequentially.
+// Returns an error if the schedule is invalid.
+func (s *Simulation) AddConditionMaintenancePolicy(schedule ConditionMaintenanceSchedule) (*Simulation, error) {
+	p, err := policy.NewConditionMaintenancePolicy(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddConvectiveWeatherPolicy adds a convective weather policy that randomly closes or
+// restricts the whole airfield for short periods (wind shear alerts, thunderstorms overhead).
+// Disruptions are drawn from the simulation's random source (see WithSeed).
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddConvectiveWeatherPolicy(config ConvectiveWeatherConfiguration) (*Simulation, error) {
+	p, err := policy.NewConvectiveWeatherPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddDisruptionPolicy adds a policy that randomly closes runways for short periods (disabled
+// aircraft, inspections after bird strikes) at a rate per 10,000 movements.
+// Closures are drawn from the simulation's random source (see WithSeed).
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddDisruptionPolicy(config DisruptionConfiguration) (*Simulation, error) {
+	p, err := policy.NewDisruptionPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddTemperaturePolicy adds a temperature policy that models time-varying outside air
+// temperature. While it is hot, aircraft classes in the airport's fleet mix are restricted
+// off runways too short for them at the resulting density altitude.
+// Returns an error if the schedule validation fails.
+func (s *Simulation) AddTemperaturePolicy(schedule []TemperatureChange) (*Simulation, error) {
+	p, err := policy.NewTemperaturePolicy(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddTailwindPerformancePolicy adds fleet landing performance data so that operations with a
+// tailwind component lose the arrivals whose corrected landing distance exceeds the runway length.
+// Returns an error if the fleet configuration is invalid.
+func (s *Simulation) AddTailwindPerformancePolicy(config TailwindPerformanceConfiguration) (*Simulation, error) {
+	p, err := policy.NewTailwindPerformancePolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddTidePolicy adds a tide policy that closes water runways whenever the tide falls
+// below the minimum operating height, for seaplane base capacity studies.
+// Returns an error if the tide schedule is invalid.
+func (s *Simulation) AddTidePolicy(schedule TideSchedule) (*Simulation, error) {
+	p, err := policy.NewTidePolicy(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddRunwaySurfaceConditionPolicy adds a policy that makes runways wet or contaminated during
+// the scheduled periods (e.g., from SurfaceConditionsFromRainfall), reducing their wind limits
+// and stretching their separation.
+// Returns an error if the schedule is invalid.
+func (s *Simulation) AddRunwaySurfaceConditionPolicy(schedule RunwaySurfaceConditionSchedule) (*Simulation, error) {
+	p, err := policy.NewRunwaySurfaceConditionPolicy(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddDirectionChangeoverPolicy adds a penalty window during which a runway handles no movements
+// after wind shifts reverse its operating direction (e.g., 09 to 27).
+// Returns an error if the penalty is not positive or is unrealistically long.
+func (s *Simulation) AddDirectionChangeoverPolicy(penalty time.Duration) (*Simulation, error) {
+	p, err := policy.NewDirectionChangeoverPolicy(penalty)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddCommissioningPolicy adds a phased runway opening (e.g., daylight-only operations for
+// three months followed by full operations) as a single policy.
+// Returns an error if the commissioning plan is invalid.
+func (s *Simulation) AddCommissioningPolicy(plan CommissioningPlan) (*Simulation, error) {
+	p, err := policy.NewCommissioningPolicy(plan)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddNoiseQuotaPolicy adds an annual noise budget that caps movements on noise-critical
+// runway ends once their weighted movements exhaust the quota.
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddNoiseQuotaPolicy(config NoiseQuotaConfiguration) (*Simulation, error) {
+	p, err := policy.NewNoiseQuotaPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddRunwayCurfewPolicy adds a daily curfew on specific runway ends or operations
+// (e.g., no departures off 27R after 23:00), or a partial curfew that caps movements per hour.
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddRunwayCurfewPolicy(config RunwayCurfewConfiguration) (*Simulation, error) {
+	p, err := policy.NewRunwayCurfewPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddNightQuotaPolicy adds a nightly period in which only a limited number of movements are
+// permitted (e.g., 16 movements between 23:30 and 06:00), optionally with an annual allowance.
+// Such simulations are processed sequentially.
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddNightQuotaPolicy(config NightQuotaConfiguration) (*Simulation, error) {
+	p, err := policy.NewNightQuotaPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddGAReservationPolicy adds a policy reserving runways or a share of capacity for general
+// aviation during daily hours. Reserved movements are reported separately from the capacity.
+// Returns an error if the configuration is invalid.
+func (s *Simulation) AddGAReservationPolicy(config GAReservationConfiguration) (*Simulation, error) {
+	p, err := policy.NewGAReservationPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddTemporarySeparationPolicy adds a policy increasing runway separation during daily hours,
+// such as trainee controllers on position or runway inspections, dividing the affected
+// runways' capacity by the factor. Returns an error if the configuration is invalid.
+func (s *Simulation) AddTemporarySeparationPolicy(config TemporarySeparationConfiguration) (*Simulation, error) {
+	p, err := policy.NewTemporarySeparationPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddCalendarPolicy adds holiday and special-event overrides (full closures, extended curfews,
+// reduced capacity) on specific dates. Overrides stack with the normal curfew policy.
+// Returns an error if the calendar is empty or an override is invalid.
+func (s *Simulation) AddCalendarPolicy(overrides []CalendarOverride) (*Simulation, error) {
+	p, err := policy.NewCalendarPolicy(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddNoisePreferentialPolicy adds a noise-preferential runway schedule: during each band the
+// first of its runway configurations that can operate is used, even where another has more
+// capacity (see policy.ReadNoisePreferentialSchedule to import a published schedule).
+// Returns an error if the schedule is empty or a band is invalid.
+func (s *Simulation) AddNoisePreferentialPolicy(bands []NoisePreferentialBand) (*Simulation, error) {
+	p, err := policy.NewNoisePreferentialPolicy(bands)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddConstructionPolicy adds a runway construction project: the runway closes for the works
+// and then reopens modified, or is replaced by a new runway (which the policy adds to the
+// airport), optionally with new compatibility.
+// Returns an error if the plan is invalid.
+func (s *Simulation) AddConstructionPolicy(plan ConstructionPlan) (*Simulation, error) {
+	p, err := policy.NewConstructionPolicy(plan)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddPlannedMaintenancePolicy closes runways for an explicit list of maintenance windows,
+// such as a MaintenancePlan's (see OptimizeMaintenance).
+// Returns an error if there are no windows, or a window names no runway or does not end after
+// it starts.
+func (s *Simulation) AddPlannedMaintenancePolicy(windows []MaintenanceWindow) (*Simulation, error) {
+	p, err := policy.NewPlannedMaintenancePolicy(windows)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
+
+// AddRegisteredPolicy adds the policy registered under name (see policy.Register), created
+// from the parameters decode provides.
+// Returns an error if no policy is registered under name or its parameters are invalid.
+func (s *Simulation) AddRegisteredPolicy(name string, decode PolicyDecoder) (*Simulation, error) {
+	p, err := policy.NewRegistered(name, decode)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPolicy(p), nil
+}
@@ -0,0 +1,890 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// addRunwayPlugin is a pre-simulation plugin that adds a runway to the airport.
+type addRunwayPlugin struct {
+	runway airport.Runway
+}
+
+func (p addRunwayPlugin) Apply(ap airport.Airport) airport.Airport {
+	ap.Runways = append(append([]airport.Runway{}, ap.Runways...), p.runway)
+	return ap
+}
+
+func TestSimulation_RunWithBaseline(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}},
+	}
+
+	sim, err := NewSimulation(ap, testEngineLogger()).AddCurfewPolicy(
+		time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+
+	result, err := sim.RunWithBaseline(context.Background())
+	if err != nil {
+		t.Fatalf("RunWithBaseline failed: %v", err)
+	}
+
+	// 366 days (2024 is a leap year) of 60 movements per hour with no constraints
+	expectedBaseline := float32(366 * 24 * 60)
+	if result.Baseline.TotalCapacity != expectedBaseline {
+		t.Errorf("Expected baseline %.0f, got %.0f", expectedBaseline, result.Baseline.TotalCapacity)
+	}
+	if result.Constrained.TotalCapacity >= result.Baseline.TotalCapacity {
+		t.Errorf("Expected curfew to reduce capacity below baseline, got %.0f >= %.0f",
+			result.Constrained.TotalCapacity, result.Baseline.TotalCapacity)
+	}
+
+	expectedUtilization := float64(result.Constrained.TotalCapacity) / float64(result.Baseline.TotalCapacity) * 100
+	if math.Abs(result.UtilizationPercent-expectedUtilization) > 1e-9 {
+		t.Errorf("Expected utilization %.2f%%, got %.2f%%", expectedUtilization, result.UtilizationPercent)
+	}
+	// The curfew closes the runway 7 hours a night
+	if math.Abs(result.RealismGapPercent-(100-expectedUtilization)) > 1e-9 || math.Abs(result.RealismGapPercent-7.0/24*100) > 0.1 {
+		t.Errorf("Expected a realism gap of about %.1f%%, got %.2f%%", 7.0/24*100, result.RealismGapPercent)
+	}
+}
+
+func TestSimulation_RunIsRepeatableWithPlugins(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}},
+	}
+	sim := NewSimulation(ap, testEngineLogger()).AddPreSimulationPlugin(addRunwayPlugin{
+		runway: airport.Runway{RunwayDesignation: "18", TrueBearing: 180, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+	})
+
+	first, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("First run failed: %v", err)
+	}
+	second, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Second run failed: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("Expected repeated runs to match, got %.0f and %.0f", first, second)
+	}
+}
+
+func TestSimulation_CalendarComposesWithCurfew(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}},
+	}
+
+	sim, err := NewSimulation(ap, testEngineLogger()).AddCurfewPolicy(
+		time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+	sim, err = sim.AddCalendarPolicy([]CalendarOverride{
+		{Name: "Holiday", Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Kind: CalendarClosure},
+		{
+			Name:                "Airshow",
+			Date:                time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			Kind:                CalendarReducedCapacity,
+			StartTime:           time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+			EndTime:             time.Date(0, 1, 1, 16, 0, 0, 0, time.UTC),
+			MaxMovementsPerHour: 10,
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddCalendarPolicy failed: %v", err)
+	}
+
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	daily := result.DailyCapacity()
+	expected := []float32{
+		23 * 60,      // Jan 1: curfew from 23:00
+		0,            // Jan 2: holiday closure is not lifted by the 06:00 curfew end
+		17 * 60,      // Jan 3: normal day
+		13*60 + 4*10, // Jan 4: airshow caps 12:00-16:00 at 10 movements per hour
+	}
+	for i, want := range expected {
+		if daily[i].Capacity != want {
+			t.Errorf("Day %s: expected %.0f movements, got %.0f", daily[i].Date.Format("2006-01-02"), want, daily[i].Capacity)
+		}
+	}
+}
+
+func TestSimulation_Validate(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 365, MinimumSeparation: 0},
+		},
+	}
+
+	sim := NewSimulation(ap, testEngineLogger()).AddMaintenancePolicy(MaintenanceSchedule{
+		RunwayDesignations: []string{"27"},
+		Duration:           8 * time.Hour,
+		Frequency:          30 * 24 * time.Hour,
+	})
+
+	err := sim.Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	// Bearing and separation of 18, plus maintenance on an unknown runway
+	if len(validationErr.Problems) != 3 {
+		t.Errorf("Expected 3 problems, got %d: %v", len(validationErr.Problems), err)
+	}
+	if !errors.Is(err, simerrors.ErrRunwayNotFound) {
+		t.Errorf("Expected problems to include ErrRunwayNotFound, got %v", err)
+	}
+	if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected problems to include ErrInvalidConfiguration, got %v", err)
+	}
+
+	if _, err := sim.Run(context.Background()); !errors.As(err, &validationErr) {
+		t.Errorf("Expected Run to fail validation, got %v", err)
+	}
+
+	valid := NewSimulation(airport.Airport{Name: "Test", Runways: ap.Runways[:1]}, testEngineLogger())
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := valid.WithPeriod(start, start).Validate(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected an empty period to be invalid, got %v", err)
+	}
+}
+
+func TestSimulation_RunwayManagerOptions(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 120 * time.Second},
+			{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 120 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{
+			"09L": {"09R"},
+			"09R": {"09L"},
+			"18":  {},
+		}),
+	}
+
+	sim := NewSimulation(ap, testEngineLogger()).WithRunwayManagerOptions(RunwayManagerOptions{PreferredRunways: []string{"27"}})
+	if err := sim.Validate(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Fatalf("Expected invalid configuration error for unknown preferred runway, got %v", err)
+	}
+
+	result, err := sim.WithRunwayManagerOptions(RunwayManagerOptions{TieBreak: PreferMoreRunways}).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if got := result.ConfigurationHistory[0].Label; got != "09L, 09R" {
+		t.Errorf("Expected initial configuration 09L, 09R, got %q", got)
+	}
+}
+
+func TestSimulation_ValidatePolicyConflicts(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createTestRunways()}
+
+	sim, err := NewSimulation(ap, testEngineLogger()).AddWindPolicy(10, 270)
+	if err != nil {
+		t.Fatalf("AddWindPolicy failed: %v", err)
+	}
+	if err := sim.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	sim, err = sim.AddScheduledWindPolicy([]WindChange{{Timestamp: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), SpeedKnots: 5, DirectionTrue: 90}})
+	if err != nil {
+		t.Fatalf("AddScheduledWindPolicy failed: %v", err)
+	}
+
+	err = sim.Validate()
+	if !errors.Is(err, simerrors.ErrPolicyConflict) {
+		t.Fatalf("Expected ErrPolicyConflict, got %v", err)
+	}
+	var conflict *simerrors.PolicyConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected *simerrors.PolicyConflictError, got %v", err)
+	}
+	if len(conflict.Policies) != 2 || conflict.Policies[0] != "WindPolicy" || conflict.Policies[1] != "ScheduledWindPolicy" {
+		t.Errorf("Expected WindPolicy and ScheduledWindPolicy to conflict, got %v", conflict.Policies)
+	}
+}
+
+func TestSimulation_ConstructionChangesCompatibility(t *testing.T) {
+	// 09 and 18 cross, so only one operates at a time until 18 is rebuilt clear of 09
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "18", TrueBearing: 180, LengthMeters: 3000, MinimumSeparation: 60 * time.Second},
+		},
+		RunwayCompatibility: airport.NewRunwayCompatibility(map[string][]string{"09": {}, "18": {}}),
+	}
+	start, end := simulationPeriod()
+	worksEnd := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	replacement := airport.Runway{RunwayDesignation: "17", TrueBearing: 170, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}
+
+	// One runway at 60 movements per hour until the works end, then two
+	expected := float32(worksEnd.Sub(start).Hours()*60 + end.Sub(worksEnd).Hours()*120)
+
+	tests := []struct {
+		name string
+		plan ConstructionPlan
+	}{
+		{
+			name: "rebuilt in place",
+			plan: ConstructionPlan{
+				RunwayDesignation: "18",
+				Start:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				End:               worksEnd,
+				Modification:      airport.RunwayModification{LengthMeters: 3500},
+				CompatibleWith:    []string{"09"},
+			},
+		},
+		{
+			name: "replaced by a new runway",
+			plan: ConstructionPlan{
+				RunwayDesignation: "18",
+				Start:             time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				End:               worksEnd,
+				Replacement:       &replacement,
+				CompatibleWith:    []string{"09"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim, err := NewSimulation(ap, testEngineLogger()).AddConstructionPolicy(tt.plan)
+			if err != nil {
+				t.Fatalf("AddConstructionPolicy failed: %v", err)
+			}
+
+			sequential, err := sim.RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("RunResult failed: %v", err)
+			}
+			if sequential.TotalCapacity != expected {
+				t.Errorf("Expected %.0f movements, got %.0f", expected, sequential.TotalCapacity)
+			}
+
+			// The compatibility change carries across month boundaries
+			parallel, err := sim.WithParallelism(4).RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("Parallel RunResult failed: %v", err)
+			}
+			assertSameResult(t, sequential, parallel)
+		})
+	}
+
+	// The simulation's airport is unchanged by the replacement
+	if len(ap.Runways) != 2 || len(ap.RunwayCompatibility.CompatibleWith["09"]) != 0 {
+		t.Errorf("Expected the airport to be unchanged, got %v %v", ap.Runways, ap.RunwayCompatibility.CompatibleWith)
+	}
+}
+
+func TestSimulation_OverlappingClosures(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	// Maintenance ending inside the works must not reopen the runway
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, end).
+		AddConstructionPolicy(ConstructionPlan{RunwayDesignation: "09", Start: start.AddDate(0, 0, 1), End: end})
+	if err != nil {
+		t.Fatalf("AddConstructionPolicy failed: %v", err)
+	}
+	if _, err := sim.AddPlannedMaintenancePolicy([]MaintenanceWindow{{RunwayDesignation: "09", Start: start.AddDate(0, 0, 3), End: start.AddDate(0, 0, 4)}}); err != nil {
+		t.Fatalf("AddPlannedMaintenancePolicy failed: %v", err)
+	}
+
+	// Only the first day is open, at 60 movements per hour
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+	if result.TotalCapacity != 1440 {
+		t.Errorf("Expected 1440 movements, got %.0f", result.TotalCapacity)
+	}
+}
+
+func TestSimulation_ConvectiveWeatherDisruptions(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createTestRunways()}
+	config := ConvectiveWeatherConfiguration{
+		EventsPerYear:           300,
+		Duration:                LogNormalDuration{Median: 45 * time.Minute, Sigma: 0.8},
+		TotalClosureProbability: 0.4,
+		PartialCapacityFactor:   0.5,
+	}
+
+	baseline, err := NewSimulation(ap, testEngineLogger()).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Baseline RunResult failed: %v", err)
+	}
+	sim, err := NewSimulation(ap, testEngineLogger()).WithSeed(11).AddConvectiveWeatherPolicy(config)
+	if err != nil {
+		t.Fatalf("AddConvectiveWeatherPolicy failed: %v", err)
+	}
+	sequential, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// About 300 * 0.6 * 45 min equivalent hours lost out of 8784
+	loss := 1 - float64(sequential.TotalCapacity/baseline.TotalCapacity)
+	if loss <= 0 || loss > 0.05 {
+		t.Errorf("Expected a small capacity loss from disruptions, got %.2f%%", loss*100)
+	}
+	disrupted := false
+	for _, window := range sequential.Windows {
+		if window.DisruptionFactor < 1 {
+			disrupted = true
+			break
+		}
+	}
+	if !disrupted {
+		t.Error("Expected windows with disruptions")
+	}
+
+	// Disruptions in progress carry across month boundaries
+	parallel, err := sim.WithParallelism(4).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Parallel RunResult failed: %v", err)
+	}
+	assertSameResult(t, sequential, parallel)
+}
+
+func TestSimulation_UnplannedClosures(t *testing.T) {
+	ap := airport.Airport{Name: "Test", Runways: createTestRunways()}
+	config := DisruptionConfiguration{
+		EventsPer10kMovements: 3,
+		MovementsPerHour:      60,
+		Duration:              ExponentialDuration{Mean: 20 * time.Minute, Max: 90 * time.Minute},
+	}
+
+	baseline, err := NewSimulation(ap, testEngineLogger()).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Baseline RunResult failed: %v", err)
+	}
+	sim, err := NewSimulation(ap, testEngineLogger()).WithSeed(7).AddDisruptionPolicy(config)
+	if err != nil {
+		t.Fatalf("AddDisruptionPolicy failed: %v", err)
+	}
+	first, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// About 160 closures of 20 minutes each, spread over the runways
+	loss := 1 - float64(first.TotalCapacity/baseline.TotalCapacity)
+	if loss <= 0 || loss > 0.02 {
+		t.Errorf("Expected a small capacity loss from unplanned closures, got %.3f%%", loss*100)
+	}
+
+	// The same seed reproduces the same closures, sequentially or in parallel
+	second, err := sim.WithParallelism(4).RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("Parallel RunResult failed: %v", err)
+	}
+	assertSameResult(t, first, second)
+}
+
+// scheduledPolicy is a policy that schedules a fixed list of events, with a priority.
+type scheduledPolicy struct {
+	name     string
+	priority int
+	events   []event.Event
+}
+
+func (p *scheduledPolicy) Name() string  { return p.name }
+func (p *scheduledPolicy) Priority() int { return p.priority }
+
+func (p *scheduledPolicy) GenerateEvents(ctx context.Context, world policy.EventWorld) error {
+	for _, evt := range p.events {
+		world.ScheduleEvent(evt)
+	}
+	return nil
+}
+
+func TestSimulation_DeterministicEventGeneration(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	noon := start.Add(12 * time.Hour)
+
+	// Several policies set the rotation multiplier at noon; the last applied is in force
+	trace := func() ([]byte, float32) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		sim := NewSimulation(ap, logger).WithSeed(1).WithPeriod(start, start.AddDate(0, 0, 1))
+		for i := range 8 {
+			sim.AddPolicy(&scheduledPolicy{
+				name:   fmt.Sprintf("rotation-%d", i),
+				events: []event.Event{event.NewRotationChangeEvent(float32(i+1)/10, noon)},
+			})
+		}
+		result, err := sim.RunResult(context.Background())
+		if err != nil {
+			t.Fatalf("RunResult failed: %v", err)
+		}
+		for _, w := range result.Windows {
+			fmt.Fprintf(&buf, "%v %v %v\n", w.Start, w.End, w.Capacity)
+		}
+		return buf.Bytes(), result.TotalCapacity
+	}
+
+	first, total := trace()
+	for range 20 {
+		if got, _ := trace(); !bytes.Equal(got, first) {
+			t.Fatalf("Expected identical traces across runs, got:\n%s\nthen:\n%s", first, got)
+		}
+	}
+	// The multiplier of the last policy added applies from noon
+	if want := float32(12*60 + 12*60*0.8); total != want {
+		t.Errorf("Expected %.0f movements, got %.1f", want, total)
+	}
+}
+
+// initialStatePolicy sets world state at the start with apply and schedules events.
+type initialStatePolicy struct {
+	scheduledPolicy
+	apply func(ctx context.Context, world policy.InitialStateWorld) error
+}
+
+func (p *initialStatePolicy) SetInitialState(ctx context.Context, world policy.InitialStateWorld) error {
+	return p.apply(ctx, world)
+}
+
+func TestSimulation_InitialStatePolicy(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second, CrosswindLimitKnots: 20}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(t *testing.T, policies ...Policy) float32 {
+		t.Helper()
+		sim := NewSimulation(ap, testEngineLogger()).WithSeed(1).WithPeriod(start, start.AddDate(0, 0, 1))
+		for _, p := range policies {
+			sim.AddPolicy(p)
+		}
+		capacity, err := sim.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return capacity
+	}
+
+	t.Run("scheduled wind before start", func(t *testing.T) {
+		// A southerly gale from the evening before closes 09 until the calm at noon
+		wind, err := policy.NewScheduledWindPolicy([]WindChange{
+			{Timestamp: start.Add(-time.Hour), SpeedKnots: 30, DirectionTrue: 180},
+			{Timestamp: start.Add(12 * time.Hour), SpeedKnots: 0, DirectionTrue: 0},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := run(t, wind); got != 12*60 {
+			t.Errorf("Expected %d movements, got %.1f", 12*60, got)
+		}
+	})
+
+	t.Run("runway closed at start", func(t *testing.T) {
+		closed := &initialStatePolicy{
+			scheduledPolicy: scheduledPolicy{name: "closed", events: []event.Event{event.NewRunwayClosureEndEvent("09", "works", start.Add(6*time.Hour))}},
+			apply: func(ctx context.Context, world policy.InitialStateWorld) error {
+				return event.NewRunwayClosureStartEvent("09", "works", world.GetStartTime()).Apply(ctx, world)
+			},
+		}
+		if got := run(t, closed); got != 18*60 {
+			t.Errorf("Expected %d movements, got %.1f", 18*60, got)
+		}
+	})
+
+	t.Run("priority order", func(t *testing.T) {
+		// As with events, the state of the policy applied last is in force
+		rotation := func(name string, priority int, multiplier float32) *initialStatePolicy {
+			return &initialStatePolicy{
+				scheduledPolicy: scheduledPolicy{name: name, priority: priority},
+				apply: func(ctx context.Context, world policy.InitialStateWorld) error {
+					world.SetRotationMultiplier(multiplier)
+					return nil
+				},
+			}
+		}
+		if got := run(t, rotation("low", 0, 0.5), rotation("high", 1, 0.8)); got != 24*60*0.5 {
+			t.Errorf("Expected %.0f movements, got %.1f", 24*60*0.5, got)
+		}
+		if got := run(t, rotation("first", 0, 0.5), rotation("second", 0, 0.8)); got != 24*60*0.8 {
+			t.Errorf("Expected %.0f movements, got %.1f", 24*60*0.8, got)
+		}
+	})
+}
+
+func TestSimulation_InitialStateWithoutEvents(t *testing.T) {
+	runway := func(designation string, bearing float64) airport.Runway {
+		return airport.Runway{RunwayDesignation: designation, TrueBearing: bearing, LengthMeters: 3000, MinimumSeparation: 60 * time.Second, CrosswindLimitKnots: 20}
+	}
+	ap := airport.Airport{Name: "Test", Runways: []airport.Runway{runway("09", 90), runway("18", 180), runway("27N", 270)}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sim := NewSimulation(ap, testEngineLogger()).WithSeed(1).WithPeriod(start, start.AddDate(0, 0, 7)).
+		RunwayRotationPolicy(TimeBasedRotation)
+	sim, err := sim.AddGateCapacityPolicy(GateCapacityConstraint{TotalGates: 40, AverageTurnaroundTime: time.Hour})
+	if err != nil {
+		t.Fatalf("AddGateCapacityPolicy failed: %v", err)
+	}
+	if _, err := sim.AddTaxiTimePolicy(TaxiTimeConfiguration{AverageTaxiInTime: 5 * time.Minute, AverageTaxiOutTime: 10 * time.Minute}); err != nil {
+		t.Fatalf("AddTaxiTimePolicy failed: %v", err)
+	}
+	if _, err := sim.AddTailwindPerformancePolicy(TailwindPerformanceConfiguration{
+		Fleet: []airport.LandingPerformance{{Class: "A320", Share: 1, LandingDistanceMeters: 1500}},
+	}); err != nil {
+		t.Fatalf("AddTailwindPerformancePolicy failed: %v", err)
+	}
+	if _, err := sim.AddCommissioningPolicy(CommissioningPlan{RunwayDesignation: "27N", Phases: []CommissioningPhase{
+		{Start: start.AddDate(0, 0, -1), Mode: CommissioningClosed},
+		{Start: start.AddDate(0, 0, 2), Mode: CommissioningFull},
+	}}); err != nil {
+		t.Fatalf("AddCommissioningPolicy failed: %v", err)
+	}
+	if _, err := sim.AddConstructionPolicy(ConstructionPlan{RunwayDesignation: "18", Start: start.AddDate(0, 0, -1), End: start.AddDate(0, 0, 1)}); err != nil {
+		t.Fatalf("AddConstructionPolicy failed: %v", err)
+	}
+	if _, err := sim.AddPlannedMaintenancePolicy([]MaintenanceWindow{{RunwayDesignation: "09", Start: start.Add(-time.Hour), End: start.Add(time.Hour)}}); err != nil {
+		t.Fatalf("AddPlannedMaintenancePolicy failed: %v", err)
+	}
+
+	world, _, err := sim.prepareWorld(context.Background())
+	if err != nil {
+		t.Fatalf("prepareWorld failed: %v", err)
+	}
+
+	// Their starting conditions are in force...
+	if got := world.GetRotationMultiplier(); got == 1 {
+		t.Error("Expected the rotation multiplier set at the start")
+	}
+	if gates, turnaround := world.GetGateCapacity(); gates != 40 || turnaround != time.Hour {
+		t.Errorf("Expected 40 gates with a 1h turnaround at the start, got %d and %v", gates, turnaround)
+	}
+	if got := world.GetTaxiTimeOverhead(); got != 15*time.Minute {
+		t.Errorf("Expected 15m taxi time overhead at the start, got %v", got)
+	}
+	if len(world.LandingPerformance) != 1 {
+		t.Errorf("Expected the landing performance set at the start, got %v", world.LandingPerformance)
+	}
+	for _, runwayID := range []string{"09", "18", "27N"} {
+		if available, _ := world.GetRunwayAvailable(runwayID); available {
+			t.Errorf("Expected %s closed at the start", runwayID)
+		}
+	}
+
+	// ... without any event at the start racing with the others there
+	for world.Events.HasNext() {
+		evt := world.Events.Pop()
+		if !evt.Time().After(start) {
+			switch evt.Type() {
+			case event.RotationChangeType, event.GateCapacityConstraintType, event.TaxiTimeAdjustmentType, event.TailwindPerformanceType,
+				event.RunwayClosureStartType, event.RunwayMaintenanceStartType, event.RunwayModificationType:
+				t.Errorf("Expected no %v event at the start", evt.Type())
+			}
+		}
+	}
+}
+
+func TestSimulation_PolicyPriority(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	noon := start.Add(12 * time.Hour)
+	rotation := func(name string, priority int, multiplier float32) *scheduledPolicy {
+		return &scheduledPolicy{name: name, priority: priority, events: []event.Event{event.NewRotationChangeEvent(multiplier, noon)}}
+	}
+
+	tests := []struct {
+		name string
+		sim  *Simulation
+		want float32
+	}{
+		{
+			name: "declaration order",
+			sim:  NewSimulation(ap, testEngineLogger()).AddPolicy(rotation("a", 0, 0.5)).AddPolicy(rotation("b", 0, 0.25)),
+			want: 12*60 + 12*60*0.25,
+		},
+		{
+			// Higher priority events apply first, so the lower priority multiplier is in force
+			name: "declared priority",
+			sim:  NewSimulation(ap, testEngineLogger()).AddPolicy(rotation("a", 0, 0.5)).AddPolicy(rotation("b", 1, 0.25)),
+			want: 12*60 + 12*60*0.5,
+		},
+		{
+			name: "priority set when added",
+			sim:  NewSimulation(ap, testEngineLogger()).AddPolicyWithPriority(rotation("a", 0, 0.5), 2).AddPolicy(rotation("b", 1, 0.25)),
+			want: 12*60 + 12*60*0.25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.sim.WithPeriod(start, start.AddDate(0, 0, 1)).RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("RunResult failed: %v", err)
+			}
+			if result.TotalCapacity != tt.want {
+				t.Errorf("Expected %.0f movements, got %.1f", tt.want, result.TotalCapacity)
+			}
+		})
+	}
+}
+
+func TestSimulation_PreviewEvents(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+		AddPolicy(&scheduledPolicy{name: "late", events: []event.Event{
+			event.NewRunwayMaintenanceStartEvent("09", start.Add(10*time.Hour)),
+			event.NewRunwayMaintenanceEndEvent("09", start.Add(12*time.Hour)),
+		}}).
+		AddPolicy(&scheduledPolicy{name: "early", events: []event.Event{
+			event.NewRotationChangeEvent(0.5, start.Add(2*time.Hour)),
+		}})
+
+	events, err := sim.PreviewEvents(context.Background())
+	if err != nil {
+		t.Fatalf("PreviewEvents failed: %v", err)
+	}
+	want := []event.EventType{event.RotationChangeType, event.RunwayMaintenanceStartType, event.RunwayMaintenanceEndType}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(events))
+	}
+	for i, evt := range events {
+		if evt.Type() != want[i] {
+			t.Errorf("Event %d: expected %v, got %v", i, want[i], evt.Type())
+		}
+	}
+
+	// Disabled policies generate nothing and do not run
+	events, err = sim.DisablePolicy("late").PreviewEvents(context.Background())
+	if err != nil {
+		t.Fatalf("PreviewEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Type() != event.RotationChangeType {
+		t.Errorf("Expected only the rotation change with late disabled, got %d events", len(events))
+	}
+	total, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if want := float32(2*60 + 22*30); total != want {
+		t.Errorf("Expected %.0f movements with late disabled, got %.1f", want, total)
+	}
+
+	total, err = sim.EnablePolicy("late").Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if want := float32(2*60 + 20*30); total != want {
+		t.Errorf("Expected %.0f movements with every policy enabled, got %.1f", want, total)
+	}
+
+	// Disabling a policy that does not exist is a configuration problem
+	err = sim.DisablePolicy("missing").Validate()
+	if !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected an invalid configuration error for an unknown disabled policy, got %v", err)
+	}
+}
+
+func TestSimulation_GAReservation(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return start.Add(time.Duration(hour) * time.Hour) }
+
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+		AddGAReservationPolicy(GAReservationConfiguration{StartTime: at(8), EndTime: at(10), Runways: []string{"09"}})
+	if err != nil {
+		t.Fatalf("AddGAReservationPolicy failed: %v", err)
+	}
+	if sim, err = sim.AddGAReservationPolicy(GAReservationConfiguration{StartTime: at(10), EndTime: at(12), Share: 0.25}); err != nil {
+		t.Fatalf("AddGAReservationPolicy failed: %v", err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// The whole runway for two hours, then a quarter of it for two hours
+	ga := float32(0)
+	for _, w := range result.Windows {
+		ga += w.GAMovements
+	}
+	if want := float32(2*60 + 2*15); ga != want {
+		t.Errorf("Expected %.0f GA movements, got %.1f", want, ga)
+	}
+	if want := float32(24*60) - ga; result.TotalCapacity != want {
+		t.Errorf("Expected %.0f movements excluding GA, got %.1f", want, result.TotalCapacity)
+	}
+
+	usage := result.RunwayGAUsage()
+	if len(usage) != 1 || usage[0].Label != "09" || usage[0].Duration != 4*time.Hour || usage[0].Movements != float64(ga) {
+		t.Errorf("Unexpected GA runway usage: %+v", usage)
+	}
+}
+
+func TestSimulation_GateClosures(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 40 gates sustain 80 movements per hour, above the runway's 60, until closing 20 of
+	// them halves that to 40 for twelve hours. In the first hour of the closure the 30
+	// aircraft already on gates still depart, freeing 20 gates for arrivals: 10 more movements
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+		AddGateCapacityPolicy(GateCapacityConstraint{
+			TotalGates:            40,
+			AverageTurnaroundTime: time.Hour,
+			Closures:              []GateClosure{{Gates: 20, Start: start.Add(6 * time.Hour), End: start.Add(18 * time.Hour)}},
+		})
+	if err != nil {
+		t.Fatalf("AddGateCapacityPolicy failed: %v", err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	if want := float32(12*60 + 12*40 + 10); math.Abs(float64(result.TotalCapacity-want)) > 0.5 {
+		t.Errorf("Expected %.0f movements, got %.1f", want, result.TotalCapacity)
+	}
+}
+
+func TestSimulation_ExplicitRunwayEnds(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{{
+			RunwayDesignation: "03",
+			TrueBearing:       32,
+			MinimumSeparation: 60 * time.Second,
+			Ends:              [2]airport.RunwayEnd{{Designation: "03", TrueBearing: 32}, {Designation: "20", TrueBearing: 205}},
+		}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	curfew := func(end string) RunwayCurfewConfiguration {
+		return RunwayCurfewConfiguration{StartTime: start, EndTime: start.Add(6 * time.Hour), RunwayEnds: []string{end}}
+	}
+
+	// Runway end 20 replaces the derived reciprocal of 03 (21)
+	invalid, err := NewSimulation(ap, testEngineLogger()).AddRunwayCurfewPolicy(curfew("21"))
+	if err != nil {
+		t.Fatalf("AddRunwayCurfewPolicy failed: %v", err)
+	}
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected an error for a curfew on runway end 21")
+	}
+
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).AddWindPolicy(10, 205)
+	if err != nil {
+		t.Fatalf("AddWindPolicy failed: %v", err)
+	}
+	if sim, err = sim.AddRunwayCurfewPolicy(curfew("20")); err != nil {
+		t.Fatalf("AddRunwayCurfewPolicy failed: %v", err)
+	}
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	// Into the wind from 205 degrees, the runway operates from end 20, which is curfewed at night
+	if want := float32(18 * 60); result.TotalCapacity != want {
+		t.Errorf("Expected %.0f movements, got %.1f", want, result.TotalCapacity)
+	}
+	for _, w := range result.Windows {
+		if len(w.RunwayEnds) != 1 || w.RunwayEnds[0] != "20" {
+			t.Fatalf("Expected runway end 20 in use, got %v", w.RunwayEnds)
+		}
+	}
+}
+
+func TestSimulation_Helipads(t *testing.T) {
+	ap := airport.Airport{
+		Name:     "Test",
+		Runways:  []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: 60 * time.Second}},
+		Helipads: []airport.Helipad{{Designation: "H1", MinimumSeparation: 120 * time.Second, WindLimitKnots: 25}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return start.Add(time.Duration(hour) * time.Hour) }
+
+	sim := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+		AddPolicy(&scheduledPolicy{name: "events", events: []event.Event{
+			// The runway closes while the helipad keeps operating...
+			event.NewRunwayMaintenanceStartEvent("09", at(10)),
+			event.NewRunwayMaintenanceEndEvent("09", at(12)),
+			// ...and a strong wind closes the helipad but not the runway
+			event.NewWindChangeEvent(30, 90, at(14)),
+			event.NewWindChangeEvent(10, 90, at(16)),
+		}})
+	result, err := sim.RunResult(context.Background())
+	if err != nil {
+		t.Fatalf("RunResult failed: %v", err)
+	}
+
+	helipad := float32(0)
+	for _, w := range result.Windows {
+		helipad += w.HelipadMovements
+	}
+	if want := float32(22 * 30); helipad != want {
+		t.Errorf("Expected %.0f helipad movements, got %.1f", want, helipad)
+	}
+	if want := float32(22*60 + 22*30); result.TotalCapacity != want {
+		t.Errorf("Expected %.0f movements in total, got %.1f", want, result.TotalCapacity)
+	}
+}
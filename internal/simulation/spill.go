@@ -0,0 +1,166 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DefaultSpillStep is the window over which demand is compared with capacity by default.
+const DefaultSpillStep = time.Hour
+
+// SpillAirport is one airport of a multi-airport system (e.g., a city's airports) for a demand
+// spill model: the result of simulating the airport on its own and the traffic that wants to
+// use it.
+type SpillAirport struct {
+	Name   string
+	Result *Result // Capacity of the airport, from its own simulation
+
+	// DemandPerHour returns the movements per hour that want to use the airport at time t.
+	// It is sampled at the start of each step.
+	DemandPerHour func(t time.Time) float64
+}
+
+// SpillOptions configures SpillDemand. Zero values select the defaults.
+type SpillOptions struct {
+	Step            time.Duration // Window over which demand is compared with capacity (default: DefaultSpillStep)
+	TransferPenalty float64       // Share of spilled movements that do not transfer to another airport, in [0, 1]
+}
+
+// AirportSpill is the traffic of one airport in a demand spill model over the whole period.
+type AirportSpill struct {
+	Name     string
+	Demand   float64 // Movements that wanted to use the airport
+	Capacity float64 // Movements the airport could handle
+	Served   float64 // Movements of the airport's own demand it handled
+	Spilled  float64 // Movements of the airport's demand beyond its capacity
+	Absorbed float64 // Movements spilled from other airports that it handled
+}
+
+// Utilization returns the share of the airport's capacity used by its own and absorbed
+// traffic, or 0 for an airport with no capacity.
+func (a AirportSpill) Utilization() float64 {
+	if a.Capacity == 0 {
+		return 0
+	}
+	return (a.Served + a.Absorbed) / a.Capacity
+}
+
+// SpillResult is the outcome of a demand spill model.
+type SpillResult struct {
+	Airports []AirportSpill // One entry per airport, in the order given
+	Spilled  float64        // Movements spilled across the system
+	Absorbed float64        // Spilled movements handled by another airport
+	Lost     float64        // Spilled movements not handled anywhere (Spilled - Absorbed)
+}
+
+// String formats the spill of each airport on its own line, followed by the system totals.
+func (r *SpillResult) String() string {
+	var b strings.Builder
+	for _, a := range r.Airports {
+		fmt.Fprintf(&b, "%s: %.0f of %.0f movements spilled, %.0f absorbed from other airports (%.1f%% utilization)\n",
+			a.Name, a.Spilled, a.Demand, a.Absorbed, a.Utilization()*100)
+	}
+	fmt.Fprintf(&b, "System: %.0f movements spilled, %.0f absorbed, %.0f lost", r.Spilled, r.Absorbed, r.Lost)
+	return b.String()
+}
+
+// SpillDemand models traffic reallocation within a system of airports simulated separately
+// over the same period. In each step, an airport whose demand exceeds its capacity spills the
+// excess; after the transfer penalty, the rest is offered to the other airports in the order
+// given, each absorbing what its remaining capacity (after its own demand) allows. Movements
+// no airport absorbs are lost. Airports spill in the order given, so earlier airports have
+// first claim on the spare capacity of later ones.
+//
+// Capacity is assumed to be spread evenly within each simulation window.
+// Returns an error if there are fewer than two airports, an airport is incomplete or named
+// twice, the results cover different periods, or an option is out of range.
+func SpillDemand(airports []SpillAirport, opts SpillOptions) (*SpillResult, error) {
+	step := opts.Step
+	if step == 0 {
+		step = DefaultSpillStep
+	}
+	if step < 0 {
+		return nil, simerrors.Invalidf("spill step must be positive, got %v", step)
+	}
+	if opts.TransferPenalty < 0 || opts.TransferPenalty > 1 || math.IsNaN(opts.TransferPenalty) {
+		return nil, simerrors.Invalidf("transfer penalty must be in [0, 1], got %v", opts.TransferPenalty)
+	}
+	if len(airports) < 2 {
+		return nil, simerrors.Invalidf("demand spill needs at least two airports, got %d", len(airports))
+	}
+
+	names := make(map[string]bool, len(airports))
+	for i, a := range airports {
+		switch {
+		case a.Name == "":
+			return nil, simerrors.Invalidf("spill airport %d has no name", i+1)
+		case names[a.Name]:
+			return nil, simerrors.Invalidf("spill airport %s is listed twice", a.Name)
+		case a.Result == nil:
+			return nil, simerrors.Invalidf("spill airport %s has no result", a.Name)
+		case a.DemandPerHour == nil:
+			return nil, simerrors.Invalidf("spill airport %s has no demand", a.Name)
+		case !a.Result.StartTime.Equal(airports[0].Result.StartTime) || !a.Result.EndTime.Equal(airports[0].Result.EndTime):
+			return nil, simerrors.Invalidf("spill airport %s is simulated over %v to %v, not %v to %v", a.Name,
+				a.Result.StartTime, a.Result.EndTime, airports[0].Result.StartTime, airports[0].Result.EndTime)
+		}
+		names[a.Name] = true
+	}
+
+	result := &SpillResult{Airports: make([]AirportSpill, len(airports))}
+	cumulative := make([]func(time.Time) float64, len(airports))
+	for i, a := range airports {
+		result.Airports[i].Name = a.Name
+		cumulative[i] = a.Result.cumulativeCapacity()
+	}
+
+	spare := make([]float64, len(airports))
+	excess := make([]float64, len(airports))
+	start, end := airports[0].Result.StartTime, airports[0].Result.EndTime
+	for t := start; t.Before(end); t = t.Add(step) {
+		next := t.Add(step)
+		if next.After(end) {
+			next = end
+		}
+
+		// Each airport serves its own demand first
+		for i, a := range airports {
+			demand := max(a.DemandPerHour(t), 0) * next.Sub(t).Hours()
+			capacity := cumulative[i](next) - cumulative[i](t)
+			served := min(demand, capacity)
+
+			spill := &result.Airports[i]
+			spill.Demand += demand
+			spill.Capacity += capacity
+			spill.Served += served
+			spill.Spilled += demand - served
+			spare[i] = capacity - served
+			excess[i] = demand - served
+		}
+
+		// Then offers what it cannot to the others
+		for i := range airports {
+			offered := excess[i] * (1 - opts.TransferPenalty)
+			for j := range airports {
+				if j == i || offered <= 0 {
+					continue
+				}
+				absorbed := min(offered, spare[j])
+				spare[j] -= absorbed
+				offered -= absorbed
+				result.Airports[j].Absorbed += absorbed
+			}
+		}
+	}
+
+	for _, a := range result.Airports {
+		result.Spilled += a.Spilled
+		result.Absorbed += a.Absorbed
+	}
+	result.Lost = result.Spilled - result.Absorbed
+	return result, nil
+}
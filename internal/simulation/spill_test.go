@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// spillResult returns a result over [start, start+hours) with a constant capacity per hour.
+func spillResult(start time.Time, hours int, perHour float32) *Result {
+	end := start.Add(time.Duration(hours) * time.Hour)
+	return &Result{
+		StartTime:     start,
+		EndTime:       end,
+		TotalCapacity: perHour * float32(hours),
+		Windows:       []WindowResult{{Start: start, End: end, Capacity: perHour * float32(hours)}},
+	}
+}
+
+func constantDemand(perHour float64) func(time.Time) float64 {
+	return func(time.Time) float64 { return perHour }
+}
+
+func TestSpillDemand(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	airports := []SpillAirport{
+		{Name: "A", Result: spillResult(start, 2, 60), DemandPerHour: constantDemand(80)},
+		{Name: "B", Result: spillResult(start, 2, 60), DemandPerHour: constantDemand(50)},
+		{Name: "C", Result: spillResult(start, 2, 60), DemandPerHour: constantDemand(55)},
+	}
+
+	result, err := SpillDemand(airports, SpillOptions{TransferPenalty: 0.25})
+	if err != nil {
+		t.Fatalf("SpillDemand failed: %v", err)
+	}
+
+	// A spills 20 an hour, of which 15 transfer: 10 to B, then the 5 C has spare
+	want := []AirportSpill{
+		{Name: "A", Demand: 160, Capacity: 120, Served: 120, Spilled: 40},
+		{Name: "B", Demand: 100, Capacity: 120, Served: 100, Absorbed: 20},
+		{Name: "C", Demand: 110, Capacity: 120, Served: 110, Absorbed: 10},
+	}
+	for i, w := range want {
+		if got := result.Airports[i]; got != w {
+			t.Errorf("Airport %s: expected %+v, got %+v", w.Name, w, got)
+		}
+	}
+	if result.Spilled != 40 || result.Absorbed != 30 || result.Lost != 10 {
+		t.Errorf("Expected 40 spilled, 30 absorbed and 10 lost, got %.1f, %.1f and %.1f", result.Spilled, result.Absorbed, result.Lost)
+	}
+	if got := result.Airports[1].Utilization(); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected B to be fully utilized, got %.3f", got)
+	}
+}
+
+func TestSpillDemand_TimeVaryingDemand(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A is busy in the first hour and B in the second, so each absorbs the other's spill
+	peak := func(hour int) func(time.Time) float64 {
+		return func(t time.Time) float64 {
+			if t.Hour() == hour {
+				return 70
+			}
+			return 40
+		}
+	}
+	result, err := SpillDemand([]SpillAirport{
+		{Name: "A", Result: spillResult(start, 2, 60), DemandPerHour: peak(0)},
+		{Name: "B", Result: spillResult(start, 2, 60), DemandPerHour: peak(1)},
+	}, SpillOptions{})
+	if err != nil {
+		t.Fatalf("SpillDemand failed: %v", err)
+	}
+	if result.Spilled != 20 || result.Absorbed != 20 || result.Lost != 0 {
+		t.Errorf("Expected 20 spilled and absorbed, got %.1f spilled, %.1f absorbed, %.1f lost", result.Spilled, result.Absorbed, result.Lost)
+	}
+}
+
+func TestSpillDemand_Invalid(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() []SpillAirport {
+		return []SpillAirport{
+			{Name: "A", Result: spillResult(start, 2, 60), DemandPerHour: constantDemand(80)},
+			{Name: "B", Result: spillResult(start, 2, 60), DemandPerHour: constantDemand(50)},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		airports func() []SpillAirport
+		opts     SpillOptions
+	}{
+		{"one airport", func() []SpillAirport { return valid()[:1] }, SpillOptions{}},
+		{"duplicate name", func() []SpillAirport { a := valid(); a[1].Name = "A"; return a }, SpillOptions{}},
+		{"no result", func() []SpillAirport { a := valid(); a[1].Result = nil; return a }, SpillOptions{}},
+		{"no demand", func() []SpillAirport { a := valid(); a[1].DemandPerHour = nil; return a }, SpillOptions{}},
+		{"different periods", func() []SpillAirport { a := valid(); a[1].Result = spillResult(start, 3, 60); return a }, SpillOptions{}},
+		{"negative step", valid, SpillOptions{Step: -time.Hour}},
+		{"penalty above one", valid, SpillOptions{TransferPenalty: 1.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SpillDemand(tt.airports(), tt.opts); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+				t.Errorf("Expected invalid configuration error, got %v", err)
+			}
+		})
+	}
+}
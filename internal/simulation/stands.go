@@ -0,0 +1,79 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DefaultStandDemandInterval is the interval between the samples of a stand demand profile
+// written by scenarios.
+const DefaultStandDemandInterval = 15 * time.Minute
+
+// StandDemand returns the stand demand profile of a run with a gate capacity constraint: the
+// gates in use at the start of every interval from the run's start, interpolated from the
+// windows' gate samples. It shows when an airport runs out of stands, even while its runways
+// have capacity to spare.
+//
+// Returns nil if the run had no gate capacity constraint, and omits any times before the
+// gates were first filled. Returns an error if the interval is not positive.
+func (r *Result) StandDemand(interval time.Duration) ([]GateSample, error) {
+	if interval <= 0 {
+		return nil, simerrors.Invalidf("stand demand interval must be positive, got %v", interval)
+	}
+
+	var samples []GateSample
+	for _, w := range r.Windows {
+		samples = append(samples, w.GateSamples...)
+	}
+	if len(samples) == 0 {
+		return nil, nil
+	}
+
+	var profile []GateSample
+	i := 0
+	for t := r.StartTime; t.Before(r.EndTime); t = t.Add(interval) {
+		if t.Before(samples[0].Time) {
+			continue
+		}
+		// Find the last sample at or before t: where windows meet, the next window's first
+		// sample, with the gates open in it
+		for i+1 < len(samples) && !samples[i+1].Time.After(t) {
+			i++
+		}
+		sample := GateSample{Time: t, Open: samples[i].Open, InUse: samples[i].InUse}
+		if i+1 < len(samples) && t.After(samples[i].Time) {
+			next := samples[i+1]
+			fraction := t.Sub(samples[i].Time).Seconds() / next.Time.Sub(samples[i].Time).Seconds()
+			sample.InUse += fraction * (next.InUse - samples[i].InUse)
+		}
+		profile = append(profile, sample)
+	}
+	return profile, nil
+}
+
+// WriteStandDemandCSV writes a stand demand profile as CSV with a header row and one row per
+// sample: the RFC 3339 time, the gates open, the gates in use to one decimal place and their
+// utilization to three.
+func WriteStandDemandCSV(w io.Writer, profile []GateSample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "gates_open", "gates_in_use", "utilization"}); err != nil {
+		return err
+	}
+	for _, s := range profile {
+		record := []string{
+			s.Time.Format(time.RFC3339),
+			strconv.Itoa(s.Open),
+			strconv.FormatFloat(s.InUse, 'f', 1, 64),
+			strconv.FormatFloat(s.Utilization(), 'f', 3, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,120 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestResult_StandDemand(t *testing.T) {
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: time.Minute}},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		gates           int
+		wantInUse       float64
+		wantUtilization float64
+	}{
+		// The runway's 60 movements per hour put 30 aircraft on gates for an hour each
+		{"runway limited", 100, 30, 0.3},
+		// 20 gates held for an hour sustain only 40 movements per hour, so every stand is in use
+		{"stand limited", 20, 20, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).
+				AddGateCapacityPolicy(GateCapacityConstraint{TotalGates: tt.gates, AverageTurnaroundTime: time.Hour})
+			if err != nil {
+				t.Fatalf("AddGateCapacityPolicy failed: %v", err)
+			}
+			result, err := sim.RunResult(context.Background())
+			if err != nil {
+				t.Fatalf("RunResult failed: %v", err)
+			}
+
+			profile, err := result.StandDemand(DefaultStandDemandInterval)
+			if err != nil {
+				t.Fatalf("StandDemand failed: %v", err)
+			}
+			if len(profile) != 96 {
+				t.Fatalf("Expected 96 quarter hours, got %d", len(profile))
+			}
+			for i, s := range profile {
+				if !s.Time.Equal(start.Add(time.Duration(i) * 15 * time.Minute)) {
+					t.Fatalf("Sample %d: expected time %v, got %v", i, start.Add(time.Duration(i)*15*time.Minute), s.Time)
+				}
+				if s.Open != tt.gates || math.Abs(s.InUse-tt.wantInUse) > 1e-6 || math.Abs(s.Utilization()-tt.wantUtilization) > 1e-6 {
+					t.Fatalf("At %v: expected %.1f of %d gates in use, got %+v", s.Time, tt.wantInUse, tt.gates, s)
+				}
+			}
+		})
+	}
+}
+
+func TestResult_StandDemandInterpolation(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	result := &Result{
+		StartTime: start,
+		EndTime:   start.Add(3 * time.Hour),
+		Windows: []WindowResult{
+			// Gates not yet filled
+			{Start: start, End: start.Add(time.Hour)},
+			{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), GateSamples: []GateSample{
+				{Time: start.Add(time.Hour), Open: 40, InUse: 20},
+				{Time: start.Add(2 * time.Hour), Open: 40, InUse: 40},
+			}},
+			// A closure takes 10 gates out of service, emptying as their aircraft depart
+			{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), GateSamples: []GateSample{
+				{Time: start.Add(2 * time.Hour), Open: 30, InUse: 40},
+				{Time: start.Add(150 * time.Minute), Open: 30, InUse: 30},
+				{Time: start.Add(3 * time.Hour), Open: 30, InUse: 30},
+			}},
+		},
+	}
+
+	profile, err := result.StandDemand(30 * time.Minute)
+	if err != nil {
+		t.Fatalf("StandDemand failed: %v", err)
+	}
+	want := []GateSample{
+		{Time: start.Add(time.Hour), Open: 40, InUse: 20},
+		{Time: start.Add(90 * time.Minute), Open: 40, InUse: 30},
+		{Time: start.Add(2 * time.Hour), Open: 30, InUse: 40},
+		{Time: start.Add(150 * time.Minute), Open: 30, InUse: 30},
+	}
+	if len(profile) != len(want) {
+		t.Fatalf("Expected %d samples, got %+v", len(want), profile)
+	}
+	for i := range want {
+		if !profile[i].Time.Equal(want[i].Time) || profile[i].Open != want[i].Open || math.Abs(profile[i].InUse-want[i].InUse) > 1e-9 {
+			t.Errorf("Sample %d: expected %+v, got %+v", i, want[i], profile[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteStandDemandCSV(&buf, profile); err != nil {
+		t.Fatalf("WriteStandDemandCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || lines[0] != "time,gates_open,gates_in_use,utilization" || lines[3] != "2024-01-01T02:00:00Z,30,40.0,1.333" {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+
+	if _, err := result.StandDemand(0); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a zero interval, got %v", err)
+	}
+	if profile, err := (&Result{StartTime: start, EndTime: start.Add(time.Hour), Windows: []WindowResult{{Start: start, End: start.Add(time.Hour)}}}).StandDemand(time.Hour); err != nil || profile != nil {
+		t.Errorf("Expected no profile without gate samples, got %v, %v", profile, err)
+	}
+}
@@ -0,0 +1,242 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// MixedFlowLabel labels a configuration whose runways operate in opposing directions.
+const MixedFlowLabel = "Mixed flow"
+
+// compassPoints names the eight flow directions, starting at north and moving clockwise.
+var compassPoints = []string{"North", "Northeast", "East", "Southeast", "South", "Southwest", "West", "Northwest"}
+
+// ConfigurationUsage is the share of a run spent in one runway configuration or traffic flow,
+// and the movements handled while it was active.
+type ConfigurationUsage struct {
+	Label           string        // Configuration label (see ConfigurationLabel) or flow (see ConfigurationFlow)
+	Duration        time.Duration // Time the configuration was active
+	TimePercent     float64       // Duration as a percentage of the simulation period
+	Movements       float64       // Movements handled while the configuration was active
+	MovementPercent float64       // Movements as a percentage of all movements (0 when there are none)
+}
+
+// String returns the label and its share of time (e.g., "West flow 62.0%").
+func (u ConfigurationUsage) String() string {
+	return fmt.Sprintf("%s %.1f%%", u.Label, u.TimePercent)
+}
+
+// FormatUsage joins usage entries into a single line, as airports report configuration
+// usage (e.g., "West flow 62.0%, East flow 30.0%, Closed 8.0%").
+func FormatUsage(usage []ConfigurationUsage) string {
+	parts := make([]string, len(usage))
+	for i, u := range usage {
+		parts[i] = u.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatMovementShares joins usage entries into a single line of their shares of movements
+// (e.g., "09L 54.2%, 09R 45.8%").
+func FormatMovementShares(usage []ConfigurationUsage) string {
+	parts := make([]string, len(usage))
+	for i, u := range usage {
+		parts[i] = fmt.Sprintf("%s %.1f%%", u.Label, u.MovementPercent)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ConfigurationFlow returns the traffic flow of a runway configuration: the compass direction
+// aircraft operate towards, averaged over the active runway ends (e.g., "West flow" for 27L and
+// 27R). Configurations whose runway ends point in opposing directions are MixedFlowLabel, and
+// an empty configuration is ClosedConfigurationLabel.
+func ConfigurationFlow(config map[string]*event.ActiveRunwayInfo) string {
+	if len(config) == 0 {
+		return ClosedConfigurationLabel
+	}
+
+	var east, north float64
+	for _, info := range config {
+		radians := info.End().TrueBearing * math.Pi / 180
+		east += math.Sin(radians)
+		north += math.Cos(radians)
+	}
+
+	// Opposing runway ends cancel out
+	if math.Hypot(east, north) < 0.5*float64(len(config)) {
+		return MixedFlowLabel
+	}
+
+	bearing := math.Mod(math.Atan2(east, north)*180/math.Pi+360, 360)
+	return compassPoints[int(math.Round(bearing/45))%len(compassPoints)] + " flow"
+}
+
+// ConfigurationUsage reports the percentage of time and the movements attributed to each
+// runway configuration, most used first.
+func (r *Result) ConfigurationUsage() []ConfigurationUsage {
+	return r.usageBy(func(w WindowResult) string { return w.Configuration })
+}
+
+// FlowUsage reports the percentage of time and the movements attributed to each traffic flow
+// (see ConfigurationFlow), most used first.
+func (r *Result) FlowUsage() []ConfigurationUsage {
+	return r.usageBy(func(w WindowResult) string { return w.Flow })
+}
+
+// usageBy aggregates window durations and capacities by the given key.
+func (r *Result) usageBy(key func(WindowResult) string) []ConfigurationUsage {
+	byLabel := make(map[string]*ConfigurationUsage)
+	totalMovements := 0.0
+	for _, w := range r.Windows {
+		label := key(w)
+		usage, ok := byLabel[label]
+		if !ok {
+			usage = &ConfigurationUsage{Label: label}
+			byLabel[label] = usage
+		}
+		usage.Duration += w.Duration()
+		usage.Movements += float64(w.Capacity)
+		totalMovements += float64(w.Capacity)
+	}
+
+	period := r.EndTime.Sub(r.StartTime)
+	usages := make([]ConfigurationUsage, 0, len(byLabel))
+	for _, usage := range byLabel {
+		if period > 0 {
+			usage.TimePercent = float64(usage.Duration) / float64(period) * 100
+		}
+		if totalMovements > 0 {
+			usage.MovementPercent = usage.Movements / totalMovements * 100
+		}
+		usages = append(usages, *usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Duration != usages[j].Duration {
+			return usages[i].Duration > usages[j].Duration
+		}
+		return usages[i].Label < usages[j].Label
+	})
+	return usages
+}
+
+// RunwayUsage reports, for each runway that was ever active, the percentage of time it was in
+// the active configuration and the movements attributed to it, most movements first. Each
+// window's movements are attributed to its runways in proportion to their capacity, so the
+// shares show how the traffic is spread across the runways.
+func (r *Result) RunwayUsage() []ConfigurationUsage {
+	return r.runwayUsageBy(windowRunwayMovements, func(w WindowResult, i int) []runwayShare {
+		return []runwayShare{{label: w.ActiveRunways[i], share: 1}}
+	})
+}
+
+// RunwayEndUsage reports the same as RunwayUsage for each runway end that was ever in use,
+// so the two directions of a runway (e.g., 09L and 27R) are reported separately.
+func (r *Result) RunwayEndUsage() []ConfigurationUsage {
+	return r.runwayUsageBy(windowRunwayMovements, func(w WindowResult, i int) []runwayShare {
+		return []runwayShare{{label: windowRunwayEnd(w, i), share: 1}}
+	})
+}
+
+// RunwayEndOperationUsage reports the same as RunwayEndUsage for the arrivals and departures on
+// each runway end (e.g., "27R departures"), as noise-sharing reports break them down. Mixed
+// mode runways are assumed to split their movements evenly between arrivals and departures.
+func (r *Result) RunwayEndOperationUsage() []ConfigurationUsage {
+	return r.runwayUsageBy(windowRunwayMovements, func(w WindowResult, i int) []runwayShare {
+		end := windowRunwayEnd(w, i)
+		operation := event.Mixed
+		if i < len(w.RunwayOperations) {
+			operation = w.RunwayOperations[i]
+		}
+		switch operation {
+		case event.LandingOnly:
+			return []runwayShare{{label: end + " arrivals", share: 1}}
+		case event.TakeoffOnly:
+			return []runwayShare{{label: end + " departures", share: 1}}
+		default:
+			return []runwayShare{{label: end + " arrivals", share: 0.5}, {label: end + " departures", share: 0.5}}
+		}
+	})
+}
+
+// RunwayGAUsage reports, for each runway that ever had movements reserved for general aviation,
+// the percentage of time movements were reserved on it and the GA movements reserved, most
+// movements first. Movement percentages are shares of all GA movements.
+func (r *Result) RunwayGAUsage() []ConfigurationUsage {
+	movements := func(w WindowResult) []float32 { return w.RunwayGAMovements }
+	return r.runwayUsageBy(movements, func(w WindowResult, i int) []runwayShare {
+		if i >= len(w.RunwayGAMovements) || w.RunwayGAMovements[i] == 0 {
+			return nil
+		}
+		return []runwayShare{{label: w.ActiveRunways[i], share: 1}}
+	})
+}
+
+// windowRunwayMovements returns the movements attributed to each active runway of a window.
+func windowRunwayMovements(w WindowResult) []float32 {
+	return w.RunwayMovements
+}
+
+// runwayShare is the share of an active runway's movements attributed to a usage label.
+type runwayShare struct {
+	label string
+	share float64
+}
+
+// windowRunwayEnd returns the runway end in use by the i-th active runway of a window, or the
+// runway's designation for windows recorded without runway ends.
+func windowRunwayEnd(w WindowResult, i int) string {
+	if i < len(w.RunwayEnds) && w.RunwayEnds[i] != "" {
+		return w.RunwayEnds[i]
+	}
+	return w.ActiveRunways[i]
+}
+
+// runwayUsageBy aggregates window durations and the movements of each active runway (as
+// returned by movements) by the labels shares returns for it, most movements first.
+func (r *Result) runwayUsageBy(movements func(w WindowResult) []float32, shares func(w WindowResult, i int) []runwayShare) []ConfigurationUsage {
+	byLabel := make(map[string]*ConfigurationUsage)
+	totalMovements := 0.0
+	for _, w := range r.Windows {
+		runwayMovements := movements(w)
+		for i := range w.ActiveRunways {
+			for _, s := range shares(w, i) {
+				usage, ok := byLabel[s.label]
+				if !ok {
+					usage = &ConfigurationUsage{Label: s.label}
+					byLabel[s.label] = usage
+				}
+				usage.Duration += w.Duration()
+				if i < len(runwayMovements) {
+					usage.Movements += float64(runwayMovements[i]) * s.share
+					totalMovements += float64(runwayMovements[i]) * s.share
+				}
+			}
+		}
+	}
+
+	period := r.EndTime.Sub(r.StartTime)
+	usages := make([]ConfigurationUsage, 0, len(byLabel))
+	for _, usage := range byLabel {
+		if period > 0 {
+			usage.TimePercent = float64(usage.Duration) / float64(period) * 100
+		}
+		if totalMovements > 0 {
+			usage.MovementPercent = usage.Movements / totalMovements * 100
+		}
+		usages = append(usages, *usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Movements != usages[j].Movements {
+			return usages[i].Movements > usages[j].Movements
+		}
+		return usages[i].Label < usages[j].Label
+	})
+	return usages
+}
@@ -0,0 +1,161 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+func TestConfigurationFlow(t *testing.T) {
+	info := func(id string, bearing float64, direction event.Direction) *event.ActiveRunwayInfo {
+		return &event.ActiveRunwayInfo{RunwayDesignation: id, Direction: direction, Runway: airport.Runway{RunwayDesignation: id, TrueBearing: bearing}}
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]*event.ActiveRunwayInfo
+		expected string
+	}{
+		{"closed", nil, ClosedConfigurationLabel},
+		{"parallel westerly", map[string]*event.ActiveRunwayInfo{"09L": info("09L", 90, event.Reverse), "09R": info("09R", 90, event.Reverse)}, "West flow"},
+		{"single easterly", map[string]*event.ActiveRunwayInfo{"09L": info("09L", 92, event.Forward)}, "East flow"},
+		{"crossing runways", map[string]*event.ActiveRunwayInfo{"09": info("09", 90, event.Forward), "18": info("18", 180, event.Forward)}, "Southeast flow"},
+		{"opposing ends", map[string]*event.ActiveRunwayInfo{"09L": info("09L", 90, event.Forward), "09R": info("09R", 90, event.Reverse)}, MixedFlowLabel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConfigurationFlow(tt.config); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResult_ConfigurationUsage(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, TailwindLimitKnots: 5, MinimumSeparation: 60 * time.Second},
+		},
+	}
+	world := NewWorld(ap, start, start.Add(10*time.Hour))
+
+	// East flow for 4 hours, west flow for 5 hours, closed for 1 hour
+	world.ScheduleEvent(event.NewWindChangeEvent(15, 270, start.Add(4*time.Hour)))
+	world.ScheduleEvent(event.NewCurfewStartEvent(start.Add(9 * time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	expected := []ConfigurationUsage{
+		{Label: "West flow", Duration: 5 * time.Hour, TimePercent: 50, Movements: 300, MovementPercent: 300.0 / 540 * 100},
+		{Label: "East flow", Duration: 4 * time.Hour, TimePercent: 40, Movements: 240, MovementPercent: 240.0 / 540 * 100},
+		{Label: ClosedConfigurationLabel, Duration: time.Hour, TimePercent: 10},
+	}
+	flows := result.FlowUsage()
+	if len(flows) != len(expected) {
+		t.Fatalf("Expected %d flows, got %+v", len(expected), flows)
+	}
+	for i, want := range expected {
+		got := flows[i]
+		if got.Label != want.Label || got.Duration != want.Duration || math.Abs(got.TimePercent-want.TimePercent) > 1e-9 ||
+			math.Abs(got.Movements-want.Movements) > 1e-3 || math.Abs(got.MovementPercent-want.MovementPercent) > 1e-3 {
+			t.Errorf("Flow %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if got := FormatUsage(flows); got != "West flow 50.0%, East flow 40.0%, Closed 10.0%" {
+		t.Errorf("Unexpected formatted usage %q", got)
+	}
+	if configs := result.ConfigurationUsage(); len(configs) != 3 || configs[0].Label != "27" {
+		t.Errorf("Expected runway 27 to be the most used configuration, got %+v", configs)
+	}
+}
+
+func TestResult_RunwayUsage(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09L", TrueBearing: 90, MinimumSeparation: 60 * time.Second},
+			{RunwayDesignation: "09R", TrueBearing: 90, MinimumSeparation: 120 * time.Second},
+		},
+	}
+	world := NewWorld(ap, start, start.Add(3*time.Hour))
+
+	// Both runways for an hour, 09R alone for an hour, then both at half efficiency
+	world.ScheduleEvent(event.NewRunwayMaintenanceStartEvent("09L", start.Add(time.Hour)))
+	world.ScheduleEvent(event.NewRunwayMaintenanceEndEvent("09L", start.Add(2*time.Hour)))
+	world.ScheduleEvent(event.NewRotationChangeEvent(0.5, start.Add(2*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	expected := []ConfigurationUsage{
+		{Label: "09L", Duration: 2 * time.Hour, TimePercent: 200.0 / 3, Movements: 90, MovementPercent: 90.0 / 165 * 100},
+		{Label: "09R", Duration: 3 * time.Hour, TimePercent: 100, Movements: 75, MovementPercent: 75.0 / 165 * 100},
+	}
+	runways := result.RunwayUsage()
+	if len(runways) != len(expected) {
+		t.Fatalf("Expected %d runways, got %+v", len(expected), runways)
+	}
+	for i, want := range expected {
+		got := runways[i]
+		if got.Label != want.Label || got.Duration != want.Duration || math.Abs(got.TimePercent-want.TimePercent) > 1e-9 ||
+			math.Abs(got.Movements-want.Movements) > 1e-3 || math.Abs(got.MovementPercent-want.MovementPercent) > 1e-3 {
+			t.Errorf("Runway %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if got := FormatMovementShares(runways); got != "09L 54.5%, 09R 45.5%" {
+		t.Errorf("Unexpected formatted shares %q", got)
+	}
+	if movements := world.RunwayMovements("09R"); math.Abs(movements-75) > 1e-3 {
+		t.Errorf("Expected 75 movements attributed to 09R in the world, got %.1f", movements)
+	}
+}
+
+func TestResult_RunwayEndUsage(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, TailwindLimitKnots: 5, MinimumSeparation: 60 * time.Second},
+		},
+	}
+	world := NewWorld(ap, start, start.Add(10*time.Hour))
+
+	// Runway 09 for 4 hours, then 27 for 6 hours
+	world.ScheduleEvent(event.NewWindChangeEvent(15, 270, start.Add(4*time.Hour)))
+
+	result, err := NewEngine(testEngineLogger()).CalculateResult(context.Background(), world)
+	if err != nil {
+		t.Fatalf("CalculateResult failed: %v", err)
+	}
+
+	if got := FormatMovementShares(result.RunwayEndUsage()); got != "27 60.0%, 09 40.0%" {
+		t.Errorf("Unexpected runway end shares %q", got)
+	}
+	if got := FormatMovementShares(result.RunwayUsage()); got != "09 100.0%" {
+		t.Errorf("Expected both ends to count towards runway 09, got %q", got)
+	}
+
+	// The mixed mode runway splits its movements evenly between arrivals and departures
+	usage := result.RunwayEndOperationUsage()
+	if got := FormatMovementShares(usage); got != "27 arrivals 30.0%, 27 departures 30.0%, 09 arrivals 20.0%, 09 departures 20.0%" {
+		t.Errorf("Unexpected runway end operation shares %q", got)
+	}
+	if usage[1].Movements != 180 || usage[1].Duration != 6*time.Hour {
+		t.Errorf("Expected 180 departures off 27 over 6 hours, got %+v", usage[1])
+	}
+}
@@ -0,0 +1,90 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// DefaultTrendWindows are the rolling window lengths, in days, used by Result.CapacityTrends
+// when none are given: a week and a month.
+var DefaultTrendWindows = []int{7, 30}
+
+// CapacityTrend is the rolling average of daily capacity over trailing windows of one length.
+type CapacityTrend struct {
+	WindowDays int             // Days in each window
+	Averages   []DailyCapacity // Average movements per day over the window ending on each day, from the first day with a full window
+}
+
+// CapacityTrends are the daily capacity of a run with its rolling averages.
+type CapacityTrends struct {
+	Daily  []DailyCapacity // Capacity of each day (see Result.DailyCapacity)
+	Trends []CapacityTrend // One per window length, in the order the lengths were given
+}
+
+// CapacityTrends returns the daily capacity of the run with its rolling average over trailing
+// windows of each length in days (default: DefaultTrendWindows), so that seasonal effects such
+// as a maintenance campaign or a windy month show up as trends rather than day-to-day noise.
+// The average on a day covers the window ending on that day, so each trend starts once its
+// first window is full. Partial days at the ends of the run count as they are.
+// Returns an error if a window length is not positive or is longer than the run in days.
+func (r *Result) CapacityTrends(windowDays ...int) (*CapacityTrends, error) {
+	if len(windowDays) == 0 {
+		windowDays = DefaultTrendWindows
+	}
+	daily := r.DailyCapacity()
+	for _, days := range windowDays {
+		if days <= 0 {
+			return nil, simerrors.Invalidf("trend window must be positive, got %d days", days)
+		}
+		if days > len(daily) {
+			return nil, simerrors.Invalidf("trend window of %d days is longer than the %d days simulated", days, len(daily))
+		}
+	}
+
+	trends := &CapacityTrends{Daily: daily}
+	for _, days := range windowDays {
+		trend := CapacityTrend{WindowDays: days, Averages: make([]DailyCapacity, 0, len(daily)-days+1)}
+		for end := days; end <= len(daily); end++ {
+			var sum float64
+			for _, day := range daily[end-days : end] {
+				sum += float64(day.Capacity)
+			}
+			trend.Averages = append(trend.Averages, DailyCapacity{Date: daily[end-1].Date, Capacity: float32(sum / float64(days))})
+		}
+		trends.Trends = append(trends.Trends, trend)
+	}
+	return trends, nil
+}
+
+// WriteCapacityTrendsCSV writes capacity trends as CSV with one row per day: its date, its
+// capacity and a column per trend (e.g. rolling_7d) holding the rolling average, empty until
+// the trend's first window is full.
+func WriteCapacityTrendsCSV(w io.Writer, trends *CapacityTrends) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date", "capacity"}
+	for _, trend := range trends.Trends {
+		header = append(header, fmt.Sprintf("rolling_%dd", trend.WindowDays))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for i, day := range trends.Daily {
+		record := []string{day.Date.Format("2006-01-02"), strconv.FormatFloat(float64(day.Capacity), 'f', 1, 32)}
+		for _, trend := range trends.Trends {
+			average := ""
+			if j := i - (trend.WindowDays - 1); j >= 0 {
+				average = strconv.FormatFloat(float64(trend.Averages[j].Capacity), 'f', 1, 32)
+			}
+			record = append(record, average)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
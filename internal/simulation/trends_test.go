@@ -0,0 +1,65 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestResult_CapacityTrends(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Ten days of 100 movements, the fourth and fifth halved by maintenance
+	result := &Result{StartTime: start, EndTime: start.AddDate(0, 0, 10)}
+	for day := range 10 {
+		capacity := float32(100)
+		if day == 3 || day == 4 {
+			capacity = 50
+		}
+		result.Windows = append(result.Windows, WindowResult{Start: start.AddDate(0, 0, day), End: start.AddDate(0, 0, day+1), Capacity: capacity})
+	}
+
+	trends, err := result.CapacityTrends(3, 10)
+	if err != nil {
+		t.Fatalf("CapacityTrends failed: %v", err)
+	}
+	if len(trends.Daily) != 10 || len(trends.Trends) != 2 {
+		t.Fatalf("Expected 10 days and 2 trends, got %+v", trends)
+	}
+
+	// The 3-day average dips while the maintenance is in its window
+	want := []float32{100, 250.0 / 3, 200.0 / 3, 200.0 / 3, 250.0 / 3, 100, 100, 100}
+	short := trends.Trends[0]
+	if short.WindowDays != 3 || len(short.Averages) != len(want) {
+		t.Fatalf("Expected %d 3-day averages, got %+v", len(want), short)
+	}
+	for i, average := range short.Averages {
+		if !average.Date.Equal(start.AddDate(0, 0, i+2)) || average.Capacity != want[i] {
+			t.Errorf("Average %d: expected %.1f ending %v, got %+v", i, want[i], start.AddDate(0, 0, i+2), average)
+		}
+	}
+	if whole := trends.Trends[1].Averages; len(whole) != 1 || whole[0].Capacity != 90 {
+		t.Errorf("Expected one 10-day average of 90, got %+v", whole)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCapacityTrendsCSV(&buf, trends); err != nil {
+		t.Fatalf("WriteCapacityTrendsCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 11 || lines[0] != "date,capacity,rolling_3d,rolling_10d" || lines[1] != "2024-01-01,100.0,," ||
+		lines[3] != "2024-01-03,100.0,100.0," || lines[10] != "2024-01-10,100.0,100.0,90.0" {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+
+	// The default windows need a month of results
+	if _, err := result.CapacityTrends(); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a 30-day window over 10 days, got %v", err)
+	}
+	if _, err := result.CapacityTrends(0); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a zero window, got %v", err)
+	}
+}
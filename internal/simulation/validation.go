@@ -0,0 +1,228 @@
+package simulation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// ValidationError reports every problem found while validating a simulation.
+type ValidationError struct {
+	Problems []error // Individual problems in the order they were found
+}
+
+// Error returns a multi-line report listing every problem.
+func (e *ValidationError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "simulation configuration has %d problem(s):", len(e.Problems))
+	for _, problem := range e.Problems {
+		builder.WriteString("\n  - ")
+		builder.WriteString(problem.Error())
+	}
+	return builder.String()
+}
+
+// Unwrap returns the individual problems so errors.Is and errors.As can inspect them.
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// Validate checks the simulation period (end after start), the airport configuration (runway
+// bearings and separations, unique designations, compatibility graph consistency), the runway
+// manager options (preferred runways that exist), and every policy that implements
+// policy.ValidatingPolicy (e.g., maintenance referencing real runways, curfews within the
+// simulation period). Policies that would overwrite each other's world state, such as two
+// wind sources, are reported as a *simerrors.PolicyConflictError.
+// Pre-simulation plugins are applied first. Disabled policies are not validated, but
+// disabling a name no policy has is reported.
+// Returns a *ValidationError listing all problems found, or nil if there are none.
+func (s *Simulation) Validate() error {
+	problems := s.unknownDisabledPolicies()
+	s = s.enabledPolicies()
+
+	ap, pluginProblems := s.validatePlugins()
+	problems = append(problems, pluginProblems...)
+	problems = appendProblems(problems, ap.Validate())
+	problems = appendProblems(problems, s.runwayOptions.Validate(ap.Runways))
+
+	startTime, endTime := s.period()
+	if !endTime.After(startTime) {
+		problems = append(problems, simerrors.Invalidf("simulation period end %v must be after its start %v", endTime, startTime))
+	}
+	world := &validationWorld{airport: ap, startTime: startTime, endTime: endTime}
+	for _, p := range s.policies {
+		if vp, ok := p.(policy.ValidatingPolicy); ok {
+			problems = appendProblems(problems, vp.Validate(world))
+		}
+	}
+	problems = append(problems, policyConflicts(s.policies)...)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+// unknownDisabledPolicies returns an error for each name passed to DisablePolicy that no
+// policy of the simulation has, in sorted order.
+func (s *Simulation) unknownDisabledPolicies() []error {
+	names := make(map[string]bool, len(s.policies))
+	for _, p := range s.policies {
+		names[p.Name()] = true
+	}
+
+	var problems []error
+	for _, name := range slices.Sorted(maps.Keys(s.disabledPolicies)) {
+		if !names[name] {
+			problems = append(problems, simerrors.Invalidf("disabled policy %q is not in the simulation", name))
+		}
+	}
+	return problems
+}
+
+// appendProblems appends err to problems, flattening errors that wrap several problems.
+func appendProblems(problems []error, err error) []error {
+	if err == nil {
+		return problems
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, inner := range joined.Unwrap() {
+			problems = appendProblems(problems, inner)
+		}
+		return problems
+	}
+	return append(problems, err)
+}
+
+// policyConflicts reports every exclusive policy group (see exclusivePolicyGroup) that
+// holds more than one policy.
+func policyConflicts(policies []Policy) []error {
+	var groups []string
+	members := make(map[string][]string)
+	for _, p := range policies {
+		group := exclusivePolicyGroup(p)
+		if group == "" {
+			continue
+		}
+		if _, seen := members[group]; !seen {
+			groups = append(groups, group)
+		}
+		members[group] = append(members[group], p.Name())
+	}
+
+	var conflicts []error
+	for _, group := range groups {
+		if len(members[group]) > 1 {
+			conflicts = append(conflicts, &simerrors.PolicyConflictError{
+				Policies: members[group],
+				Reason:   fmt.Sprintf("only one %s policy may be applied", group),
+			})
+		}
+	}
+	return conflicts
+}
+
+// exclusivePolicyGroup returns the group of policies p belongs to, of which a simulation
+// may hold at most one because each replaces the world state set by the others.
+// Returns "" for policies that can be combined freely.
+func exclusivePolicyGroup(p Policy) string {
+	switch p.(type) {
+	case *policy.WindPolicy, *policy.ScheduledWindPolicy, *policy.WindRosePolicy:
+		return "wind"
+	case *policy.TemperaturePolicy:
+		return "temperature"
+	case *policy.RunwaySurfaceConditionPolicy:
+		return "surface condition"
+	case *policy.RunwayRotationPolicy:
+		return "runway rotation"
+	case *policy.GateCapacityPolicy:
+		return "gate capacity"
+	case *policy.DeicingPolicy:
+		return "de-icing"
+	case *policy.TaxiTimePolicy:
+		return "taxi time"
+	case *policy.TailwindPerformancePolicy:
+		return "tailwind performance"
+	case *policy.DirectionChangeoverPolicy:
+		return "direction changeover"
+	case *policy.GustFactorPolicy:
+		return "gust factor"
+	case *policy.NoiseQuotaPolicy:
+		return "noise quota"
+	case *policy.DeclaredCapacityPolicy:
+		return "declared capacity"
+	case *policy.NightQuotaPolicy:
+		return "night quota"
+	default:
+		return ""
+	}
+}
+
+// simulationPeriod returns the period a simulation run covers unless WithPeriod is used.
+func simulationPeriod() (time.Time, time.Time) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return startTime, startTime.AddDate(1, 0, 0) // One year simulation
+}
+
+// period returns the period the simulation covers: the one set with WithPeriod, or the
+// default simulation period.
+func (s *Simulation) period() (time.Time, time.Time) {
+	if s.startTime.IsZero() && s.endTime.IsZero() {
+		return simulationPeriod()
+	}
+	return s.startTime, s.endTime
+}
+
+// validationWorld is a read-only policy.EventWorld used to validate policies without
+// building a full simulation world. Scheduled events are discarded.
+type validationWorld struct {
+	airport   airport.Airport
+	startTime time.Time
+	endTime   time.Time
+}
+
+func (w *validationWorld) ScheduleEvent(event.Event) {}
+
+func (w *validationWorld) GetEventQueue() *event.EventQueue {
+	return event.NewEventQueue()
+}
+
+func (w *validationWorld) GetStartTime() time.Time {
+	return w.startTime
+}
+
+func (w *validationWorld) GetEndTime() time.Time {
+	return w.endTime
+}
+
+func (w *validationWorld) GetFleetMix() *airport.FleetMix {
+	return w.airport.FleetMix
+}
+
+func (w *validationWorld) GetGateCount() int {
+	return w.airport.Gates
+}
+
+func (w *validationWorld) GetLocation() *time.Location {
+	loc, _ := w.airport.TimeZone()
+	return loc
+}
+
+func (w *validationWorld) GetRunwayIDs() []string {
+	ids := make([]string, 0, len(w.airport.Runways))
+	for _, runway := range w.airport.Runways {
+		ids = append(ids, runway.RunwayDesignation)
+	}
+	return ids
+}
+
+func (w *validationWorld) GetRunwayEnds() []string {
+	return w.airport.RunwayEndDesignations()
+}
@@ -0,0 +1,135 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+)
+
+// AttributionMode selects how a capacity waterfall attributes capacity loss to policies.
+type AttributionMode int
+
+const (
+	// CumulativeAttribution adds policies one at a time in configuration order, attributing to
+	// each the capacity lost when it is added. The losses sum to the total loss, but a policy's
+	// share depends on the policies added before it.
+	CumulativeAttribution AttributionMode = iota
+	// LeaveOneOutAttribution removes each policy from the full configuration in turn,
+	// attributing to it the capacity regained. Shares do not depend on order; loss that only
+	// arises from policies acting together is reported as CapacityWaterfall.Interaction.
+	LeaveOneOutAttribution
+)
+
+// String returns the mode name.
+func (m AttributionMode) String() string {
+	switch m {
+	case CumulativeAttribution:
+		return "Cumulative"
+	case LeaveOneOutAttribution:
+		return "LeaveOneOut"
+	default:
+		return "Unknown"
+	}
+}
+
+// WaterfallStep is the capacity loss attributed to one policy.
+type WaterfallStep struct {
+	Policy      string  // Policy name
+	Capacity    float32 // Capacity after adding the policy (cumulative) or without it (leave-one-out)
+	Loss        float32 // Movements attributed to the policy
+	LossPercent float64 // Loss as a percentage of the baseline (0 when the baseline is zero)
+}
+
+// String formats the step for display, e.g. "CurfewPolicy: -150000 movements (29.2%)".
+func (s WaterfallStep) String() string {
+	return fmt.Sprintf("%s: -%.0f movements (%.1f%%)", s.Policy, s.Loss, s.LossPercent)
+}
+
+// CapacityWaterfall attributes the gap between the theoretical maximum and the constrained
+// capacity to the simulation's policies.
+type CapacityWaterfall struct {
+	Mode        AttributionMode
+	Baseline    float32         // Capacity with no policies (theoretical maximum)
+	Constrained float32         // Capacity with every policy
+	Steps       []WaterfallStep // One step per policy, in configuration order
+	Interaction float32         // Loss not attributed to any single policy (always 0 when cumulative)
+}
+
+// TotalLoss returns the movements lost to all policies together.
+func (w *CapacityWaterfall) TotalLoss() float32 {
+	return w.Baseline - w.Constrained
+}
+
+// CapacityWaterfall runs the simulation repeatedly with subsets of its policies and attributes
+// the capacity lost against the theoretical maximum to each policy (see AttributionMode).
+// Every run uses the same seed, and stochastic policies keep their random streams whatever
+// subset they run in, so differences between runs come only from the policies present.
+// Cumulative attribution needs one run per policy plus the baseline; leave-one-out needs one
+// more.
+func (s *Simulation) CapacityWaterfall(ctx context.Context, mode AttributionMode) (*CapacityWaterfall, error) {
+	s = s.enabledPolicies()
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	run := func(indices []int) (float32, error) {
+		return s.withPolicies(indices).WithSeed(seed).Run(ctx)
+	}
+
+	all := make([]int, len(s.policies))
+	for i := range all {
+		all[i] = i
+	}
+
+	s.log(ctx).InfoContext(ctx, "Running capacity waterfall", "mode", mode, "policies", len(s.policies))
+	baseline, err := run(nil)
+	if err != nil {
+		return nil, err
+	}
+	constrained, err := run(all)
+	if err != nil {
+		return nil, err
+	}
+
+	waterfall := &CapacityWaterfall{
+		Mode:        mode,
+		Baseline:    baseline,
+		Constrained: constrained,
+		Steps:       make([]WaterfallStep, 0, len(s.policies)),
+	}
+
+	previous := baseline
+	attributed := float32(0)
+	for i, p := range s.policies {
+		step := WaterfallStep{Policy: p.Name()}
+		switch mode {
+		case CumulativeAttribution:
+			if i == len(s.policies)-1 {
+				step.Capacity = constrained
+			} else if step.Capacity, err = run(all[:i+1]); err != nil {
+				return nil, err
+			}
+			step.Loss = previous - step.Capacity
+			previous = step.Capacity
+		case LeaveOneOutAttribution:
+			without := append(append([]int{}, all[:i]...), all[i+1:]...)
+			if step.Capacity, err = run(without); err != nil {
+				return nil, err
+			}
+			step.Loss = step.Capacity - constrained
+		default:
+			return nil, fmt.Errorf("unknown attribution mode %d", mode)
+		}
+
+		if baseline > 0 {
+			step.LossPercent = float64(step.Loss) / float64(baseline) * 100
+		}
+		attributed += step.Loss
+		waterfall.Steps = append(waterfall.Steps, step)
+	}
+
+	if mode == LeaveOneOutAttribution {
+		waterfall.Interaction = waterfall.TotalLoss() - attributed
+	}
+	return waterfall, nil
+}
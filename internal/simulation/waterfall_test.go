@@ -0,0 +1,110 @@
+package simulation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+)
+
+// newWaterfallSimulation builds a single-runway simulation with a nightly curfew and weekly
+// maintenance that starts inside the curfew, so the two policies overlap.
+func newWaterfallSimulation(t *testing.T) *Simulation {
+	t.Helper()
+	ap := airport.Airport{
+		Name:    "Test",
+		Runways: []airport.Runway{{RunwayDesignation: "09", TrueBearing: 90, LengthMeters: 3000, MinimumSeparation: 60 * time.Second}},
+	}
+
+	sim, err := NewSimulation(ap, testEngineLogger()).AddCurfewPolicy(
+		time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+	)
+	if err != nil {
+		t.Fatalf("AddCurfewPolicy failed: %v", err)
+	}
+	return sim.AddMaintenancePolicy(MaintenanceSchedule{
+		RunwayDesignations: []string{"09"},
+		Duration:           8 * time.Hour,
+		Frequency:          7 * 24 * time.Hour,
+	}).WithSeed(1)
+}
+
+func TestSimulation_CapacityWaterfall_Cumulative(t *testing.T) {
+	sim := newWaterfallSimulation(t)
+
+	waterfall, err := sim.CapacityWaterfall(context.Background(), CumulativeAttribution)
+	if err != nil {
+		t.Fatalf("CapacityWaterfall failed: %v", err)
+	}
+
+	expectedBaseline := float32(366 * 24 * 60)
+	if waterfall.Baseline != expectedBaseline {
+		t.Errorf("Expected baseline %.0f, got %.0f", expectedBaseline, waterfall.Baseline)
+	}
+	constrained, err := sim.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if waterfall.Constrained != constrained {
+		t.Errorf("Expected constrained %.0f, got %.0f", constrained, waterfall.Constrained)
+	}
+
+	if len(waterfall.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(waterfall.Steps))
+	}
+	if waterfall.Steps[0].Policy != "CurfewPolicy" || waterfall.Steps[1].Policy != "MaintenancePolicy" {
+		t.Errorf("Expected steps in policy order, got %s, %s", waterfall.Steps[0].Policy, waterfall.Steps[1].Policy)
+	}
+
+	// Curfew closes 7 of every 24 hours
+	if curfew := waterfall.Steps[0]; math.Abs(curfew.LossPercent-7.0/24*100) > 0.5 {
+		t.Errorf("Expected curfew loss near %.1f%%, got %.1f%%", 7.0/24*100, curfew.LossPercent)
+	}
+
+	var sum float32
+	for _, step := range waterfall.Steps {
+		if step.Loss <= 0 {
+			t.Errorf("Expected %s to reduce capacity, got loss %.0f", step.Policy, step.Loss)
+		}
+		sum += step.Loss
+	}
+	if math.Abs(float64(sum-waterfall.TotalLoss())) > 1 {
+		t.Errorf("Expected cumulative losses to sum to %.0f, got %.0f", waterfall.TotalLoss(), sum)
+	}
+	if waterfall.Interaction != 0 {
+		t.Errorf("Expected no interaction in cumulative mode, got %.0f", waterfall.Interaction)
+	}
+}
+
+func TestSimulation_CapacityWaterfall_LeaveOneOut(t *testing.T) {
+	sim := newWaterfallSimulation(t)
+
+	cumulative, err := sim.CapacityWaterfall(context.Background(), CumulativeAttribution)
+	if err != nil {
+		t.Fatalf("CapacityWaterfall failed: %v", err)
+	}
+	waterfall, err := sim.CapacityWaterfall(context.Background(), LeaveOneOutAttribution)
+	if err != nil {
+		t.Fatalf("CapacityWaterfall failed: %v", err)
+	}
+
+	// Removing the last policy leaves the same simulation as adding every policy but the last
+	if waterfall.Steps[1].Capacity != cumulative.Steps[0].Capacity {
+		t.Errorf("Expected capacity without maintenance %.0f, got %.0f", cumulative.Steps[0].Capacity, waterfall.Steps[1].Capacity)
+	}
+
+	// Maintenance overlapping the curfew is lost to both, so neither is charged with it
+	if waterfall.Interaction <= 0 {
+		t.Errorf("Expected positive interaction from overlapping closures, got %.0f", waterfall.Interaction)
+	}
+	sum := waterfall.Interaction
+	for _, step := range waterfall.Steps {
+		sum += step.Loss
+	}
+	if math.Abs(float64(sum-waterfall.TotalLoss())) > 1 {
+		t.Errorf("Expected losses plus interaction to sum to %.0f, got %.0f", waterfall.TotalLoss(), sum)
+	}
+}
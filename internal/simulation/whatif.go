@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
+)
+
+// WhatIf evaluates the airport in a single set of conditions that can be changed one at a
+// time (runways closed, wind, curfew), recomputing the active runway configuration and its
+// hourly capacity with the runway manager after each change. It suits interactive
+// exploration, e.g. in ops-room discussions, where a full simulation is not needed.
+//
+// Capacities are theoretical hourly rates as in CapacityTable; runtime policies are not
+// applied. WhatIf is not safe for concurrent use.
+type WhatIf struct {
+	airport   airport.Airport
+	rm        *RunwayManager
+	closed    map[string]bool
+	curfew    bool
+	windSpeed float64
+	windGust  float64
+	windDir   float64
+}
+
+// NewWhatIf creates a what-if evaluator for the airport with every runway open, calm wind
+// and no curfew.
+// Returns an error if the airport configuration is invalid.
+func NewWhatIf(ap airport.Airport) (*WhatIf, error) {
+	if err := ap.Validate(); err != nil {
+		return nil, err
+	}
+
+	rm := NewRunwayManager(ap.Runways, ap.RunwayCompatibility)
+	rm.SetFleetMix(ap.FleetMix)
+	return &WhatIf{
+		airport: ap,
+		rm:      rm,
+		closed:  make(map[string]bool),
+	}, nil
+}
+
+// SetRunwayOpen opens or closes a runway.
+// Returns a RunwayNotFoundError if the airport has no such runway.
+func (w *WhatIf) SetRunwayOpen(runwayID string, open bool) error {
+	if _, found := w.rm.findRunwayByID(runwayID); !found {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+
+	if open {
+		delete(w.closed, runwayID)
+		w.rm.OnRunwayAvailable(runwayID)
+	} else {
+		w.closed[runwayID] = true
+		w.rm.OnRunwayUnavailable(runwayID)
+	}
+	return nil
+}
+
+// SetWind sets the wind speed, gust speed (0 = no gusts) and true direction it blows from.
+// Returns an error if a speed is negative, the gust is below the speed or the direction is
+// outside [0, 360).
+func (w *WhatIf) SetWind(speedKnots, gustKnots, directionTrue float64) error {
+	if speedKnots < 0 || math.IsNaN(speedKnots) {
+		return simerrors.Invalidf("wind speed must not be negative, got %v", speedKnots)
+	}
+	if gustKnots != 0 && (gustKnots < speedKnots || math.IsNaN(gustKnots)) {
+		return simerrors.Invalidf("gust speed %v must not be below the wind speed %v", gustKnots, speedKnots)
+	}
+	if directionTrue < 0 || directionTrue >= 360 || math.IsNaN(directionTrue) {
+		return simerrors.Invalidf("wind direction must be in [0, 360), got %v", directionTrue)
+	}
+
+	w.windSpeed, w.windGust, w.windDir = speedKnots, gustKnots, directionTrue
+	w.rm.OnGustingWindChanged(speedKnots, gustKnots, directionTrue)
+	return nil
+}
+
+// SetCurfew starts or ends a curfew, during which no runway or helipad operates.
+func (w *WhatIf) SetCurfew(active bool) {
+	w.curfew = active
+	w.rm.OnCurfewChanged(active)
+}
+
+// ClosedRunways returns the runways currently closed, sorted.
+func (w *WhatIf) ClosedRunways() []string {
+	closed := make([]string, 0, len(w.closed))
+	for runwayID := range w.closed {
+		closed = append(closed, runwayID)
+	}
+	sort.Strings(closed)
+	return closed
+}
+
+// Wind returns the current wind speed, gust speed and true direction.
+func (w *WhatIf) Wind() (speedKnots, gustKnots, directionTrue float64) {
+	return w.windSpeed, w.windGust, w.windDir
+}
+
+// Curfew reports whether a curfew is in effect.
+func (w *WhatIf) Curfew() bool {
+	return w.curfew
+}
+
+// Configuration returns the active runway configuration (see RunwayManager.GetActiveConfiguration).
+func (w *WhatIf) Configuration() map[string]*event.ActiveRunwayInfo {
+	return w.rm.GetActiveConfiguration()
+}
+
+// HourlyCapacity returns the movements per hour of the active runway configuration and of
+// the helipads usable in the current wind.
+func (w *WhatIf) HourlyCapacity() (runwayMovements, helipadMovements float64) {
+	w.rm.mu.Lock()
+	capacity := w.rm.rateConfiguration(w.rm.currentConfiguration)
+	reference := w.rm.options.referenceSeconds()
+	w.rm.mu.Unlock()
+	runwayMovements = float64(capacity) * 3600 / float64(reference)
+
+	if w.curfew {
+		return runwayMovements, 0
+	}
+	for _, helipad := range w.airport.Helipads {
+		if helipad.UsableInWind(w.windSpeed, w.windGust) {
+			helipadMovements += 3600 / helipad.MinimumSeparation.Seconds()
+		}
+	}
+	return runwayMovements, helipadMovements
+}
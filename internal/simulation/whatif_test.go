@@ -0,0 +1,63 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestWhatIf(t *testing.T) {
+	ap := capacityTableAirport()
+	ap.Helipads = []airport.Helipad{{Designation: "H1", MinimumSeparation: 120 * time.Second, WindLimitKnots: 25}}
+
+	w, err := NewWhatIf(ap)
+	if err != nil {
+		t.Fatalf("NewWhatIf failed: %v", err)
+	}
+	check := func(step string, wantLabel string, wantRunway, wantHelipad float64) {
+		t.Helper()
+		if got := ConfigurationLabel(w.Configuration()); got != wantLabel {
+			t.Errorf("%s: expected configuration %q, got %q", step, wantLabel, got)
+		}
+		runway, helipad := w.HourlyCapacity()
+		if runway != wantRunway || helipad != wantHelipad {
+			t.Errorf("%s: expected %.0f runway and %.0f helipad movements, got %.1f and %.1f", step, wantRunway, wantHelipad, runway, helipad)
+		}
+	}
+
+	check("initial", "09L, 09R", 120, 30)
+
+	if err := w.SetRunwayOpen("09L", false); err != nil {
+		t.Fatalf("SetRunwayOpen failed: %v", err)
+	}
+	// Only whole maximal runway sets are selected, so the crossing runway takes over
+	check("09L closed", "18", 40, 30)
+
+	// A strong northerly leaves only the crosswind runway and closes the helipad
+	if err := w.SetWind(30, 0, 0); err != nil {
+		t.Fatalf("SetWind failed: %v", err)
+	}
+	check("northerly", "36", 40, 0)
+
+	if err := w.SetWind(10, 0, 270); err != nil {
+		t.Fatalf("SetWind failed: %v", err)
+	}
+	if err := w.SetRunwayOpen("09L", true); err != nil {
+		t.Fatalf("SetRunwayOpen failed: %v", err)
+	}
+	check("westerly", "27L, 27R", 120, 30)
+
+	w.SetCurfew(true)
+	check("curfew", ClosedConfigurationLabel, 0, 0)
+
+	var notFound *simerrors.RunwayNotFoundError
+	if err := w.SetRunwayOpen("99", false); !errors.As(err, &notFound) {
+		t.Errorf("Expected RunwayNotFoundError, got %v", err)
+	}
+	if err := w.SetWind(10, 5, 90); !errors.Is(err, simerrors.ErrInvalidConfiguration) {
+		t.Errorf("Expected invalid configuration error for gust below wind speed, got %v", err)
+	}
+}
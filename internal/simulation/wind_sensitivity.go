@@ -0,0 +1,158 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+// WindLimitSweep describes the runway wind limits a wind-limit sensitivity study tries, such as
+// the crosswind capability a fleet upgrade or new procedure would bring.
+type WindLimitSweep struct {
+	RunwayDesignations []string // Runways whose limits change (empty = every runway)
+	PerRunway          bool     // Change each runway's limits on its own rather than all together
+
+	CrosswindLimitsKnots []float64 // Crosswind limits to try (empty = unchanged)
+	TailwindLimitsKnots  []float64 // Tailwind limits to try (empty = unchanged); combined with every crosswind limit
+}
+
+// WindLimitCase is the capacity of a simulation with changed runway wind limits.
+type WindLimitCase struct {
+	RunwayDesignations  []string // Runways whose limits changed
+	CrosswindLimitKnots float64  // Crosswind limit from either end (0 = unchanged)
+	TailwindLimitKnots  float64  // Tailwind limit from either end (0 = unchanged)
+
+	Capacity      float32 // Capacity with the changed limits
+	Change        float32 // Movements gained (negative when lost) over the current limits
+	AnnualChange  float64 // Change per calendar year (see Period.Annualized)
+	ChangePercent float64 // Change as a percentage of the current capacity (0 when it is zero)
+}
+
+// String formats the case for display, e.g. "09L/27R crosswind 38 kt: +5120 movements per
+// year (+1.0%)".
+func (c WindLimitCase) String() string {
+	var limits []string
+	if c.CrosswindLimitKnots > 0 {
+		limits = append(limits, fmt.Sprintf("crosswind %g kt", c.CrosswindLimitKnots))
+	}
+	if c.TailwindLimitKnots > 0 {
+		limits = append(limits, fmt.Sprintf("tailwind %g kt", c.TailwindLimitKnots))
+	}
+	return fmt.Sprintf("%s %s: %+.0f movements per year (%+.1f%%)",
+		strings.Join(c.RunwayDesignations, ","), strings.Join(limits, ", "), c.AnnualChange, c.ChangePercent)
+}
+
+// WindLimitSensitivity is the capacity change each wind limit case of a sweep brings.
+type WindLimitSensitivity struct {
+	Capacity float32         // Capacity with the current limits
+	Cases    []WindLimitCase // One case per runway group and limit combination, in sweep order
+}
+
+// WindLimitSensitivity reruns the simulation with the runway wind limits of each case of the
+// sweep and reports the annual capacity each gains or loses: one case for every crosswind and
+// tailwind limit combination, for the runways together or, with PerRunway, each in turn.
+//
+// The limits are applied by a RunwayExtensionPlugin per runway, replacing the limits of both
+// runway ends. Every run uses the same seed, so wind policies replay the same wind and the
+// change comes only from the limits. Returns an error if the sweep has no limits, a limit is
+// not positive or a runway does not exist.
+func (s *Simulation) WindLimitSensitivity(ctx context.Context, sweep WindLimitSweep) (*WindLimitSensitivity, error) {
+	s = s.enabledPolicies()
+	ap := s.applyPlugins(ctx, nil)
+	if err := sweep.validate(ap); err != nil {
+		return nil, err
+	}
+
+	runways := sweep.RunwayDesignations
+	if len(runways) == 0 {
+		for _, runway := range ap.Runways {
+			runways = append(runways, runway.RunwayDesignation)
+		}
+	}
+	groups := [][]string{runways}
+	if sweep.PerRunway {
+		groups = groups[:0]
+		for _, id := range runways {
+			groups = append(groups, []string{id})
+		}
+	}
+	crosswinds, tailwinds := sweep.CrosswindLimitsKnots, sweep.TailwindLimitsKnots
+	if len(crosswinds) == 0 {
+		crosswinds = []float64{0}
+	}
+	if len(tailwinds) == 0 {
+		tailwinds = []float64{0}
+	}
+
+	seed := s.seed
+	if !s.seeded {
+		seed = rand.Int64()
+	}
+	all := make([]int, len(s.policies))
+	for i := range all {
+		all[i] = i
+	}
+
+	s.log(ctx).InfoContext(ctx, "Running wind limit sensitivity", "cases", len(groups)*len(crosswinds)*len(tailwinds))
+	current, err := s.withPolicies(all).WithSeed(seed).Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	period := s.Period()
+
+	sensitivity := &WindLimitSensitivity{Capacity: current}
+	for _, group := range groups {
+		for _, crosswind := range crosswinds {
+			for _, tailwind := range tailwinds {
+				c := WindLimitCase{RunwayDesignations: group, CrosswindLimitKnots: crosswind, TailwindLimitKnots: tailwind}
+				sim := s.withPolicies(all)
+				plugins := slices.Clip(sim.preSimulationPlugins)
+				for _, id := range group {
+					plugins = append(plugins, RunwayExtensionPlugin{
+						RunwayDesignation: id,
+						Modification:      airport.RunwayModification{CrosswindLimitKnots: crosswind, TailwindLimitKnots: tailwind},
+					})
+				}
+				sim.preSimulationPlugins = plugins
+				if c.Capacity, err = sim.WithSeed(seed).Run(ctx); err != nil {
+					return nil, fmt.Errorf("evaluating %s: %w", c, err)
+				}
+
+				c.Change = c.Capacity - current
+				c.AnnualChange = period.Annualized(c.Change)
+				if current > 0 {
+					c.ChangePercent = float64(c.Change) / float64(current) * 100
+				}
+				sensitivity.Cases = append(sensitivity.Cases, c)
+			}
+		}
+	}
+	return sensitivity, nil
+}
+
+// validate checks that the sweep has a limit to try, that every limit is positive and that
+// every runway exists.
+// All problems found are returned together (see errors.Join), or nil if there are none.
+func (w WindLimitSweep) validate(ap airport.Airport) error {
+	var problems []error
+	if len(w.CrosswindLimitsKnots) == 0 && len(w.TailwindLimitsKnots) == 0 {
+		problems = append(problems, simerrors.Invalidf("wind limit sweep needs a crosswind or tailwind limit to try"))
+	}
+	for _, limit := range slices.Concat(w.CrosswindLimitsKnots, w.TailwindLimitsKnots) {
+		if limit <= 0 {
+			problems = append(problems, simerrors.Invalidf("wind limits must be positive, got %g", limit))
+		}
+	}
+	for _, id := range w.RunwayDesignations {
+		if !hasRunway(ap, id) {
+			problems = append(problems, &simerrors.RunwayNotFoundError{RunwayID: id})
+		}
+	}
+	return errors.Join(problems...)
+}
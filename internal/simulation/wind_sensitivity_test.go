@@ -0,0 +1,90 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
+)
+
+func TestSimulation_WindLimitSensitivity(t *testing.T) {
+	ap := airport.Airport{
+		Name: "Test",
+		Runways: []airport.Runway{
+			{RunwayDesignation: "09", TrueBearing: 90, MinimumSeparation: time.Minute, CrosswindLimitKnots: 20, TailwindLimitKnots: 5},
+			{RunwayDesignation: "18", TrueBearing: 180, MinimumSeparation: time.Minute, CrosswindLimitKnots: 20, TailwindLimitKnots: 5},
+		},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A 30 knot southerly is a full crosswind on 09/27, leaving only 18 at 60 movements per hour
+	sim, err := NewSimulation(ap, testEngineLogger()).WithPeriod(start, start.AddDate(0, 0, 1)).WithSeed(1).AddWindPolicy(30, 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sensitivity, err := sim.WindLimitSensitivity(context.Background(), WindLimitSweep{
+		PerRunway:            true,
+		CrosswindLimitsKnots: []float64{25, 33},
+	})
+	if err != nil {
+		t.Fatalf("WindLimitSensitivity failed: %v", err)
+	}
+	if sensitivity.Capacity != 1440 {
+		t.Errorf("Expected 1440 movements with the current limits, got %v", sensitivity.Capacity)
+	}
+
+	// Only a 33 knot limit on 09 lets it operate alongside 18; 2024 has 366 days
+	want := []struct {
+		runway    string
+		crosswind float64
+		change    float32
+	}{{"09", 25, 0}, {"09", 33, 1440}, {"18", 25, 0}, {"18", 33, 0}}
+	if len(sensitivity.Cases) != len(want) {
+		t.Fatalf("Expected %d cases, got %+v", len(want), sensitivity.Cases)
+	}
+	for i, c := range sensitivity.Cases {
+		w := want[i]
+		if len(c.RunwayDesignations) != 1 || c.RunwayDesignations[0] != w.runway || c.CrosswindLimitKnots != w.crosswind || c.TailwindLimitKnots != 0 ||
+			c.Change != w.change || c.AnnualChange != float64(w.change)*366 || c.ChangePercent != float64(w.change)/1440*100 {
+			t.Errorf("Case %d: expected %s at %g kt to change capacity by %v, got %+v", i, w.runway, w.crosswind, w.change, c)
+		}
+	}
+	if got := sensitivity.Cases[1].String(); got != "09 crosswind 33 kt: +527040 movements per year (+100.0%)" {
+		t.Errorf("Unexpected case string %q", got)
+	}
+
+	// Together, with tailwind limits combined with each crosswind limit
+	together, err := sim.WindLimitSensitivity(context.Background(), WindLimitSweep{
+		CrosswindLimitsKnots: []float64{33},
+		TailwindLimitsKnots:  []float64{5, 10},
+	})
+	if err != nil {
+		t.Fatalf("WindLimitSensitivity failed: %v", err)
+	}
+	if len(together.Cases) != 2 || len(together.Cases[0].RunwayDesignations) != 2 || together.Cases[1].TailwindLimitKnots != 10 || together.Cases[1].Change != 1440 {
+		t.Errorf("Unexpected cases %+v", together.Cases)
+	}
+}
+
+func TestSimulation_WindLimitSensitivityErrors(t *testing.T) {
+	sim := NewSimulation(capacityTableAirport(), testEngineLogger())
+	tests := []struct {
+		name  string
+		sweep WindLimitSweep
+		want  error
+	}{
+		{"no limits", WindLimitSweep{}, simerrors.ErrInvalidConfiguration},
+		{"negative limit", WindLimitSweep{TailwindLimitsKnots: []float64{-5}}, simerrors.ErrInvalidConfiguration},
+		{"unknown runway", WindLimitSweep{RunwayDesignations: []string{"36"}, CrosswindLimitsKnots: []float64{38}}, simerrors.ErrRunwayNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := sim.WindLimitSensitivity(context.Background(), tt.sweep); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -4,11 +4,13 @@
 package simulation
 
 import (
-	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/airport"
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simerrors"
 	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/event"
 )
 
@@ -29,23 +31,70 @@ type World struct {
 	Events *event.EventQueue // Priority queue of events ordered chronologically
 
 	// Operational state
-	RunwayStates map[string]*RunwayState // Per-runway availability and configuration (legacy, for historical tracking)
-	CurfewActive bool                    // Whether airport curfew is currently in effect
-	WindSpeed    float64                 // Current wind speed in knots
-	WindDirection float64                // Current wind direction in degrees true (0 = no wind)
+	RunwayStates          map[string]*RunwayState       // Per-runway availability and configuration (legacy, for historical tracking)
+	CurfewActive          bool                          // Whether airport curfew is currently in effect
+	CurfewRestrictions    []*event.CurfewRestriction    // Runway-specific or partial curfews currently in effect (in activation order)
+	Disruptions           []*event.Disruption           // Airfield disruptions currently in effect (in activation order)
+	GAReservations        []*event.GAReservation        // General aviation reservations currently in effect (in activation order)
+	SeparationAdjustments []*event.SeparationAdjustment // Temporary separation adjustments currently in effect (in activation order)
+	WindSpeed             float64                       // Current wind speed in knots
+	WindDirection         float64                       // Current wind direction in degrees true (0 = no wind)
+	WindGust              float64                       // Current peak gust speed in knots (0 = no gusts)
+	TemperatureCelsius    float64                       // Current outside air temperature in °C (only used when TemperatureKnown)
+	TemperatureKnown      bool                          // Whether a temperature has been set (false = standard atmosphere)
 
 	// Runway management (single source of truth for active runways)
-	RunwayManager            *RunwayManager                          // Manages runway availability and active configuration
-	activeConfigMu           sync.RWMutex                            // Protects ActiveRunwayConfiguration
-	ActiveRunwayConfiguration map[string]*event.ActiveRunwayInfo     // Current active runway configuration
+	RunwayManager             *RunwayManager                     // Manages runway availability and active configuration
+	activeConfigMu            sync.RWMutex                       // Protects ActiveRunwayConfiguration
+	ActiveRunwayConfiguration map[string]*event.ActiveRunwayInfo // Current active runway configuration
+	configurationHistory      []ConfigurationChange              // Every distinct active configuration (protected by activeConfigMu)
+	applyingEvent             string                             // Type of the event the engine is applying ("" outside event processing)
+	activeIDs                 []string                           // Sorted runway IDs of ActiveRunwayConfiguration (protected by activeConfigMu)
+	activeLabel, activeFlow   string                             // Label and flow of ActiveRunwayConfiguration (protected by activeConfigMu)
 
 	// Capacity modifiers
-	RotationMultiplier     float32       // Efficiency multiplier from runway rotation strategy (1.0 = no penalty)
-	GateCapacityConstraint float32       // Max movements/second limited by gates (0 = no constraint)
-	TaxiTimeOverhead       time.Duration // Total taxi time overhead per aircraft cycle (0 = no overhead)
+	RotationMultiplier        float32            // Efficiency multiplier from runway rotation strategy (1.0 = no penalty)
+	RunwayRotationMultipliers map[string]float32 // Runway end designation -> efficiency multiplier of its movements (nil = no per-runway penalty)
+	OpenGates                 int                // Gates open for turnarounds (0 = no gate constraint)
+	GateTurnaround            time.Duration      // Average time an aircraft occupies a gate, before taxi time
+	gateOccupancy             *gateOccupancy     // Aircraft on gates (nil without a gate constraint)
+	TaxiTimeOverhead          time.Duration      // Total taxi time overhead per aircraft cycle (0 = no overhead)
+	DeclaredCapacity          float64            // Declared movements per rolling hour for slot coordination (0 = no cap)
+	DeicingConstraint         float32            // Max movements/second limited by de-icing pads (0 = no constraint)
+	DeicingThresholdCelsius   float64            // Temperature below which DeicingConstraint applies
+
+	// Aircraft performance
+	LandingPerformance            []airport.LandingPerformance // Fleet landing distance requirements (nil = no tailwind penalty)
+	TailwindDistanceFactorPerKnot float64                      // Fractional landing distance increase per knot of tailwind
+
+	// Direction changeovers
+	DirectionChangeoverPenalty time.Duration        // Throughput lost each time an active runway reverses direction (0 = instantaneous)
+	directionChangeovers       map[string]time.Time // Runway ID -> time its current changeover completes
+
+	// Gust factor
+	GustFactorThresholdKnots float64       // Gust spread (gust minus mean wind) at which GustFactorSeparation applies
+	GustFactorSeparation     time.Duration // Extra separation per movement during gusty periods (0 = none)
+
+	// Noise quota
+	NoiseQuotaWeights map[string]float64 // Runway end designation -> noise points per movement (nil = no quota)
+	NoiseQuotaPoints  float64            // Noise points available per calendar year
+	noiseQuotaUsed    map[int]float64    // Calendar year -> noise points consumed so far
+
+	// Night movement quota
+	NightQuotaMovements       float64         // Movements permitted in each night quota period (0 = no quota)
+	NightQuotaAnnualMovements float64         // Movements permitted in night quota periods per calendar year (0 = no annual limit)
+	NightQuotaActive          bool            // Whether a night quota period is in effect
+	nightQuotaRemaining       float64         // Movements left in the current night quota period
+	nightQuotaUsed            map[int]float64 // Calendar year -> movements made in night quota periods so far
+
+	// Runway wear
+	runwayMovements map[string]float64 // Runway ID -> movements handled so far
+	wearBaselines   map[string]float64 // Runway ID -> movements handled at its last wear-based maintenance (nil = no wear checks)
 
 	// Metrics
 	TotalCapacity float32 // Accumulated total capacity (movements) calculated so far
+
+	retainEvents bool // Events are shared with other worlds forked from a checkpoint and must not be released
 }
 
 // RunwayState tracks a single runway's operational status and configuration.
@@ -53,7 +102,8 @@ type World struct {
 // (e.g., maintenance makes runway unavailable).
 type RunwayState struct {
 	Runway    airport.Runway // The runway's configuration (designation, separation, etc.)
-	Available bool           // Whether the runway is currently available for operations
+	Available bool           // Whether the runway is currently available for operations (no active closures)
+	Closures  map[string]int // Active closures by reason (e.g., "maintenance" -> 2 for two overlapping windows)
 }
 
 // NewWorld creates a new simulation world initialized with an airport and time boundaries.
@@ -62,7 +112,7 @@ type RunwayState struct {
 //   - All runways are available
 //   - No curfew is active
 //   - RotationMultiplier is 1.0 (no efficiency penalty)
-//   - GateCapacityConstraint is 0 (no gate limitation)
+//   - OpenGates is 0 (no gate limitation)
 //   - TaxiTimeOverhead is 0 (no taxi time impact)
 //   - WindSpeed is 0, WindDirection is 0 (calm conditions)
 //   - Empty event queue
@@ -70,17 +120,21 @@ type RunwayState struct {
 // Policies will later modify these defaults by generating events that change the world state.
 func NewWorld(airport airport.Airport, startTime, endTime time.Time) *World {
 	world := &World{
-		Airport:            airport,
-		StartTime:          startTime,
-		EndTime:            endTime,
-		CurrentTime:        startTime,
-		Events:             event.NewEventQueue(),
-		RunwayStates:       make(map[string]*RunwayState),
-		CurfewActive:       false,
-		WindSpeed:          0, // Default: calm conditions
-		WindDirection:      0, // Default: calm conditions
-		RotationMultiplier: 1.0, // Default: no rotation penalty
-		TotalCapacity:      0,
+		Airport:              airport,
+		StartTime:            startTime,
+		EndTime:              endTime,
+		CurrentTime:          startTime,
+		Events:               event.NewEventQueue(),
+		RunwayStates:         make(map[string]*RunwayState),
+		directionChangeovers: make(map[string]time.Time),
+		noiseQuotaUsed:       make(map[int]float64),
+		nightQuotaUsed:       make(map[int]float64),
+		runwayMovements:      make(map[string]float64),
+		CurfewActive:         false,
+		WindSpeed:            0,   // Default: calm conditions
+		WindDirection:        0,   // Default: calm conditions
+		RotationMultiplier:   1.0, // Default: no rotation penalty
+		TotalCapacity:        0,
 	}
 
 	// Initialize runway states - all runways start available
@@ -93,9 +147,14 @@ func NewWorld(airport airport.Airport, startTime, endTime time.Time) *World {
 
 	// Initialize runway manager (single source of truth for active runways)
 	world.RunwayManager = NewRunwayManager(airport.Runways, airport.RunwayCompatibility)
+	world.RunwayManager.SetLocation(world.GetLocation())
+	world.RunwayManager.SetTime(startTime)
+	world.RunwayManager.SetFleetMix(airport.FleetMix)
 
 	// Set initial active runway configuration (all runways available)
 	world.ActiveRunwayConfiguration = world.RunwayManager.GetActiveConfiguration()
+	world.summarizeActiveConfiguration()
+	world.recordConfigurationChange("")
 
 	return world
 }
@@ -115,17 +174,142 @@ func (w *World) GetCurfewActive() bool {
 	return w.CurfewActive
 }
 
-// SetRunwayAvailable marks a runway as available or unavailable for operations.
-// Called by RunwayMaintenanceStartEvent (sets false) and RunwayMaintenanceEndEvent (sets true).
+// ActivateCurfewRestriction starts a runway-specific or partial curfew.
+// Called by RunwayCurfewStartEvent. Activating a restriction that is already active has no effect.
+func (w *World) ActivateCurfewRestriction(restriction *event.CurfewRestriction) {
+	for _, active := range w.CurfewRestrictions {
+		if active == restriction {
+			return
+		}
+	}
+	w.CurfewRestrictions = append(w.CurfewRestrictions, restriction)
+}
+
+// DeactivateCurfewRestriction ends a curfew restriction previously started by
+// ActivateCurfewRestriction. Called by RunwayCurfewEndEvent.
+func (w *World) DeactivateCurfewRestriction(restriction *event.CurfewRestriction) {
+	for i, active := range w.CurfewRestrictions {
+		if active == restriction {
+			w.CurfewRestrictions = append(w.CurfewRestrictions[:i], w.CurfewRestrictions[i+1:]...)
+			return
+		}
+	}
+}
+
+// ActivateDisruption starts a total or partial airfield disruption.
+// Called by DisruptionStartEvent. Activating a disruption that is already active has no effect.
+func (w *World) ActivateDisruption(disruption *event.Disruption) {
+	for _, active := range w.Disruptions {
+		if active == disruption {
+			return
+		}
+	}
+	w.Disruptions = append(w.Disruptions, disruption)
+}
+
+// DeactivateDisruption ends a disruption previously started by ActivateDisruption.
+// Called by DisruptionEndEvent.
+func (w *World) DeactivateDisruption(disruption *event.Disruption) {
+	for i, active := range w.Disruptions {
+		if active == disruption {
+			w.Disruptions = append(w.Disruptions[:i], w.Disruptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// ActivateGAReservation starts reserving capacity for general aviation.
+// Called by GAReservationStartEvent. Activating a reservation that is already active has no effect.
+func (w *World) ActivateGAReservation(reservation *event.GAReservation) {
+	for _, active := range w.GAReservations {
+		if active == reservation {
+			return
+		}
+	}
+	w.GAReservations = append(w.GAReservations, reservation)
+}
+
+// DeactivateGAReservation ends a reservation previously started by ActivateGAReservation.
+// Called by GAReservationEndEvent.
+func (w *World) DeactivateGAReservation(reservation *event.GAReservation) {
+	for i, active := range w.GAReservations {
+		if active == reservation {
+			w.GAReservations = append(w.GAReservations[:i], w.GAReservations[i+1:]...)
+			return
+		}
+	}
+}
+
+// ActivateSeparationAdjustment starts a temporary runway separation adjustment.
+// Called by SeparationAdjustmentStartEvent. Activating an adjustment that is already active has no effect.
+func (w *World) ActivateSeparationAdjustment(adjustment *event.SeparationAdjustment) {
+	for _, active := range w.SeparationAdjustments {
+		if active == adjustment {
+			return
+		}
+	}
+	w.SeparationAdjustments = append(w.SeparationAdjustments, adjustment)
+}
+
+// DeactivateSeparationAdjustment ends an adjustment previously started by ActivateSeparationAdjustment.
+// Called by SeparationAdjustmentEndEvent.
+func (w *World) DeactivateSeparationAdjustment(adjustment *event.SeparationAdjustment) {
+	for i, active := range w.SeparationAdjustments {
+		if active == adjustment {
+			w.SeparationAdjustments = append(w.SeparationAdjustments[:i], w.SeparationAdjustments[i+1:]...)
+			return
+		}
+	}
+}
+
+// separationFactor returns the multiplier applied to a runway's separation by the active
+// separation adjustments: the product of those that apply to it (1 when there are none).
+func (w *World) separationFactor(runwayID string) float32 {
+	factor := float32(1)
+	for _, adjustment := range w.SeparationAdjustments {
+		if adjustment.AppliesTo(runwayID) {
+			factor *= float32(adjustment.Factor)
+		}
+	}
+	return factor
+}
+
+// DisruptionFactor returns the fraction of capacity remaining under the active disruptions:
+// the most severe one applies (1 when there are none, 0 when the airfield is closed).
+func (w *World) DisruptionFactor() float32 {
+	factor := float32(1)
+	for _, disruption := range w.Disruptions {
+		factor = min(factor, float32(disruption.CapacityFactor))
+	}
+	return factor
+}
+
+// SetRunwayAvailable starts (available = false) or ends (available = true) one closure of a
+// runway for the given reason (e.g., "maintenance", "tide restriction").
+// Called by the maintenance, tide restriction and runway closure start and end events.
+// Closures may overlap, so the runway only becomes available again once every closure
+// started on it has ended; an end without a matching start is ignored.
 // Unavailable runways are excluded from capacity calculations.
 // Returns an error if the runway ID is not found in the airport configuration.
-func (w *World) SetRunwayAvailable(runwayID string, available bool) error {
+func (w *World) SetRunwayAvailable(runwayID, reason string, available bool) error {
 	state, exists := w.RunwayStates[runwayID]
 	if !exists {
-		return fmt.Errorf("runway %s not found", runwayID)
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
 	}
 
-	state.Available = available
+	if available {
+		if state.Closures[reason] > 1 {
+			state.Closures[reason]--
+		} else {
+			delete(state.Closures, reason)
+		}
+	} else {
+		if state.Closures == nil {
+			state.Closures = make(map[string]int)
+		}
+		state.Closures[reason]++
+	}
+	state.Available = len(state.Closures) == 0
 	return nil
 }
 
@@ -134,7 +318,7 @@ func (w *World) SetRunwayAvailable(runwayID string, available bool) error {
 func (w *World) GetRunwayAvailable(runwayID string) (bool, error) {
 	state, exists := w.RunwayStates[runwayID]
 	if !exists {
-		return false, fmt.Errorf("runway %s not found", runwayID)
+		return false, &simerrors.RunwayNotFoundError{RunwayID: runwayID}
 	}
 
 	return state.Available, nil
@@ -153,34 +337,102 @@ func (w *World) GetRotationMultiplier() float32 {
 	return w.RotationMultiplier
 }
 
-// SetGateCapacityConstraint sets the maximum movements per second allowed by gate capacity.
-// Called by GateCapacityConstraintEvent during initialization.
-// This constraint caps the sustained throughput when gates are more restrictive than runways.
-// A value of 0 means no gate constraint is applied.
+// SetRunwayRotationMultipliers sets the rotation efficiency multiplier of each runway end, for
+// rotation schemes that only penalize specific runways or directions (e.g., 0.85 on 27L). Called
+// by RotationChangeEvent. Each active runway's capacity is multiplied by the multiplier of the
+// end in use; ends without one are not penalized, and nil clears every multiplier. They apply
+// on top of RotationMultiplier.
+// Returns an error if a multiplier is negative.
+func (w *World) SetRunwayRotationMultipliers(multipliers map[string]float32) error {
+	for end, multiplier := range multipliers {
+		if multiplier < 0 {
+			return simerrors.Invalidf("rotation multiplier for runway end %s cannot be negative: %f", end, multiplier)
+		}
+	}
+	w.RunwayRotationMultipliers = maps.Clone(multipliers)
+	return nil
+}
+
+// runwayRotationMultiplier returns the rotation efficiency multiplier of a runway end (1.0 if
+// it has none).
+func (w *World) runwayRotationMultiplier(runwayEnd string) float32 {
+	if multiplier, ok := w.RunwayRotationMultipliers[runwayEnd]; ok {
+		return multiplier
+	}
+	return 1.0
+}
+
+// SetGateCapacity sets the gates open for turnarounds and the average time an aircraft
+// occupies one. Called by GateCapacityConstraintEvent at simulation start and when scheduled
+// gate closures start or end. Aircraft already on gates stay there, so closing gates limits
+// arrivals only once enough aircraft have departed (see gateOccupancy).
+// A gate count of 0 means no gate constraint is applied.
+// Returns an error if the gate count is negative or the turnaround is not positive.
+func (w *World) SetGateCapacity(gates int, turnaround time.Duration) error {
+	if gates < 0 {
+		return simerrors.Invalidf("open gates cannot be negative: %d", gates)
+	}
+	if gates > 0 && turnaround <= 0 {
+		return simerrors.Invalidf("gate turnaround must be positive, got %v", turnaround)
+	}
+	w.OpenGates = gates
+	w.GateTurnaround = turnaround
+	if gates == 0 {
+		w.gateOccupancy = nil
+	} else if w.gateOccupancy == nil {
+		w.gateOccupancy = &gateOccupancy{}
+	}
+	return nil
+}
+
+// SetDeclaredCapacity sets the movements per rolling hour a slot-coordinated airport declares.
+// Called by DeclaredCapacityEvent during initialization. A value of 0 means no cap is applied.
+// Returns an error if the declared capacity is negative.
+func (w *World) SetDeclaredCapacity(movementsPerHour float64) error {
+	if movementsPerHour < 0 {
+		return simerrors.Invalidf("declared capacity cannot be negative: %f", movementsPerHour)
+	}
+	w.DeclaredCapacity = movementsPerHour
+	return nil
+}
+
+// SetDeicingConstraint sets the maximum movements per second the de-icing pads allow while the
+// temperature is below thresholdCelsius. Called by DeicingConstraintEvent during initialization.
+// A value of 0 means no de-icing constraint is applied.
 // Returns an error if the constraint is negative.
-func (w *World) SetGateCapacityConstraint(maxMovementsPerSecond float32) error {
+func (w *World) SetDeicingConstraint(maxMovementsPerSecond float32, thresholdCelsius float64) error {
 	if maxMovementsPerSecond < 0 {
-		return fmt.Errorf("gate capacity constraint cannot be negative: %f", maxMovementsPerSecond)
+		return simerrors.Invalidf("de-icing constraint cannot be negative: %f", maxMovementsPerSecond)
 	}
-	w.GateCapacityConstraint = maxMovementsPerSecond
+	w.DeicingConstraint = maxMovementsPerSecond
+	w.DeicingThresholdCelsius = thresholdCelsius
 	return nil
 }
 
-// GetGateCapacityConstraint returns the gate capacity constraint in movements per second.
-// A value of 0 means no constraint is applied.
-func (w *World) GetGateCapacityConstraint() float32 {
-	return w.GateCapacityConstraint
+// deicingConstraint returns the de-icing constraint in movements per second for the current
+// temperature, or 0 when it is warm enough (or no temperature is known) for de-icing not to apply.
+func (w *World) deicingConstraint() float32 {
+	if w.DeicingConstraint == 0 || !w.TemperatureKnown || w.TemperatureCelsius >= w.DeicingThresholdCelsius {
+		return 0
+	}
+	return w.DeicingConstraint
+}
+
+// GetGateCapacity returns the gates open for turnarounds and the average turnaround.
+// A gate count of 0 means no constraint is applied.
+func (w *World) GetGateCapacity() (gates int, turnaround time.Duration) {
+	return w.OpenGates, w.GateTurnaround
 }
 
 // SetTaxiTimeOverhead sets the total taxi time overhead per aircraft cycle.
 // Called by TaxiTimeAdjustmentEvent during initialization.
-// This overhead (taxi-in + taxi-out) extends the effective turnaround time, reducing
+// This overhead (taxi-in + taxi-out) extends the time each aircraft occupies a gate, reducing
 // the sustainable capacity when combined with gate constraints.
 // A value of 0 means no taxi time impact.
 // Returns an error if the overhead is negative.
 func (w *World) SetTaxiTimeOverhead(overhead time.Duration) error {
 	if overhead < 0 {
-		return fmt.Errorf("taxi time overhead cannot be negative: %v", overhead)
+		return simerrors.Invalidf("taxi time overhead cannot be negative: %v", overhead)
 	}
 	w.TaxiTimeOverhead = overhead
 	return nil
@@ -195,18 +447,35 @@ func (w *World) GetTaxiTimeOverhead() time.Duration {
 // SetWind sets the current wind conditions (speed in knots, direction in degrees true).
 // Called by WindPolicy during initialization or by WindChangeEvent if wind varies over time.
 // Wind direction of 0 with speed 0 indicates no wind (calm conditions).
-// Notifies the RunwayManager to recalculate active runway configuration based on new wind.
+// Any gusts from earlier wind conditions are cleared. See SetGustingWind.
 // Returns an error if wind speed is negative.
 func (w *World) SetWind(speed, direction float64) error {
+	return w.SetGustingWind(speed, 0, direction)
+}
+
+// SetGustingWind sets the current wind conditions with gusts up to gust knots (0 means no
+// gusts). Called by WindChangeEvent. Runways are checked against their crosswind and tailwind
+// limits at the gust speed.
+// Notifies the RunwayManager to recalculate active runway configuration based on new wind
+// and schedules an ActiveRunwayConfigurationChangedEvent at the current simulation time.
+// Returns an error if wind speed is negative or gusts are below the wind speed.
+func (w *World) SetGustingWind(speed, gust, direction float64) error {
 	if speed < 0 {
-		return fmt.Errorf("wind speed cannot be negative: %f", speed)
+		return simerrors.Invalidf("wind speed cannot be negative: %f", speed)
+	}
+	if gust != 0 && gust < speed {
+		return simerrors.Invalidf("wind gust %f cannot be below wind speed %f", gust, speed)
 	}
 	w.WindSpeed = speed
+	w.WindGust = gust
 	w.WindDirection = direction
 
 	// Notify RunwayManager of wind change (triggers runway configuration recalculation)
+	// and schedule an event so the engine picks up the new configuration
 	if w.RunwayManager != nil {
-		w.RunwayManager.OnWindChanged(speed, direction)
+		w.RunwayManager.OnGustingWindChanged(speed, gust, direction)
+		newConfig := w.RunwayManager.GetActiveConfiguration()
+		w.ScheduleEvent(event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, w.CurrentTime))
 	}
 
 	return nil
@@ -222,6 +491,239 @@ func (w *World) GetWindDirection() float64 {
 	return w.WindDirection
 }
 
+// SetTemperature sets the current outside air temperature in °C.
+// Called by TemperatureChangeEvent. Once set, runway length requirements of the airport's
+// fleet mix are assessed at the density altitude for this temperature.
+// Returns an error if the temperature is below absolute zero.
+func (w *World) SetTemperature(celsius float64) error {
+	if celsius < -273.15 {
+		return simerrors.Invalidf("temperature cannot be below absolute zero: %f", celsius)
+	}
+	w.TemperatureCelsius = celsius
+	w.TemperatureKnown = true
+	return nil
+}
+
+// SetLandingPerformance sets the fleet landing performance data used to penalise
+// tailwind operations. Called by TailwindPerformanceEvent during initialization.
+// When set, arrivals whose tailwind-corrected landing distance exceeds the active
+// runway's length are treated as infeasible and removed from that runway's capacity.
+// Returns an error if the distance factor is negative.
+func (w *World) SetLandingPerformance(fleet []airport.LandingPerformance, factorPerKnot float64) error {
+	if factorPerKnot < 0 {
+		return simerrors.Invalidf("tailwind distance factor cannot be negative: %f", factorPerKnot)
+	}
+	w.LandingPerformance = make([]airport.LandingPerformance, len(fleet))
+	copy(w.LandingPerformance, fleet)
+	w.TailwindDistanceFactorPerKnot = factorPerKnot
+	return nil
+}
+
+// SetDirectionChangeoverPenalty sets the throughput lost each time an active runway reverses
+// its operating direction. Called by DirectionChangeoverPenaltyEvent during initialization.
+// A value of 0 means direction changes are instantaneous.
+// Returns an error if the penalty is negative.
+func (w *World) SetDirectionChangeoverPenalty(penalty time.Duration) error {
+	if penalty < 0 {
+		return simerrors.Invalidf("direction changeover penalty cannot be negative: %v", penalty)
+	}
+	w.DirectionChangeoverPenalty = penalty
+	return nil
+}
+
+// SetGustFactor sets the separation added to every runway while the gust spread (gust minus
+// mean wind speed) is at least thresholdKnots. Called by GustFactorEvent during initialization.
+// Returns an error if the threshold or extra separation is negative.
+func (w *World) SetGustFactor(thresholdKnots float64, extraSeparation time.Duration) error {
+	if thresholdKnots < 0 {
+		return simerrors.Invalidf("gust factor threshold cannot be negative: %f", thresholdKnots)
+	}
+	if extraSeparation < 0 {
+		return simerrors.Invalidf("gust factor separation cannot be negative: %v", extraSeparation)
+	}
+	w.GustFactorThresholdKnots = thresholdKnots
+	w.GustFactorSeparation = extraSeparation
+	return nil
+}
+
+// gustSeparation returns the separation added to every runway for the current gusts.
+func (w *World) gustSeparation() time.Duration {
+	if w.GustFactorSeparation == 0 || w.WindGust == 0 || w.WindGust-w.WindSpeed < w.GustFactorThresholdKnots {
+		return 0
+	}
+	return w.GustFactorSeparation
+}
+
+// helipadMovements returns the helicopter movements the airport's helipads handle in a window
+// of the given duration: none during an airport-wide curfew, otherwise each helipad usable in
+// the current wind handles one movement per minimum separation, reduced by any airfield
+// disruption. Runway closures and runway-specific restrictions do not apply.
+func (w *World) helipadMovements(duration time.Duration) float32 {
+	if w.CurfewActive || len(w.Airport.Helipads) == 0 {
+		return 0
+	}
+	movements := float32(0)
+	for _, helipad := range w.Airport.Helipads {
+		if helipad.UsableInWind(w.WindSpeed, w.WindGust) {
+			movements += float32(duration.Seconds() / helipad.MinimumSeparation.Seconds())
+		}
+	}
+	return movements * w.DisruptionFactor()
+}
+
+// DirectionChangeoverLoss returns how much of the window [windowStart, windowEnd) a runway
+// spends in a direction changeover and therefore cannot handle movements.
+func (w *World) DirectionChangeoverLoss(runwayID string, windowStart, windowEnd time.Time) time.Duration {
+	changeoverEnd, exists := w.directionChangeovers[runwayID]
+	if !exists || !changeoverEnd.After(windowStart) {
+		return 0
+	}
+	if changeoverEnd.After(windowEnd) {
+		return windowEnd.Sub(windowStart)
+	}
+	return changeoverEnd.Sub(windowStart)
+}
+
+// SetNoiseQuota sets the noise points charged per movement on each runway end and the
+// number of points available per calendar year. Called by NoiseQuotaEvent during initialization.
+// Returns an error if the quota or any weight is negative.
+func (w *World) SetNoiseQuota(weights map[string]float64, quotaPoints float64) error {
+	if quotaPoints < 0 {
+		return simerrors.Invalidf("noise quota cannot be negative: %f", quotaPoints)
+	}
+	w.NoiseQuotaWeights = make(map[string]float64, len(weights))
+	for end, weight := range weights {
+		if weight < 0 {
+			return simerrors.Invalidf("noise weight for runway end %s cannot be negative: %f", end, weight)
+		}
+		w.NoiseQuotaWeights[end] = weight
+	}
+	w.NoiseQuotaPoints = quotaPoints
+	return nil
+}
+
+// ConsumeNoiseQuota charges movements on a runway end against the quota for the calendar
+// year containing at, and returns how many of those movements the remaining quota allows.
+// Runway ends without a weight are never limited.
+func (w *World) ConsumeNoiseQuota(runwayEnd string, movements float32, at time.Time) float32 {
+	weight := w.NoiseQuotaWeights[runwayEnd]
+	if weight <= 0 || movements <= 0 {
+		return movements
+	}
+
+	year := at.Year()
+	remaining := w.NoiseQuotaPoints - w.noiseQuotaUsed[year]
+	if remaining <= 0 {
+		return 0
+	}
+
+	allowed := movements
+	if points := float64(movements) * weight; points > remaining {
+		allowed = float32(remaining / weight)
+	}
+	w.noiseQuotaUsed[year] += float64(allowed) * weight
+	return allowed
+}
+
+// NoiseQuotaUsed returns the noise points consumed in the calendar year containing at.
+func (w *World) NoiseQuotaUsed(at time.Time) float64 {
+	return w.noiseQuotaUsed[at.Year()]
+}
+
+// SetNightQuota sets the movements permitted in each night quota period and across the night
+// quota periods of a calendar year (0 for no annual limit). Called by NightQuotaEvent during
+// initialization. Returns an error if either allowance is negative.
+func (w *World) SetNightQuota(movementsPerNight, movementsPerYear float64) error {
+	if movementsPerNight < 0 {
+		return simerrors.Invalidf("night quota cannot be negative: %f", movementsPerNight)
+	}
+	if movementsPerYear < 0 {
+		return simerrors.Invalidf("annual night quota cannot be negative: %f", movementsPerYear)
+	}
+	w.NightQuotaMovements = movementsPerNight
+	w.NightQuotaAnnualMovements = movementsPerYear
+	return nil
+}
+
+// SetNightQuotaActive sets whether a night quota period is in effect. Called by
+// NightQuotaStartEvent (sets true, with the full nightly allowance) and NightQuotaEndEvent.
+func (w *World) SetNightQuotaActive(active bool) {
+	w.NightQuotaActive = active
+	if active {
+		w.nightQuotaRemaining = w.NightQuotaMovements
+	}
+}
+
+// ConsumeNightQuota charges movements made during a night quota period against the nightly
+// allowance and the annual allowance of the calendar year containing at, and returns how many
+// of those movements the remaining allowances permit.
+func (w *World) ConsumeNightQuota(movements float32, at time.Time) float32 {
+	if movements <= 0 {
+		return 0
+	}
+
+	year := at.Year()
+	remaining := w.nightQuotaRemaining
+	if w.NightQuotaAnnualMovements > 0 {
+		remaining = min(remaining, w.NightQuotaAnnualMovements-w.nightQuotaUsed[year])
+	}
+	if remaining <= 0 {
+		return 0
+	}
+
+	allowed := movements
+	if float64(movements) > remaining {
+		allowed = float32(remaining)
+	}
+	w.nightQuotaRemaining -= float64(allowed)
+	w.nightQuotaUsed[year] += float64(allowed)
+	return allowed
+}
+
+// NightQuotaUsed returns the movements made in night quota periods in the calendar year
+// containing at.
+func (w *World) NightQuotaUsed(at time.Time) float64 {
+	return w.nightQuotaUsed[at.Year()]
+}
+
+// RunwayMovements returns the movements attributed to a runway so far.
+func (w *World) RunwayMovements(runwayID string) float64 {
+	return w.runwayMovements[runwayID]
+}
+
+// recordRunwayMovements adds a window's movements to each runway's total.
+func (w *World) recordRunwayMovements(runwayMovements map[string]float32) {
+	for runwayID, movements := range runwayMovements {
+		w.runwayMovements[runwayID] += float64(movements)
+	}
+}
+
+// CheckRunwayWear schedules maintenance of the given duration on a runway, starting at
+// timestamp, once the runway has handled movementsPerMaintenance movements since its last
+// wear-based maintenance (or the start of the simulation). Called by RunwayWearCheckEvent.
+// Returns an error if the runway does not exist or the limit or duration is not positive.
+func (w *World) CheckRunwayWear(runwayID string, movementsPerMaintenance float64, duration time.Duration, timestamp time.Time) error {
+	if _, ok := w.RunwayStates[runwayID]; !ok {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+	if movementsPerMaintenance <= 0 || duration <= 0 {
+		return simerrors.Invalidf("runway wear limit and maintenance duration must be positive, got %g movements and %v", movementsPerMaintenance, duration)
+	}
+
+	if w.wearBaselines == nil {
+		w.wearBaselines = make(map[string]float64)
+	}
+	movements := w.runwayMovements[runwayID]
+	if movements-w.wearBaselines[runwayID] < movementsPerMaintenance {
+		return nil
+	}
+
+	w.wearBaselines[runwayID] = movements
+	w.ScheduleEvent(event.NewRunwayMaintenanceStartEvent(runwayID, timestamp))
+	w.ScheduleEvent(event.NewRunwayMaintenanceEndEvent(runwayID, timestamp.Add(duration)))
+	return nil
+}
+
 // GetAvailableRunways returns a slice of currently available runways.
 func (w *World) GetAvailableRunways() []airport.Runway {
 	available := []airport.Runway{}
@@ -268,6 +770,22 @@ func (w *World) GetEndTime() time.Time {
 	return w.EndTime
 }
 
+// GetFleetMix returns the airport's fleet mix (nil if none is configured).
+func (w *World) GetFleetMix() *airport.FleetMix {
+	return w.Airport.FleetMix
+}
+
+// GetGateCount returns the airport's gate count (0 if unknown).
+func (w *World) GetGateCount() int {
+	return w.Airport.Gates
+}
+
+// GetLocation returns the airport's time zone (nil if none is set or it is unknown).
+func (w *World) GetLocation() *time.Location {
+	loc, _ := w.Airport.TimeZone()
+	return loc
+}
+
 // GetRunwayIDs returns a list of all runway IDs.
 func (w *World) GetRunwayIDs() []string {
 	ids := make([]string, 0, len(w.RunwayStates))
@@ -277,15 +795,30 @@ func (w *World) GetRunwayIDs() []string {
 	return ids
 }
 
+// GetRunwayEnds returns the designations of both ends of every runway (see airport.Runway.End).
+func (w *World) GetRunwayEnds() []string {
+	return w.Airport.RunwayEndDesignations()
+}
+
 // SetActiveRunwayConfiguration sets the active runway configuration.
 // This is the single source of truth for which runways the engine should use
-// for capacity calculations. Stores a copy to prevent external mutation.
+// for capacity calculations. Stores a copy to prevent external mutation, and records
+// the change with the type of the event that triggered it in the configuration history.
 //
 // Thread-safe: Uses write lock.
-func (w *World) SetActiveRunwayConfiguration(config map[string]*event.ActiveRunwayInfo) error {
+func (w *World) SetActiveRunwayConfiguration(config map[string]*event.ActiveRunwayInfo, trigger string) error {
 	w.activeConfigMu.Lock()
 	defer w.activeConfigMu.Unlock()
 
+	// Start a changeover window for every runway that remains active but reverses direction
+	if w.DirectionChangeoverPenalty > 0 {
+		for id, newInfo := range config {
+			if oldInfo, exists := w.ActiveRunwayConfiguration[id]; exists && oldInfo.Direction != newInfo.Direction {
+				w.directionChangeovers[id] = w.CurrentTime.Add(w.DirectionChangeoverPenalty)
+			}
+		}
+	}
+
 	// Store a copy to prevent external mutation
 	w.ActiveRunwayConfiguration = make(map[string]*event.ActiveRunwayInfo, len(config))
 	for k, v := range config {
@@ -293,10 +826,54 @@ func (w *World) SetActiveRunwayConfiguration(config map[string]*event.ActiveRunw
 		infoCopy := *v
 		w.ActiveRunwayConfiguration[k] = &infoCopy
 	}
+	w.summarizeActiveConfiguration()
+	w.recordConfigurationChange(trigger)
 
 	return nil
 }
 
+// summarizeActiveConfiguration caches the sorted runway IDs, label and flow of the active
+// configuration, which every window result records, so they are computed once per change
+// rather than once per window.
+//
+// NOT thread-safe: Must be called while holding the write lock (or before the world is shared).
+func (w *World) summarizeActiveConfiguration() {
+	w.activeIDs = configurationRunwayIDs(w.ActiveRunwayConfiguration)
+	w.activeLabel = ConfigurationLabel(w.ActiveRunwayConfiguration)
+	w.activeFlow = ConfigurationFlow(w.ActiveRunwayConfiguration)
+}
+
+// activeRunwayIDs returns the sorted IDs of the runways in the active configuration
+// without copying the configuration itself.
+//
+// Thread-safe: Uses read lock.
+func (w *World) activeRunwayIDs() []string {
+	w.activeConfigMu.RLock()
+	defer w.activeConfigMu.RUnlock()
+
+	return append(make([]string, 0, len(w.activeIDs)), w.activeIDs...)
+}
+
+// activeConfigurationLabels returns the label and traffic flow of the active configuration.
+func (w *World) activeConfigurationLabels() (label, flow string) {
+	w.activeConfigMu.RLock()
+	defer w.activeConfigMu.RUnlock()
+
+	return w.activeLabel, w.activeFlow
+}
+
+// activeConfiguration returns the active runway configuration without copying it, for the
+// engine's per-window calculations. The map is replaced rather than modified when the
+// configuration changes, so it stays consistent, but callers must treat it as read-only.
+//
+// Thread-safe: Uses read lock.
+func (w *World) activeConfiguration() map[string]*event.ActiveRunwayInfo {
+	w.activeConfigMu.RLock()
+	defer w.activeConfigMu.RUnlock()
+
+	return w.ActiveRunwayConfiguration
+}
+
 // GetActiveRunwayConfiguration returns the current active runway configuration.
 // Returns a copy to prevent external mutation of internal state.
 //
@@ -331,12 +908,97 @@ func (w *World) NotifyRunwayAvailabilityChange(runwayID string, available bool,
 	newConfig := w.RunwayManager.GetActiveConfiguration()
 
 	// Schedule an event to update the world's active configuration
-	configEvent := event.NewActiveRunwayConfigurationChangedEvent(newConfig, timestamp)
+	configEvent := event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp)
 	w.ScheduleEvent(configEvent)
 
 	return nil
 }
 
+// ModifyRunway applies a change to a runway's characteristics (e.g., length, approach category,
+// separation), notifies the RunwayManager, and schedules an ActiveRunwayConfigurationChangedEvent
+// so the engine uses the updated runway. Returns an error if the runway ID is not found.
+func (w *World) ModifyRunway(runwayID string, modification airport.RunwayModification, timestamp time.Time) error {
+	state, exists := w.RunwayStates[runwayID]
+	if !exists {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+
+	state.Runway = modification.Apply(state.Runway)
+	w.RunwayManager.OnRunwayModified(state.Runway)
+
+	newConfig := w.RunwayManager.GetActiveConfiguration()
+	w.ScheduleEvent(event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp))
+
+	return nil
+}
+
+// SetRunwayCompatibility makes runwayID compatible with exactly the runways in compatibleWith,
+// notifies the RunwayManager, and schedules an ActiveRunwayConfigurationChangedEvent.
+// The airport's compatibility graph is replaced rather than modified, since it may be shared.
+// Returns an error if the runway ID is not found.
+func (w *World) SetRunwayCompatibility(runwayID string, compatibleWith []string, timestamp time.Time) error {
+	if _, exists := w.RunwayStates[runwayID]; !exists {
+		return &simerrors.RunwayNotFoundError{RunwayID: runwayID}
+	}
+
+	runwayIDs := make([]string, 0, len(w.Airport.Runways))
+	for _, runway := range w.Airport.Runways {
+		runwayIDs = append(runwayIDs, runway.RunwayDesignation)
+	}
+	w.Airport.RunwayCompatibility = w.Airport.RunwayCompatibility.WithRunway(runwayID, compatibleWith, runwayIDs)
+	w.RunwayManager.OnCompatibilityChanged(w.Airport.RunwayCompatibility)
+
+	newConfig := w.RunwayManager.GetActiveConfiguration()
+	w.ScheduleEvent(event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp))
+
+	return nil
+}
+
+// NotifyCompatibilityHours re-selects the active runway configuration for the compatibility
+// hours in effect at timestamp and schedules an ActiveRunwayConfigurationChangedEvent.
+// Called by CompatibilityHoursEvent (see scheduleCompatibilityHours).
+func (w *World) NotifyCompatibilityHours(timestamp time.Time) error {
+	w.RunwayManager.SetTime(timestamp)
+
+	newConfig := w.RunwayManager.GetActiveConfiguration()
+	w.ScheduleEvent(event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp))
+
+	return nil
+}
+
+// scheduleCompatibilityHours schedules a CompatibilityHoursEvent at every time in the
+// simulation period at which a runway pair enters or leaves its compatibility hours (see
+// airport.CompatibilityHours). Days are laid out in the airport's local time, so the hours
+// follow daylight saving time changes.
+func (w *World) scheduleCompatibilityHours() {
+	rc := w.Airport.RunwayCompatibility
+	if rc == nil || len(rc.Hours) == 0 {
+		return
+	}
+
+	boundaries := make([]time.Duration, 0, 2*len(rc.Hours))
+	for _, hours := range rc.Hours {
+		boundaries = append(boundaries, hours.From, hours.Until)
+	}
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+
+	loc := w.GetLocation()
+	if loc == nil {
+		loc = w.StartTime.Location()
+	}
+	day := w.StartTime.In(loc)
+	for midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc); midnight.Before(w.EndTime); midnight = midnight.AddDate(0, 0, 1) {
+		for _, boundary := range boundaries {
+			t := time.Date(midnight.Year(), midnight.Month(), midnight.Day(),
+				int(boundary/time.Hour), int(boundary%time.Hour/time.Minute), int(boundary%time.Minute/time.Second), 0, loc).In(w.StartTime.Location())
+			if t.After(w.StartTime) && t.Before(w.EndTime) {
+				w.ScheduleEvent(event.NewCompatibilityHoursEvent(t))
+			}
+		}
+	}
+}
+
 // NotifyCurfewChange notifies the RunwayManager of a curfew status change
 // and schedules an ActiveRunwayConfigurationChangedEvent with the new configuration.
 // During curfew, the configuration will be empty (no active runways).
@@ -348,7 +1010,7 @@ func (w *World) NotifyCurfewChange(active bool, timestamp time.Time) error {
 	newConfig := w.RunwayManager.GetActiveConfiguration()
 
 	// Schedule an event to update the world's active configuration
-	configEvent := event.NewActiveRunwayConfigurationChangedEvent(newConfig, timestamp)
+	configEvent := event.NewActiveRunwayConfigurationChangedEvent(newConfig, w.applyingEvent, timestamp)
 	w.ScheduleEvent(configEvent)
 
 	return nil
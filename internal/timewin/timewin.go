@@ -0,0 +1,226 @@
+// Package timewin provides half-open time windows and the operations policies build
+// operational rules from: laying out daily and weekday windows, merging, intersecting and
+// subtracting sets of windows, and splitting windows at midnight.
+package timewin
+
+import (
+	"slices"
+	"time"
+)
+
+// Window is the half-open time period [Start, End).
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the window.
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Empty reports whether the window covers no time.
+func (w Window) Empty() bool {
+	return !w.End.After(w.Start)
+}
+
+// Contains reports whether t is within the window, including its start but not its end.
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Overlaps reports whether the windows have a period in common. Windows that only touch do not
+// overlap.
+func (w Window) Overlaps(other Window) bool {
+	return w.Start.Before(other.End) && other.Start.Before(w.End)
+}
+
+// Contains reports whether t is within any of the windows.
+func Contains(windows []Window, t time.Time) bool {
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// OnDay returns the window from the clock time of from to the clock time of to on day, in
+// day's location. A to clock time at or before from's gives an overnight window ending the
+// next day.
+func OnDay(day, from, to time.Time) Window {
+	start := time.Date(day.Year(), day.Month(), day.Day(), from.Hour(), from.Minute(), 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), to.Hour(), to.Minute(), 0, 0, day.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return Window{Start: start, End: end}
+}
+
+// Daily returns the window between the clock times of from and to (see OnDay) on every day
+// from the date of startTime until endTime, laid out in loc so that the windows follow
+// daylight saving time changes, and expressed in startTime's location. Windows are not
+// clipped to [startTime, endTime]; only those touching it are returned.
+func Daily(startTime, endTime, from, to time.Time, loc *time.Location) []Window {
+	windows := []Window{}
+	for day := startTime.In(loc); day.Before(endTime); day = day.AddDate(0, 0, 1) {
+		w := OnDay(day, from, to)
+		w.Start, w.End = w.Start.In(startTime.Location()), w.End.In(startTime.Location())
+		if !w.Start.After(endTime) && !w.End.Before(startTime) {
+			windows = append(windows, w)
+		}
+	}
+	return windows
+}
+
+// Weekdays returns the whole days within [startTime, endTime) that fall on one of the given
+// weekdays in loc, merged into one window per run of consecutive days (e.g., one per weekend)
+// and clipped to [startTime, endTime).
+func Weekdays(startTime, endTime time.Time, loc *time.Location, days ...time.Weekday) []Window {
+	windows := []Window{}
+
+	local := startTime.In(loc)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc); day.Before(endTime); day = day.AddDate(0, 0, 1) {
+		if slices.Contains(days, day.Weekday()) {
+			windows = append(windows, Window{Start: day, End: day.AddDate(0, 0, 1)})
+		}
+	}
+
+	return Clip(Merge(windows), Window{Start: startTime, End: endTime})
+}
+
+// Clip returns the windows cut to bounds, in their original order and without merging.
+// Windows with nothing inside bounds are dropped.
+func Clip(windows []Window, bounds Window) []Window {
+	clipped := []Window{}
+	for _, w := range windows {
+		if w.Start.Before(bounds.Start) {
+			w.Start = bounds.Start
+		}
+		if w.End.After(bounds.End) {
+			w.End = bounds.End
+		}
+		if !w.Empty() {
+			clipped = append(clipped, w)
+		}
+	}
+	return clipped
+}
+
+// Merge returns the periods covered by any of the windows (OR), sorted and with windows that
+// overlap or touch merged. Empty windows are dropped.
+func Merge(windows ...[]Window) []Window {
+	sorted := []Window{}
+	for _, ws := range windows {
+		for _, w := range ws {
+			if !w.Empty() {
+				sorted = append(sorted, w)
+			}
+		}
+	}
+	if len(sorted) == 0 {
+		return sorted
+	}
+	slices.SortFunc(sorted, func(a, b Window) int { return a.Start.Compare(b.Start) })
+
+	merged := []Window{sorted[0]}
+	for _, w := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !w.Start.After(last.End) {
+			if w.End.After(last.End) {
+				last.End = w.End
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+
+	return merged
+}
+
+// Intersect returns the periods covered by at least one window of every set (AND), sorted
+// and merged, e.g. the curfews that fall on a weekend. With no sets it returns no windows.
+func Intersect(sets ...[]Window) []Window {
+	if len(sets) == 0 {
+		return []Window{}
+	}
+
+	result := Merge(sets[0])
+	for _, set := range sets[1:] {
+		other := Merge(set)
+		intersection := []Window{}
+		for i, j := 0, 0; i < len(result) && j < len(other); {
+			w := Window{Start: maxTime(result[i].Start, other[j].Start), End: minTime(result[i].End, other[j].End)}
+			if !w.Empty() {
+				intersection = append(intersection, w)
+			}
+
+			// Advance whichever window ends first
+			if result[i].End.Before(other[j].End) {
+				i++
+			} else {
+				j++
+			}
+		}
+		result = intersection
+	}
+
+	return result
+}
+
+// Subtract returns the periods of windows not covered by any of the excluded windows
+// (AND NOT), sorted and merged, e.g. the weekdays outside a blackout period.
+func Subtract(windows, excluded []Window) []Window {
+	remaining := []Window{}
+	excluded = Merge(excluded)
+
+	for _, w := range Merge(windows) {
+		start := w.Start
+		for _, x := range excluded {
+			if !x.End.After(start) || !x.Start.Before(w.End) {
+				continue
+			}
+			if x.Start.After(start) {
+				remaining = append(remaining, Window{Start: start, End: x.Start})
+			}
+			start = x.End
+		}
+		if w.End.After(start) {
+			remaining = append(remaining, Window{Start: start, End: w.End})
+		}
+	}
+
+	return remaining
+}
+
+// SplitByDay splits the window at every midnight in loc, returning one piece per calendar
+// day it spans in chronological order. An empty window has no pieces.
+func SplitByDay(w Window, loc *time.Location) []Window {
+	pieces := []Window{}
+	for start := w.Start; start.Before(w.End); {
+		local := start.In(loc)
+		end := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+		if end.After(w.End) {
+			end = w.End
+		}
+		pieces = append(pieces, Window{Start: start, End: end})
+		start = end
+	}
+	return pieces
+}
+
+// minTime returns the earlier of two times.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of two times.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,175 @@
+package timewin
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+var base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// at returns the time the given number of hours after base.
+func at(hour int) time.Time {
+	return base.Add(time.Duration(hour) * time.Hour)
+}
+
+// windowSet is a random set of windows within the first 48 hours after base, some of them
+// empty, overlapping or touching.
+type windowSet []Window
+
+// Generate implements quick.Generator.
+func (windowSet) Generate(r *rand.Rand, size int) reflect.Value {
+	set := make(windowSet, r.Intn(6))
+	for i := range set {
+		start := r.Intn(48)
+		set[i] = Window{Start: at(start), End: at(start + r.Intn(12))}
+	}
+	return reflect.ValueOf(set)
+}
+
+// normalized reports whether windows are sorted, non-empty and neither overlap nor touch.
+func normalized(windows []Window) bool {
+	for i, w := range windows {
+		if w.Empty() || (i > 0 && !w.Start.After(windows[i-1].End)) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPoints reports whether in(t) == want(t) at every half hour of the window sets' range.
+func checkPoints(in func(time.Time) bool, want func(time.Time) bool) bool {
+	for t := at(-1); t.Before(at(62)); t = t.Add(30 * time.Minute) {
+		if in(t) != want(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMerge_Properties(t *testing.T) {
+	property := func(a, b windowSet) bool {
+		merged := Merge(a, b)
+		return normalized(merged) && checkPoints(
+			func(t time.Time) bool { return Contains(merged, t) },
+			func(t time.Time) bool { return Contains(a, t) || Contains(b, t) },
+		)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIntersect_Properties(t *testing.T) {
+	property := func(a, b, c windowSet) bool {
+		intersection := Intersect(a, b, c)
+		return normalized(intersection) && checkPoints(
+			func(t time.Time) bool { return Contains(intersection, t) },
+			func(t time.Time) bool { return Contains(a, t) && Contains(b, t) && Contains(c, t) },
+		)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubtract_Properties(t *testing.T) {
+	property := func(a, b windowSet) bool {
+		remaining := Subtract(a, b)
+		return normalized(remaining) && checkPoints(
+			func(t time.Time) bool { return Contains(remaining, t) },
+			func(t time.Time) bool { return Contains(a, t) && !Contains(b, t) },
+		)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSplitByDay_Properties(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	property := func(startMinutes, lengthMinutes uint16) bool {
+		w := Window{Start: base.Add(time.Duration(startMinutes) * time.Minute)}
+		w.End = w.Start.Add(time.Duration(lengthMinutes) * 5 * time.Minute)
+
+		pieces := SplitByDay(w, loc)
+		var total time.Duration
+		for i, piece := range pieces {
+			start, last := piece.Start.In(loc), piece.End.Add(-time.Nanosecond).In(loc)
+			if piece.Empty() || start.YearDay() != last.YearDay() || (i > 0 && !piece.Start.Equal(pieces[i-1].End)) {
+				return false
+			}
+			total += piece.Duration()
+		}
+		return total == w.Duration() && (len(pieces) == 0 || pieces[0].Start.Equal(w.Start) && pieces[len(pieces)-1].End.Equal(w.End))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWindow_ContainsAndOverlaps(t *testing.T) {
+	w := Window{Start: at(2), End: at(4)}
+	if !w.Contains(at(2)) || w.Contains(at(4)) {
+		t.Error("Expected the window to contain its start but not its end")
+	}
+	if !w.Overlaps(Window{Start: at(3), End: at(5)}) {
+		t.Error("Expected overlapping windows to overlap")
+	}
+	if w.Overlaps(Window{Start: at(4), End: at(5)}) {
+		t.Error("Expected touching windows not to overlap")
+	}
+}
+
+func TestDaily(t *testing.T) {
+	clock := func(hour int) time.Time { return time.Date(0, 1, 1, hour, 0, 0, 0, time.UTC) }
+
+	// Overnight windows end the next day; the last one starts before the end
+	overnight := Daily(base, at(48), clock(23), clock(6), time.UTC)
+	want := []Window{{Start: at(23), End: at(30)}, {Start: at(47), End: at(54)}}
+	if !slices.Equal(overnight, want) {
+		t.Errorf("Daily overnight = %v, expected %v", overnight, want)
+	}
+
+	// Equal clock times give windows a whole day long
+	if whole := Daily(base, at(24), clock(6), clock(6), time.UTC); len(whole) != 1 || whole[0].Duration() != 24*time.Hour {
+		t.Errorf("Expected one 24 hour window, got %v", whole)
+	}
+
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Local clock times follow the clocks going forward on 31 March 2024
+	start := time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)
+	windows := Daily(start, start.AddDate(0, 0, 2), clock(6), clock(7), loc)
+	if len(windows) != 2 || windows[0].Start.Hour() != 6 || windows[1].Start.Hour() != 5 {
+		t.Errorf("Expected 06:00 UTC then 05:00 UTC after the clocks change, got %v", windows)
+	}
+}
+
+func TestWeekdaysAndClip(t *testing.T) {
+	// 2024-01-05 is a Friday; the period starts at Saturday noon
+	start := time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)
+	weekend := Weekdays(start, start.AddDate(0, 0, 7), time.UTC, time.Saturday, time.Sunday)
+	want := []Window{
+		{Start: start, End: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), End: start.AddDate(0, 0, 7)},
+	}
+	if !slices.Equal(weekend, want) {
+		t.Errorf("Weekdays = %v, expected %v", weekend, want)
+	}
+
+	// Clip keeps touching windows apart and drops those outside the bounds
+	clipped := Clip([]Window{{Start: at(0), End: at(2)}, {Start: at(2), End: at(4)}, {Start: at(5), End: at(6)}}, Window{Start: at(1), End: at(5)})
+	if want := []Window{{Start: at(1), End: at(2)}, {Start: at(2), End: at(4)}}; !slices.Equal(clipped, want) {
+		t.Errorf("Clip = %v, expected %v", clipped, want)
+	}
+}
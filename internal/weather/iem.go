@@ -0,0 +1,168 @@
+package weather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// DefaultIEMBaseURL is the Iowa Environmental Mesonet ASOS/METAR archive download endpoint.
+const DefaultIEMBaseURL = "https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py"
+
+// iemTimeLayout is the timestamp format returned by the IEM archive.
+const iemTimeLayout = "2006-01-02 15:04"
+
+// IEMProvider is a WeatherProvider backed by the Iowa Environmental Mesonet public
+// METAR archive. Observations with missing wind fields are skipped and consecutive
+// observations with identical wind are collapsed into a single change.
+type IEMProvider struct {
+	BaseURL string       // Archive endpoint (defaults to DefaultIEMBaseURL)
+	Client  *http.Client // HTTP client (defaults to http.DefaultClient)
+}
+
+// NewIEMProvider creates a provider using the public IEM archive.
+func NewIEMProvider() *IEMProvider {
+	return &IEMProvider{
+		BaseURL: DefaultIEMBaseURL,
+		Client:  http.DefaultClient,
+	}
+}
+
+// GetWindSeries fetches METAR wind observations for [from, to) and converts them to wind changes.
+func (p *IEMProvider) GetWindSeries(ctx context.Context, icao string, from, to time.Time) ([]policy.WindChange, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.requestURL(icao, from, to), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather archive returned status %d", resp.StatusCode)
+	}
+
+	return parseIEMResponse(resp.Body, from, to)
+}
+
+// requestURL builds the archive query for a station and period.
+func (p *IEMProvider) requestURL(icao string, from, to time.Time) string {
+	base := p.BaseURL
+	if base == "" {
+		base = DefaultIEMBaseURL
+	}
+
+	from, to = from.UTC(), to.UTC()
+	q := url.Values{}
+	q.Set("station", iemStationID(icao))
+	q.Add("data", "drct")
+	q.Add("data", "sknt")
+	q.Add("data", "gust")
+	q.Set("year1", strconv.Itoa(from.Year()))
+	q.Set("month1", strconv.Itoa(int(from.Month())))
+	q.Set("day1", strconv.Itoa(from.Day()))
+	q.Set("year2", strconv.Itoa(to.Year()))
+	q.Set("month2", strconv.Itoa(int(to.Month())))
+	q.Set("day2", strconv.Itoa(to.Day()))
+	q.Set("tz", "Etc/UTC")
+	q.Set("format", "onlycomma")
+	q.Set("latlon", "no")
+	q.Set("missing", "M")
+	q.Set("report_type", "3") // Routine METARs only
+
+	return base + "?" + q.Encode()
+}
+
+// iemStationID converts an ICAO code to the identifier used by IEM.
+// Contiguous US stations are archived under their 3-letter FAA identifier (KJFK -> JFK).
+func iemStationID(icao string) string {
+	icao = strings.ToUpper(icao)
+	if len(icao) == 4 && icao[0] == 'K' {
+		return icao[1:]
+	}
+	return icao
+}
+
+// parseIEMResponse parses the "onlycomma" CSV format (station,valid,drct,sknt[,gust]).
+// Gusts are optional; missing gusts mean none were reported.
+func parseIEMResponse(r io.Reader, from, to time.Time) ([]policy.WindChange, error) {
+	schedule := []policy.WindChange{}
+	scanner := bufio.NewScanner(r)
+
+	header := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if header {
+			header = false
+			if strings.HasPrefix(line, "station,") {
+				continue
+			}
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("malformed weather record: %q", line)
+		}
+
+		timestamp, err := time.ParseInLocation(iemTimeLayout, fields[1], time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("malformed weather timestamp %q: %w", fields[1], err)
+		}
+		if timestamp.Before(from) || !timestamp.Before(to) {
+			continue
+		}
+
+		direction, dirErr := strconv.ParseFloat(fields[2], 64)
+		speed, spdErr := strconv.ParseFloat(fields[3], 64)
+		if dirErr != nil || spdErr != nil {
+			continue // Missing ("M") or variable wind
+		}
+
+		var gust float64
+		if len(fields) > 4 {
+			if g, err := strconv.ParseFloat(fields[4], 64); err == nil && g > speed {
+				gust = g
+			}
+		}
+
+		// Skip non-increasing timestamps and unchanged wind
+		if n := len(schedule); n > 0 {
+			last := schedule[n-1]
+			if !timestamp.After(last.Timestamp) || (last.SpeedKnots == speed && last.DirectionTrue == direction && last.GustKnots == gust) {
+				continue
+			}
+		}
+
+		schedule = append(schedule, policy.WindChange{
+			Timestamp:     timestamp,
+			SpeedKnots:    speed,
+			DirectionTrue: direction,
+			GustKnots:     gust,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
@@ -0,0 +1,80 @@
+// Package weather provides adapters that fetch historical or live weather data and convert it
+// into wind schedules usable by the simulation's ScheduledWindPolicy.
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harrydayexe/AirportCapacityCalculator/internal/simulation/policy"
+)
+
+// LiveReferencePrefix is the scheme used by scenario files to request live weather data
+// (e.g., "live:KJFK:2023").
+const LiveReferencePrefix = "live"
+
+// ErrInvalidReference indicates a weather reference string could not be parsed
+var ErrInvalidReference = errors.New("invalid weather reference")
+
+// WeatherProvider fetches observed wind conditions for an airport.
+type WeatherProvider interface {
+	// GetWindSeries returns wind observations for the airport identified by icao in the
+	// half-open interval [from, to), in chronological order.
+	GetWindSeries(ctx context.Context, icao string, from, to time.Time) ([]policy.WindChange, error)
+}
+
+// Reference identifies a weather series requested by a scenario file.
+type Reference struct {
+	ICAO string // Airport ICAO code (e.g., "KJFK")
+	Year int    // Calendar year of data to fetch (UTC)
+}
+
+// ParseReference parses a weather reference of the form "live:<ICAO>:<year>".
+func ParseReference(ref string) (Reference, error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) != 3 || parts[0] != LiveReferencePrefix {
+		return Reference{}, fmt.Errorf("%w: %q (expected %s:<ICAO>:<year>)", ErrInvalidReference, ref, LiveReferencePrefix)
+	}
+
+	icao := strings.ToUpper(strings.TrimSpace(parts[1]))
+	if len(icao) != 4 {
+		return Reference{}, fmt.Errorf("%w: ICAO code must be 4 characters, got %q", ErrInvalidReference, parts[1])
+	}
+
+	year, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Reference{}, fmt.Errorf("%w: invalid year %q", ErrInvalidReference, parts[2])
+	}
+
+	return Reference{ICAO: icao, Year: year}, nil
+}
+
+// Period returns the UTC time range covered by the reference.
+func (r Reference) Period() (from, to time.Time) {
+	from = time.Date(r.Year, 1, 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(1, 0, 0)
+}
+
+// LoadWindSchedule resolves a weather reference string using the provider and returns a
+// chronological wind schedule ready for ScheduledWindPolicy.
+func LoadWindSchedule(ctx context.Context, provider WeatherProvider, ref string) ([]policy.WindChange, error) {
+	parsed, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := parsed.Period()
+	schedule, err := provider.GetWindSeries(ctx, parsed.ICAO, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("fetching weather for %s: %w", ref, err)
+	}
+	if len(schedule) == 0 {
+		return nil, fmt.Errorf("fetching weather for %s: %w", ref, policy.ErrEmptyWindSchedule)
+	}
+
+	return schedule, nil
+}
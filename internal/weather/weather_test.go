@@ -0,0 +1,138 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         string
+		expected    Reference
+		expectError bool
+	}{
+		{"valid", "live:KJFK:2023", Reference{ICAO: "KJFK", Year: 2023}, false},
+		{"lowercase icao", "live:egll:2022", Reference{ICAO: "EGLL", Year: 2022}, false},
+		{"wrong prefix", "file:KJFK:2023", Reference{}, true},
+		{"missing year", "live:KJFK", Reference{}, true},
+		{"bad year", "live:KJFK:twenty", Reference{}, true},
+		{"bad icao", "live:JFK:2023", Reference{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReference(tt.ref)
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidReference) {
+					t.Errorf("Expected ErrInvalidReference, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+const sampleIEMResponse = `station,valid,drct,sknt
+JFK,2023-01-01 00:51,270.00,10.00
+JFK,2023-01-01 01:51,270.00,10.00
+JFK,2023-01-01 02:51,M,M
+JFK,2023-01-01 03:51,280.00,14.00
+JFK,2024-01-01 00:51,290.00,8.00
+`
+
+func TestIEMProvider_GetWindSeries(t *testing.T) {
+	var gotStation string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotStation = r.URL.Query().Get("station")
+		fmt.Fprint(w, sampleIEMResponse)
+	}))
+	defer server.Close()
+
+	provider := &IEMProvider{BaseURL: server.URL, Client: server.Client()}
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	schedule, err := provider.GetWindSeries(context.Background(), "KJFK", from, to)
+	if err != nil {
+		t.Fatalf("GetWindSeries failed: %v", err)
+	}
+
+	if gotStation != "JFK" {
+		t.Errorf("Expected station JFK, got %q", gotStation)
+	}
+
+	// Duplicate, missing and out-of-range observations are dropped
+	if len(schedule) != 2 {
+		t.Fatalf("Expected 2 wind changes, got %d", len(schedule))
+	}
+	if schedule[1].SpeedKnots != 14 || schedule[1].DirectionTrue != 280 {
+		t.Errorf("Unexpected second wind change: %+v", schedule[1])
+	}
+}
+
+func TestParseIEMResponse_Gusts(t *testing.T) {
+	response := `station,valid,drct,sknt,gust
+JFK,2023-01-01 00:51,270.00,18.00,M
+JFK,2023-01-01 01:51,270.00,18.00,31.00
+JFK,2023-01-01 02:51,270.00,18.00,12.00
+`
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := parseIEMResponse(strings.NewReader(response), from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("parseIEMResponse failed: %v", err)
+	}
+
+	// A gust onset is a change; a gust below the wind speed is dropped
+	if len(schedule) != 3 {
+		t.Fatalf("Expected 3 wind changes, got %d", len(schedule))
+	}
+	if schedule[0].GustKnots != 0 || schedule[1].GustKnots != 31 || schedule[2].GustKnots != 0 {
+		t.Errorf("Unexpected gusts: %+v", schedule)
+	}
+}
+
+func TestIEMProvider_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := &IEMProvider{BaseURL: server.URL, Client: server.Client()}
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := provider.GetWindSeries(context.Background(), "EGLL", from, from.AddDate(0, 0, 1)); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
+
+func TestLoadWindSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, sampleIEMResponse)
+	}))
+	defer server.Close()
+
+	provider := &IEMProvider{BaseURL: server.URL, Client: server.Client()}
+	schedule, err := LoadWindSchedule(context.Background(), provider, "live:KJFK:2023")
+	if err != nil {
+		t.Fatalf("LoadWindSchedule failed: %v", err)
+	}
+	if len(schedule) != 2 {
+		t.Errorf("Expected 2 wind changes, got %d", len(schedule))
+	}
+
+	if _, err := LoadWindSchedule(context.Background(), provider, "live:KJFK:2019"); err == nil {
+		t.Error("Expected error when no observations fall in the period")
+	}
+}